@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/config"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/spf13/cobra"
+)
+
+func TestActorResolution(t *testing.T) {
+	t.Setenv("DOCKET_ACTOR", "env-agent")
+	for _, tt := range []struct {
+		flag, want string
+	}{
+		{"", "env-agent"},
+		{"flag-agent", "flag-agent"},
+	} {
+		cfg, err := config.Resolve(config.Options{Actor: tt.flag})
+		if err != nil {
+			t.Fatalf("Resolve: %v", err)
+		}
+		if cfg.Actor != tt.want {
+			t.Errorf("actor with --actor %q = %q, want %q", tt.flag, cfg.Actor, tt.want)
+		}
+	}
+}
+
+// TestActorRecordedOnEveryChange runs the mutating issue commands with
+// DOCKET_ACTOR set and checks that every activity entry names the actor.
+func TestActorRecordedOnEveryChange(t *testing.T) {
+	t.Setenv("DOCKET_ACTOR", "agent-7")
+	cfg, err := config.Resolve(config.Options{})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	config.SetActor(cfg.Actor)
+	t.Cleanup(func() { config.SetActor("") })
+
+	// Commands without a run function write their output to os.Stdout.
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("opening %s: %v", os.DevNull, err)
+	}
+	stdout := os.Stdout
+	os.Stdout = devNull
+	t.Cleanup(func() {
+		os.Stdout = stdout
+		devNull.Close()
+	})
+
+	conn := newTestDB(t)
+	a := runCreate(t, conn, map[string]string{"title": "A", "label": "bug", "file": "main.go"}).ID
+	b := runCreate(t, conn, map[string]string{"title": "B"}).ID
+	idA, idB := model.FormatID(a), model.FormatID(b)
+
+	// run calls c's RunE with string flags set to the given values.
+	run := func(c *cobra.Command, flags map[string]string, args ...string) {
+		t.Helper()
+		cmd := cmdWithDB(conn)
+		cmd.Flags().Set("json", "true")
+		for name, value := range flags {
+			cmd.Flags().String(name, "", "")
+			if err := cmd.Flags().Set(name, value); err != nil {
+				t.Fatalf("set %s: %v", name, err)
+			}
+		}
+		if err := c.RunE(cmd, args); err != nil {
+			t.Fatalf("%s %v: %v", c.CommandPath(), args, err)
+		}
+	}
+
+	run(commentAddCmd, map[string]string{"message": "looking into it"}, idA)
+	run(labelAddCmd, nil, idA, "ui")
+	run(labelRmCmd, nil, idA, "bug")
+	run(fileAddCmd, nil, idA, "README.md")
+	run(fileRemoveCmd, nil, idA, "main.go")
+	run(moveCmd, nil, idA, "in-progress")
+	w, _ := bufWriter(true)
+	if err := runLinkAdd(linkAddCmdWithDB(conn), []string{idA, "blocks", idB}, w); err != nil {
+		t.Fatalf("runLinkAdd: %v", err)
+	}
+	run(linkRemoveCmd, nil, idA, "blocks", idB)
+
+	rows, err := conn.Query(`SELECT field_changed, COALESCE(changed_by, '') FROM activity_log ORDER BY id`)
+	if err != nil {
+		t.Fatalf("querying activity: %v", err)
+	}
+	defer rows.Close()
+	seen := make(map[string]bool)
+	for rows.Next() {
+		var field, by string
+		if err := rows.Scan(&field, &by); err != nil {
+			t.Fatalf("scanning activity: %v", err)
+		}
+		seen[field] = true
+		if by != "agent-7" {
+			t.Errorf("%s activity changed by %q, want agent-7", field, by)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("iterating activity: %v", err)
+	}
+	for _, field := range []string{"created", "files", "comment_added", "label_added", "label_removed", "status", "relation_added", "relation_removed"} {
+		if !seen[field] {
+			t.Errorf("no %s activity recorded", field)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package cli
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/config"
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// maxPatchSize caps the size of a patch file read by apply.
+const maxPatchSize = 10 << 20 // 10 MiB
+
+// patchOpJSON is the wire format of one operation in a patch file. IDs may
+// be numbers (12) or issue IDs ("DKT-12").
+type patchOpJSON struct {
+	Op     string                     `json:"op"`
+	ID     json.RawMessage            `json:"id"`
+	Fields map[string]json.RawMessage `json:"fields"`
+	Body   string                     `json:"body"`
+	Labels []string                   `json:"labels"`
+	Source json.RawMessage            `json:"source"`
+	Target json.RawMessage            `json:"target"`
+	Type   string                     `json:"type"`
+}
+
+// applyResult is the JSON wire format for the apply command output.
+type applyResult struct {
+	DryRun  bool            `json:"dry_run"`
+	Applied int             `json:"applied"`
+	Results []applyOpResult `json:"results"`
+}
+
+// applyOpResult is the outcome of one operation in a patch.
+type applyOpResult struct {
+	Index      int    `json:"index"`
+	Op         string `json:"op"`
+	ID         string `json:"id"`
+	CommentID  int    `json:"comment_id,omitempty"`
+	RelationID int    `json:"relation_id,omitempty"`
+}
+
+// applyFailure is the data attached to the JSON error envelope when a patch
+// is rejected.
+type applyFailure struct {
+	FailedIndex int    `json:"failed_index"`
+	Op          string `json:"op"`
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply <patch.json>",
+	Short: "Apply a batch of issue changes from a JSON patch file",
+	Long: `Apply a batch of issue changes from a JSON patch file atomically.
+
+The patch is a JSON array of operations, read from a file or from stdin
+when the path is "-". Issue IDs may be numbers (12) or IDs ("DKT-12").
+
+  {"op": "update", "id": 12, "fields": {"status": "done", "priority": "high"}}
+      fields: title, description, status, priority, kind, assignee,
+      parent_id (an issue ID or null), milestone_id (a milestone ID or
+      name, or null)
+  {"op": "comment", "id": 7, "body": "Fixed in the last release"}
+  {"op": "label-add", "id": 3, "labels": ["bug", "ui"]}
+  {"op": "relate", "source": 3, "target": 9, "type": "blocks"}
+      type: blocks, depends_on, relates_to, duplicates, supersedes
+
+Every operation is validated before any is applied: issue IDs must exist,
+enum values must be valid, and relations must not be duplicates or create
+cycles. The operations then run in order in a single transaction. If any
+fails, nothing is changed and the error names the failing operation's
+zero-based index. --dry-run runs the whole patch and rolls it back.
+
+  docket apply changes.json --dry-run
+  docket apply changes.json --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runApply(cmd, args, getWriter(cmd))
+	},
+}
+
+func runApply(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	data, err := readPatchFile(args[0], cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+	ops, err := parsePatch(conn, data)
+	if err != nil {
+		return applyErr(err)
+	}
+
+	results, err := db.ApplyPatch(conn, ops, db.ApplyOptions{DryRun: dryRun, ChangedBy: config.DefaultAuthor()})
+	if err != nil {
+		return applyErr(err)
+	}
+
+	result := applyResult{DryRun: dryRun, Applied: len(results), Results: make([]applyOpResult, len(results))}
+	for i, r := range results {
+		result.Results[i] = applyOpResult{
+			Index:      i,
+			Op:         r.Op,
+			ID:         model.FormatID(r.IssueID),
+			CommentID:  r.CommentID,
+			RelationID: r.RelationID,
+		}
+	}
+	if w.JSONMode {
+		w.Success(result, "")
+		return nil
+	}
+	w.Success(result, applySummary(result))
+	return nil
+}
+
+// readPatchFile reads a patch from path, or from stdin when path is "-".
+func readPatchFile(path string, stdin io.Reader) ([]byte, error) {
+	r := stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, cmdErr(fmt.Errorf("opening patch file: %w", err), output.ErrValidation)
+		}
+		defer f.Close()
+		r = f
+	}
+	data, err := io.ReadAll(&io.LimitedReader{R: r, N: maxPatchSize + 1})
+	if err != nil {
+		return nil, cmdErr(fmt.Errorf("reading patch file: %w", err), output.ErrGeneral)
+	}
+	if len(data) > maxPatchSize {
+		return nil, cmdErr(fmt.Errorf("patch file exceeds %d bytes", maxPatchSize), output.ErrValidation)
+	}
+	return data, nil
+}
+
+// parsePatch decodes a patch file into operations. Problems with a single
+// operation are reported as a *db.PatchError naming its index.
+func parsePatch(conn *sql.DB, data []byte) ([]db.PatchOp, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w: patch must be a JSON array of operations: %v", db.ErrValidation, err)
+	}
+
+	ops := make([]db.PatchOp, len(raw))
+	for i, r := range raw {
+		var j patchOpJSON
+		dec := json.NewDecoder(bytes.NewReader(r))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&j); err != nil {
+			return nil, &db.PatchError{Index: i, Op: j.Op, Err: fmt.Errorf("%w: %v", db.ErrValidation, err)}
+		}
+		op, err := patchOpFromJSON(conn, j)
+		if err != nil {
+			return nil, &db.PatchError{Index: i, Op: j.Op, Err: err}
+		}
+		ops[i] = op
+	}
+	return ops, nil
+}
+
+// patchOpFromJSON converts the wire format of one operation to a db.PatchOp,
+// resolving IDs and milestone names. Values are checked further by
+// db.ApplyPatch.
+func patchOpFromJSON(conn *sql.DB, j patchOpJSON) (db.PatchOp, error) {
+	op := db.PatchOp{Op: j.Op, Body: j.Body, Labels: j.Labels}
+	if !slices.Contains(db.PatchOps, j.Op) {
+		return op, fmt.Errorf("%w: unknown op %q (must be one of %s)", db.ErrValidation, j.Op, strings.Join(db.PatchOps, ", "))
+	}
+
+	idField, idRaw := "id", j.ID
+	if j.Op == db.PatchOpRelate {
+		idField, idRaw = "source", j.Source
+	}
+	id, err := parsePatchID(idField, idRaw)
+	if err != nil {
+		return op, err
+	}
+	op.IssueID = id
+
+	if j.Op == db.PatchOpRelate {
+		if op.TargetID, err = parsePatchID("target", j.Target); err != nil {
+			return op, err
+		}
+		rt, err := model.ParseRelationType(j.Type)
+		if err != nil {
+			return op, fmt.Errorf("%w: %v", db.ErrValidation, err)
+		}
+		op.RelationType = rt
+	}
+
+	if j.Fields != nil {
+		op.Fields = make(map[string]interface{}, len(j.Fields))
+		for _, name := range slices.Sorted(maps.Keys(j.Fields)) {
+			value, err := patchFieldValue(conn, name, j.Fields[name])
+			if err != nil {
+				return op, err
+			}
+			op.Fields[name] = value
+		}
+	}
+	return op, nil
+}
+
+// parsePatchID parses an issue ID given as a number or a string.
+func parsePatchID(field string, raw json.RawMessage) (int, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return 0, fmt.Errorf("%w: %s is required", db.ErrValidation, field)
+	}
+	var n int
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return 0, fmt.Errorf("%w: %s must be an issue ID", db.ErrValidation, field)
+	}
+	id, err := model.ParseID(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %v", db.ErrValidation, field, err)
+	}
+	return id, nil
+}
+
+// patchFieldValue converts the JSON value of an update field to the form
+// db.UpdateIssue expects: IDs for parent_id and milestone_id (or nil to
+// clear them) and strings for everything else.
+func patchFieldValue(conn *sql.DB, name string, raw json.RawMessage) (interface{}, error) {
+	switch name {
+	case "parent_id", "milestone_id":
+		if string(raw) == "null" {
+			return nil, nil
+		}
+	}
+	switch name {
+	case "parent_id":
+		return parsePatchID("parent_id", raw)
+	case "milestone_id":
+		var n int
+		if err := json.Unmarshal(raw, &n); err == nil {
+			return n, nil
+		}
+		var milestone string
+		if err := json.Unmarshal(raw, &milestone); err != nil {
+			return nil, fmt.Errorf("%w: milestone_id must be a milestone ID or name", db.ErrValidation)
+		}
+		m, err := db.GetMilestoneByName(conn, milestone)
+		if err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				return nil, fmt.Errorf("milestone %q %w", milestone, db.ErrNotFound)
+			}
+			return nil, fmt.Errorf("fetching milestone: %w", err)
+		}
+		return m.ID, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, fmt.Errorf("%w: %s must be a string", db.ErrValidation, name)
+	}
+	return s, nil
+}
+
+// applyErr converts an error from parsing or applying a patch to a CmdError,
+// attaching the failing operation's index when there is one.
+func applyErr(err error) *CmdError {
+	code := output.ErrGeneral
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		code = output.ErrNotFound
+	case errors.Is(err, db.ErrValidation), errors.Is(err, db.ErrSelfRelation):
+		code = output.ErrValidation
+	case errors.Is(err, db.ErrDuplicateRelation), errors.Is(err, db.ErrCycleDetected):
+		code = output.ErrConflict
+	}
+	ce := &CmdError{Err: fmt.Errorf("applying patch: %w", err), Code: code}
+	var pe *db.PatchError
+	if errors.As(err, &pe) {
+		ce.Err = fmt.Errorf("patch rejected, nothing was changed: %w", err)
+		ce.Data = applyFailure{FailedIndex: pe.Index, Op: pe.Op}
+	}
+	return ce
+}
+
+// applySummary describes an applied patch for human output, one line per
+// operation.
+func applySummary(r applyResult) string {
+	var b strings.Builder
+	if r.DryRun {
+		fmt.Fprintf(&b, "Dry run: %d operation(s) would apply; nothing was changed", r.Applied)
+	} else {
+		fmt.Fprintf(&b, "Applied %d operation(s)", r.Applied)
+	}
+	for _, op := range r.Results {
+		fmt.Fprintf(&b, "\n  %d  %-9s %s", op.Index, op.Op, op.ID)
+		switch {
+		case op.CommentID != 0:
+			fmt.Fprintf(&b, " (comment #%d)", op.CommentID)
+		case op.RelationID != 0:
+			fmt.Fprintf(&b, " (relation #%d)", op.RelationID)
+		}
+	}
+	return b.String()
+}
+
+func init() {
+	applyCmd.Flags().Bool("dry-run", false, "Validate and run the patch, then roll it back")
+	rootCmd.AddCommand(applyCmd)
+}
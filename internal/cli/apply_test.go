@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func applyCmdWithDB(conn *sql.DB, dryRun bool) *cobra.Command {
+	cmd := cmdWithDB(conn)
+	cmd.Flags().Bool("dry-run", false, "")
+	if dryRun {
+		cmd.Flags().Set("dry-run", "true")
+	}
+	return cmd
+}
+
+func writePatch(t *testing.T, patch string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "patch.json")
+	if err := os.WriteFile(path, []byte(patch), 0o644); err != nil {
+		t.Fatalf("writing patch: %v", err)
+	}
+	return path
+}
+
+func TestApplyJSON(t *testing.T) {
+	conn := newTestDB(t)
+	a := createIssue(t, conn, "a", model.StatusTodo, model.PriorityLow)
+	b := createIssue(t, conn, "b", model.StatusTodo, model.PriorityLow)
+
+	path := writePatch(t, `[
+		{"op": "update", "id": "DKT-1", "fields": {"priority": "high", "parent_id": null}},
+		{"op": "comment", "id": 2, "body": "blocked on DKT-1"},
+		{"op": "label-add", "id": "dkt1", "labels": ["bug"]},
+		{"op": "relate", "source": 1, "target": "DKT-2", "type": "depends-on"}
+	]`)
+
+	w, buf := bufWriter(true)
+	if err := runApply(applyCmdWithDB(conn, false), []string{path}, w); err != nil {
+		t.Fatalf("runApply: %v", err)
+	}
+	var env struct {
+		Data applyResult `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	got := env.Data
+	if got.DryRun || got.Applied != 4 || got.Results[1].ID != model.FormatID(b) || got.Results[1].CommentID == 0 || got.Results[3].RelationID == 0 {
+		t.Errorf("result = %+v", got)
+	}
+
+	issue, err := db.GetIssue(conn, a)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if issue.Priority != model.PriorityHigh {
+		t.Errorf("priority = %s, want high", issue.Priority)
+	}
+}
+
+func TestApplyReportsFailingIndex(t *testing.T) {
+	conn := newTestDB(t)
+	a := createIssue(t, conn, "a", model.StatusTodo, model.PriorityLow)
+
+	tests := []struct {
+		name  string
+		patch string
+		index int
+		code  output.ErrorCode
+	}{
+		{"missing issue", `[{"op": "comment", "id": 1, "body": "x"}, {"op": "comment", "id": 42, "body": "y"}]`, 1, output.ErrNotFound},
+		{"bad relation type", `[{"op": "relate", "source": 1, "target": 1, "type": "parent_of"}]`, 0, output.ErrValidation},
+		{"unknown key", `[{"op": "comment", "id": 1, "body": "x", "bogus": true}]`, 0, output.ErrValidation},
+		{"missing id", `[{"op": "label-add", "labels": ["x"]}]`, 0, output.ErrValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, _ := bufWriter(true)
+			err := runApply(applyCmdWithDB(conn, false), []string{writePatch(t, tt.patch)}, w)
+			var ce *CmdError
+			if !errors.As(err, &ce) || ce.Code != tt.code {
+				t.Fatalf("error = %v, want %s", err, tt.code)
+			}
+			if f, ok := ce.Data.(applyFailure); !ok || f.FailedIndex != tt.index {
+				t.Errorf("data = %+v, want failed_index %d", ce.Data, tt.index)
+			}
+		})
+	}
+
+	if comments, _ := db.ListIssueComments(conn, a); len(comments) != 0 {
+		t.Errorf("rejected patches left %d comment(s)", len(comments))
+	}
+}
+
+func TestApplyDryRun(t *testing.T) {
+	conn := newTestDB(t)
+	a := createIssue(t, conn, "a", model.StatusTodo, model.PriorityLow)
+
+	w, buf := bufWriter(false)
+	path := writePatch(t, `[{"op": "update", "id": 1, "fields": {"title": "renamed"}}]`)
+	if err := runApply(applyCmdWithDB(conn, true), []string{path}, w); err != nil {
+		t.Fatalf("runApply: %v", err)
+	}
+	if want := "Dry run: 1 operation(s) would apply; nothing was changed\n  0  update    DKT-1\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+	if issue, _ := db.GetIssue(conn, a); issue.Title != "a" {
+		t.Errorf("dry run renamed the issue to %q", issue.Title)
+	}
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+// addMineFlag registers --mine on a command that also has --assignee, as
+// shorthand for --assignee me.
+func addMineFlag(cmd *cobra.Command, usage string) {
+	cmd.Flags().Bool("mine", false, usage)
+	cmd.MarkFlagsMutuallyExclusive("assignee", "mine")
+}
+
+// assigneeFromFlags returns the --assignee value with the "me" alias, or
+// --mine, resolved to the configured current user. ok reports whether either
+// flag was given.
+func assigneeFromFlags(cmd *cobra.Command, conn *sql.DB) (assignee string, ok bool, err error) {
+	assignee, ok = assigneeFlag(cmd)
+	assignee, err = resolveAssignee(cmd, conn, assignee)
+	if err != nil {
+		return "", false, err
+	}
+	return assignee, ok, nil
+}
+
+// assigneeFlag returns the unresolved --assignee value, or "me" for --mine,
+// and whether either flag was given.
+func assigneeFlag(cmd *cobra.Command) (string, bool) {
+	if mine, _ := cmd.Flags().GetBool("mine"); mine {
+		return db.MeAssignee, true
+	}
+	assignee, _ := cmd.Flags().GetString("assignee")
+	return assignee, cmd.Flags().Changed("assignee")
+}
+
+// resolveAssignee resolves the "me" alias to the current user: the one set
+// by the project config file, or else the one stored in the database.
+func resolveAssignee(cmd *cobra.Command, conn *sql.DB, assignee string) (string, error) {
+	if user := projectUser(cmd); user != "" && strings.EqualFold(strings.TrimSpace(assignee), db.MeAssignee) {
+		return user, nil
+	}
+	resolved, err := db.ResolveAssignee(conn, assignee)
+	if err != nil {
+		if errors.Is(err, db.ErrNoCurrentUser) {
+			return "", cmdErr(fmt.Errorf("%q needs a current user: set one with 'docket config user <name>'", db.MeAssignee), output.ErrValidation)
+		}
+		return "", cmdErr(fmt.Errorf("resolving assignee: %w", err), output.ErrGeneral)
+	}
+	return resolved, nil
+}
+
+// projectUser returns the current user set by the project config file, or
+// "" when there is none.
+func projectUser(cmd *cobra.Command) string {
+	if cfg := getCfg(cmd); cfg != nil {
+		return cfg.User
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+func TestAssigneeMeResolvesOnCreateAndList(t *testing.T) {
+	conn := newTestDB(t)
+	if err := db.SetCurrentUser(conn, "jane"); err != nil {
+		t.Fatalf("SetCurrentUser: %v", err)
+	}
+
+	mine := runCreate(t, conn, map[string]string{"title": "mine", "assignee": "me"})
+	if mine.Assignee != "jane" {
+		t.Errorf("created assignee = %q, want jane", mine.Assignee)
+	}
+	other := createIssue(t, conn, "other", model.StatusTodo, model.PriorityLow)
+	if err := db.UpdateIssue(conn, other, map[string]interface{}{"assignee": "bob"}, "tester"); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+
+	cmd := listCmdWithDB(conn)
+	cmd.Flags().Set("assignee", "ME")
+	w, buf := bufWriter(true)
+	if err := runIssueList(cmd, nil, w); err != nil {
+		t.Fatalf("runIssueList: %v", err)
+	}
+	var lj listJSON
+	if err := json.Unmarshal(buf.Bytes(), &lj); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if len(lj.Data.Issues) != 1 || lj.Data.Issues[0].ID != model.FormatID(mine.ID) {
+		t.Errorf("list --assignee me = %+v, want only %s", lj.Data.Issues, model.FormatID(mine.ID))
+	}
+
+	cmd = listCmdWithDB(conn)
+	cmd.Flags().Bool("mine", false, "")
+	cmd.Flags().Set("mine", "true")
+	w, buf = bufWriter(true)
+	if err := runIssueList(cmd, nil, w); err != nil {
+		t.Fatalf("runIssueList --mine: %v", err)
+	}
+	lj = listJSON{}
+	if err := json.Unmarshal(buf.Bytes(), &lj); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if len(lj.Data.Issues) != 1 || lj.Data.Issues[0].ID != model.FormatID(mine.ID) {
+		t.Errorf("list --mine = %+v, want only %s", lj.Data.Issues, model.FormatID(mine.ID))
+	}
+}
+
+func TestAssigneeMeWithoutCurrentUser(t *testing.T) {
+	conn := newTestDB(t)
+
+	cmd := createCmdWithDB(conn)
+	cmd.Flags().Set("json", "true")
+	cmd.Flags().Set("title", "mine")
+	cmd.Flags().Set("assignee", "me")
+	w, _ := bufWriter(true)
+	err := runIssueCreate(cmd, nil, w)
+
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Fatalf("runIssueCreate error = %v, want a validation error", err)
+	}
+	if n, _ := db.CountIssues(conn); n != 0 {
+		t.Errorf("created %d issues, want 0", n)
+	}
+
+	cmd = listCmdWithDB(conn)
+	cmd.Flags().Set("assignee", "me")
+	w, _ = bufWriter(true)
+	if err := runIssueList(cmd, nil, w); !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Errorf("runIssueList error = %v, want a validation error", err)
+	}
+}
@@ -2,9 +2,11 @@ package cli
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"golang.org/x/term"
@@ -24,9 +26,20 @@ type boardColumn struct {
 	Issues []*model.Issue `json:"issues"`
 }
 
-// boardResult is the JSON output structure for the board command.
+// boardProgress is the sub-issue completion of one parent card in the board
+// JSON output.
+type boardProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// boardResult is the JSON output structure for the board command. Columns
+// appear in render.StatusOrder and only for statuses with matching issues;
+// Progress is keyed by the formatted ID (e.g. "DKT-3") of each shown parent
+// card, matching the "id" of the card itself.
 type boardResult struct {
-	Columns []boardColumn `json:"columns"`
+	Columns  []boardColumn            `json:"columns"`
+	Progress map[string]boardProgress `json:"progress"`
 }
 
 var boardCmd = &cobra.Command{
@@ -60,8 +73,22 @@ func runBoard(cmd *cobra.Command, args []string, w *output.Writer) error {
 
 	labels, _ := cmd.Flags().GetStringSlice("label")
 	priorities, _ := cmd.Flags().GetStringSlice("priority")
-	assignee, _ := cmd.Flags().GetString("assignee")
 	expand, _ := cmd.Flags().GetBool("expand")
+	showAssignee, _ := cmd.Flags().GetBool("show-assignee")
+	showAge, _ := cmd.Flags().GetBool("show-age")
+	sortCards, _ := cmd.Flags().GetString("sort-cards")
+	includeSnoozed, _ := cmd.Flags().GetBool("include-snoozed")
+	legend, _ := cmd.Flags().GetBool("legend")
+
+	layout, err := getLayout(cmd)
+	if err != nil {
+		return err
+	}
+
+	assignee, _, err := assigneeFromFlags(cmd, conn)
+	if err != nil {
+		return err
+	}
 
 	// Validate filter enum values.
 	for _, p := range priorities {
@@ -70,60 +97,50 @@ func runBoard(cmd *cobra.Command, args []string, w *output.Writer) error {
 		}
 	}
 
-	opts := db.ListOptions{
-		Priorities:  priorities,
-		Labels:      labels,
-		Assignee:    assignee,
-		IncludeDone: true,
+	sortKeys, err := boardSortKeys(sortCards)
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
 	}
 
-	issues, _, err := db.ListIssues(conn, opts)
-	if err != nil {
-		return cmdErr(fmt.Errorf("listing issues: %w", err), output.ErrGeneral)
+	// Cards are fetched one page per column so memory stays proportional to
+	// what is shown. JSON output keeps every card unless --limit is given.
+	limit, _ := cmd.Flags().GetInt("limit")
+	offset, _ := cmd.Flags().GetInt("offset")
+	if limit < 0 || offset < 0 {
+		return cmdErr(fmt.Errorf("--limit and --offset must not be negative"), output.ErrValidation)
+	}
+	if w.JSONMode && !cmd.Flags().Changed("limit") {
+		limit = 0
 	}
 
 	// By default, roll up sub-issues into their parent (exclude issues that
 	// have a parent). When --expand is set, show all issues individually.
-	if !expand {
-		var roots []*model.Issue
-		for _, issue := range issues {
-			if issue.ParentID == nil {
-				roots = append(roots, issue)
-			}
-		}
-		issues = roots
+	board, err := db.ListBoardIssues(conn, db.BoardQueryOptions{
+		Priorities:     priorities,
+		Labels:         labels,
+		Assignee:       assignee,
+		RootsOnly:      !expand,
+		IncludeSnoozed: includeSnoozed,
+		SortKeys:       sortKeys,
+		Limit:          limit,
+		Offset:         offset,
+	})
+	if err != nil {
+		return cmdErr(fmt.Errorf("listing issues: %w", err), output.ErrGeneral)
 	}
 
-	if w.JSONMode {
-		// Group issues by status for structured output.
-		groups := make(map[model.Status][]*model.Issue)
-		for _, issue := range issues {
-			groups[issue.Status] = append(groups[issue.Status], issue)
-		}
-
-		var columns []boardColumn
-		for _, status := range render.StatusOrder {
-			col := groups[status]
-			if col == nil {
-				col = []*model.Issue{}
-			}
-			columns = append(columns, boardColumn{
-				Status: string(status),
-				Count:  len(col),
-				Issues: col,
-			})
-		}
-
-		w.Success(boardResult{Columns: columns}, "")
-		return nil
+	var issues []*model.Issue
+	for _, status := range render.StatusOrder {
+		issues = append(issues, board.Columns[status]...)
 	}
 
-	// Build sub-issue progress map for parent issues in a single query.
+	// Build sub-issue progress map for the shown parent issues in a single
+	// query.
 	parentIDs := make([]int, len(issues))
 	for i, issue := range issues {
 		parentIDs[i] = issue.ID
 	}
-	batchProgress, err := db.GetBatchSubIssueProgress(conn, parentIDs)
+	batchProgress, err := db.GetCachedSubIssueProgress(conn, parentIDs)
 	if err != nil {
 		return cmdErr(fmt.Errorf("fetching sub-issue progress: %w", err), output.ErrGeneral)
 	}
@@ -134,20 +151,137 @@ func runBoard(cmd *cobra.Command, args []string, w *output.Writer) error {
 		}
 	}
 
+	if w.JSONMode {
+		w.Success(boardJSON(board, progress), "")
+		return nil
+	}
+
+	if len(issues) == 0 {
+		message, err := emptyIssuesMessage(cmd, conn, "No issues on the board.", "",
+			"label", "priority", "assignee", "mine", "offset")
+		if err != nil {
+			return err
+		}
+		w.Success(nil, message)
+		return nil
+	}
+
+	perColumn := limit
+	if perColumn == 0 {
+		perColumn = -1
+	}
 	boardOpts := render.BoardOptions{
-		Expand:   expand,
-		Progress: progress,
+		Expand:       expand,
+		Progress:     progress,
+		ShowAssignee: showAssignee,
+		ShowAge:      showAge,
+		Layout:       layout,
+		PerColumn:    perColumn,
+		Offset:       offset,
+		Totals:       board.Totals,
+		StatusColors: layout.StatusColors,
 	}
 	message := render.RenderBoard(issues, boardOpts)
+	if legend {
+		key, err := boardLegend(conn, issues)
+		if err != nil {
+			return err
+		}
+		if key != "" {
+			message = strings.TrimRight(message, "\n") + "\n\n" + key
+		}
+	}
 	w.Success(nil, message)
 
 	return nil
 }
 
+// boardJSON builds the JSON board: one column per status that has matching
+// issues, in render.StatusOrder, and the progress of each shown parent card.
+// A column's count is its full size even when --limit or --offset trims the
+// issues it lists.
+func boardJSON(board db.BoardIssues, progress map[int]render.SubIssueProgress) boardResult {
+	columns := []boardColumn{}
+	for _, status := range render.StatusOrder {
+		if board.Totals[status] == 0 {
+			continue
+		}
+		col := board.Columns[status]
+		if col == nil {
+			col = []*model.Issue{}
+		}
+		columns = append(columns, boardColumn{
+			Status: string(status),
+			Count:  board.Totals[status],
+			Issues: col,
+		})
+	}
+
+	result := boardResult{Columns: columns, Progress: make(map[string]boardProgress, len(progress))}
+	for id, p := range progress {
+		result.Progress[model.FormatID(id)] = boardProgress{Done: p.Done, Total: p.Total}
+	}
+	return result
+}
+
+// boardLegend renders the color key for every label on issues, or "" when
+// none of them has a label.
+func boardLegend(conn *sql.DB, issues []*model.Issue) (string, error) {
+	onBoard := make(map[string]bool)
+	for _, issue := range issues {
+		for _, name := range issue.Labels {
+			onBoard[name] = true
+		}
+	}
+	if len(onBoard) == 0 {
+		return "", nil
+	}
+
+	all, err := db.ListAllLabelsRaw(conn)
+	if err != nil {
+		return "", cmdErr(fmt.Errorf("listing labels: %w", err), output.ErrGeneral)
+	}
+	var labels []*model.Label
+	for _, l := range all {
+		if onBoard[l.Name] {
+			labels = append(labels, l)
+		}
+	}
+	return render.RenderLabelLegend(labels), nil
+}
+
+// boardSortKeys returns the sort keys for --sort-cards: "priority" puts the
+// highest priority first, "age" the oldest issue first, and "updated" the
+// most recently updated first, with ties broken by ascending ID. Empty
+// selects the default list order. The board groups issues by status, so
+// this orders cards within each column.
+func boardSortKeys(by string) ([]db.SortKey, error) {
+	switch by {
+	case "":
+		return nil, nil
+	case "priority":
+		return []db.SortKey{{Field: "priority", Dir: "desc"}}, nil
+	case "age":
+		return []db.SortKey{{Field: "created_at", Dir: "asc"}}, nil
+	case "updated":
+		return []db.SortKey{{Field: "updated_at", Dir: "desc"}}, nil
+	default:
+		return nil, fmt.Errorf("invalid --sort-cards value %q: must be one of priority, age, updated", by)
+	}
+}
+
 func init() {
 	boardCmd.Flags().StringSliceP("label", "l", nil, "Filter by label (repeatable)")
 	boardCmd.Flags().StringSliceP("priority", "p", nil, "Filter by priority (repeatable)")
-	boardCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
+	boardCmd.Flags().StringP("assignee", "a", "", "Filter by assignee (\"me\" for the configured current user)")
+	addMineFlag(boardCmd, "Only show issues assigned to the configured current user")
 	boardCmd.Flags().Bool("expand", false, "Show sub-issues individually instead of rolling up")
+	boardCmd.Flags().Bool("show-assignee", true, "Show the assignee on each card")
+	boardCmd.Flags().Bool("show-age", false, "Show how long ago each card was created")
+	boardCmd.Flags().String("sort-cards", "", "Order cards within columns: priority, age, updated")
+	boardCmd.Flags().Int("limit", render.MaxCardsPerColumn, "Maximum cards per column (0 for all; JSON output shows all unless set)")
+	boardCmd.Flags().Bool("include-snoozed", false, "Include snoozed issues")
+	boardCmd.Flags().Bool("legend", false, "Print a color key of the labels on the board below it")
+	boardCmd.Flags().Int("offset", 0, "Skip this many cards at the top of each column")
 	rootCmd.AddCommand(boardCmd)
 }
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+	"github.com/spf13/cobra"
+)
+
+func boardCmdWithDB(conn *sql.DB) *cobra.Command {
+	cmd := cmdWithDB(conn)
+	cmd.Flags().StringSlice("label", nil, "")
+	cmd.Flags().StringSlice("priority", nil, "")
+	cmd.Flags().String("assignee", "", "")
+	cmd.Flags().Bool("mine", false, "")
+	cmd.Flags().Bool("expand", false, "")
+	cmd.Flags().Bool("show-assignee", true, "")
+	cmd.Flags().Bool("show-age", false, "")
+	cmd.Flags().String("sort-cards", "", "")
+	cmd.Flags().Int("limit", render.MaxCardsPerColumn, "")
+	cmd.Flags().Bool("include-snoozed", false, "")
+	cmd.Flags().Bool("legend", false, "")
+	cmd.Flags().Int("offset", 0, "")
+	cmd.Flags().Int("width", 0, "")
+	cmd.Flags().Bool("no-truncate", false, "")
+	return cmd
+}
+
+func TestBoardJSON_PresentColumnsInOrder(t *testing.T) {
+	conn := newTestDB(t)
+	done := createIssue(t, conn, "Shipped", model.StatusDone, model.PriorityLow)
+	todo := createIssue(t, conn, "Queued", model.StatusTodo, model.PriorityHigh)
+	active := createIssue(t, conn, "Underway", model.StatusInProgress, model.PriorityMedium)
+	parent := createIssue(t, conn, "Epic", model.StatusTodo, model.PriorityMedium)
+	if _, err := db.CreateIssue(conn, &model.Issue{
+		Title:    "Child",
+		Status:   model.StatusDone,
+		Priority: model.PriorityLow,
+		Kind:     model.IssueKindTask,
+		ParentID: &parent,
+	}, nil, nil); err != nil {
+		t.Fatalf("CreateIssue(child): %v", err)
+	}
+
+	w, buf := bufWriter(true)
+	if err := runBoard(boardCmdWithDB(conn), nil, w); err != nil {
+		t.Fatalf("runBoard: %v", err)
+	}
+
+	var got struct {
+		Data struct {
+			Columns []struct {
+				Status string `json:"status"`
+				Count  int    `json:"count"`
+				Issues []struct {
+					ID string `json:"id"`
+				} `json:"issues"`
+			} `json:"columns"`
+			Progress map[string]boardProgress `json:"progress"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+
+	// Backlog and review have no issues, so they get no column; the child
+	// rolls up into its parent and leaves only its own card in done.
+	want := map[string][]string{
+		"todo":        {model.FormatID(todo), model.FormatID(parent)},
+		"in-progress": {model.FormatID(active)},
+		"done":        {model.FormatID(done)},
+	}
+	var statuses []string
+	for _, col := range got.Data.Columns {
+		statuses = append(statuses, col.Status)
+		var ids []string
+		for _, issue := range col.Issues {
+			ids = append(ids, issue.ID)
+		}
+		if col.Count != len(want[col.Status]) {
+			t.Errorf("column %s count = %d, want %d", col.Status, col.Count, len(want[col.Status]))
+		}
+		sort.Strings(ids)
+		if !reflect.DeepEqual(ids, want[col.Status]) {
+			t.Errorf("column %s issues = %v, want %v", col.Status, ids, want[col.Status])
+		}
+	}
+	wantStatuses := []string{"todo", "in-progress", "done"}
+	if !reflect.DeepEqual(statuses, wantStatuses) {
+		t.Errorf("columns = %v, want %v", statuses, wantStatuses)
+	}
+
+	// Progress is keyed like the cards' "id", so the two can be joined.
+	wantProgress := map[string]boardProgress{model.FormatID(parent): {Done: 1, Total: 1}}
+	if !reflect.DeepEqual(got.Data.Progress, wantProgress) {
+		t.Errorf("progress = %v, want %v", got.Data.Progress, wantProgress)
+	}
+}
+
+func TestBoardSortKeys(t *testing.T) {
+	tests := []struct {
+		by   string
+		want []db.SortKey
+	}{
+		{"", nil},
+		{"priority", []db.SortKey{{Field: "priority", Dir: "desc"}}},
+		{"age", []db.SortKey{{Field: "created_at", Dir: "asc"}}},
+		{"updated", []db.SortKey{{Field: "updated_at", Dir: "desc"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			got, err := boardSortKeys(tt.by)
+			if err != nil {
+				t.Fatalf("boardSortKeys(%q): %v", tt.by, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("boardSortKeys(%q) = %v, want %v", tt.by, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := boardSortKeys("bogus"); err == nil {
+		t.Error("boardSortKeys(\"bogus\") succeeded, want error")
+	}
+}
+
+func TestBoardLegendListsLabelsOnBoard(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	conn := newTestDB(t)
+	id := createIssue(t, conn, "Card", model.StatusTodo, model.PriorityHigh)
+	if err := db.AddLabelsToIssue(conn, id, []string{"ui", "backend"}, "", "test"); err != nil {
+		t.Fatalf("AddLabelsToIssue: %v", err)
+	}
+	if err := db.AddLabelsToIssue(conn, id, []string{"security"}, "red", "test"); err != nil {
+		t.Fatalf("AddLabelsToIssue: %v", err)
+	}
+	other := createIssue(t, conn, "Unlisted", model.StatusDone, model.PriorityLow)
+	if err := db.AddLabelsToIssue(conn, other, []string{"archived"}, "", "test"); err != nil {
+		t.Fatalf("AddLabelsToIssue: %v", err)
+	}
+	if err := db.RemoveLabelsFromIssue(conn, other, []string{"archived"}, "test"); err != nil {
+		t.Fatalf("RemoveLabelsFromIssue: %v", err)
+	}
+
+	cmd := boardCmdWithDB(conn)
+	cmd.Flags().Set("legend", "true")
+	w, buf := bufWriter(false)
+	if err := runBoard(cmd, nil, w); err != nil {
+		t.Fatalf("runBoard: %v", err)
+	}
+	out := buf.String()
+	if !strings.HasSuffix(strings.TrimSpace(out), "Labels: backend, security (red), ui") {
+		t.Errorf("legend missing or wrong:\n%s", out)
+	}
+	if !strings.Contains(out, "  ui, backend +1\n") {
+		t.Errorf("card labels not fitted:\n%s", out)
+	}
+}
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+)
+
+// cleanupResult is the JSON output of cleanup. Removed is set once the
+// candidates have been deleted, ExportedTo once they have been exported.
+type cleanupResult struct {
+	DryRun     bool            `json:"dry_run"`
+	Candidates []string        `json:"candidates"`
+	ExportedTo string          `json:"exported_to,omitempty"`
+	Removed    *db.PurgeCounts `json:"removed,omitempty"`
+}
+
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Permanently delete long-done issues",
+	Long: `Permanently delete done issues completed at least --done-older-than ago,
+along with their comments, relations and history:
+
+  docket cleanup --done-older-than 180d --dry-run
+  docket cleanup --done-older-than 180d --export-first done-archive.json
+
+Issues still blocking an open issue are kept, as is any issue with a sub-issue
+that is not also being deleted, so open work never disappears with its
+parent. --dry-run lists the issues that would be deleted and changes nothing.
+Otherwise the list is shown and must be confirmed before anything is
+deleted; with --json the issues are deleted without asking.
+
+--export-first writes the issues being deleted to a JSON export, as
+'docket export' would with only those issues, before deleting them, so they
+can be restored with 'docket import'. Issues are deleted in batches rather
+than in one large transaction.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCleanup(cmd, args, getWriter(cmd))
+	},
+}
+
+// confirmCleanup asks whether to permanently delete n issues. Tests replace
+// it to answer without a terminal.
+var confirmCleanup = func(n int) (bool, error) {
+	var confirmed bool
+	err := huh.NewForm(huh.NewGroup(
+		huh.NewConfirm().
+			Title(fmt.Sprintf("Permanently delete %d issues? This cannot be undone.", n)).
+			Value(&confirmed),
+	)).Run()
+	return confirmed, err
+}
+
+func runCleanup(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	olderThan, _ := cmd.Flags().GetString("done-older-than")
+	age, err := parseAge(olderThan)
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	exportPath, _ := cmd.Flags().GetString("export-first")
+	if cmd.Flags().Changed("export-first") && exportPath == "" {
+		return cmdErr(fmt.Errorf("--export-first needs a file name"), output.ErrValidation)
+	}
+
+	if !dryRun {
+		if err := requireWritable(cmd); err != nil {
+			return err
+		}
+		if !w.JSONMode && !stdinIsTerminal() {
+			return cmdErr(fmt.Errorf("non-interactive environment detected; run with --dry-run to review the issues, then use --json to delete them"), output.ErrValidation)
+		}
+	}
+
+	layout, err := getLayout(cmd)
+	if err != nil {
+		return err
+	}
+
+	candidates, err := db.CleanupCandidates(conn, time.Now().Add(-age))
+	if err != nil {
+		return cmdErr(fmt.Errorf("finding issues to clean up: %w", err), output.ErrGeneral)
+	}
+	result := cleanupResult{DryRun: dryRun, Candidates: make([]string, len(candidates))}
+	ids := make([]int, len(candidates))
+	for i, issue := range candidates {
+		ids[i] = issue.ID
+		result.Candidates[i] = model.FormatID(issue.ID)
+	}
+
+	if len(candidates) == 0 {
+		w.Success(result, fmt.Sprintf("No done issues older than %s can be cleaned up", olderThan))
+		return nil
+	}
+	if dryRun {
+		w.Success(result, fmt.Sprintf("%s\nWould permanently delete %d issue(s)", render.RenderTable(candidates, false, layout), len(candidates)))
+		return nil
+	}
+
+	if !w.JSONMode {
+		fmt.Fprintln(w.Stdout, render.RenderTable(candidates, false, layout))
+		confirmed, err := confirmCleanup(len(candidates))
+		if err != nil && !errors.Is(err, huh.ErrUserAborted) {
+			return cmdErr(fmt.Errorf("interactive form failed: %w", err), output.ErrGeneral)
+		}
+		if !confirmed {
+			w.Info("Cancelled.")
+			return nil
+		}
+	}
+
+	if exportPath != "" {
+		if err := exportIssueSubset(conn, exportPath, ids); err != nil {
+			return err
+		}
+		result.ExportedTo = exportPath
+	}
+
+	removed, err := db.PurgeIssues(conn, ids)
+	if err != nil {
+		return cmdErr(fmt.Errorf("deleting issues (%d deleted before the error): %w", removed.Issues, err), output.ErrGeneral)
+	}
+	result.Removed = &removed
+	w.Success(result, fmt.Sprintf("Permanently deleted %d issue(s), %d comment(s) and %d relation(s)",
+		removed.Issues, removed.Comments, removed.Relations))
+	return nil
+}
+
+// exportIssueSubset writes a JSON export holding only the issues with the
+// given IDs, and what belongs to them, to filePath. Parent IDs pointing
+// outside the subset are cleared, as 'docket export --status' does.
+func exportIssueSubset(conn *sql.DB, filePath string, ids []int) error {
+	data, err := loadExportData(conn)
+	if err != nil {
+		return cmdErr(err, output.ErrGeneral)
+	}
+
+	subset := slices.DeleteFunc(data.Issues, func(i *model.Issue) bool {
+		_, found := slices.BinarySearch(ids, i.ID)
+		return !found
+	})
+	for _, issue := range subset {
+		if issue.ParentID != nil {
+			if _, found := slices.BinarySearch(ids, *issue.ParentID); !found {
+				issue.ParentID = nil
+			}
+		}
+	}
+	restrictExportToIssues(&data, subset)
+	fillEmptyExportSlices(&data)
+
+	raw, err := renderExportJSON(data)
+	if err != nil {
+		return cmdErr(fmt.Errorf("rendering export: %w", err), output.ErrGeneral)
+	}
+	return writeExport(filePath, hasGzipExt(filePath), raw)
+}
+
+func init() {
+	cleanupCmd.Flags().String("done-older-than", "", "Delete done issues completed at least this long ago (e.g. 180d, 26w)")
+	cleanupCmd.Flags().Bool("dry-run", false, "List the issues that would be deleted without deleting them")
+	cleanupCmd.Flags().String("export-first", "", "Export the issues to this JSON file before deleting them")
+	_ = cleanupCmd.MarkFlagRequired("done-older-than")
+	rootCmd.AddCommand(cleanupCmd)
+}
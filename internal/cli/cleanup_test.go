@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/spf13/cobra"
+)
+
+func cleanupCmdWithDB(conn *sql.DB, olderThan string) *cobra.Command {
+	cmd := cmdWithDB(conn)
+	cmd.Flags().String("done-older-than", "", "")
+	cmd.Flags().Bool("dry-run", false, "")
+	cmd.Flags().String("export-first", "", "")
+	cmd.Flags().Bool("read-only", false, "")
+	cmd.Flags().Set("done-older-than", olderThan)
+	return cmd
+}
+
+// seedOldDoneIssues creates two done issues completed a year ago, one of
+// them with a comment, and a recently completed done issue.
+func seedOldDoneIssues(t *testing.T, conn *sql.DB) (old []int, recent int) {
+	t.Helper()
+	old = []int{
+		createIssue(t, conn, "Old one", model.StatusDone, model.PriorityLow),
+		createIssue(t, conn, "Old two", model.StatusDone, model.PriorityLow),
+	}
+	recent = createIssue(t, conn, "Recent", model.StatusDone, model.PriorityLow)
+	yearAgo := time.Now().AddDate(-1, 0, 0).UTC().Format(time.RFC3339)
+	for _, id := range old {
+		if _, err := conn.Exec(`UPDATE issues SET completed_at = ? WHERE id = ?`, yearAgo, id); err != nil {
+			t.Fatalf("backdating completed_at: %v", err)
+		}
+	}
+	if _, err := db.CreateComment(conn, &model.Comment{IssueID: old[0], Body: "done", Author: "tester"}); err != nil {
+		t.Fatalf("CreateComment: %v", err)
+	}
+	return old, recent
+}
+
+func TestCleanupDryRunDeletesNothing(t *testing.T) {
+	conn := newTestDB(t)
+	old, _ := seedOldDoneIssues(t, conn)
+
+	cmd := cleanupCmdWithDB(conn, "180d")
+	cmd.Flags().Set("dry-run", "true")
+	w, buf := bufWriter(true)
+	if err := runCleanup(cmd, nil, w); err != nil {
+		t.Fatalf("runCleanup: %v", err)
+	}
+	var got struct {
+		Data cleanupResult `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	want := []string{model.FormatID(old[0]), model.FormatID(old[1])}
+	if !got.Data.DryRun || strings.Join(got.Data.Candidates, ",") != strings.Join(want, ",") || got.Data.Removed != nil {
+		t.Errorf("result = %+v, want dry run listing %v", got.Data, want)
+	}
+	if n, _ := db.CountIssues(conn); n != 3 {
+		t.Errorf("CountIssues = %d after dry run, want 3", n)
+	}
+}
+
+func TestCleanupExportsThenDeletes(t *testing.T) {
+	conn := newTestDB(t)
+	old, recent := seedOldDoneIssues(t, conn)
+	archive := filepath.Join(t.TempDir(), "done-archive.json")
+
+	cmd := cleanupCmdWithDB(conn, "180d")
+	cmd.Flags().Set("export-first", archive)
+	w, buf := bufWriter(true)
+	if err := runCleanup(cmd, nil, w); err != nil {
+		t.Fatalf("runCleanup: %v", err)
+	}
+	var got struct {
+		Data cleanupResult `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if want := (db.PurgeCounts{Issues: 2, Comments: 1}); got.Data.Removed == nil || *got.Data.Removed != want {
+		t.Errorf("removed = %+v, want %+v", got.Data.Removed, want)
+	}
+
+	raw, err := os.ReadFile(archive)
+	if err != nil {
+		t.Fatalf("reading archive: %v", err)
+	}
+	var data model.ExportData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		t.Fatalf("unmarshal archive: %v", err)
+	}
+	if len(data.Issues) != 2 || len(data.Comments) != 1 {
+		t.Errorf("archive holds %d issues and %d comments, want 2 and 1", len(data.Issues), len(data.Comments))
+	}
+	for _, issue := range data.Issues {
+		if issue.ID != old[0] && issue.ID != old[1] {
+			t.Errorf("archive holds %s, which was not cleaned up", model.FormatID(issue.ID))
+		}
+	}
+
+	if _, err := db.GetIssue(conn, recent); err != nil {
+		t.Errorf("recently done issue should survive: %v", err)
+	}
+	if n, _ := db.CountIssues(conn); n != 1 {
+		t.Errorf("CountIssues = %d, want 1", n)
+	}
+}
+
+func TestCleanupHumanModeNeedsConfirmation(t *testing.T) {
+	conn := newTestDB(t)
+	seedOldDoneIssues(t, conn)
+
+	oldTTY, oldConfirm := stdinIsTerminal, confirmCleanup
+	t.Cleanup(func() { stdinIsTerminal, confirmCleanup = oldTTY, oldConfirm })
+
+	stdinIsTerminal = func() bool { return false }
+	w, _ := bufWriter(false)
+	if err := runCleanup(cleanupCmdWithDB(conn, "180d"), nil, w); err == nil {
+		t.Fatal("expected an error without a terminal")
+	}
+
+	stdinIsTerminal = func() bool { return true }
+	var asked int
+	confirmCleanup = func(n int) (bool, error) {
+		asked = n
+		return false, nil
+	}
+	w, buf := bufWriter(false)
+	if err := runCleanup(cleanupCmdWithDB(conn, "180d"), nil, w); err != nil {
+		t.Fatalf("runCleanup: %v", err)
+	}
+	if asked != 2 {
+		t.Errorf("asked to delete %d issues, want 2", asked)
+	}
+	if !strings.Contains(buf.String(), "Old one") {
+		t.Errorf("candidates should be listed before confirming, got %q", buf.String())
+	}
+	if n, _ := db.CountIssues(conn); n != 3 {
+		t.Errorf("CountIssues = %d after declining, want 3", n)
+	}
+}
@@ -0,0 +1,16 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// commentRootCmd is the top-level "comment" group, for working with
+// comments across issues; commentCmd is "issue comment".
+var commentRootCmd = &cobra.Command{
+	Use:   "comment",
+	Short: "Search comments across issues",
+}
+
+func init() {
+	rootCmd.AddCommand(commentRootCmd)
+}
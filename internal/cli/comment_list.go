@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+	"github.com/spf13/cobra"
+)
+
+type commentSearchResult struct {
+	Comments []model.IssueComment `json:"comments"`
+	Total    int                  `json:"total"`
+	Limit    int                  `json:"limit"`
+	Offset   int                  `json:"offset"`
+}
+
+var commentSearchCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List comments across issues, newest first",
+	Aliases: []string{"ls"},
+	Long: `List comments across issues, newest first:
+
+  docket comment list --author bob --since 7d
+  docket comment list --issue DKT-5 --contains "flaky"
+
+Each comment is shown on one line with its issue and the start of its body;
+--json includes complete bodies. Use --limit and --offset to page through
+long results.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCommentSearch(cmd, args, getWriter(cmd))
+	},
+}
+
+func runCommentSearch(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	opts := db.CommentListOptions{}
+	opts.Author, _ = cmd.Flags().GetString("author")
+	opts.Contains, _ = cmd.Flags().GetString("contains")
+	opts.Limit, _ = cmd.Flags().GetInt("limit")
+	opts.Offset, _ = cmd.Flags().GetInt("offset")
+	if opts.Limit < 0 || opts.Offset < 0 {
+		return cmdErr(fmt.Errorf("--limit and --offset must not be negative"), output.ErrValidation)
+	}
+
+	if issueFlag, _ := cmd.Flags().GetString("issue"); issueFlag != "" {
+		id, err := model.ParseID(issueFlag)
+		if err != nil {
+			return cmdErr(err, output.ErrValidation)
+		}
+		opts.IssueID = id
+	}
+
+	now := time.Now()
+	for _, f := range []struct {
+		name string
+		dst  *time.Time
+	}{{"since", &opts.Since}, {"until", &opts.Until}} {
+		v, _ := cmd.Flags().GetString(f.name)
+		if v == "" {
+			continue
+		}
+		age, err := parseAge(v)
+		if err != nil {
+			return cmdErr(fmt.Errorf("--%s: %w", f.name, err), output.ErrValidation)
+		}
+		*f.dst = now.Add(-age)
+	}
+
+	comments, total, err := db.ListComments(conn, opts)
+	if err != nil {
+		return cmdErr(fmt.Errorf("listing comments: %w", err), output.ErrGeneral)
+	}
+
+	result := commentSearchResult{Comments: comments, Total: total, Limit: opts.Limit, Offset: opts.Offset}
+	var message string
+	switch {
+	case w.JSONMode:
+	case len(comments) == 0:
+		message = render.EmptyState("No matching comments.", "", w.QuietMode)
+	default:
+		message = render.RenderCommentSearch(comments)
+		if next := opts.Offset + len(comments); next < total {
+			message += fmt.Sprintf("\n\nShowing %d-%d of %d comments; use --offset %d for more.", opts.Offset+1, next, total, next)
+		}
+	}
+	w.Success(result, message)
+	return nil
+}
+
+func init() {
+	commentSearchCmd.Flags().String("author", "", "Only show comments by this author (case-insensitive)")
+	commentSearchCmd.Flags().String("issue", "", "Only show comments on this issue (e.g. DKT-5)")
+	commentSearchCmd.Flags().String("since", "", "Only show comments made within this long (e.g. 7d, 2w, 12h)")
+	commentSearchCmd.Flags().String("until", "", "Only show comments made at least this long ago (e.g. 1d)")
+	commentSearchCmd.Flags().String("contains", "", "Only show comments whose body contains this text (case-insensitive)")
+	commentSearchCmd.Flags().Int("limit", 50, "Maximum number of results")
+	commentSearchCmd.Flags().Int("offset", 0, "Skip this many results, for paging")
+	commentRootCmd.AddCommand(commentSearchCmd)
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+func commentSearchCmdWithDB(conn *sql.DB) *cobra.Command {
+	cmd := cmdWithDB(conn)
+	cmd.Flags().String("author", "", "")
+	cmd.Flags().String("issue", "", "")
+	cmd.Flags().String("since", "", "")
+	cmd.Flags().String("until", "", "")
+	cmd.Flags().String("contains", "", "")
+	cmd.Flags().Int("limit", 50, "")
+	cmd.Flags().Int("offset", 0, "")
+	return cmd
+}
+
+func TestCommentSearch(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	conn := newTestDB(t)
+	login := createIssue(t, conn, "Flaky login test", model.StatusTodo, model.PriorityNone)
+	cache := createIssue(t, conn, "Cache warmup", model.StatusTodo, model.PriorityNone)
+	long := "Fails on CI because the fixture server starts slowly; wait for the health check before connecting"
+	for _, c := range []model.Comment{
+		{IssueID: login, Body: long, Author: "bob"},
+		{IssueID: cache, Body: "Warmup takes 5s", Author: "alice"},
+		{IssueID: cache, Body: "Still slow", Author: "bob"},
+	} {
+		if _, err := db.CreateComment(conn, &c); err != nil {
+			t.Fatalf("CreateComment: %v", err)
+		}
+	}
+
+	cmd := commentSearchCmdWithDB(conn)
+	for name, value := range map[string]string{"author": "bob", "since": "7d", "limit": "1"} {
+		if err := cmd.Flags().Set(name, value); err != nil {
+			t.Fatalf("Set(%s): %v", name, err)
+		}
+	}
+	w, buf := bufWriter(false)
+	if err := runCommentSearch(cmd, nil, w); err != nil {
+		t.Fatalf("runCommentSearch: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, model.FormatID(cache)+" · bob · ") || !strings.Contains(out, ": Still slow") {
+		t.Errorf("expected bob's newest comment, got:\n%s", out)
+	}
+	if strings.Contains(out, "Warmup") {
+		t.Errorf("expected only bob's comments, got:\n%s", out)
+	}
+	if !strings.Contains(out, "Showing 1-1 of 2 comments; use --offset 1 for more.") {
+		t.Errorf("expected a pagination hint, got:\n%s", out)
+	}
+
+	cmd = commentSearchCmdWithDB(conn)
+	if err := cmd.Flags().Set("issue", model.FormatID(login)); err != nil {
+		t.Fatalf("Set(issue): %v", err)
+	}
+	w, buf = bufWriter(true)
+	if err := runCommentSearch(cmd, nil, w); err != nil {
+		t.Fatalf("runCommentSearch: %v", err)
+	}
+	var env struct {
+		Data struct {
+			Comments []struct {
+				IssueID    string `json:"issue_id"`
+				IssueTitle string `json:"issue_title"`
+				Body       string `json:"body"`
+			} `json:"comments"`
+			Total  int `json:"total"`
+			Limit  int `json:"limit"`
+			Offset int `json:"offset"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("Unmarshal: %v\n%s", err, buf.String())
+	}
+	if env.Data.Total != 1 || env.Data.Limit != 50 || env.Data.Offset != 0 || len(env.Data.Comments) != 1 {
+		t.Fatalf("unexpected result: %+v", env.Data)
+	}
+	if got := env.Data.Comments[0]; got.IssueTitle != "Flaky login test" || got.Body != long {
+		t.Errorf("comment = %+v, want the full body with its issue title", got)
+	}
+}
+
+func TestCommentSearch_InvalidSince(t *testing.T) {
+	conn := newTestDB(t)
+	cmd := commentSearchCmdWithDB(conn)
+	if err := cmd.Flags().Set("since", "soon"); err != nil {
+		t.Fatalf("Set(since): %v", err)
+	}
+	w, _ := bufWriter(false)
+	err := runCommentSearch(cmd, nil, w)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+}
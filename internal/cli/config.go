@@ -26,6 +26,8 @@ type configInfo struct {
 	IssuePrefix   string `json:"issue_prefix"`
 	DocketPathEnv string `json:"docket_path_env"`
 	DocketPathSet bool   `json:"docket_path_set"`
+	CurrentUser   string `json:"current_user"`
+	ConfigFile    string `json:"config_file,omitempty"`
 }
 
 var configCmd = &cobra.Command{
@@ -75,6 +77,8 @@ func runConfig(cmd *cobra.Command, args []string, w *output.Writer) error {
 			IssuePrefix:   model.IDPrefix,
 			DocketPathEnv: docketPathEnv,
 			DocketPathSet: cfg.EnvVarSet,
+			CurrentUser:   cfg.User,
+			ConfigFile:    cfg.ProjectFile,
 		}
 
 		w.Success(info, formatConfigHuman(info, true))
@@ -82,7 +86,11 @@ func runConfig(cmd *cobra.Command, args []string, w *output.Writer) error {
 		return nil
 	}
 
-	conn, err := db.Open(cfg.DBPath)
+	open := db.Open
+	if readOnlyMode(cmd) {
+		open = db.OpenReadOnly
+	}
+	conn, err := open(cfg.DBPath)
 	if err != nil {
 		return cmdErr(fmt.Errorf("opening database: %w", err), output.ErrGeneral)
 	}
@@ -99,6 +107,14 @@ func runConfig(cmd *cobra.Command, args []string, w *output.Writer) error {
 	}
 	dbSize := stat.Size()
 
+	currentUser := cfg.User
+	if currentUser == "" {
+		currentUser, err = db.CurrentUser(conn)
+		if err != nil {
+			return cmdErr(err, output.ErrGeneral)
+		}
+	}
+
 	info := configInfo{
 		DBPath:        cfg.DBPath,
 		DBSizeBytes:   dbSize,
@@ -106,6 +122,8 @@ func runConfig(cmd *cobra.Command, args []string, w *output.Writer) error {
 		IssuePrefix:   model.IDPrefix,
 		DocketPathEnv: docketPathEnv,
 		DocketPathSet: cfg.EnvVarSet,
+		CurrentUser:   currentUser,
+		ConfigFile:    cfg.ProjectFile,
 	}
 
 	w.Success(info, formatConfigHuman(info, false))
@@ -163,10 +181,13 @@ func formatConfigHuman(info configInfo, notFound bool) string {
 	if !notFound {
 		lines += fmt.Sprintf("  %s  %s\n", keyStyle.Render("Database size:"), valStyle.Render(formatSize(info.DBSizeBytes)))
 		lines += fmt.Sprintf("  %s %s\n", keyStyle.Render("Schema version:"), valStyle.Render(fmt.Sprintf("%d", info.SchemaVersion)))
+		lines += fmt.Sprintf("  %s   %s\n", keyStyle.Render("Current user:"), valStyle.Render(formatEnvValue(info.CurrentUser)))
 	}
 
 	lines += fmt.Sprintf("  %s   %s\n", keyStyle.Render("Issue prefix:"), valStyle.Render(info.IssuePrefix))
 
+	lines += fmt.Sprintf("  %s    %s\n", keyStyle.Render("Config file:"), valStyle.Render(formatEnvValue(info.ConfigFile)))
+
 	envVal := formatEnvValue(info.DocketPathEnv)
 	lines += fmt.Sprintf("  %s    %s", keyStyle.Render("DOCKET_PATH:"), valStyle.Render(envVal))
 
@@ -183,8 +204,10 @@ func formatConfigPlain(info configInfo, notFound bool) string {
 	if !notFound {
 		lines += fmt.Sprintf("Database size:   %s\n", formatSize(info.DBSizeBytes))
 		lines += fmt.Sprintf("Schema version:  %d\n", info.SchemaVersion)
+		lines += fmt.Sprintf("Current user:    %s\n", formatEnvValue(info.CurrentUser))
 	}
 	lines += fmt.Sprintf("Issue prefix:    %s\n", info.IssuePrefix)
+	lines += fmt.Sprintf("Config file:     %s\n", formatEnvValue(info.ConfigFile))
 	lines += fmt.Sprintf("DOCKET_PATH:     %s", formatEnvValue(info.DocketPathEnv))
 
 	return lines
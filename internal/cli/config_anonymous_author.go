@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// configAnonymousAuthorResult is the JSON wire format for the config
+// anonymous-author command output.
+type configAnonymousAuthorResult struct {
+	AnonymousAuthor string `json:"anonymous_author"`
+}
+
+var configAnonymousAuthorCmd = &cobra.Command{
+	Use:   "anonymous-author [name]",
+	Short: "Show or set the name shown for comments and activity without an author",
+	Long: `Show or set the name shown for comments and activity recorded without an
+author, for example when no git user.name or OS user could be found:
+
+  docket config anonymous-author system
+
+Without a name set, such comments show "anonymous" and such activity shows
+"system". Once set, both show the configured name, in human and JSON output.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigAnonymousAuthor(cmd, args, getWriter(cmd))
+	},
+}
+
+func runConfigAnonymousAuthor(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+	unset, _ := cmd.Flags().GetBool("unset")
+
+	if unset && len(args) > 0 {
+		return cmdErr(fmt.Errorf("--unset does not take a name"), output.ErrValidation)
+	}
+
+	if !unset && len(args) == 0 {
+		name, err := db.AnonymousAuthor(conn)
+		if err != nil {
+			return cmdErr(err, output.ErrGeneral)
+		}
+		if name == "" {
+			w.Success(configAnonymousAuthorResult{}, "No anonymous author set. Set one with: docket config anonymous-author <name>")
+			return nil
+		}
+		w.Success(configAnonymousAuthorResult{AnonymousAuthor: name}, name)
+		return nil
+	}
+
+	if err := requireWritable(cmd); err != nil {
+		return err
+	}
+
+	var name string
+	if len(args) > 0 {
+		name = strings.TrimSpace(args[0])
+		if name == "" {
+			return cmdErr(fmt.Errorf("anonymous author cannot be empty; use --unset to clear it"), output.ErrValidation)
+		}
+	}
+
+	if err := db.SetAnonymousAuthor(conn, name); err != nil {
+		if errors.Is(err, db.ErrValidation) {
+			return cmdErr(err, output.ErrValidation)
+		}
+		return cmdErr(err, output.ErrGeneral)
+	}
+	model.SetAnonymousAuthor(name)
+
+	if name == "" {
+		w.Success(configAnonymousAuthorResult{}, "Cleared the anonymous author")
+		return nil
+	}
+	w.Success(configAnonymousAuthorResult{AnonymousAuthor: name}, fmt.Sprintf("Anonymous author set to %s", name))
+	return nil
+}
+
+// loadAnonymousAuthor makes the anonymous author stored in conn, if any, the
+// name shown for comments and activity without an author.
+func loadAnonymousAuthor(conn *sql.DB) error {
+	name, err := db.AnonymousAuthor(conn)
+	if err != nil {
+		return err
+	}
+	model.SetAnonymousAuthor(name)
+	return nil
+}
+
+func init() {
+	configAnonymousAuthorCmd.Flags().Bool("unset", false, "Clear the anonymous author")
+	configCmd.AddCommand(configAnonymousAuthorCmd)
+}
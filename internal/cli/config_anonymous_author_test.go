@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+func TestConfigAnonymousAuthorReadOnly(t *testing.T) {
+	conn := newTestDB(t)
+	t.Cleanup(func() { model.SetAnonymousAuthor("") })
+
+	if !readOnlyCommands[configAnonymousAuthorCmd.CommandPath()] {
+		t.Fatalf("%q is refused on a read-only database", configAnonymousAuthorCmd.CommandPath())
+	}
+
+	cmd := cmdWithDB(conn)
+	cmd.Flags().Bool("unset", false, "")
+	cmd.Flags().Bool("read-only", false, "")
+	cmd.Flags().Set("read-only", "true")
+
+	w, _ := bufWriter(true)
+	if err := runConfigAnonymousAuthor(cmd, nil, w); err != nil {
+		t.Errorf("showing the anonymous author with --read-only: %v", err)
+	}
+
+	var ce *CmdError
+	if err := runConfigAnonymousAuthor(cmd, []string{"bot"}, w); !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Errorf("setting the anonymous author with --read-only = %v, want a validation error", err)
+	}
+}
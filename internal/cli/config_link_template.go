@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+	"github.com/spf13/cobra"
+)
+
+// configLinkTemplateResult is the JSON wire format for the config
+// link-template command output.
+type configLinkTemplateResult struct {
+	LinkTemplate string `json:"link_template"`
+}
+
+var configLinkTemplateCmd = &cobra.Command{
+	Use:   "link-template [template]",
+	Short: "Show or set the URL template for clickable issue IDs",
+	Long: `Show or set the URL template used to make issue IDs clickable. %s in the
+template stands for the issue ID:
+
+  docket config link-template "https://tracker.example/issues/%s"
+
+When a template is set and colors are enabled, issue IDs in tables, trees,
+boards and issue details are wrapped in terminal hyperlinks (OSC 8), so
+terminals that support them open the URL on click. With NO_COLOR set, a dumb
+terminal or --json, IDs are written as plain text.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigLinkTemplate(cmd, args, getWriter(cmd))
+	},
+}
+
+func runConfigLinkTemplate(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+	unset, _ := cmd.Flags().GetBool("unset")
+
+	if unset && len(args) > 0 {
+		return cmdErr(fmt.Errorf("--unset does not take a template"), output.ErrValidation)
+	}
+
+	if !unset && len(args) == 0 {
+		tmpl, err := db.LinkTemplate(conn)
+		if err != nil {
+			return cmdErr(err, output.ErrGeneral)
+		}
+		if tmpl == "" {
+			w.Success(configLinkTemplateResult{}, "No link template set. Set one with: docket config link-template <template>")
+			return nil
+		}
+		w.Success(configLinkTemplateResult{LinkTemplate: tmpl}, tmpl)
+		return nil
+	}
+
+	if err := requireWritable(cmd); err != nil {
+		return err
+	}
+
+	var tmpl string
+	if len(args) > 0 {
+		tmpl = strings.TrimSpace(args[0])
+		if tmpl == "" {
+			return cmdErr(fmt.Errorf("link template cannot be empty; use --unset to clear it"), output.ErrValidation)
+		}
+	}
+
+	if err := db.SetLinkTemplate(conn, tmpl); err != nil {
+		if errors.Is(err, db.ErrValidation) {
+			return cmdErr(err, output.ErrValidation)
+		}
+		return cmdErr(err, output.ErrGeneral)
+	}
+
+	if tmpl == "" {
+		w.Success(configLinkTemplateResult{}, "Cleared the link template")
+		return nil
+	}
+	w.Success(configLinkTemplateResult{LinkTemplate: tmpl}, fmt.Sprintf("Link template set to %s", tmpl))
+	return nil
+}
+
+// loadLinkTemplate points render.LinkedID at the link template stored in
+// conn, if any.
+func loadLinkTemplate(conn *sql.DB) error {
+	tmpl, err := db.LinkTemplate(conn)
+	if err != nil {
+		return err
+	}
+	render.SetLinkTemplate(tmpl)
+	return nil
+}
+
+func init() {
+	configLinkTemplateCmd.Flags().Bool("unset", false, "Clear the link template")
+	configCmd.AddCommand(configLinkTemplateCmd)
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// configSortResult is the JSON wire format for the config sort command
+// output. Sort is empty when the built-in order applies.
+type configSortResult struct {
+	Sort string `json:"sort"`
+}
+
+var configSortCmd = &cobra.Command{
+	Use:   "sort [keys]",
+	Short: "Show or set the default sort for issue list",
+	Long: `Show or set the default sort used by issue list when --sort is not given.
+Keys are comma-separated field:direction pairs applied in order:
+
+  docket config sort priority:desc,updated_at:desc
+
+Status and priority sort by rank rather than alphabetically: priority:desc
+lists critical first, and status:desc lists in-progress first. Without a
+default, issues are sorted by status, then priority, then newest first.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigSort(cmd, args, getWriter(cmd))
+	},
+}
+
+func runConfigSort(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+	unset, _ := cmd.Flags().GetBool("unset")
+
+	if unset && len(args) > 0 {
+		return cmdErr(fmt.Errorf("--unset does not take sort keys"), output.ErrValidation)
+	}
+
+	if !unset && len(args) == 0 {
+		keys, err := db.DefaultSort(conn)
+		if err != nil {
+			return cmdErr(err, output.ErrGeneral)
+		}
+		if keys == nil {
+			w.Success(configSortResult{}, "No default sort set. Set one with: docket config sort <field:direction,...>")
+			return nil
+		}
+		spec := db.FormatSortKeys(keys)
+		w.Success(configSortResult{Sort: spec}, spec)
+		return nil
+	}
+
+	if err := requireWritable(cmd); err != nil {
+		return err
+	}
+
+	var keys []db.SortKey
+	if len(args) > 0 {
+		var err error
+		if keys, err = db.ParseSortKeys(args[0]); err != nil {
+			return cmdErr(err, output.ErrValidation)
+		}
+	}
+
+	if err := db.SetDefaultSort(conn, keys); err != nil {
+		if errors.Is(err, db.ErrValidation) {
+			return cmdErr(err, output.ErrValidation)
+		}
+		return cmdErr(err, output.ErrGeneral)
+	}
+
+	if keys == nil {
+		w.Success(configSortResult{}, "Cleared the default sort")
+		return nil
+	}
+	spec := db.FormatSortKeys(keys)
+	w.Success(configSortResult{Sort: spec}, fmt.Sprintf("Default sort set to %s", spec))
+	return nil
+}
+
+func init() {
+	configSortCmd.Flags().Bool("unset", false, "Clear the default sort")
+	configCmd.AddCommand(configSortCmd)
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// configTransitionsResult is the JSON wire format for the config transitions
+// command output. Transitions is null when every transition is allowed.
+type configTransitionsResult struct {
+	Transitions model.Transitions `json:"transitions"`
+}
+
+var configTransitionsCmd = &cobra.Command{
+	Use:   "transitions",
+	Short: "Show or set the allowed status transitions",
+	Long: `Show or set the status transitions allowed by issue edit, move, close and
+reopen. By default every transition is allowed. Each --allow rule lists the
+statuses an issue may move to from one status; statuses without a rule cannot
+be left:
+
+  docket config transitions \
+    --allow backlog=todo --allow todo=in-progress,backlog \
+    --allow in-progress=review,todo --allow review=done,in-progress \
+    --allow done=backlog
+
+--allow replaces the whole map. Use --clear to allow every transition again.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigTransitions(cmd, args, getWriter(cmd))
+	},
+}
+
+func runConfigTransitions(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+	rules, _ := cmd.Flags().GetStringArray("allow")
+	reset, _ := cmd.Flags().GetBool("clear")
+
+	if reset && len(rules) > 0 {
+		return cmdErr(fmt.Errorf("--clear cannot be combined with --allow"), output.ErrValidation)
+	}
+
+	if !reset && len(rules) == 0 {
+		transitions, err := db.StatusTransitions(conn)
+		if err != nil {
+			return cmdErr(err, output.ErrGeneral)
+		}
+		w.Success(configTransitionsResult{Transitions: transitions}, formatTransitions(transitions))
+		return nil
+	}
+
+	if err := requireWritable(cmd); err != nil {
+		return err
+	}
+
+	var transitions model.Transitions
+	if !reset {
+		transitions = make(model.Transitions)
+		for _, rule := range rules {
+			from, to, err := model.ParseTransitionRule(rule)
+			if err != nil {
+				return cmdErr(err, output.ErrValidation)
+			}
+			if _, dup := transitions[from]; dup {
+				return cmdErr(fmt.Errorf("status %q has more than one --allow rule", from), output.ErrValidation)
+			}
+			transitions[from] = to
+		}
+	}
+
+	if err := db.SetStatusTransitions(conn, transitions); err != nil {
+		if errors.Is(err, db.ErrValidation) {
+			return cmdErr(err, output.ErrValidation)
+		}
+		return cmdErr(err, output.ErrGeneral)
+	}
+
+	if transitions == nil {
+		w.Success(configTransitionsResult{}, "Cleared the status transitions: every transition is allowed")
+		return nil
+	}
+	w.Success(configTransitionsResult{Transitions: transitions}, formatTransitions(transitions))
+	return nil
+}
+
+// formatTransitions renders one "from -> to, to" line per status, sorted by
+// status name.
+func formatTransitions(t model.Transitions) string {
+	if t == nil {
+		return "All status transitions are allowed. Restrict them with: docket config transitions --allow <from>=<to>[,<to>...]"
+	}
+	froms := make([]string, 0, len(t))
+	for from := range t {
+		froms = append(froms, string(from))
+	}
+	sort.Strings(froms)
+
+	var b strings.Builder
+	b.WriteString("Allowed status transitions:")
+	for _, from := range froms {
+		fmt.Fprintf(&b, "\n  %-12s -> %s", from, formatStatusList(t[model.Status(from)]))
+	}
+	return b.String()
+}
+
+// formatStatusList joins statuses with commas, or returns "(none)".
+func formatStatusList(statuses []model.Status) string {
+	if len(statuses) == 0 {
+		return "(none)"
+	}
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
+
+func init() {
+	configTransitionsCmd.Flags().StringArray("allow", nil, "Allowed moves from a status, as from=to[,to...] (repeatable; replaces the map)")
+	configTransitionsCmd.Flags().Bool("clear", false, "Allow every status transition again")
+	configCmd.AddCommand(configTransitionsCmd)
+}
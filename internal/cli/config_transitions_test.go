@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+func TestConfigTransitionsSetAndClear(t *testing.T) {
+	conn := newTestDB(t)
+
+	cmd := cmdWithDB(conn)
+	cmd.Flags().StringArray("allow", nil, "")
+	cmd.Flags().Bool("clear", false, "")
+	cmd.Flags().Bool("read-only", false, "")
+	cmd.Flags().Set("allow", "backlog=todo")
+	cmd.Flags().Set("allow", "todo=in-progress,backlog")
+	w, _ := bufWriter(true)
+	if err := runConfigTransitions(cmd, nil, w); err != nil {
+		t.Fatalf("runConfigTransitions --allow: %v", err)
+	}
+
+	tr, err := db.StatusTransitions(conn)
+	if err != nil {
+		t.Fatalf("StatusTransitions: %v", err)
+	}
+	if !tr.Allows(model.StatusTodo, model.StatusBacklog) || tr.Allows(model.StatusBacklog, model.StatusDone) {
+		t.Errorf("stored transitions = %v", tr)
+	}
+
+	cmd.Flags().Set("allow", "todo=done")
+	var ce *CmdError
+	if err := runConfigTransitions(cmd, nil, w); !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Errorf("duplicate rule error = %v, want a validation error", err)
+	}
+
+	cmd = cmdWithDB(conn)
+	cmd.Flags().StringArray("allow", nil, "")
+	cmd.Flags().Bool("clear", false, "")
+	cmd.Flags().Bool("read-only", false, "")
+	cmd.Flags().Set("clear", "true")
+	if err := runConfigTransitions(cmd, nil, w); err != nil {
+		t.Fatalf("runConfigTransitions --clear: %v", err)
+	}
+	if tr, err := db.StatusTransitions(conn); err != nil || tr != nil {
+		t.Errorf("transitions after --clear = %v, %v; want nil", tr, err)
+	}
+}
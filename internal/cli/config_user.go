@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// configUserResult is the JSON wire format for the config user command output.
+type configUserResult struct {
+	User string `json:"user"`
+}
+
+var configUserCmd = &cobra.Command{
+	Use:   "user [name]",
+	Short: "Show or set the current user",
+	Long: `Show or set the current user stored in this database. A "user" set in the
+project config file (.docket.toml or .docket.json) takes precedence. Wherever
+an assignee is accepted, "me" (or --mine) stands for the current user:
+
+  docket config user jane
+  docket issue create -t "Fix login" --assignee me
+  docket issue list --mine`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigUser(cmd, args, getWriter(cmd))
+	},
+}
+
+func runConfigUser(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+	unset, _ := cmd.Flags().GetBool("unset")
+
+	if unset && len(args) > 0 {
+		return cmdErr(fmt.Errorf("--unset does not take a name"), output.ErrValidation)
+	}
+
+	if !unset && len(args) == 0 {
+		if user := projectUser(cmd); user != "" {
+			w.Success(configUserResult{User: user}, fmt.Sprintf("%s (from %s)", user, getCfg(cmd).ProjectFile))
+			return nil
+		}
+		user, err := db.CurrentUser(conn)
+		if err != nil {
+			return cmdErr(err, output.ErrGeneral)
+		}
+		if user == "" {
+			w.Success(configUserResult{}, "No current user set. Set one with: docket config user <name>")
+			return nil
+		}
+		w.Success(configUserResult{User: user}, user)
+		return nil
+	}
+
+	if err := requireWritable(cmd); err != nil {
+		return err
+	}
+
+	var name string
+	if len(args) > 0 {
+		name = strings.TrimSpace(args[0])
+		if name == "" {
+			return cmdErr(fmt.Errorf("user name cannot be empty; use --unset to clear it"), output.ErrValidation)
+		}
+		if strings.EqualFold(name, db.MeAssignee) {
+			return cmdErr(fmt.Errorf("%q is reserved as the alias for the current user", db.MeAssignee), output.ErrValidation)
+		}
+	}
+
+	if err := db.SetCurrentUser(conn, name); err != nil {
+		return cmdErr(err, output.ErrGeneral)
+	}
+	if user := projectUser(cmd); user != "" {
+		w.Warn("%s sets the current user to %s, which takes precedence", getCfg(cmd).ProjectFile, user)
+	}
+
+	if name == "" {
+		w.Success(configUserResult{}, "Cleared the current user")
+		return nil
+	}
+	w.Success(configUserResult{User: name}, fmt.Sprintf("Current user set to %s", name))
+	return nil
+}
+
+func init() {
+	configUserCmd.Flags().Bool("unset", false, "Clear the current user")
+	configCmd.AddCommand(configUserCmd)
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// maxDescriptionSize caps descriptions read from stdin, a file, or an editor.
+const maxDescriptionSize = 1 << 20 // 1 MiB
+
+// addDescriptionSourceFlags registers the flags that let issue create and
+// issue edit take the description from somewhere other than --description.
+func addDescriptionSourceFlags(cmd *cobra.Command) {
+	cmd.Flags().String("description-file", "", "Read the description from a file (use \"-\" for stdin)")
+	cmd.Flags().Bool("editor", false, "Write the description in $EDITOR")
+	cmd.MarkFlagsMutuallyExclusive("description", "description-file", "editor")
+}
+
+// descriptionFromFlags resolves the description requested by --description,
+// --description-file, or --editor. current seeds the editor buffer. ok is
+// false when none of the flags was given, so callers keep their existing
+// description.
+func descriptionFromFlags(cmd *cobra.Command, stdin io.Reader, current string) (description string, ok bool, err error) {
+	switch {
+	case cmd.Flags().Changed("description-file"):
+		path, _ := cmd.Flags().GetString("description-file")
+		description, err = readDescriptionFile(path, stdin)
+		if err != nil {
+			return "", false, err
+		}
+		return description, true, nil
+	case cmd.Flags().Changed("editor"):
+		if useEditor, _ := cmd.Flags().GetBool("editor"); !useEditor {
+			return "", false, nil
+		}
+		if jsonMode, _ := cmd.Flags().GetBool("json"); jsonMode {
+			return "", false, cmdErr(fmt.Errorf("--editor is not available in JSON mode; use --description-file instead"), output.ErrValidation)
+		}
+		description, err = editDescription(current)
+		if err != nil {
+			return "", false, err
+		}
+		return description, true, nil
+	case cmd.Flags().Changed("description"):
+		description, _ = cmd.Flags().GetString("description")
+		if description == "-" {
+			description, err = readDescription(stdin, "stdin")
+			if err != nil {
+				return "", false, err
+			}
+		}
+		return description, true, nil
+	}
+	return "", false, nil
+}
+
+// readDescriptionFile reads a description from path, or from stdin when path
+// is "-".
+func readDescriptionFile(path string, stdin io.Reader) (string, error) {
+	if path == "-" {
+		return readDescription(stdin, "stdin")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", cmdErr(fmt.Errorf("opening description file: %w", err), output.ErrValidation)
+	}
+	defer f.Close()
+	return readDescription(f, path)
+}
+
+// readDescription reads at most maxDescriptionSize bytes from r, trimming
+// trailing newlines. source names r in error messages.
+func readDescription(r io.Reader, source string) (string, error) {
+	data, err := io.ReadAll(&io.LimitedReader{R: r, N: maxDescriptionSize + 1})
+	if err != nil {
+		return "", cmdErr(fmt.Errorf("reading description from %s: %w", source, err), output.ErrGeneral)
+	}
+	if len(data) > maxDescriptionSize {
+		return "", cmdErr(fmt.Errorf("description from %s exceeds %d bytes", source, maxDescriptionSize), output.ErrValidation)
+	}
+	return strings.TrimRight(string(data), "\n"), nil
+}
+
+// editDescription opens $EDITOR (vi by default) on a temp file holding
+// current and returns the saved contents. $EDITOR may include arguments,
+// as in "code --wait".
+func editDescription(current string) (string, error) {
+	editor := os.Getenv("EDITOR")
+	if strings.TrimSpace(editor) == "" {
+		editor = "vi"
+	}
+
+	tmpFile, err := os.CreateTemp("", "docket-description-*.md")
+	if err != nil {
+		return "", cmdErr(fmt.Errorf("creating temp file: %w", err), output.ErrGeneral)
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+	if _, err := tmpFile.WriteString(current); err != nil {
+		tmpFile.Close()
+		return "", cmdErr(fmt.Errorf("writing temp file: %w", err), output.ErrGeneral)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return "", cmdErr(fmt.Errorf("closing temp file: %w", err), output.ErrGeneral)
+	}
+
+	editorArgs := strings.Fields(editor)
+	editorCmd := exec.Command(editorArgs[0], append(editorArgs[1:], tmpPath)...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return "", cmdErr(fmt.Errorf("editor exited with error: %w", err), output.ErrGeneral)
+	}
+
+	f, err := os.Open(tmpPath)
+	if err != nil {
+		return "", cmdErr(fmt.Errorf("reading temp file: %w", err), output.ErrGeneral)
+	}
+	defer f.Close()
+	return readDescription(f, "editor")
+}
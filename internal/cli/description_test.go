@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func descriptionCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("json", false, "")
+	cmd.Flags().StringP("description", "d", "", "")
+	addDescriptionSourceFlags(cmd)
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatalf("ParseFlags(%v): %v", args, err)
+	}
+	return cmd
+}
+
+func TestReadDescriptionFileFromReader(t *testing.T) {
+	got, err := readDescriptionFile("-", strings.NewReader("line one\nline two\n\n"))
+	if err != nil {
+		t.Fatalf("readDescriptionFile: %v", err)
+	}
+	if got != "line one\nline two" {
+		t.Errorf("description = %q, want trailing newlines trimmed", got)
+	}
+}
+
+func TestReadDescriptionFileFromPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "desc.md")
+	if err := os.WriteFile(path, []byte("# Heading\n\nBody\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	got, err := readDescriptionFile(path, strings.NewReader("ignored"))
+	if err != nil {
+		t.Fatalf("readDescriptionFile: %v", err)
+	}
+	if got != "# Heading\n\nBody" {
+		t.Errorf("description = %q", got)
+	}
+
+	if _, err := readDescriptionFile(filepath.Join(t.TempDir(), "missing.md"), nil); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestReadDescriptionRejectsOversizedInput(t *testing.T) {
+	big := strings.NewReader(strings.Repeat("x", maxDescriptionSize+1))
+	if _, err := readDescriptionFile("-", big); err == nil {
+		t.Error("expected an error for input over the size limit")
+	}
+}
+
+func TestDescriptionFromFlags(t *testing.T) {
+	stdin := strings.NewReader("from stdin\n")
+
+	got, ok, err := descriptionFromFlags(descriptionCmd(t, "--description-file", "-"), stdin, "current")
+	if err != nil || !ok || got != "from stdin" {
+		t.Errorf("--description-file - = %q, %v, %v", got, ok, err)
+	}
+
+	got, ok, err = descriptionFromFlags(descriptionCmd(t, "-d", "inline"), stdin, "current")
+	if err != nil || !ok || got != "inline" {
+		t.Errorf("-d inline = %q, %v, %v", got, ok, err)
+	}
+
+	if _, ok, err := descriptionFromFlags(descriptionCmd(t), stdin, "current"); err != nil || ok {
+		t.Errorf("no flags: ok = %v, err = %v; want false, nil", ok, err)
+	}
+
+	if _, _, err := descriptionFromFlags(descriptionCmd(t, "--editor", "--json"), stdin, ""); err == nil {
+		t.Error("expected --editor to be rejected in JSON mode")
+	}
+}
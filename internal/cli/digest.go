@@ -0,0 +1,232 @@
+package cli
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/digest"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// digestIssueJSON is the JSON wire format for an issue listed in a digest.
+type digestIssueJSON struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Priority string `json:"priority"`
+	Kind     string `json:"kind"`
+}
+
+// digestCompletedJSON is the JSON wire format for a completed issue.
+type digestCompletedJSON struct {
+	digestIssueJSON
+	CompletedAt      string `json:"completed_at"`
+	CycleTimeSeconds int64  `json:"cycle_time_seconds"`
+}
+
+// digestStatusChangeJSON is the JSON wire format for an issue's net status
+// change over the period.
+type digestStatusChangeJSON struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Changes int    `json:"changes"`
+}
+
+// digestBlockedJSON is the JSON wire format for a blocked issue.
+type digestBlockedJSON struct {
+	digestIssueJSON
+	BlockedBy []string `json:"blocked_by"`
+}
+
+// digestStaleJSON is the JSON wire format for a stale issue.
+type digestStaleJSON struct {
+	digestIssueJSON
+	UpdatedAt   string `json:"updated_at"`
+	IdleSeconds int64  `json:"idle_seconds"`
+}
+
+// digestResult is the JSON wire format for the digest command output.
+type digestResult struct {
+	Since         string                   `json:"since"`
+	Until         string                   `json:"until"`
+	Created       []digestIssueJSON        `json:"created"`
+	Completed     []digestCompletedJSON    `json:"completed"`
+	StatusChanges []digestStatusChangeJSON `json:"status_changes"`
+	NewComments   int                      `json:"new_comments"`
+	Blocked       []digestBlockedJSON      `json:"blocked"`
+	Stale         []digestStaleJSON        `json:"stale"`
+	File          string                   `json:"file,omitempty"`
+}
+
+var digestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Generate a Markdown digest of recent changes",
+	Long: `Generate a Markdown report of the issues created, completed (with their
+cycle time) and moved between statuses over a period, the number of new
+comments, the issues currently blocked, and the open issues that have gone
+longest without an update.
+
+  docket digest --since 7d --file WEEKLY.md
+
+Cycle time runs from when an issue moved to in-progress, or from its creation
+if it never did. With --json, the structured data behind the report is
+written instead.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDigest(cmd, args, getWriter(cmd))
+	},
+}
+
+func runDigest(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	filePath, _ := cmd.Flags().GetString("file")
+	staleLimit, _ := cmd.Flags().GetInt("stale")
+	age, err := parseAge(sinceFlag)
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+	if staleLimit < 1 {
+		return cmdErr(fmt.Errorf("--stale must be at least 1"), output.ErrValidation)
+	}
+
+	until := time.Now().UTC().Truncate(time.Second)
+	d, err := buildDigest(conn, until.Add(-age), until, staleLimit)
+	if err != nil {
+		return cmdErr(err, output.ErrGeneral)
+	}
+	markdown := digest.Markdown(d)
+
+	result := newDigestResult(d)
+	if filePath != "" {
+		if err := os.WriteFile(filePath, []byte(markdown), 0o644); err != nil {
+			return cmdErr(fmt.Errorf("writing digest: %w", err), output.ErrGeneral)
+		}
+		result.File = filePath
+		w.Success(result, fmt.Sprintf("Wrote digest to %s", filePath))
+		return nil
+	}
+
+	if w.JSONMode {
+		w.Success(result, "")
+		return nil
+	}
+	fmt.Fprint(w.Stdout, markdown)
+	return nil
+}
+
+// buildDigest gathers the data for a digest of [since, until).
+func buildDigest(conn *sql.DB, since, until time.Time, staleLimit int) (*digest.Digest, error) {
+	created, err := db.IssuesCreatedBetween(conn, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("fetching created issues: %w", err)
+	}
+	completed, err := db.IssuesCompletedBetween(conn, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("fetching completed issues: %w", err)
+	}
+	activity, err := db.ListActivitySince(conn, since)
+	if err != nil {
+		return nil, fmt.Errorf("fetching activity: %w", err)
+	}
+	comments, err := db.CountCommentsBetween(conn, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("counting comments: %w", err)
+	}
+	issues, err := db.ListAllIssues(conn)
+	if err != nil {
+		return nil, fmt.Errorf("listing issues: %w", err)
+	}
+	relations, err := db.GetAllDirectionalRelations(conn)
+	if err != nil {
+		return nil, fmt.Errorf("loading relations: %w", err)
+	}
+
+	return digest.Build(digest.Input{
+		Since:       since,
+		Until:       until,
+		Created:     created,
+		Completed:   completed,
+		Activity:    activity,
+		NewComments: comments,
+		Issues:      issues,
+		Relations:   relations,
+		StaleLimit:  staleLimit,
+	}), nil
+}
+
+func newDigestIssueJSON(issue *model.Issue) digestIssueJSON {
+	return digestIssueJSON{
+		ID:       model.FormatID(issue.ID),
+		Title:    issue.Title,
+		Status:   string(issue.Status),
+		Priority: string(issue.Priority),
+		Kind:     string(issue.Kind),
+	}
+}
+
+// newDigestResult converts d to its JSON wire format.
+func newDigestResult(d *digest.Digest) digestResult {
+	result := digestResult{
+		Since:         d.Since.UTC().Format(time.RFC3339),
+		Until:         d.Until.UTC().Format(time.RFC3339),
+		Created:       make([]digestIssueJSON, 0, len(d.Created)),
+		Completed:     make([]digestCompletedJSON, 0, len(d.Completed)),
+		StatusChanges: make([]digestStatusChangeJSON, 0, len(d.StatusChanges)),
+		NewComments:   d.NewComments,
+		Blocked:       make([]digestBlockedJSON, 0, len(d.Blocked)),
+		Stale:         make([]digestStaleJSON, 0, len(d.Stale)),
+	}
+	for _, issue := range d.Created {
+		result.Created = append(result.Created, newDigestIssueJSON(issue))
+	}
+	for _, c := range d.Completed {
+		result.Completed = append(result.Completed, digestCompletedJSON{
+			digestIssueJSON:  newDigestIssueJSON(c.Issue),
+			CompletedAt:      c.Issue.CompletedAt.UTC().Format(time.RFC3339),
+			CycleTimeSeconds: int64(c.CycleTime.Seconds()),
+		})
+	}
+	for _, c := range d.StatusChanges {
+		result.StatusChanges = append(result.StatusChanges, digestStatusChangeJSON{
+			ID:      model.FormatID(c.IssueID),
+			Title:   c.IssueTitle,
+			From:    string(c.From),
+			To:      string(c.To),
+			Changes: c.Count,
+		})
+	}
+	for _, b := range d.Blocked {
+		ids := make([]string, len(b.BlockedBy))
+		for i, blocker := range b.BlockedBy {
+			ids[i] = model.FormatID(blocker.ID)
+		}
+		result.Blocked = append(result.Blocked, digestBlockedJSON{
+			digestIssueJSON: newDigestIssueJSON(b.Issue),
+			BlockedBy:       ids,
+		})
+	}
+	for _, s := range d.Stale {
+		result.Stale = append(result.Stale, digestStaleJSON{
+			digestIssueJSON: newDigestIssueJSON(s.Issue),
+			UpdatedAt:       s.Issue.UpdatedAt.UTC().Format(time.RFC3339),
+			IdleSeconds:     int64(s.Idle.Seconds()),
+		})
+	}
+	return result
+}
+
+func init() {
+	digestCmd.Flags().String("since", "7d", "How far back to look (e.g. 7d, 2w, 36h)")
+	digestCmd.Flags().StringP("file", "f", "", "Write the Markdown report to this file instead of stdout")
+	digestCmd.Flags().Int("stale", digest.DefaultStaleLimit, "Number of stale issues to list")
+	rootCmd.AddCommand(digestCmd)
+}
@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/digest"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// digestUntil is the end of the seeded digest period; it starts 7 days
+// earlier.
+var digestUntil = time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+// seedDigestDB creates an in-memory database with fixed timestamps around
+// the week before digestUntil.
+func seedDigestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	conn := newTestDB(t)
+	day := func(d, h int) time.Time { return time.Date(2026, 3, d, h, 0, 0, 0, time.UTC) }
+
+	issues := []*model.Issue{
+		// Stale: open and untouched since before the period.
+		{ID: 1, Title: "Old *flaky* test", Status: model.StatusTodo, Priority: model.PriorityLow, Kind: model.IssueKindBug, CreatedAt: day(1, 9), UpdatedAt: day(1, 9)},
+		{ID: 2, Title: "Refactor config", Status: model.StatusBacklog, Priority: model.PriorityMedium, Kind: model.IssueKindChore, CreatedAt: day(2, 9), UpdatedAt: day(3, 9)},
+		// Completed in the period, started before it.
+		{ID: 3, Title: "Add login", Status: model.StatusDone, Priority: model.PriorityHigh, Kind: model.IssueKindFeature, CreatedAt: day(4, 9), UpdatedAt: day(12, 15), StartedAt: day(10, 10), CompletedAt: day(12, 15)},
+		// Created and completed in the period, never started.
+		{ID: 4, Title: "Fix typo", Status: model.StatusDone, Priority: model.PriorityNone, Kind: model.IssueKindBug, CreatedAt: day(9, 10), UpdatedAt: day(9, 11), CompletedAt: day(9, 11)},
+		// Created in the period and blocked by 5.
+		{ID: 5, Title: "Design API", Status: model.StatusInProgress, Priority: model.PriorityHigh, Kind: model.IssueKindTask, CreatedAt: day(10, 9), UpdatedAt: day(11, 9), StartedAt: day(11, 9)},
+		{ID: 6, Title: "Build API", Status: model.StatusTodo, Priority: model.PriorityMedium, Kind: model.IssueKindTask, CreatedAt: day(10, 10), UpdatedAt: day(10, 10)},
+		// Completed before the period.
+		{ID: 7, Title: "Bootstrap repo", Status: model.StatusDone, Priority: model.PriorityLow, Kind: model.IssueKindChore, CreatedAt: day(1, 8), UpdatedAt: day(2, 8), CompletedAt: day(2, 8)},
+	}
+	activity := []*model.Activity{
+		{ID: 1, IssueID: 7, FieldChanged: "status", OldValue: "todo", NewValue: "done", ChangedBy: "alice", CreatedAt: day(2, 8)},
+		{ID: 2, IssueID: 4, FieldChanged: "status", OldValue: "todo", NewValue: "done", ChangedBy: "bob", CreatedAt: day(9, 11)},
+		{ID: 3, IssueID: 3, FieldChanged: "status", OldValue: "todo", NewValue: "in-progress", ChangedBy: "alice", CreatedAt: day(10, 10)},
+		{ID: 4, IssueID: 5, FieldChanged: "status", OldValue: "todo", NewValue: "in-progress", ChangedBy: "bob", CreatedAt: day(11, 9)},
+		{ID: 5, IssueID: 3, FieldChanged: "status", OldValue: "in-progress", NewValue: "review", ChangedBy: "alice", CreatedAt: day(12, 9)},
+		{ID: 6, IssueID: 3, FieldChanged: "status", OldValue: "review", NewValue: "done", ChangedBy: "alice", CreatedAt: day(12, 15)},
+		{ID: 7, IssueID: 5, FieldChanged: "priority", OldValue: "medium", NewValue: "high", ChangedBy: "bob", CreatedAt: day(11, 10)},
+	}
+	comments := []*model.Comment{
+		{ID: 1, IssueID: 1, Body: "still flaky", Author: "alice", CreatedAt: day(2, 9)},
+		{ID: 2, IssueID: 3, Body: "ready for review", Author: "alice", CreatedAt: day(12, 9)},
+		{ID: 3, IssueID: 5, Body: "draft attached", Author: "bob", CreatedAt: day(11, 9)},
+	}
+	relations := []*model.Relation{
+		{ID: 1, SourceIssueID: 5, TargetIssueID: 6, RelationType: model.RelationBlocks, CreatedAt: day(10, 10)},
+	}
+
+	tx, err := conn.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	defer tx.Rollback()
+	for _, issue := range issues {
+		if _, err := db.InsertIssueWithID(tx, issue); err != nil {
+			t.Fatalf("InsertIssueWithID: %v", err)
+		}
+	}
+	for _, a := range activity {
+		if _, err := db.InsertActivityWithID(tx, a); err != nil {
+			t.Fatalf("InsertActivityWithID: %v", err)
+		}
+	}
+	for _, c := range comments {
+		if _, err := db.InsertCommentWithID(tx, c); err != nil {
+			t.Fatalf("InsertCommentWithID: %v", err)
+		}
+	}
+	for _, rel := range relations {
+		if _, err := db.InsertRelationWithID(tx, rel); err != nil {
+			t.Fatalf("InsertRelationWithID: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	return conn
+}
+
+// checkGolden compares got with testdata/name, rewriting it under -update.
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s mismatch (run go test -update to refresh)\ngot:\n%s\nwant:\n%s", name, got, want)
+	}
+}
+
+func TestDigestMarkdownGolden(t *testing.T) {
+	conn := seedDigestDB(t)
+	d, err := buildDigest(conn, digestUntil.AddDate(0, 0, -7), digestUntil, digest.DefaultStaleLimit)
+	if err != nil {
+		t.Fatalf("buildDigest: %v", err)
+	}
+	checkGolden(t, "digest.golden.md", digest.Markdown(d))
+}
+
+func TestDigestMarkdownLinksIDs(t *testing.T) {
+	render.SetLinkTemplate("https://tracker.example/%s")
+	t.Cleanup(func() { render.SetLinkTemplate("") })
+
+	conn := seedDigestDB(t)
+	d, err := buildDigest(conn, digestUntil.AddDate(0, 0, -7), digestUntil, 1)
+	if err != nil {
+		t.Fatalf("buildDigest: %v", err)
+	}
+	checkGolden(t, "digest_links.golden.md", digest.Markdown(d))
+}
+
+func TestDigestJSON(t *testing.T) {
+	conn := seedDigestDB(t)
+	d, err := buildDigest(conn, digestUntil.AddDate(0, 0, -7), digestUntil, digest.DefaultStaleLimit)
+	if err != nil {
+		t.Fatalf("buildDigest: %v", err)
+	}
+	out, err := json.MarshalIndent(newDigestResult(d), "", "  ")
+	if err != nil {
+		t.Fatalf("MarshalIndent: %v", err)
+	}
+	checkGolden(t, "digest.golden.json", string(out)+"\n")
+}
+
+func TestDigestWritesFile(t *testing.T) {
+	conn := seedDigestDB(t)
+	path := filepath.Join(t.TempDir(), "WEEKLY.md")
+
+	cmd := cmdWithDB(conn)
+	cmd.Flags().String("since", "7d", "")
+	cmd.Flags().String("file", path, "")
+	cmd.Flags().Int("stale", digest.DefaultStaleLimit, "")
+	w, buf := bufWriter(true)
+	if err := runDigest(cmd, nil, w); err != nil {
+		t.Fatalf("runDigest: %v", err)
+	}
+
+	var env struct {
+		Data digestResult `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("decoding output: %v\n%s", err, buf.String())
+	}
+	if env.Data.File != path {
+		t.Errorf("file = %q, want %q", env.Data.File, path)
+	}
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if len(written) == 0 || written[0] != '#' {
+		t.Errorf("written digest does not start with a heading:\n%s", written)
+	}
+}
+
+func TestDigestRejectsBadSince(t *testing.T) {
+	cmd := cmdWithDB(newTestDB(t))
+	cmd.Flags().String("since", "soon", "")
+	cmd.Flags().String("file", "", "")
+	cmd.Flags().Int("stale", digest.DefaultStaleLimit, "")
+	w, _ := bufWriter(false)
+	if err := runDigest(cmd, nil, w); err == nil {
+		t.Error("runDigest accepted --since soon")
+	}
+}
@@ -36,7 +36,7 @@ var docLinkAddCmd = &cobra.Command{
 		issueArg, _ := cmd.Flags().GetString("issue")
 		issueID, err := model.ParseID(issueArg)
 		if err != nil {
-			return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+			return cmdErr(err, output.ErrValidation)
 		}
 
 		if err := db.LinkDocIssue(conn, docID, issueID); err != nil {
@@ -76,7 +76,7 @@ var docLinkRemoveCmd = &cobra.Command{
 		issueArg, _ := cmd.Flags().GetString("issue")
 		issueID, err := model.ParseID(issueArg)
 		if err != nil {
-			return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+			return cmdErr(err, output.ErrValidation)
 		}
 
 		if err := db.UnlinkDocIssue(conn, docID, issueID); err != nil {
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// doctorResult is the JSON wire format for the doctor command output.
+type doctorResult struct {
+	InvalidEnums      []db.InvalidEnumValue `json:"invalid_enums"`
+	InvalidTimestamps []db.InvalidTimestamp `json:"invalid_timestamps"`
+	Problems          int                   `json:"problems"`
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the database for invalid issue data",
+	Long: `Checks every issue, including those in the trash, for status, priority
+and type values that docket does not recognize. Such issues are skipped by
+status filters and the board. Where a value looks like a misspelling of a
+valid one (e.g. "in_progress"), the likely intended value is suggested; fix
+it with 'docket issue edit'.
+
+It also checks issue, comment and relation timestamps, which must be RFC 3339;
+rows with others, e.g. from a hand-edited database, break every command that
+reads them. --fix-timestamps rewrites those in a recognized format, such as
+"2026-01-05 10:00:00" or Unix seconds, as RFC 3339 in UTC.
+
+--rebuild-stats recomputes the cached sub-issue progress that list and board
+show, which is otherwise kept up to date as issues change.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDoctor(cmd, args, getWriter(cmd))
+	},
+}
+
+func runDoctor(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	if rebuild, _ := cmd.Flags().GetBool("rebuild-stats"); rebuild {
+		if err := requireWritable(cmd); err != nil {
+			return err
+		}
+		if err := db.RebuildSubIssueStats(conn); err != nil {
+			return cmdErr(fmt.Errorf("rebuilding sub-issue stats: %w", err), output.ErrGeneral)
+		}
+		w.Info("Rebuilt sub-issue stats")
+	}
+
+	if fix, _ := cmd.Flags().GetBool("fix-timestamps"); fix {
+		if err := requireWritable(cmd); err != nil {
+			return err
+		}
+		repaired, err := db.RepairTimestamps(conn)
+		if err != nil {
+			return cmdErr(fmt.Errorf("repairing timestamps: %w", err), output.ErrGeneral)
+		}
+		w.Info("Normalized %d timestamp(s)", repaired)
+	}
+
+	invalid, err := db.FindInvalidEnumValues(conn)
+	if err != nil {
+		return cmdErr(fmt.Errorf("checking issues: %w", err), output.ErrGeneral)
+	}
+	if invalid == nil {
+		invalid = []db.InvalidEnumValue{}
+	}
+	timestamps, err := db.FindInvalidTimestamps(conn)
+	if err != nil {
+		return cmdErr(fmt.Errorf("checking timestamps: %w", err), output.ErrGeneral)
+	}
+	if timestamps == nil {
+		timestamps = []db.InvalidTimestamp{}
+	}
+	result := doctorResult{
+		InvalidEnums:      invalid,
+		InvalidTimestamps: timestamps,
+		Problems:          len(invalid) + len(timestamps),
+	}
+
+	if w.JSONMode {
+		w.Success(result, "")
+		return nil
+	}
+	if result.Problems == 0 {
+		w.Success(result, "No problems found")
+		return nil
+	}
+
+	var b strings.Builder
+	if len(invalid) > 0 {
+		fmt.Fprintf(&b, "Found %d invalid value(s):\n", len(invalid))
+		for _, bad := range invalid {
+			fmt.Fprintf(&b, "  %s  %s %q", model.FormatID(bad.IssueID), bad.Field, bad.Value)
+			if bad.Suggestion != "" {
+				fmt.Fprintf(&b, " (did you mean %q?)", bad.Suggestion)
+			}
+			b.WriteString("\n")
+		}
+	}
+	if len(timestamps) > 0 {
+		fmt.Fprintf(&b, "Found %d invalid timestamp(s):\n", len(timestamps))
+		for _, bad := range timestamps {
+			fmt.Fprintf(&b, "  %s %d  %s %q", bad.Table, bad.RowID, bad.Field, bad.Value)
+			if bad.Normalized != "" {
+				fmt.Fprintf(&b, " (--fix-timestamps writes %s)", bad.Normalized)
+			}
+			b.WriteString("\n")
+		}
+	}
+	w.Success(result, strings.TrimRight(b.String(), "\n"))
+	return nil
+}
+
+func init() {
+	doctorCmd.Flags().Bool("rebuild-stats", false, "Recompute cached sub-issue progress before checking")
+	doctorCmd.Flags().Bool("fix-timestamps", false, "Rewrite timestamps in a recognized non-RFC 3339 format as RFC 3339 before checking")
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestDoctorReportsInvalidEnums(t *testing.T) {
+	conn := newTestDB(t)
+	id := createIssue(t, conn, "odd", model.StatusTodo, model.PriorityLow)
+
+	w, buf := bufWriter(false)
+	if err := runDoctor(cmdWithDB(conn), nil, w); err != nil {
+		t.Fatalf("runDoctor: %v", err)
+	}
+	if !strings.Contains(buf.String(), "No problems found") {
+		t.Errorf("clean database output = %q", buf.String())
+	}
+
+	if _, err := conn.Exec(`UPDATE issues SET status = 'in_progress' WHERE id = ?`, id); err != nil {
+		t.Fatalf("corrupting issue: %v", err)
+	}
+	w, buf = bufWriter(false)
+	if err := runDoctor(cmdWithDB(conn), nil, w); err != nil {
+		t.Fatalf("runDoctor: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{model.FormatID(id), `status "in_progress"`, `did you mean "in-progress"?`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDoctorRebuildStats(t *testing.T) {
+	conn := newTestDB(t)
+	parent := createIssue(t, conn, "parent", model.StatusTodo, model.PriorityLow)
+	child := createIssue(t, conn, "child", model.StatusDone, model.PriorityLow)
+	if err := db.UpdateIssue(conn, child, map[string]interface{}{"parent_id": parent}, "t"); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+	if _, err := conn.Exec(`UPDATE sub_issue_stats SET done = 0, total = 5`); err != nil {
+		t.Fatalf("corrupting stats: %v", err)
+	}
+
+	cmd := cmdWithDB(conn)
+	cmd.Flags().Bool("rebuild-stats", true, "")
+	w, _ := bufWriter(false)
+	if err := runDoctor(cmd, nil, w); err != nil {
+		t.Fatalf("runDoctor: %v", err)
+	}
+
+	progress, err := db.GetCachedSubIssueProgress(conn, []int{parent})
+	if err != nil {
+		t.Fatalf("GetCachedSubIssueProgress: %v", err)
+	}
+	if got := progress[parent]; got != [2]int{1, 1} {
+		t.Errorf("progress after rebuild = %v, want [1 1]", got)
+	}
+}
+
+func TestDoctorFixTimestamps(t *testing.T) {
+	conn := newTestDB(t)
+	id := createIssue(t, conn, "hand-edited", model.StatusTodo, model.PriorityLow)
+	if _, err := conn.Exec(`UPDATE issues SET created_at = '2026-01-05 10:00:00', updated_at = 'soon' WHERE id = ?`, id); err != nil {
+		t.Fatalf("corrupting issue: %v", err)
+	}
+
+	w, buf := bufWriter(false)
+	if err := runDoctor(cmdWithDB(conn), nil, w); err != nil {
+		t.Fatalf("runDoctor: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{`issues 1  created_at "2026-01-05 10:00:00" (--fix-timestamps writes 2026-01-05T10:00:00Z)`, `updated_at "soon"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	cmd := cmdWithDB(conn)
+	cmd.Flags().Bool("fix-timestamps", true, "")
+	w, buf = bufWriter(false)
+	if err := runDoctor(cmd, nil, w); err != nil {
+		t.Fatalf("runDoctor --fix-timestamps: %v", err)
+	}
+	// The readable timestamp is rewritten; the other still needs a hand fix.
+	if out := buf.String(); strings.Contains(out, "created_at") || !strings.Contains(out, `updated_at "soon"`) {
+		t.Errorf("output after fixing:\n%s", out)
+	}
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+)
+
+// filterSummary describes an empty issue listing for
+// render.IssuesEmptyState: which of the filter flags named by flags were
+// set, and how many issues the database holds. includeDoneFlag is the flag
+// that would include done issues, or "" when none were left out.
+func filterSummary(cmd *cobra.Command, conn *sql.DB, includeDoneFlag string, flags ...string) (render.FilterSummary, error) {
+	total, err := db.CountIssues(conn)
+	if err != nil {
+		return render.FilterSummary{}, err
+	}
+
+	summary := render.FilterSummary{IncludeDoneFlag: includeDoneFlag, TotalIssues: total}
+	for _, name := range flags {
+		f := cmd.Flags().Lookup(name)
+		if f == nil || !f.Changed {
+			continue
+		}
+		var value string
+		switch f.Value.Type() {
+		case "bool":
+		case "stringSlice":
+			values, _ := cmd.Flags().GetStringSlice(name)
+			value = strings.Join(values, ",")
+		default:
+			value = f.Value.String()
+		}
+		summary.Filters = append(summary.Filters, render.ActiveFilter{Flag: name, Value: value})
+	}
+	return summary, nil
+}
+
+// emptyIssuesMessage returns the empty state for an issue listing that
+// found nothing, built by filterSummary from cmd's filter flags.
+func emptyIssuesMessage(cmd *cobra.Command, conn *sql.DB, message, includeDoneFlag string, flags ...string) (string, error) {
+	summary, err := filterSummary(cmd, conn, includeDoneFlag, flags...)
+	if err != nil {
+		return "", cmdErr(err, output.ErrGeneral)
+	}
+	return render.IssuesEmptyState(message, summary), nil
+}
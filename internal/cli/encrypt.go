@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/ALT-F4-LLC/docket/internal/cryptofile"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// readPassphrase returns the passphrase for --encrypt or --decrypt: the
+// contents of --passphrase-file without its trailing newline, or else one
+// typed at a terminal prompt. With confirm set the prompt asks twice, so a
+// typo cannot seal an export nobody can open.
+func readPassphrase(cmd *cobra.Command, confirm bool) ([]byte, error) {
+	if path, _ := cmd.Flags().GetString("passphrase-file"); path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, cmdErr(fmt.Errorf("reading passphrase file: %w", err), output.ErrGeneral)
+		}
+		passphrase := []byte(strings.TrimRight(string(raw), "\r\n"))
+		if len(passphrase) == 0 {
+			return nil, cmdErr(fmt.Errorf("passphrase file %s is empty", path), output.ErrValidation)
+		}
+		return passphrase, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, cmdErr(fmt.Errorf("non-interactive environment detected; use --passphrase-file"), output.ErrValidation)
+	}
+	fmt.Fprint(os.Stderr, "Passphrase: ")
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, cmdErr(fmt.Errorf("reading passphrase: %w", err), output.ErrGeneral)
+	}
+	if len(passphrase) == 0 {
+		return nil, cmdErr(fmt.Errorf("passphrase must not be empty"), output.ErrValidation)
+	}
+	if confirm {
+		fmt.Fprint(os.Stderr, "Confirm passphrase: ")
+		again, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, cmdErr(fmt.Errorf("reading passphrase: %w", err), output.ErrGeneral)
+		}
+		if !bytes.Equal(passphrase, again) {
+			return nil, cmdErr(fmt.Errorf("passphrases do not match"), output.ErrValidation)
+		}
+	}
+	return passphrase, nil
+}
+
+// writeSealedExport encrypts a rendered export with passphrase, gzipping it
+// first when compress is set, and writes it to filePath, or to stdout when
+// it is empty.
+func writeSealedExport(filePath string, compress bool, raw string, passphrase []byte) error {
+	payload := []byte(raw)
+	if compress {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		if _, err := io.WriteString(gz, raw); err != nil {
+			return cmdErr(fmt.Errorf("compressing export: %w", err), output.ErrGeneral)
+		}
+		if err := gz.Close(); err != nil {
+			return cmdErr(fmt.Errorf("compressing export: %w", err), output.ErrGeneral)
+		}
+		payload = buf.Bytes()
+	}
+
+	sealed, err := cryptofile.Seal(payload, passphrase)
+	if err != nil {
+		return cmdErr(fmt.Errorf("encrypting export: %w", err), output.ErrGeneral)
+	}
+	// The compression, if any, is inside the envelope.
+	return writeExport(filePath, false, string(sealed))
+}
+
+// openSealedImport decrypts an import file read by readImportFile when it is
+// encrypted, and returns it unchanged otherwise. An encrypted file is only
+// opened with --decrypt; a wrong passphrase is a validation error.
+func openSealedImport(cmd *cobra.Command, data []byte) ([]byte, error) {
+	if !cryptofile.IsSealed(data) {
+		return data, nil
+	}
+	if decrypt, _ := cmd.Flags().GetBool("decrypt"); !decrypt {
+		return nil, cmdErr(fmt.Errorf("file is encrypted; use --decrypt"), output.ErrValidation)
+	}
+
+	passphrase, err := readPassphrase(cmd, false)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := cryptofile.Open(data, passphrase)
+	if err != nil {
+		if errors.Is(err, cryptofile.ErrDecrypt) || errors.Is(err, cryptofile.ErrNotSealed) {
+			return nil, cmdErr(fmt.Errorf("decrypting file: %w", err), output.ErrValidation)
+		}
+		return nil, cmdErr(fmt.Errorf("decrypting file: %w", err), output.ErrGeneral)
+	}
+
+	if !bytes.HasPrefix(plaintext, []byte{0x1f, 0x8b}) {
+		return plaintext, nil
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(plaintext))
+	if err != nil {
+		return nil, cmdErr(fmt.Errorf("decompressing file: %w", err), output.ErrValidation)
+	}
+	defer gz.Close()
+	plaintext, err = io.ReadAll(gz)
+	if err != nil {
+		return nil, cmdErr(fmt.Errorf("decompressing file: %w", err), output.ErrValidation)
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/cryptofile"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func writePassphraseFile(t *testing.T, passphrase string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "passphrase")
+	if err := os.WriteFile(path, []byte(passphrase+"\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func encryptedImportCmd(conn *sql.DB, passphraseFile string) *cobra.Command {
+	imp := cmdWithDB(conn)
+	imp.Flags().Bool("merge", false, "")
+	imp.Flags().Bool("replace", false, "")
+	imp.Flags().String("format", "", "")
+	imp.Flags().Bool("decrypt", false, "")
+	imp.Flags().String("passphrase-file", "", "")
+	imp.Flags().Set("json", "true")
+	if passphraseFile != "" {
+		imp.Flags().Set("decrypt", "true")
+		imp.Flags().Set("passphrase-file", passphraseFile)
+	}
+	return imp
+}
+
+func TestEncryptedExportImportRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name, file string
+	}{
+		{"plain", "export.json.enc"},
+		{"gzipped", "export.json.gz"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			src := newTestDB(t)
+			seedJSONLFixture(t, src)
+			passFile := writePassphraseFile(t, "incident 42")
+
+			cmd := cmdWithDB(src)
+			cmd.Flags().StringP("format", "o", "json", "")
+			cmd.Flags().StringP("file", "f", "", "")
+			cmd.Flags().Bool("gzip", false, "")
+			cmd.Flags().Bool("encrypt", false, "")
+			cmd.Flags().String("passphrase-file", "", "")
+			path := filepath.Join(t.TempDir(), tc.file)
+			cmd.Flags().Set("file", path)
+			cmd.Flags().Set("encrypt", "true")
+			cmd.Flags().Set("passphrase-file", passFile)
+			if err := exportCmd.RunE(cmd, nil); err != nil {
+				t.Fatalf("exportCmd.RunE: %v", err)
+			}
+
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("ReadFile: %v", err)
+			}
+			if !cryptofile.IsSealed(raw) {
+				t.Fatalf("export is not encrypted: %q", raw[:min(len(raw), 16)])
+			}
+			if bytes.Contains(raw, []byte(`"issues"`)) {
+				t.Error("encrypted export contains plaintext JSON")
+			}
+
+			dst := newTestDB(t)
+			if err := importCmd.RunE(encryptedImportCmd(dst, passFile), []string{path}); err != nil {
+				t.Fatalf("importCmd.RunE: %v", err)
+			}
+			if want, got := snapshotDB(t, src), snapshotDB(t, dst); want != got {
+				t.Errorf("encrypted round trip differs\nsource:   %s\nimported: %s", want, got)
+			}
+		})
+	}
+}
+
+func TestEncryptedImportErrors(t *testing.T) {
+	src := newTestDB(t)
+	seedJSONLFixture(t, src)
+	path := filepath.Join(t.TempDir(), "export.json")
+
+	cmd := cmdWithDB(src)
+	cmd.Flags().StringP("format", "o", "json", "")
+	cmd.Flags().StringP("file", "f", "", "")
+	cmd.Flags().Bool("encrypt", false, "")
+	cmd.Flags().String("passphrase-file", "", "")
+	cmd.Flags().Set("file", path)
+	cmd.Flags().Set("encrypt", "true")
+	cmd.Flags().Set("passphrase-file", writePassphraseFile(t, "right"))
+	if err := exportCmd.RunE(cmd, nil); err != nil {
+		t.Fatalf("exportCmd.RunE: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		passFile string
+	}{
+		{"wrong passphrase", writePassphraseFile(t, "wrong")},
+		{"without --decrypt", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := newTestDB(t)
+			err := importCmd.RunE(encryptedImportCmd(dst, tt.passFile), []string{path})
+			var ce *CmdError
+			if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+				t.Fatalf("importCmd.RunE error = %v, want a validation error", err)
+			}
+			if snapshotDB(t, dst) != snapshotDB(t, newTestDB(t)) {
+				t.Error("failed import changed the database")
+			}
+		})
+	}
+}
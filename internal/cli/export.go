@@ -1,22 +1,29 @@
 package cli
 
 import (
+	"cmp"
+	"database/sql"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/ALT-F4-LLC/docket/internal/db"
 	"github.com/ALT-F4-LLC/docket/internal/model"
 	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
 	"github.com/spf13/cobra"
 )
 
 var exportCmd = &cobra.Command{
 	Use:   "export",
-	Short: "Export issues to JSON, CSV, or Markdown",
+	Short: "Export issues to JSON, JSON Lines, CSV, or Markdown",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		conn := getDB(cmd)
 
@@ -24,310 +31,112 @@ var exportCmd = &cobra.Command{
 		filePath, _ := cmd.Flags().GetString("file")
 		statuses, _ := cmd.Flags().GetStringSlice("status")
 		labels, _ := cmd.Flags().GetStringSlice("label")
+		compress, _ := cmd.Flags().GetBool("gzip")
+		compress = compress || hasGzipExt(filePath)
 
 		// Validate format.
 		switch format {
-		case "json", "csv", "markdown":
+		case "json", "jsonl", "csv", "markdown":
 		default:
 			return cmdErr(
-				fmt.Errorf("invalid format %q: must be one of json, csv, markdown", format),
+				fmt.Errorf("invalid format %q: must be one of json, jsonl, csv, markdown", format),
 				output.ErrValidation,
 			)
 		}
 
-		// Validate filter enum values.
-		for _, s := range statuses {
-			if err := model.ValidateStatus(model.Status(s)); err != nil {
-				return cmdErr(err, output.ErrValidation)
-			}
+		// Column selection and delimiter only shape CSV output.
+		columns, _ := cmd.Flags().GetStringSlice("columns")
+		delimiterFlag, _ := cmd.Flags().GetString("delimiter")
+		if format != "csv" && (cmd.Flags().Changed("columns") || cmd.Flags().Changed("delimiter")) {
+			return cmdErr(fmt.Errorf("--columns and --delimiter require --format csv"), output.ErrValidation)
 		}
-
-		// Fetch all data.
-		issues, err := db.ListAllIssues(conn)
-		if err != nil {
-			return cmdErr(fmt.Errorf("fetching issues: %w", err), output.ErrGeneral)
+		if err := validateCSVColumns(columns); err != nil {
+			return cmdErr(err, output.ErrValidation)
 		}
-
-		comments, err := db.ListAllComments(conn)
+		delimiter, err := parseCSVDelimiter(delimiterFlag)
 		if err != nil {
-			return cmdErr(fmt.Errorf("fetching comments: %w", err), output.ErrGeneral)
+			return cmdErr(err, output.ErrValidation)
 		}
 
-		relations, err := db.GetAllRelations(conn)
-		if err != nil {
-			return cmdErr(fmt.Errorf("fetching relations: %w", err), output.ErrGeneral)
+		// Date layout only shapes the text formats; JSON keeps RFC3339.
+		dateFlag, _ := cmd.Flags().GetString("date-format")
+		if format != "csv" && format != "markdown" && cmd.Flags().Changed("date-format") {
+			return cmdErr(fmt.Errorf("--date-format requires --format csv or markdown"), output.ErrValidation)
 		}
-
-		allLabels, err := db.ListAllLabelsRaw(conn)
-		if err != nil {
-			return cmdErr(fmt.Errorf("fetching labels: %w", err), output.ErrGeneral)
-		}
-
-		mappings, err := db.ListAllIssueLabelMappings(conn)
+		formatDate, err := parseDateFormat(dateFlag)
 		if err != nil {
-			return cmdErr(fmt.Errorf("fetching label mappings: %w", err), output.ErrGeneral)
+			return cmdErr(err, output.ErrValidation)
 		}
 
-		fileMappings, err := db.ListAllIssueFileMappings(conn)
-		if err != nil {
-			return cmdErr(fmt.Errorf("fetching file mappings: %w", err), output.ErrGeneral)
+		// Validate filter enum values.
+		for _, s := range statuses {
+			if err := model.ValidateStatus(model.Status(s)); err != nil {
+				return cmdErr(err, output.ErrValidation)
+			}
 		}
 
-		activityLog, err := db.ListAllActivity(conn)
-		if err != nil {
-			return cmdErr(fmt.Errorf("fetching activity log: %w", err), output.ErrGeneral)
+		stable, _ := cmd.Flags().GetBool("stable")
+		if stable && format != "json" {
+			return cmdErr(fmt.Errorf("--stable requires --format json"), output.ErrValidation)
 		}
 
-		docs, err := db.ListAllDocs(conn)
-		if err != nil {
-			return cmdErr(fmt.Errorf("fetching docs: %w", err), output.ErrGeneral)
+		issuesOnly, _ := cmd.Flags().GetBool("issues-only")
+		if issuesOnly && format != "jsonl" {
+			return cmdErr(fmt.Errorf("--issues-only requires --format jsonl"), output.ErrValidation)
 		}
 
-		docRevisions, err := db.ListAllDocRevisions(conn)
-		if err != nil {
-			return cmdErr(fmt.Errorf("fetching doc revisions: %w", err), output.ErrGeneral)
+		var since time.Time
+		if sinceFlag, _ := cmd.Flags().GetString("since"); sinceFlag != "" {
+			if format != "json" {
+				return cmdErr(fmt.Errorf("--since requires --format json"), output.ErrValidation)
+			}
+			since, err = time.Parse(time.RFC3339, sinceFlag)
+			if err != nil {
+				return cmdErr(fmt.Errorf("invalid --since %q: must be an RFC 3339 timestamp", sinceFlag), output.ErrValidation)
+			}
 		}
 
-		docComments, err := db.ListAllDocComments(conn)
-		if err != nil {
-			return cmdErr(fmt.Errorf("fetching doc comments: %w", err), output.ErrGeneral)
+		encrypt, _ := cmd.Flags().GetBool("encrypt")
+		if encrypt && format != "json" {
+			return cmdErr(fmt.Errorf("--encrypt requires --format json"), output.ErrValidation)
 		}
-
-		docIssueLinks, err := db.ListAllDocIssueLinks(conn)
-		if err != nil {
-			return cmdErr(fmt.Errorf("fetching doc-issue links: %w", err), output.ErrGeneral)
+		if !encrypt && cmd.Flags().Changed("passphrase-file") {
+			return cmdErr(fmt.Errorf("--passphrase-file requires --encrypt"), output.ErrValidation)
 		}
-
-		proposalDocs, err := db.ListAllProposalDocs(conn)
-		if err != nil {
-			return cmdErr(fmt.Errorf("fetching proposal-doc links: %w", err), output.ErrGeneral)
+		var passphrase []byte
+		if encrypt {
+			if passphrase, err = readPassphrase(cmd, true); err != nil {
+				return err
+			}
 		}
 
-		proposals, err := db.ListAllProposals(conn)
-		if err != nil {
-			return cmdErr(fmt.Errorf("fetching proposals: %w", err), output.ErrGeneral)
+		// JSON Lines streams straight from the database instead of building
+		// the export in memory.
+		if format == "jsonl" {
+			return exportJSONL(conn, filePath, compress, statuses, labels, issuesOnly)
 		}
 
-		votes, err := db.ListAllVotes(conn)
-		if err != nil {
-			return cmdErr(fmt.Errorf("fetching votes: %w", err), output.ErrGeneral)
+		// Markdown needs only issues and their comments.
+		if format == "markdown" {
+			return exportMarkdown(conn, filePath, compress, statuses, labels, formatDate)
 		}
 
-		proposalIssues, err := db.ListAllProposalIssues(conn)
+		data, err := loadExportData(conn)
 		if err != nil {
-			return cmdErr(fmt.Errorf("fetching proposal-issue links: %w", err), output.ErrGeneral)
+			return cmdErr(err, output.ErrGeneral)
 		}
 
 		// Apply filters if provided.
 		if len(statuses) > 0 || len(labels) > 0 {
-			issues = filterIssues(issues, statuses, labels)
-
-			// Build set of filtered issue IDs.
-			issueIDs := make(map[int]bool, len(issues))
-			for _, issue := range issues {
-				issueIDs[issue.ID] = true
-			}
-
-			// Filter comments to only those belonging to filtered issues.
-			filtered := make([]*model.Comment, 0, len(comments))
-			for _, c := range comments {
-				if issueIDs[c.IssueID] {
-					filtered = append(filtered, c)
-				}
-			}
-			comments = filtered
-
-			// Filter relations to only those where both sides are in the filtered set.
-			filteredRels := make([]model.Relation, 0, len(relations))
-			for _, r := range relations {
-				if issueIDs[r.SourceIssueID] && issueIDs[r.TargetIssueID] {
-					filteredRels = append(filteredRels, r)
-				}
-			}
-			relations = filteredRels
-
-			// Filter label mappings to only those for filtered issues.
-			filteredMappings := make([]model.IssueLabelMapping, 0, len(mappings))
-			for _, m := range mappings {
-				if issueIDs[m.IssueID] {
-					filteredMappings = append(filteredMappings, m)
-				}
-			}
-			mappings = filteredMappings
-
-			// Filter file mappings to only those for filtered issues.
-			filteredFileMappings := make([]model.IssueFileMapping, 0, len(fileMappings))
-			for _, m := range fileMappings {
-				if issueIDs[m.IssueID] {
-					filteredFileMappings = append(filteredFileMappings, m)
-				}
-			}
-			fileMappings = filteredFileMappings
-
-			// Filter activity log to only entries for filtered issues.
-			filteredActivity := make([]*model.Activity, 0, len(activityLog))
-			for _, a := range activityLog {
-				if issueIDs[a.IssueID] {
-					filteredActivity = append(filteredActivity, a)
-				}
-			}
-			activityLog = filteredActivity
-
-			// Filter doc-issue links to only those whose issue survives the filter.
-			filteredDocIssueLinks := make([]model.DocIssueLink, 0, len(docIssueLinks))
-			for _, l := range docIssueLinks {
-				if issueIDs[l.IssueID] {
-					filteredDocIssueLinks = append(filteredDocIssueLinks, l)
-				}
-			}
-			docIssueLinks = filteredDocIssueLinks
-
-			// Filter proposal-issue links to only those whose issue survives the filter.
-			filteredProposalIssues := make([]model.ProposalIssueLink, 0, len(proposalIssues))
-			for _, l := range proposalIssues {
-				if issueIDs[l.IssueID] {
-					filteredProposalIssues = append(filteredProposalIssues, l)
-				}
-			}
-			proposalIssues = filteredProposalIssues
-
-			survivingDocIDs := make(map[int]bool, len(docIssueLinks))
-			for _, l := range docIssueLinks {
-				survivingDocIDs[l.DocID] = true
-			}
-			filteredDocs := make([]*model.Doc, 0, len(docs))
-			for _, d := range docs {
-				if survivingDocIDs[d.ID] {
-					filteredDocs = append(filteredDocs, d)
-				}
-			}
-			docs = filteredDocs
-
-			filteredDocRevisions := make([]*model.DocRevision, 0, len(docRevisions))
-			for _, r := range docRevisions {
-				if survivingDocIDs[r.DocID] {
-					filteredDocRevisions = append(filteredDocRevisions, r)
-				}
-			}
-			docRevisions = filteredDocRevisions
-
-			filteredDocComments := make([]*model.DocComment, 0, len(docComments))
-			for _, c := range docComments {
-				if survivingDocIDs[c.DocID] {
-					filteredDocComments = append(filteredDocComments, c)
-				}
-			}
-			docComments = filteredDocComments
-
-			survivingProposalIDs := make(map[int]bool, len(proposalIssues))
-			for _, l := range proposalIssues {
-				survivingProposalIDs[l.ProposalID] = true
-			}
-			filteredProposals := make([]*model.Proposal, 0, len(proposals))
-			for _, p := range proposals {
-				if survivingProposalIDs[p.ID] {
-					filteredProposals = append(filteredProposals, p)
-				}
-			}
-			proposals = filteredProposals
-
-			filteredVotes := make([]*model.Vote, 0, len(votes))
-			for _, v := range votes {
-				if survivingProposalIDs[v.ProposalID] {
-					filteredVotes = append(filteredVotes, v)
-				}
-			}
-			votes = filteredVotes
-
-			filteredProposalDocs := make([]model.ProposalDocLink, 0, len(proposalDocs))
-			for _, l := range proposalDocs {
-				if survivingProposalIDs[l.ProposalID] && survivingDocIDs[l.DocID] {
-					filteredProposalDocs = append(filteredProposalDocs, l)
-				}
-			}
-			proposalDocs = filteredProposalDocs
-
-			// Filter labels to only those referenced by remaining mappings.
-			usedLabelIDs := make(map[int]bool)
-			for _, m := range mappings {
-				usedLabelIDs[m.LabelID] = true
-			}
-			filteredLabels := make([]*model.Label, 0, len(allLabels))
-			for _, l := range allLabels {
-				if usedLabelIDs[l.ID] {
-					filteredLabels = append(filteredLabels, l)
-				}
-			}
-			allLabels = filteredLabels
-		}
-
-		// Build export data.
-		data := model.ExportData{
-			Version:            1,
-			ExportedAt:         time.Now().UTC().Format(time.RFC3339),
-			Issues:             issues,
-			Comments:           comments,
-			Relations:          relations,
-			Labels:             allLabels,
-			IssueLabelMappings: mappings,
-			IssueFileMappings:  fileMappings,
-			ActivityLog:        activityLog,
-			Docs:               docs,
-			DocRevisions:       docRevisions,
-			DocComments:        docComments,
-			DocIssueLinks:      docIssueLinks,
-			Proposals:          proposals,
-			Votes:              votes,
-			ProposalIssues:     proposalIssues,
-			ProposalDocs:       proposalDocs,
-		}
-
-		// Ensure nil slices become empty arrays in JSON.
-		if data.Issues == nil {
-			data.Issues = []*model.Issue{}
+			restrictExportToIssues(&data, filterIssues(data.Issues, statuses, labels))
 		}
-		if data.Comments == nil {
-			data.Comments = []*model.Comment{}
+		if stable {
+			data.ExportedAt = ""
 		}
-		if data.Relations == nil {
-			data.Relations = []model.Relation{}
-		}
-		if data.Labels == nil {
-			data.Labels = []*model.Label{}
-		}
-		if data.IssueLabelMappings == nil {
-			data.IssueLabelMappings = []model.IssueLabelMapping{}
-		}
-		if data.IssueFileMappings == nil {
-			data.IssueFileMappings = []model.IssueFileMapping{}
-		}
-		if data.ActivityLog == nil {
-			data.ActivityLog = []*model.Activity{}
-		}
-		if data.Docs == nil {
-			data.Docs = []*model.Doc{}
-		}
-		if data.DocRevisions == nil {
-			data.DocRevisions = []*model.DocRevision{}
-		}
-		if data.DocComments == nil {
-			data.DocComments = []*model.DocComment{}
-		}
-		if data.DocIssueLinks == nil {
-			data.DocIssueLinks = []model.DocIssueLink{}
-		}
-		if data.Proposals == nil {
-			data.Proposals = []*model.Proposal{}
-		}
-		if data.Votes == nil {
-			data.Votes = []*model.Vote{}
-		}
-		if data.ProposalIssues == nil {
-			data.ProposalIssues = []model.ProposalIssueLink{}
-		}
-		if data.ProposalDocs == nil {
-			data.ProposalDocs = []model.ProposalDocLink{}
+		if !since.IsZero() {
+			filterExportSince(&data, since)
 		}
+		fillEmptyExportSlices(&data)
 
 		// Generate output based on format.
 		var raw string
@@ -335,65 +144,357 @@ var exportCmd = &cobra.Command{
 		case "json":
 			raw, err = renderExportJSON(data)
 		case "csv":
-			raw, err = renderExportCSV(issues)
-		case "markdown":
-			raw, err = renderExportMarkdown(issues, comments)
+			raw, err = renderExportCSV(data.Issues, columns, delimiter, formatDate)
 		}
 		if err != nil {
 			return cmdErr(fmt.Errorf("rendering export: %w", err), output.ErrGeneral)
 		}
+		if encrypt {
+			return writeSealedExport(filePath, compress, raw, passphrase)
+		}
+		return writeExport(filePath, compress, raw)
+	},
+}
 
-		// Write to file or stdout.
-		if filePath != "" {
-			if err := os.WriteFile(filePath, []byte(raw), 0o644); err != nil {
-				return cmdErr(fmt.Errorf("writing file: %w", err), output.ErrGeneral)
-			}
-			fmt.Fprintf(os.Stderr, "Exported to %s\n", filePath)
-			return nil
+// writeExport writes a rendered export to filePath, or to stdout when it is
+// empty.
+func writeExport(filePath string, compress bool, raw string) error {
+	out, err := createExportOutput(filePath, compress)
+	if err != nil {
+		return cmdErr(fmt.Errorf("writing file: %w", err), output.ErrGeneral)
+	}
+	if _, err := io.WriteString(out, raw); err != nil {
+		out.Close()
+		return cmdErr(fmt.Errorf("writing export: %w", err), output.ErrGeneral)
+	}
+	if err := out.Close(); err != nil {
+		return cmdErr(fmt.Errorf("writing export: %w", err), output.ErrGeneral)
+	}
+	if filePath != "" {
+		fmt.Fprintf(os.Stderr, "Exported to %s\n", filePath)
+	}
+	return nil
+}
+
+// exportMarkdown writes the Markdown export. With a status or label filter,
+// comments are fetched for the matching issues only rather than for the
+// whole database, and only relations between matching issues are shown.
+func exportMarkdown(conn *sql.DB, filePath string, compress bool, statuses, labels []string, formatDate dateFormatter) error {
+	issues, err := db.ListAllIssues(conn)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching issues: %w", err), output.ErrGeneral)
+	}
+
+	var comments map[int][]*model.Comment
+	if len(statuses) > 0 || len(labels) > 0 {
+		issues = filterIssues(issues, statuses, labels)
+		ids := make([]int, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.ID
 		}
+		comments, err = db.GetCommentsByIssueIDs(conn, ids)
+	} else {
+		comments, err = commentsByIssue(conn)
+	}
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching comments: %w", err), output.ErrGeneral)
+	}
+
+	relations, err := db.GetAllRelations(conn)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching relations: %w", err), output.ErrGeneral)
+	}
+	issueIDs := make(map[int]bool, len(issues))
+	for _, issue := range issues {
+		issueIDs[issue.ID] = true
+	}
+	relations = slices.DeleteFunc(relations, func(r model.Relation) bool {
+		return !issueIDs[r.SourceIssueID] || !issueIDs[r.TargetIssueID]
+	})
+
+	raw, err := renderExportMarkdown(issues, comments, relations, formatDate)
+	if err != nil {
+		return cmdErr(fmt.Errorf("rendering export: %w", err), output.ErrGeneral)
+	}
+	return writeExport(filePath, compress, raw)
+}
 
-		fmt.Fprint(os.Stdout, raw)
+// commentsByIssue returns every live issue's comments keyed by issue ID, in
+// chronological order.
+func commentsByIssue(conn *sql.DB) (map[int][]*model.Comment, error) {
+	byIssue := make(map[int][]*model.Comment)
+	err := db.StreamComments(conn, func(c *model.Comment) error {
+		byIssue[c.IssueID] = append(byIssue[c.IssueID], c)
 		return nil
-	},
+	})
+	return byIssue, err
 }
 
 func init() {
-	exportCmd.Flags().StringP("format", "o", "json", "Export format: json, csv, markdown")
-	exportCmd.Flags().StringP("file", "f", "", "Output file path (default: stdout)")
+	exportCmd.Flags().StringP("format", "o", "json", "Export format: json, jsonl, csv, markdown")
+	exportCmd.Flags().StringP("file", "f", "", "Output file path (default: stdout); a .gz path implies --gzip")
+	exportCmd.Flags().Bool("gzip", false, "Gzip-compress the output")
 	exportCmd.Flags().StringSliceP("status", "s", nil, "Filter by status (repeatable)")
 	exportCmd.Flags().StringSliceP("label", "l", nil, "Filter by label (OR, repeatable)")
+	exportCmd.Flags().StringSlice("columns", nil, "CSV columns to emit, in order (default: all)")
+	exportCmd.Flags().String("delimiter", ",", "CSV field delimiter (use \\t for tab)")
+	exportCmd.Flags().String("date-format", "rfc3339", "Timestamp layout for CSV and Markdown: rfc3339, date, datetime, unix, or a Go layout")
+	exportCmd.Flags().Bool("stable", false, "With --format json, omit exported_at so unchanged databases export identically")
+	exportCmd.Flags().Bool("issues-only", false, "With --format jsonl, write one issue object per line with its labels and files inlined")
+	exportCmd.Flags().String("since", "", "With --format json, export only issues updated and comments and relations created after this RFC 3339 time")
+	exportCmd.Flags().Bool("encrypt", false, "With --format json, encrypt the export with a passphrase (AES-256-GCM)")
+	exportCmd.Flags().String("passphrase-file", "", "Read the --encrypt passphrase from this file instead of prompting")
 	rootCmd.AddCommand(exportCmd)
 }
 
-// filterIssues returns issues matching the given status and label filters.
-func filterIssues(issues []*model.Issue, statuses, labels []string) []*model.Issue {
-	statusSet := make(map[string]bool, len(statuses))
-	for _, s := range statuses {
-		statusSet[s] = true
+// exportJSONL writes a streaming jsonl export to filePath, or to stdout when
+// filePath is empty, gzip-compressed when compress is set. With issuesOnly,
+// each line is a bare issue object rather than a typed record.
+func exportJSONL(conn *sql.DB, filePath string, compress bool, statuses, labels []string, issuesOnly bool) error {
+	out, err := createExportOutput(filePath, compress)
+	if err != nil {
+		return cmdErr(fmt.Errorf("writing file: %w", err), output.ErrGeneral)
+	}
+	write := writeExportJSONL
+	if issuesOnly {
+		write = writeIssuesJSONL
+	}
+	if err := write(out, conn, statuses, labels); err != nil {
+		out.Close()
+		return cmdErr(fmt.Errorf("rendering export: %w", err), output.ErrGeneral)
 	}
-	labelSet := make(map[string]bool, len(labels))
-	for _, l := range labels {
-		labelSet[l] = true
+	if err := out.Close(); err != nil {
+		return cmdErr(fmt.Errorf("writing file: %w", err), output.ErrGeneral)
 	}
+	if filePath != "" {
+		fmt.Fprintf(os.Stderr, "Exported to %s\n", filePath)
+	}
+	return nil
+}
 
-	filtered := make([]*model.Issue, 0, len(issues))
+// loadExportData reads everything a full JSON export holds from the
+// database.
+func loadExportData(conn *sql.DB) (model.ExportData, error) {
+	data := model.ExportData{
+		Version:    1,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	var err error
+	if data.Issues, err = db.ListAllIssues(conn); err != nil {
+		return data, fmt.Errorf("fetching issues: %w", err)
+	}
+	if data.Milestones, err = db.ListAllMilestones(conn); err != nil {
+		return data, fmt.Errorf("fetching milestones: %w", err)
+	}
+	if data.Comments, err = db.ListAllComments(conn); err != nil {
+		return data, fmt.Errorf("fetching comments: %w", err)
+	}
+	if data.RelationTypes, err = db.ListCustomRelationTypes(conn); err != nil {
+		return data, fmt.Errorf("fetching relation types: %w", err)
+	}
+	if data.Relations, err = db.GetAllRelations(conn); err != nil {
+		return data, fmt.Errorf("fetching relations: %w", err)
+	}
+	if data.Labels, err = db.ListAllLabelsRaw(conn); err != nil {
+		return data, fmt.Errorf("fetching labels: %w", err)
+	}
+	if data.IssueLabelMappings, err = db.ListAllIssueLabelMappings(conn); err != nil {
+		return data, fmt.Errorf("fetching label mappings: %w", err)
+	}
+	if data.IssueFileMappings, err = db.ListAllIssueFileMappings(conn); err != nil {
+		return data, fmt.Errorf("fetching file mappings: %w", err)
+	}
+	if data.IssueLinks, err = db.ListAllIssueLinks(conn); err != nil {
+		return data, fmt.Errorf("fetching issue links: %w", err)
+	}
+	if data.ActivityLog, err = db.ListAllActivity(conn); err != nil {
+		return data, fmt.Errorf("fetching activity log: %w", err)
+	}
+	if data.Docs, err = db.ListAllDocs(conn); err != nil {
+		return data, fmt.Errorf("fetching docs: %w", err)
+	}
+	if data.DocRevisions, err = db.ListAllDocRevisions(conn); err != nil {
+		return data, fmt.Errorf("fetching doc revisions: %w", err)
+	}
+	if data.DocComments, err = db.ListAllDocComments(conn); err != nil {
+		return data, fmt.Errorf("fetching doc comments: %w", err)
+	}
+	if data.DocIssueLinks, err = db.ListAllDocIssueLinks(conn); err != nil {
+		return data, fmt.Errorf("fetching doc-issue links: %w", err)
+	}
+	if data.ProposalDocs, err = db.ListAllProposalDocs(conn); err != nil {
+		return data, fmt.Errorf("fetching proposal-doc links: %w", err)
+	}
+	if data.Proposals, err = db.ListAllProposals(conn); err != nil {
+		return data, fmt.Errorf("fetching proposals: %w", err)
+	}
+	if data.Votes, err = db.ListAllVotes(conn); err != nil {
+		return data, fmt.Errorf("fetching votes: %w", err)
+	}
+	if data.ProposalIssues, err = db.ListAllProposalIssues(conn); err != nil {
+		return data, fmt.Errorf("fetching proposal-issue links: %w", err)
+	}
+	return data, nil
+}
+
+// restrictExportToIssues narrows data to the given issues. Comments, label,
+// file, link and activity rows are kept only for those issues, relations
+// only when both ends remain, and docs and proposals only while linked to a
+// remaining issue, along with their revisions, comments and votes. Labels
+// and milestones are kept only when still referenced.
+func restrictExportToIssues(data *model.ExportData, issues []*model.Issue) {
+	data.Issues = issues
+
+	issueIDs := make(map[int]bool, len(issues))
+	usedMilestoneIDs := make(map[int]bool)
 	for _, issue := range issues {
-		if len(statusSet) > 0 && !statusSet[string(issue.Status)] {
-			continue
+		issueIDs[issue.ID] = true
+		if issue.MilestoneID != nil {
+			usedMilestoneIDs[*issue.MilestoneID] = true
 		}
-		if len(labelSet) > 0 {
-			hasAny := false
-			for _, il := range issue.Labels {
-				if labelSet[il] {
-					hasAny = true
-					break
-				}
-			}
-			if !hasAny {
-				continue
-			}
+	}
+
+	data.Comments = slices.DeleteFunc(data.Comments, func(c *model.Comment) bool { return !issueIDs[c.IssueID] })
+	data.Relations = slices.DeleteFunc(data.Relations, func(r model.Relation) bool {
+		return !issueIDs[r.SourceIssueID] || !issueIDs[r.TargetIssueID]
+	})
+	data.IssueLabelMappings = slices.DeleteFunc(data.IssueLabelMappings, func(m model.IssueLabelMapping) bool { return !issueIDs[m.IssueID] })
+	data.IssueFileMappings = slices.DeleteFunc(data.IssueFileMappings, func(m model.IssueFileMapping) bool { return !issueIDs[m.IssueID] })
+	data.IssueLinks = slices.DeleteFunc(data.IssueLinks, func(l model.IssueLink) bool { return !issueIDs[l.IssueID] })
+	data.ActivityLog = slices.DeleteFunc(data.ActivityLog, func(a *model.Activity) bool { return !issueIDs[a.IssueID] })
+	data.DocIssueLinks = slices.DeleteFunc(data.DocIssueLinks, func(l model.DocIssueLink) bool { return !issueIDs[l.IssueID] })
+	data.ProposalIssues = slices.DeleteFunc(data.ProposalIssues, func(l model.ProposalIssueLink) bool { return !issueIDs[l.IssueID] })
+
+	docIDs := make(map[int]bool, len(data.DocIssueLinks))
+	for _, l := range data.DocIssueLinks {
+		docIDs[l.DocID] = true
+	}
+	data.Docs = slices.DeleteFunc(data.Docs, func(d *model.Doc) bool { return !docIDs[d.ID] })
+	data.DocRevisions = slices.DeleteFunc(data.DocRevisions, func(r *model.DocRevision) bool { return !docIDs[r.DocID] })
+	data.DocComments = slices.DeleteFunc(data.DocComments, func(c *model.DocComment) bool { return !docIDs[c.DocID] })
+
+	proposalIDs := make(map[int]bool, len(data.ProposalIssues))
+	for _, l := range data.ProposalIssues {
+		proposalIDs[l.ProposalID] = true
+	}
+	data.Proposals = slices.DeleteFunc(data.Proposals, func(p *model.Proposal) bool { return !proposalIDs[p.ID] })
+	data.Votes = slices.DeleteFunc(data.Votes, func(v *model.Vote) bool { return !proposalIDs[v.ProposalID] })
+	data.ProposalDocs = slices.DeleteFunc(data.ProposalDocs, func(l model.ProposalDocLink) bool {
+		return !proposalIDs[l.ProposalID] || !docIDs[l.DocID]
+	})
+
+	usedLabelIDs := make(map[int]bool)
+	for _, m := range data.IssueLabelMappings {
+		usedLabelIDs[m.LabelID] = true
+	}
+	data.Labels = slices.DeleteFunc(data.Labels, func(l *model.Label) bool { return !usedLabelIDs[l.ID] })
+	data.Milestones = slices.DeleteFunc(data.Milestones, func(m *model.Milestone) bool { return !usedMilestoneIDs[m.ID] })
+}
+
+// fillEmptyExportSlices replaces nil slices in data with empty ones so they
+// encode as [] rather than null.
+func fillEmptyExportSlices(data *model.ExportData) {
+	if data.Issues == nil {
+		data.Issues = []*model.Issue{}
+	}
+	if data.Comments == nil {
+		data.Comments = []*model.Comment{}
+	}
+	if data.Relations == nil {
+		data.Relations = []model.Relation{}
+	}
+	if data.Labels == nil {
+		data.Labels = []*model.Label{}
+	}
+	if data.Milestones == nil {
+		data.Milestones = []*model.Milestone{}
+	}
+	if data.IssueLabelMappings == nil {
+		data.IssueLabelMappings = []model.IssueLabelMapping{}
+	}
+	if data.IssueFileMappings == nil {
+		data.IssueFileMappings = []model.IssueFileMapping{}
+	}
+	if data.IssueLinks == nil {
+		data.IssueLinks = []model.IssueLink{}
+	}
+	if data.ActivityLog == nil {
+		data.ActivityLog = []*model.Activity{}
+	}
+	if data.Docs == nil {
+		data.Docs = []*model.Doc{}
+	}
+	if data.DocRevisions == nil {
+		data.DocRevisions = []*model.DocRevision{}
+	}
+	if data.DocComments == nil {
+		data.DocComments = []*model.DocComment{}
+	}
+	if data.DocIssueLinks == nil {
+		data.DocIssueLinks = []model.DocIssueLink{}
+	}
+	if data.Proposals == nil {
+		data.Proposals = []*model.Proposal{}
+	}
+	if data.Votes == nil {
+		data.Votes = []*model.Vote{}
+	}
+	if data.ProposalIssues == nil {
+		data.ProposalIssues = []model.ProposalIssueLink{}
+	}
+	if data.ProposalDocs == nil {
+		data.ProposalDocs = []model.ProposalDocLink{}
+	}
+}
+
+// filterExportSince narrows data to an incremental export of what changed
+// after since: issues updated, and comments and relations created, after it.
+// Label, file, link, doc and proposal mappings and activity are kept only for
+// the remaining issues (activity only when also newer than since), and
+// labels and milestones only when still referenced. Parent IDs are left as
+// they are, since the consumer already holds unchanged parents.
+func filterExportSince(data *model.ExportData, since time.Time) {
+	data.Since = since.UTC().Format(time.RFC3339)
+
+	data.Issues = slices.DeleteFunc(data.Issues, func(i *model.Issue) bool { return !i.UpdatedAt.After(since) })
+	data.Comments = slices.DeleteFunc(data.Comments, func(c *model.Comment) bool { return !c.CreatedAt.After(since) })
+	data.Relations = slices.DeleteFunc(data.Relations, func(r model.Relation) bool { return !r.CreatedAt.After(since) })
+
+	issueIDs := make(map[int]bool, len(data.Issues))
+	usedMilestoneIDs := make(map[int]bool)
+	for _, issue := range data.Issues {
+		issueIDs[issue.ID] = true
+		if issue.MilestoneID != nil {
+			usedMilestoneIDs[*issue.MilestoneID] = true
+		}
+	}
+
+	data.IssueLabelMappings = slices.DeleteFunc(data.IssueLabelMappings, func(m model.IssueLabelMapping) bool { return !issueIDs[m.IssueID] })
+	data.IssueFileMappings = slices.DeleteFunc(data.IssueFileMappings, func(m model.IssueFileMapping) bool { return !issueIDs[m.IssueID] })
+	data.IssueLinks = slices.DeleteFunc(data.IssueLinks, func(l model.IssueLink) bool { return !issueIDs[l.IssueID] })
+	data.DocIssueLinks = slices.DeleteFunc(data.DocIssueLinks, func(l model.DocIssueLink) bool { return !issueIDs[l.IssueID] })
+	data.ProposalIssues = slices.DeleteFunc(data.ProposalIssues, func(l model.ProposalIssueLink) bool { return !issueIDs[l.IssueID] })
+	data.ActivityLog = slices.DeleteFunc(data.ActivityLog, func(a *model.Activity) bool {
+		return !issueIDs[a.IssueID] || !a.CreatedAt.After(since)
+	})
+
+	usedLabelIDs := make(map[int]bool)
+	for _, m := range data.IssueLabelMappings {
+		usedLabelIDs[m.LabelID] = true
+	}
+	data.Labels = slices.DeleteFunc(data.Labels, func(l *model.Label) bool { return !usedLabelIDs[l.ID] })
+	data.Milestones = slices.DeleteFunc(data.Milestones, func(m *model.Milestone) bool { return !usedMilestoneIDs[m.ID] })
+}
+
+// filterIssues returns issues matching the given status and label filters.
+func filterIssues(issues []*model.Issue, statuses, labels []string) []*model.Issue {
+	statusSet, labelSet := stringSet(statuses), stringSet(labels)
+
+	filtered := make([]*model.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if matchesExportFilter(issue, statusSet, labelSet) {
+			filtered = append(filtered, issue)
 		}
-		filtered = append(filtered, issue)
 	}
 
 	survivingIDs := make(map[int]bool, len(filtered))
@@ -409,8 +510,34 @@ func filterIssues(issues []*model.Issue, statuses, labels []string) []*model.Iss
 	return filtered
 }
 
+// matchesExportFilter reports whether an issue has one of the given statuses
+// and at least one of the given labels. An empty set matches everything.
+func matchesExportFilter(issue *model.Issue, statusSet, labelSet map[string]bool) bool {
+	if len(statusSet) > 0 && !statusSet[string(issue.Status)] {
+		return false
+	}
+	if len(labelSet) == 0 {
+		return true
+	}
+	for _, il := range issue.Labels {
+		if labelSet[il] {
+			return true
+		}
+	}
+	return false
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
 // renderExportJSON produces a pretty-printed JSON string of the export data.
 func renderExportJSON(data model.ExportData) (string, error) {
+	sortExportData(&data)
 	b, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		return "", err
@@ -418,39 +545,200 @@ func renderExportJSON(data model.ExportData) (string, error) {
 	return string(b) + "\n", nil
 }
 
-// renderExportCSV produces a CSV string with a header row and one row per issue.
-func renderExportCSV(issues []*model.Issue) (string, error) {
+// sortExportData puts every collection of data in a fixed order, so that
+// exports of an unchanged database differ only in ExportedAt: entities with
+// an ID (issues, comments, relations, labels, milestones, links, activity,
+// docs, revisions, doc comments, proposals and votes) by ID, relation types
+// by name, and join-table rows by their composite key.
+func sortExportData(data *model.ExportData) {
+	slices.SortFunc(data.Issues, func(a, b *model.Issue) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(data.Comments, func(a, b *model.Comment) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(data.RelationTypes, func(a, b model.RelationTypeDef) int { return cmp.Compare(a.Name, b.Name) })
+	slices.SortFunc(data.Relations, func(a, b model.Relation) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(data.Labels, func(a, b *model.Label) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(data.Milestones, func(a, b *model.Milestone) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(data.IssueLabelMappings, func(a, b model.IssueLabelMapping) int {
+		return cmp.Or(cmp.Compare(a.IssueID, b.IssueID), cmp.Compare(a.LabelID, b.LabelID))
+	})
+	slices.SortFunc(data.IssueFileMappings, func(a, b model.IssueFileMapping) int {
+		return cmp.Or(cmp.Compare(a.IssueID, b.IssueID), cmp.Compare(a.FilePath, b.FilePath))
+	})
+	slices.SortFunc(data.IssueLinks, func(a, b model.IssueLink) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(data.ActivityLog, func(a, b *model.Activity) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(data.Docs, func(a, b *model.Doc) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(data.DocRevisions, func(a, b *model.DocRevision) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(data.DocComments, func(a, b *model.DocComment) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(data.DocIssueLinks, func(a, b model.DocIssueLink) int {
+		return cmp.Or(cmp.Compare(a.DocID, b.DocID), cmp.Compare(a.IssueID, b.IssueID))
+	})
+	slices.SortFunc(data.Proposals, func(a, b *model.Proposal) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(data.Votes, func(a, b *model.Vote) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(data.ProposalIssues, func(a, b model.ProposalIssueLink) int {
+		return cmp.Or(cmp.Compare(a.ProposalID, b.ProposalID), cmp.Compare(a.IssueID, b.IssueID))
+	})
+	slices.SortFunc(data.ProposalDocs, func(a, b model.ProposalDocLink) int {
+		return cmp.Or(cmp.Compare(a.ProposalID, b.ProposalID), cmp.Compare(a.DocID, b.DocID))
+	})
+}
+
+// csvColumns lists every column renderExportCSV can emit, in the default
+// order.
+var csvColumns = []string{"id", "parent_id", "title", "description", "status", "priority", "type", "assignee", "labels", "files", "created_at", "updated_at", "started_at", "completed_at", "snoozed_until", "pinned"}
+
+// csvCell returns the value of one CSV column for an issue, rendering
+// timestamps with formatDate.
+func csvCell(issue *model.Issue, column string, formatDate dateFormatter) string {
+	switch column {
+	case "id":
+		return model.FormatID(issue.ID)
+	case "parent_id":
+		if issue.ParentID != nil {
+			return model.FormatID(*issue.ParentID)
+		}
+		return ""
+	case "title":
+		return csvSafe(issue.Title)
+	case "description":
+		return csvSafe(issue.Description)
+	case "status":
+		return string(issue.Status)
+	case "priority":
+		return string(issue.Priority)
+	case "type":
+		return string(issue.Kind)
+	case "assignee":
+		return csvSafe(issue.Assignee)
+	case "labels":
+		return csvSafe(strings.Join(issue.Labels, ","))
+	case "files":
+		// Use ";" to separate file paths since paths may contain commas.
+		return csvSafe(strings.Join(issue.Files, ";"))
+	case "created_at":
+		return formatDate(issue.CreatedAt)
+	case "updated_at":
+		return formatDate(issue.UpdatedAt)
+	case "started_at":
+		return formatOptionalTime(issue.StartedAt, formatDate)
+	case "completed_at":
+		return formatOptionalTime(issue.CompletedAt, formatDate)
+	case "snoozed_until":
+		return formatOptionalTime(issue.SnoozedUntil, formatDate)
+	case "pinned":
+		return strconv.FormatBool(issue.Pinned)
+	default:
+		return ""
+	}
+}
+
+// formatOptionalTime formats t with formatDate, or returns "" when t is zero.
+func formatOptionalTime(t time.Time, formatDate dateFormatter) string {
+	if t.IsZero() {
+		return ""
+	}
+	return formatDate(t)
+}
+
+// dateFormatter renders an export timestamp.
+type dateFormatter func(time.Time) string
+
+// rfc3339Date is the default export date formatter.
+func rfc3339Date(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// dateFormatAliases maps the --date-format aliases to Go layouts. "unix" is
+// handled separately because it is not a layout.
+var dateFormatAliases = map[string]string{
+	"rfc3339":  time.RFC3339,
+	"date":     time.DateOnly,
+	"datetime": time.DateTime,
+}
+
+// parseDateFormat returns the formatter for a --date-format value: one of
+// the aliases rfc3339, date, datetime or unix, or a Go reference-time layout
+// such as "02 Jan 2006". Timestamps are always rendered in UTC. An empty
+// value means RFC 3339.
+func parseDateFormat(s string) (dateFormatter, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return rfc3339Date, nil
+	}
+	if strings.EqualFold(s, "unix") {
+		return func(t time.Time) string { return strconv.FormatInt(t.Unix(), 10) }, nil
+	}
+	layout, ok := dateFormatAliases[strings.ToLower(s)]
+	if !ok {
+		// A layout that renders an arbitrary time as itself contains no
+		// date or time elements, so it is almost certainly a mistyped alias.
+		probe := time.Date(1999, time.December, 31, 23, 59, 58, 0, time.UTC)
+		if probe.Format(s) == s {
+			return nil, fmt.Errorf("invalid date format %q: must be rfc3339, date, datetime, unix, or a Go time layout", s)
+		}
+		layout = s
+	}
+	return func(t time.Time) string { return t.UTC().Format(layout) }, nil
+}
+
+// validateCSVColumns returns an error naming the first column that is not in
+// csvColumns.
+func validateCSVColumns(columns []string) error {
+	for _, c := range columns {
+		if !slices.Contains(csvColumns, c) {
+			return fmt.Errorf("unknown CSV column %q: must be one of %s", c, strings.Join(csvColumns, ", "))
+		}
+	}
+	return nil
+}
+
+// parseCSVDelimiter converts a --delimiter value into a rune. The two-character
+// escape `\t` is accepted for a tab, and an empty value selects the default
+// comma (returned as 0).
+func parseCSVDelimiter(s string) (rune, error) {
+	switch s {
+	case "":
+		return 0, nil
+	case `\t`:
+		return '\t', nil
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	if size != len(s) || r == utf8.RuneError {
+		return 0, fmt.Errorf("invalid delimiter %q: must be a single character", s)
+	}
+	if r == '"' || r == '\r' || r == '\n' {
+		return 0, fmt.Errorf("invalid delimiter %q: quotes and line breaks are not allowed", s)
+	}
+	return r, nil
+}
+
+// renderExportCSV produces a CSV string with a header row and one row per
+// issue. columns selects and orders the emitted columns (nil means all of
+// csvColumns), delimiter separates fields (0 means a comma) and formatDate
+// renders timestamps (nil means RFC 3339).
+func renderExportCSV(issues []*model.Issue, columns []string, delimiter rune, formatDate dateFormatter) (string, error) {
+	if len(columns) == 0 {
+		columns = csvColumns
+	}
+	if formatDate == nil {
+		formatDate = rfc3339Date
+	}
+	if err := validateCSVColumns(columns); err != nil {
+		return "", err
+	}
+
 	var buf strings.Builder
 	cw := csv.NewWriter(&buf)
+	if delimiter != 0 {
+		cw.Comma = delimiter
+	}
 
-	header := []string{"id", "parent_id", "title", "description", "status", "priority", "type", "assignee", "labels", "files", "created_at", "updated_at"}
-	if err := cw.Write(header); err != nil {
+	if err := cw.Write(columns); err != nil {
 		return "", err
 	}
 
+	row := make([]string, len(columns))
 	for _, issue := range issues {
-		parentID := ""
-		if issue.ParentID != nil {
-			parentID = model.FormatID(*issue.ParentID)
-		}
-
-		labelsStr := strings.Join(issue.Labels, ",")
-		// Use ";" to separate file paths since paths may contain commas.
-		filesStr := strings.Join(issue.Files, ";")
-
-		row := []string{
-			model.FormatID(issue.ID),
-			parentID,
-			csvSafe(issue.Title),
-			csvSafe(issue.Description),
-			string(issue.Status),
-			string(issue.Priority),
-			string(issue.Kind),
-			csvSafe(issue.Assignee),
-			csvSafe(labelsStr),
-			csvSafe(filesStr),
-			issue.CreatedAt.UTC().Format(time.RFC3339),
-			issue.UpdatedAt.UTC().Format(time.RFC3339),
+		for i, c := range columns {
+			row[i] = csvCell(issue, c, formatDate)
 		}
 		if err := cw.Write(row); err != nil {
 			return "", err
@@ -477,26 +765,15 @@ func csvSafe(s string) string {
 	}
 }
 
-// escapeMarkdown replaces characters that have special meaning in Markdown so
-// that arbitrary user text can be safely embedded in headings and inline spans.
-func escapeMarkdown(s string) string {
-	r := strings.NewReplacer(
-		`\`, `\\`,
-		`#`, `\#`,
-		`*`, `\*`,
-		`_`, `\_`,
-		`[`, `\[`,
-		`]`, `\]`,
-		`<`, `\<`,
-		`>`, `\>`,
-		"`", "\\`",
-		`|`, `\|`,
-	)
-	return r.Replace(s)
-}
+// renderExportMarkdown produces a Markdown string grouping issues by status,
+// after a table of contents linking to each issue's heading. comments holds
+// each issue's comments keyed by issue ID, relations are listed under both
+// issues they connect, and formatDate renders timestamps (nil means RFC 3339).
+func renderExportMarkdown(issues []*model.Issue, comments map[int][]*model.Comment, relations []model.Relation, formatDate dateFormatter) (string, error) {
+	if formatDate == nil {
+		formatDate = rfc3339Date
+	}
 
-// renderExportMarkdown produces a Markdown string grouping issues by status.
-func renderExportMarkdown(issues []*model.Issue, comments []*model.Comment) (string, error) {
 	// Group issues by status.
 	statusOrder := []model.Status{
 		model.StatusBacklog,
@@ -511,15 +788,26 @@ func renderExportMarkdown(issues []*model.Issue, comments []*model.Comment) (str
 		grouped[issue.Status] = append(grouped[issue.Status], issue)
 	}
 
-	// Build comment lookup by issue ID.
-	commentsByIssue := make(map[int][]*model.Comment)
-	for _, c := range comments {
-		commentsByIssue[c.IssueID] = append(commentsByIssue[c.IssueID], c)
+	relationsByIssue := make(map[int][]model.Relation)
+	for _, r := range relations {
+		relationsByIssue[r.SourceIssueID] = append(relationsByIssue[r.SourceIssueID], r)
+		relationsByIssue[r.TargetIssueID] = append(relationsByIssue[r.TargetIssueID], r)
 	}
 
 	var buf strings.Builder
 	buf.WriteString("# Docket Export\n\n")
 
+	// Table of contents.
+	if len(issues) > 0 {
+		buf.WriteString("## Contents\n\n")
+		for _, status := range statusOrder {
+			for _, issue := range grouped[status] {
+				buf.WriteString(fmt.Sprintf("- %s: %s\n", markdownIssueLink(issue.ID), render.EscapeMarkdown(issue.Title)))
+			}
+		}
+		buf.WriteString("\n")
+	}
+
 	for _, status := range statusOrder {
 		group := grouped[status]
 		if len(group) == 0 {
@@ -529,44 +817,56 @@ func renderExportMarkdown(issues []*model.Issue, comments []*model.Comment) (str
 		buf.WriteString(fmt.Sprintf("## %s\n\n", string(status)))
 
 		for _, issue := range group {
-			buf.WriteString(fmt.Sprintf("### %s: %s\n\n", model.FormatID(issue.ID), escapeMarkdown(issue.Title)))
+			buf.WriteString(fmt.Sprintf("<a id=\"%s\"></a>\n\n", markdownAnchor(issue.ID)))
+			buf.WriteString(fmt.Sprintf("### %s: %s\n\n", model.FormatID(issue.ID), render.EscapeMarkdown(issue.Title)))
 
 			// Metadata.
-			buf.WriteString(fmt.Sprintf("- **Priority:** %s\n", escapeMarkdown(string(issue.Priority))))
-			buf.WriteString(fmt.Sprintf("- **Type:** %s\n", escapeMarkdown(string(issue.Kind))))
+			buf.WriteString(fmt.Sprintf("- **Priority:** %s\n", render.EscapeMarkdown(string(issue.Priority))))
+			buf.WriteString(fmt.Sprintf("- **Type:** %s\n", render.EscapeMarkdown(string(issue.Kind))))
 			if issue.Assignee != "" {
-				buf.WriteString(fmt.Sprintf("- **Assignee:** %s\n", escapeMarkdown(issue.Assignee)))
+				buf.WriteString(fmt.Sprintf("- **Assignee:** %s\n", render.EscapeMarkdown(issue.Assignee)))
 			}
 			if len(issue.Labels) > 0 {
 				escaped := make([]string, len(issue.Labels))
 				for i, l := range issue.Labels {
-					escaped[i] = escapeMarkdown(l)
+					escaped[i] = render.EscapeMarkdown(l)
 				}
 				buf.WriteString(fmt.Sprintf("- **Labels:** %s\n", strings.Join(escaped, ", ")))
 			}
 			if len(issue.Files) > 0 {
 				escapedFiles := make([]string, len(issue.Files))
 				for i, f := range issue.Files {
-					escapedFiles[i] = escapeMarkdown(f)
+					escapedFiles[i] = render.EscapeMarkdown(f)
 				}
 				buf.WriteString(fmt.Sprintf("- **Files:** %s\n", strings.Join(escapedFiles, ", ")))
 			}
+			buf.WriteString(fmt.Sprintf("- **Created:** %s\n", render.EscapeMarkdown(formatDate(issue.CreatedAt))))
+			buf.WriteString(fmt.Sprintf("- **Updated:** %s\n", render.EscapeMarkdown(formatDate(issue.UpdatedAt))))
 			buf.WriteString("\n")
 
 			// Description.
 			if issue.Description != "" {
-				buf.WriteString(escapeMarkdown(issue.Description) + "\n\n")
+				buf.WriteString(render.EscapeMarkdown(issue.Description) + "\n\n")
+			}
+
+			// Relations, seen from this issue as in the detail view.
+			if issueRelations := relationsByIssue[issue.ID]; len(issueRelations) > 0 {
+				buf.WriteString("**Relations:**\n\n")
+				for _, r := range issueRelations {
+					buf.WriteString("- " + markdownRelation(issue.ID, r) + "\n")
+				}
+				buf.WriteString("\n")
 			}
 
 			// Comments.
-			issueComments := commentsByIssue[issue.ID]
+			issueComments := comments[issue.ID]
 			if len(issueComments) > 0 {
 				buf.WriteString("**Comments:**\n\n")
 				for _, c := range issueComments {
 					buf.WriteString(fmt.Sprintf("> **%s** (%s):\n> %s\n\n",
-						escapeMarkdown(c.AuthorOrAnonymous()),
-						c.CreatedAt.UTC().Format(time.RFC3339),
-						escapeMarkdown(c.Body),
+						render.EscapeMarkdown(c.AuthorOrAnonymous()),
+						render.EscapeMarkdown(formatDate(c.CreatedAt)),
+						render.EscapeMarkdown(c.Body),
 					))
 				}
 			}
@@ -575,3 +875,28 @@ func renderExportMarkdown(issues []*model.Issue, comments []*model.Comment) (str
 
 	return buf.String(), nil
 }
+
+// markdownAnchor returns the anchor name of an issue's heading in the
+// Markdown export, e.g. "dkt-5".
+func markdownAnchor(id int) string {
+	return strings.ToLower(model.FormatID(id))
+}
+
+// markdownIssueLink links an issue ID to its heading in the Markdown export.
+func markdownIssueLink(id int) string {
+	return fmt.Sprintf("[%s](#%s)", model.FormatID(id), markdownAnchor(id))
+}
+
+// markdownRelation formats r as seen from issueID, e.g. "→ blocks [DKT-2](#dkt-2)",
+// using the inverse type when issueID is the target.
+func markdownRelation(issueID int, r model.Relation) string {
+	arrow, name, other := render.RelationArrow(r.RelationType, true), string(r.RelationType), r.TargetIssueID
+	if r.SourceIssueID != issueID {
+		arrow, name, other = render.RelationArrow(r.RelationType, false), r.RelationType.Inverse(), r.SourceIssueID
+	}
+	line := fmt.Sprintf("%s %s %s", arrow, render.EscapeMarkdown(name), markdownIssueLink(other))
+	if r.Note != "" {
+		line += " (" + render.EscapeMarkdown(r.Note) + ")"
+	}
+	return line
+}
@@ -0,0 +1,606 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// Record types used in the "type" field of a jsonl export. Entity records are
+// written in this order, which is also a valid foreign-key insertion order, so
+// an importer can insert each line as it is read.
+const (
+	jsonlHeader        = "header"
+	jsonlLabel         = "label"
+	jsonlMilestone     = "milestone"
+	jsonlIssue         = "issue"
+	jsonlIssueLabel    = "issue_label"
+	jsonlIssueFile     = "issue_file"
+	jsonlIssueLink     = "issue_link"
+	jsonlComment       = "comment"
+	jsonlRelationType  = "relation_type"
+	jsonlRelation      = "relation"
+	jsonlActivity      = "activity"
+	jsonlProposal      = "proposal"
+	jsonlVote          = "vote"
+	jsonlProposalIssue = "proposal_issue"
+	jsonlDoc           = "doc"
+	jsonlDocRevision   = "doc_revision"
+	jsonlDocComment    = "doc_comment"
+	jsonlDocIssueLink  = "doc_issue_link"
+	jsonlProposalDoc   = "proposal_doc"
+)
+
+// jsonlRecord is the envelope for every line of a jsonl export. Entities are
+// nested under "data" rather than flattened because several of them (docs,
+// for example) already carry a "type" field of their own.
+type jsonlRecord struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// jsonlHeaderData is the payload of the first line of a jsonl export.
+type jsonlHeaderData struct {
+	Version    int            `json:"version"`
+	ExportedAt string         `json:"exported_at"`
+	Counts     map[string]int `json:"counts"`
+}
+
+// exportSelection records which rows survive an export's --status and
+// --label filters. A nil selection keeps every row.
+type exportSelection struct {
+	issues     map[int]bool
+	labels     map[int]bool
+	milestones map[int]bool
+	docs       map[int]bool
+	proposals  map[int]bool
+}
+
+func (s *exportSelection) issue(id int) bool     { return s == nil || s.issues[id] }
+func (s *exportSelection) label(id int) bool     { return s == nil || s.labels[id] }
+func (s *exportSelection) milestone(id int) bool { return s == nil || s.milestones[id] }
+func (s *exportSelection) doc(id int) bool       { return s == nil || s.docs[id] }
+func (s *exportSelection) proposal(id int) bool  { return s == nil || s.proposals[id] }
+
+// buildExportSelection streams the issues and link tables once to work out
+// which rows a filtered export keeps, mirroring the filtering the JSON export
+// applies to its in-memory slices. It returns nil when no filters are set.
+func buildExportSelection(conn *sql.DB, statuses, labels []string) (*exportSelection, error) {
+	if len(statuses) == 0 && len(labels) == 0 {
+		return nil, nil
+	}
+
+	statusSet, labelSet := stringSet(statuses), stringSet(labels)
+	sel := &exportSelection{
+		issues:     make(map[int]bool),
+		labels:     make(map[int]bool),
+		milestones: make(map[int]bool),
+		docs:       make(map[int]bool),
+		proposals:  make(map[int]bool),
+	}
+
+	err := db.StreamIssues(conn, func(issue *model.Issue) error {
+		if matchesExportFilter(issue, statusSet, labelSet) {
+			sel.issues[issue.ID] = true
+			if issue.MilestoneID != nil {
+				sel.milestones[*issue.MilestoneID] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("selecting issues: %w", err)
+	}
+
+	err = db.StreamIssueLabelMappings(conn, func(m model.IssueLabelMapping) error {
+		if sel.issues[m.IssueID] {
+			sel.labels[m.LabelID] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("selecting labels: %w", err)
+	}
+
+	err = db.StreamDocIssueLinks(conn, func(l model.DocIssueLink) error {
+		if sel.issues[l.IssueID] {
+			sel.docs[l.DocID] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("selecting docs: %w", err)
+	}
+
+	err = db.StreamProposalIssues(conn, func(l model.ProposalIssueLink) error {
+		if sel.issues[l.IssueID] {
+			sel.proposals[l.ProposalID] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("selecting proposals: %w", err)
+	}
+
+	return sel, nil
+}
+
+// jsonlSection streams every surviving row of one entity type to emit.
+type jsonlSection struct {
+	typ    string
+	stream func(emit func(v any) error) error
+}
+
+// jsonlSections returns the entity sections of a jsonl export in write order.
+func jsonlSections(conn *sql.DB, sel *exportSelection) []jsonlSection {
+	return []jsonlSection{
+		{jsonlLabel, func(emit func(any) error) error {
+			return db.StreamLabels(conn, func(l *model.Label) error {
+				if !sel.label(l.ID) {
+					return nil
+				}
+				return emit(l)
+			})
+		}},
+		{jsonlMilestone, func(emit func(any) error) error {
+			return db.StreamMilestones(conn, func(m *model.Milestone) error {
+				if !sel.milestone(m.ID) {
+					return nil
+				}
+				return emit(m)
+			})
+		}},
+		{jsonlIssue, func(emit func(any) error) error {
+			return db.StreamIssues(conn, func(issue *model.Issue) error {
+				if !sel.issue(issue.ID) {
+					return nil
+				}
+				if issue.ParentID != nil && !sel.issue(*issue.ParentID) {
+					issue.ParentID = nil
+				}
+				return emit(issue)
+			})
+		}},
+		{jsonlIssueLabel, func(emit func(any) error) error {
+			return db.StreamIssueLabelMappings(conn, func(m model.IssueLabelMapping) error {
+				if !sel.issue(m.IssueID) {
+					return nil
+				}
+				return emit(m)
+			})
+		}},
+		{jsonlIssueFile, func(emit func(any) error) error {
+			return db.StreamIssueFileMappings(conn, func(m model.IssueFileMapping) error {
+				if !sel.issue(m.IssueID) {
+					return nil
+				}
+				return emit(m)
+			})
+		}},
+		{jsonlIssueLink, func(emit func(any) error) error {
+			return db.StreamIssueLinks(conn, func(l model.IssueLink) error {
+				if !sel.issue(l.IssueID) {
+					return nil
+				}
+				return emit(l)
+			})
+		}},
+		{jsonlComment, func(emit func(any) error) error {
+			return db.StreamComments(conn, func(c *model.Comment) error {
+				if !sel.issue(c.IssueID) {
+					return nil
+				}
+				return emit(c)
+			})
+		}},
+		{jsonlRelationType, func(emit func(any) error) error {
+			defs, err := db.ListCustomRelationTypes(conn)
+			if err != nil {
+				return err
+			}
+			for _, d := range defs {
+				if err := emit(d); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{jsonlRelation, func(emit func(any) error) error {
+			return db.StreamRelations(conn, func(r model.Relation) error {
+				if !sel.issue(r.SourceIssueID) || !sel.issue(r.TargetIssueID) {
+					return nil
+				}
+				return emit(r)
+			})
+		}},
+		{jsonlActivity, func(emit func(any) error) error {
+			return db.StreamActivity(conn, func(a *model.Activity) error {
+				if !sel.issue(a.IssueID) {
+					return nil
+				}
+				return emit(a)
+			})
+		}},
+		{jsonlProposal, func(emit func(any) error) error {
+			return db.StreamProposals(conn, func(p *model.Proposal) error {
+				if !sel.proposal(p.ID) {
+					return nil
+				}
+				return emit(p)
+			})
+		}},
+		{jsonlVote, func(emit func(any) error) error {
+			return db.StreamVotes(conn, func(v *model.Vote) error {
+				if !sel.proposal(v.ProposalID) {
+					return nil
+				}
+				return emit(v)
+			})
+		}},
+		{jsonlProposalIssue, func(emit func(any) error) error {
+			return db.StreamProposalIssues(conn, func(l model.ProposalIssueLink) error {
+				if !sel.issue(l.IssueID) {
+					return nil
+				}
+				return emit(l)
+			})
+		}},
+		{jsonlDoc, func(emit func(any) error) error {
+			return db.StreamDocs(conn, func(d *model.Doc) error {
+				if !sel.doc(d.ID) {
+					return nil
+				}
+				return emit(d)
+			})
+		}},
+		{jsonlDocRevision, func(emit func(any) error) error {
+			return db.StreamDocRevisions(conn, func(r *model.DocRevision) error {
+				if !sel.doc(r.DocID) {
+					return nil
+				}
+				return emit(r)
+			})
+		}},
+		{jsonlDocComment, func(emit func(any) error) error {
+			return db.StreamDocComments(conn, func(c *model.DocComment) error {
+				if !sel.doc(c.DocID) {
+					return nil
+				}
+				return emit(c)
+			})
+		}},
+		{jsonlDocIssueLink, func(emit func(any) error) error {
+			return db.StreamDocIssueLinks(conn, func(l model.DocIssueLink) error {
+				if !sel.issue(l.IssueID) {
+					return nil
+				}
+				return emit(l)
+			})
+		}},
+		{jsonlProposalDoc, func(emit func(any) error) error {
+			return db.StreamProposalDocs(conn, func(l model.ProposalDocLink) error {
+				if !sel.proposal(l.ProposalID) || !sel.doc(l.DocID) {
+					return nil
+				}
+				return emit(l)
+			})
+		}},
+	}
+}
+
+// writeExportJSONL streams a jsonl export to out: a header line carrying the
+// version, export time, and per-type record counts, followed by one record
+// per line. Rows are never buffered in memory; the database is read twice,
+// once to count records for the header and once to write them.
+func writeExportJSONL(out io.Writer, conn *sql.DB, statuses, labels []string) error {
+	sel, err := buildExportSelection(conn, statuses, labels)
+	if err != nil {
+		return err
+	}
+	sections := jsonlSections(conn, sel)
+
+	counts := make(map[string]int, len(sections))
+	for _, s := range sections {
+		counts[s.typ] = 0
+		err := s.stream(func(any) error {
+			counts[s.typ]++
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("counting %s records: %w", s.typ, err)
+		}
+	}
+
+	bw := bufio.NewWriter(out)
+	enc := json.NewEncoder(bw)
+
+	writeRecord := func(typ string, v any) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("encoding %s record: %w", typ, err)
+		}
+		return enc.Encode(jsonlRecord{Type: typ, Data: data})
+	}
+
+	header := jsonlHeaderData{
+		Version:    1,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Counts:     counts,
+	}
+	if err := writeRecord(jsonlHeader, header); err != nil {
+		return err
+	}
+
+	for _, s := range sections {
+		err := s.stream(func(v any) error {
+			return writeRecord(s.typ, v)
+		})
+		if err != nil {
+			return fmt.Errorf("writing %s records: %w", s.typ, err)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// writeIssuesJSONL streams the issues matching the status and label filters
+// to out as JSON Lines: one issue object per line, with its labels and files
+// inlined, and no header or other record types. This suits log pipelines
+// that ingest one object per line; it cannot be imported.
+func writeIssuesJSONL(out io.Writer, conn *sql.DB, statuses, labels []string) error {
+	statusSet, labelSet := stringSet(statuses), stringSet(labels)
+	bw := bufio.NewWriter(out)
+	enc := json.NewEncoder(bw)
+	err := db.StreamIssues(conn, func(issue *model.Issue) error {
+		if !matchesExportFilter(issue, statusSet, labelSet) {
+			return nil
+		}
+		if err := enc.Encode(issue); err != nil {
+			return fmt.Errorf("encoding issue %s: %w", model.FormatID(issue.ID), err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// errJSONLNoHeader is returned when a jsonl import does not start with a
+// header record.
+var errJSONLNoHeader = errors.New("missing header record: first line must have type \"header\"")
+
+// readJSONL decodes r line by line, calling fn with the record type and the
+// decoded value for every non-blank line: *jsonlHeaderData for the header,
+// and the same model types used by model.ExportData for entities.
+func readJSONL(r io.Reader, fn func(line int, typ string, v any) error) error {
+	br := bufio.NewReader(r)
+	line := 0
+	sawHeader := false
+	for {
+		raw, readErr := br.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return fmt.Errorf("reading line %d: %w", line+1, readErr)
+		}
+		line++
+
+		raw = bytes.TrimSpace(raw)
+		if len(raw) > 0 {
+			typ, v, err := decodeJSONLRecord(raw)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+			if !sawHeader && typ != jsonlHeader {
+				return fmt.Errorf("line %d: %w", line, errJSONLNoHeader)
+			}
+			if sawHeader && typ == jsonlHeader {
+				return fmt.Errorf("line %d: duplicate header record", line)
+			}
+			sawHeader = true
+			if err := fn(line, typ, v); err != nil {
+				return err
+			}
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+	}
+	if !sawHeader {
+		return errJSONLNoHeader
+	}
+	return nil
+}
+
+func decodeJSONLRecord(raw []byte) (string, any, error) {
+	var rec jsonlRecord
+	if err := json.Unmarshal(raw, &rec); err != nil {
+		return "", nil, fmt.Errorf("parsing JSON: %w", err)
+	}
+
+	var v any
+	switch rec.Type {
+	case jsonlHeader:
+		v = &jsonlHeaderData{}
+	case jsonlLabel:
+		v = &model.Label{}
+	case jsonlMilestone:
+		v = &model.Milestone{}
+	case jsonlIssue:
+		v = &model.Issue{}
+	case jsonlIssueLabel:
+		v = &model.IssueLabelMapping{}
+	case jsonlIssueFile:
+		v = &model.IssueFileMapping{}
+	case jsonlIssueLink:
+		v = &model.IssueLink{}
+	case jsonlComment:
+		v = &model.Comment{}
+	case jsonlRelationType:
+		v = &model.RelationTypeDef{}
+	case jsonlRelation:
+		v = &model.Relation{}
+	case jsonlActivity:
+		v = &model.Activity{}
+	case jsonlProposal:
+		v = &model.Proposal{}
+	case jsonlVote:
+		v = &model.Vote{}
+	case jsonlProposalIssue:
+		v = &model.ProposalIssueLink{}
+	case jsonlDoc:
+		v = &model.Doc{}
+	case jsonlDocRevision:
+		v = &model.DocRevision{}
+	case jsonlDocComment:
+		v = &model.DocComment{}
+	case jsonlDocIssueLink:
+		v = &model.DocIssueLink{}
+	case jsonlProposalDoc:
+		v = &model.ProposalDocLink{}
+	case "":
+		return "", nil, fmt.Errorf("record has no type")
+	default:
+		return "", nil, fmt.Errorf("unknown record type %q", rec.Type)
+	}
+
+	if len(rec.Data) == 0 {
+		return "", nil, fmt.Errorf("%s record has no data", rec.Type)
+	}
+	if err := json.Unmarshal(rec.Data, v); err != nil {
+		return "", nil, fmt.Errorf("parsing %s record: %w", rec.Type, err)
+	}
+	return rec.Type, v, nil
+}
+
+// validateJSONL checks a jsonl export for the same structural problems
+// validateExportData catches, plus header counts that don't match the number
+// of records present (a truncated file, for example).
+func validateJSONL(r io.Reader) ([]string, error) {
+	var errs []string
+	var header *jsonlHeaderData
+	seen := make(map[string]int)
+	declared := make(map[model.RelationType]bool)
+
+	err := readJSONL(r, func(line int, typ string, v any) error {
+		switch v := v.(type) {
+		case *jsonlHeaderData:
+			header = v
+			if v.Version != 1 {
+				errs = append(errs, fmt.Sprintf("unsupported version %d: expected 1", v.Version))
+			}
+			return nil
+		case *model.Issue:
+			errs = append(errs, validateImportIssue(v)...)
+		case *model.RelationTypeDef:
+			errs = append(errs, validateImportRelationType(*v)...)
+			declared[v.Name] = true
+		case *model.Relation:
+			errs = append(errs, validateImportRelation(*v, declared)...)
+		case *model.Proposal:
+			errs = append(errs, validateImportProposal(v)...)
+		case *model.Vote:
+			errs = append(errs, validateImportVote(v)...)
+		}
+		seen[typ]++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for typ, want := range header.Counts {
+		if got := seen[typ]; got != want {
+			errs = append(errs, fmt.Sprintf("header declares %d %s record(s), found %d", want, typ, got))
+		}
+	}
+
+	return errs, nil
+}
+
+// doImportJSONL inserts a jsonl export line by line within a single
+// transaction. Records must appear in foreign-key order, as written by
+// writeExportJSONL; parent links are restored after the last line.
+func doImportJSONL(conn *sql.DB, r io.Reader, replace bool) (*importResult, error) {
+	tx, err := conn.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if replace {
+		if err := db.ClearAllDataTx(tx); err != nil {
+			return nil, fmt.Errorf("clearing database: %w", err)
+		}
+	}
+
+	im := newImporter(tx)
+
+	err = readJSONL(r, func(line int, typ string, v any) error {
+		var err error
+		switch v := v.(type) {
+		case *jsonlHeaderData:
+		case *model.Label:
+			err = im.label(v)
+		case *model.Milestone:
+			err = im.milestone(v)
+		case *model.Issue:
+			err = im.issue(v)
+		case *model.IssueLabelMapping:
+			err = im.issueLabel(*v)
+		case *model.IssueFileMapping:
+			err = im.issueFile(*v)
+		case *model.IssueLink:
+			err = im.issueLink(v)
+		case *model.Comment:
+			err = im.comment(v)
+		case *model.RelationTypeDef:
+			err = im.relationType(v)
+		case *model.Relation:
+			err = im.relation(*v)
+		case *model.Activity:
+			err = im.activity(v)
+		case *model.Proposal:
+			err = im.proposal(v)
+		case *model.Vote:
+			err = im.vote(v)
+		case *model.ProposalIssueLink:
+			err = im.proposalIssue(*v)
+		case *model.Doc:
+			err = im.doc(v)
+		case *model.DocRevision:
+			err = im.docRevision(v)
+		case *model.DocComment:
+			err = im.docComment(v)
+		case *model.DocIssueLink:
+			err = im.docIssueLink(*v)
+		case *model.ProposalDocLink:
+			err = im.proposalDoc(*v)
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := im.restoreParents(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return im.result(), nil
+}
@@ -0,0 +1,343 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/spf13/cobra"
+)
+
+// seedJSONLFixture populates conn with at least one row of every exported
+// entity type, including a child issue whose parent was created after it.
+func seedJSONLFixture(t *testing.T, conn *sql.DB) {
+	t.Helper()
+
+	childID := createIssue(t, conn, "done child", model.StatusDone, model.PriorityLow)
+	parentID := createIssue(t, conn, "in-progress parent", model.StatusInProgress, model.PriorityHigh)
+	if err := db.UpdateIssue(conn, childID, map[string]interface{}{"parent_id": parentID}, "tester"); err != nil {
+		t.Fatalf("UpdateIssue(parent_id): %v", err)
+	}
+	otherID := createIssue(t, conn, "todo sibling", model.StatusTodo, model.PriorityMedium)
+
+	for _, ms := range []struct {
+		name    string
+		issueID int
+	}{{"v1.4", otherID}, {"v2.0", parentID}} {
+		msID, err := db.CreateMilestone(conn, &model.Milestone{Name: ms.name})
+		if err != nil {
+			t.Fatalf("CreateMilestone(%q): %v", ms.name, err)
+		}
+		if err := db.UpdateIssue(conn, ms.issueID, map[string]interface{}{"milestone_id": msID}, "tester"); err != nil {
+			t.Fatalf("UpdateIssue(milestone_id): %v", err)
+		}
+	}
+
+	if err := db.AddLabelToIssue(conn, parentID, "backend", "blue", "tester"); err != nil {
+		t.Fatalf("AddLabelToIssue: %v", err)
+	}
+	if err := db.AddLabelToIssue(conn, otherID, "frontend", "", "tester"); err != nil {
+		t.Fatalf("AddLabelToIssue: %v", err)
+	}
+	if err := db.AttachFiles(conn, parentID, []string{"internal/db/db.go", "cmd/main.go"}, "tester"); err != nil {
+		t.Fatalf("AttachFiles: %v", err)
+	}
+	if _, err := db.CreateComment(conn, &model.Comment{IssueID: childID, Body: "multi\nline {\"json\"}", Author: "alice"}); err != nil {
+		t.Fatalf("CreateComment: %v", err)
+	}
+	if _, err := db.CreateRelation(conn, &model.Relation{
+		SourceIssueID: parentID,
+		TargetIssueID: otherID,
+		RelationType:  model.RelationBlocks,
+	}); err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+	if err := db.CreateRelationType(conn, &model.RelationTypeDef{Name: "tested_by", Inverse: "tests", Directional: true}); err != nil {
+		t.Fatalf("CreateRelationType: %v", err)
+	}
+	if _, err := db.CreateRelation(conn, &model.Relation{
+		SourceIssueID: otherID,
+		TargetIssueID: childID,
+		RelationType:  "tested_by",
+	}); err != nil {
+		t.Fatalf("CreateRelation(tested_by): %v", err)
+	}
+
+	docID := createDoc(t, conn, "design doc", "tdd", "draft")
+	linkDocIssue(t, conn, docID, parentID)
+	if _, err := db.CreateDocComment(conn, &model.DocComment{DocID: docID, Body: "lgtm", Author: "bob"}); err != nil {
+		t.Fatalf("CreateDocComment: %v", err)
+	}
+
+	proposalID, err := db.CreateProposal(conn, &model.Proposal{
+		Description:    "ship it",
+		Criticality:    model.CriticalityLow,
+		Status:         model.ProposalStatusOpen,
+		RequiredVoters: 1,
+		Threshold:      0.5,
+		CreatedBy:      "carol",
+	})
+	if err != nil {
+		t.Fatalf("CreateProposal: %v", err)
+	}
+	if _, err := db.CastVote(conn, &model.Vote{
+		ProposalID: proposalID,
+		VoterName:  "dave",
+		VoterRole:  "reviewer",
+		Verdict:    model.VerdictApprove,
+		Confidence: 0.7,
+		Summary:    "fine",
+	}); err != nil {
+		t.Fatalf("CastVote: %v", err)
+	}
+	if err := db.LinkProposalIssue(conn, proposalID, otherID); err != nil {
+		t.Fatalf("LinkProposalIssue: %v", err)
+	}
+	if err := db.LinkProposalDoc(conn, proposalID, docID); err != nil {
+		t.Fatalf("LinkProposalDoc: %v", err)
+	}
+}
+
+// runFormatExport runs the export command with the given format and status
+// filters and returns the path of the written file.
+func runFormatExport(t *testing.T, conn *sql.DB, format string, statuses []string) string {
+	t.Helper()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().StringP("format", "o", "json", "")
+	cmd.Flags().StringP("file", "f", "", "")
+	cmd.Flags().StringSliceP("status", "s", nil, "")
+	cmd.Flags().StringSliceP("label", "l", nil, "")
+	cmd.SetContext(context.WithValue(context.Background(), dbKey, conn))
+
+	outPath := filepath.Join(t.TempDir(), "export."+format)
+	if err := cmd.Flags().Set("format", format); err != nil {
+		t.Fatalf("set format flag: %v", err)
+	}
+	if err := cmd.Flags().Set("file", outPath); err != nil {
+		t.Fatalf("set file flag: %v", err)
+	}
+	for _, s := range statuses {
+		if err := cmd.Flags().Set("status", s); err != nil {
+			t.Fatalf("set status flag: %v", err)
+		}
+	}
+
+	if err := exportCmd.RunE(cmd, nil); err != nil {
+		t.Fatalf("exportCmd.RunE: %v", err)
+	}
+	return outPath
+}
+
+// importViaFormat imports the file at path into a fresh database using the
+// JSON or jsonl path and returns the resulting database.
+func importViaFormat(t *testing.T, path, format string) *sql.DB {
+	t.Helper()
+
+	dst := newTestDB(t)
+	switch format {
+	case "json":
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+		var export model.ExportData
+		if err := json.Unmarshal(raw, &export); err != nil {
+			t.Fatalf("Unmarshal: %v", err)
+		}
+		if errs := validateExportData(&export); len(errs) > 0 {
+			t.Fatalf("validateExportData: %v", errs)
+		}
+		if _, err := doImport(dst, &export, false); err != nil {
+			t.Fatalf("doImport: %v", err)
+		}
+	case "jsonl":
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		errs, err := validateJSONL(f)
+		f.Close()
+		if err != nil || len(errs) > 0 {
+			t.Fatalf("validateJSONL: err=%v errs=%v", err, errs)
+		}
+		if _, err := importJSONLFile(dst, path, false); err != nil {
+			t.Fatalf("importJSONLFile: %v", err)
+		}
+	}
+	return dst
+}
+
+// snapshotDB serializes every exported table of conn so two databases can be
+// compared for equality.
+func snapshotDB(t *testing.T, conn *sql.DB) string {
+	t.Helper()
+	b, err := json.Marshal(buildExport(t, conn))
+	if err != nil {
+		t.Fatalf("Marshal snapshot: %v", err)
+	}
+	return string(b)
+}
+
+func TestJSONLRoundTripMatchesJSON(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		statuses []string
+	}{
+		{"unfiltered", nil},
+		{"filtered", []string{"done", "todo"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			src := newTestDB(t)
+			seedJSONLFixture(t, src)
+
+			viaJSON := importViaFormat(t, runFormatExport(t, src, "json", tc.statuses), "json")
+			viaJSONL := importViaFormat(t, runFormatExport(t, src, "jsonl", tc.statuses), "jsonl")
+
+			want, got := snapshotDB(t, viaJSON), snapshotDB(t, viaJSONL)
+			if want != got {
+				t.Errorf("jsonl import differs from JSON import\njson:  %s\njsonl: %s", want, got)
+			}
+			if tc.statuses == nil && got != snapshotDB(t, src) {
+				t.Errorf("unfiltered jsonl round trip differs from source database")
+			}
+		})
+	}
+}
+
+func TestWriteExportJSONLHeaderAndRecordTypes(t *testing.T) {
+	src := newTestDB(t)
+	seedJSONLFixture(t, src)
+
+	var buf bytes.Buffer
+	if err := writeExportJSONL(&buf, src, nil, nil); err != nil {
+		t.Fatalf("writeExportJSONL: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	var header struct {
+		Type string          `json:"type"`
+		Data jsonlHeaderData `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatalf("Unmarshal header: %v", err)
+	}
+	if header.Type != jsonlHeader || header.Data.Version != 1 || header.Data.ExportedAt == "" {
+		t.Fatalf("unexpected header: %+v", header)
+	}
+	if header.Data.Counts[jsonlIssue] != 3 {
+		t.Errorf("expected header to count 3 issues, got %d", header.Data.Counts[jsonlIssue])
+	}
+
+	seen := make(map[string]int)
+	for _, line := range lines[1:] {
+		var rec jsonlRecord
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("Unmarshal record %q: %v", line, err)
+		}
+		seen[rec.Type]++
+	}
+	for typ, want := range header.Data.Counts {
+		if seen[typ] != want {
+			t.Errorf("%s: header count %d, found %d records", typ, want, seen[typ])
+		}
+	}
+	for _, typ := range []string{jsonlIssue, jsonlComment, jsonlLabel, jsonlMilestone, jsonlRelationType, jsonlRelation, jsonlIssueLabel, jsonlIssueFile} {
+		if seen[typ] == 0 {
+			t.Errorf("expected at least one %s record", typ)
+		}
+	}
+}
+
+func TestValidateJSONLDetectsTruncationAndMissingHeader(t *testing.T) {
+	src := newTestDB(t)
+	seedJSONLFixture(t, src)
+
+	var buf bytes.Buffer
+	if err := writeExportJSONL(&buf, src, nil, nil); err != nil {
+		t.Fatalf("writeExportJSONL: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+
+	truncated := strings.Join(lines[:len(lines)-1], "\n")
+	errs, err := validateJSONL(strings.NewReader(truncated))
+	if err != nil {
+		t.Fatalf("validateJSONL: %v", err)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0], "header declares") {
+		t.Errorf("expected a single count mismatch error, got %v", errs)
+	}
+
+	headless := strings.Join(lines[1:], "\n")
+	if _, err := validateJSONL(strings.NewReader(headless)); err == nil {
+		t.Error("expected an error for a file without a header record")
+	}
+}
+
+func TestDoImportJSONLRollsBackOnFailure(t *testing.T) {
+	dst := newTestDB(t)
+	createIssue(t, dst, "must survive", model.StatusTodo, model.PriorityHigh)
+
+	// A comment on a nonexistent issue violates the foreign key.
+	input := `{"type":"header","data":{"version":1,"exported_at":"2026-01-01T00:00:00Z","counts":{}}}
+{"type":"comment","data":{"id":1,"issue_id":"DKT-999","body":"orphan","author":"x","created_at":"2026-01-01T00:00:00Z"}}
+`
+	if _, err := doImportJSONL(dst, strings.NewReader(input), true); err == nil {
+		t.Fatal("expected doImportJSONL to fail")
+	}
+
+	issues, err := db.ListAllIssues(dst)
+	if err != nil {
+		t.Fatalf("ListAllIssues: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Title != "must survive" {
+		t.Errorf("expected replace to roll back, got %d issues", len(issues))
+	}
+}
+
+func TestWriteIssuesJSONLOneIssuePerLine(t *testing.T) {
+	src := newTestDB(t)
+	seedJSONLFixture(t, src)
+
+	tests := []struct {
+		name     string
+		statuses []string
+		want     []string
+	}{
+		{"all issues", nil, []string{"DKT-1", "DKT-2", "DKT-3"}},
+		{"filtered", []string{"in-progress"}, []string{"DKT-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeIssuesJSONL(&buf, src, tt.statuses, nil); err != nil {
+				t.Fatalf("writeIssuesJSONL: %v", err)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			if len(lines) != len(tt.want) {
+				t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(tt.want), buf.String())
+			}
+			for i, line := range lines {
+				var issue model.Issue
+				if err := json.Unmarshal([]byte(line), &issue); err != nil {
+					t.Fatalf("line %d does not parse as an issue: %v\n%s", i+1, err, line)
+				}
+				if id := model.FormatID(issue.ID); id != tt.want[i] {
+					t.Errorf("line %d is %s, want %s", i+1, id, tt.want[i])
+				}
+				if issue.ID == 2 && (!slices.Equal(issue.Labels, []string{"backend"}) || len(issue.Files) != 2) {
+					t.Errorf("DKT-2 labels = %v, files = %v; want them inlined", issue.Labels, issue.Files)
+				}
+			}
+		})
+	}
+}
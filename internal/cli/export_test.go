@@ -1,7 +1,13 @@
 package cli
 
 import (
+	"bytes"
+	"database/sql"
 	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -53,7 +59,7 @@ func TestRenderExportCSVNeutralizesFormulaInjection(t *testing.T) {
 		},
 	}
 
-	out, err := renderExportCSV(issues)
+	out, err := renderExportCSV(issues, nil, 0, nil)
 	if err != nil {
 		t.Fatalf("renderExportCSV: %v", err)
 	}
@@ -77,3 +83,365 @@ func TestRenderExportCSVNeutralizesFormulaInjection(t *testing.T) {
 		t.Errorf("assignee cell = %q, want %q", got, "'@alice")
 	}
 }
+
+func TestRenderExportCSVColumnSubsetAndDelimiter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	issues := []*model.Issue{
+		{ID: 7, Title: "first; with delimiter", Status: model.StatusTodo, Priority: model.PriorityHigh, Kind: model.IssueKindBug, CreatedAt: now, UpdatedAt: now},
+		{ID: 9, Title: "second", Status: model.StatusDone, Priority: model.PriorityLow, Kind: model.IssueKindTask, CreatedAt: now, UpdatedAt: now},
+	}
+
+	out, err := renderExportCSV(issues, []string{"status", "id", "title"}, ';', nil)
+	if err != nil {
+		t.Fatalf("renderExportCSV: %v", err)
+	}
+
+	r := csv.NewReader(strings.NewReader(out))
+	r.Comma = ';'
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("parse CSV: %v", err)
+	}
+
+	want := [][]string{
+		{"status", "id", "title"},
+		{"todo", "DKT-7", "first; with delimiter"},
+		{"done", "DKT-9", "second"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d:\n%s", len(records), len(want), out)
+	}
+	for i := range want {
+		if strings.Join(records[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("record %d = %q, want %q", i, records[i], want[i])
+		}
+	}
+	if !strings.HasPrefix(out, "status;id;title\n") {
+		t.Errorf("expected ';'-delimited header, got %q", out)
+	}
+}
+
+func TestRenderExportCSVDefaultsToAllColumns(t *testing.T) {
+	out, err := renderExportCSV(nil, nil, 0, nil)
+	if err != nil {
+		t.Fatalf("renderExportCSV: %v", err)
+	}
+	if want := strings.Join(csvColumns, ",") + "\n"; out != want {
+		t.Errorf("header = %q, want %q", out, want)
+	}
+}
+
+func TestRenderExportCSVUnknownColumn(t *testing.T) {
+	_, err := renderExportCSV(nil, []string{"id", "bogus"}, 0, nil)
+	if err == nil || !strings.Contains(err.Error(), `unknown CSV column "bogus"`) {
+		t.Errorf("expected unknown column error, got %v", err)
+	}
+}
+
+func TestParseCSVDelimiter(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    rune
+		wantErr bool
+	}{
+		{",", ',', false},
+		{";", ';', false},
+		{`\t`, '\t', false},
+		{"", 0, false},
+		{"ab", 0, true},
+		{`"`, 0, true},
+		{"\n", 0, true},
+	}
+	for _, tc := range cases {
+		got, err := parseCSVDelimiter(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("parseCSVDelimiter(%q) error = %v, wantErr %v", tc.in, err, tc.wantErr)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("parseCSVDelimiter(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParseDateFormat(t *testing.T) {
+	ts := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"", "2026-01-02T15:04:05Z"},
+		{"rfc3339", "2026-01-02T15:04:05Z"},
+		{"date", "2026-01-02"},
+		{"DATE", "2026-01-02"},
+		{"datetime", "2026-01-02 15:04:05"},
+		{"unix", "1767366245"},
+		{"02/01/2006", "02/01/2026"},
+	}
+	for _, c := range cases {
+		formatDate, err := parseDateFormat(c.in)
+		if err != nil {
+			t.Errorf("parseDateFormat(%q): unexpected error %v", c.in, err)
+			continue
+		}
+		if got := formatDate(ts); got != c.want {
+			t.Errorf("parseDateFormat(%q) formatted %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestParseDateFormatInvalid(t *testing.T) {
+	for _, in := range []string{"iso", "yyyy-mm-dd"} {
+		if _, err := parseDateFormat(in); err == nil || !strings.Contains(err.Error(), "invalid date format") {
+			t.Errorf("parseDateFormat(%q) = %v, want invalid date format error", in, err)
+		}
+	}
+}
+
+func TestRenderExportDateFormat(t *testing.T) {
+	created := time.Date(2026, 1, 2, 9, 30, 0, 0, time.UTC)
+	issues := []*model.Issue{
+		{ID: 1, Title: "dated", Status: model.StatusTodo, Priority: model.PriorityLow, Kind: model.IssueKindTask, CreatedAt: created, UpdatedAt: created.Add(48 * time.Hour)},
+	}
+	formatDate, err := parseDateFormat("date")
+	if err != nil {
+		t.Fatalf("parseDateFormat: %v", err)
+	}
+
+	csvOut, err := renderExportCSV(issues, []string{"id", "created_at", "updated_at", "completed_at"}, 0, formatDate)
+	if err != nil {
+		t.Fatalf("renderExportCSV: %v", err)
+	}
+	if want := "id,created_at,updated_at,completed_at\nDKT-1,2026-01-02,2026-01-04,\n"; csvOut != want {
+		t.Errorf("CSV = %q, want %q", csvOut, want)
+	}
+
+	mdOut, err := renderExportMarkdown(issues, nil, nil, formatDate)
+	if err != nil {
+		t.Fatalf("renderExportMarkdown: %v", err)
+	}
+	for _, want := range []string{"- **Created:** 2026-01-02\n", "- **Updated:** 2026-01-04\n"} {
+		if !strings.Contains(mdOut, want) {
+			t.Errorf("Markdown missing %q:\n%s", want, mdOut)
+		}
+	}
+}
+
+func TestRenderExportMarkdownTOCAndRelations(t *testing.T) {
+	now := time.Date(2026, 1, 2, 9, 30, 0, 0, time.UTC)
+	issues := []*model.Issue{
+		{ID: 1, Title: "Schema", Status: model.StatusTodo, Priority: model.PriorityHigh, Kind: model.IssueKindTask, CreatedAt: now, UpdatedAt: now},
+		{ID: 2, Title: "API", Status: model.StatusBacklog, Priority: model.PriorityLow, Kind: model.IssueKindTask, CreatedAt: now, UpdatedAt: now},
+		{ID: 3, Title: "Docs", Status: model.StatusDone, Priority: model.PriorityLow, Kind: model.IssueKindTask, CreatedAt: now, UpdatedAt: now},
+	}
+	relations := []model.Relation{
+		{ID: 1, SourceIssueID: 1, TargetIssueID: 2, RelationType: model.RelationBlocks},
+	}
+
+	out, err := renderExportMarkdown(issues, nil, relations, nil)
+	if err != nil {
+		t.Fatalf("renderExportMarkdown: %v", err)
+	}
+
+	toc, body, ok := strings.Cut(out, "## backlog")
+	if !ok {
+		t.Fatalf("missing backlog section:\n%s", out)
+	}
+	for _, issue := range issues {
+		link := fmt.Sprintf("(#dkt-%d)", issue.ID)
+		if n := strings.Count(toc, link); n != 1 {
+			t.Errorf("TOC has %d links %s, want 1:\n%s", n, link, toc)
+		}
+		anchor := fmt.Sprintf("<a id=\"dkt-%d\"></a>\n\n### DKT-%d:", issue.ID, issue.ID)
+		if !strings.Contains(body, anchor) {
+			t.Errorf("missing anchored heading %q:\n%s", anchor, body)
+		}
+	}
+
+	// Each side of the relation lists it under its own heading.
+	section := func(id int) string {
+		_, rest, _ := strings.Cut(body, fmt.Sprintf("### DKT-%d:", id))
+		sec, _, _ := strings.Cut(rest, "### ")
+		return sec
+	}
+	if want := "- → blocks [DKT-2](#dkt-2)\n"; !strings.Contains(section(1), want) {
+		t.Errorf("DKT-1 section missing %q:\n%s", want, section(1))
+	}
+	if want := "- ← blocked\\_by [DKT-1](#dkt-1)\n"; !strings.Contains(section(2), want) {
+		t.Errorf("DKT-2 section missing %q:\n%s", want, section(2))
+	}
+	if strings.Contains(section(3), "Relations") {
+		t.Errorf("DKT-3 has no relations but shows a Relations list:\n%s", section(3))
+	}
+}
+
+// runStableExport exports conn with --stable and returns the raw output.
+func runStableExport(t *testing.T, conn *sql.DB) []byte {
+	t.Helper()
+
+	cmd := cmdWithDB(conn)
+	cmd.Flags().StringP("format", "o", "json", "")
+	cmd.Flags().StringP("file", "f", "", "")
+	cmd.Flags().Bool("stable", false, "")
+	path := filepath.Join(t.TempDir(), "export.json")
+	cmd.Flags().Set("file", path)
+	cmd.Flags().Set("stable", "true")
+	if err := exportCmd.RunE(cmd, nil); err != nil {
+		t.Fatalf("exportCmd.RunE: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	return raw
+}
+
+func TestStableExportIsByteIdentical(t *testing.T) {
+	conn := newTestDB(t)
+	seedJSONLFixture(t, conn)
+
+	first := runStableExport(t, conn)
+	second := runStableExport(t, conn)
+
+	if !bytes.Equal(first, second) {
+		t.Errorf("stable exports differ:\nfirst:  %s\nsecond: %s", first, second)
+	}
+	if bytes.Contains(first, []byte(`"exported_at"`)) {
+		t.Errorf("stable export contains exported_at:\n%s", first)
+	}
+}
+
+func TestStableExportRoundTripIsByteIdentical(t *testing.T) {
+	src := newTestDB(t)
+	seedJSONLFixture(t, src)
+
+	exported := runStableExport(t, src)
+	var data model.ExportData
+	if err := json.Unmarshal(exported, &data); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	dst := newTestDB(t)
+	if _, err := doImport(dst, &data, false); err != nil {
+		t.Fatalf("doImport: %v", err)
+	}
+
+	if reexported := runStableExport(t, dst); !bytes.Equal(exported, reexported) {
+		t.Errorf("re-export of imported stable export differs:\noriginal: %s\nre-export: %s", exported, reexported)
+	}
+}
+
+func TestSortExportDataOrdersCollections(t *testing.T) {
+	data := model.ExportData{
+		Issues:             []*model.Issue{{ID: 3}, {ID: 1}, {ID: 2}},
+		Comments:           []*model.Comment{{ID: 9}, {ID: 4}},
+		Labels:             []*model.Label{{ID: 2, Name: "a"}, {ID: 1, Name: "b"}},
+		IssueLabelMappings: []model.IssueLabelMapping{{IssueID: 2, LabelID: 1}, {IssueID: 1, LabelID: 2}, {IssueID: 1, LabelID: 1}},
+		IssueFileMappings:  []model.IssueFileMapping{{IssueID: 1, FilePath: "b.go"}, {IssueID: 1, FilePath: "a.go"}},
+	}
+	sortExportData(&data)
+
+	if got := []int{data.Issues[0].ID, data.Issues[1].ID, data.Issues[2].ID}; got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("issues ordered %v, want [1 2 3]", got)
+	}
+	if data.Comments[0].ID != 4 {
+		t.Errorf("comments ordered %d first, want 4", data.Comments[0].ID)
+	}
+	if data.Labels[0].ID != 1 {
+		t.Errorf("labels ordered %d first, want 1", data.Labels[0].ID)
+	}
+	wantMappings := []model.IssueLabelMapping{{IssueID: 1, LabelID: 1}, {IssueID: 1, LabelID: 2}, {IssueID: 2, LabelID: 1}}
+	for i, m := range wantMappings {
+		if data.IssueLabelMappings[i] != m {
+			t.Errorf("label mappings = %v, want %v", data.IssueLabelMappings, wantMappings)
+			break
+		}
+	}
+	if data.IssueFileMappings[0].FilePath != "a.go" {
+		t.Errorf("file mappings = %v, want a.go first", data.IssueFileMappings)
+	}
+}
+
+func TestFilterExportSince(t *testing.T) {
+	cutoff := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	before, after := cutoff.Add(-time.Hour), cutoff.Add(time.Hour)
+	milestone := 7
+	data := model.ExportData{
+		Issues: []*model.Issue{
+			{ID: 1, UpdatedAt: before},
+			{ID: 2, UpdatedAt: after, MilestoneID: &milestone},
+			{ID: 3, UpdatedAt: cutoff},
+		},
+		Comments:           []*model.Comment{{ID: 1, IssueID: 2, CreatedAt: before}, {ID: 2, IssueID: 2, CreatedAt: after}},
+		Relations:          []model.Relation{{ID: 1, SourceIssueID: 1, TargetIssueID: 3, CreatedAt: after}, {ID: 2, SourceIssueID: 1, TargetIssueID: 2, CreatedAt: before}},
+		Labels:             []*model.Label{{ID: 1, Name: "old"}, {ID: 2, Name: "new"}},
+		Milestones:         []*model.Milestone{{ID: milestone}, {ID: 8}},
+		IssueLabelMappings: []model.IssueLabelMapping{{IssueID: 1, LabelID: 1}, {IssueID: 2, LabelID: 2}},
+		IssueFileMappings:  []model.IssueFileMapping{{IssueID: 1, FilePath: "a.go"}, {IssueID: 2, FilePath: "b.go"}},
+		ActivityLog:        []*model.Activity{{ID: 1, IssueID: 2, CreatedAt: before}, {ID: 2, IssueID: 2, CreatedAt: after}, {ID: 3, IssueID: 1, CreatedAt: after}},
+	}
+	filterExportSince(&data, cutoff)
+
+	if data.Since != "2026-03-01T12:00:00Z" {
+		t.Errorf("Since = %q, want the cutoff", data.Since)
+	}
+	// The cutoff itself is not after the cutoff.
+	if len(data.Issues) != 1 || data.Issues[0].ID != 2 {
+		t.Errorf("issues = %v, want only DKT-2", data.Issues)
+	}
+	if len(data.Comments) != 1 || data.Comments[0].ID != 2 {
+		t.Errorf("comments = %v, want only comment 2", data.Comments)
+	}
+	// A new relation is kept even though neither of its issues changed.
+	if len(data.Relations) != 1 || data.Relations[0].ID != 1 {
+		t.Errorf("relations = %v, want only relation 1", data.Relations)
+	}
+	if len(data.IssueLabelMappings) != 1 || data.IssueLabelMappings[0].IssueID != 2 {
+		t.Errorf("label mappings = %v, want only DKT-2's", data.IssueLabelMappings)
+	}
+	if len(data.IssueFileMappings) != 1 || data.IssueFileMappings[0].FilePath != "b.go" {
+		t.Errorf("file mappings = %v, want only DKT-2's", data.IssueFileMappings)
+	}
+	if len(data.Labels) != 1 || data.Labels[0].Name != "new" {
+		t.Errorf("labels = %v, want only the label DKT-2 uses", data.Labels)
+	}
+	if len(data.Milestones) != 1 || data.Milestones[0].ID != milestone {
+		t.Errorf("milestones = %v, want only DKT-2's", data.Milestones)
+	}
+	if len(data.ActivityLog) != 1 || data.ActivityLog[0].ID != 2 {
+		t.Errorf("activity = %v, want only DKT-2's newer entry", data.ActivityLog)
+	}
+}
+
+func TestExportSinceRecordsCutoff(t *testing.T) {
+	conn := newTestDB(t)
+	createIssue(t, conn, "Fresh", model.StatusTodo, model.PriorityLow)
+
+	cmd := cmdWithDB(conn)
+	cmd.Flags().StringP("format", "o", "json", "")
+	cmd.Flags().StringP("file", "f", "", "")
+	cmd.Flags().String("since", "", "")
+	path := filepath.Join(t.TempDir(), "delta.json")
+	cmd.Flags().Set("file", path)
+	cmd.Flags().Set("since", "2000-01-01T00:00:00+02:00")
+	if err := exportCmd.RunE(cmd, nil); err != nil {
+		t.Fatalf("exportCmd.RunE: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	var export model.ExportData
+	if err := json.Unmarshal(raw, &export); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if export.Since != "1999-12-31T22:00:00Z" || len(export.Issues) != 1 {
+		t.Errorf("since, issues = %q, %d; want 1999-12-31T22:00:00Z, 1", export.Since, len(export.Issues))
+	}
+
+	cmd.Flags().Set("since", "yesterday")
+	if err := exportCmd.RunE(cmd, nil); err == nil {
+		t.Error("export with --since yesterday succeeded, want a validation error")
+	}
+}
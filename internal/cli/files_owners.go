@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+	"github.com/spf13/cobra"
+)
+
+var filesCmd = &cobra.Command{
+	Use:   "files",
+	Short: "Query files attached to issues",
+}
+
+var filesOwnersCmd = &cobra.Command{
+	Use:   "owners <path-or-glob>",
+	Short: "List open issues attached to matching files",
+	Long: `List open issues attached to matching files, to see pending work before
+editing them.
+
+A plain argument matches attached paths by prefix, so 'internal/db/' covers
+every file under that directory. An argument containing glob characters
+(*, ? or [) matches attached paths against the pattern, where * does not
+cross directory separators. JSON output maps each file to its issues.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runFilesOwners(cmd, args, getWriter(cmd))
+	},
+}
+
+func runFilesOwners(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	var owners map[string][]*model.Issue
+	var err error
+	if isFileGlob(args[0]) {
+		owners, err = db.FindIssuesByFileGlob(conn, args[0])
+	} else {
+		owners, err = db.FindIssuesByFilePrefix(conn, args[0])
+	}
+	if err != nil {
+		if errors.Is(err, db.ErrValidation) {
+			return cmdErr(err, output.ErrValidation)
+		}
+		return cmdErr(fmt.Errorf("finding issues by file: %w", err), output.ErrGeneral)
+	}
+
+	if len(owners) == 0 {
+		quiet, _ := cmd.Flags().GetBool("quiet")
+		msg := render.EmptyState(
+			fmt.Sprintf("No open issues attached to files matching %s", args[0]),
+			"",
+			quiet,
+		)
+		w.Success(owners, msg)
+		return nil
+	}
+
+	if w.JSONMode {
+		w.Success(owners, "")
+		return nil
+	}
+
+	layout, err := getLayout(cmd)
+	if err != nil {
+		return err
+	}
+	w.Success(owners, renderFileOwners(owners, layout))
+	return nil
+}
+
+// renderFileOwners renders each file, sorted by path, followed by its issues.
+func renderFileOwners(owners map[string][]*model.Issue, layout render.LayoutOptions) string {
+	files := make([]string, 0, len(owners))
+	for f := range owners {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+
+	colors := render.ColorsEnabled()
+	fileStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
+
+	var sb strings.Builder
+	for _, f := range files {
+		if colors {
+			fmt.Fprintf(&sb, "%s\n", fileStyle.Render("▸ "+f))
+		} else {
+			fmt.Fprintf(&sb, "%s\n", f)
+		}
+		for _, issue := range owners[f] {
+			fmt.Fprintf(&sb, "  %s\n", layout.IssueOneLine(issue))
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func init() {
+	filesCmd.AddCommand(filesOwnersCmd)
+	rootCmd.AddCommand(filesCmd)
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestFilesOwnersJSON(t *testing.T) {
+	conn := newTestDB(t)
+	dbIssue := createIssueWithFile(t, conn, "db work", "internal/db/files.go")
+	renderIssue := createIssueWithFile(t, conn, "render work", "internal/render/detail.go")
+
+	owners := func(arg string) map[string][]string {
+		t.Helper()
+		w, buf := bufWriter(true)
+		if err := runFilesOwners(cmdWithDB(conn), []string{arg}, w); err != nil {
+			t.Fatalf("runFilesOwners(%q): %v", arg, err)
+		}
+		var env struct {
+			Data map[string][]struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+			t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+		}
+		got := make(map[string][]string)
+		for f, issues := range env.Data {
+			for _, i := range issues {
+				got[f] = append(got[f], i.ID)
+			}
+		}
+		return got
+	}
+
+	got := owners("internal/db/")
+	if len(got) != 1 || len(got["internal/db/files.go"]) != 1 || got["internal/db/files.go"][0] != model.FormatID(dbIssue) {
+		t.Errorf("prefix owners = %v", got)
+	}
+
+	got = owners("internal/*/*.go")
+	if len(got) != 2 || got["internal/render/detail.go"][0] != model.FormatID(renderIssue) {
+		t.Errorf("glob owners = %v", got)
+	}
+
+	if got := owners("cmd/"); len(got) != 0 {
+		t.Errorf("owners with no match = %v, want empty", got)
+	}
+}
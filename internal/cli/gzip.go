@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+)
+
+// gzipExt is the file extension that selects gzip compression on export and
+// is ignored when detecting the format of an import file.
+const gzipExt = ".gz"
+
+// hasGzipExt reports whether path ends in .gz, in any case.
+func hasGzipExt(path string) bool {
+	return strings.HasSuffix(strings.ToLower(path), gzipExt)
+}
+
+// exportOutput is the destination of an export: a file, or stdout when no
+// path is given, optionally gzip-compressed. Close must be called to flush
+// the compressed stream.
+type exportOutput struct {
+	io.Writer
+	gz   *gzip.Writer
+	file *os.File
+}
+
+// createExportOutput creates the file at path, or uses stdout when path is
+// empty, compressing what is written when compress is set.
+func createExportOutput(path string, compress bool) (*exportOutput, error) {
+	out := &exportOutput{Writer: os.Stdout}
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return nil, err
+		}
+		out.file = f
+		out.Writer = f
+	}
+	if compress {
+		out.gz = gzip.NewWriter(out.Writer)
+		out.Writer = out.gz
+	}
+	return out, nil
+}
+
+// Close flushes the gzip stream and closes the file, if any.
+func (o *exportOutput) Close() error {
+	var err error
+	if o.gz != nil {
+		err = o.gz.Close()
+	}
+	if o.file != nil {
+		if cerr := o.file.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// openImportFile opens path for reading, transparently decompressing it when
+// it starts with the gzip magic number.
+func openImportFile(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(f)
+	magic, _ := br.Peek(2)
+	if len(magic) < 2 || magic[0] != 0x1f || magic[1] != 0x8b {
+		return readCloser{br, f}, nil
+	}
+	gz, err := gzip.NewReader(br)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return readCloser{gz, closerFunc(func() error {
+		gzErr := gz.Close()
+		if err := f.Close(); err != nil {
+			return err
+		}
+		return gzErr
+	})}, nil
+}
+
+// readCloser pairs a reader with the closer of its underlying resource.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// closerFunc adapts a function to io.Closer.
+type closerFunc func() error
+
+func (f closerFunc) Close() error { return f() }
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGzipExportImportRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name, format, file string
+		gzipFlag           bool
+	}{
+		{"json by extension", "json", "export.json.gz", false},
+		{"jsonl by flag", "jsonl", "export.jsonl", true},
+		{"csv by flag", "csv", "export.csv", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			src := newTestDB(t)
+			seedJSONLFixture(t, src)
+
+			cmd := cmdWithDB(src)
+			cmd.Flags().StringP("format", "o", "json", "")
+			cmd.Flags().StringP("file", "f", "", "")
+			cmd.Flags().StringSliceP("status", "s", nil, "")
+			cmd.Flags().StringSliceP("label", "l", nil, "")
+			cmd.Flags().StringSlice("columns", nil, "")
+			cmd.Flags().String("delimiter", ",", "")
+			cmd.Flags().Bool("gzip", false, "")
+			path := filepath.Join(t.TempDir(), tc.file)
+			cmd.Flags().Set("format", tc.format)
+			cmd.Flags().Set("file", path)
+			if tc.gzipFlag {
+				cmd.Flags().Set("gzip", "true")
+			}
+			if err := exportCmd.RunE(cmd, nil); err != nil {
+				t.Fatalf("exportCmd.RunE: %v", err)
+			}
+
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("ReadFile: %v", err)
+			}
+			if !bytes.HasPrefix(raw, []byte{0x1f, 0x8b}) {
+				t.Fatalf("export is not gzipped: %q", raw[:min(len(raw), 16)])
+			}
+			if tc.format == "csv" {
+				r, err := readImportFile(path)
+				if err != nil || !bytes.HasPrefix(r, []byte("id,")) {
+					t.Errorf("decompressed CSV = %q, %v; want a header row", r[:min(len(r), 16)], err)
+				}
+				return
+			}
+
+			dst := newTestDB(t)
+			imp := cmdWithDB(dst)
+			imp.Flags().Bool("merge", false, "")
+			imp.Flags().Bool("replace", false, "")
+			imp.Flags().String("format", "", "")
+			imp.Flags().Set("json", "true")
+			if err := importCmd.RunE(imp, []string{path}); err != nil {
+				t.Fatalf("importCmd.RunE: %v", err)
+			}
+
+			if want, got := snapshotDB(t, src), snapshotDB(t, dst); want != got {
+				t.Errorf("gzip round trip differs\nsource:   %s\nimported: %s", want, got)
+			}
+		})
+	}
+}
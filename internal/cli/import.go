@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/ALT-F4-LLC/docket/internal/db"
 	"github.com/ALT-F4-LLC/docket/internal/model"
@@ -22,8 +25,13 @@ type importResult struct {
 
 var importCmd = &cobra.Command{
 	Use:   "import <file>",
-	Short: "Import issues from a JSON export file",
-	Args:  cobra.ExactArgs(1),
+	Short: "Import issues from a JSON, JSON Lines or CSV export file (optionally gzipped)",
+	Long: `Import issues from a file written by 'docket export', optionally gzipped.
+
+JSON and JSON Lines exports restore the whole database. A CSV export restores
+its issues with their labels and files; it must have been written with RFC 3339
+dates and must include the id and title columns.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		w := getWriter(cmd)
 		conn := getDB(cmd)
@@ -35,24 +43,99 @@ var importCmd = &cobra.Command{
 			return cmdErr(fmt.Errorf("--merge and --replace are mutually exclusive"), output.ErrValidation)
 		}
 
-		// Read and parse the export file.
-		data, err := os.ReadFile(args[0])
+		format, _ := cmd.Flags().GetString("format")
+		if format == "" {
+			format = "json"
+			name := args[0]
+			if hasGzipExt(name) {
+				name = name[:len(name)-len(gzipExt)]
+			}
+			switch strings.ToLower(filepath.Ext(name)) {
+			case ".jsonl":
+				format = "jsonl"
+			case ".csv":
+				format = "csv"
+			}
+		}
+
+		delimiterFlag, _ := cmd.Flags().GetString("delimiter")
+		if format != "csv" && cmd.Flags().Changed("delimiter") {
+			return cmdErr(fmt.Errorf("--delimiter requires --format csv"), output.ErrValidation)
+		}
+		delimiter, err := parseCSVDelimiter(delimiterFlag)
 		if err != nil {
-			return cmdErr(fmt.Errorf("reading file: %w", err), output.ErrGeneral)
+			return cmdErr(err, output.ErrValidation)
 		}
 
-		var export model.ExportData
-		if err := json.Unmarshal(data, &export); err != nil {
-			return cmdErr(fmt.Errorf("parsing JSON: %w", err), output.ErrValidation)
+		decrypt, _ := cmd.Flags().GetBool("decrypt")
+		if format != "json" && decrypt {
+			return cmdErr(fmt.Errorf("--decrypt requires a JSON export"), output.ErrValidation)
+		}
+		if !decrypt && cmd.Flags().Changed("passphrase-file") {
+			return cmdErr(fmt.Errorf("--passphrase-file requires --decrypt"), output.ErrValidation)
 		}
 
-		// Validate export data before any mutations.
-		if errs := validateExportData(&export); len(errs) > 0 {
-			msg := fmt.Sprintf("validation failed with %d error(s):", len(errs))
-			for _, e := range errs {
-				msg += "\n  - " + e
+		var export model.ExportData
+		var csvIssues []*model.Issue
+		switch format {
+		case "json":
+			// Read and parse the export file.
+			data, err := readImportFile(args[0])
+			if err != nil {
+				return cmdErr(fmt.Errorf("reading file: %w", err), output.ErrGeneral)
+			}
+			data, err = openSealedImport(cmd, data)
+			if err != nil {
+				return err
+			}
+
+			if err := json.Unmarshal(data, &export); err != nil {
+				// Decoding stops at the first unparseable timestamp; list
+				// them all so a hand-edited file can be fixed in one go.
+				if errs := validateExportTimestamps(data); len(errs) > 0 {
+					return cmdErr(importValidationError(errs), output.ErrValidation)
+				}
+				return cmdErr(fmt.Errorf("parsing JSON: %w", err), output.ErrValidation)
+			}
+
+			// Validate export data before any mutations.
+			if errs := validateExportData(&export); len(errs) > 0 {
+				return cmdErr(importValidationError(errs), output.ErrValidation)
+			}
+		case "jsonl":
+			// Validate in a first streaming pass so nothing is mutated (or
+			// confirmed) for a file that would fail part way through.
+			f, err := openImportFile(args[0])
+			if err != nil {
+				return cmdErr(fmt.Errorf("reading file: %w", err), output.ErrGeneral)
 			}
-			return cmdErr(fmt.Errorf("%s", msg), output.ErrValidation)
+			errs, err := validateJSONL(f)
+			f.Close()
+			if err != nil {
+				return cmdErr(fmt.Errorf("parsing JSON Lines: %w", err), output.ErrValidation)
+			}
+			if len(errs) > 0 {
+				return cmdErr(importValidationError(errs), output.ErrValidation)
+			}
+		case "csv":
+			f, err := openImportFile(args[0])
+			if err != nil {
+				return cmdErr(fmt.Errorf("reading file: %w", err), output.ErrGeneral)
+			}
+			issues, errs, err := parseImportCSV(f, delimiter)
+			f.Close()
+			if err != nil {
+				return cmdErr(fmt.Errorf("parsing CSV: %w", err), output.ErrValidation)
+			}
+			if len(errs) > 0 {
+				return cmdErr(importValidationError(errs), output.ErrValidation)
+			}
+			csvIssues = issues
+		default:
+			return cmdErr(
+				fmt.Errorf("invalid format %q: must be one of json, jsonl, csv", format),
+				output.ErrValidation,
+			)
 		}
 
 		// Determine import mode.
@@ -92,7 +175,12 @@ var importCmd = &cobra.Command{
 			if err != nil {
 				return cmdErr(fmt.Errorf("checking database: %w", err), output.ErrGeneral)
 			}
-			if count > 0 {
+			// Trashed issues still hold their IDs until the trash is emptied.
+			trashed, err := db.CountTrashedIssues(conn)
+			if err != nil {
+				return cmdErr(fmt.Errorf("checking database: %w", err), output.ErrGeneral)
+			}
+			if count+trashed > 0 {
 				return cmdErr(
 					fmt.Errorf("database is not empty: use --merge to merge with existing data or --replace to replace it"),
 					output.ErrConflict,
@@ -100,8 +188,22 @@ var importCmd = &cobra.Command{
 			}
 		}
 
-		// Perform the import within a single transaction.
-		result, err := doImport(conn, &export, replace)
+		// Perform the import within a single transaction, re-running it if
+		// another process holds the write lock. The jsonl file is reopened on
+		// each attempt.
+		var result *importResult
+		err = db.WithRetry(func() error {
+			var err error
+			switch format {
+			case "jsonl":
+				result, err = importJSONLFile(conn, args[0], replace)
+			case "csv":
+				result, err = doImportCSV(conn, csvIssues, replace)
+			default:
+				result, err = doImport(conn, &export, replace)
+			}
+			return err
+		})
 		if err != nil {
 			return cmdErr(fmt.Errorf("importing data: %w", err), output.ErrGeneral)
 		}
@@ -119,6 +221,38 @@ var importCmd = &cobra.Command{
 	},
 }
 
+// importValidationError formats the collected validation failures of an
+// import file as a single error.
+func importValidationError(errs []string) error {
+	msg := fmt.Sprintf("validation failed with %d error(s):", len(errs))
+	for _, e := range errs {
+		msg += "\n  - " + e
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// readImportFile reads the whole import file at path, decompressing it if
+// it is gzipped.
+func readImportFile(path string) ([]byte, error) {
+	f, err := openImportFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+// importJSONLFile opens path, decompressing it if it is gzipped, and imports
+// it with doImportJSONL.
+func importJSONLFile(conn *sql.DB, path string, replace bool) (*importResult, error) {
+	f, err := openImportFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+	defer f.Close()
+	return doImportJSONL(conn, f, replace)
+}
+
 // validateExportData checks the export data for structural validity.
 func validateExportData(export *model.ExportData) []string {
 	var errs []string
@@ -130,41 +264,123 @@ func validateExportData(export *model.ExportData) []string {
 	// Issues are validated by UnmarshalJSON (status, priority, kind), but we
 	// re-validate here to collect all errors instead of failing on the first.
 	for _, issue := range export.Issues {
-		if err := model.ValidateStatus(issue.Status); err != nil {
-			errs = append(errs, fmt.Sprintf("issue %s: %s", model.FormatID(issue.ID), err))
-		}
-		if err := model.ValidatePriority(issue.Priority); err != nil {
-			errs = append(errs, fmt.Sprintf("issue %s: %s", model.FormatID(issue.ID), err))
-		}
-		if err := model.ValidateIssueKind(issue.Kind); err != nil {
-			errs = append(errs, fmt.Sprintf("issue %s: %s", model.FormatID(issue.ID), err))
-		}
+		errs = append(errs, validateImportIssue(issue)...)
+	}
+
+	// Relations may use the custom types the file declares as well as the
+	// types already registered in the database.
+	declared := make(map[model.RelationType]bool, len(export.RelationTypes))
+	for _, d := range export.RelationTypes {
+		errs = append(errs, validateImportRelationType(d)...)
+		declared[d.Name] = true
 	}
 
 	for _, rel := range export.Relations {
-		if err := model.ValidateRelationType(rel.RelationType); err != nil {
-			errs = append(errs, fmt.Sprintf("relation %d: %s", rel.ID, err))
-		}
+		errs = append(errs, validateImportRelation(rel, declared)...)
 	}
 
 	for _, p := range export.Proposals {
-		if err := model.ValidateCriticality(p.Criticality); err != nil {
-			errs = append(errs, fmt.Sprintf("proposal %s: %s", model.FormatProposalID(p.ID), err))
-		}
-		if err := model.ValidateProposalStatus(p.Status); err != nil {
-			errs = append(errs, fmt.Sprintf("proposal %s: %s", model.FormatProposalID(p.ID), err))
-		}
+		errs = append(errs, validateImportProposal(p)...)
 	}
 
 	for _, v := range export.Votes {
-		if err := model.ValidateVerdict(v.Verdict); err != nil {
-			errs = append(errs, fmt.Sprintf("vote %d: %s", v.ID, err))
+		errs = append(errs, validateImportVote(v)...)
+	}
+
+	return errs
+}
+
+// validateExportTimestamps returns an error for every timestamp of an issue,
+// comment or relation in the export file data that model.ParseTimestamp
+// cannot read. It returns nil when data does not hold export lists.
+func validateExportTimestamps(data []byte) []string {
+	var lists struct {
+		Issues    []map[string]json.RawMessage `json:"issues"`
+		Comments  []map[string]json.RawMessage `json:"comments"`
+		Relations []map[string]json.RawMessage `json:"relations"`
+	}
+	if err := json.Unmarshal(data, &lists); err != nil {
+		return nil
+	}
+
+	var errs []string
+	check := func(kind string, records []map[string]json.RawMessage, fields ...string) {
+		for _, rec := range records {
+			id := strings.Trim(string(rec["id"]), `"`)
+			for _, field := range fields {
+				value, ok := rec[field]
+				if !ok || string(value) == "null" {
+					continue
+				}
+				if _, err := model.ParseTimestampJSON(value); err != nil {
+					errs = append(errs, fmt.Sprintf("%s %s: %s: %s", kind, id, field, err))
+				}
+			}
 		}
 	}
+	check("issue", lists.Issues, "created_at", "updated_at", "started_at", "completed_at", "snoozed_until", "last_comment_at")
+	check("comment", lists.Comments, "created_at")
+	check("relation", lists.Relations, "created_at")
+	return errs
+}
 
+func validateImportIssue(issue *model.Issue) []string {
+	var errs []string
+	if err := model.ValidateStatus(issue.Status); err != nil {
+		errs = append(errs, fmt.Sprintf("issue %s: %s", model.FormatID(issue.ID), err))
+	}
+	if err := model.ValidatePriority(issue.Priority); err != nil {
+		errs = append(errs, fmt.Sprintf("issue %s: %s", model.FormatID(issue.ID), err))
+	}
+	if err := model.ValidateIssueKind(issue.Kind); err != nil {
+		errs = append(errs, fmt.Sprintf("issue %s: %s", model.FormatID(issue.ID), err))
+	}
 	return errs
 }
 
+func validateImportRelationType(d model.RelationTypeDef) []string {
+	var errs []string
+	if err := model.ValidateRelationTypeName(d.Name); err != nil {
+		errs = append(errs, fmt.Sprintf("relation type %q: %s", d.Name, err))
+	}
+	if d.Inverse != "" {
+		if err := model.ValidateRelationTypeName(model.RelationType(d.Inverse)); err != nil {
+			errs = append(errs, fmt.Sprintf("relation type %q: %s", d.Name, err))
+		}
+	}
+	return errs
+}
+
+// validateImportRelation checks rel's type against the built-in and
+// registered types, and the custom types declared by the import file.
+func validateImportRelation(rel model.Relation, declared map[model.RelationType]bool) []string {
+	if declared[rel.RelationType] {
+		return nil
+	}
+	if err := model.ValidateRelationType(rel.RelationType); err != nil {
+		return []string{fmt.Sprintf("relation %d: %s", rel.ID, err)}
+	}
+	return nil
+}
+
+func validateImportProposal(p *model.Proposal) []string {
+	var errs []string
+	if err := model.ValidateCriticality(p.Criticality); err != nil {
+		errs = append(errs, fmt.Sprintf("proposal %s: %s", model.FormatProposalID(p.ID), err))
+	}
+	if err := model.ValidateProposalStatus(p.Status); err != nil {
+		errs = append(errs, fmt.Sprintf("proposal %s: %s", model.FormatProposalID(p.ID), err))
+	}
+	return errs
+}
+
+func validateImportVote(v *model.Vote) []string {
+	if err := model.ValidateVerdict(v.Verdict); err != nil {
+		return []string{fmt.Sprintf("vote %d: %s", v.ID, err)}
+	}
+	return nil
+}
+
 // doImport inserts all export data into the database. In merge mode, existing
 // IDs are skipped. Returns counts of imported and skipped entities.
 func doImport(conn *sql.DB, export *model.ExportData, replace bool) (*importResult, error) {
@@ -180,228 +396,132 @@ func doImport(conn *sql.DB, export *model.ExportData, replace bool) (*importResu
 		}
 	}
 
-	var imported, skipped int
+	im := newImporter(tx)
 
 	// 1. Labels (no FK dependencies).
 	for _, label := range export.Labels {
-		inserted, err := db.InsertLabelWithID(tx, label)
-		if err != nil {
-			return nil, fmt.Errorf("inserting label %q: %w", label.Name, err)
-		}
-		if inserted {
-			imported++
-		} else {
-			skipped++
+		if err := im.label(label); err != nil {
+			return nil, err
 		}
 	}
 
-	// 2. Issues: insert all with parent_id = NULL first, then UPDATE parent_id.
-	parentIDs := make(map[int]*int) // issue ID -> original parent_id
-	for _, issue := range export.Issues {
-		// Stash parent_id and insert without it for safe insertion order.
-		// We avoid mutating the caller's data by restoring after insert.
-		origParentID := issue.ParentID
-		if issue.ParentID != nil {
-			pid := *issue.ParentID
-			parentIDs[issue.ID] = &pid
-			issue.ParentID = nil
-		}
-		inserted, err := db.InsertIssueWithID(tx, issue)
-		if err != nil {
-			issue.ParentID = origParentID
-			return nil, fmt.Errorf("inserting issue %s: %w", model.FormatID(issue.ID), err)
-		}
-		issue.ParentID = origParentID
-		if inserted {
-			imported++
-		} else {
-			skipped++
-			// Remove from parentIDs so we don't UPDATE a skipped issue.
-			delete(parentIDs, issue.ID)
+	// 2. Milestones (no FK dependencies; must precede issues).
+	for _, m := range export.Milestones {
+		if err := im.milestone(m); err != nil {
+			return nil, err
 		}
 	}
 
-	// Now restore parent_id references for newly inserted issues.
-	for issueID, parentID := range parentIDs {
-		var parentExists bool
-		if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)", *parentID).Scan(&parentExists); err != nil {
-			return nil, fmt.Errorf("checking parent for issue %s: %w", model.FormatID(issueID), err)
-		}
-		if !parentExists {
-			continue
-		}
-		_, err := tx.Exec(`UPDATE issues SET parent_id = ? WHERE id = ?`, *parentID, issueID)
-		if err != nil {
-			return nil, fmt.Errorf("setting parent_id for issue %s: %w", model.FormatID(issueID), err)
+	// 3. Issues: insert all with parent_id = NULL first, then UPDATE parent_id.
+	for _, issue := range export.Issues {
+		if err := im.issue(issue); err != nil {
+			return nil, err
 		}
 	}
+	if err := im.restoreParents(); err != nil {
+		return nil, err
+	}
 
-	// 3. Issue-label mappings.
+	// 4. Issue-label mappings.
 	for _, m := range export.IssueLabelMappings {
-		inserted, err := db.InsertIssueLabelMapping(tx, m.IssueID, m.LabelID)
-		if err != nil {
-			return nil, fmt.Errorf("inserting issue-label mapping (issue=%d, label=%d): %w", m.IssueID, m.LabelID, err)
-		}
-		if inserted {
-			imported++
-		} else {
-			skipped++
+		if err := im.issueLabel(m); err != nil {
+			return nil, err
 		}
 	}
 
-	// 4. Issue-file mappings.
+	// 5. Issue-file mappings.
 	for _, m := range export.IssueFileMappings {
-		inserted, err := db.InsertIssueFileMapping(tx, m.IssueID, m.FilePath)
-		if err != nil {
-			return nil, fmt.Errorf("inserting issue-file mapping (issue=%d, file=%q): %w", m.IssueID, m.FilePath, err)
+		if err := im.issueFile(m); err != nil {
+			return nil, err
 		}
-		if inserted {
-			imported++
-		} else {
-			skipped++
+	}
+
+	// 6. Issue links (FK: issues).
+	for i := range export.IssueLinks {
+		if err := im.issueLink(&export.IssueLinks[i]); err != nil {
+			return nil, err
 		}
 	}
 
-	// 5. Comments.
+	// 7. Comments.
 	for _, comment := range export.Comments {
-		inserted, err := db.InsertCommentWithID(tx, comment)
-		if err != nil {
-			return nil, fmt.Errorf("inserting comment %d: %w", comment.ID, err)
-		}
-		if inserted {
-			imported++
-		} else {
-			skipped++
+		if err := im.comment(comment); err != nil {
+			return nil, err
 		}
 	}
 
-	// 6. Relations.
-	for _, rel := range export.Relations {
-		inserted, err := db.InsertRelationWithID(tx, &rel)
-		if err != nil {
-			return nil, fmt.Errorf("inserting relation %d: %w", rel.ID, err)
+	// 8. Relation types, then relations.
+	for i := range export.RelationTypes {
+		if err := im.relationType(&export.RelationTypes[i]); err != nil {
+			return nil, err
 		}
-		if inserted {
-			imported++
-		} else {
-			skipped++
+	}
+	for _, rel := range export.Relations {
+		if err := im.relation(rel); err != nil {
+			return nil, err
 		}
 	}
 
-	// 7. Activity log (FK: issues).
+	// 9. Activity log (FK: issues).
 	for _, a := range export.ActivityLog {
-		inserted, err := db.InsertActivityWithID(tx, a)
-		if err != nil {
-			return nil, fmt.Errorf("inserting activity %d: %w", a.ID, err)
-		}
-		if inserted {
-			imported++
-		} else {
-			skipped++
+		if err := im.activity(a); err != nil {
+			return nil, err
 		}
 	}
 
-	// 8. Proposals (FK: none; must precede votes/proposal_issues/proposal_docs).
+	// 10. Proposals (FK: none; must precede votes/proposal_issues/proposal_docs).
 	for _, p := range export.Proposals {
-		inserted, err := db.InsertProposalWithID(tx, p)
-		if err != nil {
-			return nil, fmt.Errorf("inserting proposal %s: %w", model.FormatProposalID(p.ID), err)
-		}
-		if inserted {
-			imported++
-		} else {
-			skipped++
+		if err := im.proposal(p); err != nil {
+			return nil, err
 		}
 	}
 
-	// 9. Votes (FK: proposals).
+	// 11. Votes (FK: proposals).
 	for _, v := range export.Votes {
-		inserted, err := db.InsertVoteWithID(tx, v)
-		if err != nil {
-			return nil, fmt.Errorf("inserting vote %d: %w", v.ID, err)
-		}
-		if inserted {
-			imported++
-		} else {
-			skipped++
+		if err := im.vote(v); err != nil {
+			return nil, err
 		}
 	}
 
-	// 10. Proposal-issue links (FK: proposals, issues).
+	// 12. Proposal-issue links (FK: proposals, issues).
 	for _, l := range export.ProposalIssues {
-		inserted, err := db.InsertProposalIssueLink(tx, l.ProposalID, l.IssueID)
-		if err != nil {
-			return nil, fmt.Errorf("inserting proposal-issue link (proposal=%d, issue=%d): %w", l.ProposalID, l.IssueID, err)
-		}
-		if inserted {
-			imported++
-		} else {
-			skipped++
+		if err := im.proposalIssue(l); err != nil {
+			return nil, err
 		}
 	}
 
-	// 11. Docs (FK: none; must precede revisions/comments/links).
+	// 13. Docs (FK: none; must precede revisions/comments/links).
 	for _, doc := range export.Docs {
-		inserted, err := db.InsertDocWithID(tx, doc)
-		if err != nil {
-			return nil, fmt.Errorf("inserting doc %s: %w", model.FormatDocID(doc.ID), err)
-		}
-		if inserted {
-			imported++
-		} else {
-			skipped++
+		if err := im.doc(doc); err != nil {
+			return nil, err
 		}
 	}
 
-	// 12. Doc revisions (FK: docs).
+	// 14. Doc revisions (FK: docs).
 	for _, rev := range export.DocRevisions {
-		inserted, err := db.InsertDocRevisionWithID(tx, rev)
-		if err != nil {
-			return nil, fmt.Errorf("inserting doc revision %d: %w", rev.ID, err)
-		}
-		if inserted {
-			imported++
-		} else {
-			skipped++
+		if err := im.docRevision(rev); err != nil {
+			return nil, err
 		}
 	}
 
-	// 13. Doc comments (FK: docs).
+	// 15. Doc comments (FK: docs).
 	for _, c := range export.DocComments {
-		inserted, err := db.InsertDocCommentWithID(tx, c)
-		if err != nil {
-			return nil, fmt.Errorf("inserting doc comment %d: %w", c.ID, err)
-		}
-		if inserted {
-			imported++
-		} else {
-			skipped++
+		if err := im.docComment(c); err != nil {
+			return nil, err
 		}
 	}
 
-	// 14. Doc-issue links (FK: docs, issues).
+	// 16. Doc-issue links (FK: docs, issues).
 	for _, l := range export.DocIssueLinks {
-		inserted, err := db.InsertDocIssueLink(tx, l.DocID, l.IssueID, l.CreatedAt)
-		if err != nil {
-			return nil, fmt.Errorf("inserting doc-issue link (doc=%d, issue=%d): %w", l.DocID, l.IssueID, err)
-		}
-		if inserted {
-			imported++
-		} else {
-			skipped++
+		if err := im.docIssueLink(l); err != nil {
+			return nil, err
 		}
 	}
 
-	// 15. Proposal-doc links (FK: proposals, docs — both inserted above).
+	// 17. Proposal-doc links (FK: proposals, docs — both inserted above).
 	for _, l := range export.ProposalDocs {
-		inserted, err := db.InsertProposalDocLink(tx, l.ProposalID, l.DocID, l.CreatedAt)
-		if err != nil {
-			return nil, fmt.Errorf("inserting proposal-doc link (proposal=%d, doc=%d): %w", l.ProposalID, l.DocID, err)
-		}
-		if inserted {
-			imported++
-		} else {
-			skipped++
+		if err := im.proposalDoc(l); err != nil {
+			return nil, err
 		}
 	}
 
@@ -409,11 +529,261 @@ func doImport(conn *sql.DB, export *model.ExportData, replace bool) (*importResu
 		return nil, fmt.Errorf("committing transaction: %w", err)
 	}
 
-	return &importResult{Imported: imported, Skipped: skipped}, nil
+	return im.result(), nil
+}
+
+// importer inserts export entities one at a time within a transaction,
+// tallying imported and skipped rows. It is shared by the JSON and jsonl
+// import paths so both produce identical databases.
+type importer struct {
+	tx        *sql.Tx
+	parentIDs map[int]*int // issue ID -> original parent_id
+	imported  int
+	skipped   int
+}
+
+func newImporter(tx *sql.Tx) *importer {
+	return &importer{tx: tx, parentIDs: make(map[int]*int)}
+}
+
+func (im *importer) tally(inserted bool) {
+	if inserted {
+		im.imported++
+	} else {
+		im.skipped++
+	}
+}
+
+func (im *importer) result() *importResult {
+	return &importResult{Imported: im.imported, Skipped: im.skipped}
+}
+
+func (im *importer) label(label *model.Label) error {
+	inserted, err := db.InsertLabelWithID(im.tx, label)
+	if err != nil {
+		return fmt.Errorf("inserting label %q: %w", label.Name, err)
+	}
+	im.tally(inserted)
+	return nil
+}
+
+func (im *importer) milestone(m *model.Milestone) error {
+	inserted, err := db.InsertMilestoneWithID(im.tx, m)
+	if err != nil {
+		return fmt.Errorf("inserting milestone %q: %w", m.Name, err)
+	}
+	im.tally(inserted)
+	return nil
+}
+
+// issue inserts an issue with parent_id = NULL and stashes the original
+// parent so restoreParents can set it once every issue has been inserted.
+// A milestone link is dropped if the milestone is not in the database.
+func (im *importer) issue(issue *model.Issue) error {
+	_, err := im.insertIssue(issue)
+	return err
+}
+
+// insertIssue is issue, also reporting whether the issue was inserted rather
+// than skipped as a duplicate.
+func (im *importer) insertIssue(issue *model.Issue) (bool, error) {
+	// We avoid mutating the caller's data by restoring after insert.
+	origParentID, origMilestoneID := issue.ParentID, issue.MilestoneID
+	issue.ParentID = nil
+	if issue.MilestoneID != nil {
+		exists, err := db.MilestoneExistsTx(im.tx, *issue.MilestoneID)
+		if err != nil {
+			return false, err
+		}
+		if !exists {
+			issue.MilestoneID = nil
+		}
+	}
+	inserted, err := db.InsertIssueWithID(im.tx, issue)
+	issue.ParentID, issue.MilestoneID = origParentID, origMilestoneID
+	if err != nil {
+		return false, fmt.Errorf("inserting issue %s: %w", model.FormatID(issue.ID), err)
+	}
+	im.tally(inserted)
+	// Skipped issues keep their existing parent_id.
+	if inserted && origParentID != nil {
+		pid := *origParentID
+		im.parentIDs[issue.ID] = &pid
+	}
+	return inserted, nil
+}
+
+// restoreParents restores parent_id references for newly inserted issues
+// whose parent exists after the import.
+func (im *importer) restoreParents() error {
+	for issueID, parentID := range im.parentIDs {
+		var parentExists bool
+		if err := im.tx.QueryRow("SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)", *parentID).Scan(&parentExists); err != nil {
+			return fmt.Errorf("checking parent for issue %s: %w", model.FormatID(issueID), err)
+		}
+		if !parentExists {
+			continue
+		}
+		_, err := im.tx.Exec(`UPDATE issues SET parent_id = ? WHERE id = ?`, *parentID, issueID)
+		if err != nil {
+			return fmt.Errorf("setting parent_id for issue %s: %w", model.FormatID(issueID), err)
+		}
+	}
+	im.parentIDs = make(map[int]*int)
+	return nil
+}
+
+func (im *importer) issueLabel(m model.IssueLabelMapping) error {
+	inserted, err := db.InsertIssueLabelMapping(im.tx, m.IssueID, m.LabelID)
+	if err != nil {
+		return fmt.Errorf("inserting issue-label mapping (issue=%d, label=%d): %w", m.IssueID, m.LabelID, err)
+	}
+	im.tally(inserted)
+	return nil
+}
+
+// issueLabelName attaches the label named name to an issue, creating the
+// label if the database does not have it.
+func (im *importer) issueLabelName(issueID int, name string) error {
+	labelID, err := db.EnsureLabelTx(im.tx, name)
+	if err != nil {
+		return err
+	}
+	return im.issueLabel(model.IssueLabelMapping{IssueID: issueID, LabelID: labelID})
+}
+
+func (im *importer) issueFile(m model.IssueFileMapping) error {
+	inserted, err := db.InsertIssueFileMapping(im.tx, m.IssueID, m.FilePath)
+	if err != nil {
+		return fmt.Errorf("inserting issue-file mapping (issue=%d, file=%q): %w", m.IssueID, m.FilePath, err)
+	}
+	im.tally(inserted)
+	return nil
+}
+
+func (im *importer) issueLink(l *model.IssueLink) error {
+	inserted, err := db.InsertIssueLinkWithID(im.tx, l)
+	if err != nil {
+		return fmt.Errorf("inserting issue link %d: %w", l.ID, err)
+	}
+	im.tally(inserted)
+	return nil
+}
+
+func (im *importer) comment(comment *model.Comment) error {
+	inserted, err := db.InsertCommentWithID(im.tx, comment)
+	if err != nil {
+		return fmt.Errorf("inserting comment %d: %w", comment.ID, err)
+	}
+	im.tally(inserted)
+	return nil
+}
+
+func (im *importer) relationType(d *model.RelationTypeDef) error {
+	inserted, err := db.InsertRelationTypeTx(im.tx, d)
+	if err != nil {
+		return err
+	}
+	im.tally(inserted)
+	return nil
+}
+
+func (im *importer) relation(rel model.Relation) error {
+	inserted, err := db.InsertRelationWithID(im.tx, &rel)
+	if err != nil {
+		return fmt.Errorf("inserting relation %d: %w", rel.ID, err)
+	}
+	im.tally(inserted)
+	return nil
+}
+
+func (im *importer) activity(a *model.Activity) error {
+	inserted, err := db.InsertActivityWithID(im.tx, a)
+	if err != nil {
+		return fmt.Errorf("inserting activity %d: %w", a.ID, err)
+	}
+	im.tally(inserted)
+	return nil
+}
+
+func (im *importer) proposal(p *model.Proposal) error {
+	inserted, err := db.InsertProposalWithID(im.tx, p)
+	if err != nil {
+		return fmt.Errorf("inserting proposal %s: %w", model.FormatProposalID(p.ID), err)
+	}
+	im.tally(inserted)
+	return nil
+}
+
+func (im *importer) vote(v *model.Vote) error {
+	inserted, err := db.InsertVoteWithID(im.tx, v)
+	if err != nil {
+		return fmt.Errorf("inserting vote %d: %w", v.ID, err)
+	}
+	im.tally(inserted)
+	return nil
+}
+
+func (im *importer) proposalIssue(l model.ProposalIssueLink) error {
+	inserted, err := db.InsertProposalIssueLink(im.tx, l.ProposalID, l.IssueID)
+	if err != nil {
+		return fmt.Errorf("inserting proposal-issue link (proposal=%d, issue=%d): %w", l.ProposalID, l.IssueID, err)
+	}
+	im.tally(inserted)
+	return nil
+}
+
+func (im *importer) doc(doc *model.Doc) error {
+	inserted, err := db.InsertDocWithID(im.tx, doc)
+	if err != nil {
+		return fmt.Errorf("inserting doc %s: %w", model.FormatDocID(doc.ID), err)
+	}
+	im.tally(inserted)
+	return nil
+}
+
+func (im *importer) docRevision(rev *model.DocRevision) error {
+	inserted, err := db.InsertDocRevisionWithID(im.tx, rev)
+	if err != nil {
+		return fmt.Errorf("inserting doc revision %d: %w", rev.ID, err)
+	}
+	im.tally(inserted)
+	return nil
+}
+
+func (im *importer) docComment(c *model.DocComment) error {
+	inserted, err := db.InsertDocCommentWithID(im.tx, c)
+	if err != nil {
+		return fmt.Errorf("inserting doc comment %d: %w", c.ID, err)
+	}
+	im.tally(inserted)
+	return nil
+}
+
+func (im *importer) docIssueLink(l model.DocIssueLink) error {
+	inserted, err := db.InsertDocIssueLink(im.tx, l.DocID, l.IssueID, l.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("inserting doc-issue link (doc=%d, issue=%d): %w", l.DocID, l.IssueID, err)
+	}
+	im.tally(inserted)
+	return nil
+}
+
+func (im *importer) proposalDoc(l model.ProposalDocLink) error {
+	inserted, err := db.InsertProposalDocLink(im.tx, l.ProposalID, l.DocID, l.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("inserting proposal-doc link (proposal=%d, doc=%d): %w", l.ProposalID, l.DocID, err)
+	}
+	im.tally(inserted)
+	return nil
 }
 
 func init() {
 	importCmd.Flags().Bool("merge", false, "Merge with existing database, skip duplicates by ID")
 	importCmd.Flags().Bool("replace", false, "Replace entire database (destructive)")
+	importCmd.Flags().String("format", "", "Import format: json, jsonl, csv (default: detected from file extension)")
+	importCmd.Flags().String("delimiter", ",", "Field delimiter for --format csv (use \\t for tab)")
+	importCmd.Flags().Bool("decrypt", false, "Decrypt a JSON export written with 'docket export --encrypt'")
+	importCmd.Flags().String("passphrase-file", "", "Read the --decrypt passphrase from this file instead of prompting")
 	rootCmd.AddCommand(importCmd)
 }
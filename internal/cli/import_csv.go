@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// csvRequiredColumns are the columns a CSV import file must have.
+var csvRequiredColumns = []string{"id", "title"}
+
+// parseImportCSV reads issues from a CSV export as written by
+// renderExportCSV with RFC 3339 dates. The header row names the columns,
+// which may be any subset of csvColumns that includes id and title; issues
+// carry their labels and files in Labels and Files. Quoted fields keep
+// embedded newlines and delimiters, and the quote csvSafe prefixes to
+// formula-like values is removed. A missing status, priority or type takes
+// its default, and a missing created_at is the time of the import.
+//
+// Rows that cannot be converted are reported in errs, one per problem, so
+// that all of them can be shown at once; err is set only when r is not
+// well-formed CSV.
+func parseImportCSV(r io.Reader, delimiter rune) (issues []*model.Issue, errs []string, err error) {
+	cr := csv.NewReader(r)
+	if delimiter != 0 {
+		cr.Comma = delimiter
+	}
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	if err := validateCSVColumns(header); err != nil {
+		return nil, nil, err
+	}
+	for _, c := range csvRequiredColumns {
+		if !slices.Contains(header, c) {
+			return nil, nil, fmt.Errorf("CSV header is missing the %q column", c)
+		}
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		issue := &model.Issue{
+			Status:   model.StatusBacklog,
+			Priority: model.PriorityNone,
+			Kind:     model.IssueKindTask,
+		}
+		var rowErrs []string
+		for i, column := range header {
+			if err := setCSVCell(issue, column, record[i]); err != nil {
+				rowErrs = append(rowErrs, fmt.Sprintf("line %d: %s: %s", line, column, err))
+			}
+		}
+		if len(rowErrs) > 0 {
+			errs = append(errs, rowErrs...)
+			continue
+		}
+		if issue.CreatedAt.IsZero() {
+			issue.CreatedAt = now
+		}
+		if issue.UpdatedAt.IsZero() {
+			issue.UpdatedAt = issue.CreatedAt
+		}
+		errs = append(errs, validateImportIssue(issue)...)
+		issues = append(issues, issue)
+	}
+	return issues, errs, nil
+}
+
+// setCSVCell sets the field of issue that one CSV column holds. It is the
+// inverse of csvCell for RFC 3339 dates.
+func setCSVCell(issue *model.Issue, column, value string) error {
+	var err error
+	switch column {
+	case "id":
+		issue.ID, err = model.ParseID(value)
+	case "parent_id":
+		if value != "" {
+			var id int
+			id, err = model.ParseID(value)
+			issue.ParentID = &id
+		}
+	case "title":
+		issue.Title = csvUnsafe(value)
+		err = model.ValidateTitle(issue.Title)
+	case "description":
+		issue.Description = csvUnsafe(value)
+	case "status":
+		if value != "" {
+			issue.Status = model.Status(value)
+		}
+	case "priority":
+		if value != "" {
+			issue.Priority = model.Priority(value)
+		}
+	case "type":
+		if value != "" {
+			issue.Kind = model.IssueKind(value)
+		}
+	case "assignee":
+		issue.Assignee = csvUnsafe(value)
+	case "labels":
+		issue.Labels = splitCSVList(csvUnsafe(value), ",")
+	case "files":
+		issue.Files = splitCSVList(csvUnsafe(value), ";")
+	case "created_at":
+		issue.CreatedAt, err = parseCSVTime(value)
+	case "updated_at":
+		issue.UpdatedAt, err = parseCSVTime(value)
+	case "started_at":
+		issue.StartedAt, err = parseCSVTime(value)
+	case "completed_at":
+		issue.CompletedAt, err = parseCSVTime(value)
+	case "snoozed_until":
+		issue.SnoozedUntil, err = parseCSVTime(value)
+	case "pinned":
+		if value != "" {
+			issue.Pinned, err = strconv.ParseBool(value)
+		}
+	}
+	return err
+}
+
+// csvUnsafe reverses csvSafe, removing the quote it prefixes to a value that
+// a spreadsheet would read as a formula.
+func csvUnsafe(s string) string {
+	if len(s) < 2 || s[0] != '\'' {
+		return s
+	}
+	if csvSafe(s[1:]) == s {
+		return s[1:]
+	}
+	return s
+}
+
+// splitCSVList splits a list cell on sep, dropping empty entries.
+func splitCSVList(s, sep string) []string {
+	var items []string
+	for _, item := range strings.Split(s, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseCSVTime parses a timestamp with model.ParseTimestamp, which reads the
+// rfc3339, date, datetime and unix export date formats; an empty value is the
+// zero time.
+func parseCSVTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return model.ParseTimestamp(s)
+}
+
+// doImportCSV inserts issues parsed by parseImportCSV, with their labels and
+// files, within a single transaction. Labels are matched to existing labels by
+// name and created when missing. In merge mode an issue whose ID exists is
+// skipped together with its labels and files.
+func doImportCSV(conn *sql.DB, issues []*model.Issue, replace bool) (*importResult, error) {
+	tx, err := conn.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if replace {
+		if err := db.ClearAllDataTx(tx); err != nil {
+			return nil, fmt.Errorf("clearing database: %w", err)
+		}
+	}
+
+	im := newImporter(tx)
+	for _, issue := range issues {
+		inserted, err := im.insertIssue(issue)
+		if err != nil {
+			return nil, err
+		}
+		if !inserted {
+			continue
+		}
+		for _, name := range issue.Labels {
+			if err := im.issueLabelName(issue.ID, name); err != nil {
+				return nil, err
+			}
+		}
+		for _, path := range issue.Files {
+			if err := im.issueFile(model.IssueFileMapping{IssueID: issue.ID, FilePath: path}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := im.restoreParents(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return im.result(), nil
+}
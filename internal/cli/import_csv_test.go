@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestCSVRoundTripPreservesMultiLineDescriptions(t *testing.T) {
+	src := newTestDB(t)
+	parent := createIssue(t, src, "Parent", model.StatusTodo, model.PriorityHigh)
+	description := "First line, with a comma\n\n- a bullet\n\"quoted\"\ttabbed\ntrailing newline\n"
+	id, err := db.CreateIssue(src, &model.Issue{
+		Title:       "-starts with a dash, has a comma",
+		Description: description,
+		Status:      model.StatusInProgress,
+		Priority:    model.PriorityLow,
+		Kind:        model.IssueKindBug,
+		Assignee:    "@alice",
+		ParentID:    &parent,
+	}, []string{"backend", "needs review"}, []string{"cmd/a,b.go", "internal/x.go"})
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+
+	issues, err := db.ListAllIssues(src)
+	if err != nil {
+		t.Fatalf("ListAllIssues: %v", err)
+	}
+	if err := db.HydrateLabels(src, issues); err != nil {
+		t.Fatalf("HydrateLabels: %v", err)
+	}
+	if err := db.HydrateFiles(src, issues); err != nil {
+		t.Fatalf("HydrateFiles: %v", err)
+	}
+	out, err := renderExportCSV(issues, nil, 0, nil)
+	if err != nil {
+		t.Fatalf("renderExportCSV: %v", err)
+	}
+
+	parsed, errs, err := parseImportCSV(strings.NewReader(out), 0)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("parseImportCSV: %v %v", err, errs)
+	}
+	dst := newTestDB(t)
+	if _, err := doImportCSV(dst, parsed, false); err != nil {
+		t.Fatalf("doImportCSV: %v", err)
+	}
+
+	want, err := db.GetIssue(src, id)
+	if err != nil {
+		t.Fatalf("GetIssue(src): %v", err)
+	}
+	got, err := db.GetIssue(dst, id)
+	if err != nil {
+		t.Fatalf("GetIssue(dst): %v", err)
+	}
+	if got.Description != description {
+		t.Errorf("description = %q, want %q", got.Description, description)
+	}
+	if got.Title != want.Title || got.Assignee != want.Assignee {
+		t.Errorf("title, assignee = %q, %q; want %q, %q", got.Title, got.Assignee, want.Title, want.Assignee)
+	}
+	if got.Status != want.Status || got.Priority != want.Priority || got.Kind != want.Kind {
+		t.Errorf("status, priority, kind = %s, %s, %s; want %s, %s, %s",
+			got.Status, got.Priority, got.Kind, want.Status, want.Priority, want.Kind)
+	}
+	if got.ParentID == nil || *got.ParentID != parent {
+		t.Errorf("parent = %v, want %d", got.ParentID, parent)
+	}
+	if !got.CreatedAt.Equal(want.CreatedAt) || !got.StartedAt.Equal(want.StartedAt) {
+		t.Errorf("created, started = %v, %v; want %v, %v", got.CreatedAt, got.StartedAt, want.CreatedAt, want.StartedAt)
+	}
+	labels, err := db.GetIssueLabels(dst, id)
+	if err != nil {
+		t.Fatalf("GetIssueLabels: %v", err)
+	}
+	slices.Sort(labels)
+	if !slices.Equal(labels, []string{"backend", "needs review"}) {
+		t.Errorf("labels = %v, want [backend needs review]", labels)
+	}
+	files, err := db.GetIssueFiles(dst, id)
+	if err != nil {
+		t.Fatalf("GetIssueFiles: %v", err)
+	}
+	slices.Sort(files)
+	if !slices.Equal(files, []string{"cmd/a,b.go", "internal/x.go"}) {
+		t.Errorf("files = %v, want [cmd/a,b.go internal/x.go]", files)
+	}
+}
+
+func TestParseImportCSV(t *testing.T) {
+	t.Run("defaults for missing columns", func(t *testing.T) {
+		issues, errs, err := parseImportCSV(strings.NewReader("id;title\nDKT-4;\"two\nlines\"\n"), ';')
+		if err != nil || len(errs) > 0 {
+			t.Fatalf("parseImportCSV: %v %v", err, errs)
+		}
+		if len(issues) != 1 {
+			t.Fatalf("got %d issues, want 1", len(issues))
+		}
+		got := issues[0]
+		if got.ID != 4 || got.Title != "two\nlines" || got.Status != model.StatusBacklog || got.Kind != model.IssueKindTask {
+			t.Errorf("issue = %+v, want DKT-4 %q in backlog as a task", got, "two\nlines")
+		}
+		if got.CreatedAt.IsZero() || !got.UpdatedAt.Equal(got.CreatedAt) {
+			t.Errorf("created, updated = %v, %v; want both set to the import time", got.CreatedAt, got.UpdatedAt)
+		}
+	})
+
+	t.Run("collects row errors", func(t *testing.T) {
+		input := "id,title,status,created_at\nDKT-1,ok,todo,2026-01-01T00:00:00Z\nnope,,todo,yesterday\nDKT-3,bad status,open,\n"
+		_, errs, err := parseImportCSV(strings.NewReader(input), 0)
+		if err != nil {
+			t.Fatalf("parseImportCSV: %v", err)
+		}
+		if len(errs) != 4 {
+			t.Fatalf("got %d errors, want 4: %v", len(errs), errs)
+		}
+		if !strings.HasPrefix(errs[0], "line 3: id:") || !strings.Contains(errs[2], "RFC 3339") || !strings.Contains(errs[3], "DKT-3") {
+			t.Errorf("errors = %q", errs)
+		}
+	})
+
+	t.Run("requires id and title", func(t *testing.T) {
+		if _, _, err := parseImportCSV(strings.NewReader("id,status\nDKT-1,todo\n"), 0); err == nil || !strings.Contains(err.Error(), `"title"`) {
+			t.Errorf("got %v, want a missing title column error", err)
+		}
+	})
+
+	t.Run("rejects unknown columns", func(t *testing.T) {
+		if _, _, err := parseImportCSV(strings.NewReader("id,title,color\n"), 0); err == nil {
+			t.Error("expected an unknown column error")
+		}
+	})
+}
+
+func TestCsvUnsafe(t *testing.T) {
+	for _, s := range []string{"=SUM(A1)", "-cmd", "@alice", "'quoted", "'", "plain", ""} {
+		if got := csvUnsafe(csvSafe(s)); got != s {
+			t.Errorf("csvUnsafe(csvSafe(%q)) = %q", s, got)
+		}
+	}
+}
+
+func TestDoImportCSVMergeSkipsExistingIssues(t *testing.T) {
+	conn := newTestDB(t)
+	id := createIssue(t, conn, "Existing", model.StatusTodo, model.PriorityHigh)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	result, err := doImportCSV(conn, []*model.Issue{
+		{ID: id, Title: "Imported", Status: model.StatusTodo, Priority: model.PriorityNone, Kind: model.IssueKindTask, Labels: []string{"new"}, CreatedAt: now, UpdatedAt: now},
+		{ID: id + 1, Title: "New", Status: model.StatusTodo, Priority: model.PriorityNone, Kind: model.IssueKindTask, Labels: []string{"new"}, CreatedAt: now, UpdatedAt: now},
+	}, false)
+	if err != nil {
+		t.Fatalf("doImportCSV: %v", err)
+	}
+	if result.Imported != 2 || result.Skipped != 1 {
+		t.Errorf("result = %+v, want 2 imported (issue and label mapping) and 1 skipped", result)
+	}
+	existing, err := db.GetIssue(conn, id)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	labels, err := db.GetIssueLabels(conn, id)
+	if err != nil {
+		t.Fatalf("GetIssueLabels: %v", err)
+	}
+	if existing.Title != "Existing" || len(labels) != 0 {
+		t.Errorf("existing issue = %q %v, want it untouched", existing.Title, labels)
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ALT-F4-LLC/docket/internal/db"
 	"github.com/ALT-F4-LLC/docket/internal/model"
@@ -24,6 +26,10 @@ func buildExport(t *testing.T, conn *sql.DB) *model.ExportData {
 	if err != nil {
 		t.Fatalf("ListAllComments: %v", err)
 	}
+	relationTypes, err := db.ListCustomRelationTypes(conn)
+	if err != nil {
+		t.Fatalf("ListCustomRelationTypes: %v", err)
+	}
 	relations, err := db.GetAllRelations(conn)
 	if err != nil {
 		t.Fatalf("GetAllRelations: %v", err)
@@ -32,6 +38,10 @@ func buildExport(t *testing.T, conn *sql.DB) *model.ExportData {
 	if err != nil {
 		t.Fatalf("ListAllLabelsRaw: %v", err)
 	}
+	milestones, err := db.ListAllMilestones(conn)
+	if err != nil {
+		t.Fatalf("ListAllMilestones: %v", err)
+	}
 	labelMappings, err := db.ListAllIssueLabelMappings(conn)
 	if err != nil {
 		t.Fatalf("ListAllIssueLabelMappings: %v", err)
@@ -40,6 +50,10 @@ func buildExport(t *testing.T, conn *sql.DB) *model.ExportData {
 	if err != nil {
 		t.Fatalf("ListAllIssueFileMappings: %v", err)
 	}
+	issueLinks, err := db.ListAllIssueLinks(conn)
+	if err != nil {
+		t.Fatalf("ListAllIssueLinks: %v", err)
+	}
 	docs, err := db.ListAllDocs(conn)
 	if err != nil {
 		t.Fatalf("ListAllDocs: %v", err)
@@ -82,10 +96,13 @@ func buildExport(t *testing.T, conn *sql.DB) *model.ExportData {
 		ExportedAt:         "2026-01-01T00:00:00Z",
 		Issues:             issues,
 		Comments:           comments,
+		RelationTypes:      relationTypes,
 		Relations:          relations,
 		Labels:             labels,
+		Milestones:         milestones,
 		IssueLabelMappings: labelMappings,
 		IssueFileMappings:  fileMappings,
+		IssueLinks:         issueLinks,
 		Docs:               docs,
 		DocRevisions:       docRevisions,
 		DocComments:        docComments,
@@ -179,6 +196,106 @@ func TestDoImportRoundTripPreservesDocs(t *testing.T) {
 	}
 }
 
+func TestDoImportRoundTripPreservesMilestones(t *testing.T) {
+	src := newTestDB(t)
+
+	due := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	msID, err := db.CreateMilestone(src, &model.Milestone{Name: "v1.4", Description: "spring release", DueDate: &due})
+	if err != nil {
+		t.Fatalf("CreateMilestone: %v", err)
+	}
+	issueID := createIssue(t, src, "in the release", model.StatusTodo, model.PriorityMedium)
+	if err := db.UpdateIssue(src, issueID, map[string]interface{}{"milestone_id": msID}, "tester"); err != nil {
+		t.Fatalf("UpdateIssue(milestone_id): %v", err)
+	}
+
+	dst := newTestDB(t)
+	if _, err := doImport(dst, buildExport(t, src), false); err != nil {
+		t.Fatalf("doImport: %v", err)
+	}
+
+	got, err := db.GetMilestoneByName(dst, "v1.4")
+	if err != nil {
+		t.Fatalf("GetMilestoneByName(dst): %v", err)
+	}
+	if got.ID != msID || got.Description != "spring release" || got.FormatDueDate() != "2026-03-01" {
+		t.Errorf("milestone not preserved: %+v", got)
+	}
+
+	issue, err := db.GetIssue(dst, issueID)
+	if err != nil {
+		t.Fatalf("GetIssue(dst): %v", err)
+	}
+	if issue.MilestoneID == nil || *issue.MilestoneID != msID {
+		t.Errorf("issue milestone_id = %v, want %d", issue.MilestoneID, msID)
+	}
+}
+
+func TestDoImportRoundTripPreservesIssueLinks(t *testing.T) {
+	src := newTestDB(t)
+
+	issueID := createIssue(t, src, "has a PR", model.StatusTodo, model.PriorityMedium)
+	if _, err := db.AddLink(src, issueID, "https://example.com/pr/7", "Fix PR", "tester"); err != nil {
+		t.Fatalf("AddLink: %v", err)
+	}
+
+	dst := newTestDB(t)
+	if _, err := doImport(dst, buildExport(t, src), false); err != nil {
+		t.Fatalf("doImport: %v", err)
+	}
+
+	links, err := db.GetIssueLinks(dst, issueID)
+	if err != nil {
+		t.Fatalf("GetIssueLinks(dst): %v", err)
+	}
+	if len(links) != 1 || links[0].URL != "https://example.com/pr/7" || links[0].Title != "Fix PR" {
+		t.Errorf("links not preserved: %+v", links)
+	}
+}
+
+func TestValidateExportDataRequiresDeclaredRelationTypes(t *testing.T) {
+	rel := model.Relation{ID: 1, SourceIssueID: 1, TargetIssueID: 2, RelationType: "tested_by"}
+	export := &model.ExportData{Version: 1, Relations: []model.Relation{rel}}
+
+	errs := validateExportData(export)
+	if len(errs) != 1 || !strings.Contains(errs[0], `invalid relation type "tested_by"`) {
+		t.Fatalf("expected an invalid relation type error, got %v", errs)
+	}
+
+	export.RelationTypes = []model.RelationTypeDef{{Name: "tested_by", Inverse: "tests", Directional: true}}
+	if errs := validateExportData(export); len(errs) != 0 {
+		t.Errorf("expected the declared type to validate, got %v", errs)
+	}
+}
+
+func TestDoImportDropsLinkToMissingMilestone(t *testing.T) {
+	src := newTestDB(t)
+
+	msID, err := db.CreateMilestone(src, &model.Milestone{Name: "v1.4"})
+	if err != nil {
+		t.Fatalf("CreateMilestone: %v", err)
+	}
+	issueID := createIssue(t, src, "orphaned link", model.StatusTodo, model.PriorityMedium)
+	if err := db.UpdateIssue(src, issueID, map[string]interface{}{"milestone_id": msID}, "tester"); err != nil {
+		t.Fatalf("UpdateIssue(milestone_id): %v", err)
+	}
+
+	export := buildExport(t, src)
+	export.Milestones = nil
+
+	dst := newTestDB(t)
+	if _, err := doImport(dst, export, false); err != nil {
+		t.Fatalf("doImport: %v", err)
+	}
+	issue, err := db.GetIssue(dst, issueID)
+	if err != nil {
+		t.Fatalf("GetIssue(dst): %v", err)
+	}
+	if issue.MilestoneID != nil {
+		t.Errorf("issue milestone_id = %d, want nil", *issue.MilestoneID)
+	}
+}
+
 func TestDoImportRoundTripPreservesProposalsSubsystem(t *testing.T) {
 	src := newTestDB(t)
 
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestDoImportNormalizesTimestamps(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "import_timestamps.json"))
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+	var export model.ExportData
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatalf("parsing fixture: %v", err)
+	}
+	if errs := validateExportData(&export); len(errs) > 0 {
+		t.Fatalf("validateExportData: %v", errs)
+	}
+
+	conn := newTestDB(t)
+	if _, err := doImport(conn, &export, false); err != nil {
+		t.Fatalf("doImport: %v", err)
+	}
+
+	ten := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	midnight := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		id               int
+		created, updated time.Time
+	}{
+		{1, ten, ten},
+		{2, ten, ten},
+		{3, ten, ten},
+		{4, midnight, midnight},
+		{5, ten, ten},
+	}
+	for _, tt := range tests {
+		issue, err := db.GetIssue(conn, tt.id)
+		if err != nil {
+			t.Fatalf("GetIssue(%d): %v", tt.id, err)
+		}
+		if !issue.CreatedAt.Equal(tt.created) || !issue.UpdatedAt.Equal(tt.updated) {
+			t.Errorf("DKT-%d created, updated = %v, %v; want %v, %v", tt.id, issue.CreatedAt, issue.UpdatedAt, tt.created, tt.updated)
+		}
+	}
+
+	// Every stored timestamp is RFC 3339, so lists can read them all.
+	bad, err := db.FindInvalidTimestamps(conn)
+	if err != nil {
+		t.Fatalf("FindInvalidTimestamps: %v", err)
+	}
+	if len(bad) > 0 {
+		t.Errorf("invalid timestamps after import: %+v", bad)
+	}
+	issues, _, err := db.ListIssues(conn, db.ListOptions{IncludeDone: true})
+	if err != nil || len(issues) != 5 {
+		t.Fatalf("ListIssues = %d issues, %v; want 5", len(issues), err)
+	}
+	comments, err := db.ListIssueComments(conn, 1)
+	if err != nil || len(comments) != 1 || !comments[0].CreatedAt.Equal(ten) {
+		t.Errorf("comments = %+v, %v; want one created at %v", comments, err, ten)
+	}
+}
+
+func TestValidateExportTimestampsReportsEachEntity(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "import_bad_timestamps.json"))
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+	var export model.ExportData
+	if err := json.Unmarshal(data, &export); err == nil {
+		t.Fatal("parsing the fixture succeeded, want an error")
+	}
+
+	errs := validateExportTimestamps(data)
+	want := []string{"issue DKT-2: created_at:", "issue DKT-2: snoozed_until:", "comment 7: created_at:"}
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors, want %d: %q", len(errs), len(want), errs)
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(errs[i], prefix) {
+			t.Errorf("errs[%d] = %q, want prefix %q", i, errs[i], prefix)
+		}
+	}
+}
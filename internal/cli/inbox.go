@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+	"github.com/spf13/cobra"
+)
+
+// inboxResult is the JSON wire format for the inbox command output.
+type inboxResult struct {
+	Recipient     string                `json:"recipient"`
+	Notifications []*model.Notification `json:"notifications"`
+	Total         int                   `json:"total"`
+	Unread        int                   `json:"unread"`
+}
+
+var inboxCmd = &cobra.Command{
+	Use:   "inbox",
+	Short: "List notifications about assignments, mentions, and status changes",
+	Long: `List the current user's notifications, newest first. A notification is
+recorded when someone else assigns you an issue, mentions you as @name in a
+description or comment, or changes the status of an issue assigned to you.
+
+  docket config user jane
+  docket inbox --unread
+  docket inbox read --all
+
+Notifications are not included in exports.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runInbox(cmd, args, getWriter(cmd))
+	},
+}
+
+// inboxRecipient resolves --user, defaulting to the current user.
+func inboxRecipient(cmd *cobra.Command) (string, error) {
+	user, _ := cmd.Flags().GetString("user")
+	if user == "" {
+		user = db.MeAssignee
+	}
+	return resolveAssignee(cmd, getDB(cmd), user)
+}
+
+func runInbox(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+	unreadOnly, _ := cmd.Flags().GetBool("unread")
+
+	layout, err := getLayout(cmd)
+	if err != nil {
+		return err
+	}
+
+	recipient, err := inboxRecipient(cmd)
+	if err != nil {
+		return err
+	}
+
+	notifications, err := db.ListNotifications(conn, recipient, unreadOnly)
+	if err != nil {
+		return cmdErr(fmt.Errorf("listing notifications: %w", err), output.ErrGeneral)
+	}
+
+	unread := 0
+	for _, n := range notifications {
+		if n.Unread() {
+			unread++
+		}
+	}
+
+	var message string
+	if !w.JSONMode {
+		message = render.RenderInbox(notifications, layout)
+	}
+	w.Success(inboxResult{
+		Recipient:     recipient,
+		Notifications: notifications,
+		Total:         len(notifications),
+		Unread:        unread,
+	}, message)
+	return nil
+}
+
+func init() {
+	inboxCmd.PersistentFlags().String("user", "", "Show the inbox of this user instead of the current user")
+	inboxCmd.Flags().Bool("unread", false, "Only show unread notifications")
+	rootCmd.AddCommand(inboxCmd)
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// inboxReadResult is the JSON wire format for the inbox read command output.
+type inboxReadResult struct {
+	Recipient string `json:"recipient"`
+	Marked    int    `json:"marked"`
+}
+
+var inboxReadCmd = &cobra.Command{
+	Use:   "read",
+	Short: "Mark notifications as read",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runInboxRead(cmd, args, getWriter(cmd))
+	},
+}
+
+func runInboxRead(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+	all, _ := cmd.Flags().GetBool("all")
+	id, _ := cmd.Flags().GetInt("id")
+	idSet := cmd.Flags().Changed("id")
+
+	if all == idSet {
+		return cmdErr(fmt.Errorf("specify exactly one of --all or --id"), output.ErrValidation)
+	}
+	if idSet && id <= 0 {
+		return cmdErr(fmt.Errorf("--id must be a positive notification number"), output.ErrValidation)
+	}
+
+	recipient, err := inboxRecipient(cmd)
+	if err != nil {
+		return err
+	}
+
+	if all {
+		marked, err := db.MarkAllNotificationsRead(conn, recipient)
+		if err != nil {
+			return cmdErr(fmt.Errorf("marking notifications read: %w", err), output.ErrGeneral)
+		}
+		w.Success(inboxReadResult{Recipient: recipient, Marked: marked}, fmt.Sprintf("Marked %d notification(s) read", marked))
+		return nil
+	}
+
+	if err := db.MarkNotificationRead(conn, recipient, id); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return cmdErr(fmt.Errorf("notification %d not found in %s's inbox", id, recipient), output.ErrNotFound)
+		}
+		return cmdErr(fmt.Errorf("marking notification read: %w", err), output.ErrGeneral)
+	}
+	w.Success(inboxReadResult{Recipient: recipient, Marked: 1}, fmt.Sprintf("Marked notification %d read", id))
+	return nil
+}
+
+func init() {
+	inboxReadCmd.Flags().Bool("all", false, "Mark every unread notification read")
+	inboxReadCmd.Flags().Int("id", 0, "Mark the notification with this number read")
+	inboxCmd.AddCommand(inboxReadCmd)
+}
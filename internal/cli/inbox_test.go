@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+func TestInboxListsAndMarksRead(t *testing.T) {
+	conn := newTestDB(t)
+	if err := db.SetCurrentUser(conn, "jane"); err != nil {
+		t.Fatalf("SetCurrentUser: %v", err)
+	}
+	id := createIssue(t, conn, "Needs eyes", model.StatusTodo, model.PriorityHigh)
+	if err := db.UpdateIssue(conn, id, map[string]interface{}{"assignee": "jane"}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+	if _, err := db.CreateComment(conn, &model.Comment{IssueID: id, Body: "@jane ping", Author: "bob"}); err != nil {
+		t.Fatalf("CreateComment: %v", err)
+	}
+
+	list := func(unread bool) inboxResult {
+		t.Helper()
+		cmd := cmdWithDB(conn)
+		cmd.Flags().Bool("unread", unread, "")
+		w, buf := bufWriter(true)
+		if err := runInbox(cmd, nil, w); err != nil {
+			t.Fatalf("runInbox: %v", err)
+		}
+		var env struct {
+			Data inboxResult `json:"data"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+			t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+		}
+		return env.Data
+	}
+
+	got := list(false)
+	if got.Recipient != "jane" || got.Total != 2 || got.Unread != 2 {
+		t.Fatalf("inbox = %+v, want 2 unread notifications for jane", got)
+	}
+
+	cmd := cmdWithDB(conn)
+	cmd.Flags().Int("id", 0, "")
+	cmd.Flags().Set("id", "1")
+	w, _ := bufWriter(true)
+	if err := runInboxRead(cmd, nil, w); err != nil {
+		t.Fatalf("runInboxRead --id: %v", err)
+	}
+	if got := list(true); got.Total != 1 {
+		t.Errorf("unread after --id = %d, want 1", got.Total)
+	}
+
+	cmd = cmdWithDB(conn)
+	cmd.Flags().Bool("all", true, "")
+	w, _ = bufWriter(true)
+	if err := runInboxRead(cmd, nil, w); err != nil {
+		t.Fatalf("runInboxRead --all: %v", err)
+	}
+	if got := list(false); got.Total != 2 || got.Unread != 0 {
+		t.Errorf("inbox after --all = %+v, want 2 read notifications", got)
+	}
+}
+
+func TestInboxReadFlagErrors(t *testing.T) {
+	conn := newTestDB(t)
+	if err := db.SetCurrentUser(conn, "jane"); err != nil {
+		t.Fatalf("SetCurrentUser: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		all  bool
+		id   string
+		want output.ErrorCode
+	}{
+		{"neither", false, "", output.ErrValidation},
+		{"both", true, "1", output.ErrValidation},
+		{"missing", false, "42", output.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := cmdWithDB(conn)
+			cmd.Flags().Bool("all", tt.all, "")
+			cmd.Flags().Int("id", 0, "")
+			if tt.id != "" {
+				cmd.Flags().Set("id", tt.id)
+			}
+			w, _ := bufWriter(true)
+			var ce *CmdError
+			if err := runInboxRead(cmd, nil, w); !errors.As(err, &ce) || ce.Code != tt.want {
+				t.Errorf("runInboxRead error = %v, want code %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestInboxWithoutCurrentUser(t *testing.T) {
+	conn := newTestDB(t)
+	w, _ := bufWriter(true)
+	var ce *CmdError
+	if err := runInbox(cmdWithDB(conn), nil, w); !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Errorf("runInbox error = %v, want a validation error", err)
+	}
+}
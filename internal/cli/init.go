@@ -3,30 +3,88 @@ package cli
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
 	"github.com/ALT-F4-LLC/docket/internal/output"
 	"github.com/ALT-F4-LLC/docket/internal/render"
 
 	"github.com/spf13/cobra"
 )
 
+// initResult is the JSON payload of docket init.
+type initResult struct {
+	Path          string      `json:"path"`
+	DBPath        string      `json:"db_path"`
+	SchemaVersion int         `json:"schema_version"`
+	Created       bool        `json:"created"`
+	StartID       int         `json:"start_id,omitempty"`
+	Seeded        *seedCounts `json:"seeded,omitempty"`
+}
+
 var initCmd = &cobra.Command{
-	Use:         "init",
-	Short:       "Initialize a new docket database",
+	Use:   "init",
+	Short: "Initialize a new docket database",
+	Long: `Initialize a new docket database.
+
+With --from, the new database is seeded from a JSON export at a local path
+or an http(s) URL, so every repository can start with a standard set of
+epics and labels. With --sample, it is seeded with a small demo dataset.
+Seeding an existing database requires --force and replaces all of its data.
+
+With --start-id, issues in the new database are numbered from that ID, e.g.
+10000, so two teams' databases can later be merged without their IDs
+colliding. 'docket import --merge' skips any incoming issue whose ID is
+already taken, so keep the ranges apart.`,
 	Annotations: map[string]string{"skipDB": "true"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		w := getWriter(cmd)
 		cfg := getCfg(cmd)
 
+		from, _ := cmd.Flags().GetString("from")
+		sample, _ := cmd.Flags().GetBool("sample")
+		force, _ := cmd.Flags().GetBool("force")
+		startID, _ := cmd.Flags().GetInt("start-id")
+		if startID < 0 {
+			return cmdErr(fmt.Errorf("--start-id must be a positive number"), output.ErrValidation)
+		}
+
+		// Load the seed data before touching the filesystem so a bad
+		// export never leaves a half-initialized database behind.
+		var seed *model.ExportData
+		switch {
+		case from != "":
+			var err error
+			if seed, err = loadSeedExport(from); err != nil {
+				return err
+			}
+		case sample:
+			seed = sampleExport(time.Now())
+		}
+
 		exists, err := cfg.Exists()
 		if err != nil {
 			return cmdErr(fmt.Errorf("checking database: %w", err), output.ErrGeneral)
 		}
 
-		if exists {
+		if exists && seed != nil && !force {
+			return cmdErr(
+				fmt.Errorf("database already exists at %s: use --force to replace its data", cfg.DBPath),
+				output.ErrConflict,
+			)
+		}
+
+		if exists && seed == nil && startID > 0 {
+			return cmdErr(
+				fmt.Errorf("database already exists at %s: --start-id applies only when creating or re-seeding a database", cfg.DBPath),
+				output.ErrConflict,
+			)
+		}
+
+		if exists && seed == nil {
 			w.Warn("Database already exists at %s", cfg.DBPath)
 
 			conn, err := db.Open(cfg.DBPath)
@@ -46,12 +104,7 @@ var initCmd = &cobra.Command{
 
 			msg := render.StyledText("Database already initialized", lipgloss.NewStyle().Foreground(lipgloss.Color("3")))
 
-			w.Success(struct {
-				Path          string `json:"path"`
-				DBPath        string `json:"db_path"`
-				SchemaVersion int    `json:"schema_version"`
-				Created       bool   `json:"created"`
-			}{
+			w.Success(initResult{
 				Path:          cfg.DocketDir,
 				DBPath:        cfg.DBPath,
 				SchemaVersion: schemaVersion,
@@ -84,27 +137,58 @@ var initCmd = &cobra.Command{
 			return cmdErr(fmt.Errorf("reading schema version: %w", err), output.ErrGeneral)
 		}
 
-		successMsg := render.StyledText("Initialized docket database", lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("10")))
-
-		w.Success(struct {
-			Path          string `json:"path"`
-			DBPath        string `json:"db_path"`
-			SchemaVersion int    `json:"schema_version"`
-			Created       bool   `json:"created"`
-		}{
+		result := initResult{
 			Path:          cfg.DocketDir,
 			DBPath:        cfg.DBPath,
 			SchemaVersion: schemaVersion,
-			Created:       true,
-		}, successMsg)
+			Created:       !exists,
+		}
+
+		if seed != nil {
+			// With --force on an existing database, replace its data.
+			if err := db.WithRetry(func() error {
+				_, err := doImport(conn, seed, exists)
+				return err
+			}); err != nil {
+				return cmdErr(fmt.Errorf("seeding database: %w", err), output.ErrGeneral)
+			}
+			counts := countSeeded(seed)
+			result.Seeded = &counts
+		}
+
+		// Set after seeding so seeded issues keep their IDs and new ones
+		// follow whichever is higher.
+		if startID > 0 {
+			if err := db.SetStartID(conn, startID); err != nil {
+				return cmdErr(fmt.Errorf("setting start ID: %w", err), output.ErrGeneral)
+			}
+			result.StartID = startID
+		}
 
-		w.Info("Database created at %s", cfg.DBPath)
-		w.Info("Consider adding .docket/ to your .gitignore")
+		successMsg := render.StyledText("Initialized docket database", lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("10")))
+
+		w.Success(result, successMsg)
+
+		if result.Seeded != nil {
+			w.Info("Seeded %d issue(s), %d label(s), %d relation(s)", result.Seeded.Issues, result.Seeded.Labels, result.Seeded.Relations)
+		}
+		if result.StartID > 0 {
+			w.Info("Issue IDs start at %s", model.FormatID(result.StartID))
+		}
+		if !exists {
+			w.Info("Database created at %s", cfg.DBPath)
+			w.Info("Consider adding .docket/ to your .gitignore")
+		}
 
 		return nil
 	},
 }
 
 func init() {
+	initCmd.Flags().String("from", "", "Seed the database from a JSON export file or http(s) URL")
+	initCmd.Flags().Bool("sample", false, "Seed the database with a small demo dataset")
+	initCmd.Flags().Bool("force", false, "Replace the data of an existing database when seeding")
+	initCmd.Flags().Int("start-id", 0, "Number new issues from this ID (e.g. 10000) instead of 1")
+	initCmd.MarkFlagsMutuallyExclusive("from", "sample")
 	rootCmd.AddCommand(initCmd)
 }
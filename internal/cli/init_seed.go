@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+const (
+	// seedFetchTimeout bounds the whole HTTP request for init --from <url>.
+	seedFetchTimeout = 30 * time.Second
+	// maxSeedSize caps the size of a remote seed export.
+	maxSeedSize = 64 << 20 // 64 MiB
+)
+
+// seedCounts reports how many entities init seeded into a new database.
+type seedCounts struct {
+	Issues     int `json:"issues"`
+	Labels     int `json:"labels"`
+	Relations  int `json:"relations"`
+	Comments   int `json:"comments"`
+	Milestones int `json:"milestones"`
+	Docs       int `json:"docs"`
+}
+
+func countSeeded(export *model.ExportData) seedCounts {
+	return seedCounts{
+		Issues:     len(export.Issues),
+		Labels:     len(export.Labels),
+		Relations:  len(export.Relations),
+		Comments:   len(export.Comments),
+		Milestones: len(export.Milestones),
+		Docs:       len(export.Docs),
+	}
+}
+
+// loadSeedExport reads and validates the JSON export at source, which is
+// either a local path or an http(s) URL.
+func loadSeedExport(source string) (*model.ExportData, error) {
+	var data []byte
+	if strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://") {
+		var err error
+		data, err = fetchSeedExport(source)
+		if err != nil {
+			return nil, cmdErr(fmt.Errorf("fetching %s: %w", source, err), output.ErrGeneral)
+		}
+	} else {
+		var err error
+		data, err = os.ReadFile(source)
+		if err != nil {
+			return nil, cmdErr(fmt.Errorf("reading file: %w", err), output.ErrGeneral)
+		}
+	}
+
+	var export model.ExportData
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, cmdErr(fmt.Errorf("parsing JSON: %w", err), output.ErrValidation)
+	}
+	if errs := validateExportData(&export); len(errs) > 0 {
+		return nil, cmdErr(importValidationError(errs), output.ErrValidation)
+	}
+	return &export, nil
+}
+
+func fetchSeedExport(url string) ([]byte, error) {
+	client := &http.Client{Timeout: seedFetchTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	data, err := io.ReadAll(&io.LimitedReader{R: resp.Body, N: maxSeedSize + 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxSeedSize {
+		return nil, fmt.Errorf("export exceeds %d bytes", maxSeedSize)
+	}
+	return data, nil
+}
+
+// sampleExport builds the demo dataset seeded by init --sample. It is built
+// from the model types rather than a checked-in file so it always matches
+// the current schema.
+func sampleExport(now time.Time) *model.ExportData {
+	now = now.UTC().Truncate(time.Second)
+	epicID := 1
+	issue := func(id int, title, description string, status model.Status, priority model.Priority, kind model.IssueKind) *model.Issue {
+		i := &model.Issue{
+			ID:          id,
+			Title:       title,
+			Description: description,
+			Status:      status,
+			Priority:    priority,
+			Kind:        kind,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if id != epicID {
+			i.ParentID = &epicID
+		}
+		return i
+	}
+
+	return &model.ExportData{
+		Version:    1,
+		ExportedAt: now.Format(time.RFC3339),
+		Issues: []*model.Issue{
+			issue(1, "Launch the demo app", "An epic grouping the work needed for a first release.", model.StatusInProgress, model.PriorityHigh, model.IssueKindEpic),
+			issue(2, "Design the data model", "Sketch the tables and relationships.", model.StatusDone, model.PriorityHigh, model.IssueKindTask),
+			issue(3, "Build the API", "Expose the data model over HTTP.", model.StatusInProgress, model.PriorityMedium, model.IssueKindFeature),
+			issue(4, "Write the getting started guide", "Document setup and first steps.", model.StatusTodo, model.PriorityMedium, model.IssueKindTask),
+			issue(5, "Fix crash on empty config", "The app panics when the config file is empty.", model.StatusBacklog, model.PriorityLow, model.IssueKindBug),
+		},
+		Comments: []*model.Comment{
+			{ID: 1, IssueID: 3, Body: "Blocked on the data model until it lands.", Author: "docket", CreatedAt: now},
+		},
+		// A chain: data model blocks the API, which blocks the guide.
+		Relations: []model.Relation{
+			{ID: 1, SourceIssueID: 2, TargetIssueID: 3, RelationType: model.RelationBlocks, CreatedAt: now},
+			{ID: 2, SourceIssueID: 3, TargetIssueID: 4, RelationType: model.RelationBlocks, CreatedAt: now},
+		},
+		Labels: []*model.Label{
+			{ID: 1, Name: "backend", Color: "#1d76db"},
+			{ID: 2, Name: "docs", Color: "#0e8a16"},
+			{ID: 3, Name: "good-first-issue", Color: "#7057ff"},
+		},
+		IssueLabelMappings: []model.IssueLabelMapping{
+			{IssueID: 2, LabelID: 1},
+			{IssueID: 3, LabelID: 1},
+			{IssueID: 4, LabelID: 2},
+			{IssueID: 5, LabelID: 3},
+		},
+	}
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+)
+
+func TestSampleExportImports(t *testing.T) {
+	conn := newTestDB(t)
+	seed := sampleExport(time.Now())
+
+	if errs := validateExportData(seed); len(errs) > 0 {
+		t.Fatalf("sample export is invalid: %v", errs)
+	}
+	if _, err := doImport(conn, seed, false); err != nil {
+		t.Fatalf("doImport: %v", err)
+	}
+
+	counts := countSeeded(seed)
+	if n, err := db.CountIssues(conn); err != nil || n != counts.Issues {
+		t.Errorf("CountIssues = %d, %v; want %d", n, err, counts.Issues)
+	}
+	relations, err := db.GetAllDirectionalRelations(conn)
+	if err != nil {
+		t.Fatalf("GetAllDirectionalRelations: %v", err)
+	}
+	if len(relations) != counts.Relations || counts.Relations == 0 {
+		t.Errorf("got %d relations, want %d", len(relations), counts.Relations)
+	}
+	sub, err := db.GetSubIssues(conn, 1)
+	if err != nil {
+		t.Fatalf("GetSubIssues: %v", err)
+	}
+	if len(sub) != counts.Issues-1 {
+		t.Errorf("epic has %d sub-issues, want %d", len(sub), counts.Issues-1)
+	}
+}
+
+func TestLoadSeedExportFromFileAndURL(t *testing.T) {
+	data, err := json.Marshal(sampleExport(time.Now()))
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "seed.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	fromFile, err := loadSeedExport(path)
+	if err != nil {
+		t.Fatalf("loadSeedExport(file): %v", err)
+	}
+	if len(fromFile.Issues) != 5 {
+		t.Errorf("loaded %d issues from file, want 5", len(fromFile.Issues))
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/seed.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	defer srv.Close()
+
+	fromURL, err := loadSeedExport(srv.URL + "/seed.json")
+	if err != nil {
+		t.Fatalf("loadSeedExport(url): %v", err)
+	}
+	if len(fromURL.Labels) != 3 {
+		t.Errorf("loaded %d labels from URL, want 3", len(fromURL.Labels))
+	}
+
+	if _, err := loadSeedExport(srv.URL + "/missing.json"); err == nil {
+		t.Error("expected an error for a 404 response")
+	}
+}
+
+func TestLoadSeedExportRejectsInvalidData(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(path, []byte(`{"version": 2, "issues": []}`), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := loadSeedExport(path); err == nil {
+		t.Error("expected a validation error for an unsupported version")
+	}
+}
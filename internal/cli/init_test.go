@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ALT-F4-LLC/docket/internal/config"
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+func initCmdWithCfg(cfg *config.Config, startID string) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("json", true, "")
+	cmd.Flags().Bool("quiet", true, "")
+	cmd.Flags().String("from", "", "")
+	cmd.Flags().Bool("sample", false, "")
+	cmd.Flags().Bool("force", false, "")
+	cmd.Flags().Int("start-id", 0, "")
+	cmd.Flags().Set("start-id", startID)
+	cmd.SetContext(context.WithValue(context.Background(), cfgKey, cfg))
+	return cmd
+}
+
+func TestInitStartID(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".docket")
+	cfg := &config.Config{DocketDir: dir, DBPath: filepath.Join(dir, "issues.db")}
+
+	if err := initCmd.RunE(initCmdWithCfg(cfg, "10000"), nil); err != nil {
+		t.Fatalf("init --start-id 10000: %v", err)
+	}
+
+	conn, err := db.Open(cfg.DBPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer conn.Close()
+
+	if next, err := db.GetNextIssueID(conn); err != nil || next != 10000 {
+		t.Errorf("GetNextIssueID = %d, %v; want 10000", next, err)
+	}
+	if id := createIssue(t, conn, "First", model.StatusTodo, model.PriorityLow); id != 10000 {
+		t.Errorf("first issue ID = %d, want 10000", id)
+	}
+
+	// An existing database keeps its numbering.
+	err = initCmd.RunE(initCmdWithCfg(cfg, "500"), nil)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrConflict {
+		t.Errorf("init --start-id on an existing database = %v, want a conflict", err)
+	}
+	if next, err := db.GetNextIssueID(conn); err != nil || next != 10001 {
+		t.Errorf("GetNextIssueID after rejected re-init = %d, %v; want 10001", next, err)
+	}
+}
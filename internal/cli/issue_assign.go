@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/config"
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+	"github.com/spf13/cobra"
+)
+
+// assignmentChangeJSON is one assignee change in the assignment history
+// JSON output; an empty from or to means unassigned.
+type assignmentChangeJSON struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	By   string `json:"by"`
+	At   string `json:"at"`
+}
+
+// assigneeTenureJSON is the total time one assignee held the issue.
+type assigneeTenureJSON struct {
+	Assignee    string `json:"assignee"`
+	HeldSeconds int64  `json:"held_seconds"`
+	Current     bool   `json:"current"`
+}
+
+// assignmentHistoryResult is the JSON output of 'issue show
+// --assignment-history'.
+type assignmentHistoryResult struct {
+	ID       string                 `json:"id"`
+	Assignee string                 `json:"assignee"`
+	Changes  []assignmentChangeJSON `json:"changes"`
+	Tenure   []assigneeTenureJSON   `json:"tenure"`
+}
+
+var assignCmd = &cobra.Command{
+	Use:   "assign <id> <name|me|none>",
+	Short: "Assign an issue to someone, or unassign it",
+	Long: `Assign an issue to someone, or unassign it:
+
+  docket issue assign DKT-5 alice
+  docket issue assign DKT-5 me
+  docket issue assign DKT-5 none
+
+"me" is the configured current user. 'docket issue show --assignment-history'
+lists everyone who has held the issue.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAssign(cmd, args, getWriter(cmd))
+	},
+}
+
+// runAssign sets the assignee of the issue named by args[0] to args[1].
+func runAssign(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	id, err := model.ParseID(args[0])
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+
+	assignee := strings.TrimSpace(args[1])
+	if strings.EqualFold(assignee, "none") {
+		assignee = ""
+	} else if assignee, err = resolveAssignee(cmd, conn, assignee); err != nil {
+		return err
+	}
+
+	if err := db.UpdateIssue(conn, id, map[string]interface{}{"assignee": assignee}, config.DefaultAuthor()); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return issueNotFoundErr(conn, w, id)
+		}
+		if errors.Is(err, db.ErrValidation) {
+			return cmdErr(err, output.ErrValidation)
+		}
+		return cmdErr(fmt.Errorf("updating issue: %w", err), output.ErrGeneral)
+	}
+
+	issue, err := db.GetIssue(conn, id)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching updated issue: %w", err), output.ErrGeneral)
+	}
+	message := fmt.Sprintf("Assigned %s to %s: %s", model.FormatID(id), assignee, issue.Title)
+	if assignee == "" {
+		message = fmt.Sprintf("Unassigned %s: %s", model.FormatID(id), issue.Title)
+	}
+	w.Success(issue, message)
+	return nil
+}
+
+// writeAssignmentHistory writes the assignee changes of issue, read from its
+// activity, and the time each assignee has held it.
+func writeAssignmentHistory(w *output.Writer, issue *model.Issue, activity []model.Activity, now time.Time) {
+	changes := model.AssignmentChanges(activity)
+	tenures := model.AssignmentTenures(issue.CreatedAt, changes, issue.Assignee, now)
+
+	if !w.JSONMode {
+		w.Success(nil, render.RenderAssignmentHistory(issue, changes, tenures, now))
+		return
+	}
+
+	result := assignmentHistoryResult{
+		ID:       model.FormatID(issue.ID),
+		Assignee: issue.Assignee,
+		Changes:  make([]assignmentChangeJSON, 0, len(changes)),
+		Tenure:   make([]assigneeTenureJSON, 0, len(tenures)),
+	}
+	for _, c := range changes {
+		result.Changes = append(result.Changes, assignmentChangeJSON{
+			From: c.From,
+			To:   c.To,
+			By:   c.By,
+			At:   c.At.UTC().Format(time.RFC3339),
+		})
+	}
+	for _, t := range tenures {
+		result.Tenure = append(result.Tenure, assigneeTenureJSON{
+			Assignee:    t.Assignee,
+			HeldSeconds: int64(t.Held / time.Second),
+			Current:     t.Current,
+		})
+	}
+	w.Success(result, "")
+}
+
+func init() {
+	issueCmd.AddCommand(assignCmd)
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+func TestAssignAndAssignmentHistory(t *testing.T) {
+	conn := newTestDB(t)
+	id := createIssue(t, conn, "Rotate keys", model.StatusTodo, model.PriorityHigh)
+	ref := model.FormatID(id)
+
+	for _, name := range []string{"alice", "bob", "none"} {
+		w, _ := bufWriter(true)
+		if err := runAssign(cmdWithDB(conn), []string{ref, name}, w); err != nil {
+			t.Fatalf("runAssign(%s): %v", name, err)
+		}
+	}
+	issue, err := db.GetIssue(conn, id)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if issue.Assignee != "" {
+		t.Errorf("assignee = %q after 'none', want unassigned", issue.Assignee)
+	}
+
+	cmd := cmdWithDB(conn)
+	cmd.Flags().Bool("assignment-history", false, "")
+	cmd.Flags().Set("assignment-history", "true")
+	w, buf := bufWriter(true)
+	if err := runIssueShow(cmd, []string{ref}, w); err != nil {
+		t.Fatalf("runIssueShow: %v", err)
+	}
+
+	var got struct {
+		Data assignmentHistoryResult `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	// The three changes share a second, so compare them as a set of hops.
+	hops := make(map[[2]string]bool)
+	for _, c := range got.Data.Changes {
+		hops[[2]string{c.From, c.To}] = true
+	}
+	for _, want := range [][2]string{{"", "alice"}, {"alice", "bob"}, {"bob", ""}} {
+		if !hops[want] {
+			t.Errorf("changes = %+v, missing %s → %s", got.Data.Changes, want[0], want[1])
+		}
+	}
+	if len(got.Data.Changes) != 3 {
+		t.Errorf("changes = %d, want 3", len(got.Data.Changes))
+	}
+	for _, tenure := range got.Data.Tenure {
+		if tenure.Current {
+			t.Errorf("tenure %+v is current, want none after unassigning", tenure)
+		}
+	}
+}
+
+func TestAssignMeNeedsCurrentUser(t *testing.T) {
+	conn := newTestDB(t)
+	id := createIssue(t, conn, "Rotate keys", model.StatusTodo, model.PriorityHigh)
+
+	w, _ := bufWriter(true)
+	err := runAssign(cmdWithDB(conn), []string{model.FormatID(id), "me"}, w)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Fatalf("runAssign(me) error = %v, want a validation error", err)
+	}
+}
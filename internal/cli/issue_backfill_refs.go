@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// backfillRefsResult is the JSON payload for issue backfill-refs.
+type backfillRefsResult struct {
+	References int `json:"references"`
+}
+
+var backfillRefsCmd = &cobra.Command{
+	Use:   "backfill-refs",
+	Short: "Rebuild issue references from existing descriptions and comments",
+	Long: `Scans every issue description and comment for issue IDs (e.g. DKT-12) and
+rebuilds the reference index shown under "References" and "Referenced by"
+in issue show. New and edited text is indexed automatically; run this once
+after upgrading or after an import.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := getWriter(cmd)
+		conn := getDB(cmd)
+
+		n, err := db.BackfillReferences(conn)
+		if err != nil {
+			return cmdErr(fmt.Errorf("backfilling references: %w", err), output.ErrGeneral)
+		}
+
+		w.Success(backfillRefsResult{References: n}, fmt.Sprintf("Indexed %d issue references", n))
+		return nil
+	},
+}
+
+func init() {
+	issueCmd.AddCommand(backfillRefsCmd)
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ALT-F4-LLC/docket/internal/config"
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+// branchResult is the JSON wire format for the branch command output.
+type branchResult struct {
+	Branch string `json:"branch"`
+}
+
+var branchCmd = &cobra.Command{
+	Use:   "branch <id>",
+	Short: "Print a git branch name for an issue, or create it with --checkout",
+	Long: `Print a git branch name for an issue, made from its ID and title, e.g.
+"dkt-42-fix-login-timeout".
+
+With --checkout the branch is created and switched to with 'git switch -c' in
+the root of the current git repository, and recorded in the issue's activity.
+JSON output only reports the name and never runs git.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runIssueBranch(cmd, args, getWriter(cmd))
+	},
+}
+
+func runIssueBranch(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	id, err := model.ParseID(args[0])
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+
+	issue, err := db.GetIssue(conn, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return issueNotFoundErr(conn, w, id)
+		}
+		return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
+	}
+
+	branch := model.Slug(issue)
+	result := branchResult{Branch: branch}
+
+	checkout, _ := cmd.Flags().GetBool("checkout")
+	if w.JSONMode {
+		w.Success(result, "")
+		return nil
+	}
+	if !checkout {
+		// Written directly rather than through Success so the name can be
+		// used as is, e.g. in $(docket issue branch DKT-42).
+		fmt.Fprintln(w.Stdout, branch)
+		return nil
+	}
+
+	if err := requireWritable(cmd); err != nil {
+		return err
+	}
+	if err := gitSwitchCreate(branch); err != nil {
+		return err
+	}
+	if err := db.RecordActivity(conn, id, "branch", "", branch, config.DefaultAuthor()); err != nil {
+		return cmdErr(err, output.ErrGeneral)
+	}
+	w.Success(result, fmt.Sprintf("Switched to a new branch %s", branch))
+	return nil
+}
+
+// gitSwitchCreate creates branch and switches to it in the root of the git
+// repository holding the working directory.
+func gitSwitchCreate(branch string) error {
+	root, err := runGit("", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return cmdErr(fmt.Errorf("not in a git repository: %w", err), output.ErrValidation)
+	}
+	if _, err := runGit(root, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+		return cmdErr(fmt.Errorf("branch %q already exists", branch), output.ErrConflict)
+	}
+	if _, err := runGit(root, "switch", "-c", branch); err != nil {
+		return cmdErr(fmt.Errorf("creating branch %q: %w", branch, err), output.ErrGeneral)
+	}
+	return nil
+}
+
+// runGit runs git with args in dir ("" for the working directory) and
+// returns its trimmed output. A failure carries git's error message.
+func runGit(dir string, args ...string) (string, error) {
+	gitCmd := exec.Command("git", args...)
+	gitCmd.Dir = dir
+	var stderr bytes.Buffer
+	gitCmd.Stderr = &stderr
+	out, err := gitCmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func init() {
+	branchCmd.Flags().Bool("checkout", false, "Create the branch and switch to it")
+	issueCmd.AddCommand(branchCmd)
+}
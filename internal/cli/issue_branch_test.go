@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func branchCmdWithDB(conn *sql.DB, checkout bool) *cobra.Command {
+	cmd := cmdWithDB(conn)
+	cmd.Flags().Bool("checkout", checkout, "")
+	return cmd
+}
+
+func TestIssueBranch_PrintsName(t *testing.T) {
+	conn := newTestDB(t)
+	id := createIssue(t, conn, "Fix login timeout", model.StatusTodo, model.PriorityHigh)
+
+	w, buf := bufWriter(false)
+	if err := runIssueBranch(branchCmdWithDB(conn, false), []string{model.FormatID(id)}, w); err != nil {
+		t.Fatalf("runIssueBranch: %v", err)
+	}
+	if got, want := strings.TrimSpace(buf.String()), model.Slug(&model.Issue{ID: id, Title: "Fix login timeout"}); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestIssueBranch_JSONNeverRunsGit(t *testing.T) {
+	conn := newTestDB(t)
+	id := createIssue(t, conn, "Fix login timeout", model.StatusTodo, model.PriorityHigh)
+	t.Chdir(t.TempDir()) // not a git repository, so running git would fail
+
+	w, buf := bufWriter(true)
+	if err := runIssueBranch(branchCmdWithDB(conn, true), []string{model.FormatID(id)}, w); err != nil {
+		t.Fatalf("runIssueBranch: %v", err)
+	}
+	var env struct {
+		Data branchResult `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !strings.HasSuffix(env.Data.Branch, "-fix-login-timeout") {
+		t.Errorf("branch = %q", env.Data.Branch)
+	}
+}
+
+func TestIssueBranch_Checkout(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	conn := newTestDB(t)
+	id := createIssue(t, conn, "Fix login timeout", model.StatusTodo, model.PriorityHigh)
+	args := []string{model.FormatID(id)}
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	w, _ := bufWriter(false)
+	err := runIssueBranch(branchCmdWithDB(conn, true), args, w)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Fatalf("checkout outside a git repository: err = %v, want a validation error", err)
+	}
+
+	for _, gitArgs := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", gitArgs...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", gitArgs, err, out)
+		}
+	}
+
+	if err := runIssueBranch(branchCmdWithDB(conn, true), args, w); err != nil {
+		t.Fatalf("runIssueBranch --checkout: %v", err)
+	}
+	branch, err := runGit(dir, "branch", "--show-current")
+	if err != nil {
+		t.Fatalf("git branch: %v", err)
+	}
+	want := strings.ToLower(model.FormatID(id)) + "-fix-login-timeout"
+	if branch != want {
+		t.Errorf("current branch = %q, want %q", branch, want)
+	}
+
+	activity, err := db.GetActivity(conn, id, 0)
+	if err != nil {
+		t.Fatalf("GetActivity: %v", err)
+	}
+	if !slices.ContainsFunc(activity, func(a model.Activity) bool {
+		return a.FieldChanged == "branch" && a.NewValue == want
+	}) {
+		t.Errorf("activity = %+v, want a branch entry for %q", activity, want)
+	}
+
+	err = runIssueBranch(branchCmdWithDB(conn, true), args, w)
+	if !errors.As(err, &ce) || ce.Code != output.ErrConflict {
+		t.Errorf("checkout of an existing branch: err = %v, want a conflict error", err)
+	}
+}
@@ -21,13 +21,13 @@ var closeCmd = &cobra.Command{
 
 		id, err := model.ParseID(args[0])
 		if err != nil {
-			return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+			return cmdErr(err, output.ErrValidation)
 		}
 
 		issue, err := db.GetIssue(conn, id)
 		if err != nil {
 			if errors.Is(err, db.ErrNotFound) {
-				return cmdErr(fmt.Errorf("issue %s not found", args[0]), output.ErrNotFound)
+				return issueNotFoundErr(conn, w, id)
 			}
 			return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
 		}
@@ -43,6 +43,9 @@ var closeCmd = &cobra.Command{
 
 		err = db.UpdateIssue(conn, id, map[string]interface{}{"status": "done"}, config.DefaultAuthor())
 		if err != nil {
+			if errors.Is(err, db.ErrValidation) {
+				return cmdErr(err, output.ErrValidation)
+			}
 			return cmdErr(fmt.Errorf("closing issue: %w", err), output.ErrGeneral)
 		}
 
@@ -51,8 +54,7 @@ var closeCmd = &cobra.Command{
 			return cmdErr(fmt.Errorf("fetching updated issue: %w", err), output.ErrGeneral)
 		}
 
-		w.Success(issue, fmt.Sprintf("Closed %s: %s", model.FormatID(id), issue.Title))
-		return nil
+		return writeDoneResult(conn, w, issue, fmt.Sprintf("Closed %s: %s", model.FormatID(id), issue.Title))
 	},
 }
 
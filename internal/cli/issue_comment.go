@@ -30,14 +30,14 @@ var commentAddCmd = &cobra.Command{
 
 		id, err := model.ParseID(args[0])
 		if err != nil {
-			return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+			return cmdErr(err, output.ErrValidation)
 		}
 
 		// Verify issue exists.
 		issue, err := db.GetIssue(conn, id)
 		if err != nil {
 			if errors.Is(err, db.ErrNotFound) {
-				return cmdErr(fmt.Errorf("issue %s not found", args[0]), output.ErrNotFound)
+				return issueNotFoundErr(conn, w, id)
 			}
 			return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
 		}
@@ -120,7 +120,7 @@ var commentAddCmd = &cobra.Command{
 		commentID, err := db.CreateComment(conn, &comment)
 		if err != nil {
 			if errors.Is(err, db.ErrNotFound) {
-				return cmdErr(fmt.Errorf("issue %s not found", args[0]), output.ErrNotFound)
+				return issueNotFoundErr(conn, w, id)
 			}
 			return cmdErr(fmt.Errorf("creating comment: %w", err), output.ErrGeneral)
 		}
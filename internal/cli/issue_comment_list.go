@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"os"
@@ -49,18 +50,18 @@ func runIssueCommentList(cmd *cobra.Command, args []string, w *output.Writer) er
 
 	id, err := model.ParseID(args[0])
 	if err != nil {
-		return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+		return cmdErr(err, output.ErrValidation)
 	}
 
 	// Verify the issue exists.
 	if _, err := db.GetIssue(conn, id); err != nil {
 		if errors.Is(err, db.ErrNotFound) {
-			return cmdErr(fmt.Errorf("issue %s not found", args[0]), output.ErrNotFound)
+			return issueNotFoundErr(conn, w, id)
 		}
 		return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
 	}
 
-	comments, err := db.ListComments(conn, id)
+	comments, err := issueComments(cmd, conn, id)
 	if err != nil {
 		return cmdErr(fmt.Errorf("fetching comments: %w", err), output.ErrGeneral)
 	}
@@ -84,6 +85,16 @@ func runIssueCommentList(cmd *cobra.Command, args []string, w *output.Writer) er
 	return nil
 }
 
+// issueComments lists the comments on an issue oldest first, or newest first
+// when the command's --comments-desc flag is set.
+func issueComments(cmd *cobra.Command, conn *sql.DB, id int) ([]*model.Comment, error) {
+	if desc, _ := cmd.Flags().GetBool("comments-desc"); desc {
+		return db.ListIssueCommentsNewestFirst(conn, id)
+	}
+	return db.ListIssueComments(conn, id)
+}
+
 func init() {
+	commentListCmd.Flags().Bool("comments-desc", false, "List the newest comments first")
 	commentCmd.AddCommand(commentListCmd)
 }
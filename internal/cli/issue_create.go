@@ -3,10 +3,11 @@ package cli
 import (
 	"errors"
 	"fmt"
-	"io"
 	"os"
+	"slices"
 	"strings"
 
+	"github.com/ALT-F4-LLC/docket/internal/config"
 	"github.com/ALT-F4-LLC/docket/internal/db"
 	"github.com/ALT-F4-LLC/docket/internal/model"
 	"github.com/ALT-F4-LLC/docket/internal/output"
@@ -19,192 +20,236 @@ var createCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new issue",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		w := getWriter(cmd)
-		conn := getDB(cmd)
-
-		title, _ := cmd.Flags().GetString("title")
-		description, _ := cmd.Flags().GetString("description")
-		status, _ := cmd.Flags().GetString("status")
-		priority, _ := cmd.Flags().GetString("priority")
-		kind, _ := cmd.Flags().GetString("type")
-		labelFlag, _ := cmd.Flags().GetStringSlice("label")
-		fileFlag, _ := cmd.Flags().GetStringSlice("file")
-		assignee, _ := cmd.Flags().GetString("assignee")
-		parent, _ := cmd.Flags().GetString("parent")
-		jsonMode, _ := cmd.Flags().GetBool("json")
-
-		// If JSON mode and no title, return validation error.
-		if jsonMode && title == "" {
-			return cmdErr(fmt.Errorf("--title is required in JSON mode"), output.ErrValidation)
-		}
+		return runIssueCreate(cmd, args, getWriter(cmd))
+	},
+}
 
-		// If no title and not JSON mode, launch interactive form.
-		// The status, priority, and kind variables already hold their flag
-		// defaults ("backlog", "none", "task"). Passing them via .Value(...)
-		// ensures the select widgets pre-select the matching default.
-		if !jsonMode && title == "" {
-			if !term.IsTerminal(int(os.Stdin.Fd())) {
-				return cmdErr(fmt.Errorf("non-interactive environment detected; provide all required flags: --title"), output.ErrValidation)
-			}
-			var labelStr string
-			var fileStr string
-			form := huh.NewForm(
-				huh.NewGroup(
-					huh.NewInput().
-						Title("Title").
-						Value(&title).
-						Validate(func(s string) error {
-							if strings.TrimSpace(s) == "" {
-								return fmt.Errorf("title is required")
-							}
-							return nil
-						}),
-					huh.NewText().
-						Title("Description").
-						Value(&description),
-					huh.NewSelect[string]().
-						Title("Status").
-						Options(
-							huh.NewOption("backlog", "backlog"),
-							huh.NewOption("todo", "todo"),
-							huh.NewOption("in-progress", "in-progress"),
-							huh.NewOption("review", "review"),
-							huh.NewOption("done", "done"),
-						).
-						Value(&status), // pre-selects flag default ("backlog")
-					huh.NewSelect[string]().
-						Title("Priority").
-						Options(
-							huh.NewOption("none", "none"),
-							huh.NewOption("low", "low"),
-							huh.NewOption("medium", "medium"),
-							huh.NewOption("high", "high"),
-							huh.NewOption("critical", "critical"),
-						).
-						Value(&priority), // pre-selects flag default ("none")
-					huh.NewSelect[string]().
-						Title("Type").
-						Options(
-							huh.NewOption("task", "task"),
-							huh.NewOption("bug", "bug"),
-							huh.NewOption("feature", "feature"),
-							huh.NewOption("epic", "epic"),
-							huh.NewOption("chore", "chore"),
-						).
-						Value(&kind), // pre-selects flag default ("task")
-					huh.NewInput().
-						Title("Assignee").
-						Value(&assignee),
-					huh.NewInput().
-						Title("Labels (comma-separated)").
-						Value(&labelStr),
-					huh.NewInput().
-						Title("Files (comma-separated)").
-						Value(&fileStr),
-				),
-			)
-
-			if err := form.Run(); err != nil {
-				if errors.Is(err, huh.ErrUserAborted) {
-					w.Info("Cancelled.")
-					return nil
-				}
-				return cmdErr(fmt.Errorf("interactive form failed: %w", err), output.ErrGeneral)
+func runIssueCreate(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	title, _ := cmd.Flags().GetString("title")
+	description, _ := cmd.Flags().GetString("description")
+	status, _ := cmd.Flags().GetString("status")
+	priority, _ := cmd.Flags().GetString("priority")
+	kind, _ := cmd.Flags().GetString("type")
+	labelFlag, _ := cmd.Flags().GetStringSlice("label")
+	fileFlag, _ := cmd.Flags().GetStringSlice("file")
+	parent, _ := cmd.Flags().GetString("parent")
+	templateName, _ := cmd.Flags().GetString("template")
+	jsonMode, _ := cmd.Flags().GetBool("json")
+
+	assignee, _ := assigneeFlag(cmd)
+
+	// Apply template defaults for anything not set explicitly by flags.
+	var tpl *model.IssueTemplate
+	if templateName != "" {
+		var err error
+		tpl, err = db.GetTemplate(conn, templateName)
+		if err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				return cmdErr(fmt.Errorf("template %q not found", templateName), output.ErrNotFound)
 			}
-
-			if labelStr != "" {
-				for _, l := range strings.Split(labelStr, ",") {
-					l = strings.TrimSpace(l)
-					if l != "" {
-						labelFlag = append(labelFlag, l)
-					}
-				}
+			return cmdErr(fmt.Errorf("fetching template: %w", err), output.ErrGeneral)
+		}
+		applyTemplateDefaults(cmd, tpl, &description, &priority, &kind, &labelFlag)
+		if title == "" && !tpl.HasTitlePlaceholder() {
+			title = tpl.TitlePattern
+		}
+	}
+
+	// Resolve --description-file, --editor, or "-d -". A template's
+	// description seeds the editor buffer.
+	if d, ok, err := descriptionFromFlags(cmd, os.Stdin, description); err != nil {
+		return err
+	} else if ok {
+		description = d
+	}
+
+	// If JSON mode and no title, return validation error.
+	if jsonMode && title == "" {
+		return cmdErr(fmt.Errorf("--title is required in JSON mode"), output.ErrValidation)
+	}
+
+	// If no title and not JSON mode, launch interactive form.
+	// The status, priority, and kind variables already hold their flag
+	// defaults ("backlog", "none", "task"). Passing them via .Value(...)
+	// ensures the select widgets pre-select the matching default.
+	if !jsonMode && title == "" {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return cmdErr(fmt.Errorf("non-interactive environment detected; provide all required flags: --title"), output.ErrValidation)
+		}
+		var labelStr string
+		var fileStr string
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewInput().
+					Title("Title").
+					Value(&title).
+					Validate(func(s string) error {
+						if strings.TrimSpace(s) == "" {
+							return fmt.Errorf("title is required")
+						}
+						return nil
+					}),
+				huh.NewText().
+					Title("Description").
+					Value(&description),
+				huh.NewSelect[string]().
+					Title("Status").
+					Options(
+						huh.NewOption("backlog", "backlog"),
+						huh.NewOption("todo", "todo"),
+						huh.NewOption("in-progress", "in-progress"),
+						huh.NewOption("review", "review"),
+						huh.NewOption("done", "done"),
+					).
+					Value(&status), // pre-selects flag default ("backlog")
+				huh.NewSelect[string]().
+					Title("Priority").
+					Options(
+						huh.NewOption("none", "none"),
+						huh.NewOption("low", "low"),
+						huh.NewOption("medium", "medium"),
+						huh.NewOption("high", "high"),
+						huh.NewOption("critical", "critical"),
+					).
+					Value(&priority), // pre-selects flag default ("none")
+				huh.NewSelect[string]().
+					Title("Type").
+					Options(
+						huh.NewOption("task", "task"),
+						huh.NewOption("bug", "bug"),
+						huh.NewOption("feature", "feature"),
+						huh.NewOption("epic", "epic"),
+						huh.NewOption("chore", "chore"),
+					).
+					Value(&kind), // pre-selects flag default ("task")
+				huh.NewInput().
+					Title("Assignee").
+					Value(&assignee),
+				huh.NewInput().
+					Title("Labels (comma-separated)").
+					Value(&labelStr),
+				huh.NewInput().
+					Title("Files (comma-separated)").
+					Value(&fileStr),
+			),
+		)
+
+		if err := form.Run(); err != nil {
+			if errors.Is(err, huh.ErrUserAborted) {
+				w.Info("Cancelled.")
+				return nil
 			}
+			return cmdErr(fmt.Errorf("interactive form failed: %w", err), output.ErrGeneral)
+		}
 
-			if fileStr != "" {
-				for _, f := range strings.Split(fileStr, ",") {
-					f = strings.TrimSpace(f)
-					if f != "" {
-						fileFlag = append(fileFlag, f)
-					}
+		if labelStr != "" {
+			for _, l := range strings.Split(labelStr, ",") {
+				l = strings.TrimSpace(l)
+				if l != "" {
+					labelFlag = append(labelFlag, l)
 				}
 			}
 		}
 
-		// Read description from stdin if "-".
-		if description == "-" {
-			const maxStdinSize = 1 << 20 // 1 MiB
-			data, err := io.ReadAll(io.LimitReader(os.Stdin, maxStdinSize))
-			if err != nil {
-				return cmdErr(fmt.Errorf("reading description from stdin: %w", err), output.ErrGeneral)
+		if fileStr != "" {
+			for _, f := range strings.Split(fileStr, ",") {
+				f = strings.TrimSpace(f)
+				if f != "" {
+					fileFlag = append(fileFlag, f)
+				}
 			}
-			description = strings.TrimRight(string(data), "\n")
-		}
-
-		// Validate enum values.
-		if err := model.ValidateStatus(model.Status(status)); err != nil {
-			return cmdErr(err, output.ErrValidation)
 		}
-		if err := model.ValidatePriority(model.Priority(priority)); err != nil {
-			return cmdErr(err, output.ErrValidation)
+	}
+
+	if tpl != nil {
+		title = tpl.ApplyTitle(title)
+	}
+
+	// Check every value before reporting, so all problems show at once.
+	var problems fieldErrors
+	problems.check("title", model.ValidateTitle(title))
+	problems.check("status", model.ValidateStatus(model.Status(status)))
+	problems.check("priority", model.ValidatePriority(model.Priority(priority)))
+	problems.check("type", model.ValidateIssueKind(model.IssueKind(kind)))
+
+	assignee, err := resolveAssignee(cmd, conn, assignee)
+	if err := problems.check("assignee", err); err != nil {
+		return err
+	}
+
+	var parentID *int
+	if parent != "" {
+		pid, err := parentFromFlag(conn, parent, 0)
+		if err := problems.check("parent", err); err != nil {
+			return err
 		}
-		if err := model.ValidateIssueKind(model.IssueKind(kind)); err != nil {
+		parentID = &pid
+	}
+
+	if err := problems.err(); err != nil {
+		return err
+	}
+
+	issue := model.Issue{
+		ParentID:    parentID,
+		Title:       title,
+		Description: description,
+		Status:      model.Status(status),
+		Priority:    model.Priority(priority),
+		Kind:        model.IssueKind(kind),
+		Assignee:    assignee,
+	}
+
+	id, err := db.CreateIssueBy(conn, &issue, labelFlag, fileFlag, config.DefaultAuthor())
+	if err != nil {
+		if errors.Is(err, db.ErrValidation) {
 			return cmdErr(err, output.ErrValidation)
 		}
+		return cmdErr(fmt.Errorf("creating issue: %w", err), output.ErrGeneral)
+	}
 
-		// Handle parent ID.
-		var parentID *int
-		if parent != "" {
-			pid, err := model.ParseID(parent)
-			if err != nil {
-				return cmdErr(fmt.Errorf("invalid parent ID: %w", err), output.ErrValidation)
-			}
-			// Verify parent exists.
-			if _, err := db.GetIssue(conn, pid); err != nil {
-				if errors.Is(err, db.ErrNotFound) {
-					return cmdErr(fmt.Errorf("parent issue %s not found", parent), output.ErrNotFound)
-				}
-				return cmdErr(fmt.Errorf("checking parent issue: %w", err), output.ErrGeneral)
-			}
-			parentID = &pid
-		}
+	// Refetch to get full object with timestamps.
+	created, err := db.GetIssue(conn, id)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching created issue: %w", err), output.ErrGeneral)
+	}
 
-		issue := model.Issue{
-			ParentID:    parentID,
-			Title:       title,
-			Description: description,
-			Status:      model.Status(status),
-			Priority:    model.Priority(priority),
-			Kind:        model.IssueKind(kind),
-			Assignee:    assignee,
-		}
+	w.Success(created, fmt.Sprintf("Created %s: %s", model.FormatID(id), created.Title))
 
-		id, err := db.CreateIssue(conn, &issue, labelFlag, fileFlag)
-		if err != nil {
-			return cmdErr(fmt.Errorf("creating issue: %w", err), output.ErrGeneral)
-		}
-
-		// Refetch to get full object with timestamps.
-		created, err := db.GetIssue(conn, id)
-		if err != nil {
-			return cmdErr(fmt.Errorf("fetching created issue: %w", err), output.ErrGeneral)
-		}
-
-		w.Success(created, fmt.Sprintf("Created %s: %s", model.FormatID(id), created.Title))
+	return nil
+}
 
-		return nil
-	},
+// applyTemplateDefaults fills description, priority, kind, and labels from
+// tpl unless the corresponding flag was set explicitly, so flags always win.
+func applyTemplateDefaults(cmd *cobra.Command, tpl *model.IssueTemplate, description, priority, kind *string, labels *[]string) {
+	if !cmd.Flags().Changed("description") && tpl.Description != "" {
+		*description = tpl.Description
+	}
+	if !cmd.Flags().Changed("priority") && tpl.Priority != "" {
+		*priority = string(tpl.Priority)
+	}
+	if !cmd.Flags().Changed("type") && tpl.Kind != "" {
+		*kind = string(tpl.Kind)
+	}
+	if !cmd.Flags().Changed("label") && len(tpl.Labels) > 0 {
+		*labels = slices.Clone(tpl.Labels)
+	}
 }
 
 func init() {
 	createCmd.Flags().StringP("title", "t", "", "Issue title")
 	createCmd.Flags().StringP("description", "d", "", "Issue description (use \"-\" for stdin)")
+	addDescriptionSourceFlags(createCmd)
 	createCmd.Flags().StringP("status", "s", "backlog", "Issue status")
 	createCmd.Flags().StringP("priority", "p", "none", "Issue priority")
 	createCmd.Flags().StringP("type", "T", "task", "Issue type")
 	createCmd.Flags().StringSliceP("label", "l", nil, "Issue labels (repeatable)")
 	createCmd.Flags().StringSliceP("file", "f", nil, "File paths (repeatable)")
-	createCmd.Flags().StringP("assignee", "a", "", "Issue assignee")
+	createCmd.Flags().StringP("assignee", "a", "", "Issue assignee (\"me\" for the configured current user)")
+	addMineFlag(createCmd, "Assign the issue to the configured current user")
 	createCmd.Flags().String("parent", "", "Parent issue ID")
+	createCmd.Flags().String("template", "", "Apply defaults from a saved issue template (see docket template list)")
 	issueCmd.AddCommand(createCmd)
 }
@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func createCmdWithDB(conn *sql.DB) *cobra.Command {
+	cmd := cmdWithDB(conn)
+	cmd.Flags().StringP("title", "t", "", "")
+	cmd.Flags().StringP("description", "d", "", "")
+	cmd.Flags().StringP("status", "s", "backlog", "")
+	cmd.Flags().StringP("priority", "p", "none", "")
+	cmd.Flags().StringP("type", "T", "task", "")
+	cmd.Flags().StringSliceP("label", "l", nil, "")
+	cmd.Flags().StringSliceP("file", "f", nil, "")
+	cmd.Flags().StringP("assignee", "a", "", "")
+	cmd.Flags().String("parent", "", "")
+	cmd.Flags().String("template", "", "")
+	return cmd
+}
+
+// runCreate runs issue create with the given flag values and returns the
+// created issue with its labels.
+func runCreate(t *testing.T, conn *sql.DB, flags map[string]string) *model.Issue {
+	t.Helper()
+	cmd := createCmdWithDB(conn)
+	if err := cmd.Flags().Set("json", "true"); err != nil {
+		t.Fatalf("set json: %v", err)
+	}
+	for name, value := range flags {
+		if err := cmd.Flags().Set(name, value); err != nil {
+			t.Fatalf("set %s: %v", name, err)
+		}
+	}
+
+	w, _ := bufWriter(true)
+	if err := runIssueCreate(cmd, nil, w); err != nil {
+		t.Fatalf("runIssueCreate: %v", err)
+	}
+
+	issues, err := db.ListAllIssues(conn)
+	if err != nil {
+		t.Fatalf("ListAllIssues: %v", err)
+	}
+	return issues[len(issues)-1]
+}
+
+func saveBugTemplate(t *testing.T, conn *sql.DB) {
+	t.Helper()
+	if err := db.SaveTemplate(conn, &model.IssueTemplate{
+		Name:         "bug",
+		TitlePattern: "Bug: {title}",
+		Description:  "Steps to reproduce:",
+		Kind:         model.IssueKindBug,
+		Priority:     model.PriorityHigh,
+		Labels:       []string{"triage", "bug"},
+	}); err != nil {
+		t.Fatalf("SaveTemplate: %v", err)
+	}
+}
+
+func TestIssueCreateAppliesTemplateDefaults(t *testing.T) {
+	conn := newTestDB(t)
+	saveBugTemplate(t, conn)
+
+	issue := runCreate(t, conn, map[string]string{"title": "crash on save", "template": "bug"})
+
+	if issue.Title != "Bug: crash on save" {
+		t.Errorf("Title = %q, want %q", issue.Title, "Bug: crash on save")
+	}
+	if issue.Kind != model.IssueKindBug || issue.Priority != model.PriorityHigh {
+		t.Errorf("Kind/Priority = %s/%s, want bug/high", issue.Kind, issue.Priority)
+	}
+	if issue.Description != "Steps to reproduce:" {
+		t.Errorf("Description = %q", issue.Description)
+	}
+	slices.Sort(issue.Labels)
+	if !slices.Equal(issue.Labels, []string{"bug", "triage"}) {
+		t.Errorf("Labels = %v, want [bug triage]", issue.Labels)
+	}
+}
+
+func TestIssueCreateFlagsOverrideTemplate(t *testing.T) {
+	conn := newTestDB(t)
+	saveBugTemplate(t, conn)
+
+	issue := runCreate(t, conn, map[string]string{
+		"title":       "slow query",
+		"template":    "bug",
+		"priority":    "low",
+		"type":        "chore",
+		"label":       "perf",
+		"description": "explicit",
+	})
+
+	if issue.Kind != model.IssueKindChore || issue.Priority != model.PriorityLow {
+		t.Errorf("Kind/Priority = %s/%s, want chore/low", issue.Kind, issue.Priority)
+	}
+	if issue.Description != "explicit" {
+		t.Errorf("Description = %q, want explicit", issue.Description)
+	}
+	if !slices.Equal(issue.Labels, []string{"perf"}) {
+		t.Errorf("Labels = %v, want [perf]", issue.Labels)
+	}
+}
+
+func TestIssueCreateUnknownTemplate(t *testing.T) {
+	conn := newTestDB(t)
+	cmd := createCmdWithDB(conn)
+	cmd.Flags().Set("title", "x")
+	cmd.Flags().Set("template", "nope")
+
+	w, _ := bufWriter(true)
+	err := runIssueCreate(cmd, nil, w)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrNotFound {
+		t.Errorf("runIssueCreate = %v, want NOT_FOUND CmdError", err)
+	}
+}
+
+// fieldErrorsOf returns the field errors the JSON envelope for err carries.
+func fieldErrorsOf(t *testing.T, err error) (output.ErrorCode, []fieldError) {
+	t.Helper()
+	var ce *CmdError
+	if !errors.As(err, &ce) {
+		t.Fatalf("err = %v, want a CmdError", err)
+	}
+	w, buf := bufWriter(true)
+	w.ErrorWithData(ce, ce.Code, ce.Data)
+	var env struct {
+		Code output.ErrorCode `json:"code"`
+		Data struct {
+			Errors []fieldError `json:"errors"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	return env.Code, env.Data.Errors
+}
+
+func TestIssueCreateReportsEveryInvalidValue(t *testing.T) {
+	conn := newTestDB(t)
+	cmd := createCmdWithDB(conn)
+	cmd.Flags().Set("json", "true")
+	cmd.Flags().Set("title", "x")
+	cmd.Flags().Set("status", "wip")
+	cmd.Flags().Set("priority", "urgent")
+	cmd.Flags().Set("type", "chore")
+	cmd.Flags().Set("parent", "DKT-99")
+
+	w, _ := bufWriter(true)
+	err := runIssueCreate(cmd, nil, w)
+	code, problems := fieldErrorsOf(t, err)
+	if code != output.ErrValidation {
+		t.Errorf("code = %s, want %s", code, output.ErrValidation)
+	}
+	var fields []string
+	for _, p := range problems {
+		fields = append(fields, p.Field)
+		if p.Message == "" || !strings.Contains(err.Error(), p.Message) {
+			t.Errorf("%s: message %q missing from %q", p.Field, p.Message, err)
+		}
+	}
+	if want := []string{"status", "priority", "parent"}; !slices.Equal(fields, want) {
+		t.Errorf("fields = %v, want %v", fields, want)
+	}
+	if n, _ := db.CountIssues(conn); n != 0 {
+		t.Errorf("CountIssues = %d, want 0", n)
+	}
+}
+
+func TestIssueCreateSingleInvalidValueKeepsItsCode(t *testing.T) {
+	conn := newTestDB(t)
+	cmd := createCmdWithDB(conn)
+	cmd.Flags().Set("json", "true")
+	cmd.Flags().Set("title", "x")
+	cmd.Flags().Set("parent", "DKT-99")
+
+	w, _ := bufWriter(true)
+	err := runIssueCreate(cmd, nil, w)
+	code, problems := fieldErrorsOf(t, err)
+	if code != output.ErrNotFound || len(problems) != 1 || problems[0].Field != "parent" {
+		t.Errorf("code %s, problems %+v; want NOT_FOUND for parent", code, problems)
+	}
+	if err.Error() != "parent issue DKT-99 not found" {
+		t.Errorf("error = %q", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/ALT-F4-LLC/docket/internal/config"
 	"github.com/ALT-F4-LLC/docket/internal/db"
@@ -16,22 +17,48 @@ import (
 )
 
 type deleteResult struct {
-	ID string `json:"id"`
+	ID      string   `json:"id"`
+	Trashed []string `json:"trashed"`
+}
+
+// bulkDeleteResult is the JSON wire format for deleting several issues at
+// once.
+type bulkDeleteResult struct {
+	Deleted int      `json:"deleted"`
+	Missing []string `json:"missing"`
 }
 
 var deleteCmd = &cobra.Command{
-	Use:   "delete <id>",
-	Short: "Delete an issue",
-	Args:  cobra.ExactArgs(1),
+	Use:     "delete <id>...",
+	Aliases: []string{"rm"},
+	Short:   "Move issues to the trash",
+	Long: `Move one or more issues to the trash. Trashed issues are hidden everywhere
+but keep their comments, relations and history until the trash is emptied;
+use "docket trash restore <id>" to bring one back.
+
+Given several IDs, or a range such as DKT-1..DKT-4, all are trashed in a
+single transaction after confirmation (skip it with --force, which also
+trashes sub-issues). IDs that do not exist are reported rather than failing
+the batch.
+
+  docket issue rm DKT-1 DKT-2 DKT-3 --orphan
+  docket issue rm DKT-10..DKT-14 DKT-20 --force`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		w := getWriter(cmd)
 		conn := getDB(cmd)
 
+		if len(args) > 1 || strings.Contains(args[0], "..") {
+			return runBulkDelete(cmd, args, w)
+		}
+
 		force, _ := cmd.Flags().GetBool("force")
+		cascade, _ := cmd.Flags().GetBool("cascade")
 		orphan, _ := cmd.Flags().GetBool("orphan")
+		force = force || cascade
 
 		if force && orphan {
-			return cmdErr(fmt.Errorf("--force and --orphan are mutually exclusive"), output.ErrValidation)
+			return cmdErr(fmt.Errorf("--force/--cascade and --orphan are mutually exclusive"), output.ErrValidation)
 		}
 
 		id, err := model.ParseID(args[0])
@@ -42,7 +69,7 @@ var deleteCmd = &cobra.Command{
 		issue, err := db.GetIssue(conn, id)
 		if err != nil {
 			if errors.Is(err, db.ErrNotFound) {
-				return cmdErr(fmt.Errorf("issue %s not found", model.FormatID(id)), output.ErrNotFound)
+				return issueNotFoundErr(conn, w, id)
 			}
 			return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
 		}
@@ -54,10 +81,11 @@ var deleteCmd = &cobra.Command{
 
 		// No sub-issues: simple delete.
 		if len(subIssues) == 0 {
-			if err := db.DeleteIssue(conn, id); err != nil {
+			trashed, err := db.TrashIssue(conn, id, config.DefaultAuthor())
+			if err != nil {
 				return cmdErr(fmt.Errorf("deleting issue: %w", err), output.ErrGeneral)
 			}
-			w.Success(deleteResult{ID: model.FormatID(id)}, fmt.Sprintf("Deleted %s: %s", model.FormatID(id), issue.Title))
+			w.Success(newDeleteResult(id, trashed), fmt.Sprintf("Moved %s to trash: %s", model.FormatID(id), issue.Title))
 			return nil
 		}
 
@@ -72,12 +100,12 @@ var deleteCmd = &cobra.Command{
 
 		// JSON mode requires explicit flag when sub-issues exist.
 		if w.JSONMode {
-			return cmdErr(fmt.Errorf("issue %s has %d sub-issue(s): use --force to cascade-delete or --orphan to make them root issues", model.FormatID(id), len(subIssues)), output.ErrValidation)
+			return cmdErr(fmt.Errorf("issue %s has %d sub-issue(s): use --cascade to trash them too or --orphan to make them root issues", model.FormatID(id), len(subIssues)), output.ErrValidation)
 		}
 
 		// Interactive prompt.
 		if !term.IsTerminal(int(os.Stdin.Fd())) {
-			return cmdErr(fmt.Errorf("non-interactive environment detected; issue %s has %d sub-issue(s): use --force to cascade-delete or --orphan to make them root issues", model.FormatID(id), len(subIssues)), output.ErrValidation)
+			return cmdErr(fmt.Errorf("non-interactive environment detected; issue %s has %d sub-issue(s): use --cascade to trash them too or --orphan to make them root issues", model.FormatID(id), len(subIssues)), output.ErrValidation)
 		}
 		var choice string
 		form := huh.NewForm(
@@ -85,7 +113,7 @@ var deleteCmd = &cobra.Command{
 				huh.NewSelect[string]().
 					Title(fmt.Sprintf("Issue %s has %d sub-issue(s). How do you want to proceed?", model.FormatID(id), len(subIssues))).
 					Options(
-						huh.NewOption("Delete issue and all sub-issues", "cascade"),
+						huh.NewOption("Trash issue and all sub-issues", "cascade"),
 						huh.NewOption("Make sub-issues root issues", "orphan"),
 						huh.NewOption("Cancel", "cancel"),
 					).
@@ -114,11 +142,126 @@ var deleteCmd = &cobra.Command{
 	},
 }
 
+// runBulkDelete trashes every issue named in args, where IDs and ranges
+// such as DKT-1..DKT-4 combine with repeats dropped. Unless --force,
+// --cascade or --orphan settles it, human mode asks for confirmation and, if
+// any of the issues has sub-issues, whether to trash or orphan them.
+func runBulkDelete(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	force, _ := cmd.Flags().GetBool("force")
+	cascade, _ := cmd.Flags().GetBool("cascade")
+	orphan, _ := cmd.Flags().GetBool("orphan")
+	cascade = cascade || force
+	if cascade && orphan {
+		return cmdErr(fmt.Errorf("--force/--cascade and --orphan are mutually exclusive"), output.ErrValidation)
+	}
+
+	ids, err := model.ParseIDList(args)
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+
+	withChildren := 0
+	for _, id := range ids {
+		subIssues, err := db.GetSubIssues(conn, id)
+		if err != nil {
+			return cmdErr(fmt.Errorf("checking sub-issues: %w", err), output.ErrGeneral)
+		}
+		if len(subIssues) > 0 {
+			withChildren++
+		}
+	}
+
+	if !cascade && !orphan {
+		if w.JSONMode {
+			if withChildren > 0 {
+				return cmdErr(fmt.Errorf("%d of the issues have sub-issues: use --cascade to trash them too or --orphan to make them root issues", withChildren), output.ErrValidation)
+			}
+		} else {
+			if !term.IsTerminal(int(os.Stdin.Fd())) {
+				return cmdErr(fmt.Errorf("non-interactive environment detected; pass --force (or --orphan) to trash %d issues or use --json", len(ids)), output.ErrValidation)
+			}
+			choice, err := confirmBulkDelete(len(ids), withChildren)
+			if err != nil {
+				return err
+			}
+			switch choice {
+			case "cascade":
+				cascade = true
+			case "orphan":
+			default:
+				w.Info("Cancelled.")
+				return nil
+			}
+		}
+	}
+
+	deleted, missing, err := db.DeleteIssues(conn, ids, cascade, config.DefaultAuthor())
+	if err != nil {
+		return cmdErr(fmt.Errorf("deleting issues: %w", err), output.ErrGeneral)
+	}
+
+	result := bulkDeleteResult{Deleted: deleted, Missing: make([]string, len(missing))}
+	for i, id := range missing {
+		result.Missing[i] = model.FormatID(id)
+	}
+	message := fmt.Sprintf("Moved %d issue(s) to trash", deleted)
+	if len(missing) > 0 {
+		message += fmt.Sprintf("; not found: %s", strings.Join(result.Missing, ", "))
+	}
+	w.Success(result, message)
+	return nil
+}
+
+// confirmBulkDelete asks whether to trash n issues, returning "cascade",
+// "orphan" or "cancel". When withChildren of them have sub-issues it also
+// asks what to do with those.
+func confirmBulkDelete(n, withChildren int) (string, error) {
+	choice := "cancel"
+	var confirmed bool
+	var field huh.Field = huh.NewConfirm().
+		Title(fmt.Sprintf("Trash %d issues?", n)).
+		Value(&confirmed)
+	if withChildren > 0 {
+		field = huh.NewSelect[string]().
+			Title(fmt.Sprintf("Trash %d issues? %d of them have sub-issues.", n, withChildren)).
+			Options(
+				huh.NewOption("Trash issues and all sub-issues", "cascade"),
+				huh.NewOption("Trash issues and make sub-issues root issues", "orphan"),
+				huh.NewOption("Cancel", "cancel"),
+			).
+			Value(&choice)
+	}
+	if err := huh.NewForm(huh.NewGroup(field)).Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			return "cancel", nil
+		}
+		return "", cmdErr(fmt.Errorf("interactive form failed: %w", err), output.ErrGeneral)
+	}
+	if confirmed {
+		// With no sub-issues involved, orphaning trashes just the issues.
+		return "orphan", nil
+	}
+	return choice, nil
+}
+
+// newDeleteResult builds the result for an issue moved to the trash along
+// with the IDs of every issue trashed with it.
+func newDeleteResult(id int, trashed []int) deleteResult {
+	ids := make([]string, 0, len(trashed))
+	for _, t := range trashed {
+		ids = append(ids, model.FormatID(t))
+	}
+	return deleteResult{ID: model.FormatID(id), Trashed: ids}
+}
+
 func doCascadeDelete(w *output.Writer, conn *sql.DB, id int, title string, subCount int) error {
-	if err := db.CascadeDeleteIssue(conn, id); err != nil {
-		return cmdErr(fmt.Errorf("cascade deleting issue: %w", err), output.ErrGeneral)
+	trashed, err := db.TrashIssue(conn, id, config.DefaultAuthor())
+	if err != nil {
+		return cmdErr(fmt.Errorf("trashing issue: %w", err), output.ErrGeneral)
 	}
-	w.Success(deleteResult{ID: model.FormatID(id)}, fmt.Sprintf("Deleted %s: %s (and %d sub-issue(s))", model.FormatID(id), title, subCount))
+	w.Success(newDeleteResult(id, trashed), fmt.Sprintf("Moved %s to trash: %s (and %d sub-issue(s))", model.FormatID(id), title, subCount))
 	return nil
 }
 
@@ -126,15 +269,17 @@ func doOrphanDelete(w *output.Writer, conn *sql.DB, id int, title string, subCou
 	if err := db.OrphanSubIssues(conn, id, config.DefaultAuthor()); err != nil {
 		return cmdErr(fmt.Errorf("orphaning sub-issues: %w", err), output.ErrGeneral)
 	}
-	if err := db.DeleteIssue(conn, id); err != nil {
+	trashed, err := db.TrashIssue(conn, id, config.DefaultAuthor())
+	if err != nil {
 		return cmdErr(fmt.Errorf("deleting issue: %w", err), output.ErrGeneral)
 	}
-	w.Success(deleteResult{ID: model.FormatID(id)}, fmt.Sprintf("Deleted %s: %s (orphaned %d sub-issue(s))", model.FormatID(id), title, subCount))
+	w.Success(newDeleteResult(id, trashed), fmt.Sprintf("Moved %s to trash: %s (orphaned %d sub-issue(s))", model.FormatID(id), title, subCount))
 	return nil
 }
 
 func init() {
-	deleteCmd.Flags().BoolP("force", "f", false, "Skip confirmation and cascade-delete all sub-issues")
+	deleteCmd.Flags().BoolP("force", "f", false, "Skip confirmation and trash all sub-issues (same as --cascade)")
+	deleteCmd.Flags().Bool("cascade", false, "Move all sub-issues to the trash along with the issue")
 	deleteCmd.Flags().Bool("orphan", false, "Remove parent reference from sub-issues (make them root issues)")
 	issueCmd.AddCommand(deleteCmd)
 }
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func deleteCmdWithDB(conn *sql.DB, flags ...string) *cobra.Command {
+	cmd := cmdWithDB(conn)
+	cmd.Flags().Bool("force", false, "")
+	cmd.Flags().Bool("cascade", false, "")
+	cmd.Flags().Bool("orphan", false, "")
+	for _, f := range flags {
+		cmd.Flags().Set(f, "true")
+	}
+	return cmd
+}
+
+func TestBulkDeleteJSON(t *testing.T) {
+	conn := newTestDB(t)
+	parent := createIssue(t, conn, "parent", model.StatusTodo, model.PriorityLow)
+	child, err := db.CreateIssue(conn, &model.Issue{Title: "child", ParentID: &parent, Status: model.StatusTodo, Priority: model.PriorityNone, Kind: model.IssueKindTask}, nil, nil)
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	other := createIssue(t, conn, "other", model.StatusTodo, model.PriorityLow)
+	args := []string{model.FormatID(parent), model.FormatID(other), "DKT-99"}
+
+	// A parent in the batch needs an explicit choice in JSON mode.
+	w, _ := bufWriter(true)
+	err = runBulkDelete(deleteCmdWithDB(conn), args, w)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Fatalf("error = %v, want a validation error", err)
+	}
+
+	w, buf := bufWriter(true)
+	if err := runBulkDelete(deleteCmdWithDB(conn, "orphan"), args, w); err != nil {
+		t.Fatalf("runBulkDelete: %v", err)
+	}
+	var env struct {
+		Data bulkDeleteResult `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if env.Data.Deleted != 2 || !slices.Equal(env.Data.Missing, []string{"DKT-99"}) {
+		t.Errorf("result = %+v, want 2 deleted and DKT-99 missing", env.Data)
+	}
+	if c, err := db.GetIssue(conn, child); err != nil || c.ParentID != nil {
+		t.Errorf("child = %+v, %v; want a live root issue", c, err)
+	}
+}
+
+func TestBulkDeleteRange(t *testing.T) {
+	conn := newTestDB(t)
+	var ids []int
+	for _, title := range []string{"a", "b", "c", "d"} {
+		ids = append(ids, createIssue(t, conn, title, model.StatusTodo, model.PriorityLow))
+	}
+	args := []string{model.FormatID(ids[0]) + ".." + model.FormatID(ids[2]), model.FormatID(ids[1])}
+
+	w, buf := bufWriter(true)
+	if err := runBulkDelete(deleteCmdWithDB(conn), args, w); err != nil {
+		t.Fatalf("runBulkDelete: %v", err)
+	}
+	var env struct {
+		Data bulkDeleteResult `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if env.Data.Deleted != 3 || len(env.Data.Missing) != 0 {
+		t.Errorf("result = %+v, want 3 deleted", env.Data)
+	}
+	if _, err := db.GetIssue(conn, ids[3]); err != nil {
+		t.Errorf("issue outside the range should survive: %v", err)
+	}
+
+	w, _ = bufWriter(true)
+	err := runBulkDelete(deleteCmdWithDB(conn), []string{"DKT-4..DKT-1"}, w)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Errorf("inverted range error = %v, want a validation error", err)
+	}
+}
@@ -3,7 +3,6 @@ package cli
 import (
 	"errors"
 	"fmt"
-	"io"
 	"os"
 	"strings"
 
@@ -15,22 +14,29 @@ import (
 )
 
 var editCmd = &cobra.Command{
-	Use:   "edit [id]",
-	Short: "Edit an existing issue",
-	Args:  cobra.ExactArgs(1),
+	Use:     "edit [id]",
+	Short:   "Edit an existing issue (pick one interactively when no ID is given)",
+	Aliases: []string{"update"},
+	Args:    cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		w := getWriter(cmd)
 		conn := getDB(cmd)
 
+		args, ok, err := issueArgs(conn, w, args)
+		if err != nil || !ok {
+			return err
+		}
+
 		id, err := model.ParseID(args[0])
 		if err != nil {
-			return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+			return cmdErr(err, output.ErrValidation)
 		}
 
 		// Verify issue exists.
-		if _, err := db.GetIssue(conn, id); err != nil {
+		before, err := db.GetIssue(conn, id)
+		if err != nil {
 			if errors.Is(err, db.ErrNotFound) {
-				return cmdErr(fmt.Errorf("issue %s not found", args[0]), output.ErrNotFound)
+				return issueNotFoundErr(conn, w, id)
 			}
 			return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
 		}
@@ -38,59 +44,48 @@ var editCmd = &cobra.Command{
 		updates := make(map[string]interface{})
 		filesChanged := false
 
+		// Check every value before changing anything, so all problems show
+		// at once.
+		var problems fieldErrors
+
 		if cmd.Flags().Changed("title") {
 			title, _ := cmd.Flags().GetString("title")
+			problems.check("title", model.ValidateTitle(title))
 			updates["title"] = title
 		}
 
-		if cmd.Flags().Changed("description") {
-			description, _ := cmd.Flags().GetString("description")
-			if description == "-" {
-				const maxStdinSize = 1 << 20 // 1 MiB
-				data, err := io.ReadAll(io.LimitReader(os.Stdin, maxStdinSize))
-				if err != nil {
-					return cmdErr(fmt.Errorf("reading description from stdin: %w", err), output.ErrGeneral)
-				}
-				description = strings.TrimRight(string(data), "\n")
-			}
+		description, ok, err := descriptionFromFlags(cmd, os.Stdin, before.Description)
+		if err != nil {
+			return err
+		}
+		if ok {
 			updates["description"] = description
 		}
 
 		if cmd.Flags().Changed("status") {
 			status, _ := cmd.Flags().GetString("status")
-			if err := model.ValidateStatus(model.Status(status)); err != nil {
-				return cmdErr(err, output.ErrValidation)
-			}
+			problems.check("status", model.ValidateStatus(model.Status(status)))
 			updates["status"] = status
 		}
 
 		if cmd.Flags().Changed("priority") {
 			priority, _ := cmd.Flags().GetString("priority")
-			if err := model.ValidatePriority(model.Priority(priority)); err != nil {
-				return cmdErr(err, output.ErrValidation)
-			}
+			problems.check("priority", model.ValidatePriority(model.Priority(priority)))
 			updates["priority"] = priority
 		}
 
 		if cmd.Flags().Changed("type") {
 			kind, _ := cmd.Flags().GetString("type")
-			if err := model.ValidateIssueKind(model.IssueKind(kind)); err != nil {
-				return cmdErr(err, output.ErrValidation)
-			}
+			problems.check("type", model.ValidateIssueKind(model.IssueKind(kind)))
 			updates["kind"] = kind
 		}
 
-		if cmd.Flags().Changed("assignee") {
-			assignee, _ := cmd.Flags().GetString("assignee")
-			updates["assignee"] = assignee
+		assignee, ok, err := assigneeFromFlags(cmd, conn)
+		if err := problems.check("assignee", err); err != nil {
+			return err
 		}
-
-		if cmd.Flags().Changed("file") {
-			fileFlag, _ := cmd.Flags().GetStringSlice("file")
-			if err := db.SetIssueFiles(conn, id, fileFlag, config.DefaultAuthor()); err != nil {
-				return cmdErr(fmt.Errorf("setting files: %w", err), output.ErrGeneral)
-			}
-			filesChanged = true
+		if ok {
+			updates["assignee"] = assignee
 		}
 
 		if cmd.Flags().Changed("parent") {
@@ -98,30 +93,44 @@ var editCmd = &cobra.Command{
 			if strings.EqualFold(parent, "0") || strings.EqualFold(parent, "none") {
 				updates["parent_id"] = nil
 			} else {
-				newParentID, err := model.ParseID(parent)
-				if err != nil {
-					return cmdErr(fmt.Errorf("invalid parent ID: %w", err), output.ErrValidation)
+				newParentID, err := parentFromFlag(conn, parent, id)
+				if err := problems.check("parent", err); err != nil {
+					return err
 				}
-				if newParentID == id {
-					return cmdErr(fmt.Errorf("cannot set parent to self"), output.ErrValidation)
+				updates["parent_id"] = newParentID
+			}
+		}
+
+		if cmd.Flags().Changed("milestone") {
+			name, _ := cmd.Flags().GetString("milestone")
+			if name == "" || strings.EqualFold(name, "none") {
+				updates["milestone_id"] = nil
+			} else {
+				m, err := resolveMilestone(conn, name)
+				if err := problems.check("milestone", err); err != nil {
+					return err
 				}
-				if _, err := db.GetIssue(conn, newParentID); err != nil {
-					if errors.Is(err, db.ErrNotFound) {
-						return cmdErr(fmt.Errorf("parent issue %s not found", parent), output.ErrNotFound)
+				if m != nil {
+					if m.Closed {
+						problems.check("milestone", fmt.Errorf("milestone %q is closed", m.Name))
 					}
-					return cmdErr(fmt.Errorf("checking parent issue: %w", err), output.ErrGeneral)
+					updates["milestone_id"] = m.ID
 				}
-				isCycle, err := db.IsDescendant(conn, id, newParentID)
-				if err != nil {
-					return cmdErr(fmt.Errorf("checking for cycles: %w", err), output.ErrGeneral)
-				}
-				if isCycle {
-					return cmdErr(fmt.Errorf("cannot reparent: would create a cycle"), output.ErrConflict)
-				}
-				updates["parent_id"] = newParentID
 			}
 		}
 
+		if err := problems.err(); err != nil {
+			return err
+		}
+
+		if cmd.Flags().Changed("file") {
+			fileFlag, _ := cmd.Flags().GetStringSlice("file")
+			if err := db.SetIssueFiles(conn, id, fileFlag, config.DefaultAuthor()); err != nil {
+				return cmdErr(fmt.Errorf("setting files: %w", err), output.ErrGeneral)
+			}
+			filesChanged = true
+		}
+
 		if len(updates) == 0 && !filesChanged {
 			if w.JSONMode {
 				issue, err := db.GetIssue(conn, id)
@@ -138,7 +147,10 @@ var editCmd = &cobra.Command{
 		if len(updates) > 0 {
 			if err := db.UpdateIssue(conn, id, updates, config.DefaultAuthor()); err != nil {
 				if errors.Is(err, db.ErrNotFound) {
-					return cmdErr(fmt.Errorf("issue %s not found", args[0]), output.ErrNotFound)
+					return issueNotFoundErr(conn, w, id)
+				}
+				if errors.Is(err, db.ErrValidation) {
+					return cmdErr(err, output.ErrValidation)
 				}
 				return cmdErr(fmt.Errorf("updating issue: %w", err), output.ErrGeneral)
 			}
@@ -148,8 +160,15 @@ var editCmd = &cobra.Command{
 		if err != nil {
 			return cmdErr(fmt.Errorf("fetching updated issue: %w", err), output.ErrGeneral)
 		}
+		if err := db.HydrateMilestones(conn, []*model.Issue{issue}); err != nil {
+			return cmdErr(fmt.Errorf("fetching milestone: %w", err), output.ErrGeneral)
+		}
 
-		w.Success(issue, fmt.Sprintf("Updated %s: %s", model.FormatID(id), issue.Title))
+		message := fmt.Sprintf("Updated %s: %s", model.FormatID(id), issue.Title)
+		if issue.Status == model.StatusDone && before.Status != model.StatusDone {
+			return writeDoneResult(conn, w, issue, message)
+		}
+		w.Success(issue, message)
 
 		return nil
 	},
@@ -158,11 +177,14 @@ var editCmd = &cobra.Command{
 func init() {
 	editCmd.Flags().StringP("title", "t", "", "Issue title")
 	editCmd.Flags().StringP("description", "d", "", "Issue description (use \"-\" for stdin)")
+	addDescriptionSourceFlags(editCmd)
 	editCmd.Flags().StringP("status", "s", "", "Issue status")
 	editCmd.Flags().StringP("priority", "p", "", "Issue priority")
 	editCmd.Flags().StringP("type", "T", "", "Issue type")
-	editCmd.Flags().StringP("assignee", "a", "", "Issue assignee")
+	editCmd.Flags().StringP("assignee", "a", "", "Issue assignee (\"me\" for the configured current user)")
+	addMineFlag(editCmd, "Assign the issue to the configured current user")
 	editCmd.Flags().StringSliceP("file", "f", nil, "File paths (repeatable, replaces existing)")
 	editCmd.Flags().String("parent", "", "Parent issue ID (use \"0\" or \"none\" to make root)")
+	editCmd.Flags().String("milestone", "", "Milestone name (use \"none\" to clear)")
 	issueCmd.AddCommand(editCmd)
 }
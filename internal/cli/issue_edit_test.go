@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"database/sql"
+	"slices"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func editCmdWithDB(conn *sql.DB, flags map[string]string) *cobra.Command {
+	cmd := cmdWithDB(conn)
+	cmd.Flags().StringP("title", "t", "", "")
+	cmd.Flags().StringP("description", "d", "", "")
+	addDescriptionSourceFlags(cmd)
+	cmd.Flags().StringP("status", "s", "", "")
+	cmd.Flags().StringP("priority", "p", "", "")
+	cmd.Flags().StringP("type", "T", "", "")
+	cmd.Flags().StringP("assignee", "a", "", "")
+	cmd.Flags().Bool("mine", false, "")
+	cmd.Flags().StringSliceP("file", "f", nil, "")
+	cmd.Flags().String("parent", "", "")
+	cmd.Flags().String("milestone", "", "")
+	cmd.Flags().Set("json", "true")
+	for name, value := range flags {
+		cmd.Flags().Set(name, value)
+	}
+	return cmd
+}
+
+func TestIssueEditReportsEveryInvalidValueAndChangesNothing(t *testing.T) {
+	conn := newTestDB(t)
+	id := createIssue(t, conn, "Edit me", model.StatusTodo, model.PriorityLow)
+	if err := db.SetIssueFiles(conn, id, []string{"a.go"}, "tester"); err != nil {
+		t.Fatalf("SetIssueFiles: %v", err)
+	}
+
+	cmd := editCmdWithDB(conn, map[string]string{
+		"status":   "wip",
+		"type":     "nope",
+		"parent":   model.FormatID(id),
+		"priority": "high",
+		"file":     "b.go",
+	})
+	err := editCmd.RunE(cmd, []string{model.FormatID(id)})
+	code, problems := fieldErrorsOf(t, err)
+	if code != output.ErrValidation {
+		t.Errorf("code = %s, want %s", code, output.ErrValidation)
+	}
+	var fields []string
+	for _, p := range problems {
+		fields = append(fields, p.Field)
+	}
+	if want := []string{"status", "type", "parent"}; !slices.Equal(fields, want) {
+		t.Errorf("fields = %v, want %v", fields, want)
+	}
+
+	issue, err := db.GetIssue(conn, id)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if issue.Priority != model.PriorityLow {
+		t.Errorf("priority = %s, want it unchanged", issue.Priority)
+	}
+	files, err := db.GetIssueFiles(conn, id)
+	if err != nil {
+		t.Fatalf("GetIssueFiles: %v", err)
+	}
+	if !slices.Equal(files, []string{"a.go"}) {
+		t.Errorf("files = %v, want them unchanged", files)
+	}
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+// existsResult is the JSON wire format for the exists command output.
+type existsResult struct {
+	ID     string `json:"id"`
+	Exists bool   `json:"exists"`
+	Title  string `json:"title"`
+}
+
+var existsCmd = &cobra.Command{
+	Use:   "exists <id>",
+	Short: "Check whether an issue exists, for scripts",
+	Long: `Exit with status 0 if the issue exists and is not in the trash, or with the
+not-found status (2) if it does not, printing nothing either way:
+
+  if docket issue exists DKT-77; then ...; fi
+
+-v prints the issue's title when it exists and the usual error when it does
+not. With --json the result is reported in the usual envelope.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runIssueExists(cmd, args, getWriter(cmd))
+	},
+}
+
+func runIssueExists(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+	verbose, _ := cmd.Flags().GetBool("verbose")
+
+	id, err := model.ParseID(args[0])
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+
+	issue, err := db.GetIssue(conn, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			ce := issueNotFoundErr(conn, w, id)
+			ce.Silent = !verbose
+			return ce
+		}
+		return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
+	}
+
+	if w.JSONMode {
+		w.Success(existsResult{ID: model.FormatID(id), Exists: true, Title: issue.Title}, "")
+		return nil
+	}
+	if verbose {
+		// Written directly rather than through Success so the title is
+		// printed as is, without styling.
+		fmt.Fprintln(w.Stdout, issue.Title)
+	}
+	return nil
+}
+
+func init() {
+	existsCmd.Flags().BoolP("verbose", "v", false, "Print the issue's title, or why it was not found")
+	issueCmd.AddCommand(existsCmd)
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func existsCmdWithDB(conn *sql.DB, verbose bool) *cobra.Command {
+	cmd := cmdWithDB(conn)
+	cmd.Flags().Bool("verbose", false, "")
+	if verbose {
+		cmd.Flags().Set("verbose", "true")
+	}
+	return cmd
+}
+
+func TestIssueExists(t *testing.T) {
+	conn := newTestDB(t)
+	id := createIssue(t, conn, "Fix *login* timeout", model.StatusTodo, model.PriorityHigh)
+
+	tests := []struct {
+		name       string
+		id         string
+		verbose    bool
+		wantStdout string
+		wantExit   int
+		wantSilent bool
+	}{
+		{"exists", model.FormatID(id), false, "", output.ExitSuccess, false},
+		{"exists verbose", model.FormatID(id), true, "Fix *login* timeout\n", output.ExitSuccess, false},
+		{"missing", "DKT-77", false, "", output.ExitNotFound, true},
+		{"missing verbose", "DKT-77", true, "", output.ExitNotFound, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, buf := bufWriter(false)
+			err := runIssueExists(existsCmdWithDB(conn, tt.verbose), []string{tt.id}, w)
+			exit := output.ExitSuccess
+			var ce *CmdError
+			if errors.As(err, &ce) {
+				exit = output.ExitCodeForError(ce.Code)
+				if ce.Silent != tt.wantSilent {
+					t.Errorf("silent = %v, want %v", ce.Silent, tt.wantSilent)
+				}
+			} else if err != nil {
+				t.Fatalf("runIssueExists: %v", err)
+			}
+			if exit != tt.wantExit {
+				t.Errorf("exit code = %d, want %d", exit, tt.wantExit)
+			}
+			if got := buf.String(); got != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", got, tt.wantStdout)
+			}
+		})
+	}
+}
+
+func TestIssueExistsJSON(t *testing.T) {
+	conn := newTestDB(t)
+	id := createIssue(t, conn, "Present", model.StatusTodo, model.PriorityLow)
+
+	w, buf := bufWriter(true)
+	if err := runIssueExists(existsCmdWithDB(conn, false), []string{model.FormatID(id)}, w); err != nil {
+		t.Fatalf("runIssueExists: %v", err)
+	}
+	var env struct {
+		Data existsResult `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if want := (existsResult{ID: model.FormatID(id), Exists: true, Title: "Present"}); env.Data != want {
+		t.Errorf("result = %+v, want %+v", env.Data, want)
+	}
+}
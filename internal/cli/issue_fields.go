@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+// fieldError is one invalid value given to issue create or edit. Field is
+// the name of the flag the value came from.
+type fieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+
+	code output.ErrorCode
+}
+
+// fieldErrorDetails is the data attached to the JSON error envelope when
+// issue create or edit is given invalid values.
+type fieldErrorDetails struct {
+	Errors []fieldError `json:"errors"`
+}
+
+// fieldErrors collects every invalid value given to issue create or edit, so
+// they are all reported together instead of one per attempt.
+type fieldErrors []fieldError
+
+// check records err, if any, as a problem with field. A *CmdError keeps its
+// code; any other error is a validation error. A *CmdError with the general
+// code is not recorded but returned, since a failed query is not something
+// the caller can fix by changing a flag.
+func (e *fieldErrors) check(field string, err error) error {
+	if err == nil {
+		return nil
+	}
+	code := output.ErrValidation
+	var ce *CmdError
+	if errors.As(err, &ce) {
+		if ce.Code == output.ErrGeneral {
+			return err
+		}
+		code = ce.Code
+	}
+	*e = append(*e, fieldError{Field: field, Message: err.Error(), code: code})
+	return nil
+}
+
+// err returns nil when nothing was recorded. Otherwise it returns one error
+// listing every problem, with the problems attached to the JSON envelope as
+// "errors". A lone problem keeps its own code and message; several are a
+// validation error.
+func (e fieldErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	details := fieldErrorDetails{Errors: e}
+	if len(e) == 1 {
+		return &CmdError{Err: errors.New(e[0].Message), Code: e[0].code, Data: details}
+	}
+	msg := fmt.Sprintf("validation failed with %d error(s):", len(e))
+	for _, fe := range e {
+		msg += fmt.Sprintf("\n  - %s: %s", fe.Field, fe.Message)
+	}
+	return &CmdError{Err: errors.New(msg), Code: output.ErrValidation, Data: details}
+}
+
+// parentFromFlag parses a --parent value and checks that the parent exists
+// and, when editing issue self (0 when creating), that it is not self and
+// would not put self beneath one of its own sub-issues.
+func parentFromFlag(conn *sql.DB, parent string, self int) (int, error) {
+	pid, err := model.ParseID(parent)
+	if err != nil {
+		return 0, cmdErr(fmt.Errorf("invalid parent ID: %w", err), output.ErrValidation)
+	}
+	if pid == self {
+		return 0, cmdErr(fmt.Errorf("cannot set parent to self"), output.ErrValidation)
+	}
+	if _, err := db.GetIssue(conn, pid); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return 0, cmdErr(fmt.Errorf("parent issue %s not found", parent), output.ErrNotFound)
+		}
+		return 0, cmdErr(fmt.Errorf("checking parent issue: %w", err), output.ErrGeneral)
+	}
+	if self == 0 {
+		return pid, nil
+	}
+	isCycle, err := db.IsDescendant(conn, self, pid)
+	if err != nil {
+		return 0, cmdErr(fmt.Errorf("checking for cycles: %w", err), output.ErrGeneral)
+	}
+	if isCycle {
+		return 0, cmdErr(fmt.Errorf("cannot reparent: would create a cycle"), output.ErrConflict)
+	}
+	return pid, nil
+}
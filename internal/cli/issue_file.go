@@ -3,6 +3,8 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -16,32 +18,43 @@ import (
 )
 
 var fileCmd = &cobra.Command{
-	Use:   "file",
-	Short: "Manage issue file attachments",
+	Use:     "file",
+	Aliases: []string{"files"},
+	Short:   "Manage issue file attachments",
 }
 
 var fileAddCmd = &cobra.Command{
-	Use:   "add <id> <file-path>...",
-	Short: "Add files to an issue",
-	Args:  cobra.MinimumNArgs(2),
+	Use:     "add <id> <file-path-or-glob>...",
+	Aliases: []string{"attach"},
+	Short:   "Add files to an issue",
+	Long: `Add files to an issue.
+
+Arguments containing glob characters (*, ? or [) are expanded against the
+working tree when attaching, so 'internal/render/*.go' attaches each matching
+file. A pattern that matches no files is an error. Quote patterns to keep the
+shell from expanding them.`,
+	Args: cobra.MinimumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		w := getWriter(cmd)
 		conn := getDB(cmd)
 
 		id, err := model.ParseID(args[0])
 		if err != nil {
-			return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+			return cmdErr(err, output.ErrValidation)
 		}
 
 		issue, err := db.GetIssue(conn, id)
 		if err != nil {
 			if errors.Is(err, db.ErrNotFound) {
-				return cmdErr(fmt.Errorf("issue %s not found", args[0]), output.ErrNotFound)
+				return issueNotFoundErr(conn, w, id)
 			}
 			return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
 		}
 
-		filePaths := args[1:]
+		filePaths, err := expandFileGlobs(args[1:])
+		if err != nil {
+			return cmdErr(err, output.ErrValidation)
+		}
 		if err := db.AttachFiles(conn, id, filePaths, config.DefaultAuthor()); err != nil {
 			return cmdErr(fmt.Errorf("attaching files: %w", err), output.ErrGeneral)
 		}
@@ -66,13 +79,13 @@ var fileRemoveCmd = &cobra.Command{
 
 		id, err := model.ParseID(args[0])
 		if err != nil {
-			return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+			return cmdErr(err, output.ErrValidation)
 		}
 
 		issue, err := db.GetIssue(conn, id)
 		if err != nil {
 			if errors.Is(err, db.ErrNotFound) {
-				return cmdErr(fmt.Errorf("issue %s not found", args[0]), output.ErrNotFound)
+				return issueNotFoundErr(conn, w, id)
 			}
 			return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
 		}
@@ -102,12 +115,12 @@ var fileListCmd = &cobra.Command{
 
 		id, err := model.ParseID(args[0])
 		if err != nil {
-			return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+			return cmdErr(err, output.ErrValidation)
 		}
 
 		if _, err := db.GetIssue(conn, id); err != nil {
 			if errors.Is(err, db.ErrNotFound) {
-				return cmdErr(fmt.Errorf("issue %s not found", args[0]), output.ErrNotFound)
+				return issueNotFoundErr(conn, w, id)
 			}
 			return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
 		}
@@ -153,6 +166,38 @@ var fileListCmd = &cobra.Command{
 	},
 }
 
+// isFileGlob reports whether s contains glob metacharacters.
+func isFileGlob(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
+
+// expandFileGlobs replaces each glob pattern in args with the regular files
+// it matches in the working tree, using forward slashes. Other arguments are
+// kept as given.
+func expandFileGlobs(args []string) ([]string, error) {
+	var paths []string
+	for _, arg := range args {
+		if !isFileGlob(arg) {
+			paths = append(paths, arg)
+			continue
+		}
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid file pattern %q: %w", arg, err)
+		}
+		n := len(paths)
+		for _, m := range matches {
+			if info, err := os.Stat(m); err == nil && info.Mode().IsRegular() {
+				paths = append(paths, filepath.ToSlash(m))
+			}
+		}
+		if len(paths) == n {
+			return nil, fmt.Errorf("no files match %q", arg)
+		}
+	}
+	return paths, nil
+}
+
 func init() {
 	fileCmd.AddCommand(fileAddCmd)
 	fileCmd.AddCommand(fileRemoveCmd)
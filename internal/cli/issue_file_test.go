@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestExpandFileGlobs(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"render/a.go", "render/b.go", "render/notes.md", "render/sub/c.go"} {
+		path := filepath.Join(dir, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	t.Chdir(dir)
+
+	got, err := expandFileGlobs([]string{"main.go", "render/*.go"})
+	if err != nil {
+		t.Fatalf("expandFileGlobs: %v", err)
+	}
+	// Literal paths are kept as given; only regular files match a pattern.
+	if want := []string{"main.go", "render/a.go", "render/b.go"}; !slices.Equal(got, want) {
+		t.Errorf("expandFileGlobs = %q, want %q", got, want)
+	}
+
+	for _, pattern := range []string{"cmd/*.go", "render/su?"} {
+		if _, err := expandFileGlobs([]string{pattern}); err == nil {
+			t.Errorf("expandFileGlobs(%q): expected an error for a pattern with no file matches", pattern)
+		}
+	}
+	if _, err := expandFileGlobs([]string{"render/[a"}); err == nil {
+		t.Error("expected an error for a malformed pattern")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"slices"
 	"strings"
 	"syscall"
 
@@ -28,13 +29,21 @@ type graphNode struct {
 	Status string `json:"status"`
 }
 
-// graphEdge represents a dependency between two issues.
+// graphEdge represents a dependency between two issues. Edges always point
+// from blocker to blocked with Type "blocks"; RelationType and RelationID
+// name the relation the edge came from, which may be a depends_on entered
+// the other way round.
 type graphEdge struct {
-	From int    `json:"from"`
-	To   int    `json:"to"`
-	Type string `json:"type"`
+	From         int    `json:"from"`
+	To           int    `json:"to"`
+	Type         string `json:"type"`
+	RelationType string `json:"relation_type"`
+	RelationID   int    `json:"relation_id"`
 }
 
+// graphEdgeKey identifies a blocker-to-blocked edge.
+type graphEdgeKey struct{ from, to int }
+
 // graphResult is the JSON output structure for the graph command.
 type graphResult struct {
 	IssueID int         `json:"issue_id"`
@@ -74,13 +83,13 @@ func runIssueGraph(cmd *cobra.Command, args []string, w *output.Writer) error {
 
 	id, err := model.ParseID(args[0])
 	if err != nil {
-		return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+		return cmdErr(err, output.ErrValidation)
 	}
 
 	issue, err := db.GetIssue(conn, id)
 	if err != nil {
 		if errors.Is(err, db.ErrNotFound) {
-			return cmdErr(fmt.Errorf("issue %s not found", args[0]), output.ErrNotFound)
+			return issueNotFoundErr(conn, w, id)
 		}
 		return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
 	}
@@ -95,14 +104,25 @@ func runIssueGraph(cmd *cobra.Command, args []string, w *output.Writer) error {
 		return cmdErr(fmt.Errorf("depth must be non-negative"), output.ErrValidation)
 	}
 
+	relType, _ := cmd.Flags().GetString("type")
+	if relType != "all" && relType != string(model.RelationBlocks) && relType != string(model.RelationDependsOn) {
+		return cmdErr(fmt.Errorf("invalid type %q: must be one of [blocks, depends_on, all]", relType), output.ErrValidation)
+	}
+
 	// Fetch all directional relations for graph traversal.
 	allRelations, err := db.GetAllDirectionalRelations(conn)
 	if err != nil {
 		return cmdErr(fmt.Errorf("fetching relations: %w", err), output.ErrGeneral)
 	}
+	if relType != "all" {
+		allRelations = slices.DeleteFunc(allRelations, func(r model.Relation) bool {
+			return string(r.RelationType) != relType
+		})
+	}
 
 	// Build adjacency lists from relations.
 	forward, backward := planner.BuildAdjacency(allRelations)
+	provenance := graphProvenance(allRelations)
 
 	// BFS to collect reachable nodes.
 	visited := map[int]bool{id: true}
@@ -112,11 +132,11 @@ func runIssueGraph(cmd *cobra.Command, args []string, w *output.Writer) error {
 	maxDepth := depth
 
 	if direction == "down" || direction == "both" {
-		bfsGraph(id, forward, visited, &edges, "blocks", maxDepth)
+		bfsGraph(id, forward, provenance, visited, &edges, "blocks", maxDepth)
 	}
 
 	if direction == "up" || direction == "both" {
-		bfsGraph(id, backward, visited, &edges, "blocked_by", maxDepth)
+		bfsGraph(id, backward, provenance, visited, &edges, "blocked_by", maxDepth)
 	}
 
 	// Bulk-fetch issue details for all visited nodes.
@@ -158,19 +178,49 @@ func runIssueGraph(cmd *cobra.Command, args []string, w *output.Writer) error {
 		return nil
 	}
 
-	w.Success(result, renderGraphTree(id, issueMap, forward, backward, direction, maxDepth))
+	layout, err := getLayout(cmd)
+	if err != nil {
+		return err
+	}
+	w.Success(result, renderGraphTree(id, issueMap, forward, backward, provenance, direction, maxDepth, layout))
 	return nil
 }
 
+// graphProvenance maps each blocker-to-blocked edge to the relation it came
+// from. When both a blocks and a depends_on relation give the same edge, the
+// older one is kept.
+func graphProvenance(relations []model.Relation) map[graphEdgeKey]model.Relation {
+	provenance := make(map[graphEdgeKey]model.Relation, len(relations))
+	for _, rel := range relations {
+		from, to, ok := planner.BlockingEdge(rel)
+		if !ok {
+			continue
+		}
+		k := graphEdgeKey{from, to}
+		if _, dup := provenance[k]; !dup {
+			provenance[k] = rel
+		}
+	}
+	return provenance
+}
+
+// viaSuffix returns " (via depends_on)" for an edge entered as a depends_on
+// relation, and "" otherwise.
+func viaSuffix(provenance map[graphEdgeKey]model.Relation, from, to int) string {
+	if rel := provenance[graphEdgeKey{from, to}]; rel.RelationType == model.RelationDependsOn {
+		return " (via " + string(rel.RelationType) + ")"
+	}
+	return ""
+}
+
 // bfsGraph performs BFS from the start node, following the given adjacency list,
 // collecting edges and marking visited nodes. edgeType labels the edges.
-func bfsGraph(start int, adj map[int][]int, visited map[int]bool, edges *[]graphEdge, edgeType string, maxDepth int) {
+func bfsGraph(start int, adj map[int][]int, provenance map[graphEdgeKey]model.Relation, visited map[int]bool, edges *[]graphEdge, edgeType string, maxDepth int) {
 	type queueItem struct {
 		id    int
 		depth int
 	}
-	type edgeKey struct{ from, to int }
-	seen := make(map[edgeKey]bool)
+	seen := make(map[graphEdgeKey]bool)
 
 	queue := []queueItem{{id: start, depth: 0}}
 
@@ -190,7 +240,9 @@ func bfsGraph(start int, adj map[int][]int, visited map[int]bool, edges *[]graph
 				edge = graphEdge{From: neighbor, To: current.id, Type: "blocks"}
 			}
 
-			k := edgeKey{edge.From, edge.To}
+			k := graphEdgeKey{edge.From, edge.To}
+			rel := provenance[k]
+			edge.RelationType, edge.RelationID = string(rel.RelationType), rel.ID
 			if !seen[k] {
 				seen[k] = true
 				*edges = append(*edges, edge)
@@ -221,24 +273,92 @@ func renderMermaid(issueMap map[int]*model.Issue, edges []graphEdge) string {
 			toTitle = fmt.Sprintf("%s: %s", toID, iss.Title)
 		}
 
-		fmt.Fprintf(&sb, "    %s[\"%s\"] --> %s[\"%s\"]\n", fromID, fromTitle, toID, toTitle)
+		// Edges entered as depends_on are dashed.
+		arrow := "-->"
+		if e.RelationType == string(model.RelationDependsOn) {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&sb, "    %s[\"%s\"] %s %s[\"%s\"]\n", fromID, fromTitle, arrow, toID, toTitle)
 	}
 
+	writeMermaidStatusClasses(&sb, issueMap, edges)
+
 	return sb.String()
 }
 
+// mermaidStatuses lists the statuses that get a classDef, in workflow order.
+var mermaidStatuses = []model.Status{
+	model.StatusBacklog,
+	model.StatusTodo,
+	model.StatusInProgress,
+	model.StatusReview,
+	model.StatusDone,
+}
+
+// mermaidPalette maps the logical status color names to Mermaid
+// fill/stroke/text styles.
+var mermaidPalette = map[string]string{
+	"gray":    "fill:#e5e7eb,stroke:#6b7280,color:#111827",
+	"blue":    "fill:#dbeafe,stroke:#2563eb,color:#1e3a8a",
+	"yellow":  "fill:#fef9c3,stroke:#ca8a04,color:#713f12",
+	"magenta": "fill:#fae8ff,stroke:#c026d3,color:#701a75",
+	"green":   "fill:#dcfce7,stroke:#16a34a,color:#14532d",
+}
+
+// mermaidClassName converts a status into a valid Mermaid class name
+// (e.g. "in-progress" becomes "in_progress").
+func mermaidClassName(s model.Status) string {
+	return strings.ReplaceAll(string(s), "-", "_")
+}
+
+// writeMermaidStatusClasses appends classDef blocks for every status and
+// assigns each node that appears in edges to its issue's status class.
+// Nodes missing from issueMap are left unstyled.
+func writeMermaidStatusClasses(sb *strings.Builder, issueMap map[int]*model.Issue, edges []graphEdge) {
+	if len(edges) == 0 {
+		return
+	}
+
+	sb.WriteString("\n")
+	for _, s := range mermaidStatuses {
+		fmt.Fprintf(sb, "    classDef %s %s\n", mermaidClassName(s), mermaidPalette[s.Color()])
+	}
+
+	seen := make(map[int]bool)
+	byStatus := make(map[model.Status][]string)
+	for _, e := range edges {
+		for _, id := range []int{e.From, e.To} {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			if iss, ok := issueMap[id]; ok {
+				byStatus[iss.Status] = append(byStatus[iss.Status], model.FormatID(id))
+			}
+		}
+	}
+
+	for _, s := range mermaidStatuses {
+		if nodes := byStatus[s]; len(nodes) > 0 {
+			fmt.Fprintf(sb, "    class %s %s\n", strings.Join(nodes, ","), mermaidClassName(s))
+		}
+	}
+}
+
 // renderGraphTree renders the dependency graph as a human-readable tree.
-func renderGraphTree(focalID int, issueMap map[int]*model.Issue, forward, backward map[int][]int, direction string, maxDepth int) string {
+// Children reached through a depends_on relation are marked "(via
+// depends_on)".
+func renderGraphTree(focalID int, issueMap map[int]*model.Issue, forward, backward map[int][]int, provenance map[graphEdgeKey]model.Relation, direction string, maxDepth int, layout render.LayoutOptions) string {
 	focal := issueMap[focalID]
 	if focal == nil {
 		return ""
 	}
 
 	if !render.ColorsEnabled() {
-		return renderGraphTreePlain(focalID, issueMap, forward, backward, direction, maxDepth)
+		return renderGraphTreePlain(focalID, issueMap, forward, backward, provenance, direction, maxDepth, layout)
 	}
 
-	rootLabel := formatGraphNode(focal, true)
+	rootLabel := formatGraphNode(focal, true, layout)
 	t := tree.New().Root(rootLabel)
 
 	if direction == "up" || direction == "both" {
@@ -246,7 +366,7 @@ func renderGraphTree(focalID int, issueMap map[int]*model.Issue, forward, backwa
 			sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
 			upNode := tree.Root(sectionStyle.Render("Blocked by"))
 			visited := map[int]bool{focalID: true}
-			addGraphChildren(upNode, focalID, backward, issueMap, visited, 1, maxDepth)
+			addGraphChildren(upNode, focalID, backward, upstreamVia(provenance), issueMap, visited, 1, maxDepth, layout)
 			t.Child(upNode)
 		}
 	}
@@ -256,7 +376,7 @@ func renderGraphTree(focalID int, issueMap map[int]*model.Issue, forward, backwa
 			sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
 			downNode := tree.Root(sectionStyle.Render("Blocks"))
 			visited := map[int]bool{focalID: true}
-			addGraphChildren(downNode, focalID, forward, issueMap, visited, 1, maxDepth)
+			addGraphChildren(downNode, focalID, forward, downstreamVia(provenance), issueMap, visited, 1, maxDepth, layout)
 			t.Child(downNode)
 		}
 	}
@@ -264,32 +384,30 @@ func renderGraphTree(focalID int, issueMap map[int]*model.Issue, forward, backwa
 	return t.String()
 }
 
-// formatGraphNode formats an issue for tree display.
-func formatGraphNode(issue *model.Issue, isFocal bool) string {
-	if !render.ColorsEnabled() {
-		prefix := ""
-		if isFocal {
-			prefix = "* "
-		}
-		return fmt.Sprintf("%s%s [%s] %s", prefix, model.FormatID(issue.ID), string(issue.Status), issue.Title)
+// formatGraphNode formats an issue for tree display. Without colors the
+// focal issue is marked with "* ".
+func formatGraphNode(issue *model.Issue, isFocal bool, layout render.LayoutOptions) string {
+	line := layout.IssueOneLine(issue)
+	if isFocal && !render.ColorsEnabled() {
+		return "* " + line
 	}
+	return line
+}
 
-	idStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
-	statusStyle := lipgloss.NewStyle().Foreground(render.ColorFromName(issue.Status.Color()))
-	titleStyle := lipgloss.NewStyle()
-	if isFocal {
-		titleStyle = titleStyle.Bold(true)
-	}
+// upstreamVia returns the "(via ...)" suffix of a child in the "Blocked by"
+// section, where the child blocks its parent.
+func upstreamVia(provenance map[graphEdgeKey]model.Relation) func(parentID, childID int) string {
+	return func(parentID, childID int) string { return viaSuffix(provenance, childID, parentID) }
+}
 
-	return fmt.Sprintf("%s %s %s",
-		idStyle.Render(model.FormatID(issue.ID)),
-		statusStyle.Render("["+string(issue.Status)+"]"),
-		titleStyle.Render(issue.Title),
-	)
+// downstreamVia returns the "(via ...)" suffix of a child in the "Blocks"
+// section, where the parent blocks the child.
+func downstreamVia(provenance map[graphEdgeKey]model.Relation) func(parentID, childID int) string {
+	return func(parentID, childID int) string { return viaSuffix(provenance, parentID, childID) }
 }
 
 // addGraphChildren recursively adds child nodes for BFS tree rendering.
-func addGraphChildren(node *tree.Tree, parentID int, adj map[int][]int, issueMap map[int]*model.Issue, visited map[int]bool, currentDepth, maxDepth int) {
+func addGraphChildren(node *tree.Tree, parentID int, adj map[int][]int, via func(parentID, childID int) string, issueMap map[int]*model.Issue, visited map[int]bool, currentDepth, maxDepth int, layout render.LayoutOptions) {
 	if maxDepth > 0 && currentDepth > maxDepth {
 		return
 	}
@@ -305,27 +423,27 @@ func addGraphChildren(node *tree.Tree, parentID int, adj map[int][]int, issueMap
 			continue
 		}
 
-		childNode := tree.Root(formatGraphNode(iss, false))
-		addGraphChildren(childNode, childID, adj, issueMap, visited, currentDepth+1, maxDepth)
+		childNode := tree.Root(formatGraphNode(iss, false, layout) + via(parentID, childID))
+		addGraphChildren(childNode, childID, adj, via, issueMap, visited, currentDepth+1, maxDepth, layout)
 		node.Child(childNode)
 	}
 }
 
 // renderGraphTreePlain renders the graph tree without colors.
-func renderGraphTreePlain(focalID int, issueMap map[int]*model.Issue, forward, backward map[int][]int, direction string, maxDepth int) string {
+func renderGraphTreePlain(focalID int, issueMap map[int]*model.Issue, forward, backward map[int][]int, provenance map[graphEdgeKey]model.Relation, direction string, maxDepth int, layout render.LayoutOptions) string {
 	focal := issueMap[focalID]
 	if focal == nil {
 		return ""
 	}
 
 	var sb strings.Builder
-	fmt.Fprintf(&sb, "* %s [%s] %s\n", model.FormatID(focal.ID), string(focal.Status), focal.Title)
+	fmt.Fprintf(&sb, "%s\n", formatGraphNode(focal, true, layout))
 
 	if direction == "up" || direction == "both" {
 		if deps := backward[focalID]; len(deps) > 0 {
 			sb.WriteString("  Blocked by\n")
 			visited := map[int]bool{focalID: true}
-			renderPlainGraphChildren(&sb, focalID, backward, issueMap, visited, 2, 1, maxDepth)
+			renderPlainGraphChildren(&sb, focalID, backward, upstreamVia(provenance), issueMap, visited, 2, 1, maxDepth, layout)
 		}
 	}
 
@@ -333,7 +451,7 @@ func renderGraphTreePlain(focalID int, issueMap map[int]*model.Issue, forward, b
 		if deps := forward[focalID]; len(deps) > 0 {
 			sb.WriteString("  Blocks\n")
 			visited := map[int]bool{focalID: true}
-			renderPlainGraphChildren(&sb, focalID, forward, issueMap, visited, 2, 1, maxDepth)
+			renderPlainGraphChildren(&sb, focalID, forward, downstreamVia(provenance), issueMap, visited, 2, 1, maxDepth, layout)
 		}
 	}
 
@@ -341,7 +459,7 @@ func renderGraphTreePlain(focalID int, issueMap map[int]*model.Issue, forward, b
 }
 
 // renderPlainGraphChildren renders children in plain text with indentation.
-func renderPlainGraphChildren(sb *strings.Builder, parentID int, adj map[int][]int, issueMap map[int]*model.Issue, visited map[int]bool, indent, currentDepth, maxDepth int) {
+func renderPlainGraphChildren(sb *strings.Builder, parentID int, adj map[int][]int, via func(parentID, childID int) string, issueMap map[int]*model.Issue, visited map[int]bool, indent, currentDepth, maxDepth int, layout render.LayoutOptions) {
 	if maxDepth > 0 && currentDepth > maxDepth {
 		return
 	}
@@ -358,14 +476,15 @@ func renderPlainGraphChildren(sb *strings.Builder, parentID int, adj map[int][]i
 		}
 
 		prefix := strings.Repeat("  ", indent)
-		fmt.Fprintf(sb, "%s%s [%s] %s\n", prefix, model.FormatID(iss.ID), string(iss.Status), iss.Title)
-		renderPlainGraphChildren(sb, childID, adj, issueMap, visited, indent+1, currentDepth+1, maxDepth)
+		fmt.Fprintf(sb, "%s%s%s\n", prefix, formatGraphNode(iss, false, layout), via(parentID, childID))
+		renderPlainGraphChildren(sb, childID, adj, via, issueMap, visited, indent+1, currentDepth+1, maxDepth, layout)
 	}
 }
 
 func init() {
 	graphCmd.Flags().Int("depth", 0, "Maximum traversal depth (0 = unlimited)")
 	graphCmd.Flags().String("direction", "both", "Traversal direction: up, down, or both")
-	graphCmd.Flags().Bool("mermaid", false, "Output as Mermaid flowchart syntax")
+	graphCmd.Flags().Bool("mermaid", false, "Output as Mermaid flowchart syntax (depends_on edges are dashed)")
+	graphCmd.Flags().String("type", "all", "Relation types to follow: blocks, depends_on, or all")
 	issueCmd.AddCommand(graphCmd)
 }
@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/spf13/cobra"
+)
+
+func graphCmdWithDB(conn *sql.DB, relType string) *cobra.Command {
+	cmd := cmdWithDB(conn)
+	cmd.Flags().Int("depth", 0, "")
+	cmd.Flags().String("direction", "both", "")
+	cmd.Flags().Bool("mermaid", false, "")
+	cmd.Flags().String("type", relType, "")
+	cmd.Flags().Int("width", 0, "")
+	cmd.Flags().Bool("no-truncate", false, "")
+	return cmd
+}
+
+// seedMixedGraph creates DKT-1 blocks DKT-2 and DKT-3 depends_on DKT-2, so
+// DKT-2 sits between an upstream blocks edge and a downstream depends_on
+// edge. It returns the two relation IDs.
+func seedMixedGraph(t *testing.T, conn *sql.DB) (blocksID, dependsID int) {
+	t.Helper()
+	a := createIssue(t, conn, "Schema", model.StatusDone, model.PriorityHigh)
+	b := createIssue(t, conn, "API", model.StatusInProgress, model.PriorityHigh)
+	c := createIssue(t, conn, "UI", model.StatusTodo, model.PriorityHigh)
+	var err error
+	if blocksID, err = db.CreateRelation(conn, &model.Relation{SourceIssueID: a, TargetIssueID: b, RelationType: model.RelationBlocks}); err != nil {
+		t.Fatalf("CreateRelation(blocks): %v", err)
+	}
+	if dependsID, err = db.CreateRelation(conn, &model.Relation{SourceIssueID: c, TargetIssueID: b, RelationType: model.RelationDependsOn}); err != nil {
+		t.Fatalf("CreateRelation(depends_on): %v", err)
+	}
+	return blocksID, dependsID
+}
+
+func TestIssueGraphJSON_EdgeProvenance(t *testing.T) {
+	conn := newTestDB(t)
+	blocksID, dependsID := seedMixedGraph(t, conn)
+
+	tests := []struct {
+		relType string
+		want    []graphEdge
+	}{
+		{"all", []graphEdge{
+			{From: 2, To: 3, Type: "blocks", RelationType: "depends_on", RelationID: dependsID},
+			{From: 1, To: 2, Type: "blocks", RelationType: "blocks", RelationID: blocksID},
+		}},
+		{"blocks", []graphEdge{
+			{From: 1, To: 2, Type: "blocks", RelationType: "blocks", RelationID: blocksID},
+		}},
+		{"depends_on", []graphEdge{
+			{From: 2, To: 3, Type: "blocks", RelationType: "depends_on", RelationID: dependsID},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.relType, func(t *testing.T) {
+			w, buf := bufWriter(true)
+			if err := runIssueGraph(graphCmdWithDB(conn, tt.relType), []string{"DKT-2"}, w); err != nil {
+				t.Fatalf("runIssueGraph: %v", err)
+			}
+			var got struct {
+				Data graphResult `json:"data"`
+			}
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+			}
+			if len(got.Data.Edges) != len(tt.want) {
+				t.Fatalf("edges = %+v, want %+v", got.Data.Edges, tt.want)
+			}
+			for i, e := range got.Data.Edges {
+				if e != tt.want[i] {
+					t.Errorf("edge %d = %+v, want %+v", i, e, tt.want[i])
+				}
+			}
+		})
+	}
+
+	w, _ := bufWriter(true)
+	if err := runIssueGraph(graphCmdWithDB(conn, "relates_to"), []string{"DKT-2"}, w); err == nil {
+		t.Error("runIssueGraph --type relates_to succeeded, want a validation error")
+	}
+}
+
+func TestIssueGraphMermaid_DashesDependsOn(t *testing.T) {
+	conn := newTestDB(t)
+	seedMixedGraph(t, conn)
+
+	cmd := graphCmdWithDB(conn, "all")
+	cmd.Flags().Set("mermaid", "true")
+	w, buf := bufWriter(false)
+	if err := runIssueGraph(cmd, []string{"DKT-2"}, w); err != nil {
+		t.Fatalf("runIssueGraph: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`DKT-1["DKT-1: Schema"] --> DKT-2["DKT-2: API"]`,
+		`DKT-2["DKT-2: API"] -.-> DKT-3["DKT-3: UI"]`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("mermaid output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestIssueGraphTree_MarksDependsOn(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	conn := newTestDB(t)
+	seedMixedGraph(t, conn)
+
+	w, buf := bufWriter(false)
+	if err := runIssueGraph(graphCmdWithDB(conn, "all"), []string{"DKT-2"}, w); err != nil {
+		t.Fatalf("runIssueGraph: %v", err)
+	}
+	if !strings.Contains(buf.String(), "UI (via depends_on)") {
+		t.Errorf("tree missing the depends_on marker:\n%s", buf.String())
+	}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		switch {
+		case strings.Contains(line, "Schema") && strings.Contains(line, "via"):
+			t.Errorf("blocks edge marked as via: %q", line)
+		case strings.Contains(line, "UI") && !strings.HasSuffix(line, "(via depends_on)"):
+			t.Errorf("depends_on edge not marked: %q", line)
+		}
+	}
+}
+
+func TestRenderMermaid_StatusClasses(t *testing.T) {
+	issueMap := map[int]*model.Issue{
+		1: {ID: 1, Title: "Schema", Status: model.StatusDone},
+		2: {ID: 2, Title: "API", Status: model.StatusInProgress},
+		3: {ID: 3, Title: "UI", Status: model.StatusTodo},
+	}
+	edges := []graphEdge{
+		{From: 1, To: 2, Type: "blocks"},
+		{From: 2, To: 3, Type: "blocks"},
+	}
+
+	out := renderMermaid(issueMap, edges)
+
+	if !strings.Contains(out, `    DKT-1["DKT-1: Schema"] --> DKT-2["DKT-2: API"]`) {
+		t.Errorf("edge line missing:\n%s", out)
+	}
+	if !strings.Contains(out, "classDef done ") {
+		t.Errorf("expected classDef line for done:\n%s", out)
+	}
+	if !strings.Contains(out, "classDef in_progress ") {
+		t.Errorf("expected mermaid-safe in_progress classDef:\n%s", out)
+	}
+	if !strings.Contains(out, "class DKT-1 done\n") {
+		t.Errorf("expected DKT-1 to be assigned the done class:\n%s", out)
+	}
+	if !strings.Contains(out, "class DKT-2 in_progress\n") {
+		t.Errorf("expected DKT-2 to be assigned the in_progress class:\n%s", out)
+	}
+}
+
+func TestRenderMermaid_NoEdges(t *testing.T) {
+	out := renderMermaid(map[int]*model.Issue{}, nil)
+	if out != "graph TD\n" {
+		t.Errorf("out = %q, want bare header", out)
+	}
+}
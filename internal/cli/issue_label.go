@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
@@ -38,13 +40,13 @@ var labelAddCmd = &cobra.Command{
 
 		id, err := model.ParseID(args[0])
 		if err != nil {
-			return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+			return cmdErr(err, output.ErrValidation)
 		}
 
 		issue, err := db.GetIssue(conn, id)
 		if err != nil {
 			if errors.Is(err, db.ErrNotFound) {
-				return cmdErr(fmt.Errorf("issue %s not found", args[0]), output.ErrNotFound)
+				return issueNotFoundErr(conn, w, id)
 			}
 			return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
 		}
@@ -59,15 +61,26 @@ var labelAddCmd = &cobra.Command{
 			}
 		}
 
-		if err := db.AddLabelsToIssue(conn, id, labelNames, color, author); err != nil {
+		ignoreConflict, _ := cmd.Flags().GetBool("ignore-color-conflict")
+
+		var conflicts []db.LabelColorConflict
+		if ignoreConflict {
+			conflicts, err = db.AddLabelsToIssueKeepingColors(conn, id, labelNames, color, author)
+		} else {
+			err = db.AddLabelsToIssue(conn, id, labelNames, color, author)
+		}
+		if err != nil {
 			if errors.Is(err, db.ErrNotFound) {
-				return cmdErr(fmt.Errorf("issue %s not found", args[0]), output.ErrNotFound)
+				return issueNotFoundErr(conn, w, id)
 			}
 			if errors.Is(err, db.ErrLabelColorConflict) {
-				return cmdErr(fmt.Errorf("label already exists with a different color"), output.ErrValidation)
+				return cmdErr(fmt.Errorf("label already exists with a different color (use --ignore-color-conflict to keep its color and attach it anyway)"), output.ErrValidation)
 			}
 			return cmdErr(fmt.Errorf("adding labels: %w", err), output.ErrGeneral)
 		}
+		for _, c := range conflicts {
+			w.Warn("label %q already has color %s; kept it instead of %s", c.Label, c.ExistingColor, c.RequestedColor)
+		}
 
 		labels, err := db.GetIssueLabelObjects(conn, id)
 		if err != nil {
@@ -89,13 +102,13 @@ var labelRmCmd = &cobra.Command{
 
 		id, err := model.ParseID(args[0])
 		if err != nil {
-			return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+			return cmdErr(err, output.ErrValidation)
 		}
 
 		issue, err := db.GetIssue(conn, id)
 		if err != nil {
 			if errors.Is(err, db.ErrNotFound) {
-				return cmdErr(fmt.Errorf("issue %s not found", args[0]), output.ErrNotFound)
+				return issueNotFoundErr(conn, w, id)
 			}
 			return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
 		}
@@ -132,77 +145,114 @@ var labelRmCmd = &cobra.Command{
 var labelListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all labels",
-	Args:  cobra.NoArgs,
+	Long: `List all labels with the number of issues carrying each and when each was
+last attached to or detached from an issue.
+
+--stale lists only the labels not used for that long, to find ones to clean
+up with 'docket issue label delete':
+
+  docket issue label list --stale 90d`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		w := getWriter(cmd)
-		conn := getDB(cmd)
+		return runLabelList(cmd, args, getWriter(cmd))
+	},
+}
+
+func runLabelList(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
 
-		labels, err := db.ListAllLabels(conn)
+	stale, _ := cmd.Flags().GetString("stale")
+	var cutoff time.Time
+	if stale != "" {
+		age, err := parseAge(stale)
 		if err != nil {
-			return cmdErr(fmt.Errorf("listing labels: %w", err), output.ErrGeneral)
+			return cmdErr(fmt.Errorf("--stale: %w", err), output.ErrValidation)
 		}
+		cutoff = time.Now().Add(-age)
+	}
 
-		if len(labels) == 0 {
-			quiet, _ := cmd.Flags().GetBool("quiet")
-			msg := render.EmptyState(
-				"No labels found.",
-				"Add one with: docket issue label add <id> <label>",
-				quiet,
-			)
-			w.Success([]string{}, msg)
-			return nil
-		}
+	labels, err := db.ListAllLabels(conn)
+	if err != nil {
+		return cmdErr(fmt.Errorf("listing labels: %w", err), output.ErrGeneral)
+	}
+	if stale != "" {
+		labels = slices.DeleteFunc(labels, func(l *model.LabelWithCount) bool {
+			return l.LastUsedAt != nil && !l.LastUsedAt.Before(cutoff)
+		})
+	}
 
-		if w.JSONMode {
-			w.Success(labels, "")
+	if len(labels) == 0 {
+		if stale != "" {
+			w.Success([]string{}, fmt.Sprintf("No labels unused for %s.", stale))
 			return nil
 		}
+		quiet, _ := cmd.Flags().GetBool("quiet")
+		msg := render.EmptyState(
+			"No labels found.",
+			"Add one with: docket issue label add <id> <label>",
+			quiet,
+		)
+		w.Success([]string{}, msg)
+		return nil
+	}
 
-		if render.ColorsEnabled() {
-			rows := make([][]string, 0, len(labels))
-			for _, l := range labels {
-				color := l.Color
-				if color == "" {
-					color = "-"
-				}
-				var swatch string
-				if l.Color != "" {
-					swatch = lipgloss.NewStyle().Foreground(lipgloss.Color(l.Color)).Render("\u25a0")
-				} else {
-					swatch = lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render("\u25a0")
-				}
-				rows = append(rows, []string{swatch + " " + l.Name, color, fmt.Sprintf("%d", l.IssueCount)})
+	if w.JSONMode {
+		w.Success(labels, "")
+		return nil
+	}
+
+	if render.ColorsEnabled() {
+		rows := make([][]string, 0, len(labels))
+		for _, l := range labels {
+			color := l.Color
+			if color == "" {
+				color = "-"
 			}
+			var swatch string
+			if l.Color != "" {
+				swatch = lipgloss.NewStyle().Foreground(lipgloss.Color(l.Color)).Render("\u25a0")
+			} else {
+				swatch = lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render("\u25a0")
+			}
+			rows = append(rows, []string{swatch + " " + l.Name, color, fmt.Sprintf("%d", l.IssueCount), labelLastUsed(l)})
+		}
 
-			t := table.New().
-				Border(lipgloss.NormalBorder()).
-				BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("8"))).
-				Headers("NAME", "COLOR", "ISSUES").
-				Rows(rows...).
-				StyleFunc(func(row, col int) lipgloss.Style {
-					s := lipgloss.NewStyle().PaddingLeft(1).PaddingRight(1)
-					if row == table.HeaderRow {
-						return s.Bold(true).Foreground(lipgloss.Color("15"))
-					}
-					return s
-				})
-
-			w.Success(labels, t.Render())
-		} else {
-			var sb strings.Builder
-			fmt.Fprintf(&sb, "%-20s %-12s %s\n", "NAME", "COLOR", "ISSUES")
-			fmt.Fprintf(&sb, "%-20s %-12s %s\n", "----", "-----", "------")
-			for _, l := range labels {
-				color := l.Color
-				if color == "" {
-					color = "-"
+		t := table.New().
+			Border(lipgloss.NormalBorder()).
+			BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("8"))).
+			Headers("NAME", "COLOR", "ISSUES", "LAST USED").
+			Rows(rows...).
+			StyleFunc(func(row, col int) lipgloss.Style {
+				s := lipgloss.NewStyle().PaddingLeft(1).PaddingRight(1)
+				if row == table.HeaderRow {
+					return s.Bold(true).Foreground(lipgloss.Color("15"))
 				}
-				fmt.Fprintf(&sb, "%-20s %-12s %d\n", l.Name, color, l.IssueCount)
+				return s
+			})
+
+		w.Success(labels, t.Render())
+	} else {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "%-20s %-12s %-6s %s\n", "NAME", "COLOR", "ISSUES", "LAST USED")
+		fmt.Fprintf(&sb, "%-20s %-12s %-6s %s\n", "----", "-----", "------", "---------")
+		for _, l := range labels {
+			color := l.Color
+			if color == "" {
+				color = "-"
 			}
-			w.Success(labels, sb.String())
+			fmt.Fprintf(&sb, "%-20s %-12s %-6d %s\n", l.Name, color, l.IssueCount, labelLastUsed(l))
 		}
-		return nil
-	},
+		w.Success(labels, sb.String())
+	}
+	return nil
+}
+
+// labelLastUsed formats the date a label was last used, or "-" if never.
+func labelLastUsed(l *model.LabelWithCount) string {
+	if l.LastUsedAt == nil {
+		return "-"
+	}
+	return l.LastUsedAt.Local().Format("2006-01-02")
 }
 
 var labelDeleteCmd = &cobra.Command{
@@ -283,7 +333,9 @@ func validateLabelName(name string) error {
 
 func init() {
 	labelAddCmd.Flags().String("color", "", "Label color (hex)")
+	labelAddCmd.Flags().Bool("ignore-color-conflict", false, "Keep an existing label's color instead of failing when --color differs")
 	labelDeleteCmd.Flags().BoolP("force", "f", false, "Skip confirmation")
+	labelListCmd.Flags().String("stale", "", "Only list labels not attached or detached for this long (e.g. 90d, 12w)")
 
 	labelCmd.AddCommand(labelAddCmd)
 	labelCmd.AddCommand(labelRmCmd)
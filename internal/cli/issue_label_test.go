@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/spf13/cobra"
+)
+
+func labelListCmdWithDB(conn *sql.DB, stale string) *cobra.Command {
+	cmd := cmdWithDB(conn)
+	cmd.Flags().String("stale", "", "")
+	cmd.Flags().Set("stale", stale)
+	return cmd
+}
+
+func TestLabelListStale(t *testing.T) {
+	conn := newTestDB(t)
+	id := createIssue(t, conn, "A", model.StatusTodo, model.PriorityLow)
+	if err := db.AddLabelsToIssue(conn, id, []string{"old", "recent"}, "", "tester"); err != nil {
+		t.Fatalf("AddLabelsToIssue: %v", err)
+	}
+	if _, err := conn.Exec(`UPDATE labels SET last_used_at = '2020-01-01T00:00:00Z' WHERE name = 'old'`); err != nil {
+		t.Fatalf("backdating label: %v", err)
+	}
+	if _, err := conn.Exec(`INSERT INTO labels (name) VALUES ('unused')`); err != nil {
+		t.Fatalf("inserting label: %v", err)
+	}
+
+	w, buf := bufWriter(true)
+	if err := runLabelList(labelListCmdWithDB(conn, "90d"), nil, w); err != nil {
+		t.Fatalf("runLabelList: %v", err)
+	}
+	var env struct {
+		Data []model.LabelWithCount `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	var got []string
+	for _, l := range env.Data {
+		got = append(got, l.Name)
+	}
+	if len(got) != 2 || got[0] != "old" || got[1] != "unused" {
+		t.Errorf("stale labels = %v, want [old unused]", got)
+	}
+
+	w, _ = bufWriter(true)
+	if err := runLabelList(labelListCmdWithDB(conn, "soon"), nil, w); err == nil {
+		t.Error("expected an error for an invalid --stale age")
+	}
+}
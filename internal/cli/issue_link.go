@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/ALT-F4-LLC/docket/internal/config"
 	"github.com/ALT-F4-LLC/docket/internal/db"
 	"github.com/ALT-F4-LLC/docket/internal/model"
 	"github.com/ALT-F4-LLC/docket/internal/output"
@@ -20,6 +21,7 @@ type relationDisplay struct {
 	RelationType string `json:"relation_type"`
 	IssueID      string `json:"issue_id"`
 	Direction    string `json:"direction"`
+	Note         string `json:"note,omitempty"`
 }
 
 // unlinkResult is the JSON-friendly structure returned by the unlink command.
@@ -29,63 +31,234 @@ type unlinkResult struct {
 	RelationType  string `json:"relation_type"`
 }
 
+// duplicateRelationDetails is the data attached to the JSON error envelope
+// when a relation add conflicts with an existing relation.
+type duplicateRelationDetails struct {
+	Existing  model.Relation `json:"existing_relation"`
+	Direction string         `json:"direction"`
+}
+
+// duplicateRelationErr describes the existing relation and how to remove it,
+// so the caller can tell an exact duplicate from an inverse one.
+func duplicateRelationErr(dup *db.DuplicateRelationError) *CmdError {
+	existing := dup.Existing
+	direction := "same"
+	if dup.Inverse {
+		direction = "inverse"
+	}
+	return &CmdError{
+		Err: fmt.Errorf("A relation already exists: %s %s %s (#%d). Remove it with `docket relation remove --id %d`.",
+			model.FormatID(existing.SourceIssueID), string(existing.RelationType),
+			model.FormatID(existing.TargetIssueID), existing.ID, existing.ID),
+		Code: output.ErrConflict,
+		Data: duplicateRelationDetails{Existing: existing, Direction: direction},
+	}
+}
+
 var linkCmd = &cobra.Command{
-	Use:   "link",
-	Short: "Manage issue relations",
+	Use:   "link <id> <url>",
+	Short: "Attach a URL to an issue or manage issue relations",
+	Long: `Attach a URL to an issue, such as a pull request or design document:
+
+  docket issue link DKT-5 https://github.com/org/repo/pull/12 --title "Fix PR"
+
+Relations between issues are managed with the add, remove and list
+subcommands.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return nil
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return cmd.Help()
+		}
+		return runIssueLinkURL(cmd, args, getWriter(cmd))
+	},
+}
+
+func runIssueLinkURL(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	id, err := model.ParseID(args[0])
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+
+	url := strings.TrimSpace(args[1])
+	if err := model.ValidateLinkURL(url); err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+
+	title, _ := cmd.Flags().GetString("title")
+	title = strings.TrimSpace(title)
+
+	linkID, err := db.AddLink(conn, id, url, title, config.DefaultAuthor())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return issueNotFoundErr(conn, w, id)
+		}
+		if errors.Is(err, db.ErrConflict) {
+			return cmdErr(fmt.Errorf("%s is already linked to %s", url, model.FormatID(id)), output.ErrConflict)
+		}
+		return cmdErr(fmt.Errorf("adding link: %w", err), output.ErrGeneral)
+	}
+
+	links, err := db.GetIssueLinks(conn, id)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching links: %w", err), output.ErrGeneral)
+	}
+	var link model.IssueLink
+	for _, l := range links {
+		if l.ID == linkID {
+			link = l
+		}
+	}
+
+	w.Success(link, fmt.Sprintf("Linked %s to %s", url, model.FormatID(id)))
+	return nil
 }
 
 var linkAddCmd = &cobra.Command{
-	Use:   "add <id> <relation> <target_id>",
+	Use:   "add <id> <relation> <target_id>...",
 	Short: "Create a relation between two issues",
-	Args:  cobra.ExactArgs(3),
+	Long: `Create a relation between two issues. The relation is one of blocks,
+depends-on, relates-to, duplicates or supersedes.
+
+Either side may name several issues, as IDs or ranges such as DKT-1..DKT-4,
+to create a relation for every pair. Pairs that cannot be linked, because
+an issue does not exist or the relation already exists or would form a
+cycle, are reported and skipped:
+
+  docket issue link add DKT-2 depends-on DKT-10..DKT-12 DKT-15
+
+When one issue replaces another, --close-superseded also moves the superseded
+issue to done in the same step:
+
+  docket issue link add DKT-9 supersedes DKT-3 --close-superseded
+
+--note annotates the relation, e.g. to tell hard blockers from soft ones:
+
+  docket issue link add DKT-4 blocks DKT-7 --note "hard blocker"`,
+	Args: cobra.MinimumNArgs(3),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		w := getWriter(cmd)
-		conn := getDB(cmd)
+		return runLinkAdd(cmd, args, getWriter(cmd))
+	},
+}
 
-		sourceID, err := model.ParseID(args[0])
-		if err != nil {
-			return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
-		}
+func runLinkAdd(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
 
-		relType, err := model.ParseRelationType(args[1])
-		if err != nil {
-			return cmdErr(fmt.Errorf("%w", err), output.ErrValidation)
-		}
+	sourceIDs, err := model.ParseIDList(args[:1])
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
 
-		targetID, err := model.ParseID(args[2])
-		if err != nil {
-			return cmdErr(fmt.Errorf("invalid target ID: %w", err), output.ErrValidation)
-		}
+	relType, err := model.ParseRelationType(args[1])
+	if err != nil {
+		return cmdErr(fmt.Errorf("%w", err), output.ErrValidation)
+	}
 
+	targetIDs, err := model.ParseIDList(args[2:])
+	if err != nil {
+		return cmdErr(fmt.Errorf("invalid target ID: %w", err), output.ErrValidation)
+	}
+
+	closeSuperseded, _ := cmd.Flags().GetBool("close-superseded")
+	if closeSuperseded && relType != model.RelationSupersedes {
+		return cmdErr(fmt.Errorf("--close-superseded requires the supersedes relation, not %s", relType), output.ErrValidation)
+	}
+
+	note, _ := cmd.Flags().GetString("note")
+	opts := db.CreateRelationOptions{
+		CloseSuperseded: closeSuperseded,
+		ChangedBy:       config.DefaultAuthor(),
+	}
+
+	if len(sourceIDs) == 1 && len(targetIDs) == 1 {
+		sourceID, targetID := sourceIDs[0], targetIDs[0]
 		rel := &model.Relation{
 			SourceIssueID: sourceID,
 			TargetIssueID: targetID,
 			RelationType:  relType,
+			Note:          strings.TrimSpace(note),
 		}
-
-		relID, err := db.CreateRelation(conn, rel)
+		relID, err := db.CreateRelationWithOptions(conn, rel, opts)
 		if err != nil {
-			if errors.Is(err, db.ErrNotFound) {
-				return cmdErr(fmt.Errorf("issue not found"), output.ErrNotFound)
+			return linkAddErr(err)
+		}
+		rel.ID = relID
+
+		message := fmt.Sprintf("Linked %s %s %s",
+			model.FormatID(sourceID), string(relType), model.FormatID(targetID))
+		if closeSuperseded {
+			message += fmt.Sprintf(" and closed %s", model.FormatID(targetID))
+		}
+		w.Success(rel, message)
+		return nil
+	}
+
+	created := []*model.Relation{}
+	var lines []string
+	for _, sourceID := range sourceIDs {
+		for _, targetID := range targetIDs {
+			if sourceID == targetID {
+				continue
 			}
-			if errors.Is(err, db.ErrSelfRelation) {
-				return cmdErr(fmt.Errorf("cannot link an issue to itself"), output.ErrValidation)
+			rel := &model.Relation{
+				SourceIssueID: sourceID,
+				TargetIssueID: targetID,
+				RelationType:  relType,
+				Note:          strings.TrimSpace(note),
 			}
-			if errors.Is(err, db.ErrDuplicateRelation) {
-				return cmdErr(fmt.Errorf("relation already exists"), output.ErrConflict)
+			relID, err := db.CreateRelationWithOptions(conn, rel, opts)
+			if err != nil {
+				ce := linkAddErr(err)
+				if ce.Code == output.ErrGeneral {
+					ce.Err = fmt.Errorf("%w (%d relation(s) created before the error)", ce.Err, len(created))
+					return ce
+				}
+				w.Warn("Skipped %s %s %s: %v",
+					model.FormatID(sourceID), string(relType), model.FormatID(targetID), ce)
+				continue
 			}
-			if errors.Is(err, db.ErrCycleDetected) {
-				return cmdErr(err, output.ErrConflict)
+			rel.ID = relID
+			created = append(created, rel)
+			line := fmt.Sprintf("Linked %s %s %s",
+				model.FormatID(sourceID), string(relType), model.FormatID(targetID))
+			if closeSuperseded {
+				line += fmt.Sprintf(" and closed %s", model.FormatID(targetID))
 			}
-			return cmdErr(fmt.Errorf("creating relation: %w", err), output.ErrGeneral)
+			lines = append(lines, line)
 		}
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "No relations created")
+	}
+	w.Success(created, strings.Join(lines, "\n"))
+	return nil
+}
 
-		rel.ID = relID
-
-		w.Success(rel, fmt.Sprintf("Linked %s %s %s",
-			model.FormatID(sourceID), string(relType), model.FormatID(targetID)))
-		return nil
-	},
+// linkAddErr maps an error creating a relation to a command error.
+func linkAddErr(err error) *CmdError {
+	if errors.Is(err, db.ErrNotFound) {
+		return cmdErr(fmt.Errorf("issue not found"), output.ErrNotFound)
+	}
+	if errors.Is(err, db.ErrSelfRelation) {
+		return cmdErr(fmt.Errorf("cannot link an issue to itself"), output.ErrValidation)
+	}
+	var dup *db.DuplicateRelationError
+	if errors.As(err, &dup) {
+		return duplicateRelationErr(dup)
+	}
+	if errors.Is(err, db.ErrDuplicateRelation) {
+		return cmdErr(fmt.Errorf("relation already exists"), output.ErrConflict)
+	}
+	if errors.Is(err, db.ErrCycleDetected) || errors.Is(err, db.ErrConflict) {
+		return cmdErr(err, output.ErrConflict)
+	}
+	return cmdErr(fmt.Errorf("creating relation: %w", err), output.ErrGeneral)
 }
 
 var linkRemoveCmd = &cobra.Command{
@@ -98,7 +271,7 @@ var linkRemoveCmd = &cobra.Command{
 
 		sourceID, err := model.ParseID(args[0])
 		if err != nil {
-			return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+			return cmdErr(err, output.ErrValidation)
 		}
 
 		relType, err := model.ParseRelationType(args[1])
@@ -111,7 +284,7 @@ var linkRemoveCmd = &cobra.Command{
 			return cmdErr(fmt.Errorf("invalid target ID: %w", err), output.ErrValidation)
 		}
 
-		if err := db.DeleteRelation(conn, sourceID, targetID, string(relType)); err != nil {
+		if err := db.DeleteRelation(conn, sourceID, targetID, string(relType), config.DefaultAuthor()); err != nil {
 			if errors.Is(err, db.ErrNotFound) {
 				return cmdErr(fmt.Errorf("relation not found"), output.ErrNotFound)
 			}
@@ -140,7 +313,7 @@ var linkListCmd = &cobra.Command{
 
 		id, err := model.ParseID(args[0])
 		if err != nil {
-			return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+			return cmdErr(err, output.ErrValidation)
 		}
 
 		exists, err := db.IssueExists(conn, id)
@@ -171,6 +344,7 @@ var linkListCmd = &cobra.Command{
 		for _, rel := range relations {
 			var d relationDisplay
 			d.ID = rel.ID
+			d.Note = rel.Note
 			if rel.SourceIssueID == id {
 				d.RelationType = string(rel.RelationType)
 				d.IssueID = model.FormatID(rel.TargetIssueID)
@@ -192,6 +366,7 @@ var linkListCmd = &cobra.Command{
 		if render.ColorsEnabled() {
 			sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
 			boldStyle := lipgloss.NewStyle().Bold(true)
+			dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
 			fmt.Fprintf(&sb, "%s\n", sectionStyle.Render(fmt.Sprintf("Relations for %s", model.FormatID(id))))
 			for _, d := range displays {
 				relType := model.RelationType(d.RelationType)
@@ -202,12 +377,20 @@ var linkListCmd = &cobra.Command{
 				} else {
 					arrow = render.RelationArrow(relType, false)
 				}
-				fmt.Fprintf(&sb, "  %s %s %s\n", arrow, typeStyle.Render(d.RelationType), boldStyle.Render(d.IssueID))
+				fmt.Fprintf(&sb, "  %s %s %s %s", arrow, typeStyle.Render(d.RelationType), boldStyle.Render(d.IssueID), dimStyle.Render(fmt.Sprintf("(#%d)", d.ID)))
+				if d.Note != "" {
+					fmt.Fprintf(&sb, " %s", dimStyle.Render("— "+d.Note))
+				}
+				sb.WriteString("\n")
 			}
 		} else {
 			fmt.Fprintf(&sb, "Relations for %s:\n", model.FormatID(id))
 			for _, d := range displays {
-				fmt.Fprintf(&sb, "  %s %s\n", d.RelationType, d.IssueID)
+				fmt.Fprintf(&sb, "  %s %s (#%d)", d.RelationType, d.IssueID, d.ID)
+				if d.Note != "" {
+					fmt.Fprintf(&sb, " - %s", d.Note)
+				}
+				sb.WriteString("\n")
 			}
 		}
 
@@ -217,6 +400,9 @@ var linkListCmd = &cobra.Command{
 }
 
 func init() {
+	linkCmd.Flags().String("title", "", "Optional title shown for the link")
+	linkAddCmd.Flags().Bool("close-superseded", false, "With supersedes, also move the superseded issue to done")
+	linkAddCmd.Flags().String("note", "", "Annotate the relation, e.g. \"hard blocker\"")
 	linkCmd.AddCommand(linkAddCmd)
 	linkCmd.AddCommand(linkRemoveCmd)
 	linkCmd.AddCommand(linkListCmd)
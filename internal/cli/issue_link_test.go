@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func TestDuplicateRelationErr(t *testing.T) {
+	conn := newTestDB(t)
+	a := createIssue(t, conn, "A", model.StatusTodo, model.PriorityHigh)
+	b := createIssue(t, conn, "B", model.StatusTodo, model.PriorityHigh)
+
+	relID, err := db.CreateRelation(conn, &model.Relation{SourceIssueID: b, TargetIssueID: a, RelationType: model.RelationBlocks})
+	if err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		source        int
+		target        int
+		wantDirection string
+	}{
+		{"same direction", b, a, "same"},
+		{"inverse direction", a, b, "inverse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := db.CreateRelation(conn, &model.Relation{SourceIssueID: tt.source, TargetIssueID: tt.target, RelationType: model.RelationBlocks})
+			var dup *db.DuplicateRelationError
+			if !errors.As(err, &dup) {
+				t.Fatalf("expected *db.DuplicateRelationError, got %v", err)
+			}
+
+			ce := duplicateRelationErr(dup)
+			if ce.Code != output.ErrConflict {
+				t.Errorf("code = %s, want %s", ce.Code, output.ErrConflict)
+			}
+			want := "A relation already exists: DKT-2 blocks DKT-1 (#1). Remove it with `docket relation remove --id 1`."
+			if relID != 1 || ce.Error() != want {
+				t.Errorf("message = %q, want %q", ce.Error(), want)
+			}
+
+			var stdout bytes.Buffer
+			w := &output.Writer{JSONMode: true, Stdout: &stdout, Stderr: &bytes.Buffer{}}
+			w.ErrorWithData(ce.Err, ce.Code, ce.Data)
+
+			var env struct {
+				Data struct {
+					Existing struct {
+						ID            int    `json:"id"`
+						SourceIssueID string `json:"source_issue_id"`
+						TargetIssueID string `json:"target_issue_id"`
+						RelationType  string `json:"relation_type"`
+					} `json:"existing_relation"`
+					Direction string `json:"direction"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(stdout.Bytes(), &env); err != nil {
+				t.Fatalf("unmarshal: %v\n%s", err, stdout.String())
+			}
+			got := env.Data.Existing
+			if got.ID != relID || got.SourceIssueID != "DKT-2" || got.TargetIssueID != "DKT-1" || got.RelationType != "blocks" {
+				t.Errorf("existing_relation = %+v", got)
+			}
+			if env.Data.Direction != tt.wantDirection {
+				t.Errorf("direction = %q, want %q", env.Data.Direction, tt.wantDirection)
+			}
+			if !strings.Contains(stdout.String(), `"code":"CONFLICT"`) {
+				t.Errorf("envelope missing CONFLICT code: %s", stdout.String())
+			}
+		})
+	}
+}
+
+func linkAddCmdWithDB(conn *sql.DB) *cobra.Command {
+	cmd := cmdWithDB(conn)
+	cmd.Flags().Bool("close-superseded", false, "")
+	cmd.Flags().String("note", "", "")
+	return cmd
+}
+
+func TestLinkAddRange(t *testing.T) {
+	conn := newTestDB(t)
+	a := createIssue(t, conn, "A", model.StatusTodo, model.PriorityHigh)
+	b := createIssue(t, conn, "B", model.StatusTodo, model.PriorityHigh)
+	c := createIssue(t, conn, "C", model.StatusTodo, model.PriorityHigh)
+	d := createIssue(t, conn, "D", model.StatusTodo, model.PriorityHigh)
+	if _, err := db.CreateRelation(conn, &model.Relation{SourceIssueID: a, TargetIssueID: c, RelationType: model.RelationBlocks}); err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+
+	// The range includes A itself, which is left out, and the existing A
+	// blocks C, which is skipped; the repeated B is linked once.
+	args := []string{model.FormatID(a), "blocks", model.FormatID(a) + ".." + model.FormatID(d), model.FormatID(b)}
+	w, buf := bufWriter(true)
+	if err := runLinkAdd(linkAddCmdWithDB(conn), args, w); err != nil {
+		t.Fatalf("runLinkAdd: %v", err)
+	}
+	var env struct {
+		Data []struct {
+			TargetIssueID string `json:"target_issue_id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	var got []string
+	for _, rel := range env.Data {
+		got = append(got, rel.TargetIssueID)
+	}
+	if want := []string{model.FormatID(b), model.FormatID(d)}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("linked %v, want %v", got, want)
+	}
+
+	w, _ = bufWriter(true)
+	err := runLinkAdd(linkAddCmdWithDB(conn), []string{model.FormatID(a), "blocks", "DKT-4..DKT-2"}, w)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Errorf("inverted range error = %v, want a validation error", err)
+	}
+}
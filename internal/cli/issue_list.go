@@ -5,8 +5,8 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
-	"strings"
 	"syscall"
+	"time"
 
 	"github.com/ALT-F4-LLC/docket/internal/db"
 	"github.com/ALT-F4-LLC/docket/internal/model"
@@ -17,9 +17,25 @@ import (
 	"golang.org/x/term"
 )
 
+// listResult is the JSON output of 'issue list' and 'recent'. Total counts every issue
+// matching the filters, while Returned counts those in Issues after Offset
+// and the limit are applied.
 type listResult struct {
-	Issues []*model.Issue `json:"issues"`
-	Total  int            `json:"total"`
+	Issues   []*model.Issue `json:"issues"`
+	Total    int            `json:"total"`
+	Returned int            `json:"returned"`
+	Offset   int            `json:"offset"`
+}
+
+// newListResult builds the listResult for a page of issues starting at
+// offset, out of total matches.
+func newListResult(issues []*model.Issue, total, offset int) listResult {
+	return listResult{Issues: issues, Total: total, Returned: len(issues), Offset: offset}
+}
+
+// listCountResult is the JSON output of 'issue list --count'.
+type listCountResult struct {
+	Total int `json:"total"`
 }
 
 var listCmd = &cobra.Command{
@@ -56,13 +72,44 @@ func runIssueList(cmd *cobra.Command, args []string, w *output.Writer) error {
 	priorities, _ := cmd.Flags().GetStringSlice("priority")
 	labels, _ := cmd.Flags().GetStringSlice("label")
 	types, _ := cmd.Flags().GetStringSlice("type")
-	assignee, _ := cmd.Flags().GetString("assignee")
 	parent, _ := cmd.Flags().GetString("parent")
 	rootsOnly, _ := cmd.Flags().GetBool("roots")
+	hasChildren, _ := cmd.Flags().GetBool("has-children")
+	noChildren, _ := cmd.Flags().GetBool("no-children")
+	hasFiles, _ := cmd.Flags().GetBool("has-files")
+	noFiles, _ := cmd.Flags().GetBool("no-files")
+	pinned, _ := cmd.Flags().GetBool("pinned")
 	treeMode, _ := cmd.Flags().GetBool("tree")
 	sortFlag, _ := cmd.Flags().GetString("sort")
 	limit, _ := cmd.Flags().GetInt("limit")
+	if cfg := getCfg(cmd); cfg != nil && cfg.ListLimit > 0 && !cmd.Flags().Changed("limit") {
+		limit = cfg.ListLimit
+	}
+	offset, _ := cmd.Flags().GetInt("offset")
+	if offset < 0 {
+		return cmdErr(fmt.Errorf("--offset must not be negative"), output.ErrValidation)
+	}
 	all, _ := cmd.Flags().GetBool("all")
+	includeSnoozed, _ := cmd.Flags().GetBool("include-snoozed")
+	milestone, _ := cmd.Flags().GetString("milestone")
+	completedSince, _ := cmd.Flags().GetString("completed-since")
+
+	layout, err := getLayout(cmd)
+	if err != nil {
+		return err
+	}
+
+	assignee, _, err := assigneeFromFlags(cmd, conn)
+	if err != nil {
+		return err
+	}
+
+	if hasChildren && noChildren {
+		return cmdErr(fmt.Errorf("--has-children and --no-children are mutually exclusive"), output.ErrValidation)
+	}
+	if hasFiles && noFiles {
+		return cmdErr(fmt.Errorf("--has-files and --no-files are mutually exclusive"), output.ErrValidation)
+	}
 
 	// Validate filter enum values.
 	for _, s := range statuses {
@@ -82,14 +129,46 @@ func runIssueList(cmd *cobra.Command, args []string, w *output.Writer) error {
 	}
 
 	opts := db.ListOptions{
-		Statuses:    statuses,
-		Priorities:  priorities,
-		Labels:      labels,
-		Types:       types,
-		Assignee:    assignee,
-		RootsOnly:   rootsOnly,
-		IncludeDone: all,
-		Limit:       limit,
+		Statuses:       statuses,
+		Priorities:     priorities,
+		Labels:         labels,
+		Types:          types,
+		Assignee:       assignee,
+		RootsOnly:      rootsOnly,
+		IncludeDone:    all,
+		IncludeSnoozed: includeSnoozed,
+		Limit:          limit,
+		Offset:         offset,
+		PinnedFirst:    true,
+	}
+
+	if allStatuses(cmd) {
+		opts = opts.WithAllStatuses()
+	}
+	if hasChildren || noChildren {
+		opts.HasChildren = &hasChildren
+	}
+	if hasFiles || noFiles {
+		opts.HasFiles = &hasFiles
+	}
+	if pinned {
+		opts.Pinned = &pinned
+	}
+
+	if completedSince != "" {
+		age, err := parseAge(completedSince)
+		if err != nil {
+			return cmdErr(fmt.Errorf("--completed-since: %w", err), output.ErrValidation)
+		}
+		opts.CompletedSince = time.Now().Add(-age)
+	}
+
+	if milestone != "" {
+		m, err := resolveMilestone(conn, milestone)
+		if err != nil {
+			return err
+		}
+		opts.MilestoneID = &m.ID
 	}
 
 	// Parse --parent flag.
@@ -101,14 +180,39 @@ func runIssueList(cmd *cobra.Command, args []string, w *output.Writer) error {
 		opts.ParentID = &pid
 	}
 
-	// Parse --sort flag (field:direction).
+	// --count needs neither an order nor the issues themselves. The total is
+	// written directly rather than through Success so that it can be used as
+	// is, e.g. in $(docket issue list --count -p critical).
+	if countOnly, _ := cmd.Flags().GetBool("count"); countOnly {
+		opts.CountOnly = true
+		_, total, err := db.ListIssues(conn, opts)
+		if err != nil {
+			return cmdErr(fmt.Errorf("counting issues: %w", err), output.ErrGeneral)
+		}
+		if w.JSONMode {
+			w.Success(listCountResult{Total: total}, "")
+			return nil
+		}
+		fmt.Fprintln(w.Stdout, total)
+		return nil
+	}
+
+	// Parse --sort flag (field:direction, comma-separated), falling back to
+	// the configured default sort.
 	if sortFlag != "" {
-		parts := strings.SplitN(sortFlag, ":", 2)
-		opts.Sort = parts[0]
-		if len(parts) > 1 {
-			opts.SortDir = parts[1]
+		keys, err := db.ParseSortKeys(sortFlag)
+		if err != nil {
+			return cmdErr(fmt.Errorf("--sort: %w", err), output.ErrValidation)
+		}
+		opts.SortKeys = keys
+	} else {
+		keys, err := db.DefaultSort(conn)
+		if err != nil {
+			return cmdErr(err, output.ErrGeneral)
 		}
+		opts.SortKeys = keys
 	}
+	layout.KeepOrder = len(opts.SortKeys) > 0
 
 	issues, total, err := db.ListIssues(conn, opts)
 	if err != nil {
@@ -119,7 +223,19 @@ func runIssueList(cmd *cobra.Command, args []string, w *output.Writer) error {
 		return cmdErr(fmt.Errorf("fetching linked docs: %w", err), output.ErrGeneral)
 	}
 
-	result := listResult{Issues: issues, Total: total}
+	if err := db.HydrateCommentCounts(conn, issues); err != nil {
+		return cmdErr(fmt.Errorf("fetching comment counts: %w", err), output.ErrGeneral)
+	}
+
+	if err := db.HydrateMilestones(conn, issues); err != nil {
+		return cmdErr(fmt.Errorf("fetching milestones: %w", err), output.ErrGeneral)
+	}
+
+	if err := hydrateColumns(conn, issues, layout); err != nil {
+		return err
+	}
+
+	result := newListResult(issues, total, offset)
 
 	// Fetch parent issues and sub-issue progress for the grouped display.
 	// Only needed for human-readable output (JSON stays flat).
@@ -180,7 +296,7 @@ func runIssueList(cmd *cobra.Command, args []string, w *output.Writer) error {
 			for id := range parentIDSet {
 				parentIDs = append(parentIDs, id)
 			}
-			batchProgress, err := db.GetBatchSubIssueProgress(conn, parentIDs)
+			batchProgress, err := db.GetCachedSubIssueProgress(conn, parentIDs)
 			if err != nil {
 				return cmdErr(fmt.Errorf("fetching sub-issue progress: %w", err), output.ErrGeneral)
 			}
@@ -194,29 +310,57 @@ func runIssueList(cmd *cobra.Command, args []string, w *output.Writer) error {
 	}
 
 	var message string
-	if !w.JSONMode {
-		if treeMode {
-			message = render.RenderTable(issues, true)
-		} else {
-			message = render.RenderGroupedTable(issues, parentMap, progress)
+	switch {
+	case w.JSONMode:
+	case len(issues) == 0:
+		includeDoneFlag := "--all"
+		if opts.IncludeDone || !opts.CompletedSince.IsZero() {
+			includeDoneFlag = ""
+		}
+		message, err = emptyIssuesMessage(cmd, conn, "No issues found.", includeDoneFlag, listFilterFlags...)
+		if err != nil {
+			return err
 		}
+	case treeMode:
+		message = render.RenderTable(issues, true, layout)
+	default:
+		message = render.RenderGroupedTable(issues, parentMap, progress, layout)
 	}
 	w.Success(result, message)
 
 	return nil
 }
 
+// listFilterFlags are the issue list flags that narrow the result, in the
+// order an empty result names them.
+var listFilterFlags = []string{
+	"status", "priority", "label", "type", "assignee", "mine", "parent", "milestone",
+	"roots", "has-children", "no-children", "has-files", "no-files", "pinned", "completed-since", "offset",
+}
+
 func init() {
 	listCmd.Flags().StringSliceP("status", "s", nil, "Filter by status (repeatable)")
 	listCmd.Flags().StringSliceP("priority", "p", nil, "Filter by priority (repeatable)")
 	listCmd.Flags().StringSliceP("label", "l", nil, "Filter by label (repeatable)")
 	listCmd.Flags().StringSliceP("type", "T", nil, "Filter by type (repeatable)")
-	listCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
+	listCmd.Flags().StringP("assignee", "a", "", "Filter by assignee (\"me\" for the configured current user)")
+	addMineFlag(listCmd, "Only show issues assigned to the configured current user")
 	listCmd.Flags().String("parent", "", "Filter by parent issue ID")
+	listCmd.Flags().String("milestone", "", "Filter by milestone name")
 	listCmd.Flags().Bool("roots", false, "Only show root issues (no parent)")
+	listCmd.Flags().Bool("has-children", false, "Only show issues that have sub-issues")
+	listCmd.Flags().Bool("no-children", false, "Only show leaf issues (no sub-issues)")
+	listCmd.Flags().Bool("has-files", false, "Only show issues with attached files")
+	listCmd.Flags().Bool("no-files", false, "Only show issues without attached files")
+	listCmd.Flags().Bool("pinned", false, "Only show pinned issues")
 	listCmd.Flags().Bool("tree", false, "Display as indented hierarchy")
-	listCmd.Flags().String("sort", "", "Sort by field:direction (e.g. priority:asc)")
+	listCmd.Flags().String("sort", "", "Sort by comma-separated field:direction keys (e.g. priority:desc,updated_at:desc); overrides 'docket config sort'")
+	listCmd.Flags().String("completed-since", "", "Only show issues completed within this long (e.g. 7d, 2w); implies --all")
 	listCmd.Flags().Int("limit", 50, "Maximum number of results")
+	listCmd.Flags().Int("offset", 0, "Skip this many matching issues (for paging with --limit)")
 	listCmd.Flags().Bool("all", false, "Include done issues")
+	listCmd.Flags().Bool("include-snoozed", false, "Include snoozed issues")
+	listCmd.Flags().Bool("count", false, "Print only the number of matching issues, ignoring --limit and --offset")
+	addColumnsFlag(listCmd)
 	issueCmd.AddCommand(listCmd)
 }
@@ -1,11 +1,18 @@
 package cli
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/ALT-F4-LLC/docket/internal/config"
+	"github.com/ALT-F4-LLC/docket/internal/db"
 	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -18,10 +25,21 @@ func listCmdWithDB(conn *sql.DB) *cobra.Command {
 	cmd.Flags().String("assignee", "", "")
 	cmd.Flags().String("parent", "", "")
 	cmd.Flags().Bool("roots", false, "")
+	cmd.Flags().Bool("has-children", false, "")
+	cmd.Flags().Bool("no-children", false, "")
+	cmd.Flags().Bool("has-files", false, "")
+	cmd.Flags().Bool("no-files", false, "")
+	cmd.Flags().Bool("pinned", false, "")
 	cmd.Flags().Bool("tree", false, "")
 	cmd.Flags().String("sort", "", "")
 	cmd.Flags().Int("limit", 50, "")
+	cmd.Flags().Int("offset", 0, "")
 	cmd.Flags().Bool("all", false, "")
+	cmd.Flags().Bool("include-snoozed", false, "")
+	cmd.Flags().Int("width", 0, "")
+	cmd.Flags().Bool("no-truncate", false, "")
+	cmd.Flags().String("columns", "", "")
+	cmd.Flags().Bool("count", false, "")
 	return cmd
 }
 
@@ -37,7 +55,9 @@ type listJSON struct {
 				Status string `json:"status"`
 			} `json:"docs"`
 		} `json:"issues"`
-		Total int `json:"total"`
+		Total    int `json:"total"`
+		Returned int `json:"returned"`
+		Offset   int `json:"offset"`
 	} `json:"data"`
 }
 
@@ -99,3 +119,309 @@ func TestListJSON_FilesAndDocsEmptyAreArrays(t *testing.T) {
 		}
 	}
 }
+
+func TestListHuman_NoTruncate(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	conn := newTestDB(t)
+	longTitle := "Refactor the storage layer so that every query goes through one retrying helper"
+	createIssue(t, conn, longTitle, model.StatusTodo, model.PriorityHigh)
+
+	cmd := listCmdWithDB(conn)
+	w, buf := bufWriter(false)
+	if err := runIssueList(cmd, nil, w); err != nil {
+		t.Fatalf("runIssueList: %v", err)
+	}
+	if strings.Contains(buf.String(), longTitle) {
+		t.Errorf("expected title truncated by default, got:\n%s", buf.String())
+	}
+
+	cmd = listCmdWithDB(conn)
+	if err := cmd.Flags().Set("no-truncate", "true"); err != nil {
+		t.Fatal(err)
+	}
+	w, buf = bufWriter(false)
+	if err := runIssueList(cmd, nil, w); err != nil {
+		t.Fatalf("runIssueList: %v", err)
+	}
+	if !strings.Contains(buf.String(), longTitle) {
+		t.Errorf("expected full title with --no-truncate, got:\n%s", buf.String())
+	}
+}
+
+func TestListHuman_DepsColumn(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	conn := newTestDB(t)
+	head := createIssue(t, conn, "Head", model.StatusTodo, model.PriorityHigh)
+	middle := createIssue(t, conn, "Middle", model.StatusTodo, model.PriorityHigh)
+	leaf := createIssue(t, conn, "Leaf", model.StatusTodo, model.PriorityHigh)
+	for _, rel := range [][2]int{{head, middle}, {middle, leaf}} {
+		if _, err := db.CreateRelation(conn, &model.Relation{SourceIssueID: rel[0], TargetIssueID: rel[1], RelationType: model.RelationBlocks}); err != nil {
+			t.Fatalf("CreateRelation: %v", err)
+		}
+	}
+
+	cmd := listCmdWithDB(conn)
+	if err := cmd.Flags().Set("columns", "title,deps"); err != nil {
+		t.Fatal(err)
+	}
+	w, buf := bufWriter(false)
+	if err := runIssueList(cmd, nil, w); err != nil {
+		t.Fatalf("runIssueList: %v", err)
+	}
+	var rows []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		rows = append(rows, strings.Join(strings.Fields(line), " "))
+	}
+	for _, want := range []string{"Head ↓1", "Middle ↑1 ↓1", "Leaf ↑1"} {
+		if !slices.Contains(rows, want) {
+			t.Errorf("expected a row %q, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestListRejectsNegativeWidth(t *testing.T) {
+	conn := newTestDB(t)
+	cmd := listCmdWithDB(conn)
+	if err := cmd.Flags().Set("width", "-1"); err != nil {
+		t.Fatal(err)
+	}
+	w, _ := bufWriter(false)
+	err := runIssueList(cmd, nil, w)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Fatalf("err = %v, want validation error", err)
+	}
+}
+
+func TestListChildrenFlagsAreMutuallyExclusive(t *testing.T) {
+	conn := newTestDB(t)
+	cmd := listCmdWithDB(conn)
+	for _, f := range []string{"has-children", "no-children"} {
+		if err := cmd.Flags().Set(f, "true"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w, _ := bufWriter(true)
+	err := runIssueList(cmd, nil, w)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Fatalf("err = %v, want validation error", err)
+	}
+}
+
+func TestListSortUsesConfiguredDefault(t *testing.T) {
+	conn := newTestDB(t)
+	low := createIssue(t, conn, "b low", model.StatusInProgress, model.PriorityLow)
+	crit := createIssue(t, conn, "a critical", model.StatusTodo, model.PriorityCritical)
+	if err := db.SetDefaultSort(conn, []db.SortKey{{Field: "priority", Dir: "desc"}}); err != nil {
+		t.Fatalf("SetDefaultSort: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		sort string
+		want []int
+	}{
+		{"configured default", "", []int{crit, low}},
+		{"flag overrides default", "status:desc,title:asc", []int{low, crit}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := listCmdWithDB(conn)
+			cmd.Flags().Set("sort", tt.sort)
+			w, buf := bufWriter(true)
+			if err := runIssueList(cmd, nil, w); err != nil {
+				t.Fatalf("runIssueList: %v", err)
+			}
+			var lj listJSON
+			if err := json.Unmarshal(buf.Bytes(), &lj); err != nil {
+				t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+			}
+			var got []string
+			for _, issue := range lj.Data.Issues {
+				got = append(got, issue.ID)
+			}
+			want := []string{model.FormatID(tt.want[0]), model.FormatID(tt.want[1])}
+			if !slices.Equal(got, want) {
+				t.Errorf("order = %v, want %v", got, want)
+			}
+		})
+	}
+
+	cmd := listCmdWithDB(conn)
+	cmd.Flags().Set("sort", "priority:up")
+	w, _ := bufWriter(true)
+	var ce *CmdError
+	if err := runIssueList(cmd, nil, w); !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Errorf("invalid --sort: err = %v, want a validation error", err)
+	}
+}
+
+func TestListEmptyStateNamesActiveFilters(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	conn := newTestDB(t)
+	createIssue(t, conn, "Todo work", model.StatusTodo, model.PriorityLow)
+
+	cmd := listCmdWithDB(conn)
+	if err := cmd.Flags().Set("status", "in-progress"); err != nil {
+		t.Fatalf("Set(status): %v", err)
+	}
+	if err := cmd.Flags().Set("label", "backend"); err != nil {
+		t.Fatalf("Set(label): %v", err)
+	}
+	w, buf := bufWriter(false)
+	if err := runIssueList(cmd, nil, w); err != nil {
+		t.Fatalf("runIssueList: %v", err)
+	}
+
+	got := buf.String()
+	want := "No issues match your filters (status=in-progress, label=backend).\nTry removing --status, --label or use --all.\n"
+	if got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestListEmptyStateSuggestsCreateOnlyWhenNoIssues(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	conn := newTestDB(t)
+
+	w, buf := bufWriter(false)
+	if err := runIssueList(listCmdWithDB(conn), nil, w); err != nil {
+		t.Fatalf("runIssueList: %v", err)
+	}
+	if want := "No issues found.\nCreate one with: docket issue create\n"; buf.String() != want {
+		t.Errorf("empty database output = %q, want %q", buf.String(), want)
+	}
+
+	createIssue(t, conn, "Finished", model.StatusDone, model.PriorityLow)
+	w, buf = bufWriter(false)
+	if err := runIssueList(listCmdWithDB(conn), nil, w); err != nil {
+		t.Fatalf("runIssueList: %v", err)
+	}
+	if want := "No issues found.\nUse --all to include done issues.\n"; buf.String() != want {
+		t.Errorf("all-done output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestListAllStatuses(t *testing.T) {
+	conn := newTestDB(t)
+	open := createIssue(t, conn, "open", model.StatusTodo, model.PriorityMedium)
+	done := createIssue(t, conn, "done", model.StatusDone, model.PriorityMedium)
+
+	tests := []struct {
+		name   string
+		flag   string
+		config bool
+		want   []int
+	}{
+		{"default hides done", "", false, []int{open}},
+		{"flag includes done", "true", false, []int{open, done}},
+		{"config includes done", "", true, []int{open, done}},
+		{"flag overrides config", "false", true, []int{open}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := listCmdWithDB(conn)
+			cmd.Flags().Bool("all-statuses", false, "")
+			if tt.flag != "" {
+				cmd.Flags().Set("all-statuses", tt.flag)
+			}
+			cmd.Flags().Set("sort", "id:asc")
+			cmd.SetContext(context.WithValue(cmd.Context(), cfgKey, &config.Config{AllStatuses: tt.config}))
+			w, buf := bufWriter(true)
+			if err := runIssueList(cmd, nil, w); err != nil {
+				t.Fatalf("runIssueList: %v", err)
+			}
+			var lj listJSON
+			if err := json.Unmarshal(buf.Bytes(), &lj); err != nil {
+				t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+			}
+			var got, want []string
+			for _, issue := range lj.Data.Issues {
+				got = append(got, issue.ID)
+			}
+			for _, id := range tt.want {
+				want = append(want, model.FormatID(id))
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("issues = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestListJSON_PaginationEnvelope(t *testing.T) {
+	conn := newTestDB(t)
+	var ids []int
+	for _, title := range []string{"one", "two", "three", "four", "five"} {
+		ids = append(ids, createIssue(t, conn, title, model.StatusTodo, model.PriorityMedium))
+	}
+
+	cmd := listCmdWithDB(conn)
+	cmd.Flags().Set("sort", "id:asc")
+	cmd.Flags().Set("limit", "2")
+	cmd.Flags().Set("offset", "3")
+	w, buf := bufWriter(true)
+	if err := runIssueList(cmd, nil, w); err != nil {
+		t.Fatalf("runIssueList: %v", err)
+	}
+
+	var lj listJSON
+	if err := json.Unmarshal(buf.Bytes(), &lj); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if lj.Data.Total != 5 || lj.Data.Returned != 2 || lj.Data.Offset != 3 {
+		t.Errorf("total = %d, returned = %d, offset = %d; want 5, 2, 3", lj.Data.Total, lj.Data.Returned, lj.Data.Offset)
+	}
+	if len(lj.Data.Issues) != 2 || lj.Data.Issues[0].ID != model.FormatID(ids[3]) {
+		t.Errorf("issues = %+v, want %s and %s", lj.Data.Issues, model.FormatID(ids[3]), model.FormatID(ids[4]))
+	}
+
+	// An offset without a limit still pages.
+	cmd.Flags().Set("limit", "0")
+	w, buf = bufWriter(true)
+	if err := runIssueList(cmd, nil, w); err != nil {
+		t.Fatalf("runIssueList without limit: %v", err)
+	}
+	lj = listJSON{}
+	if err := json.Unmarshal(buf.Bytes(), &lj); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if lj.Data.Total != 5 || lj.Data.Returned != 2 {
+		t.Errorf("without limit: total = %d, returned = %d; want 5, 2", lj.Data.Total, lj.Data.Returned)
+	}
+}
+
+func TestListCount(t *testing.T) {
+	conn := newTestDB(t)
+	createIssue(t, conn, "a", model.StatusTodo, model.PriorityCritical)
+	createIssue(t, conn, "b", model.StatusInProgress, model.PriorityCritical)
+	createIssue(t, conn, "c", model.StatusTodo, model.PriorityLow)
+	createIssue(t, conn, "d", model.StatusDone, model.PriorityCritical)
+
+	cmd := listCmdWithDB(conn)
+	cmd.Flags().Set("count", "true")
+	cmd.Flags().Set("priority", "critical")
+	cmd.Flags().Set("limit", "1")
+	w, buf := bufWriter(false)
+	if err := runIssueList(cmd, nil, w); err != nil {
+		t.Fatalf("runIssueList: %v", err)
+	}
+	if got := buf.String(); got != "2\n" {
+		t.Errorf("stdout = %q, want %q", got, "2\n")
+	}
+
+	w, buf = bufWriter(true)
+	if err := runIssueList(cmd, nil, w); err != nil {
+		t.Fatalf("runIssueList: %v", err)
+	}
+	var env struct {
+		Data listCountResult `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if env.Data.Total != 2 {
+		t.Errorf("total = %d, want 2", env.Data.Total)
+	}
+}
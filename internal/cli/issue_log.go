@@ -60,12 +60,12 @@ func runIssueLog(cmd *cobra.Command, args []string, w *output.Writer) error {
 
 	id, err := model.ParseID(args[0])
 	if err != nil {
-		return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+		return cmdErr(err, output.ErrValidation)
 	}
 
 	if _, err := db.GetIssue(conn, id); err != nil {
 		if errors.Is(err, db.ErrNotFound) {
-			return cmdErr(fmt.Errorf("issue %s not found", args[0]), output.ErrNotFound)
+			return issueNotFoundErr(conn, w, id)
 		}
 		return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
 	}
@@ -104,12 +104,18 @@ func runIssueLog(cmd *cobra.Command, args []string, w *output.Writer) error {
 		return nil
 	}
 
-	message := formatActivityLog(model.FormatID(id), activity)
+	layout, err := getLayout(cmd)
+	if err != nil {
+		return err
+	}
+	message := formatActivityLog(model.FormatID(id), activity, layout)
 	w.Success(result, message)
 	return nil
 }
 
-func formatActivityLog(issueID string, activity []model.Activity) string {
+// formatActivityLog renders activity as aligned rows, each description edit
+// followed by its line diff fitted according to layout.
+func formatActivityLog(issueID string, activity []model.Activity, layout render.LayoutOptions) string {
 	var lines []string
 	lines = append(lines, fmt.Sprintf("Activity for %s:", issueID))
 	lines = append(lines, "")
@@ -125,18 +131,18 @@ func formatActivityLog(issueID string, activity []model.Activity) string {
 	// Pre-compute column widths from the data.
 	timeW, actorW, fieldW := 0, 0, 0
 	type row struct {
-		ts, actor, field string
+		ts, actor, field, diff string
 	}
 	rows := make([]row, len(activity))
 	for i, a := range activity {
 		rows[i].ts = humanize.Time(a.CreatedAt)
-		rows[i].actor = a.ChangedBy
-		if rows[i].actor == "" {
-			rows[i].actor = "system"
-		}
+		rows[i].actor = a.ActorOrSystem()
+		summary, isDiff := render.ActivityDiffSummary(a)
 		switch {
 		case a.FieldChanged == "created":
 			rows[i].field = "created"
+		case isDiff:
+			rows[i].field = fmt.Sprintf("%-14s edited (%s)", a.FieldChanged, summary)
 		case a.OldValue != "" && a.NewValue != "":
 			rows[i].field = fmt.Sprintf("%-14s %s -> %s", a.FieldChanged, a.OldValue, a.NewValue)
 		case a.NewValue != "":
@@ -146,6 +152,7 @@ func formatActivityLog(issueID string, activity []model.Activity) string {
 		default:
 			rows[i].field = a.FieldChanged
 		}
+		rows[i].diff, _ = render.ActivityDiff(a, layout, "    ")
 		if len(rows[i].ts) > timeW {
 			timeW = len(rows[i].ts)
 		}
@@ -173,6 +180,9 @@ func formatActivityLog(issueID string, activity []model.Activity) string {
 			line = fmt.Sprintf("  "+timeFmt+" "+actorFmt+" "+fieldFmt, r.ts, r.actor, r.field)
 		}
 		lines = append(lines, line)
+		if r.diff != "" {
+			lines = append(lines, r.diff)
+		}
 	}
 
 	return strings.Join(lines, "\n")
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/config"
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// mergeResult is the JSON wire format for the merge command output.
+type mergeResult struct {
+	Merged              string           `json:"merged"`
+	Into                string           `json:"into"`
+	Comments            []int            `json:"comments"`
+	Files               []string         `json:"files"`
+	Labels              []string         `json:"labels"`
+	SubIssues           []string         `json:"sub_issues"`
+	Relations           []model.Relation `json:"relations"`
+	DroppedRelations    []model.Relation `json:"dropped_relations"`
+	DescriptionAppended bool             `json:"description_appended"`
+	DuplicateRelationID int              `json:"duplicate_relation_id"`
+	// Disposition is "closed" or "trashed".
+	Disposition string `json:"disposition"`
+}
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge <id> --into <id>",
+	Short: "Merge a duplicate issue into another",
+	Long: `Merge a duplicate issue into another in a single step.
+
+The merged issue's comments, attached files, labels and sub-issues move to
+the issue named by --into, its relations are rewritten to point there
+(dropping any that would become self-referential or duplicate an existing
+one), and its description is appended under a "Merged from" heading. A
+duplicates relation is recorded for history and the merged issue is closed,
+or moved to the trash with --delete.
+
+  docket issue merge DKT-12 --into DKT-7`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runIssueMerge(cmd, args, getWriter(cmd))
+	},
+}
+
+func runIssueMerge(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	loserID, err := model.ParseID(args[0])
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+	into, _ := cmd.Flags().GetString("into")
+	if into == "" {
+		return cmdErr(fmt.Errorf("--into is required"), output.ErrValidation)
+	}
+	winnerID, err := model.ParseID(into)
+	if err != nil {
+		return cmdErr(fmt.Errorf("invalid --into issue ID: %w", err), output.ErrValidation)
+	}
+	trash, _ := cmd.Flags().GetBool("delete")
+
+	merged, err := db.MergeIssue(conn, loserID, winnerID, db.MergeOptions{Trash: trash, ChangedBy: config.DefaultAuthor()})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrNotFound):
+			return cmdErr(fmt.Errorf("issue %s or %s not found", model.FormatID(loserID), model.FormatID(winnerID)), output.ErrNotFound)
+		case errors.Is(err, db.ErrValidation):
+			return cmdErr(err, output.ErrValidation)
+		case errors.Is(err, db.ErrConflict):
+			return cmdErr(err, output.ErrConflict)
+		}
+		return cmdErr(fmt.Errorf("merging issues: %w", err), output.ErrGeneral)
+	}
+
+	result := newMergeResult(loserID, winnerID, merged)
+	if w.JSONMode {
+		w.Success(result, "")
+		return nil
+	}
+	w.Success(result, mergeSummary(result))
+	return nil
+}
+
+// newMergeResult converts a db.MergeResult to its JSON wire format, using
+// empty lists rather than null for nothing transferred.
+func newMergeResult(loserID, winnerID int, m db.MergeResult) mergeResult {
+	result := mergeResult{
+		Merged:              model.FormatID(loserID),
+		Into:                model.FormatID(winnerID),
+		Comments:            orEmpty(m.Comments),
+		Files:               orEmpty(m.Files),
+		Labels:              orEmpty(m.Labels),
+		SubIssues:           make([]string, 0, len(m.SubIssues)),
+		Relations:           orEmpty(m.Relations),
+		DroppedRelations:    orEmpty(m.DroppedRelations),
+		DescriptionAppended: m.DescriptionAppended,
+		DuplicateRelationID: m.DuplicateRelationID,
+		Disposition:         "closed",
+	}
+	for _, id := range m.SubIssues {
+		result.SubIssues = append(result.SubIssues, model.FormatID(id))
+	}
+	if m.Trashed {
+		result.Disposition = "trashed"
+	}
+	return result
+}
+
+// orEmpty returns s, or an empty slice when s is nil.
+func orEmpty[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+// mergeSummary describes a merge in one human-readable line.
+func mergeSummary(r mergeResult) string {
+	var moved []string
+	for _, part := range []struct {
+		n    int
+		noun string
+	}{
+		{len(r.Comments), "comment(s)"},
+		{len(r.Files), "file(s)"},
+		{len(r.Labels), "label(s)"},
+		{len(r.SubIssues), "sub-issue(s)"},
+		{len(r.Relations), "relation(s)"},
+	} {
+		if part.n > 0 {
+			moved = append(moved, fmt.Sprintf("%d %s", part.n, part.noun))
+		}
+	}
+	if r.DescriptionAppended {
+		moved = append(moved, "description")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Merged %s into %s", r.Merged, r.Into)
+	if len(moved) > 0 {
+		fmt.Fprintf(&b, ": moved %s", strings.Join(moved, ", "))
+	}
+	if n := len(r.DroppedRelations); n > 0 {
+		fmt.Fprintf(&b, "; dropped %d redundant relation(s)", n)
+	}
+	fmt.Fprintf(&b, "; %s %s", r.Merged, r.Disposition)
+	return b.String()
+}
+
+func init() {
+	mergeCmd.Flags().String("into", "", "Issue to merge into (required)")
+	mergeCmd.Flags().Bool("delete", false, "Move the merged issue to the trash instead of closing it")
+	mergeCmd.MarkFlagRequired("into")
+	issueCmd.AddCommand(mergeCmd)
+}
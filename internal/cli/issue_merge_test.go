@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func mergeCmdWithDB(conn *sql.DB, into string, del bool) *cobra.Command {
+	cmd := cmdWithDB(conn)
+	cmd.Flags().String("into", "", "")
+	cmd.Flags().Bool("delete", false, "")
+	cmd.Flags().Set("into", into)
+	if del {
+		cmd.Flags().Set("delete", "true")
+	}
+	return cmd
+}
+
+func TestIssueMergeJSON(t *testing.T) {
+	conn := newTestDB(t)
+	winner := createIssue(t, conn, "crash on save", model.StatusTodo, model.PriorityHigh)
+	loser := createIssueWithFile(t, conn, "save crashes", "internal/save.go")
+	if _, err := db.CreateComment(conn, &model.Comment{IssueID: loser, Body: "stack trace"}); err != nil {
+		t.Fatalf("CreateComment: %v", err)
+	}
+
+	w, buf := bufWriter(true)
+	if err := runIssueMerge(mergeCmdWithDB(conn, model.FormatID(winner), true), []string{model.FormatID(loser)}, w); err != nil {
+		t.Fatalf("runIssueMerge: %v", err)
+	}
+
+	var env struct {
+		Data mergeResult `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	got := env.Data
+	if got.Merged != model.FormatID(loser) || got.Into != model.FormatID(winner) || got.Disposition != "trashed" {
+		t.Errorf("result = %+v", got)
+	}
+	if len(got.Comments) != 1 || len(got.Files) != 1 || got.Files[0] != "internal/save.go" {
+		t.Errorf("transferred comments %v, files %v", got.Comments, got.Files)
+	}
+	if got.Labels == nil || got.SubIssues == nil || got.DroppedRelations == nil {
+		t.Errorf("empty transfers should be [] not null: %s", buf.String())
+	}
+	if got.DuplicateRelationID == 0 {
+		t.Error("no duplicates relation reported")
+	}
+}
+
+func TestIssueMergeRejectsDescendant(t *testing.T) {
+	conn := newTestDB(t)
+	parent := createIssue(t, conn, "parent", model.StatusTodo, model.PriorityHigh)
+	child, err := db.CreateIssue(conn, &model.Issue{Title: "child", ParentID: &parent, Status: model.StatusTodo, Priority: model.PriorityNone, Kind: model.IssueKindTask}, nil, nil)
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+
+	w, _ := bufWriter(true)
+	err = runIssueMerge(mergeCmdWithDB(conn, model.FormatID(child), false), []string{model.FormatID(parent)}, w)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Fatalf("error = %v, want a validation error", err)
+	}
+}
+
+func TestMergeSummary(t *testing.T) {
+	r := mergeResult{
+		Merged: "DKT-2", Into: "DKT-1",
+		Comments: []int{4, 5}, Labels: []string{"ui"},
+		DroppedRelations: []model.Relation{{}}, DescriptionAppended: true,
+		Disposition: "closed",
+	}
+	want := "Merged DKT-2 into DKT-1: moved 2 comment(s), 1 label(s), description; dropped 1 redundant relation(s); DKT-2 closed"
+	if got := mergeSummary(r); got != want {
+		t.Errorf("mergeSummary = %q, want %q", got, want)
+	}
+}
@@ -13,15 +13,34 @@ import (
 
 var moveCmd = &cobra.Command{
 	Use:   "move <id> <status>",
-	Short: "Move an issue to a new status",
-	Args:  cobra.ExactArgs(2),
+	Short: "Move an issue to a new status, or among its siblings",
+	Long: `Move an issue to a new status:
+
+  docket issue move DKT-7 done
+
+or, with --before or --after, to a new place among the sub-issues of its
+parent, leaving its status alone:
+
+  docket issue move DKT-7 --before DKT-12
+
+See 'docket issue reorder' to set the order of all sub-issues at once.`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		w := getWriter(cmd)
 		conn := getDB(cmd)
 
+		before, _ := cmd.Flags().GetString("before")
+		after, _ := cmd.Flags().GetString("after")
+		if before != "" || after != "" {
+			return runMoveAmongSiblings(cmd, args, w, before, after)
+		}
+		if len(args) != 2 {
+			return cmdErr(fmt.Errorf("a status is required unless --before or --after is given"), output.ErrValidation)
+		}
+
 		id, err := model.ParseID(args[0])
 		if err != nil {
-			return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+			return cmdErr(err, output.ErrValidation)
 		}
 
 		newStatus := model.Status(args[1])
@@ -32,7 +51,7 @@ var moveCmd = &cobra.Command{
 		issue, err := db.GetIssue(conn, id)
 		if err != nil {
 			if errors.Is(err, db.ErrNotFound) {
-				return cmdErr(fmt.Errorf("issue %s not found", args[0]), output.ErrNotFound)
+				return issueNotFoundErr(conn, w, id)
 			}
 			return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
 		}
@@ -49,6 +68,9 @@ var moveCmd = &cobra.Command{
 		}
 
 		if err := db.UpdateIssue(conn, id, map[string]interface{}{"status": string(newStatus)}, config.DefaultAuthor()); err != nil {
+			if errors.Is(err, db.ErrValidation) {
+				return cmdErr(err, output.ErrValidation)
+			}
 			return cmdErr(fmt.Errorf("updating issue: %w", err), output.ErrGeneral)
 		}
 
@@ -57,12 +79,67 @@ var moveCmd = &cobra.Command{
 			return cmdErr(fmt.Errorf("fetching updated issue: %w", err), output.ErrGeneral)
 		}
 
-		w.Success(issue, fmt.Sprintf("Moved %s: %s \u2192 %s", model.FormatID(id), oldStatus, newStatus))
+		message := fmt.Sprintf("Moved %s: %s \u2192 %s", model.FormatID(id), oldStatus, newStatus)
+		if newStatus == model.StatusDone {
+			return writeDoneResult(conn, w, issue, message)
+		}
+		w.Success(issue, message)
 
 		return nil
 	},
 }
 
+// runMoveAmongSiblings moves an issue just before or after a sibling.
+func runMoveAmongSiblings(cmd *cobra.Command, args []string, w *output.Writer, before, after string) error {
+	conn := getDB(cmd)
+
+	if len(args) != 1 {
+		return cmdErr(fmt.Errorf("--before and --after take no status argument"), output.ErrValidation)
+	}
+	if before != "" && after != "" {
+		return cmdErr(fmt.Errorf("--before and --after are mutually exclusive"), output.ErrValidation)
+	}
+
+	id, err := model.ParseID(args[0])
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+	where, move := "before", db.MoveIssueBefore
+	raw := before
+	if after != "" {
+		where, move, raw = "after", db.MoveIssueAfter, after
+	}
+	siblingID, err := model.ParseID(raw)
+	if err != nil {
+		return cmdErr(fmt.Errorf("--%s: %w", where, err), output.ErrValidation)
+	}
+
+	for _, issueID := range []int{id, siblingID} {
+		if _, err := db.GetIssue(conn, issueID); err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				return issueNotFoundErr(conn, w, issueID)
+			}
+			return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
+		}
+	}
+
+	if err := move(conn, id, siblingID, config.DefaultAuthor()); err != nil {
+		if errors.Is(err, db.ErrValidation) {
+			return cmdErr(err, output.ErrValidation)
+		}
+		return cmdErr(fmt.Errorf("reordering sub-issues: %w", err), output.ErrGeneral)
+	}
+
+	issue, err := db.GetIssue(conn, id)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching updated issue: %w", err), output.ErrGeneral)
+	}
+	w.Success(issue, fmt.Sprintf("Moved %s %s %s", model.FormatID(id), where, model.FormatID(siblingID)))
+	return nil
+}
+
 func init() {
+	moveCmd.Flags().String("before", "", "Move the issue just before this sibling")
+	moveCmd.Flags().String("after", "", "Move the issue just after this sibling")
 	issueCmd.AddCommand(moveCmd)
 }
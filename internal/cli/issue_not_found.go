@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+// maxIDSuggestions caps the near-miss issues offered when an ID is not found.
+const maxIDSuggestions = 3
+
+// idCandidate is a near-miss issue offered when an ID is not found.
+type idCandidate struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// notFoundDetails is the data attached to the JSON error envelope when an
+// issue ID is not found.
+type notFoundDetails struct {
+	Candidates []idCandidate `json:"candidates"`
+}
+
+// issueNotFoundErr reports that issue id does not exist, suggesting live
+// issues whose IDs it is a plausible typo of (see model.NearMissIDs). Human
+// output names the suggestions in the message; JSON output keeps the message
+// plain and lists them only in the candidates array.
+func issueNotFoundErr(conn *sql.DB, w *output.Writer, id int) *CmdError {
+	candidates := suggestIssueIDs(conn, id)
+	msg := fmt.Sprintf("issue %s not found", model.FormatID(id))
+	if len(candidates) > 0 && !w.JSONMode {
+		names := make([]string, len(candidates))
+		for i, c := range candidates {
+			names[i] = fmt.Sprintf("%s (%s)", c.ID, c.Title)
+		}
+		msg += ". Did you mean " + joinOr(names) + "?"
+	}
+	return &CmdError{
+		Err:  errors.New(msg),
+		Code: output.ErrNotFound,
+		Data: notFoundDetails{Candidates: candidates},
+	}
+}
+
+// suggestIssueIDs returns up to maxIDSuggestions live issues whose IDs are
+// near misses of id, most plausible first. Lookup failures yield no
+// suggestions rather than masking the not-found error.
+func suggestIssueIDs(conn *sql.DB, id int) []idCandidate {
+	nearby := model.NearMissIDs(id)
+	titles, err := db.GetIssueTitles(conn, nearby)
+	if err != nil {
+		return []idCandidate{}
+	}
+	candidates := make([]idCandidate, 0, maxIDSuggestions)
+	for _, n := range nearby {
+		title, ok := titles[n]
+		if !ok {
+			continue
+		}
+		candidates = append(candidates, idCandidate{ID: model.FormatID(n), Title: title})
+		if len(candidates) == maxIDSuggestions {
+			break
+		}
+	}
+	return candidates
+}
+
+// joinOr joins items as "a", "a or b" or "a, b or c".
+func joinOr(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " or " + items[len(items)-1]
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+func TestIssueNotFoundErrSuggestsNearMisses(t *testing.T) {
+	conn := newTestDB(t)
+	for i := 1; i <= 12; i++ {
+		createIssue(t, conn, "filler", model.StatusTodo, model.PriorityLow)
+	}
+	if err := db.UpdateIssue(conn, 12, map[string]interface{}{"title": "Fix login"}, ""); err != nil {
+		t.Fatalf("renaming DKT-12: %v", err)
+	}
+
+	human, _ := bufWriter(false)
+	ce := issueNotFoundErr(conn, human, 112)
+	if want := "issue DKT-112 not found. Did you mean DKT-12 (Fix login) or DKT-11 (filler)?"; ce.Error() != want {
+		t.Errorf("human message = %q, want %q", ce.Error(), want)
+	}
+	if ce.Code != output.ErrNotFound {
+		t.Errorf("code = %s, want %s", ce.Code, output.ErrNotFound)
+	}
+
+	jsonW, _ := bufWriter(true)
+	ce = issueNotFoundErr(conn, jsonW, 112)
+	if want := "issue DKT-112 not found"; ce.Error() != want {
+		t.Errorf("JSON message = %q, want %q", ce.Error(), want)
+	}
+	data, err := json.Marshal(ce.Data)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if want := `{"candidates":[{"id":"DKT-12","title":"Fix login"},{"id":"DKT-11","title":"filler"}]}`; string(data) != want {
+		t.Errorf("data = %s, want %s", data, want)
+	}
+
+	ce = issueNotFoundErr(conn, human, 500)
+	if want := "issue DKT-500 not found"; ce.Error() != want {
+		t.Errorf("message without near misses = %q, want %q", ce.Error(), want)
+	}
+}
+
+func TestJoinOr(t *testing.T) {
+	for _, tt := range []struct {
+		items []string
+		want  string
+	}{
+		{nil, ""},
+		{[]string{"a"}, "a"},
+		{[]string{"a", "b"}, "a or b"},
+		{[]string{"a", "b", "c"}, "a, b or c"},
+	} {
+		if got := joinOr(tt.items); got != tt.want {
+			t.Errorf("joinOr(%q) = %q, want %q", tt.items, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/huh"
+	"golang.org/x/term"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+// stdinIsTerminal reports whether the user can answer a prompt. Tests
+// replace it to reach the picker without a terminal.
+var stdinIsTerminal = func() bool { return term.IsTerminal(int(os.Stdin.Fd())) }
+
+// issuePicker lets the user choose one of issues and returns its ID, or
+// huh.ErrUserAborted. Tests replace it to inject a selection.
+var issuePicker = pickIssueForm
+
+// pickIssueForm shows a searchable list of issues; typing "/" filters it.
+func pickIssueForm(issues []*model.Issue) (int, error) {
+	options := make([]huh.Option[int], len(issues))
+	for i, issue := range issues {
+		label := fmt.Sprintf("%s  %s  [%s]", model.FormatID(issue.ID), issue.Title, issue.Status)
+		options[i] = huh.NewOption(label, issue.ID)
+	}
+
+	var id int
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[int]().
+				Title("Select an issue").
+				Options(options...).
+				Filtering(true).
+				Height(15).
+				Value(&id),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// issueArgs returns args unchanged when they name an issue. Without an ID,
+// a human-mode command at a terminal lets the user pick one of the open
+// issues instead, returning it as the only argument; elsewhere an ID is
+// required. ok is false when the user cancelled the picker, which has
+// already been reported.
+func issueArgs(conn *sql.DB, w *output.Writer, args []string) (picked []string, ok bool, err error) {
+	if len(args) > 0 {
+		return args, true, nil
+	}
+	if w.JSONMode || !stdinIsTerminal() {
+		return nil, false, cmdErr(fmt.Errorf("an issue ID is required"), output.ErrValidation)
+	}
+
+	issues, _, err := db.ListIssues(conn, db.ListOptions{PinnedFirst: true})
+	if err != nil {
+		return nil, false, cmdErr(fmt.Errorf("listing issues: %w", err), output.ErrGeneral)
+	}
+	if len(issues) == 0 {
+		return nil, false, cmdErr(fmt.Errorf("no open issues to choose from; pass an issue ID"), output.ErrValidation)
+	}
+
+	id, err := issuePicker(issues)
+	if err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			w.Info("Cancelled.")
+			return nil, false, nil
+		}
+		return nil, false, cmdErr(fmt.Errorf("interactive form failed: %w", err), output.ErrGeneral)
+	}
+	return []string{model.FormatID(id)}, true, nil
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/huh"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+// fakePicker stands in for the terminal, picking the issue titled title and
+// recording the IDs it was offered.
+func fakePicker(t *testing.T, title string, offered *[]int) {
+	t.Helper()
+	oldTTY, oldPicker := stdinIsTerminal, issuePicker
+	t.Cleanup(func() { stdinIsTerminal, issuePicker = oldTTY, oldPicker })
+
+	stdinIsTerminal = func() bool { return true }
+	issuePicker = func(issues []*model.Issue) (int, error) {
+		for _, issue := range issues {
+			*offered = append(*offered, issue.ID)
+		}
+		for _, issue := range issues {
+			if issue.Title == title {
+				return issue.ID, nil
+			}
+		}
+		return 0, huh.ErrUserAborted
+	}
+}
+
+func TestIssueArgs_PickedIDFlowsIntoShow(t *testing.T) {
+	conn := newTestDB(t)
+	createIssue(t, conn, "First", model.StatusTodo, model.PriorityLow)
+	want := createIssue(t, conn, "Second", model.StatusInProgress, model.PriorityHigh)
+	createIssue(t, conn, "Finished", model.StatusDone, model.PriorityLow)
+
+	var offered []int
+	fakePicker(t, "Second", &offered)
+
+	w, buf := bufWriter(false)
+	args, ok, err := issueArgs(conn, w, nil)
+	if err != nil || !ok {
+		t.Fatalf("issueArgs = %v, %v, %v; want a picked ID", args, ok, err)
+	}
+	if len(offered) != 2 {
+		t.Errorf("picker offered %v, want the two open issues", offered)
+	}
+	if len(args) != 1 || args[0] != model.FormatID(want) {
+		t.Fatalf("issueArgs = %v, want [%s]", args, model.FormatID(want))
+	}
+
+	w, buf = bufWriter(true)
+	if err := runIssueShow(cmdWithDB(conn), args, w); err != nil {
+		t.Fatalf("runIssueShow: %v", err)
+	}
+	var got struct {
+		Data struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if got.Data.ID != model.FormatID(want) || got.Data.Title != "Second" {
+		t.Errorf("show = %+v, want the picked issue", got.Data)
+	}
+}
+
+func TestIssueArgs_CancelledPicker(t *testing.T) {
+	conn := newTestDB(t)
+	createIssue(t, conn, "Only", model.StatusTodo, model.PriorityLow)
+
+	var offered []int
+	fakePicker(t, "nothing matches", &offered)
+
+	w, _ := bufWriter(false)
+	if args, ok, err := issueArgs(conn, w, nil); err != nil || ok || args != nil {
+		t.Errorf("issueArgs = %v, %v, %v; want a quiet cancel", args, ok, err)
+	}
+}
+
+func TestIssueArgs_RequiresIDWhenNotInteractive(t *testing.T) {
+	conn := newTestDB(t)
+	createIssue(t, conn, "Only", model.StatusTodo, model.PriorityLow)
+
+	var offered []int
+	fakePicker(t, "Only", &offered)
+
+	// JSON mode never prompts, even at a terminal.
+	w, _ := bufWriter(true)
+	_, _, err := issueArgs(conn, w, nil)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Errorf("JSON mode error = %v, want a validation error", err)
+	}
+
+	stdinIsTerminal = func() bool { return false }
+	w, _ = bufWriter(false)
+	if _, _, err := issueArgs(conn, w, nil); !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Errorf("non-TTY error = %v, want a validation error", err)
+	}
+	if len(offered) != 0 {
+		t.Errorf("picker was shown %v without a terminal", offered)
+	}
+
+	// An explicit ID is passed through untouched.
+	if args, ok, err := issueArgs(conn, w, []string{"DKT-9"}); err != nil || !ok || args[0] != "DKT-9" {
+		t.Errorf("issueArgs(DKT-9) = %v, %v, %v", args, ok, err)
+	}
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ALT-F4-LLC/docket/internal/config"
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var pinCmd = &cobra.Command{
+	Use:   "pin <id>",
+	Short: "Keep an issue at the top of lists and boards",
+	Long: `Keep an issue at the top of lists and boards, whatever sort is chosen:
+
+  docket issue pin DKT-5
+
+Pinned issues come first in 'docket issue list' and lead their board column,
+where they are shown even when the column is cut off at --limit. Use
+'docket issue unpin' to let the issue sort normally again.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPin(cmd, args, getWriter(cmd), true)
+	},
+}
+
+var unpinCmd = &cobra.Command{
+	Use:   "unpin <id>",
+	Short: "Let a pinned issue sort normally again",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPin(cmd, args, getWriter(cmd), false)
+	},
+}
+
+// runPin pins or unpins the issue named by args[0].
+func runPin(cmd *cobra.Command, args []string, w *output.Writer, pinned bool) error {
+	conn := getDB(cmd)
+
+	id, err := model.ParseID(args[0])
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+
+	set, verb := db.PinIssue, "Pinned"
+	if !pinned {
+		set, verb = db.UnpinIssue, "Unpinned"
+	}
+	if err := set(conn, id, config.DefaultAuthor()); err != nil {
+		return pinErr(conn, w, id, err)
+	}
+
+	issue, err := db.GetIssue(conn, id)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching updated issue: %w", err), output.ErrGeneral)
+	}
+	w.Success(issue, fmt.Sprintf("%s %s: %s", verb, model.FormatID(id), issue.Title))
+	return nil
+}
+
+// pinErr maps an error from db.PinIssue or db.UnpinIssue to a command error.
+func pinErr(conn *sql.DB, w *output.Writer, id int, err error) error {
+	if errors.Is(err, db.ErrNotFound) {
+		return issueNotFoundErr(conn, w, id)
+	}
+	return cmdErr(fmt.Errorf("pinning issue: %w", err), output.ErrGeneral)
+}
+
+func init() {
+	issueCmd.AddCommand(pinCmd)
+	issueCmd.AddCommand(unpinCmd)
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+func TestPin_ListsPinnedFirst(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	conn := newTestDB(t)
+	createIssue(t, conn, "Urgent work", model.StatusTodo, model.PriorityCritical)
+	pinned := createIssue(t, conn, "Pinned work", model.StatusTodo, model.PriorityLow)
+
+	w, _ := bufWriter(false)
+	if err := runPin(cmdWithDB(conn), []string{model.FormatID(pinned)}, w, true); err != nil {
+		t.Fatalf("runPin: %v", err)
+	}
+
+	w, buf := bufWriter(false)
+	if err := runIssueList(listCmdWithDB(conn), nil, w); err != nil {
+		t.Fatalf("runIssueList: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "[pinned] Pinned work") {
+		t.Errorf("list output should mark the pinned issue:\n%s", out)
+	}
+	if strings.Index(out, "Pinned work") > strings.Index(out, "Urgent work") {
+		t.Errorf("pinned issue should come first:\n%s", out)
+	}
+
+	list := listCmdWithDB(conn)
+	if err := list.Flags().Set("pinned", "true"); err != nil {
+		t.Fatalf("Set(pinned): %v", err)
+	}
+	w, buf = bufWriter(false)
+	if err := runIssueList(list, nil, w); err != nil {
+		t.Fatalf("runIssueList: %v", err)
+	}
+	if out := buf.String(); strings.Contains(out, "Urgent work") || !strings.Contains(out, "Pinned work") {
+		t.Errorf("list --pinned output should only show the pinned issue:\n%s", out)
+	}
+
+	w, _ = bufWriter(false)
+	if err := runPin(cmdWithDB(conn), []string{model.FormatID(pinned)}, w, false); err != nil {
+		t.Fatalf("runPin(unpin): %v", err)
+	}
+	w, buf = bufWriter(false)
+	if err := runIssueList(listCmdWithDB(conn), nil, w); err != nil {
+		t.Fatalf("runIssueList: %v", err)
+	}
+	if out := buf.String(); strings.Contains(out, "[pinned]") {
+		t.Errorf("list output should not mark an unpinned issue:\n%s", out)
+	}
+}
+
+func TestPin_MissingIssueIsNotFound(t *testing.T) {
+	conn := newTestDB(t)
+
+	w, _ := bufWriter(false)
+	err := runPin(cmdWithDB(conn), []string{"DKT-99"}, w, true)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrNotFound {
+		t.Errorf("runPin on a missing issue: err = %v, want a not-found CmdError", err)
+	}
+}
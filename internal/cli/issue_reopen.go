@@ -21,13 +21,13 @@ var reopenCmd = &cobra.Command{
 
 		id, err := model.ParseID(args[0])
 		if err != nil {
-			return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+			return cmdErr(err, output.ErrValidation)
 		}
 
 		issue, err := db.GetIssue(conn, id)
 		if err != nil {
 			if errors.Is(err, db.ErrNotFound) {
-				return cmdErr(fmt.Errorf("issue %s not found", args[0]), output.ErrNotFound)
+				return issueNotFoundErr(conn, w, id)
 			}
 			return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
 		}
@@ -42,6 +42,9 @@ var reopenCmd = &cobra.Command{
 		}
 
 		if err := db.UpdateIssue(conn, id, map[string]interface{}{"status": "backlog"}, config.DefaultAuthor()); err != nil {
+			if errors.Is(err, db.ErrValidation) {
+				return cmdErr(err, output.ErrValidation)
+			}
 			return cmdErr(fmt.Errorf("updating issue: %w", err), output.ErrGeneral)
 		}
 
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/config"
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var reorderCmd = &cobra.Command{
+	Use:   "reorder <id>",
+	Short: "Set the order of an issue's sub-issues",
+	Long: `Set the order of an issue's sub-issues, which otherwise follow creation
+order:
+
+  docket issue reorder DKT-5 --children DKT-9,DKT-7,DKT-12
+
+The listed sub-issues come first, in the given order; any others follow in
+their current order. A range such as DKT-20..DKT-24 lists each of its IDs in
+turn. Use 'docket issue move DKT-7 --before DKT-12' to move a single
+sub-issue.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runReorder(cmd, args, getWriter(cmd))
+	},
+}
+
+func runReorder(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	parentID, err := model.ParseID(args[0])
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+
+	children, _ := cmd.Flags().GetStringSlice("children")
+	if len(children) == 0 {
+		return cmdErr(fmt.Errorf("--children is required"), output.ErrValidation)
+	}
+	for i, raw := range children {
+		children[i] = strings.TrimSpace(raw)
+	}
+	childIDs, err := model.ParseIDList(children)
+	if err != nil {
+		return cmdErr(fmt.Errorf("--children: %w", err), output.ErrValidation)
+	}
+
+	if err := db.SetChildOrder(conn, parentID, childIDs, config.DefaultAuthor()); err != nil {
+		switch {
+		case errors.Is(err, db.ErrNotFound):
+			return issueNotFoundErr(conn, w, parentID)
+		case errors.Is(err, db.ErrValidation):
+			return cmdErr(err, output.ErrValidation)
+		default:
+			return cmdErr(fmt.Errorf("reordering sub-issues: %w", err), output.ErrGeneral)
+		}
+	}
+
+	subIssues, err := db.GetSubIssues(conn, parentID)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching sub-issues: %w", err), output.ErrGeneral)
+	}
+	ids := make([]string, len(subIssues))
+	for i, sub := range subIssues {
+		ids[i] = model.FormatID(sub.ID)
+	}
+	w.Success(subIssues, fmt.Sprintf("Reordered sub-issues of %s: %s", model.FormatID(parentID), strings.Join(ids, ", ")))
+	return nil
+}
+
+func init() {
+	reorderCmd.Flags().StringSlice("children", nil, "Sub-issue IDs or ranges in their new order (comma-separated)")
+	issueCmd.AddCommand(reorderCmd)
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+// createChild creates a todo sub-issue of parentID and returns its ID.
+func createChild(t *testing.T, conn *sql.DB, title string, parentID int) int {
+	t.Helper()
+	id, err := db.CreateIssue(conn, &model.Issue{
+		Title:    title,
+		Status:   model.StatusTodo,
+		Priority: model.PriorityNone,
+		Kind:     model.IssueKindTask,
+		ParentID: &parentID,
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("CreateIssue(%q): %v", title, err)
+	}
+	return id
+}
+
+// assertInOrder fails unless each of want appears in out, in order.
+func assertInOrder(t *testing.T, out string, want ...string) {
+	t.Helper()
+	last := -1
+	for _, s := range want {
+		i := strings.Index(out, s)
+		if i < 0 || i < last {
+			t.Fatalf("expected %q in order in:\n%s", want, out)
+		}
+		last = i
+	}
+}
+
+func TestReorder_TreeListFollowsOrder(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	conn := newTestDB(t)
+	parent := createIssue(t, conn, "Runbook", model.StatusTodo, model.PriorityNone)
+	createChild(t, conn, "Drain traffic", parent)
+	backup := createChild(t, conn, "Take backup", parent)
+	upgrade := createChild(t, conn, "Upgrade database", parent)
+
+	cmd := cmdWithDB(conn)
+	cmd.Flags().StringSlice("children", nil, "")
+	if err := cmd.Flags().Set("children", model.FormatID(upgrade)+","+model.FormatID(backup)); err != nil {
+		t.Fatalf("Set(children): %v", err)
+	}
+	w, buf := bufWriter(false)
+	if err := runReorder(cmd, []string{model.FormatID(parent)}, w); err != nil {
+		t.Fatalf("runReorder: %v", err)
+	}
+	assertInOrder(t, buf.String(), model.FormatID(upgrade), model.FormatID(backup))
+
+	list := listCmdWithDB(conn)
+	if err := list.Flags().Set("tree", "true"); err != nil {
+		t.Fatalf("Set(tree): %v", err)
+	}
+	w, buf = bufWriter(false)
+	if err := runIssueList(list, nil, w); err != nil {
+		t.Fatalf("runIssueList: %v", err)
+	}
+	assertInOrder(t, buf.String(), "Runbook", "Upgrade database", "Take backup", "Drain traffic")
+}
+
+func TestReorder_RejectsNonChild(t *testing.T) {
+	conn := newTestDB(t)
+	parent := createIssue(t, conn, "Runbook", model.StatusTodo, model.PriorityNone)
+	createChild(t, conn, "Step", parent)
+	stranger := createIssue(t, conn, "Unrelated", model.StatusTodo, model.PriorityNone)
+
+	cmd := cmdWithDB(conn)
+	cmd.Flags().StringSlice("children", nil, "")
+	if err := cmd.Flags().Set("children", model.FormatID(stranger)); err != nil {
+		t.Fatalf("Set(children): %v", err)
+	}
+	w, _ := bufWriter(false)
+	err := runReorder(cmd, []string{model.FormatID(parent)}, w)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+}
+
+func TestMoveAmongSiblings(t *testing.T) {
+	conn := newTestDB(t)
+	parent := createIssue(t, conn, "Runbook", model.StatusTodo, model.PriorityNone)
+	first := createChild(t, conn, "First", parent)
+	second := createChild(t, conn, "Second", parent)
+	third := createChild(t, conn, "Third", parent)
+
+	cmd := cmdWithDB(conn)
+	w, buf := bufWriter(false)
+	if err := runMoveAmongSiblings(cmd, []string{model.FormatID(third)}, w, model.FormatID(first), ""); err != nil {
+		t.Fatalf("runMoveAmongSiblings: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Moved "+model.FormatID(third)+" before "+model.FormatID(first)) {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+
+	subs, err := db.GetSubIssues(conn, parent)
+	if err != nil {
+		t.Fatalf("GetSubIssues: %v", err)
+	}
+	var got []int
+	for _, sub := range subs {
+		got = append(got, sub.ID)
+	}
+	if want := []int{third, first, second}; !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+
+	err = runMoveAmongSiblings(cmd, []string{model.FormatID(third), "done"}, w, model.FormatID(first), "")
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Errorf("status with --before: expected a validation error, got %v", err)
+	}
+}
@@ -20,37 +20,44 @@ import (
 )
 
 // showResult composes the issue fields with additional detail fields
-// (sub-issues, relations, comments, activity) into a single flat JSON object.
+// (sub-issues, relations, references, comments, activity) into a single flat JSON object.
 type showResult struct {
-	Issue           *model.Issue     `json:"-"`
-	SubIssues       []*model.Issue   `json:"sub_issues"`
-	Relations       []model.Relation `json:"relations"`
-	LinkedProposals []model.Proposal `json:"-"`
-	Comments        []*model.Comment `json:"comments"`
-	Activity        []model.Activity `json:"activity"`
+	Issue           *model.Issue           `json:"-"`
+	SubIssues       []*model.Issue         `json:"sub_issues"`
+	Relations       []model.Relation       `json:"relations"`
+	References      []model.IssueReference `json:"references"`
+	LinkedProposals []model.Proposal       `json:"-"`
+	Comments        []*model.Comment       `json:"comments"`
+	Activity        []model.Activity       `json:"activity"`
 }
 
 // showResultJSON is the wire format that explicitly lists all fields,
 // avoiding the fragile marshal-unmarshal-remarshal pattern.
 type showResultJSON struct {
-	ID              string           `json:"id"`
-	ParentID        *string          `json:"parent_id,omitempty"`
-	Title           string           `json:"title"`
-	Description     string           `json:"description"`
-	Status          string           `json:"status"`
-	Priority        string           `json:"priority"`
-	Kind            string           `json:"kind"`
-	Assignee        string           `json:"assignee"`
-	Labels          []string         `json:"labels"`
-	Files           []string         `json:"files"`
-	Docs            []model.DocRef   `json:"docs"`
-	CreatedAt       string           `json:"created_at"`
-	UpdatedAt       string           `json:"updated_at"`
-	SubIssues       []*model.Issue   `json:"sub_issues"`
-	Relations       []model.Relation `json:"relations"`
-	LinkedProposals []string         `json:"linked_proposals"`
-	Comments        []*model.Comment `json:"comments"`
-	Activity        []model.Activity `json:"activity"`
+	ID              string                 `json:"id"`
+	ParentID        *string                `json:"parent_id,omitempty"`
+	Title           string                 `json:"title"`
+	Description     string                 `json:"description"`
+	Status          string                 `json:"status"`
+	Priority        string                 `json:"priority"`
+	Kind            string                 `json:"kind"`
+	Assignee        string                 `json:"assignee"`
+	Labels          []string               `json:"labels"`
+	Files           []string               `json:"files"`
+	Docs            []model.DocRef         `json:"docs"`
+	Links           []model.IssueLink      `json:"links"`
+	CreatedAt       string                 `json:"created_at"`
+	UpdatedAt       string                 `json:"updated_at"`
+	StartedAt       *string                `json:"started_at,omitempty"`
+	CompletedAt     *string                `json:"completed_at,omitempty"`
+	SnoozedUntil    *string                `json:"snoozed_until,omitempty"`
+	Pinned          bool                   `json:"pinned,omitempty"`
+	SubIssues       []*model.Issue         `json:"sub_issues"`
+	Relations       []model.Relation       `json:"relations"`
+	References      []model.IssueReference `json:"references"`
+	LinkedProposals []string               `json:"linked_proposals"`
+	Comments        []*model.Comment       `json:"comments"`
+	Activity        []model.Activity       `json:"activity"`
 }
 
 func (s showResult) MarshalJSON() ([]byte, error) {
@@ -68,6 +75,10 @@ func (s showResult) MarshalJSON() ([]byte, error) {
 	if docs == nil {
 		docs = []model.DocRef{}
 	}
+	links := i.Links
+	if links == nil {
+		links = []model.IssueLink{}
+	}
 	subIssues := s.SubIssues
 	if subIssues == nil {
 		subIssues = []*model.Issue{}
@@ -76,6 +87,10 @@ func (s showResult) MarshalJSON() ([]byte, error) {
 	if relations == nil {
 		relations = []model.Relation{}
 	}
+	references := s.References
+	if references == nil {
+		references = []model.IssueReference{}
+	}
 	linkedProposals := make([]string, 0, len(s.LinkedProposals))
 	for _, p := range s.LinkedProposals {
 		linkedProposals = append(linkedProposals, model.FormatProposalID(p.ID))
@@ -100,10 +115,13 @@ func (s showResult) MarshalJSON() ([]byte, error) {
 		Labels:          labels,
 		Files:           files,
 		Docs:            docs,
+		Links:           links,
 		CreatedAt:       i.CreatedAt.UTC().Format(time.RFC3339),
 		UpdatedAt:       i.UpdatedAt.UTC().Format(time.RFC3339),
+		Pinned:          i.Pinned,
 		SubIssues:       subIssues,
 		Relations:       relations,
+		References:      references,
 		LinkedProposals: linkedProposals,
 		Comments:        comments,
 		Activity:        activity,
@@ -113,15 +131,32 @@ func (s showResult) MarshalJSON() ([]byte, error) {
 		pid := model.FormatID(*i.ParentID)
 		j.ParentID = &pid
 	}
+	if !i.StartedAt.IsZero() {
+		started := i.StartedAt.UTC().Format(time.RFC3339)
+		j.StartedAt = &started
+	}
+	if !i.CompletedAt.IsZero() {
+		completed := i.CompletedAt.UTC().Format(time.RFC3339)
+		j.CompletedAt = &completed
+	}
+	if !i.SnoozedUntil.IsZero() {
+		snoozed := i.SnoozedUntil.UTC().Format(time.RFC3339)
+		j.SnoozedUntil = &snoozed
+	}
 
 	return json.Marshal(j)
 }
 
 var showCmd = &cobra.Command{
 	Use:   "show [id]",
-	Short: "Show issue details",
-	Args:  cobra.ExactArgs(1),
+	Short: "Show issue details (pick one interactively when no ID is given)",
+	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		args, ok, err := issueArgs(getDB(cmd), getWriter(cmd), args)
+		if err != nil || !ok {
+			return err
+		}
+
 		watchMode, _ := cmd.Flags().GetBool("watch")
 		if watchMode {
 			interval, _ := cmd.Flags().GetDuration("interval")
@@ -149,17 +184,26 @@ func runIssueShow(cmd *cobra.Command, args []string, w *output.Writer) error {
 
 	id, err := model.ParseID(args[0])
 	if err != nil {
-		return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+		return cmdErr(err, output.ErrValidation)
 	}
 
 	issue, err := db.GetIssue(conn, id)
 	if err != nil {
 		if errors.Is(err, db.ErrNotFound) {
-			return cmdErr(fmt.Errorf("issue %s not found", args[0]), output.ErrNotFound)
+			return issueNotFoundErr(conn, w, id)
 		}
 		return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
 	}
 
+	if history, _ := cmd.Flags().GetBool("assignment-history"); history {
+		activity, err := db.GetActivity(conn, id, 0)
+		if err != nil {
+			return cmdErr(fmt.Errorf("fetching activity: %w", err), output.ErrGeneral)
+		}
+		writeAssignmentHistory(w, issue, activity, time.Now())
+		return nil
+	}
+
 	// Hydrate labels.
 	issue.Labels, err = db.GetIssueLabels(conn, id)
 	if err != nil {
@@ -172,10 +216,26 @@ func runIssueShow(cmd *cobra.Command, args []string, w *output.Writer) error {
 		return cmdErr(fmt.Errorf("fetching files: %w", err), output.ErrGeneral)
 	}
 
+	// Hydrate links.
+	issue.Links, err = db.GetIssueLinks(conn, id)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching links: %w", err), output.ErrGeneral)
+	}
+
+	// Hydrate watchers.
+	issue.Watchers, err = db.GetWatchers(conn, id)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching watchers: %w", err), output.ErrGeneral)
+	}
+
 	if err := db.HydrateDocs(conn, []*model.Issue{issue}); err != nil {
 		return cmdErr(fmt.Errorf("fetching linked docs: %w", err), output.ErrGeneral)
 	}
 
+	if err := db.HydrateMilestones(conn, []*model.Issue{issue}); err != nil {
+		return cmdErr(fmt.Errorf("fetching milestone: %w", err), output.ErrGeneral)
+	}
+
 	subIssues, err := db.GetSubIssues(conn, id)
 	if err != nil {
 		return cmdErr(fmt.Errorf("fetching sub-issues: %w", err), output.ErrGeneral)
@@ -186,25 +246,44 @@ func runIssueShow(cmd *cobra.Command, args []string, w *output.Writer) error {
 		return cmdErr(fmt.Errorf("fetching relations: %w", err), output.ErrGeneral)
 	}
 
+	references, err := db.GetIssueReferences(conn, id)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching references: %w", err), output.ErrGeneral)
+	}
+
 	linkedProposals, err := db.GetIssueProposals(conn, id)
 	if err != nil {
 		return cmdErr(fmt.Errorf("fetching linked proposals: %w", err), output.ErrGeneral)
 	}
 
-	comments, err := db.ListComments(conn, id)
+	comments, err := issueComments(cmd, conn, id)
 	if err != nil {
 		return cmdErr(fmt.Errorf("fetching comments: %w", err), output.ErrGeneral)
 	}
 
-	activity, err := db.GetActivity(conn, id, 10)
+	activityLimit, _ := cmd.Flags().GetInt("activity")
+	if activityLimit < 0 {
+		return cmdErr(fmt.Errorf("--activity must not be negative"), output.ErrValidation)
+	}
+	activity, err := db.GetActivity(conn, id, activityLimit)
 	if err != nil {
 		return cmdErr(fmt.Errorf("fetching activity: %w", err), output.ErrGeneral)
 	}
+	// Only a full page can have older entries left out.
+	var hiddenActivity int
+	if activityLimit > 0 && len(activity) == activityLimit {
+		total, err := db.CountActivity(conn, id)
+		if err != nil {
+			return cmdErr(fmt.Errorf("counting activity: %w", err), output.ErrGeneral)
+		}
+		hiddenActivity = total - len(activity)
+	}
 
 	result := showResult{
 		Issue:           issue,
 		SubIssues:       subIssues,
 		Relations:       relations,
+		References:      references,
 		LinkedProposals: linkedProposals,
 		Comments:        comments,
 		Activity:        activity,
@@ -212,7 +291,11 @@ func runIssueShow(cmd *cobra.Command, args []string, w *output.Writer) error {
 
 	var message string
 	if !w.JSONMode {
-		message = render.RenderDetail(issue, subIssues, relations, linkedProposals, comments, activity)
+		layout, err := getLayout(cmd)
+		if err != nil {
+			return err
+		}
+		message = render.RenderDetail(issue, subIssues, relations, references, linkedProposals, comments, activity, hiddenActivity, layout)
 	}
 	w.Success(result, message)
 
@@ -220,5 +303,8 @@ func runIssueShow(cmd *cobra.Command, args []string, w *output.Writer) error {
 }
 
 func init() {
+	showCmd.Flags().Int("activity", 10, "Number of recent activity entries to show (0 for all)")
+	showCmd.Flags().Bool("comments-desc", false, "Show the newest comments first")
+	showCmd.Flags().Bool("assignment-history", false, "Show who has held the issue and for how long instead of its details")
 	issueCmd.AddCommand(showCmd)
 }
@@ -3,8 +3,10 @@ package cli
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ALT-F4-LLC/docket/internal/db"
 	"github.com/ALT-F4-LLC/docket/internal/model"
@@ -353,3 +355,108 @@ func TestIssueShowJSON_DocsEmptyIsArray(t *testing.T) {
 		t.Errorf("empty docs = %s, want []", docsRaw)
 	}
 }
+
+func TestIssueShowJSON_CommentsDesc(t *testing.T) {
+	conn := newTestDB(t)
+	issueID := createIssue(t, conn, "discussed", model.StatusTodo, model.PriorityLow)
+	for i, body := range []string{"first", "second", "third"} {
+		id, err := db.CreateComment(conn, &model.Comment{IssueID: issueID, Body: body, Author: "tester"})
+		if err != nil {
+			t.Fatalf("CreateComment: %v", err)
+		}
+		at := time.Now().Add(time.Duration(i-3) * time.Hour).UTC().Format(time.RFC3339)
+		if _, err := conn.Exec(`UPDATE comments SET created_at = ? WHERE id = ?`, at, id); err != nil {
+			t.Fatalf("backdating comment: %v", err)
+		}
+	}
+
+	for _, tc := range []struct {
+		desc bool
+		want string
+	}{
+		{false, "first,second,third"},
+		{true, "third,second,first"},
+	} {
+		cmd := cmdWithDB(conn)
+		cmd.Flags().Bool("comments-desc", tc.desc, "")
+		w, buf := bufWriter(true)
+		if err := runIssueShow(cmd, []string{model.FormatID(issueID)}, w); err != nil {
+			t.Fatalf("runIssueShow: %v", err)
+		}
+		var got struct {
+			Data struct {
+				Comments []*model.Comment `json:"comments"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+		}
+		var bodies []string
+		for i, c := range got.Data.Comments {
+			bodies = append(bodies, c.Body)
+			if i == 0 {
+				continue
+			}
+			prev := got.Data.Comments[i-1].CreatedAt
+			if tc.desc && c.CreatedAt.After(prev) || !tc.desc && c.CreatedAt.Before(prev) {
+				t.Errorf("--comments-desc=%v: %q at %v is out of order after %v", tc.desc, c.Body, c.CreatedAt, prev)
+			}
+		}
+		if strings.Join(bodies, ",") != tc.want {
+			t.Errorf("--comments-desc=%v: comments = %v, want %s", tc.desc, bodies, tc.want)
+		}
+	}
+}
+
+func TestIssueShow_ActivityLimitCountsHiddenEntries(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	conn := newTestDB(t)
+	issueID := createIssue(t, conn, "busy", model.StatusTodo, model.PriorityLow)
+	for _, p := range []string{"low", "medium", "high", "critical", "low"} {
+		if err := db.UpdateIssue(conn, issueID, map[string]interface{}{"priority": p}, "tester"); err != nil {
+			t.Fatalf("UpdateIssue: %v", err)
+		}
+	}
+	total, err := db.CountActivity(conn, issueID)
+	if err != nil {
+		t.Fatalf("CountActivity: %v", err)
+	}
+
+	for _, tc := range []struct {
+		limit     string
+		wantShown int
+	}{
+		{"2", 2},
+		{"0", total},
+		{fmt.Sprint(total), total},
+	} {
+		cmd := cmdWithDB(conn)
+		cmd.Flags().Int("activity", 10, "")
+		cmd.Flags().Set("activity", tc.limit)
+		w, buf := bufWriter(true)
+		if err := runIssueShow(cmd, []string{model.FormatID(issueID)}, w); err != nil {
+			t.Fatalf("runIssueShow: %v", err)
+		}
+		var got struct {
+			Data struct {
+				Activity []model.Activity `json:"activity"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+		}
+		if len(got.Data.Activity) != tc.wantShown {
+			t.Errorf("--activity %s: %d entries, want %d", tc.limit, len(got.Data.Activity), tc.wantShown)
+		}
+
+		w, buf = bufWriter(false)
+		if err := runIssueShow(cmd, []string{model.FormatID(issueID)}, w); err != nil {
+			t.Fatalf("runIssueShow: %v", err)
+		}
+		hidden := total - tc.wantShown
+		hint := fmt.Sprintf("… and %d more (docket issue log %s)", hidden, model.FormatID(issueID))
+		if got := strings.Contains(buf.String(), hint); got != (hidden > 0) {
+			t.Errorf("--activity %s: hint %q shown = %v, want %v:\n%s", tc.limit, hint, got, hidden > 0, buf.String())
+		}
+	}
+}
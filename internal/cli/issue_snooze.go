@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/config"
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+	"github.com/spf13/cobra"
+)
+
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze <id>",
+	Short: "Hide an issue from list, board and plan until a date",
+	Long: `Hide an issue from list, board and plan until a date, without changing
+its status:
+
+  docket issue snooze DKT-5 --until 2026-02-03
+  docket issue snooze DKT-5 --for 5d
+
+The issue comes back on its own once the time passes. Use --include-snoozed
+on list, board or plan to see snoozed issues, and 'docket issue unsnooze' to
+bring one back early. Done issues cannot be snoozed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSnooze(cmd, args, getWriter(cmd))
+	},
+}
+
+func runSnooze(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	id, err := model.ParseID(args[0])
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+
+	untilFlag, _ := cmd.Flags().GetString("until")
+	forFlag, _ := cmd.Flags().GetString("for")
+	until, err := snoozeUntil(untilFlag, forFlag, time.Now())
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+
+	if err := db.SnoozeIssue(conn, id, until, config.DefaultAuthor()); err != nil {
+		return snoozeErr(conn, w, id, err)
+	}
+
+	issue, err := db.GetIssue(conn, id)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching updated issue: %w", err), output.ErrGeneral)
+	}
+	w.Success(issue, fmt.Sprintf("Snoozed %s until %s", model.FormatID(id), render.SnoozeTime(issue.SnoozedUntil)))
+	return nil
+}
+
+var unsnoozeCmd = &cobra.Command{
+	Use:   "unsnooze <id>",
+	Short: "Bring a snoozed issue back before its snooze ends",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runUnsnooze(cmd, args, getWriter(cmd))
+	},
+}
+
+func runUnsnooze(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	id, err := model.ParseID(args[0])
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+
+	if err := db.UnsnoozeIssue(conn, id, config.DefaultAuthor()); err != nil {
+		return snoozeErr(conn, w, id, err)
+	}
+
+	issue, err := db.GetIssue(conn, id)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching updated issue: %w", err), output.ErrGeneral)
+	}
+	w.Success(issue, fmt.Sprintf("Unsnoozed %s: %s", model.FormatID(id), issue.Title))
+	return nil
+}
+
+// snoozeErr maps an error from db.SnoozeIssue or db.UnsnoozeIssue to a
+// command error.
+func snoozeErr(conn *sql.DB, w *output.Writer, id int, err error) error {
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		return issueNotFoundErr(conn, w, id)
+	case errors.Is(err, db.ErrValidation):
+		return cmdErr(err, output.ErrValidation)
+	default:
+		return cmdErr(fmt.Errorf("snoozing issue: %w", err), output.ErrGeneral)
+	}
+}
+
+// snoozeUntil returns the end of a snooze from --until (a date, taken as
+// midnight local time, or an RFC 3339 time) or --for (a duration such as
+// 5d, counted from now). Exactly one of them must be set.
+func snoozeUntil(untilFlag, forFlag string, now time.Time) (time.Time, error) {
+	switch {
+	case untilFlag != "" && forFlag != "":
+		return time.Time{}, fmt.Errorf("--until and --for are mutually exclusive")
+	case forFlag != "":
+		d, err := parseAge(forFlag)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("--for: %w", err)
+		}
+		return now.Add(d), nil
+	case untilFlag != "":
+		if t, err := time.ParseInLocation(time.DateOnly, untilFlag, time.Local); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse(time.RFC3339, untilFlag)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("--until: invalid date %q: use YYYY-MM-DD or an RFC 3339 time", untilFlag)
+		}
+		return t, nil
+	default:
+		return time.Time{}, fmt.Errorf("one of --until or --for is required")
+	}
+}
+
+func init() {
+	snoozeCmd.Flags().String("until", "", "Snooze until this date (YYYY-MM-DD) or RFC 3339 time")
+	snoozeCmd.Flags().String("for", "", "Snooze for this long (e.g. 5d, 2w, 12h)")
+	issueCmd.AddCommand(snoozeCmd)
+	issueCmd.AddCommand(unsnoozeCmd)
+}
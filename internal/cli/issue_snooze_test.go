@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+func TestSnoozeUntil(t *testing.T) {
+	now := time.Date(2026, 2, 1, 9, 30, 0, 0, time.Local)
+
+	got, err := snoozeUntil("", "5d", now)
+	if err != nil || !got.Equal(now.Add(5*24*time.Hour)) {
+		t.Errorf("--for 5d = %v, %v; want %v", got, err, now.Add(5*24*time.Hour))
+	}
+	got, err = snoozeUntil("2026-02-03", "", now)
+	if want := time.Date(2026, 2, 3, 0, 0, 0, 0, time.Local); err != nil || !got.Equal(want) {
+		t.Errorf("--until 2026-02-03 = %v, %v; want %v", got, err, want)
+	}
+	got, err = snoozeUntil("2026-02-03T15:00:00Z", "", now)
+	if want := time.Date(2026, 2, 3, 15, 0, 0, 0, time.UTC); err != nil || !got.Equal(want) {
+		t.Errorf("--until RFC 3339 = %v, %v; want %v", got, err, want)
+	}
+
+	for _, tc := range [][2]string{{"", ""}, {"2026-02-03", "5d"}, {"tomorrow", ""}, {"", "soon"}} {
+		if _, err := snoozeUntil(tc[0], tc[1], now); err == nil {
+			t.Errorf("snoozeUntil(%q, %q) succeeded, want an error", tc[0], tc[1])
+		}
+	}
+}
+
+func TestSnooze_HidesFromListUntilIncluded(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	conn := newTestDB(t)
+	snoozed := createIssue(t, conn, "Later work", model.StatusTodo, model.PriorityLow)
+	createIssue(t, conn, "Current work", model.StatusTodo, model.PriorityLow)
+
+	cmd := cmdWithDB(conn)
+	cmd.Flags().String("until", "", "")
+	cmd.Flags().String("for", "", "")
+	if err := cmd.Flags().Set("for", "5d"); err != nil {
+		t.Fatalf("Set(for): %v", err)
+	}
+	w, _ := bufWriter(false)
+	if err := runSnooze(cmd, []string{model.FormatID(snoozed)}, w); err != nil {
+		t.Fatalf("runSnooze: %v", err)
+	}
+
+	list := listCmdWithDB(conn)
+	w, buf := bufWriter(false)
+	if err := runIssueList(list, nil, w); err != nil {
+		t.Fatalf("runIssueList: %v", err)
+	}
+	if out := buf.String(); strings.Contains(out, "Later work") || !strings.Contains(out, "Current work") {
+		t.Errorf("list output should hide the snoozed issue:\n%s", out)
+	}
+
+	list = listCmdWithDB(conn)
+	if err := list.Flags().Set("include-snoozed", "true"); err != nil {
+		t.Fatalf("Set(include-snoozed): %v", err)
+	}
+	if err := list.Flags().Set("no-truncate", "true"); err != nil {
+		t.Fatalf("Set(no-truncate): %v", err)
+	}
+	w, buf = bufWriter(false)
+	if err := runIssueList(list, nil, w); err != nil {
+		t.Fatalf("runIssueList: %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "Later work (snoozed until ") {
+		t.Errorf("list --include-snoozed output should mark the snoozed issue:\n%s", out)
+	}
+}
+
+func TestSnooze_DoneIssueIsValidationError(t *testing.T) {
+	conn := newTestDB(t)
+	id := createIssue(t, conn, "Finished", model.StatusDone, model.PriorityLow)
+
+	cmd := cmdWithDB(conn)
+	cmd.Flags().String("until", "", "")
+	cmd.Flags().String("for", "", "")
+	if err := cmd.Flags().Set("for", "1d"); err != nil {
+		t.Fatalf("Set(for): %v", err)
+	}
+	w, _ := bufWriter(false)
+	err := runSnooze(cmd, []string{model.FormatID(id)}, w)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Errorf("runSnooze on a done issue: err = %v, want a validation error", err)
+	}
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+	"github.com/spf13/cobra"
+)
+
+// tasklistResult is the JSON wire format for the tasklist command output.
+type tasklistResult struct {
+	IssueID  string         `json:"issue_id"`
+	Markdown string         `json:"markdown"`
+	Issues   []*model.Issue `json:"issues"`
+}
+
+var tasklistCmd = &cobra.Command{
+	Use:   "tasklist <id>",
+	Short: "Print an issue's sub-issues as a Markdown task list",
+	Long: `Print an issue's sub-issues as a GitHub-flavored Markdown task list, nested
+to match the hierarchy, ready to paste into a pull request description:
+
+  - [ ] DKT-6 Parse the config file
+    - [x] DKT-7 Fix parser
+
+Done issues are checked. Nothing else is printed, so the output can be piped
+straight to a clipboard tool.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runIssueTasklist(cmd, args, getWriter(cmd))
+	},
+}
+
+func runIssueTasklist(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	id, err := model.ParseID(args[0])
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+
+	depth, _ := cmd.Flags().GetInt("depth")
+	if depth < 0 {
+		return cmdErr(fmt.Errorf("depth must be non-negative"), output.ErrValidation)
+	}
+	verbose, _ := cmd.Flags().GetBool("verbose")
+
+	if _, err := db.GetIssue(conn, id); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return issueNotFoundErr(conn, w, id)
+		}
+		return cmdErr(fmt.Errorf("fetching issue: %w", err), output.ErrGeneral)
+	}
+
+	issues, err := db.GetSubIssueTree(conn, id, depth)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching sub-issues: %w", err), output.ErrGeneral)
+	}
+	markdown := render.RenderTaskList(id, issues, verbose)
+
+	if w.JSONMode {
+		if issues == nil {
+			issues = []*model.Issue{}
+		}
+		w.Success(tasklistResult{IssueID: model.FormatID(id), Markdown: markdown, Issues: issues}, "")
+		return nil
+	}
+
+	if markdown == "" {
+		w.Info("%s has no sub-issues", model.FormatID(id))
+		return nil
+	}
+	// Written directly rather than through Success so a single-item list is
+	// not prefixed with a check mark.
+	fmt.Fprintln(w.Stdout, markdown)
+	return nil
+}
+
+func init() {
+	tasklistCmd.Flags().Int("depth", 0, "Maximum nesting depth (0 = unlimited)")
+	tasklistCmd.Flags().Bool("verbose", false, "Append each issue's status and assignee")
+	issueCmd.AddCommand(tasklistCmd)
+}
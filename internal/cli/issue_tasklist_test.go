@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestIssueTasklistDepth(t *testing.T) {
+	conn := newTestDB(t)
+	epic := createIssue(t, conn, "Epic", model.StatusTodo, model.PriorityLow)
+	story := createIssue(t, conn, "Story", model.StatusTodo, model.PriorityLow)
+	task := createIssue(t, conn, "Task", model.StatusDone, model.PriorityLow)
+	for child, parent := range map[int]int{story: epic, task: story} {
+		if err := db.UpdateIssue(conn, child, map[string]interface{}{"parent_id": parent}, "tester"); err != nil {
+			t.Fatalf("UpdateIssue: %v", err)
+		}
+	}
+
+	tests := []struct {
+		depth int
+		want  string
+	}{
+		{0, "- [ ] DKT-2 Story\n  - [x] DKT-3 Task\n"},
+		{1, "- [ ] DKT-2 Story\n"},
+	}
+	for _, tt := range tests {
+		cmd := cmdWithDB(conn)
+		cmd.Flags().Int("depth", tt.depth, "")
+		cmd.Flags().Bool("verbose", false, "")
+		w, buf := bufWriter(false)
+		if err := runIssueTasklist(cmd, []string{"DKT-1"}, w); err != nil {
+			t.Fatalf("runIssueTasklist: %v", err)
+		}
+		if got := buf.String(); got != tt.want {
+			t.Errorf("depth %d: got %q, want %q", tt.depth, got, tt.want)
+		}
+	}
+}
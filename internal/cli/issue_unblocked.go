@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/planner"
+)
+
+// doneResult is the payload written when an issue moves to done. It is the
+// issue's own JSON object with the dependency summary fields appended, so
+// consumers that only read the issue fields are unaffected.
+type doneResult struct {
+	Issue        *model.Issue
+	Unblocked    []*model.Issue
+	OpenBlockers []*model.Issue
+}
+
+func (r doneResult) MarshalJSON() ([]byte, error) {
+	issue, err := json.Marshal(r.Issue)
+	if err != nil {
+		return nil, err
+	}
+	extra, err := json.Marshal(struct {
+		Unblocked    []string `json:"unblocked"`
+		OpenBlockers []string `json:"open_blockers,omitempty"`
+	}{
+		Unblocked:    formatIssueIDs(r.Unblocked),
+		OpenBlockers: formatIssueIDs(r.OpenBlockers),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Splice the summary fields into the issue object: drop the issue's
+	// closing brace and the extras' opening brace.
+	out := append(issue[:len(issue)-1:len(issue)-1], ',')
+	return append(out, extra[1:]...), nil
+}
+
+// formatIssueIDs returns the display IDs of issues, never nil.
+func formatIssueIDs(issues []*model.Issue) []string {
+	ids := make([]string, 0, len(issues))
+	for _, i := range issues {
+		ids = append(ids, model.FormatID(i.ID))
+	}
+	return ids
+}
+
+// writeDoneResult writes the result of moving issue to done along with a
+// summary of its dependencies: the issues it no longer blocks, and any of
+// its own blockers that are still open. In human mode both are repeated as
+// warnings, which --quiet suppresses.
+func writeDoneResult(conn *sql.DB, w *output.Writer, issue *model.Issue, message string) error {
+	issues, err := db.ListAllIssues(conn)
+	if err != nil {
+		return cmdErr(fmt.Errorf("listing issues: %w", err), output.ErrGeneral)
+	}
+	relations, err := db.GetAllDirectionalRelations(conn)
+	if err != nil {
+		return cmdErr(fmt.Errorf("loading relations: %w", err), output.ErrGeneral)
+	}
+
+	dag := planner.BuildDAG(issues, relations)
+	result := doneResult{
+		Issue:        issue,
+		Unblocked:    planner.Unblocked(dag, issue.ID),
+		OpenBlockers: planner.OpenBlockers(dag, issue.ID),
+	}
+
+	w.Success(result, message)
+	if w.QuietMode {
+		return nil
+	}
+	return warnBlockedBy(conn, w, dag, result)
+}
+
+// warnBlockedBy warns about the dependency consequences of closing
+// r.Issue: the dependents it unblocked, those still waiting on other open
+// blockers, and its own blockers that are still open.
+func warnBlockedBy(conn *sql.DB, w *output.Writer, dag *planner.DAG, r doneResult) error {
+	id := model.FormatID(r.Issue.ID)
+	blocked, err := db.GetBlockedBy(conn, r.Issue.ID)
+	if err != nil {
+		return cmdErr(fmt.Errorf("loading blocked issues: %w", err), output.ErrGeneral)
+	}
+
+	if len(r.Unblocked) > 0 {
+		w.Warn("%s no longer blocks %s; now ready to start", id, strings.Join(formatIssueIDs(r.Unblocked), ", "))
+	}
+	var waiting []string
+	for _, depID := range blocked {
+		node, ok := dag.Nodes[depID]
+		if !ok || node.Issue.Status == model.StatusDone || slices.ContainsFunc(r.Unblocked, func(i *model.Issue) bool { return i.ID == depID }) {
+			continue
+		}
+		waiting = append(waiting, model.FormatID(depID))
+	}
+	if len(waiting) > 0 {
+		w.Warn("%s still blocked by other open issues", strings.Join(waiting, ", "))
+	}
+	if len(r.OpenBlockers) > 0 {
+		noun := "issue"
+		if len(r.OpenBlockers) > 1 {
+			noun = "issues"
+		}
+		w.Warn("%s was still blocked by open %s %s",
+			id, noun, strings.Join(formatIssueIDs(r.OpenBlockers), ", "))
+	}
+	return nil
+}
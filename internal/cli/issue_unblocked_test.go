@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+func TestWriteDoneResultIncludesUnblocked(t *testing.T) {
+	conn := newTestDB(t)
+	blocker := createIssue(t, conn, "blocker", model.StatusInProgress, model.PriorityHigh)
+	dependent := createIssue(t, conn, "dependent", model.StatusTodo, model.PriorityMedium)
+	upstream := createIssue(t, conn, "upstream", model.StatusTodo, model.PriorityLow)
+	for _, rel := range []*model.Relation{
+		{SourceIssueID: blocker, TargetIssueID: dependent, RelationType: model.RelationBlocks},
+		{SourceIssueID: upstream, TargetIssueID: blocker, RelationType: model.RelationBlocks},
+	} {
+		if _, err := db.CreateRelation(conn, rel); err != nil {
+			t.Fatalf("CreateRelation: %v", err)
+		}
+	}
+
+	if err := db.UpdateIssue(conn, blocker, map[string]interface{}{"status": "done"}, "tester"); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+	issue, err := db.GetIssue(conn, blocker)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+
+	w, buf := bufWriter(true)
+	if err := writeDoneResult(conn, w, issue, "closed"); err != nil {
+		t.Fatalf("writeDoneResult: %v", err)
+	}
+
+	var env struct {
+		Data struct {
+			ID           string   `json:"id"`
+			Status       string   `json:"status"`
+			Unblocked    []string `json:"unblocked"`
+			OpenBlockers []string `json:"open_blockers"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("decoding output: %v\n%s", err, buf.String())
+	}
+	if env.Data.ID != model.FormatID(blocker) || env.Data.Status != "done" {
+		t.Errorf("issue fields missing from payload: %+v", env.Data)
+	}
+	if len(env.Data.Unblocked) != 1 || env.Data.Unblocked[0] != model.FormatID(dependent) {
+		t.Errorf("unblocked = %v, want [%s]", env.Data.Unblocked, model.FormatID(dependent))
+	}
+	if len(env.Data.OpenBlockers) != 1 || env.Data.OpenBlockers[0] != model.FormatID(upstream) {
+		t.Errorf("open_blockers = %v, want [%s]", env.Data.OpenBlockers, model.FormatID(upstream))
+	}
+}
+
+func TestDoneResultEmptyUnblockedIsArray(t *testing.T) {
+	data, err := json.Marshal(doneResult{Issue: &model.Issue{ID: 1, Status: model.StatusDone, Priority: model.PriorityNone, Kind: model.IssueKindTask}})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var got map[string]json.RawMessage
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("payload is not a JSON object: %v\n%s", err, data)
+	}
+	if string(got["unblocked"]) != "[]" {
+		t.Errorf("unblocked = %s, want []", got["unblocked"])
+	}
+	if _, ok := got["open_blockers"]; ok {
+		t.Error("open_blockers present with no open blockers")
+	}
+}
+
+func TestWriteDoneResultWarnsAboutDependents(t *testing.T) {
+	conn := newTestDB(t)
+	blocker := createIssue(t, conn, "blocker", model.StatusDone, model.PriorityHigh)
+	ready := createIssue(t, conn, "ready", model.StatusTodo, model.PriorityMedium)
+	waiting := createIssue(t, conn, "waiting", model.StatusTodo, model.PriorityMedium)
+	other := createIssue(t, conn, "other", model.StatusTodo, model.PriorityLow)
+	lonely := createIssue(t, conn, "lonely", model.StatusDone, model.PriorityLow)
+	for _, rel := range []*model.Relation{
+		{SourceIssueID: blocker, TargetIssueID: ready, RelationType: model.RelationBlocks},
+		{SourceIssueID: waiting, TargetIssueID: blocker, RelationType: model.RelationDependsOn},
+		{SourceIssueID: other, TargetIssueID: waiting, RelationType: model.RelationBlocks},
+	} {
+		if _, err := db.CreateRelation(conn, rel); err != nil {
+			t.Fatalf("CreateRelation: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		id    int
+		quiet bool
+		want  string
+	}{
+		{"blocker", blocker, false, "Warning: DKT-1 no longer blocks DKT-2; now ready to start\n" +
+			"Warning: DKT-3 still blocked by other open issues\n"},
+		{"quiet", blocker, true, ""},
+		{"blocks nothing", lonely, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", "1")
+			issue, err := db.GetIssue(conn, tt.id)
+			if err != nil {
+				t.Fatalf("GetIssue: %v", err)
+			}
+			stderr := &bytes.Buffer{}
+			w := &output.Writer{QuietMode: tt.quiet, Stdout: &bytes.Buffer{}, Stderr: stderr}
+			if err := writeDoneResult(conn, w, issue, "closed"); err != nil {
+				t.Fatalf("writeDoneResult: %v", err)
+			}
+			if stderr.String() != tt.want {
+				t.Errorf("stderr = %q, want %q", stderr.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/config"
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// watchResult is the JSON output of 'issue watch' and 'issue unwatch': the
+// issue's watchers after the change.
+type watchResult struct {
+	ID       string   `json:"id"`
+	Watchers []string `json:"watchers"`
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch <id> <name|me>...",
+	Short: "Add people to those watching an issue",
+	Long: `Add people to those watching an issue, to keep them informed about it:
+
+  docket issue watch DKT-5 jane
+  docket issue watch DKT-5 me bob
+
+"me" is the configured current user. Watching an issue twice is harmless.
+'docket issue show' lists the watchers, and 'docket issue unwatch' removes
+them.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWatch(cmd, args, getWriter(cmd), true)
+	},
+}
+
+var unwatchCmd = &cobra.Command{
+	Use:   "unwatch <id> <name|me>...",
+	Short: "Remove people from those watching an issue",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWatch(cmd, args, getWriter(cmd), false)
+	},
+}
+
+// runWatch adds the people named by args[1:] to the watchers of the issue
+// named by args[0], or removes them when watch is false.
+func runWatch(cmd *cobra.Command, args []string, w *output.Writer, watch bool) error {
+	conn := getDB(cmd)
+
+	id, err := model.ParseID(args[0])
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+
+	names := make([]string, 0, len(args)-1)
+	for _, arg := range args[1:] {
+		name := strings.TrimSpace(arg)
+		if name == "" {
+			return cmdErr(fmt.Errorf("watcher names must not be empty"), output.ErrValidation)
+		}
+		if name, err = resolveAssignee(cmd, conn, name); err != nil {
+			return err
+		}
+		names = append(names, name)
+	}
+
+	set := db.AddWatcher
+	if !watch {
+		set = db.RemoveWatcher
+	}
+	var changed []string
+	for _, name := range names {
+		ok, err := set(conn, id, name, config.DefaultAuthor())
+		if err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				return issueNotFoundErr(conn, w, id)
+			}
+			return cmdErr(fmt.Errorf("updating watchers: %w", err), output.ErrGeneral)
+		}
+		if ok {
+			changed = append(changed, name)
+		}
+	}
+
+	watchers, err := db.GetWatchers(conn, id)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching watchers: %w", err), output.ErrGeneral)
+	}
+	if watchers == nil {
+		watchers = []string{}
+	}
+
+	var message string
+	switch {
+	case len(changed) == 0 && watch:
+		message = fmt.Sprintf("Already watching %s: %s", model.FormatID(id), strings.Join(names, ", "))
+	case len(changed) == 0:
+		message = fmt.Sprintf("Not watching %s: %s", model.FormatID(id), strings.Join(names, ", "))
+	case watch:
+		message = fmt.Sprintf("Now watching %s: %s", model.FormatID(id), strings.Join(changed, ", "))
+	default:
+		message = fmt.Sprintf("No longer watching %s: %s", model.FormatID(id), strings.Join(changed, ", "))
+	}
+	w.Success(watchResult{ID: model.FormatID(id), Watchers: watchers}, message)
+	return nil
+}
+
+func init() {
+	issueCmd.AddCommand(watchCmd)
+	issueCmd.AddCommand(unwatchCmd)
+}
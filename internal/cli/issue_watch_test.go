@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestWatch_AddRemoveAndShow(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	conn := newTestDB(t)
+	id := createIssue(t, conn, "Watched", model.StatusTodo, model.PriorityMedium)
+	ref := model.FormatID(id)
+
+	watchers := func(args []string, watch bool) []string {
+		t.Helper()
+		w, buf := bufWriter(true)
+		if err := runWatch(cmdWithDB(conn), args, w, watch); err != nil {
+			t.Fatalf("runWatch(%v, %v): %v", args, watch, err)
+		}
+		var got struct {
+			Data watchResult `json:"data"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+		}
+		return got.Data.Watchers
+	}
+
+	if got := watchers([]string{ref, "jane", "bob"}, true); !slices.Equal(got, []string{"bob", "jane"}) {
+		t.Errorf("after watch = %v, want [bob jane]", got)
+	}
+	if got := watchers([]string{ref, "jane"}, true); !slices.Equal(got, []string{"bob", "jane"}) {
+		t.Errorf("after repeated watch = %v, want [bob jane]", got)
+	}
+
+	w, buf := bufWriter(false)
+	if err := runIssueShow(cmdWithDB(conn), []string{ref}, w); err != nil {
+		t.Fatalf("runIssueShow: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Watchers: bob, jane\n") {
+		t.Errorf("show output missing watchers:\n%s", buf.String())
+	}
+
+	if got := watchers([]string{ref, "bob"}, false); !slices.Equal(got, []string{"jane"}) {
+		t.Errorf("after unwatch = %v, want [jane]", got)
+	}
+}
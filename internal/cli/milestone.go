@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var milestoneCmd = &cobra.Command{
+	Use:     "milestone",
+	Short:   "Manage milestones",
+	Aliases: []string{"ms"},
+}
+
+// resolveMilestone looks up a milestone by name, returning a NOT_FOUND
+// command error if it does not exist.
+func resolveMilestone(conn *sql.DB, name string) (*model.Milestone, error) {
+	m, err := db.GetMilestoneByName(conn, name)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, cmdErr(fmt.Errorf("milestone %q not found", name), output.ErrNotFound)
+		}
+		return nil, cmdErr(fmt.Errorf("fetching milestone: %w", err), output.ErrGeneral)
+	}
+	return m, nil
+}
+
+func init() {
+	rootCmd.AddCommand(milestoneCmd)
+}
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ALT-F4-LLC/docket/internal/config"
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+type milestoneCloseResult struct {
+	Milestone *model.Milestone `json:"milestone"`
+	Moved     int              `json:"moved"`
+	MovedTo   string           `json:"moved_to,omitempty"`
+}
+
+var milestoneCloseCmd = &cobra.Command{
+	Use:   "close <name>",
+	Short: "Close a milestone",
+	Long: `Closes a milestone. If it still has issues that are not done, pass
+--move-to <milestone> to move them to another open milestone, or --force to
+close it and leave them where they are. Without either flag an interactive
+prompt asks what to do.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMilestoneClose(cmd, args, getWriter(cmd))
+	},
+}
+
+func runMilestoneClose(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	force, _ := cmd.Flags().GetBool("force")
+	moveToName, _ := cmd.Flags().GetString("move-to")
+
+	if force && moveToName != "" {
+		return cmdErr(fmt.Errorf("--force and --move-to are mutually exclusive"), output.ErrValidation)
+	}
+
+	m, err := resolveMilestone(conn, args[0])
+	if err != nil {
+		return err
+	}
+	if m.Closed {
+		return cmdErr(fmt.Errorf("milestone %q is already closed", m.Name), output.ErrConflict)
+	}
+
+	if moveToName != "" {
+		target, err := moveTarget(conn, m, moveToName)
+		if err != nil {
+			return err
+		}
+		return doCloseMilestone(w, conn, m, target)
+	}
+
+	done, total, err := db.GetMilestoneProgress(conn, m.ID)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching milestone progress: %w", err), output.ErrGeneral)
+	}
+	open := total - done
+
+	if open == 0 || force {
+		return doCloseMilestone(w, conn, m, nil)
+	}
+
+	hint := fmt.Sprintf("milestone %q has %d open issue(s): use --move-to <milestone> to move them or --force to close anyway", m.Name, open)
+	if w.JSONMode {
+		return cmdErr(errors.New(hint), output.ErrValidation)
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return cmdErr(fmt.Errorf("non-interactive environment detected; %s", hint), output.ErrValidation)
+	}
+
+	others, err := db.ListMilestones(conn, false)
+	if err != nil {
+		return cmdErr(fmt.Errorf("listing milestones: %w", err), output.ErrGeneral)
+	}
+	// Options carry milestone IDs; the non-positive values are the choices
+	// that do not move anything.
+	const (
+		choiceCancel = -1
+		choiceLeave  = 0
+	)
+	targets := make(map[int]*model.Milestone)
+	var options []huh.Option[int]
+	for _, o := range others {
+		if o.ID != m.ID {
+			targets[o.ID] = o.Milestone
+			options = append(options, huh.NewOption("Move them to "+o.Name, o.ID))
+		}
+	}
+	options = append(options,
+		huh.NewOption("Close and leave them in this milestone", choiceLeave),
+		huh.NewOption("Cancel", choiceCancel),
+	)
+
+	var choice int
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[int]().
+				Title(fmt.Sprintf("Milestone %q has %d open issue(s). How do you want to proceed?", m.Name, open)).
+				Options(options...).
+				Value(&choice),
+		),
+	)
+	if err := form.Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			w.Info("Cancelled.")
+			return nil
+		}
+		return cmdErr(fmt.Errorf("interactive form failed: %w", err), output.ErrGeneral)
+	}
+
+	switch choice {
+	case choiceCancel:
+		w.Info("Cancelled.")
+		return nil
+	case choiceLeave:
+		return doCloseMilestone(w, conn, m, nil)
+	default:
+		return doCloseMilestone(w, conn, m, targets[choice])
+	}
+}
+
+// moveTarget resolves the milestone that open issues are moved to when m is
+// closed. It must exist, be open, and differ from m.
+func moveTarget(conn *sql.DB, m *model.Milestone, name string) (*model.Milestone, error) {
+	target, err := resolveMilestone(conn, name)
+	if err != nil {
+		return nil, err
+	}
+	if target.ID == m.ID {
+		return nil, cmdErr(fmt.Errorf("cannot move issues to the milestone being closed"), output.ErrValidation)
+	}
+	if target.Closed {
+		return nil, cmdErr(fmt.Errorf("milestone %q is closed", target.Name), output.ErrValidation)
+	}
+	return target, nil
+}
+
+func doCloseMilestone(w *output.Writer, conn *sql.DB, m *model.Milestone, target *model.Milestone) error {
+	var moveTo *int
+	if target != nil {
+		moveTo = &target.ID
+	}
+
+	moved, err := db.CloseMilestone(conn, m.ID, moveTo, config.DefaultAuthor())
+	if err != nil {
+		return cmdErr(fmt.Errorf("closing milestone: %w", err), output.ErrGeneral)
+	}
+
+	closed, err := db.GetMilestone(conn, m.ID)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching closed milestone: %w", err), output.ErrGeneral)
+	}
+
+	result := milestoneCloseResult{Milestone: closed, Moved: moved}
+	message := fmt.Sprintf("Closed milestone %q", m.Name)
+	if target != nil {
+		result.MovedTo = target.Name
+		message = fmt.Sprintf("Closed milestone %q (moved %d open issue(s) to %q)", m.Name, moved, target.Name)
+	}
+	w.Success(result, message)
+	return nil
+}
+
+func init() {
+	milestoneCloseCmd.Flags().BoolP("force", "f", false, "Close even if issues are still open")
+	milestoneCloseCmd.Flags().String("move-to", "", "Move open issues to this milestone before closing")
+	milestoneCmd.AddCommand(milestoneCloseCmd)
+}
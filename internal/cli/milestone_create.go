@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var milestoneCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a milestone",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := getWriter(cmd)
+		conn := getDB(cmd)
+
+		name := strings.TrimSpace(args[0])
+		if name == "" {
+			return cmdErr(fmt.Errorf("milestone name must not be empty"), output.ErrValidation)
+		}
+
+		description, _ := cmd.Flags().GetString("description")
+		dueFlag, _ := cmd.Flags().GetString("due")
+
+		m := &model.Milestone{Name: name, Description: description}
+		if dueFlag != "" {
+			due, err := model.ParseDueDate(dueFlag)
+			if err != nil {
+				return cmdErr(err, output.ErrValidation)
+			}
+			m.DueDate = &due
+		}
+
+		id, err := db.CreateMilestone(conn, m)
+		if err != nil {
+			if errors.Is(err, db.ErrConflict) {
+				return cmdErr(fmt.Errorf("milestone %q already exists", name), output.ErrConflict)
+			}
+			return cmdErr(fmt.Errorf("creating milestone: %w", err), output.ErrGeneral)
+		}
+
+		created, err := db.GetMilestone(conn, id)
+		if err != nil {
+			return cmdErr(fmt.Errorf("fetching created milestone: %w", err), output.ErrGeneral)
+		}
+
+		w.Success(created, fmt.Sprintf("Created milestone %q", name))
+		return nil
+	},
+}
+
+func init() {
+	milestoneCreateCmd.Flags().StringP("description", "d", "", "Milestone description")
+	milestoneCreateCmd.Flags().String("due", "", "Due date (YYYY-MM-DD)")
+	milestoneCmd.AddCommand(milestoneCreateCmd)
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+	"github.com/spf13/cobra"
+)
+
+type milestoneListResult struct {
+	Milestones []model.MilestoneSummary `json:"milestones"`
+	Total      int                      `json:"total"`
+}
+
+var milestoneListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List milestones with their progress",
+	Aliases: []string{"ls"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := getWriter(cmd)
+		conn := getDB(cmd)
+
+		all, _ := cmd.Flags().GetBool("all")
+
+		summaries, err := db.ListMilestones(conn, all)
+		if err != nil {
+			return cmdErr(fmt.Errorf("listing milestones: %w", err), output.ErrGeneral)
+		}
+
+		var message string
+		if !w.JSONMode {
+			message = render.RenderMilestoneList(summaries)
+		}
+		w.Success(milestoneListResult{Milestones: summaries, Total: len(summaries)}, message)
+		return nil
+	},
+}
+
+func init() {
+	milestoneListCmd.Flags().Bool("all", false, "Include closed milestones")
+	milestoneCmd.AddCommand(milestoneListCmd)
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+	"github.com/spf13/cobra"
+)
+
+type milestoneShowResult struct {
+	Milestone *model.Milestone `json:"milestone"`
+	Done      int              `json:"done"`
+	Total     int              `json:"total"`
+	Issues    []*model.Issue   `json:"issues"`
+}
+
+var milestoneShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show a milestone's progress and issues",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMilestoneShow(cmd, args, getWriter(cmd))
+	},
+}
+
+func runMilestoneShow(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	m, err := resolveMilestone(conn, args[0])
+	if err != nil {
+		return err
+	}
+
+	done, total, err := db.GetMilestoneProgress(conn, m.ID)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching milestone progress: %w", err), output.ErrGeneral)
+	}
+
+	issues, _, err := db.ListIssues(conn, db.ListOptions{MilestoneID: &m.ID, IncludeDone: true, IncludeSnoozed: true})
+	if err != nil {
+		return cmdErr(fmt.Errorf("listing milestone issues: %w", err), output.ErrGeneral)
+	}
+	for _, issue := range issues {
+		issue.Milestone = m.Name
+	}
+
+	result := milestoneShowResult{Milestone: m, Done: done, Total: total, Issues: issues}
+
+	var message string
+	if !w.JSONMode {
+		layout, err := getLayout(cmd)
+		if err != nil {
+			return err
+		}
+		message = render.RenderMilestoneDetail(m, done, total, issues, layout)
+	}
+	w.Success(result, message)
+	return nil
+}
+
+func init() {
+	milestoneCmd.AddCommand(milestoneShowCmd)
+}
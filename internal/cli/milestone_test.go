@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func createMilestone(t *testing.T, conn *sql.DB, name string) int {
+	t.Helper()
+	id, err := db.CreateMilestone(conn, &model.Milestone{Name: name})
+	if err != nil {
+		t.Fatalf("CreateMilestone(%q): %v", name, err)
+	}
+	return id
+}
+
+func assignIssueMilestone(t *testing.T, conn *sql.DB, issueID, milestoneID int) {
+	t.Helper()
+	if err := db.UpdateIssue(conn, issueID, map[string]interface{}{"milestone_id": milestoneID}, "tester"); err != nil {
+		t.Fatalf("UpdateIssue(milestone_id): %v", err)
+	}
+}
+
+func closeCmdWithDB(conn *sql.DB) *cobra.Command {
+	cmd := cmdWithDB(conn)
+	cmd.Flags().BoolP("force", "f", false, "")
+	cmd.Flags().String("move-to", "", "")
+	return cmd
+}
+
+func TestMilestoneClose_OpenIssuesRequireFlagInJSONMode(t *testing.T) {
+	conn := newTestDB(t)
+	msID := createMilestone(t, conn, "v1.4")
+	assignIssueMilestone(t, conn, createIssue(t, conn, "pending", model.StatusTodo, model.PriorityHigh), msID)
+
+	w, _ := bufWriter(true)
+	err := runMilestoneClose(closeCmdWithDB(conn), []string{"v1.4"}, w)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Fatalf("err = %v, want validation error", err)
+	}
+
+	m, err := db.GetMilestone(conn, msID)
+	if err != nil {
+		t.Fatalf("GetMilestone: %v", err)
+	}
+	if m.Closed {
+		t.Error("milestone closed despite open issues")
+	}
+}
+
+func TestMilestoneClose_MoveTo(t *testing.T) {
+	conn := newTestDB(t)
+	from := createMilestone(t, conn, "v1.4")
+	to := createMilestone(t, conn, "v1.5")
+	issueID := createIssue(t, conn, "slipped", model.StatusTodo, model.PriorityHigh)
+	assignIssueMilestone(t, conn, issueID, from)
+
+	cmd := closeCmdWithDB(conn)
+	if err := cmd.Flags().Set("move-to", "v1.5"); err != nil {
+		t.Fatal(err)
+	}
+	w, buf := bufWriter(true)
+	if err := runMilestoneClose(cmd, []string{"v1.4"}, w); err != nil {
+		t.Fatalf("runMilestoneClose: %v", err)
+	}
+
+	var resp struct {
+		Data struct {
+			Moved   int    `json:"moved"`
+			MovedTo string `json:"moved_to"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if resp.Data.Moved != 1 || resp.Data.MovedTo != "v1.5" {
+		t.Errorf("result = %+v, want 1 moved to v1.5", resp.Data)
+	}
+
+	issue, err := db.GetIssue(conn, issueID)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if issue.MilestoneID == nil || *issue.MilestoneID != to {
+		t.Errorf("milestone_id = %v, want %d", issue.MilestoneID, to)
+	}
+
+	// Moving into a closed milestone is rejected.
+	cmd = closeCmdWithDB(conn)
+	if err := cmd.Flags().Set("move-to", "v1.4"); err != nil {
+		t.Fatal(err)
+	}
+	w, _ = bufWriter(true)
+	err = runMilestoneClose(cmd, []string{"v1.5"}, w)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Errorf("move to closed milestone err = %v, want validation error", err)
+	}
+}
+
+func TestMilestoneClose_Force(t *testing.T) {
+	conn := newTestDB(t)
+	msID := createMilestone(t, conn, "v1.4")
+	issueID := createIssue(t, conn, "pending", model.StatusTodo, model.PriorityHigh)
+	assignIssueMilestone(t, conn, issueID, msID)
+
+	cmd := closeCmdWithDB(conn)
+	if err := cmd.Flags().Set("force", "true"); err != nil {
+		t.Fatal(err)
+	}
+	w, _ := bufWriter(true)
+	if err := runMilestoneClose(cmd, []string{"v1.4"}, w); err != nil {
+		t.Fatalf("runMilestoneClose: %v", err)
+	}
+
+	issue, err := db.GetIssue(conn, issueID)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if issue.MilestoneID == nil || *issue.MilestoneID != msID {
+		t.Errorf("milestone_id = %v, want unchanged %d", issue.MilestoneID, msID)
+	}
+
+	w, _ = bufWriter(true)
+	err = runMilestoneClose(closeCmdWithDB(conn), []string{"v1.4"}, w)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrConflict {
+		t.Errorf("closing twice err = %v, want conflict", err)
+	}
+}
+
+func TestMilestoneShowJSON(t *testing.T) {
+	conn := newTestDB(t)
+	msID := createMilestone(t, conn, "v1.4")
+	assignIssueMilestone(t, conn, createIssue(t, conn, "shipped", model.StatusDone, model.PriorityHigh), msID)
+	assignIssueMilestone(t, conn, createIssue(t, conn, "pending", model.StatusTodo, model.PriorityHigh), msID)
+	createIssue(t, conn, "unplanned", model.StatusTodo, model.PriorityHigh)
+
+	w, buf := bufWriter(true)
+	if err := runMilestoneShow(cmdWithDB(conn), []string{"v1.4"}, w); err != nil {
+		t.Fatalf("runMilestoneShow: %v", err)
+	}
+
+	var resp struct {
+		Data struct {
+			Milestone struct {
+				Name string `json:"name"`
+			} `json:"milestone"`
+			Done   int `json:"done"`
+			Total  int `json:"total"`
+			Issues []struct {
+				Milestone string `json:"milestone"`
+			} `json:"issues"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if resp.Data.Milestone.Name != "v1.4" || resp.Data.Done != 1 || resp.Data.Total != 2 {
+		t.Errorf("result = %+v, want v1.4 at 1/2", resp.Data)
+	}
+	if len(resp.Data.Issues) != 2 {
+		t.Fatalf("issues = %d, want 2", len(resp.Data.Issues))
+	}
+	for _, issue := range resp.Data.Issues {
+		if issue.Milestone != "v1.4" {
+			t.Errorf("issue milestone = %q, want v1.4", issue.Milestone)
+		}
+	}
+}
@@ -117,7 +117,14 @@ func runNext(cmd *cobra.Command, args []string, w *output.Writer) error {
 
 	var message string
 	if !w.JSONMode {
-		message = render.RenderTable(ready, false)
+		layout, err := getLayout(cmd)
+		if err != nil {
+			return err
+		}
+		if err := hydrateColumns(conn, ready, layout); err != nil {
+			return err
+		}
+		message = render.RenderTable(ready, false, layout)
 	}
 	w.Success(result, message)
 
@@ -160,5 +167,6 @@ func init() {
 	nextCmd.Flags().StringSliceP("label", "l", nil, "Filter by label (repeatable)")
 	nextCmd.Flags().StringSliceP("type", "T", nil, "Filter by type (repeatable)")
 	nextCmd.Flags().Int("limit", 10, "Maximum number of results")
+	addColumnsFlag(nextCmd)
 	rootCmd.AddCommand(nextCmd)
 }
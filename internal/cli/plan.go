@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/signal"
 	"sort"
+	"strconv"
 	"strings"
 	"syscall"
 
@@ -23,24 +24,52 @@ import (
 	"github.com/spf13/cobra"
 )
 
-// planPhaseJSON is the JSON wire format for a single execution phase.
+// unassignedLabel names the group of unassigned issues in plan output.
+const unassignedLabel = "(unassigned)"
+
+// planPhaseJSON is the JSON wire format for a single execution phase. Issues
+// are listed flat, or nested under assignee keys with --by-assignee.
 type planPhaseJSON struct {
-	Phase  int            `json:"phase"`
-	Issues []*model.Issue `json:"issues"`
+	Phase     int                       `json:"phase"`
+	Issues    []*model.Issue            `json:"issues,omitempty"`
+	Assignees map[string][]*model.Issue `json:"assignees,omitempty"`
 }
 
-// planResult is the JSON wire format for the plan command output.
+// planResult is the JSON wire format for the plan command output. Its shape
+// is described by planJSONSchema; SchemaVersion is always planSchemaVersion
+// and Phases is never null.
 type planResult struct {
+	SchemaVersion  int             `json:"schema_version"`
 	Phases         []planPhaseJSON `json:"phases"`
 	TotalIssues    int             `json:"total_issues"`
 	TotalPhases    int             `json:"total_phases"`
 	MaxParallelism int             `json:"max_parallelism"`
+	// Context lists the blockers included only because they block an issue
+	// matching --assignee.
+	Context []string `json:"context,omitempty"`
+	// Pinned maps the issues pinned with --pin to their earliest phase.
+	Pinned map[string]int `json:"pinned,omitempty"`
 }
 
 var planCmd = &cobra.Command{
-	Use:   "plan",
-	Short: "Show execution plan with phased grouping",
+	Use:         "plan",
+	Short:       "Show execution plan with phased grouping",
+	Annotations: map[string]string{"skipDBFlag": "schema"},
+	Long: `Show an execution plan: open issues grouped into phases, where each phase
+only depends on issues in earlier phases.
+
+--pin DKT-9=3 keeps an issue out of phases before the third, moving the
+issues it blocks after it; pinning an issue earlier than its blockers allow
+is an error.
+
+--json output carries a schema_version and follows the JSON Schema printed by
+--schema, so tools can check what they consume:
+
+  docket plan --schema > plan.schema.json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if schema, _ := cmd.Flags().GetBool("schema"); schema {
+			return writePlanSchema(getWriter(cmd))
+		}
 		watchMode, _ := cmd.Flags().GetBool("watch")
 		if watchMode {
 			interval, _ := cmd.Flags().GetDuration("interval")
@@ -69,6 +98,10 @@ func runPlan(cmd *cobra.Command, args []string, w *output.Writer) error {
 	statuses, _ := cmd.Flags().GetStringSlice("status")
 	labels, _ := cmd.Flags().GetStringSlice("label")
 	rootFlag, _ := cmd.Flags().GetString("root")
+	assignees, _ := cmd.Flags().GetStringSlice("assignee")
+	byAssignee, _ := cmd.Flags().GetBool("by-assignee")
+	includeSnoozed, _ := cmd.Flags().GetBool("include-snoozed")
+	pinFlags, _ := cmd.Flags().GetStringSlice("pin")
 
 	// Validate status filter values.
 	for _, s := range statuses {
@@ -77,10 +110,24 @@ func runPlan(cmd *cobra.Command, args []string, w *output.Writer) error {
 		}
 	}
 
-	// Fetch all non-done issues.
+	for i, a := range assignees {
+		resolved, err := resolveAssignee(cmd, conn, a)
+		if err != nil {
+			return err
+		}
+		assignees[i] = resolved
+	}
+
+	pins, err := parsePhasePins(pinFlags)
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+
+	// Fetch all non-done issues, leaving out snoozed ones unless asked.
 	issues, _, err := db.ListIssues(conn, db.ListOptions{
-		IncludeDone: false,
-		Limit:       0,
+		IncludeDone:    false,
+		IncludeSnoozed: includeSnoozed,
+		Limit:          0,
 	})
 	if err != nil {
 		return cmdErr(fmt.Errorf("listing issues: %w", err), output.ErrGeneral)
@@ -101,8 +148,10 @@ func runPlan(cmd *cobra.Command, args []string, w *output.Writer) error {
 
 	// Build plan filters.
 	filters := planner.PlanFilters{
-		Statuses: statuses,
-		Labels:   labels,
+		Statuses:    statuses,
+		Labels:      labels,
+		Assignees:   assignees,
+		PinnedPhase: pins,
 	}
 
 	// Parse --root flag.
@@ -118,7 +167,8 @@ func runPlan(cmd *cobra.Command, args []string, w *output.Writer) error {
 	plan, err := planner.GeneratePlan(dag, filters)
 	if err != nil {
 		var cycleErr *planner.CycleError
-		if errors.As(err, &cycleErr) {
+		var pinErr *planner.PinConflictError
+		if errors.As(err, &cycleErr) || errors.As(err, &pinErr) {
 			return cmdErr(err, output.ErrConflict)
 		}
 		return cmdErr(fmt.Errorf("generating plan: %w", err), output.ErrGeneral)
@@ -127,21 +177,43 @@ func runPlan(cmd *cobra.Command, args []string, w *output.Writer) error {
 	// Build JSON result.
 	phases := make([]planPhaseJSON, len(plan.Phases))
 	for i, phase := range plan.Phases {
-		phases[i] = planPhaseJSON{
-			Phase:  phase.Number,
-			Issues: phase.Issues,
+		phases[i] = planPhaseJSON{Phase: phase.Number}
+		if !byAssignee {
+			phases[i].Issues = phase.Issues
+			continue
+		}
+		phases[i].Assignees = make(map[string][]*model.Issue)
+		for _, g := range planner.GroupByAssignee(phase.Issues) {
+			phases[i].Assignees[assigneeLabel(g.Assignee)] = g.Issues
 		}
 	}
 
 	result := planResult{
+		SchemaVersion:  planSchemaVersion,
 		Phases:         phases,
 		TotalIssues:    plan.TotalIssues,
 		TotalPhases:    plan.TotalPhases,
 		MaxParallelism: plan.MaxParallelism,
 	}
+	for _, id := range sortedIDs(plan.Context) {
+		result.Context = append(result.Context, model.FormatID(id))
+	}
+	for id, pin := range plan.Pinned {
+		if result.Pinned == nil {
+			result.Pinned = make(map[string]int)
+		}
+		result.Pinned[model.FormatID(id)] = pin
+	}
 
 	var message string
-	if !w.JSONMode {
+	switch {
+	case w.JSONMode:
+	case plan.TotalIssues == 0:
+		message, err = emptyIssuesMessage(cmd, conn, "No issues to plan.", "", "status", "label", "root", "assignee")
+		if err != nil {
+			return err
+		}
+	default:
 		message = renderPlanHuman(plan, dag)
 	}
 	w.Success(result, message)
@@ -163,6 +235,7 @@ func renderPlanHuman(plan *planner.Plan, dag *planner.DAG) string {
 
 	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
 	phaseStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	assigneeStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("13"))
 	idStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
 	titleStyle := lipgloss.NewStyle().Bold(true)
 	depStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Italic(true)
@@ -177,37 +250,29 @@ func renderPlanHuman(plan *planner.Plan, dag *planner.DAG) string {
 			b.WriteString(separatorStyle.Render("  ────────────────────────────────"))
 			b.WriteString("\n")
 		}
+		groups := planner.GroupByAssignee(phase.Issues)
 		b.WriteString("\n")
-		if phase.Number == 1 {
-			b.WriteString(phaseStyle.Render(fmt.Sprintf("Phase %d (start):", phase.Number)))
-		} else {
-			b.WriteString(phaseStyle.Render(fmt.Sprintf("Phase %d (parallel, after Phase %d):", phase.Number, phase.Number-1)))
-		}
+		b.WriteString(phaseStyle.Render(phaseHeading(phase, groups)))
 		b.WriteString("\n")
 
-		for _, issue := range phase.Issues {
-			priStyle := lipgloss.NewStyle().Foreground(render.ColorFromName(issue.Priority.Color()))
-			statusIcon := lipgloss.NewStyle().Foreground(render.ColorFromName(issue.Status.Color())).Render(issue.Status.Icon())
-			kindIcon := lipgloss.NewStyle().Foreground(render.ColorFromName(issue.Kind.Color())).Render(issue.Kind.Icon())
+		for _, g := range groups {
+			fmt.Fprintf(&b, "  %s\n", assigneeStyle.Render(assigneeLabel(g.Assignee)))
+			for _, issue := range g.Issues {
+				priStyle := lipgloss.NewStyle().Foreground(render.ColorFromName(issue.Priority.Color()))
+				statusIcon := lipgloss.NewStyle().Foreground(render.ColorFromName(issue.Status.Color())).Render(issue.Status.Icon())
+				kindIcon := lipgloss.NewStyle().Foreground(render.ColorFromName(issue.Kind.Color())).Render(issue.Kind.Icon())
 
-			deps := collectDeps(issue.ID, dag)
-			if len(deps) > 0 {
-				fmt.Fprintf(&b, "  %s %s %s %s %s  %s\n",
-					statusIcon,
-					kindIcon,
-					idStyle.Render(fmt.Sprintf("%-6s", model.FormatID(issue.ID))),
-					priStyle.Render(fmt.Sprintf("[%-8s]", string(issue.Priority))),
-					titleStyle.Render(issue.Title),
-					depStyle.Render(fmt.Sprintf("(depends on %s)", strings.Join(deps, ", "))),
-				)
-			} else {
-				fmt.Fprintf(&b, "  %s %s %s %s %s\n",
+				fmt.Fprintf(&b, "    %s %s %s %s %s",
 					statusIcon,
 					kindIcon,
 					idStyle.Render(fmt.Sprintf("%-6s", model.FormatID(issue.ID))),
 					priStyle.Render(fmt.Sprintf("[%-8s]", string(issue.Priority))),
 					titleStyle.Render(issue.Title),
 				)
+				if note := planIssueNote(issue.ID, plan, dag); note != "" {
+					b.WriteString("  " + depStyle.Render(note))
+				}
+				b.WriteString("\n")
 			}
 		}
 	}
@@ -229,28 +294,21 @@ func renderPlanPlain(plan *planner.Plan, dag *planner.DAG) string {
 	b.WriteString("Execution Plan:\n")
 
 	for _, phase := range plan.Phases {
-		b.WriteString("\n")
-		if phase.Number == 1 {
-			fmt.Fprintf(&b, "Phase %d (start):\n", phase.Number)
-		} else {
-			fmt.Fprintf(&b, "Phase %d (parallel, after Phase %d):\n", phase.Number, phase.Number-1)
-		}
+		groups := planner.GroupByAssignee(phase.Issues)
+		fmt.Fprintf(&b, "\n%s\n", phaseHeading(phase, groups))
 
-		for _, issue := range phase.Issues {
-			deps := collectDeps(issue.ID, dag)
-			if len(deps) > 0 {
-				fmt.Fprintf(&b, "  %-6s [%-8s] %s  (depends on %s)\n",
-					model.FormatID(issue.ID),
-					string(issue.Priority),
-					issue.Title,
-					strings.Join(deps, ", "),
-				)
-			} else {
-				fmt.Fprintf(&b, "  %-6s [%-8s] %s\n",
+		for _, g := range groups {
+			fmt.Fprintf(&b, "  %s\n", assigneeLabel(g.Assignee))
+			for _, issue := range g.Issues {
+				fmt.Fprintf(&b, "    %-6s [%-8s] %s",
 					model.FormatID(issue.ID),
 					string(issue.Priority),
 					issue.Title,
 				)
+				if note := planIssueNote(issue.ID, plan, dag); note != "" {
+					b.WriteString("  " + note)
+				}
+				b.WriteString("\n")
 			}
 		}
 	}
@@ -261,6 +319,85 @@ func renderPlanPlain(plan *planner.Plan, dag *planner.DAG) string {
 	return b.String()
 }
 
+// phaseHeading returns a phase's heading with the number of issues each
+// assignee has in it, e.g. "Phase 2 (parallel, after Phase 1): alice 2,
+// (unassigned) 1".
+func phaseHeading(phase planner.Phase, groups []planner.AssigneeGroup) string {
+	counts := make([]string, len(groups))
+	for i, g := range groups {
+		counts[i] = fmt.Sprintf("%s %d", assigneeLabel(g.Assignee), len(g.Issues))
+	}
+	if len(phase.Issues) == 0 {
+		return fmt.Sprintf("Phase %d: held empty for pinned issues", phase.Number)
+	}
+	if phase.Number == 1 {
+		return fmt.Sprintf("Phase %d (start): %s", phase.Number, strings.Join(counts, ", "))
+	}
+	return fmt.Sprintf("Phase %d (parallel, after Phase %d): %s", phase.Number, phase.Number-1, strings.Join(counts, ", "))
+}
+
+// assigneeLabel returns assignee, or unassignedLabel when it is empty.
+func assigneeLabel(assignee string) string {
+	if assignee == "" {
+		return unassignedLabel
+	}
+	return assignee
+}
+
+// planIssueNote returns the parenthesized note shown after an issue in the
+// plan: its blockers, and whether it is only included as context.
+func planIssueNote(issueID int, plan *planner.Plan, dag *planner.DAG) string {
+	var notes []string
+	if deps := collectDeps(issueID, dag); len(deps) > 0 {
+		notes = append(notes, "depends on "+strings.Join(deps, ", "))
+	}
+	if _, ok := plan.Context[issueID]; ok {
+		notes = append(notes, "blocker context")
+	}
+	if pin, ok := plan.Pinned[issueID]; ok {
+		notes = append(notes, fmt.Sprintf("pinned to phase %d", pin))
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+	return "(" + strings.Join(notes, "; ") + ")"
+}
+
+// parsePhasePins parses --pin values of the form "DKT-9=3" into the earliest
+// phase of each issue.
+func parsePhasePins(values []string) (map[int]int, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	pins := make(map[int]int, len(values))
+	for _, v := range values {
+		idPart, phasePart, ok := strings.Cut(v, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --pin %q: use <id>=<phase>, e.g. DKT-9=3", v)
+		}
+		id, err := model.ParseID(strings.TrimSpace(idPart))
+		if err != nil {
+			return nil, fmt.Errorf("invalid --pin %q: %w", v, err)
+		}
+		phase, err := strconv.Atoi(strings.TrimSpace(phasePart))
+		if err != nil || phase < 1 {
+			return nil, fmt.Errorf("invalid --pin %q: the phase must be a number from 1", v)
+		}
+		pins[id] = phase
+	}
+	return pins, nil
+}
+
+// sortedIDs returns the IDs in set in ascending order.
+func sortedIDs(set map[int]struct{}) []int {
+	ids := make([]int, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
 // collectDeps returns formatted IDs of issues that block the given issue.
 func collectDeps(issueID int, dag *planner.DAG) []string {
 	node, ok := dag.Nodes[issueID]
@@ -295,5 +432,10 @@ func init() {
 	planCmd.Flags().String("root", "", "Scope to a parent issue and its descendants")
 	planCmd.Flags().StringSliceP("status", "s", nil, "Filter by status (repeatable; default: backlog, todo, in-progress)")
 	planCmd.Flags().StringSliceP("label", "l", nil, "Filter by label (repeatable)")
+	planCmd.Flags().StringSliceP("assignee", "a", nil, "Only plan issues assigned to these people, keeping their blockers as context (repeatable; \"me\" for the configured current user)")
+	planCmd.Flags().Bool("include-snoozed", false, "Include snoozed issues")
+	planCmd.Flags().StringSlice("pin", nil, "Keep an issue out of phases before the given one, as <id>=<phase> (repeatable)")
+	planCmd.Flags().Bool("by-assignee", false, "Nest each phase's JSON issues under assignee keys")
+	planCmd.Flags().Bool("schema", false, "Print the JSON Schema of the --json output and exit")
 	rootCmd.AddCommand(planCmd)
 }
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+// planSchemaVersion is the schema_version reported by plan --json. Bump it
+// whenever a field in planResult or planPhaseJSON is renamed, removed, or
+// changes type; adding an optional field does not need a bump.
+const planSchemaVersion = 1
+
+// planJSONSchema is the JSON Schema printed by plan --schema. It describes
+// the full --json envelope and must be kept in step with planResult,
+// planPhaseJSON, and the issue wire format.
+const planJSONSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "docket plan --json",
+  "type": "object",
+  "required": ["ok", "data"],
+  "properties": {
+    "ok": {"type": "boolean"},
+    "message": {"type": "string"},
+    "data": {"$ref": "#/$defs/plan"}
+  },
+  "$defs": {
+    "plan": {
+      "type": "object",
+      "required": ["schema_version", "phases", "total_issues", "total_phases", "max_parallelism"],
+      "properties": {
+        "schema_version": {"type": "integer", "const": 1},
+        "phases": {"type": "array", "items": {"$ref": "#/$defs/phase"}},
+        "total_issues": {"type": "integer", "minimum": 0},
+        "total_phases": {"type": "integer", "minimum": 0},
+        "max_parallelism": {"type": "integer", "minimum": 0},
+        "context": {
+          "description": "Blockers included only because they block an issue matching --assignee.",
+          "type": "array",
+          "items": {"type": "string"}
+        },
+        "pinned": {
+          "description": "The phase each issue pinned with --pin may start in, keyed by issue ID.",
+          "type": "object",
+          "additionalProperties": {"type": "integer", "minimum": 2}
+        }
+      }
+    },
+    "phase": {
+      "type": "object",
+      "required": ["phase"],
+      "properties": {
+        "phase": {"type": "integer", "minimum": 1},
+        "issues": {
+          "description": "The phase's issues; omitted with --by-assignee, or when the phase is held empty for issues pinned to later phases.",
+          "type": "array",
+          "items": {"$ref": "#/$defs/issue"}
+        },
+        "assignees": {
+          "description": "The phase's issues keyed by assignee, \"(unassigned)\" for none; only with --by-assignee.",
+          "type": "object",
+          "additionalProperties": {"type": "array", "items": {"$ref": "#/$defs/issue"}}
+        }
+      }
+    },
+    "issue": {
+      "type": "object",
+      "required": ["id", "title", "description", "status", "priority", "kind", "assignee", "labels", "files", "docs", "created_at", "updated_at"],
+      "properties": {
+        "id": {"type": "string"},
+        "parent_id": {"type": "string"},
+        "title": {"type": "string"},
+        "description": {"type": "string"},
+        "status": {"type": "string"},
+        "priority": {"type": "string"},
+        "kind": {"type": "string"},
+        "assignee": {"type": "string"},
+        "labels": {"type": "array", "items": {"type": "string"}},
+        "files": {"type": "array", "items": {"type": "string"}},
+        "docs": {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "required": ["id", "type", "title", "status"],
+            "properties": {
+              "id": {"type": "string"},
+              "type": {"type": "string"},
+              "title": {"type": "string"},
+              "status": {"type": "string"}
+            }
+          }
+        },
+        "links": {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "required": ["id", "issue_id", "url", "title", "created_at"],
+            "properties": {
+              "id": {"type": "integer"},
+              "issue_id": {"type": "string"},
+              "url": {"type": "string"},
+              "title": {"type": "string"},
+              "created_at": {"type": "string", "format": "date-time"}
+            }
+          }
+        },
+        "milestone_id": {"type": "integer"},
+        "milestone": {"type": "string"},
+        "comment_count": {"type": "integer"},
+        "last_comment_at": {"type": "string", "format": "date-time"},
+        "created_at": {"type": "string", "format": "date-time"},
+        "updated_at": {"type": "string", "format": "date-time"},
+        "started_at": {"type": "string", "format": "date-time"},
+        "completed_at": {"type": "string", "format": "date-time"},
+        "snoozed_until": {"type": "string", "format": "date-time"},
+        "sort_order": {"type": "integer"},
+        "pinned": {"type": "boolean"}
+      }
+    }
+  }
+}
+`
+
+// writePlanSchema prints the plan JSON Schema to w's stdout as is, without
+// the JSON envelope, so it can be saved and fed to a validator directly.
+func writePlanSchema(w *output.Writer) error {
+	if _, err := fmt.Fprint(w.Stdout, planJSONSchema); err != nil {
+		return cmdErr(fmt.Errorf("writing schema: %w", err), output.ErrGeneral)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// checkSchema reports where value does not match schema. It covers the
+// keywords planJSONSchema uses: $ref into $defs, type, const, minimum,
+// required, properties, items, and additionalProperties.
+func checkSchema(root, schema map[string]any, value any, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/$defs/")
+		def, ok := root["$defs"].(map[string]any)[name].(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: unresolvable $ref %q", path, ref)}
+		}
+		return checkSchema(root, def, value, path)
+	}
+
+	var errs []string
+	switch schema["type"] {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: got %T, want object", path, value)}
+		}
+		required, _ := schema["required"].([]any)
+		for _, r := range required {
+			if _, ok := obj[r.(string)]; !ok {
+				errs = append(errs, fmt.Sprintf("%s: missing required %q", path, r))
+			}
+		}
+		props, _ := schema["properties"].(map[string]any)
+		extra, _ := schema["additionalProperties"].(map[string]any)
+		for k, v := range obj {
+			if prop, ok := props[k].(map[string]any); ok {
+				errs = append(errs, checkSchema(root, prop, v, path+"."+k)...)
+			} else if extra != nil {
+				errs = append(errs, checkSchema(root, extra, v, path+"."+k)...)
+			} else {
+				errs = append(errs, fmt.Sprintf("%s: undocumented property %q", path, k))
+			}
+		}
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: got %T, want array", path, value)}
+		}
+		items := schema["items"].(map[string]any)
+		for i, v := range arr {
+			errs = append(errs, checkSchema(root, items, v, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			errs = append(errs, fmt.Sprintf("%s: got %T, want string", path, value))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			errs = append(errs, fmt.Sprintf("%s: got %T, want boolean", path, value))
+		}
+	case "integer":
+		n, ok := value.(float64)
+		if !ok || n != float64(int(n)) {
+			return []string{fmt.Sprintf("%s: got %v, want integer", path, value)}
+		}
+		if c, ok := schema["const"].(float64); ok && n != c {
+			errs = append(errs, fmt.Sprintf("%s: got %v, want %v", path, n, c))
+		}
+		if min, ok := schema["minimum"].(float64); ok && n < min {
+			errs = append(errs, fmt.Sprintf("%s: %v is below minimum %v", path, n, min))
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("%s: schema has unsupported type %v", path, schema["type"]))
+	}
+	return errs
+}
+
+func loadPlanSchema(t *testing.T) map[string]any {
+	t.Helper()
+	w, buf := bufWriter(false)
+	if err := writePlanSchema(w); err != nil {
+		t.Fatalf("writePlanSchema: %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+	return schema
+}
+
+func TestPlanSchemaMatchesOutput(t *testing.T) {
+	schema := loadPlanSchema(t)
+	version := schema["$defs"].(map[string]any)["plan"].(map[string]any)["properties"].(map[string]any)["schema_version"].(map[string]any)["const"]
+	if version != float64(planSchemaVersion) {
+		t.Errorf("schema pins schema_version %v, want %d", version, planSchemaVersion)
+	}
+
+	conn := newTestDB(t)
+	blocker := createIssueWithFile(t, conn, "blocker", "internal/cli/plan.go")
+	blocked := createIssue(t, conn, "blocked", model.StatusTodo, model.PriorityMedium)
+	createIssue(t, conn, "free", model.StatusBacklog, model.PriorityLow)
+	if err := db.UpdateIssue(conn, blocked, map[string]interface{}{"assignee": "alice"}, ""); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+	if _, err := db.CreateRelation(conn, &model.Relation{SourceIssueID: blocker, TargetIssueID: blocked, RelationType: model.RelationBlocks}); err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+
+	for _, flags := range []map[string]string{
+		{},
+		{"by-assignee": "true"},
+		{"assignee": "alice"},
+		{"status": "done"}, // nothing to plan
+	} {
+		cmd := planCmdWithDB(conn)
+		for k, v := range flags {
+			cmd.Flags().Set(k, v)
+		}
+		w, buf := bufWriter(true)
+		if err := runPlan(cmd, nil, w); err != nil {
+			t.Fatalf("runPlan %v: %v", flags, err)
+		}
+		var out any
+		if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+			t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+		}
+		for _, e := range checkSchema(schema, schema, out, "$") {
+			t.Errorf("plan %v: %s", flags, e)
+		}
+	}
+}
+
+func TestPlanJSON_EmptyPhasesIsArray(t *testing.T) {
+	conn := newTestDB(t)
+	w, buf := bufWriter(true)
+	if err := runPlan(planCmdWithDB(conn), nil, w); err != nil {
+		t.Fatalf("runPlan: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"phases":[]`) {
+		t.Errorf("empty plan should have phases []:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), fmt.Sprintf(`"schema_version":%d`, planSchemaVersion)) {
+		t.Errorf("missing schema_version:\n%s", buf.String())
+	}
+}
+
+func TestPlanSchemaWithoutDatabase(t *testing.T) {
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "plan.schema.json")
+	out, err := os.Create(outPath)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = out
+	t.Cleanup(func() {
+		os.Stdout = stdout
+		out.Close()
+		planCmd.Flags().Set("schema", "false")
+		rootCmd.PersistentFlags().Set("db", "")
+		rootCmd.SetArgs(nil)
+	})
+
+	rootCmd.SetArgs([]string{"plan", "--schema", "--db", filepath.Join(dir, "missing")})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("plan --schema without a database: %v", err)
+	}
+	got, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(got) != planJSONSchema {
+		t.Errorf("plan --schema printed:\n%s", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "missing")); !os.IsNotExist(err) {
+		t.Errorf("plan --schema created the database directory: %v", err)
+	}
+}
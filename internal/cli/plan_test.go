@@ -3,9 +3,13 @@ package cli
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/ALT-F4-LLC/docket/internal/db"
 	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/planner"
 	"github.com/spf13/cobra"
 )
 
@@ -14,6 +18,9 @@ func planCmdWithDB(conn *sql.DB) *cobra.Command {
 	cmd.Flags().StringSlice("status", nil, "")
 	cmd.Flags().StringSlice("label", nil, "")
 	cmd.Flags().String("root", "", "")
+	cmd.Flags().StringSlice("assignee", nil, "")
+	cmd.Flags().Bool("by-assignee", false, "")
+	cmd.Flags().StringSlice("pin", nil, "")
 	return cmd
 }
 
@@ -104,3 +111,175 @@ func TestPlanJSON_FilesAndDocsEmptyAreArrays(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderPlanPlain_GroupsPhaseByAssignee(t *testing.T) {
+	issues := []*model.Issue{
+		{ID: 1, Title: "Schema", Status: model.StatusTodo, Priority: model.PriorityHigh, Assignee: "bob"},
+		{ID: 2, Title: "Docs", Status: model.StatusTodo, Priority: model.PriorityLow},
+		{ID: 3, Title: "API", Status: model.StatusTodo, Priority: model.PriorityHigh, Assignee: "alice"},
+		{ID: 4, Title: "CLI", Status: model.StatusTodo, Priority: model.PriorityMedium, Assignee: "alice"},
+	}
+	relations := []model.Relation{
+		{SourceIssueID: 1, TargetIssueID: 3, RelationType: model.RelationBlocks},
+	}
+	dag := planner.BuildDAG(issues, relations)
+	plan, err := planner.GeneratePlan(dag, planner.PlanFilters{Assignees: []string{"alice"}})
+	if err != nil {
+		t.Fatalf("GeneratePlan: %v", err)
+	}
+
+	got := renderPlanPlain(plan, dag)
+
+	want := `Execution Plan:
+
+Phase 1 (start): alice 1, bob 1
+  alice
+    DKT-4  [medium  ] CLI
+  bob
+    DKT-1  [high    ] Schema  (blocker context)
+
+Phase 2 (parallel, after Phase 1): alice 1
+  alice
+    DKT-3  [high    ] API  (depends on DKT-1)
+
+Summary: 3 issues, 2 phases, max parallelism: 2`
+	if got != want {
+		t.Errorf("renderPlanPlain =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderPlanPlain_UnassignedBucketLast(t *testing.T) {
+	issues := []*model.Issue{
+		{ID: 1, Title: "Free", Status: model.StatusTodo, Priority: model.PriorityHigh},
+		{ID: 2, Title: "Taken", Status: model.StatusTodo, Priority: model.PriorityLow, Assignee: "zoe"},
+	}
+	dag := planner.BuildDAG(issues, nil)
+	plan, err := planner.GeneratePlan(dag, planner.PlanFilters{})
+	if err != nil {
+		t.Fatalf("GeneratePlan: %v", err)
+	}
+
+	got := renderPlanPlain(plan, dag)
+	if !strings.Contains(got, "Phase 1 (start): zoe 1, (unassigned) 1\n  zoe\n    DKT-2 ") ||
+		!strings.Contains(got, "  (unassigned)\n    DKT-1 ") {
+		t.Errorf("unexpected grouping:\n%s", got)
+	}
+}
+
+func TestPlanJSON_ByAssigneeNestsIssues(t *testing.T) {
+	conn := newTestDB(t)
+	blocker := createIssue(t, conn, "blocker", model.StatusTodo, model.PriorityHigh)
+	mine := createIssue(t, conn, "mine", model.StatusTodo, model.PriorityHigh)
+	createIssue(t, conn, "free", model.StatusTodo, model.PriorityLow)
+	if err := db.UpdateIssue(conn, mine, map[string]interface{}{"assignee": "alice"}, ""); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+	if _, err := db.CreateRelation(conn, &model.Relation{SourceIssueID: blocker, TargetIssueID: mine, RelationType: model.RelationBlocks}); err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+
+	cmd := planCmdWithDB(conn)
+	cmd.Flags().Set("by-assignee", "true")
+	w, buf := bufWriter(true)
+	if err := runPlan(cmd, nil, w); err != nil {
+		t.Fatalf("runPlan: %v", err)
+	}
+	var env struct {
+		Data struct {
+			Phases []struct {
+				Issues    json.RawMessage `json:"issues"`
+				Assignees map[string][]struct {
+					ID string `json:"id"`
+				} `json:"assignees"`
+			} `json:"phases"`
+			Context []string `json:"context"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if len(env.Data.Phases) != 2 {
+		t.Fatalf("phases = %d, want 2\n%s", len(env.Data.Phases), buf.String())
+	}
+	first := env.Data.Phases[0]
+	if first.Issues != nil {
+		t.Errorf("flat issues present with --by-assignee: %s", first.Issues)
+	}
+	if got := first.Assignees[unassignedLabel]; len(got) != 2 {
+		t.Errorf("phase 1 unassigned = %v, want 2 issues", got)
+	}
+	if got := env.Data.Phases[1].Assignees["alice"]; len(got) != 1 || got[0].ID != model.FormatID(mine) {
+		t.Errorf("phase 2 alice = %v, want [%s]", got, model.FormatID(mine))
+	}
+	if env.Data.Context != nil {
+		t.Errorf("context = %v, want none without --assignee", env.Data.Context)
+	}
+
+	cmd = planCmdWithDB(conn)
+	cmd.Flags().Set("assignee", "alice")
+	w, buf = bufWriter(true)
+	if err := runPlan(cmd, nil, w); err != nil {
+		t.Fatalf("runPlan --assignee: %v", err)
+	}
+	var filtered planJSON
+	if err := json.Unmarshal(buf.Bytes(), &filtered); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if filtered.Data.TotalIssues != 2 {
+		t.Errorf("total_issues = %d, want the blocker and alice's issue", filtered.Data.TotalIssues)
+	}
+	if !strings.Contains(buf.String(), `"context":["`+model.FormatID(blocker)+`"]`) {
+		t.Errorf("missing blocker context:\n%s", buf.String())
+	}
+}
+
+func TestPlanPin(t *testing.T) {
+	conn := newTestDB(t)
+	first := createIssue(t, conn, "first", model.StatusTodo, model.PriorityHigh)
+	later := createIssue(t, conn, "later", model.StatusTodo, model.PriorityHigh)
+	after := createIssue(t, conn, "after", model.StatusTodo, model.PriorityHigh)
+	if _, err := db.CreateRelation(conn, &model.Relation{SourceIssueID: later, TargetIssueID: after, RelationType: model.RelationBlocks}); err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+
+	cmd := planCmdWithDB(conn)
+	cmd.Flags().Set("pin", model.FormatID(later)+"=2")
+	w, buf := bufWriter(true)
+	if err := runPlan(cmd, nil, w); err != nil {
+		t.Fatalf("runPlan: %v", err)
+	}
+	var pj planJSON
+	if err := json.Unmarshal(buf.Bytes(), &pj); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	var phases [][]string
+	for _, p := range pj.Data.Phases {
+		var ids []string
+		for _, issue := range p.Issues {
+			ids = append(ids, issue.ID)
+		}
+		phases = append(phases, ids)
+	}
+	want := [][]string{{model.FormatID(first)}, {model.FormatID(later)}, {model.FormatID(after)}}
+	if fmt.Sprint(phases) != fmt.Sprint(want) {
+		t.Errorf("phases = %v, want %v", phases, want)
+	}
+	if !strings.Contains(buf.String(), `"pinned":{"`+model.FormatID(later)+`":2}`) {
+		t.Errorf("missing pinned issue:\n%s", buf.String())
+	}
+
+	cmd = planCmdWithDB(conn)
+	cmd.Flags().Set("pin", model.FormatID(after)+"=1")
+	w, _ = bufWriter(true)
+	if err := runPlan(cmd, nil, w); err == nil || !strings.Contains(err.Error(), "pinned to phase 1") {
+		t.Errorf("pin before a blocker: error = %v", err)
+	}
+
+	for _, bad := range []string{"DKT-1", "DKT-1=0", "nope=2"} {
+		cmd = planCmdWithDB(conn)
+		cmd.Flags().Set("pin", bad)
+		if err := runPlan(cmd, nil, w); err == nil {
+			t.Errorf("--pin %q: expected an error", bad)
+		}
+	}
+}
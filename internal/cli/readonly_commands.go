@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+// readOnlyCommands lists the commands that never modify the database, keyed by
+// command path. Every other command is treated as mutating and refused when
+// the database is opened read-only, so a new command must be added here to be
+// usable on a read-only database.
+var readOnlyCommands = map[string]bool{
+	"docket board":                   true,
+	"docket cleanup":                 true, // deleting calls requireWritable
+	"docket comment list":            true,
+	"docket config":                  true,
+	"docket config anonymous-author": true, // setting an author calls requireWritable
+	"docket config link-template":    true, // setting a template calls requireWritable
+	"docket config sort":             true, // setting a sort calls requireWritable
+	"docket config transitions":      true, // setting transitions calls requireWritable
+	"docket config user":             true, // setting a user calls requireWritable
+	"docket digest":                  true,
+	"docket doc comment list":        true,
+	"docket doc list":                true,
+	"docket doc show":                true,
+	"docket doctor":                  true, // --rebuild-stats calls requireWritable
+	"docket export":                  true,
+	"docket files owners":            true,
+	"docket inbox":                   true,
+	"docket issue branch":            true, // --checkout calls requireWritable
+	"docket issue comment list":      true,
+	"docket issue exists":            true,
+	"docket issue file list":         true,
+	"docket issue graph":             true,
+	"docket issue label list":        true,
+	"docket issue link list":         true,
+	"docket issue list":              true,
+	"docket issue log":               true,
+	"docket issue show":              true,
+	"docket issue tasklist":          true,
+	"docket milestone list":          true,
+	"docket milestone show":          true,
+	"docket next":                    true,
+	"docket plan":                    true,
+	"docket recent":                  true,
+	"docket relation type list":      true,
+	"docket release-notes":           true, // --save-marker calls requireWritable
+	"docket report workload":         true,
+	"docket standup":                 true,
+	"docket stats":                   true,
+	"docket status":                  true,
+	"docket suggest-links":           true, // --apply calls requireWritable
+	"docket template list":           true,
+	"docket trash list":              true,
+	"docket version":                 true,
+	"docket vote list":               true,
+	"docket vote result":             true,
+	"docket vote show":               true,
+}
+
+func isReadOnlyCommand(cmd *cobra.Command) bool {
+	return readOnlyCommands[cmd.CommandPath()]
+}
+
+// readOnlyMode reports whether the database is to be opened read-only, via
+// --read-only or DOCKET_READONLY.
+func readOnlyMode(cmd *cobra.Command) bool {
+	if readOnly, _ := cmd.Flags().GetBool("read-only"); readOnly {
+		return true
+	}
+	cfg := getCfg(cmd)
+	return cfg != nil && cfg.ReadOnly
+}
+
+// requireWritable fails with a validation error in read-only mode. Mutating
+// commands are refused before they run; read-only commands that can also
+// write, such as "config user <name>", call it before writing.
+func requireWritable(cmd *cobra.Command) error {
+	if readOnlyMode(cmd) {
+		return cmdErr(fmt.Errorf("database opened read-only: %q modifies the database", cmd.CommandPath()), output.ErrValidation)
+	}
+	return nil
+}
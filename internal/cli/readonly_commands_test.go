@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ALT-F4-LLC/docket/internal/config"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+func TestReadOnlyCommandsExist(t *testing.T) {
+	runnable := make(map[string]bool)
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		if c.Runnable() {
+			runnable[c.CommandPath()] = true
+		}
+		for _, child := range c.Commands() {
+			walk(child)
+		}
+	}
+	walk(rootCmd)
+
+	for path := range readOnlyCommands {
+		if !runnable[path] {
+			t.Errorf("readOnlyCommands lists %q, which is not a runnable command", path)
+		}
+	}
+	for _, path := range []string{"docket issue create", "docket issue edit", "docket init", "docket import"} {
+		if readOnlyCommands[path] {
+			t.Errorf("mutating command %q is listed as read-only", path)
+		}
+	}
+}
+
+func TestRequireWritable(t *testing.T) {
+	cmd := cmdWithDB(nil)
+	cmd.Flags().Bool("read-only", false, "")
+	if err := requireWritable(cmd); err != nil {
+		t.Fatalf("requireWritable without read-only mode: %v", err)
+	}
+
+	cmd.Flags().Set("read-only", "true")
+	var ce *CmdError
+	if err := requireWritable(cmd); !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Errorf("requireWritable with --read-only = %v, want a validation error", err)
+	}
+
+	// DOCKET_READONLY is carried by the resolved config.
+	cmd = cmdWithDB(nil)
+	cmd.SetContext(context.WithValue(cmd.Context(), cfgKey, &config.Config{ReadOnly: true}))
+	if err := requireWritable(cmd); err == nil {
+		t.Error("requireWritable with DOCKET_READONLY succeeded")
+	}
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+)
+
+var recentCmd = &cobra.Command{
+	Use:   "recent",
+	Short: "List issues updated recently, most recent first",
+	Long: `List issues updated recently, most recent first.
+
+Done and snoozed issues are included, so the list shows what was finished as
+well as what moved. Use --since to look further back than the last 24 hours.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRecent(cmd, args, getWriter(cmd))
+	},
+}
+
+func runRecent(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	age, err := parseAge(sinceFlag)
+	if err != nil {
+		return cmdErr(fmt.Errorf("--since: %w", err), output.ErrValidation)
+	}
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	layout, err := getLayout(cmd)
+	if err != nil {
+		return err
+	}
+	layout.KeepOrder = true
+
+	issues, total, err := db.ListIssues(conn, db.ListOptions{
+		IncludeDone:    true,
+		IncludeSnoozed: true,
+		UpdatedSince:   time.Now().Add(-age),
+		SortKeys:       []db.SortKey{{Field: "updated_at", Dir: "desc"}, {Field: "id", Dir: "desc"}},
+		Limit:          limit,
+	})
+	if err != nil {
+		return cmdErr(fmt.Errorf("listing issues: %w", err), output.ErrGeneral)
+	}
+
+	if err := db.HydrateCommentCounts(conn, issues); err != nil {
+		return cmdErr(fmt.Errorf("fetching comment counts: %w", err), output.ErrGeneral)
+	}
+
+	if err := hydrateColumns(conn, issues, layout); err != nil {
+		return err
+	}
+
+	result := newListResult(issues, total, 0)
+	var message string
+	switch {
+	case w.JSONMode:
+	case len(issues) == 0:
+		message = render.EmptyState(fmt.Sprintf("No issues updated in the last %s.", sinceFlag), "", false)
+	default:
+		message = render.RenderTable(issues, false, layout)
+	}
+	w.Success(result, message)
+	return nil
+}
+
+func init() {
+	recentCmd.Flags().String("since", "24h", "How far back to look (e.g. 18h, 3d, 1w)")
+	recentCmd.Flags().Int("limit", 50, "Maximum number of results")
+	addColumnsFlag(recentCmd)
+	rootCmd.AddCommand(recentCmd)
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestRecent_IncludesDoneAndSkipsOld(t *testing.T) {
+	conn := newTestDB(t)
+	old := createIssue(t, conn, "Old", model.StatusTodo, model.PriorityLow)
+	done := createIssue(t, conn, "Finished", model.StatusDone, model.PriorityLow)
+	open := createIssue(t, conn, "Moving", model.StatusInProgress, model.PriorityLow)
+	if _, err := conn.Exec(`UPDATE issues SET updated_at = '2020-01-01T00:00:00Z' WHERE id = ?`, old); err != nil {
+		t.Fatalf("backdating updated_at: %v", err)
+	}
+	if _, err := conn.Exec(`UPDATE issues SET updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now', '-1 hour') WHERE id = ?`, done); err != nil {
+		t.Fatalf("backdating updated_at: %v", err)
+	}
+
+	cmd := cmdWithDB(conn)
+	cmd.Flags().String("since", "24h", "")
+	cmd.Flags().Int("limit", 50, "")
+	w, buf := bufWriter(true)
+	if err := runRecent(cmd, nil, w); err != nil {
+		t.Fatalf("runRecent: %v", err)
+	}
+
+	var env struct {
+		Data struct {
+			Issues []struct {
+				ID string `json:"id"`
+			} `json:"issues"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	var got []string
+	for _, issue := range env.Data.Issues {
+		got = append(got, issue.ID)
+	}
+	if want := []string{model.FormatID(open), model.FormatID(done)}; !slices.Equal(got, want) {
+		t.Errorf("recent issues = %v, want %v", got, want)
+	}
+}
+
+func TestRecent_JSONCounts(t *testing.T) {
+	conn := newTestDB(t)
+	for _, title := range []string{"One", "Two", "Three"} {
+		createIssue(t, conn, title, model.StatusTodo, model.PriorityLow)
+	}
+
+	cmd := cmdWithDB(conn)
+	cmd.Flags().String("since", "24h", "")
+	cmd.Flags().Int("limit", 2, "")
+	w, buf := bufWriter(true)
+	if err := runRecent(cmd, nil, w); err != nil {
+		t.Fatalf("runRecent: %v", err)
+	}
+
+	var env struct {
+		Data listResult `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	got := env.Data
+	if len(got.Issues) != 2 || got.Returned != 2 || got.Total != 3 || got.Offset != 0 {
+		t.Errorf("recent = %d issues, returned %d, total %d, offset %d; want 2, 2, 3, 0",
+			len(got.Issues), got.Returned, got.Total, got.Offset)
+	}
+}
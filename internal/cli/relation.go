@@ -0,0 +1,13 @@
+package cli
+
+import "github.com/spf13/cobra"
+
+var relationCmd = &cobra.Command{
+	Use:     "relation",
+	Short:   "Manage relations by ID",
+	Aliases: []string{"rel"},
+}
+
+func init() {
+	rootCmd.AddCommand(relationCmd)
+}
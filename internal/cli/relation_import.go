@@ -0,0 +1,254 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/config"
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// relationImportEdge is the JSON wire format for one imported line.
+type relationImportEdge struct {
+	Line         int    `json:"line"`
+	ID           int    `json:"id,omitempty"`
+	Source       string `json:"source"`
+	RelationType string `json:"relation_type"`
+	Target       string `json:"target"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// relationImportResult is the JSON wire format for the relation import
+// command output.
+type relationImportResult struct {
+	DryRun  bool                 `json:"dry_run"`
+	Created []relationImportEdge `json:"created"`
+	Skipped []relationImportEdge `json:"skipped"`
+}
+
+// relationImportLineError is one rejected line in the JSON error envelope.
+type relationImportLineError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// relationImportFailure is the data attached to the JSON error envelope when
+// an import is rejected.
+type relationImportFailure struct {
+	Errors []relationImportLineError `json:"errors"`
+}
+
+var relationImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Create relations from an edge list file",
+	Long: `Create relations from a file with one relation per line, read from stdin
+when the path is "-":
+
+  DKT-3 blocks DKT-7
+  DKT-7 depends-on DKT-2
+
+or as CSV with source,type,target columns and an optional header:
+
+  source,type,target
+  DKT-3,blocks,DKT-7
+
+Relation types are accepted in the same forms as 'docket issue link add'.
+Blank lines and lines starting with # are ignored.
+
+Every line is checked before anything is written: issues must exist, types
+must be known, and directional relations must not form cycles, including
+cycles formed by lines of the same file. Relations that already exist, in
+either direction, are skipped, so a file can be imported again. If any line
+is rejected, nothing is created and every rejected line is reported.
+--dry-run reports what would happen without writing.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRelationImport(cmd, args, getWriter(cmd))
+	},
+}
+
+func runRelationImport(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	r := cmd.InOrStdin()
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return cmdErr(fmt.Errorf("opening relation file: %w", err), output.ErrValidation)
+		}
+		defer f.Close()
+		r = f
+	}
+	lines, parseErrs, err := parseRelationEdges(r)
+	if err != nil {
+		return cmdErr(fmt.Errorf("reading relation file: %w", err), output.ErrGeneral)
+	}
+	if len(lines) == 0 && len(parseErrs) == 0 {
+		return cmdErr(fmt.Errorf("relation file has no relations"), output.ErrValidation)
+	}
+
+	// Lines that did not parse are reported together with the lines the
+	// database rejects, so check the rest without writing.
+	result, err := db.ImportRelations(conn, lines, db.RelationImportOptions{
+		DryRun:    dryRun || len(parseErrs) > 0,
+		ChangedBy: config.DefaultAuthor(),
+	})
+	var importErr *db.RelationImportError
+	switch {
+	case errors.As(err, &importErr):
+		return relationImportErr(mergeRelationLineErrors(parseErrs, importErr.Lines))
+	case err != nil:
+		return cmdErr(fmt.Errorf("importing relations: %w", err), output.ErrGeneral)
+	case len(parseErrs) > 0:
+		return relationImportErr(parseErrs)
+	}
+
+	out := relationImportResult{
+		DryRun:  dryRun,
+		Created: make([]relationImportEdge, len(result.Created)),
+		Skipped: make([]relationImportEdge, len(result.Skipped)),
+	}
+	for i, o := range result.Created {
+		out.Created[i] = newRelationImportEdge(o)
+	}
+	for i, o := range result.Skipped {
+		out.Skipped[i] = newRelationImportEdge(o)
+	}
+	if w.JSONMode {
+		w.Success(out, "")
+		return nil
+	}
+	w.Success(out, relationImportSummary(out))
+	return nil
+}
+
+// parseRelationEdges reads relations from r, one per line, as
+// "DKT-3 blocks DKT-7" or "DKT-3,blocks,DKT-7". Lines that cannot be parsed
+// are returned as errors; err is set only when r cannot be read.
+func parseRelationEdges(r io.Reader) (lines []db.RelationImportLine, parseErrs []*db.RelationLineError, err error) {
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		var fields []string
+		if strings.Contains(text, ",") {
+			fields = strings.Split(text, ",")
+			for i := range fields {
+				fields[i] = strings.TrimSpace(fields[i])
+			}
+			if len(lines) == 0 && len(parseErrs) == 0 && strings.EqualFold(fields[0], "source") {
+				continue
+			}
+		} else {
+			fields = strings.Fields(text)
+		}
+
+		rel, err := parseRelationEdge(fields)
+		if err != nil {
+			parseErrs = append(parseErrs, &db.RelationLineError{Line: lineNo, Err: err})
+			continue
+		}
+		lines = append(lines, db.RelationImportLine{Line: lineNo, Relation: rel})
+	}
+	return lines, parseErrs, scanner.Err()
+}
+
+// parseRelationEdge parses the source, type and target fields of one line.
+func parseRelationEdge(fields []string) (model.Relation, error) {
+	if len(fields) != 3 {
+		return model.Relation{}, fmt.Errorf("expected <source> <type> <target>, got %d fields", len(fields))
+	}
+	source, err := model.ParseID(fields[0])
+	if err != nil {
+		return model.Relation{}, fmt.Errorf("source: %w", err)
+	}
+	relType, err := model.ParseRelationType(fields[1])
+	if err != nil {
+		return model.Relation{}, err
+	}
+	target, err := model.ParseID(fields[2])
+	if err != nil {
+		return model.Relation{}, fmt.Errorf("target: %w", err)
+	}
+	return model.Relation{SourceIssueID: source, TargetIssueID: target, RelationType: relType}, nil
+}
+
+// mergeRelationLineErrors merges two lists of line errors, each in line
+// order, into one.
+func mergeRelationLineErrors(a, b []*db.RelationLineError) []*db.RelationLineError {
+	merged := make([]*db.RelationLineError, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if a[0].Line < b[0].Line {
+			merged, a = append(merged, a[0]), a[1:]
+		} else {
+			merged, b = append(merged, b[0]), b[1:]
+		}
+	}
+	return append(append(merged, a...), b...)
+}
+
+// relationImportErr reports the rejected lines of an import, one per line
+// for humans and as a list in the JSON error envelope.
+func relationImportErr(lines []*db.RelationLineError) *CmdError {
+	msgs := make([]string, len(lines))
+	failure := relationImportFailure{Errors: make([]relationImportLineError, len(lines))}
+	for i, le := range lines {
+		msgs[i] = "  " + le.Error()
+		failure.Errors[i] = relationImportLineError{Line: le.Line, Error: le.Err.Error()}
+	}
+	return &CmdError{
+		Err:  fmt.Errorf("relation import rejected, nothing was changed:\n%s", strings.Join(msgs, "\n")),
+		Code: output.ErrValidation,
+		Data: failure,
+	}
+}
+
+func newRelationImportEdge(o db.RelationImportOutcome) relationImportEdge {
+	return relationImportEdge{
+		Line:         o.Line,
+		ID:           o.Relation.ID,
+		Source:       model.FormatID(o.Relation.SourceIssueID),
+		RelationType: string(o.Relation.RelationType),
+		Target:       model.FormatID(o.Relation.TargetIssueID),
+		Reason:       o.Reason,
+	}
+}
+
+// relationImportSummary describes an import for human output, one line per
+// created or skipped relation.
+func relationImportSummary(r relationImportResult) string {
+	var b strings.Builder
+	if r.DryRun {
+		fmt.Fprintf(&b, "Dry run: %d relation(s) would be created, %d skipped; nothing was changed", len(r.Created), len(r.Skipped))
+	} else {
+		fmt.Fprintf(&b, "Created %d relation(s), skipped %d", len(r.Created), len(r.Skipped))
+	}
+	for _, e := range r.Created {
+		fmt.Fprintf(&b, "\n  line %-4d %s %s %s", e.Line, e.Source, e.RelationType, e.Target)
+		if !r.DryRun {
+			fmt.Fprintf(&b, " (relation #%d)", e.ID)
+		}
+	}
+	for _, e := range r.Skipped {
+		fmt.Fprintf(&b, "\n  line %-4d skipped: %s", e.Line, e.Reason)
+	}
+	return b.String()
+}
+
+func init() {
+	relationImportCmd.Flags().Bool("dry-run", false, "Check every line and report what would be created without writing")
+	relationCmd.AddCommand(relationImportCmd)
+}
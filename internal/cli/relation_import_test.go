@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func relationImportCmdWithDB(conn *sql.DB, input string, dryRun bool) *cobra.Command {
+	cmd := cmdWithDB(conn)
+	cmd.Flags().Bool("dry-run", dryRun, "")
+	cmd.SetIn(strings.NewReader(input))
+	return cmd
+}
+
+func TestParseRelationEdges(t *testing.T) {
+	input := strings.Join([]string{
+		"source,type,target",
+		"DKT-1,blocks,DKT-2",
+		"",
+		"# a comment",
+		"DKT-2 depends-on DKT-3",
+		"DKT-3 blocks",
+		"DKT-3,nope,DKT-4",
+		"  3   relates-to   DKT-4  ",
+	}, "\n")
+	lines, parseErrs, err := parseRelationEdges(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseRelationEdges: %v", err)
+	}
+
+	want := []db.RelationImportLine{
+		{Line: 2, Relation: model.Relation{SourceIssueID: 1, TargetIssueID: 2, RelationType: model.RelationBlocks}},
+		{Line: 5, Relation: model.Relation{SourceIssueID: 2, TargetIssueID: 3, RelationType: model.RelationDependsOn}},
+		{Line: 8, Relation: model.Relation{SourceIssueID: 3, TargetIssueID: 4, RelationType: model.RelationRelatesTo}},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d: %+v", len(lines), len(want), lines)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("lines[%d] = %+v, want %+v", i, lines[i], want[i])
+		}
+	}
+	if len(parseErrs) != 2 || parseErrs[0].Line != 6 || parseErrs[1].Line != 7 {
+		t.Errorf("parse errors = %v, want lines 6 and 7", parseErrs)
+	}
+}
+
+func TestRelationImport_JSON(t *testing.T) {
+	conn := newTestDB(t)
+	a := createIssue(t, conn, "A", model.StatusTodo, model.PriorityMedium)
+	b := createIssue(t, conn, "B", model.StatusTodo, model.PriorityMedium)
+	c := createIssue(t, conn, "C", model.StatusTodo, model.PriorityMedium)
+	if _, err := db.CreateRelation(conn, &model.Relation{SourceIssueID: a, TargetIssueID: b, RelationType: model.RelationBlocks}); err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+
+	input := "DKT-1 blocks DKT-2\nDKT-2 blocks DKT-3\nDKT-3 relates-to DKT-1\n"
+	w, buf := bufWriter(true)
+	if err := runRelationImport(relationImportCmdWithDB(conn, input, false), []string{"-"}, w); err != nil {
+		t.Fatalf("runRelationImport: %v", err)
+	}
+
+	var env struct {
+		Data relationImportResult `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("Unmarshal: %v\n%s", err, buf.String())
+	}
+	if env.Data.DryRun || len(env.Data.Created) != 2 || len(env.Data.Skipped) != 1 {
+		t.Fatalf("result = %+v, want 2 created and 1 skipped", env.Data)
+	}
+	if got := env.Data.Created[0]; got.Line != 2 || got.ID == 0 || got.Source != "DKT-2" || got.Target != "DKT-3" {
+		t.Errorf("Created[0] = %+v, want line 2 DKT-2 -> DKT-3 with an ID", got)
+	}
+	if got := env.Data.Skipped[0]; got.Line != 1 || got.Reason == "" {
+		t.Errorf("Skipped[0] = %+v, want line 1 with a reason", got)
+	}
+	exists, err := db.RelationExists(conn, c, a, model.RelationRelatesTo)
+	if err != nil || !exists {
+		t.Errorf("RelationExists(DKT-3 relates_to DKT-1) = %v, %v; want true", exists, err)
+	}
+}
+
+func TestRelationImport_RejectsWithoutWriting(t *testing.T) {
+	conn := newTestDB(t)
+	createIssue(t, conn, "A", model.StatusTodo, model.PriorityMedium)
+	createIssue(t, conn, "B", model.StatusTodo, model.PriorityMedium)
+	createIssue(t, conn, "C", model.StatusTodo, model.PriorityMedium)
+
+	// Line 2 does not parse and line 4 closes a cycle; both are reported.
+	input := "DKT-1 blocks DKT-2\nDKT-1 blocks\nDKT-2 blocks DKT-3\nDKT-3 blocks DKT-1\n"
+	w, _ := bufWriter(false)
+	err := runRelationImport(relationImportCmdWithDB(conn, input, false), []string{"-"}, w)
+
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	failure, ok := ce.Data.(relationImportFailure)
+	if !ok || len(failure.Errors) != 2 || failure.Errors[0].Line != 2 || failure.Errors[1].Line != 4 {
+		t.Errorf("error data = %+v, want errors on lines 2 and 4", ce.Data)
+	}
+	rels, err := db.GetAllRelations(conn)
+	if err != nil {
+		t.Fatalf("GetAllRelations: %v", err)
+	}
+	if len(rels) != 0 {
+		t.Errorf("got %d relations after a rejected import, want 0", len(rels))
+	}
+}
+
+func TestRelationImport_DryRun(t *testing.T) {
+	conn := newTestDB(t)
+	createIssue(t, conn, "A", model.StatusTodo, model.PriorityMedium)
+	createIssue(t, conn, "B", model.StatusTodo, model.PriorityMedium)
+
+	w, buf := bufWriter(false)
+	if err := runRelationImport(relationImportCmdWithDB(conn, "DKT-1,blocks,DKT-2\n", true), []string{"-"}, w); err != nil {
+		t.Fatalf("runRelationImport: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Dry run: 1 relation(s) would be created") {
+		t.Errorf("output = %q, want a dry run summary", buf.String())
+	}
+	rels, err := db.GetAllRelations(conn)
+	if err != nil {
+		t.Fatalf("GetAllRelations: %v", err)
+	}
+	if len(rels) != 0 {
+		t.Errorf("got %d relations after a dry run, want 0", len(rels))
+	}
+}
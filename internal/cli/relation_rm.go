@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/config"
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// relationRemoveResult is the JSON-friendly structure returned by relation rm.
+type relationRemoveResult struct {
+	ID int `json:"id"`
+}
+
+var relationRmCmd = &cobra.Command{
+	Use:   "rm <relation-id>",
+	Short: "Remove a relation by its ID",
+	Long: "Remove a relation by its ID, as shown by `docket issue link list --json`.\n" +
+		"The ID may be given as an argument or with --id.",
+	Aliases: []string{"remove"},
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := getWriter(cmd)
+		conn := getDB(cmd)
+
+		idFlag, _ := cmd.Flags().GetString("id")
+		var raw string
+		switch {
+		case len(args) == 1 && idFlag != "":
+			return cmdErr(fmt.Errorf("pass the relation ID as an argument or with --id, not both"), output.ErrValidation)
+		case len(args) == 1:
+			raw = args[0]
+		case idFlag != "":
+			raw = idFlag
+		default:
+			return cmdErr(fmt.Errorf("relation ID is required"), output.ErrValidation)
+		}
+
+		relID, err := strconv.Atoi(strings.TrimPrefix(raw, "#"))
+		if err != nil || relID <= 0 {
+			return cmdErr(fmt.Errorf("invalid relation ID %q: must be a positive integer", raw), output.ErrValidation)
+		}
+
+		if err := db.DeleteRelationByID(conn, relID, config.DefaultAuthor()); err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				return cmdErr(fmt.Errorf("relation not found: %d", relID), output.ErrNotFound)
+			}
+			return cmdErr(fmt.Errorf("deleting relation: %w", err), output.ErrGeneral)
+		}
+
+		w.Success(relationRemoveResult{ID: relID}, fmt.Sprintf("Removed relation #%d", relID))
+		return nil
+	},
+}
+
+func init() {
+	relationRmCmd.Flags().String("id", "", "Relation ID to remove")
+	relationCmd.AddCommand(relationRmCmd)
+}
@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var relationTypeCmd = &cobra.Command{
+	Use:   "type",
+	Short: "List or add relation types",
+}
+
+// loadRelationTypes registers the custom relation types stored in the
+// database so that relation types can be validated and rendered.
+func loadRelationTypes(conn *sql.DB) error {
+	defs, err := db.ListRelationTypes(conn)
+	if err != nil {
+		return fmt.Errorf("loading relation types: %w", err)
+	}
+	model.SetCustomRelationTypes(defs)
+	return nil
+}
+
+func init() {
+	relationCmd.AddCommand(relationTypeCmd)
+}
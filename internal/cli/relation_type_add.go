@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+	"github.com/spf13/cobra"
+)
+
+var relationTypeAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Register a custom relation type",
+	Long: `Register a custom relation type that "docket issue link add" accepts
+alongside the built-in ones. --inverse names the relation as seen from the
+target issue; without it the type is symmetric. Relations of a --directional
+type are kept free of cycles, like blocks and depends-on.
+
+  docket relation type add tested-by --inverse tests --directional
+  docket issue link add DKT-4 tested-by DKT-9`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRelationTypeAdd(cmd, args, getWriter(cmd))
+	},
+}
+
+func runRelationTypeAdd(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+	inverse, _ := cmd.Flags().GetString("inverse")
+	directional, _ := cmd.Flags().GetBool("directional")
+	color, _ := cmd.Flags().GetString("color")
+
+	if directional && strings.TrimSpace(inverse) == "" {
+		return cmdErr(fmt.Errorf("--directional requires --inverse, the name seen from the target issue"), output.ErrValidation)
+	}
+	color = strings.ToLower(strings.TrimSpace(color))
+	if color != "" && !slices.Contains(render.ColorNames, color) {
+		return cmdErr(fmt.Errorf("invalid color %q: must be one of %s", color, strings.Join(render.ColorNames, ", ")), output.ErrValidation)
+	}
+
+	def := &model.RelationTypeDef{
+		Name:        model.NormalizeRelationType(args[0]),
+		Inverse:     string(model.NormalizeRelationType(inverse)),
+		Directional: directional,
+		Color:       color,
+	}
+	if err := db.CreateRelationType(conn, def); err != nil {
+		if errors.Is(err, db.ErrValidation) {
+			return cmdErr(err, output.ErrValidation)
+		}
+		if errors.Is(err, db.ErrConflict) {
+			return cmdErr(err, output.ErrConflict)
+		}
+		return cmdErr(fmt.Errorf("adding relation type: %w", err), output.ErrGeneral)
+	}
+
+	w.Success(def, fmt.Sprintf("Added relation type %s (inverse: %s)", def.Name, def.Inverse))
+	return nil
+}
+
+func init() {
+	relationTypeAddCmd.Flags().String("inverse", "", "Name of the relation as seen from the target issue (default: the name itself)")
+	relationTypeAddCmd.Flags().Bool("directional", false, "Keep relations of this type free of cycles")
+	relationTypeAddCmd.Flags().String("color", "", "Color to render the type in ("+strings.Join(render.ColorNames, ", ")+")")
+	relationTypeCmd.AddCommand(relationTypeAddCmd)
+}
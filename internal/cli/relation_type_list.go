@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+	"github.com/spf13/cobra"
+)
+
+type relationTypeListResult struct {
+	RelationTypes []model.RelationTypeDef `json:"relation_types"`
+	Total         int                     `json:"total"`
+}
+
+var relationTypeListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List built-in and custom relation types",
+	Aliases: []string{"ls"},
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRelationTypeList(cmd, args, getWriter(cmd))
+	},
+}
+
+func runRelationTypeList(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	defs, err := db.ListRelationTypes(conn)
+	if err != nil {
+		return cmdErr(fmt.Errorf("listing relation types: %w", err), output.ErrGeneral)
+	}
+
+	var message string
+	if !w.JSONMode {
+		message = renderRelationTypes(defs)
+	}
+	w.Success(relationTypeListResult{RelationTypes: defs, Total: len(defs)}, message)
+	return nil
+}
+
+// renderRelationTypes renders one line per relation type: its name in its
+// color, its inverse, and whether it is directional or custom.
+func renderRelationTypes(defs []model.RelationTypeDef) string {
+	var b strings.Builder
+	for _, d := range defs {
+		name := fmt.Sprintf("%-16s", d.Name)
+		if render.ColorsEnabled() {
+			color := d.Color
+			if color == "" {
+				color = "white"
+			}
+			name = lipgloss.NewStyle().Foreground(render.ColorFromName(color)).Render(name)
+		}
+		var notes []string
+		if d.Directional {
+			notes = append(notes, "directional")
+		}
+		if !d.Builtin {
+			notes = append(notes, "custom")
+		}
+		fmt.Fprintf(&b, "%s inverse: %-16s %s\n", name, d.Inverse, strings.Join(notes, ", "))
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func init() {
+	relationTypeCmd.AddCommand(relationTypeListCmd)
+}
@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/digest"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// releaseNotesIssueJSON is the JSON wire format for an issue listed in
+// release notes.
+type releaseNotesIssueJSON struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Kind        string   `json:"kind"`
+	Assignee    string   `json:"assignee,omitempty"`
+	Labels      []string `json:"labels"`
+	CompletedAt string   `json:"completed_at"`
+}
+
+// releaseNotesSectionJSON is the JSON wire format for a release notes
+// section.
+type releaseNotesSectionJSON struct {
+	Title  string                  `json:"title"`
+	Issues []releaseNotesIssueJSON `json:"issues"`
+}
+
+// releaseNotesResult is the JSON wire format for the release-notes command
+// output.
+type releaseNotesResult struct {
+	Since       string                    `json:"since"`
+	Until       string                    `json:"until"`
+	Sections    []releaseNotesSectionJSON `json:"sections"`
+	MarkerSaved bool                      `json:"marker_saved"`
+}
+
+var releaseNotesCmd = &cobra.Command{
+	Use:   "release-notes",
+	Short: "Generate Markdown release notes from completed issues",
+	Long: `Generate Markdown release notes from the issues completed over a period,
+grouped by kind, or by label with --label-section:
+
+  docket release-notes --since 2026-01-01 --until 2026-02-01
+  docket release-notes --since-last --save-marker
+
+--since and --until take a date (YYYY-MM-DD, midnight local time) or an
+RFC 3339 time; --until defaults to now. --save-marker records the end of the
+period so that the next run with --since-last starts where this one ended.
+With --json, the sections are written as structured data instead.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runReleaseNotes(cmd, args, getWriter(cmd))
+	},
+}
+
+func runReleaseNotes(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	untilFlag, _ := cmd.Flags().GetString("until")
+	sinceLast, _ := cmd.Flags().GetBool("since-last")
+	byLabel, _ := cmd.Flags().GetBool("label-section")
+	saveMarker, _ := cmd.Flags().GetBool("save-marker")
+
+	if saveMarker {
+		if err := requireWritable(cmd); err != nil {
+			return err
+		}
+	}
+
+	var since time.Time
+	switch {
+	case sinceFlag != "" && sinceLast:
+		return cmdErr(fmt.Errorf("--since and --since-last are mutually exclusive"), output.ErrValidation)
+	case sinceLast:
+		marker, err := db.ReleaseNotesMarker(conn)
+		if err != nil {
+			return cmdErr(err, output.ErrGeneral)
+		}
+		if marker.IsZero() {
+			return cmdErr(fmt.Errorf("no release notes marker saved: run with --since and --save-marker first"), output.ErrValidation)
+		}
+		since = marker
+	case sinceFlag != "":
+		t, err := parseReleaseNotesTime(sinceFlag)
+		if err != nil {
+			return cmdErr(fmt.Errorf("--since: %w", err), output.ErrValidation)
+		}
+		since = t
+	default:
+		return cmdErr(fmt.Errorf("one of --since or --since-last is required"), output.ErrValidation)
+	}
+
+	until := time.Now().UTC().Truncate(time.Second)
+	if untilFlag != "" {
+		t, err := parseReleaseNotesTime(untilFlag)
+		if err != nil {
+			return cmdErr(fmt.Errorf("--until: %w", err), output.ErrValidation)
+		}
+		until = t
+	}
+	if until.Before(since) {
+		return cmdErr(fmt.Errorf("--until must not be before the start of the period"), output.ErrValidation)
+	}
+
+	completed, err := db.IssuesDoneBetween(conn, since, until)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching completed issues: %w", err), output.ErrGeneral)
+	}
+	if err := db.HydrateLabels(conn, completed); err != nil {
+		return cmdErr(fmt.Errorf("fetching labels: %w", err), output.ErrGeneral)
+	}
+	notes := digest.BuildReleaseNotes(completed, since, until, byLabel)
+
+	if saveMarker {
+		if err := db.SetReleaseNotesMarker(conn, until); err != nil {
+			return cmdErr(err, output.ErrGeneral)
+		}
+	}
+
+	if w.JSONMode {
+		result := newReleaseNotesResult(notes)
+		result.MarkerSaved = saveMarker
+		w.Success(result, "")
+		return nil
+	}
+	fmt.Fprint(w.Stdout, digest.ReleaseNotesMarkdown(notes))
+	return nil
+}
+
+// parseReleaseNotesTime parses a date, taken as midnight local time, or an
+// RFC 3339 time.
+func parseReleaseNotesTime(s string) (time.Time, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD or an RFC 3339 time", s)
+	}
+	return t, nil
+}
+
+// newReleaseNotesResult converts n to its JSON wire format.
+func newReleaseNotesResult(n *digest.ReleaseNotes) releaseNotesResult {
+	result := releaseNotesResult{
+		Since:    n.Since.UTC().Format(time.RFC3339),
+		Until:    n.Until.UTC().Format(time.RFC3339),
+		Sections: make([]releaseNotesSectionJSON, 0, len(n.Sections)),
+	}
+	for _, s := range n.Sections {
+		section := releaseNotesSectionJSON{Title: s.Title, Issues: make([]releaseNotesIssueJSON, 0, len(s.Issues))}
+		for _, issue := range s.Issues {
+			labels := issue.Labels
+			if labels == nil {
+				labels = []string{}
+			}
+			section.Issues = append(section.Issues, releaseNotesIssueJSON{
+				ID:          model.FormatID(issue.ID),
+				Title:       issue.Title,
+				Kind:        string(issue.Kind),
+				Assignee:    issue.Assignee,
+				Labels:      labels,
+				CompletedAt: issue.CompletedAt.UTC().Format(time.RFC3339),
+			})
+		}
+		result.Sections = append(result.Sections, section)
+	}
+	return result
+}
+
+func init() {
+	releaseNotesCmd.Flags().String("since", "", "Start of the period: a date (YYYY-MM-DD) or RFC 3339 time")
+	releaseNotesCmd.Flags().String("until", "", "End of the period (default now)")
+	releaseNotesCmd.Flags().Bool("since-last", false, "Start where the last run with --save-marker ended")
+	releaseNotesCmd.Flags().Bool("label-section", false, "Group issues by label instead of by kind")
+	releaseNotesCmd.Flags().Bool("save-marker", false, "Save the end of the period for the next --since-last")
+	rootCmd.AddCommand(releaseNotesCmd)
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+)
+
+func releaseNotesCmdWithDB(conn *sql.DB, flags map[string]string) *cobra.Command {
+	cmd := cmdWithDB(conn)
+	cmd.Flags().String("since", "", "")
+	cmd.Flags().String("until", "", "")
+	cmd.Flags().Bool("since-last", false, "")
+	cmd.Flags().Bool("label-section", false, "")
+	cmd.Flags().Bool("save-marker", false, "")
+	for name, value := range flags {
+		cmd.Flags().Set(name, value)
+	}
+	return cmd
+}
+
+func TestReleaseNotes_SaveMarkerAndSinceLast(t *testing.T) {
+	conn := newTestDB(t)
+	id := createIssue(t, conn, "Fix login timeout", model.StatusDone, model.PriorityNone)
+	if _, err := conn.Exec(`UPDATE issues SET kind = 'bug', assignee = 'alice', completed_at = '2026-01-15T10:00:00Z' WHERE id = ?`, id); err != nil {
+		t.Fatalf("setting completed_at: %v", err)
+	}
+
+	cmd := releaseNotesCmdWithDB(conn, map[string]string{"since": "2026-01-01T00:00:00Z", "until": "2026-02-01T00:00:00Z", "save-marker": "true"})
+	w, buf := bufWriter(false)
+	if err := runReleaseNotes(cmd, nil, w); err != nil {
+		t.Fatalf("runReleaseNotes: %v", err)
+	}
+	if !strings.Contains(buf.String(), "## Bugs\n\n- "+model.FormatID(id)+" Fix login timeout (@alice)\n") {
+		t.Errorf("unexpected release notes:\n%s", buf.String())
+	}
+
+	marker, err := db.ReleaseNotesMarker(conn)
+	if err != nil {
+		t.Fatalf("ReleaseNotesMarker: %v", err)
+	}
+	if want := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC); !marker.Equal(want) {
+		t.Errorf("marker = %v, want %v", marker, want)
+	}
+
+	cmd = releaseNotesCmdWithDB(conn, map[string]string{"since-last": "true"})
+	w, buf = bufWriter(true)
+	if err := runReleaseNotes(cmd, nil, w); err != nil {
+		t.Fatalf("runReleaseNotes --since-last: %v", err)
+	}
+	var env struct {
+		Data releaseNotesResult `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("Unmarshal: %v\n%s", err, buf.String())
+	}
+	if env.Data.Since != "2026-02-01T00:00:00Z" || len(env.Data.Sections) != 0 {
+		t.Errorf("--since-last result = %+v, want an empty period from the marker", env.Data)
+	}
+}
+
+func TestReleaseNotes_SinceLastWithoutMarker(t *testing.T) {
+	conn := newTestDB(t)
+	cmd := releaseNotesCmdWithDB(conn, map[string]string{"since-last": "true"})
+	w, _ := bufWriter(false)
+	err := runReleaseNotes(cmd, nil, w)
+	var ce *CmdError
+	if !errors.As(err, &ce) || ce.Code != output.ErrValidation {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Aggregate reports over the issue database",
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+	"github.com/spf13/cobra"
+)
+
+var reportWorkloadCmd = &cobra.Command{
+	Use:   "workload",
+	Short: "Show open, in-progress, and done issue counts per assignee",
+	Long: `Show open, in-progress, and done issue counts per assignee for capacity
+planning. Open counts backlog and todo issues; in progress counts
+in-progress and review. Issues without an assignee are grouped under
+"(unassigned)".`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runReportWorkload(cmd, args, getWriter(cmd))
+	},
+}
+
+func runReportWorkload(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	workload, err := db.WorkloadByAssignee(conn)
+	if err != nil {
+		return cmdErr(fmt.Errorf("computing workload: %w", err), output.ErrGeneral)
+	}
+
+	var message string
+	if !w.JSONMode {
+		message = renderWorkload(workload)
+	}
+	w.Success(workload, message)
+	return nil
+}
+
+// workloadOrder returns the assignees in workload sorted by open count
+// descending, then by name.
+func workloadOrder(workload map[string]db.WorkloadStats) []string {
+	names := make([]string, 0, len(workload))
+	for name := range workload {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Or(cmp.Compare(workload[b].Open, workload[a].Open), strings.Compare(a, b))
+	})
+	return names
+}
+
+// renderWorkload renders the workload report as a table.
+func renderWorkload(workload map[string]db.WorkloadStats) string {
+	if len(workload) == 0 {
+		return render.EmptyState("No issues found.", "Create issues first with: docket issue create", false)
+	}
+
+	names := workloadOrder(workload)
+	if !render.ColorsEnabled() {
+		return renderPlainWorkload(workload, names)
+	}
+
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		s := workload[name]
+		rows = append(rows, []string{
+			name,
+			fmt.Sprintf("%d", s.Open),
+			fmt.Sprintf("%d", s.InProgress),
+			fmt.Sprintf("%d", s.Done),
+			fmt.Sprintf("%d", s.Total),
+		})
+	}
+
+	return table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("8"))).
+		Headers("Assignee", "Open", "In Progress", "Done", "Total").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			s := lipgloss.NewStyle().PaddingLeft(1).PaddingRight(1)
+			if row == table.HeaderRow {
+				return s.Bold(true).Foreground(lipgloss.Color("15"))
+			}
+			switch col {
+			case 0:
+				return s.Bold(true)
+			case 1:
+				return s.Bold(true).Foreground(lipgloss.Color("12"))
+			case 4:
+				return s
+			default:
+				return s.Foreground(lipgloss.Color("8"))
+			}
+		}).
+		Render()
+}
+
+// renderPlainWorkload renders the workload report without colors.
+func renderPlainWorkload(workload map[string]db.WorkloadStats, names []string) string {
+	nameW := len("Assignee")
+	for _, name := range names {
+		nameW = max(nameW, len(name))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-*s %6s %12s %6s %6s\n", nameW, "Assignee", "Open", "In Progress", "Done", "Total")
+	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", nameW+36))
+	for _, name := range names {
+		s := workload[name]
+		fmt.Fprintf(&b, "%-*s %6d %12d %6d %6d\n", nameW, name, s.Open, s.InProgress, s.Done, s.Total)
+	}
+	return b.String()
+}
+
+func init() {
+	reportCmd.AddCommand(reportWorkloadCmd)
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestReportWorkloadJSONAndOrder(t *testing.T) {
+	conn := newTestDB(t)
+	for _, tc := range []struct {
+		assignee string
+		status   model.Status
+	}{
+		{"alice", model.StatusTodo},
+		{"bob", model.StatusTodo},
+		{"bob", model.StatusBacklog},
+		{"bob", model.StatusDone},
+		{"", model.StatusInProgress},
+	} {
+		id := createIssue(t, conn, "work", tc.status, model.PriorityLow)
+		if tc.assignee != "" {
+			if err := db.UpdateIssue(conn, id, map[string]interface{}{"assignee": tc.assignee}, "tester"); err != nil {
+				t.Fatalf("UpdateIssue: %v", err)
+			}
+		}
+	}
+
+	w, buf := bufWriter(true)
+	if err := runReportWorkload(cmdWithDB(conn), nil, w); err != nil {
+		t.Fatalf("runReportWorkload: %v", err)
+	}
+	var env struct {
+		Data map[string]db.WorkloadStats `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("decoding output: %v\n%s", err, buf.String())
+	}
+	if got := env.Data["bob"]; got != (db.WorkloadStats{Open: 2, Done: 1, Total: 3}) {
+		t.Errorf("bob = %+v", got)
+	}
+	if got := env.Data[db.UnassignedBucket]; got != (db.WorkloadStats{InProgress: 1, Total: 1}) {
+		t.Errorf("unassigned = %+v", got)
+	}
+
+	order := workloadOrder(env.Data)
+	if want := []string{"bob", "alice", db.UnassignedBucket}; !slices.Equal(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+}
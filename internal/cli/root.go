@@ -6,11 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/ALT-F4-LLC/docket/internal/config"
 	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
 	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
 	"github.com/spf13/cobra"
 )
 
@@ -27,14 +31,20 @@ const (
 	cfgKey contextKey = "cfg"
 )
 
-// CmdError wraps an error with a machine-readable error code for structured output.
+// CmdError wraps an error with a machine-readable error code for structured
+// output. Data, when set, is included in the JSON error envelope. Silent
+// errors print nothing in human mode, leaving only the exit code.
 type CmdError struct {
-	Err  error
-	Code output.ErrorCode
+	Err    error
+	Code   output.ErrorCode
+	Data   any
+	Silent bool
 }
 
 func (e *CmdError) Error() string { return e.Err.Error() }
 
+func (e *CmdError) Unwrap() error { return e.Err }
+
 func cmdErr(err error, code output.ErrorCode) *CmdError {
 	return &CmdError{Err: err, Code: code}
 }
@@ -44,12 +54,30 @@ var rootCmd = &cobra.Command{
 	Short:   "Local-first CLI issue tracker",
 	Version: fmt.Sprintf("%s (commit: %s, built: %s)", version, commit, buildDate),
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Resolve()
+		dbDir, _ := cmd.Flags().GetString("db")
+		actor, _ := cmd.Flags().GetString("actor")
+		cfg, err := config.Resolve(config.Options{DocketDir: dbDir, Actor: actor})
 		if err != nil {
 			return err
 		}
+		config.SetActor(cfg.Actor)
+		if err := model.SetIDPrefix(cfg.Prefix); err != nil {
+			return cmdErr(fmt.Errorf("%s: %w", cfg.ProjectFile, err), output.ErrValidation)
+		}
+		if err := model.SetMaxTitleLength(cfg.MaxTitleLength); err != nil {
+			return cmdErr(fmt.Errorf("%s: %w", cfg.ProjectFile, err), output.ErrValidation)
+		}
 
 		ctx := context.WithValue(cmd.Context(), cfgKey, cfg)
+		cmd.SetContext(ctx)
+
+		// Refuse mutating commands up front rather than letting their first
+		// write fail part-way through.
+		if !isReadOnlyCommand(cmd) {
+			if err := requireWritable(cmd); err != nil {
+				return err
+			}
+		}
 
 		watchMode, _ := cmd.Flags().GetBool("watch")
 		if watchMode {
@@ -68,7 +96,7 @@ var rootCmd = &cobra.Command{
 			}
 		}
 
-		if _, ok := cmd.Annotations["skipDB"]; ok {
+		if skipsDB(cmd) {
 			cmd.SetContext(ctx)
 			return nil
 		}
@@ -80,6 +108,34 @@ var rootCmd = &cobra.Command{
 			)
 		}
 
+		if readOnlyMode(cmd) {
+			conn, err := db.OpenReadOnly(cfg.DBPath)
+			if err != nil {
+				return fmt.Errorf("failed to open database: %w", err)
+			}
+			if err := db.CheckSchemaCurrent(conn); err != nil {
+				conn.Close()
+				if errors.Is(err, db.ErrSchemaOutdated) {
+					return cmdErr(fmt.Errorf("%w; run any command without --read-only to migrate it", err), output.ErrValidation)
+				}
+				return err
+			}
+			if err := loadLinkTemplate(conn); err != nil {
+				conn.Close()
+				return err
+			}
+			if err := loadAnonymousAuthor(conn); err != nil {
+				conn.Close()
+				return err
+			}
+			if err := loadRelationTypes(conn); err != nil {
+				conn.Close()
+				return err
+			}
+			cmd.SetContext(context.WithValue(ctx, dbKey, conn))
+			return nil
+		}
+
 		conn, err := db.Open(cfg.DBPath)
 		if err != nil {
 			return fmt.Errorf("failed to open database: %w", err)
@@ -88,6 +144,18 @@ var rootCmd = &cobra.Command{
 		if err := db.Migrate(conn); err != nil {
 			return fmt.Errorf("failed to migrate database: %w", err)
 		}
+		if err := loadLinkTemplate(conn); err != nil {
+			conn.Close()
+			return err
+		}
+		if err := loadAnonymousAuthor(conn); err != nil {
+			conn.Close()
+			return err
+		}
+		if err := loadRelationTypes(conn); err != nil {
+			conn.Close()
+			return err
+		}
 
 		cmd.SetContext(context.WithValue(ctx, dbKey, conn))
 		return nil
@@ -103,9 +171,15 @@ var rootCmd = &cobra.Command{
 
 func init() {
 	rootCmd.PersistentFlags().Bool("json", false, "Output in JSON format")
+	rootCmd.PersistentFlags().String("db", "", "Directory holding the docket database (overrides DOCKET_PATH and .docket.toml)")
 	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Suppress non-essential output")
 	rootCmd.PersistentFlags().BoolP("watch", "w", false, "Watch for changes and refresh output")
 	rootCmd.PersistentFlags().Duration("interval", 2*time.Second, "Refresh interval for --watch")
+	rootCmd.PersistentFlags().Int("width", 0, "Render tables and boards for this many columns instead of the terminal width")
+	rootCmd.PersistentFlags().Bool("no-truncate", false, "Show issue titles in full instead of truncating them")
+	rootCmd.PersistentFlags().Bool("all-statuses", false, "Include done issues in issue lists (or set all_statuses in .docket.toml)")
+	rootCmd.PersistentFlags().String("actor", "", "Record changes as made by this name, e.g. an agent or tool (or set DOCKET_ACTOR); defaults to git user.name")
+	rootCmd.PersistentFlags().Bool("read-only", false, "Open the database read-only and refuse commands that modify it (or set DOCKET_READONLY=1)")
 	rootCmd.SilenceErrors = true
 	rootCmd.SilenceUsage = true
 }
@@ -122,6 +196,79 @@ func getWriter(cmd *cobra.Command) *output.Writer {
 	return output.New(jsonMode, quietMode)
 }
 
+// allStatuses reports whether issue lists should include done issues, as
+// requested by --all-statuses or, without the flag, by the project config
+// file's all_statuses.
+func allStatuses(cmd *cobra.Command) bool {
+	if cmd.Flags().Changed("all-statuses") {
+		all, _ := cmd.Flags().GetBool("all-statuses")
+		return all
+	}
+	cfg := getCfg(cmd)
+	return cfg != nil && cfg.AllStatuses
+}
+
+// getLayout returns the render layout selected by --width and --no-truncate,
+// by --columns on commands that define it, and by the project config file's
+// status_colors, max_labels, important_labels and diff_hunks.
+func getLayout(cmd *cobra.Command) (render.LayoutOptions, error) {
+	width, _ := cmd.Flags().GetInt("width")
+	noTruncate, _ := cmd.Flags().GetBool("no-truncate")
+	if width < 0 {
+		return render.LayoutOptions{}, cmdErr(fmt.Errorf("--width must be a positive number of columns"), output.ErrValidation)
+	}
+	layout := render.LayoutOptions{Width: width, NoTruncate: noTruncate}
+	if columns, _ := cmd.Flags().GetString("columns"); columns != "" {
+		cols, err := render.ParseTableColumns(columns)
+		if err != nil {
+			return render.LayoutOptions{}, cmdErr(err, output.ErrValidation)
+		}
+		layout.Columns = cols
+	}
+	if cfg := getCfg(cmd); cfg != nil {
+		colors, err := render.ParseStatusColors(cfg.StatusColors)
+		if err != nil {
+			return render.LayoutOptions{}, cmdErr(fmt.Errorf("%s: %w", cfg.ProjectFile, err), output.ErrValidation)
+		}
+		layout.StatusColors = colors
+		layout.MaxLabels = cfg.MaxLabels
+		layout.ImportantLabels = cfg.ImportantLabels
+		layout.DiffHunks = cfg.DiffHunks
+	}
+	return layout, nil
+}
+
+// hydrateColumns loads what the selected table columns show that listings
+// do not fetch for every table: relation degrees for the deps column.
+func hydrateColumns(conn *sql.DB, issues []*model.Issue, layout render.LayoutOptions) error {
+	if !slices.Contains(layout.Columns, "deps") {
+		return nil
+	}
+	if err := db.HydrateRelationDegrees(conn, issues); err != nil {
+		return cmdErr(fmt.Errorf("fetching relation degrees: %w", err), output.ErrGeneral)
+	}
+	return nil
+}
+
+// addColumnsFlag registers --columns on a command that renders issue tables.
+func addColumnsFlag(cmd *cobra.Command) {
+	cmd.Flags().String("columns", "", "Comma-separated table columns in order (from: "+strings.Join(render.TableColumnKeys, ", ")+")")
+}
+
+// skipsDB reports whether cmd runs without opening the database: it is
+// annotated skipDB, or it names in a skipDBFlag annotation a flag that is
+// set, as plan does for --schema.
+func skipsDB(cmd *cobra.Command) bool {
+	if _, ok := cmd.Annotations["skipDB"]; ok {
+		return true
+	}
+	if flag, ok := cmd.Annotations["skipDBFlag"]; ok {
+		set, _ := cmd.Flags().GetBool(flag)
+		return set
+	}
+	return false
+}
+
 func getCfg(cmd *cobra.Command) *config.Config {
 	cfg, _ := cmd.Context().Value(cfgKey).(*config.Config)
 	return cfg
@@ -143,9 +290,18 @@ func Execute() int {
 		quietMode, _ := rootCmd.PersistentFlags().GetBool("quiet")
 		w := output.New(jsonMode, quietMode)
 
+		// A write that gave up on the lock maps to ErrBusy whatever code
+		// the command chose, so callers can detect it and retry later.
+		if errors.Is(err, db.ErrBusy) {
+			return w.Error(err, output.ErrBusy)
+		}
+
 		var ce *CmdError
 		if errors.As(err, &ce) {
-			return w.Error(ce.Err, ce.Code)
+			if ce.Silent && !jsonMode {
+				return output.ExitCodeForError(ce.Code)
+			}
+			return w.ErrorWithData(ce.Err, ce.Code, ce.Data)
 		}
 		return w.Error(err, output.ErrGeneral)
 	}
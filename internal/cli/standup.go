@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dustin/go-humanize"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+	"github.com/ALT-F4-LLC/docket/internal/standup"
+	"github.com/spf13/cobra"
+)
+
+// standupItemJSON is the JSON wire format for one collapsed standup entry.
+type standupItemJSON struct {
+	IssueID    string   `json:"issue_id"`
+	IssueTitle string   `json:"issue_title"`
+	Action     string   `json:"action"`
+	Status     string   `json:"status,omitempty"`
+	Fields     []string `json:"fields,omitempty"`
+	Count      int      `json:"count"`
+	Summary    string   `json:"summary"`
+	LastAt     string   `json:"last_at"`
+}
+
+// standupActorJSON is the JSON wire format for one actor's activity.
+type standupActorJSON struct {
+	Actor   string            `json:"actor"`
+	Entries int               `json:"entries"`
+	Items   []standupItemJSON `json:"items"`
+}
+
+// standupResult is the JSON wire format for the standup command output.
+type standupResult struct {
+	Since  string             `json:"since"`
+	Actors []standupActorJSON `json:"actors"`
+	Total  int                `json:"total"`
+}
+
+var standupCmd = &cobra.Command{
+	Use:   "standup",
+	Short: "Summarize recent activity grouped by who did it",
+	Long: `Summarize recent activity grouped by who did it.
+
+Consecutive changes by the same person to the same issue are collapsed, so
+repeated status moves show the final status and repeated comments are
+counted. Activity recorded without an author is grouped under "system".`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStandup(cmd, args, getWriter(cmd))
+	},
+}
+
+func runStandup(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	age, err := parseAge(sinceFlag)
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+	since := time.Now().Add(-age)
+
+	activity, err := db.ListActivitySince(conn, since)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching activity: %w", err), output.ErrGeneral)
+	}
+	summaries := standup.Summarize(activity)
+
+	result := standupResult{
+		Since:  since.UTC().Format(time.RFC3339),
+		Actors: make([]standupActorJSON, 0, len(summaries)),
+		Total:  len(activity),
+	}
+	for _, s := range summaries {
+		actor := standupActorJSON{Actor: s.Actor, Entries: s.Entries, Items: make([]standupItemJSON, 0, len(s.Items))}
+		for _, it := range s.Items {
+			actor.Items = append(actor.Items, standupItemJSON{
+				IssueID:    model.FormatID(it.IssueID),
+				IssueTitle: it.IssueTitle,
+				Action:     string(it.Action),
+				Status:     string(it.Status),
+				Fields:     it.Fields,
+				Count:      it.Count,
+				Summary:    it.Summary(),
+				LastAt:     it.LastAt.UTC().Format(time.RFC3339),
+			})
+		}
+		result.Actors = append(result.Actors, actor)
+	}
+
+	if w.JSONMode {
+		w.Success(result, "")
+		return nil
+	}
+
+	w.Success(result, renderStandupHuman(summaries, sinceFlag))
+	return nil
+}
+
+// renderStandupHuman renders the standup report as human-readable text.
+func renderStandupHuman(summaries []standup.ActorSummary, since string) string {
+	if len(summaries) == 0 {
+		return render.EmptyState(fmt.Sprintf("No activity in the last %s.", since), "", false)
+	}
+
+	if !render.ColorsEnabled() {
+		return renderStandupPlain(summaries, since)
+	}
+
+	var b strings.Builder
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
+	actorStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	countStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Italic(true)
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Standup (last %s):", since)))
+	b.WriteString("\n")
+
+	for _, s := range summaries {
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "%s %s\n", actorStyle.Render(s.Actor), countStyle.Render(fmt.Sprintf("(%d change(s))", s.Entries)))
+		for _, it := range s.Items {
+			fmt.Fprintf(&b, "  • %s  %s  %s\n",
+				it.Summary(),
+				titleStyle.Render(it.IssueTitle),
+				countStyle.Render(humanize.Time(it.LastAt)),
+			)
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// renderStandupPlain renders the standup report without colors.
+func renderStandupPlain(summaries []standup.ActorSummary, since string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Standup (last %s):\n", since)
+
+	for _, s := range summaries {
+		fmt.Fprintf(&b, "\n%s (%d change(s))\n", s.Actor, s.Entries)
+		for _, it := range s.Items {
+			fmt.Fprintf(&b, "  - %s  %s  %s\n", it.Summary(), it.IssueTitle, humanize.Time(it.LastAt))
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func init() {
+	standupCmd.Flags().String("since", "24h", "How far back to look (e.g. 18h, 2d, 1w)")
+	rootCmd.AddCommand(standupCmd)
+}
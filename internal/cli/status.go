@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+	"github.com/spf13/cobra"
+)
+
+type statusInfo struct {
+	JournalMode   string `json:"journal_mode"`
+	BusyTimeoutMS int64  `json:"busy_timeout_ms"`
+	WriteLocked   bool   `json:"write_locked"`
+	ReadOnly      bool   `json:"read_only"`
+	NextIssueID   string `json:"next_issue_id"`
+	StartID       string `json:"start_id,omitempty"`
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show database concurrency status",
+	Long: `Shows the SQLite journal mode and busy timeout, and whether another
+docket process currently holds the write lock. Useful when commands fail
+with a "database is busy" error (exit code 5).`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := getWriter(cmd)
+		conn := getDB(cmd)
+
+		st, err := db.GetLockStatus(conn)
+		if err != nil {
+			return cmdErr(fmt.Errorf("reading database status: %w", err), output.ErrGeneral)
+		}
+
+		next, err := db.GetNextIssueID(conn)
+		if err != nil {
+			return cmdErr(err, output.ErrGeneral)
+		}
+		start, err := db.StartID(conn)
+		if err != nil {
+			return cmdErr(err, output.ErrGeneral)
+		}
+
+		info := statusInfo{
+			JournalMode:   st.JournalMode,
+			BusyTimeoutMS: st.BusyTimeout.Milliseconds(),
+			WriteLocked:   st.WriteLocked,
+			ReadOnly:      st.ReadOnly,
+			NextIssueID:   model.FormatID(next),
+		}
+		if start > 0 {
+			info.StartID = model.FormatID(start)
+		}
+
+		var message string
+		if !w.JSONMode {
+			message = formatStatusHuman(info)
+		}
+		w.Success(info, message)
+		return nil
+	},
+}
+
+func writeLockLabel(info statusInfo) string {
+	if info.ReadOnly {
+		return "not available (opened read-only)"
+	}
+	if info.WriteLocked {
+		return "held by another process"
+	}
+	return "free"
+}
+
+// nextIssueLabel describes the next issue ID, and the configured start when
+// numbering was set to begin somewhere other than 1.
+func nextIssueLabel(info statusInfo) string {
+	if info.StartID == "" {
+		return info.NextIssueID
+	}
+	return fmt.Sprintf("%s (numbering from %s)", info.NextIssueID, info.StartID)
+}
+
+func formatStatusHuman(info statusInfo) string {
+	if !render.ColorsEnabled() {
+		lines := fmt.Sprintf("Journal mode:  %s\n", info.JournalMode)
+		lines += fmt.Sprintf("Busy timeout:  %dms\n", info.BusyTimeoutMS)
+		lines += fmt.Sprintf("Write lock:    %s\n", writeLockLabel(info))
+		lines += fmt.Sprintf("Next issue:    %s", nextIssueLabel(info))
+		return lines
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	valStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
+
+	indicator := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render("●")
+	if info.WriteLocked {
+		indicator = lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render("●")
+	}
+
+	lines := headerStyle.Render("Docket Status") + "\n\n"
+	lines += fmt.Sprintf("  %s %s\n", keyStyle.Render("Journal mode:"), valStyle.Render(info.JournalMode))
+	lines += fmt.Sprintf("  %s %s\n", keyStyle.Render("Busy timeout:"), valStyle.Render(fmt.Sprintf("%dms", info.BusyTimeoutMS)))
+	lines += fmt.Sprintf("  %s   %s %s\n", keyStyle.Render("Write lock:"), indicator, valStyle.Render(writeLockLabel(info)))
+	lines += fmt.Sprintf("  %s   %s", keyStyle.Render("Next issue:"), valStyle.Render(nextIssueLabel(info)))
+	return lines
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
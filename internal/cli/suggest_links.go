@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+
+	"github.com/ALT-F4-LLC/docket/internal/config"
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/planner"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+)
+
+// linkSuggestionJSON is one suggested relation in the suggest-links JSON
+// output: dependent depends_on depends_on, because both touch files.
+type linkSuggestionJSON struct {
+	Dependent string   `json:"dependent"`
+	DependsOn string   `json:"depends_on"`
+	Overlap   int      `json:"overlap"`
+	Files     []string `json:"files"`
+}
+
+// suggestLinksResult is the JSON output of suggest-links. Applied lists the
+// suggestions turned into relations by --apply.
+type suggestLinksResult struct {
+	Suggestions []linkSuggestionJSON `json:"suggestions"`
+	Applied     []linkSuggestionJSON `json:"applied,omitempty"`
+}
+
+var suggestLinksCmd = &cobra.Command{
+	Use:   "suggest-links",
+	Short: "Suggest dependencies between open issues that touch the same files",
+	Long: `Suggest dependencies between open issues attached to the same files, since
+work on one likely conflicts with the other:
+
+  DKT-9 ↔ DKT-14 share 2 files (issues.go, labels.go) — suggest: DKT-14 depends_on DKT-9
+
+Pairs that are already related, or where one issue is a sub-issue of the
+other, are skipped. The issue further along (review, then in-progress, todo
+and backlog) is suggested as the dependency, the older one on a tie. Pairs
+sharing the most files come first.
+
+--apply asks about each suggestion in turn and creates the relations you
+confirm, with the same checks as 'docket issue link add'.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSuggestLinks(cmd, args, getWriter(cmd))
+	},
+}
+
+// confirmLinkSuggestion asks whether to create the relation s suggests.
+// Tests replace it to answer without a terminal.
+var confirmLinkSuggestion = func(s planner.LinkSuggestion) (bool, error) {
+	var confirmed bool
+	err := huh.NewForm(huh.NewGroup(
+		huh.NewConfirm().
+			Title(linkSuggestionLine(s) + "?").
+			Value(&confirmed),
+	)).Run()
+	return confirmed, err
+}
+
+func runSuggestLinks(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+	apply, _ := cmd.Flags().GetBool("apply")
+
+	if apply {
+		if w.JSONMode || !stdinIsTerminal() {
+			return cmdErr(fmt.Errorf("--apply asks before creating each relation and needs an interactive terminal without --json"), output.ErrValidation)
+		}
+		if err := requireWritable(cmd); err != nil {
+			return err
+		}
+	}
+
+	shared, err := db.SharedOpenFiles(conn)
+	if err != nil {
+		return cmdErr(err, output.ErrGeneral)
+	}
+	all, _, err := db.ListIssues(conn, db.ListOptions{IncludeDone: true, IncludeSnoozed: true})
+	if err != nil {
+		return cmdErr(fmt.Errorf("listing issues: %w", err), output.ErrGeneral)
+	}
+	issues := make(map[int]*model.Issue, len(all))
+	for _, issue := range all {
+		issues[issue.ID] = issue
+	}
+	relations, err := db.GetAllRelations(conn)
+	if err != nil {
+		return cmdErr(fmt.Errorf("fetching relations: %w", err), output.ErrGeneral)
+	}
+
+	suggestions := planner.SuggestLinks(shared, issues, relations)
+	result := suggestLinksResult{Suggestions: make([]linkSuggestionJSON, 0, len(suggestions))}
+	for _, s := range suggestions {
+		result.Suggestions = append(result.Suggestions, linkSuggestionToJSON(s))
+	}
+
+	if len(suggestions) == 0 {
+		quiet, _ := cmd.Flags().GetBool("quiet")
+		w.Success(result, render.EmptyState("No open issues share files with an unrelated issue.", "", quiet))
+		return nil
+	}
+	if !apply {
+		lines := make([]string, len(suggestions))
+		for i, s := range suggestions {
+			lines[i] = linkSuggestionLine(s)
+		}
+		w.Success(result, strings.Join(lines, "\n"))
+		return nil
+	}
+
+	for _, s := range suggestions {
+		confirmed, err := confirmLinkSuggestion(s)
+		if errors.Is(err, huh.ErrUserAborted) {
+			break
+		}
+		if err != nil {
+			return cmdErr(fmt.Errorf("interactive form failed: %w", err), output.ErrGeneral)
+		}
+		if !confirmed {
+			continue
+		}
+
+		_, err = db.CreateRelationWithOptions(conn, &model.Relation{
+			SourceIssueID: s.Dependent.ID,
+			TargetIssueID: s.Dependency.ID,
+			RelationType:  model.RelationDependsOn,
+		}, db.CreateRelationOptions{ChangedBy: config.DefaultAuthor()})
+		switch {
+		case err == nil:
+			result.Applied = append(result.Applied, linkSuggestionToJSON(s))
+		case errors.Is(err, db.ErrCycleDetected), errors.Is(err, db.ErrDuplicateRelation):
+			w.Warn("skipped %s depends_on %s: %v", model.FormatID(s.Dependent.ID), model.FormatID(s.Dependency.ID), err)
+		default:
+			return cmdErr(fmt.Errorf("creating relation: %w", err), output.ErrGeneral)
+		}
+	}
+
+	w.Success(result, fmt.Sprintf("Created %d of %d suggested relations", len(result.Applied), len(suggestions)))
+	return nil
+}
+
+// linkSuggestionLine describes s as "DKT-9 ↔ DKT-14 share 2 files
+// (issues.go, labels.go) — suggest: DKT-14 depends_on DKT-9", naming the
+// lower ID first and each shared file by its base name.
+func linkSuggestionLine(s planner.LinkSuggestion) string {
+	low, high := s.Dependency.ID, s.Dependent.ID
+	if high < low {
+		low, high = high, low
+	}
+	names := make([]string, len(s.Files))
+	for i, f := range s.Files {
+		names[i] = path.Base(f)
+	}
+	noun := "files"
+	if len(s.Files) == 1 {
+		noun = "file"
+	}
+	return fmt.Sprintf("%s ↔ %s share %d %s (%s) — suggest: %s depends_on %s",
+		model.FormatID(low), model.FormatID(high), len(s.Files), noun, strings.Join(names, ", "),
+		model.FormatID(s.Dependent.ID), model.FormatID(s.Dependency.ID))
+}
+
+func linkSuggestionToJSON(s planner.LinkSuggestion) linkSuggestionJSON {
+	return linkSuggestionJSON{
+		Dependent: model.FormatID(s.Dependent.ID),
+		DependsOn: model.FormatID(s.Dependency.ID),
+		Overlap:   len(s.Files),
+		Files:     s.Files,
+	}
+}
+
+func init() {
+	suggestLinksCmd.Flags().Bool("apply", false, "Ask about each suggestion and create the confirmed relations")
+	rootCmd.AddCommand(suggestLinksCmd)
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/planner"
+	"github.com/spf13/cobra"
+)
+
+func suggestLinksCmdWithDB(conn *sql.DB) *cobra.Command {
+	cmd := cmdWithDB(conn)
+	cmd.Flags().Bool("apply", false, "")
+	cmd.Flags().Bool("read-only", false, "")
+	return cmd
+}
+
+// seedSharedFiles creates an in-progress issue and a todo issue sharing two
+// files, plus a third issue already related to the first.
+func seedSharedFiles(t *testing.T, conn *sql.DB) (active, queued int) {
+	t.Helper()
+	active = createIssueWithFile(t, conn, "Active", "internal/db/issues.go")
+	if err := db.UpdateIssue(conn, active, map[string]interface{}{"status": "in-progress"}, ""); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+	queued = createIssueWithFile(t, conn, "Queued", "internal/db/issues.go")
+	related := createIssueWithFile(t, conn, "Related", "internal/db/issues.go")
+	for _, id := range []int{active, queued} {
+		if err := db.AttachFiles(conn, id, []string{"internal/db/labels.go"}, ""); err != nil {
+			t.Fatalf("AttachFiles: %v", err)
+		}
+	}
+	if _, err := db.CreateRelation(conn, &model.Relation{SourceIssueID: related, TargetIssueID: active, RelationType: model.RelationRelatesTo}); err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+	return active, queued
+}
+
+func TestSuggestLinksJSON(t *testing.T) {
+	conn := newTestDB(t)
+	active, queued := seedSharedFiles(t, conn)
+
+	w, buf := bufWriter(true)
+	if err := runSuggestLinks(suggestLinksCmdWithDB(conn), nil, w); err != nil {
+		t.Fatalf("runSuggestLinks: %v", err)
+	}
+	var got struct {
+		Data suggestLinksResult `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if len(got.Data.Suggestions) != 2 {
+		t.Fatalf("suggestions = %+v, want 2", got.Data.Suggestions)
+	}
+	first := got.Data.Suggestions[0]
+	if first.Dependent != model.FormatID(queued) || first.DependsOn != model.FormatID(active) || first.Overlap != 2 {
+		t.Errorf("first suggestion = %+v, want %s depends_on %s sharing 2 files",
+			first, model.FormatID(queued), model.FormatID(active))
+	}
+	if first.Files[0] != "internal/db/issues.go" || first.Files[1] != "internal/db/labels.go" {
+		t.Errorf("files = %v", first.Files)
+	}
+}
+
+func TestSuggestLinksApplyCreatesConfirmedRelations(t *testing.T) {
+	conn := newTestDB(t)
+	active, queued := seedSharedFiles(t, conn)
+
+	oldTTY, oldConfirm := stdinIsTerminal, confirmLinkSuggestion
+	t.Cleanup(func() { stdinIsTerminal, confirmLinkSuggestion = oldTTY, oldConfirm })
+	stdinIsTerminal = func() bool { return true }
+	var asked int
+	confirmLinkSuggestion = func(s planner.LinkSuggestion) (bool, error) {
+		asked++
+		return asked == 1, nil
+	}
+
+	cmd := suggestLinksCmdWithDB(conn)
+	cmd.Flags().Set("apply", "true")
+	w, buf := bufWriter(false)
+	if err := runSuggestLinks(cmd, nil, w); err != nil {
+		t.Fatalf("runSuggestLinks: %v", err)
+	}
+	if asked != 2 {
+		t.Errorf("asked about %d suggestions, want 2", asked)
+	}
+	if ok, err := db.RelationExists(conn, queued, active, model.RelationDependsOn); err != nil || !ok {
+		t.Errorf("RelationExists(%d depends_on %d) = %v, %v; want true", queued, active, ok, err)
+	}
+	relations, err := db.GetAllRelations(conn)
+	if err != nil {
+		t.Fatalf("GetAllRelations: %v", err)
+	}
+	if len(relations) != 2 {
+		t.Errorf("relations = %+v, want the seeded one and one created", relations)
+	}
+	if want := "Created 1 of 2 suggested relations"; !strings.Contains(buf.String(), want) {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
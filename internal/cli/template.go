@@ -0,0 +1,13 @@
+package cli
+
+import "github.com/spf13/cobra"
+
+var templateCmd = &cobra.Command{
+	Use:     "template",
+	Short:   "Manage issue templates",
+	Aliases: []string{"tpl"},
+}
+
+func init() {
+	rootCmd.AddCommand(templateCmd)
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+	"github.com/spf13/cobra"
+)
+
+var templateListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List issue templates",
+	Aliases: []string{"ls"},
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := getWriter(cmd)
+		conn := getDB(cmd)
+
+		templates, err := db.ListTemplates(conn)
+		if err != nil {
+			return cmdErr(fmt.Errorf("listing templates: %w", err), output.ErrGeneral)
+		}
+
+		if len(templates) == 0 {
+			quiet, _ := cmd.Flags().GetBool("quiet")
+			msg := render.EmptyState(
+				"No templates found.",
+				"Save one with: docket template save <name> --type bug --label triage",
+				quiet,
+			)
+			w.Success(templates, msg)
+			return nil
+		}
+
+		if w.JSONMode {
+			w.Success(templates, "")
+			return nil
+		}
+
+		rows := make([][]string, 0, len(templates))
+		for _, t := range templates {
+			pattern := t.TitlePattern
+			if pattern == "" {
+				pattern = "-"
+			}
+			labels := strings.Join(t.Labels, ", ")
+			if labels == "" {
+				labels = "-"
+			}
+			rows = append(rows, []string{t.Name, string(t.Kind), string(t.Priority), labels, pattern})
+		}
+
+		if render.ColorsEnabled() {
+			tbl := table.New().
+				Border(lipgloss.NormalBorder()).
+				BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("8"))).
+				Headers("NAME", "TYPE", "PRIORITY", "LABELS", "TITLE PATTERN").
+				Rows(rows...).
+				StyleFunc(func(row, col int) lipgloss.Style {
+					s := lipgloss.NewStyle().PaddingLeft(1).PaddingRight(1)
+					if row == table.HeaderRow {
+						return s.Bold(true).Foreground(lipgloss.Color("15"))
+					}
+					return s
+				})
+			w.Success(templates, tbl.Render())
+			return nil
+		}
+
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "%-16s %-9s %-10s %-24s %s\n", "NAME", "TYPE", "PRIORITY", "LABELS", "TITLE PATTERN")
+		fmt.Fprintf(&sb, "%-16s %-9s %-10s %-24s %s\n", "----", "----", "--------", "------", "-------------")
+		for _, r := range rows {
+			fmt.Fprintf(&sb, "%-16s %-9s %-10s %-24s %s\n", r[0], r[1], r[2], r[3], r[4])
+		}
+		w.Success(templates, sb.String())
+		return nil
+	},
+}
+
+func init() {
+	templateCmd.AddCommand(templateListCmd)
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var templateSaveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Create or replace an issue template",
+	Long: `Saves defaults for docket issue create --template <name>. The title
+pattern may contain {title}, which is replaced by the --title given at
+creation (e.g. "Bug: {title}"); a pattern without it is used as the title
+when none is given. Saving an existing name replaces its fields.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := getWriter(cmd)
+		conn := getDB(cmd)
+
+		name := strings.TrimSpace(args[0])
+		if name == "" {
+			return cmdErr(fmt.Errorf("template name must not be empty"), output.ErrValidation)
+		}
+
+		titlePattern, _ := cmd.Flags().GetString("title-pattern")
+		description, _ := cmd.Flags().GetString("description")
+		priority, _ := cmd.Flags().GetString("priority")
+		kind, _ := cmd.Flags().GetString("type")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+
+		if err := model.ValidatePriority(model.Priority(priority)); err != nil {
+			return cmdErr(err, output.ErrValidation)
+		}
+		if err := model.ValidateIssueKind(model.IssueKind(kind)); err != nil {
+			return cmdErr(err, output.ErrValidation)
+		}
+
+		tpl := &model.IssueTemplate{
+			Name:         name,
+			TitlePattern: titlePattern,
+			Description:  description,
+			Kind:         model.IssueKind(kind),
+			Priority:     model.Priority(priority),
+			Labels:       labels,
+		}
+		if err := db.SaveTemplate(conn, tpl); err != nil {
+			return cmdErr(fmt.Errorf("saving template: %w", err), output.ErrGeneral)
+		}
+
+		saved, err := db.GetTemplate(conn, name)
+		if err != nil {
+			return cmdErr(fmt.Errorf("fetching saved template: %w", err), output.ErrGeneral)
+		}
+
+		w.Success(saved, fmt.Sprintf("Saved template %q", name))
+		return nil
+	},
+}
+
+func init() {
+	templateSaveCmd.Flags().String("title-pattern", "", "Title pattern; {title} is replaced by --title at creation")
+	templateSaveCmd.Flags().StringP("description", "d", "", "Default issue description")
+	templateSaveCmd.Flags().StringP("priority", "p", "none", "Default issue priority")
+	templateSaveCmd.Flags().StringP("type", "T", "task", "Default issue type")
+	templateSaveCmd.Flags().StringSliceP("label", "l", nil, "Default labels (repeatable)")
+	templateCmd.AddCommand(templateSaveCmd)
+}
@@ -0,0 +1,14 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "List, restore, or permanently delete trashed issues",
+}
+
+func init() {
+	rootCmd.AddCommand(trashCmd)
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+type trashEmptyResult struct {
+	Deleted []string `json:"deleted"`
+	Total   int      `json:"total"`
+}
+
+var trashEmptyCmd = &cobra.Command{
+	Use:   "empty",
+	Short: "Permanently delete trashed issues",
+	Long: `Permanently delete trashed issues along with their comments, relations and
+history. With --older-than, only issues trashed at least that long ago are
+deleted.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTrashEmpty(cmd, args, getWriter(cmd))
+	},
+}
+
+func runTrashEmpty(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	var cutoff time.Time
+	if olderThan, _ := cmd.Flags().GetString("older-than"); olderThan != "" {
+		age, err := parseAge(olderThan)
+		if err != nil {
+			return cmdErr(err, output.ErrValidation)
+		}
+		cutoff = time.Now().Add(-age)
+	}
+
+	ids, err := db.EmptyTrash(conn, cutoff)
+	if err != nil {
+		return cmdErr(fmt.Errorf("emptying trash: %w", err), output.ErrGeneral)
+	}
+
+	deleted := make([]string, 0, len(ids))
+	for _, id := range ids {
+		deleted = append(deleted, model.FormatID(id))
+	}
+
+	message := "Trash is already empty"
+	if !cutoff.IsZero() {
+		message = "No trashed issues are old enough to delete"
+	}
+	if len(deleted) > 0 {
+		message = fmt.Sprintf("Permanently deleted %d issue(s): %s", len(deleted), strings.Join(deleted, ", "))
+	}
+	w.Success(trashEmptyResult{Deleted: deleted, Total: len(deleted)}, message)
+	return nil
+}
+
+// parseAge parses an age such as "30d", "2w" or any time.ParseDuration
+// string like "12h".
+func parseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	unit := map[byte]time.Duration{'d': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	if n := len(s); n > 1 {
+		if mult, ok := unit[s[n-1]]; ok {
+			v, err := strconv.Atoi(s[:n-1])
+			if err == nil && v >= 0 {
+				return time.Duration(v) * mult, nil
+			}
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q: use a number of days (30d), weeks (2w), or a duration such as 12h", s)
+	}
+	return d, nil
+}
+
+func init() {
+	trashEmptyCmd.Flags().String("older-than", "", "Only delete issues trashed at least this long ago (e.g. 30d, 2w, 12h)")
+	trashCmd.AddCommand(trashEmptyCmd)
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+	"github.com/spf13/cobra"
+)
+
+type trashListResult struct {
+	Issues []model.TrashedIssue `json:"issues"`
+	Total  int                  `json:"total"`
+}
+
+var trashListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List issues in the trash",
+	Aliases: []string{"ls"},
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTrashList(cmd, args, getWriter(cmd))
+	},
+}
+
+func runTrashList(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	layout, err := getLayout(cmd)
+	if err != nil {
+		return err
+	}
+
+	trashed, err := db.ListTrashedIssues(conn)
+	if err != nil {
+		return cmdErr(fmt.Errorf("listing trash: %w", err), output.ErrGeneral)
+	}
+	if trashed == nil {
+		trashed = []model.TrashedIssue{}
+	}
+
+	var message string
+	if !w.JSONMode {
+		message = render.RenderTrashList(trashed, layout)
+	}
+	w.Success(trashListResult{Issues: trashed, Total: len(trashed)}, message)
+	return nil
+}
+
+func init() {
+	trashCmd.AddCommand(trashListCmd)
+}
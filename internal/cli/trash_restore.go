@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ALT-F4-LLC/docket/internal/config"
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/output"
+	"github.com/spf13/cobra"
+)
+
+type trashRestoreResult struct {
+	ID         string   `json:"id"`
+	Restored   []string `json:"restored"`
+	Reparented bool     `json:"reparented"`
+}
+
+var trashRestoreCmd = &cobra.Command{
+	Use:   "restore <id>",
+	Short: "Restore an issue and the sub-issues trashed with it",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTrashRestore(cmd, args, getWriter(cmd))
+	},
+}
+
+func runTrashRestore(cmd *cobra.Command, args []string, w *output.Writer) error {
+	conn := getDB(cmd)
+
+	id, err := model.ParseID(args[0])
+	if err != nil {
+		return cmdErr(err, output.ErrValidation)
+	}
+
+	res, err := db.RestoreIssue(conn, id, config.DefaultAuthor())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return cmdErr(fmt.Errorf("issue %s is not in the trash", model.FormatID(id)), output.ErrNotFound)
+		}
+		return cmdErr(fmt.Errorf("restoring issue: %w", err), output.ErrGeneral)
+	}
+
+	restored := make([]string, 0, len(res.Restored))
+	for _, r := range res.Restored {
+		restored = append(restored, model.FormatID(r))
+	}
+
+	message := fmt.Sprintf("Restored %s", model.FormatID(id))
+	if n := len(restored) - 1; n > 0 {
+		message += fmt.Sprintf(" (and %d sub-issue(s))", n)
+	}
+	w.Success(trashRestoreResult{ID: model.FormatID(id), Restored: restored, Reparented: res.Reparented}, message)
+	if res.Reparented {
+		w.Warn("the parent of %s is still in the trash; restored it as a root issue", model.FormatID(id))
+	}
+	return nil
+}
+
+func init() {
+	trashCmd.AddCommand(trashRestoreCmd)
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/db"
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestTrashRestoreAndEmpty(t *testing.T) {
+	conn := newTestDB(t)
+	kept := createIssue(t, conn, "kept", model.StatusTodo, model.PriorityLow)
+	gone := createIssue(t, conn, "gone", model.StatusTodo, model.PriorityLow)
+	for _, id := range []int{kept, gone} {
+		if _, err := db.TrashIssue(conn, id, "tester"); err != nil {
+			t.Fatalf("TrashIssue: %v", err)
+		}
+	}
+
+	w, buf := bufWriter(true)
+	if err := runTrashRestore(cmdWithDB(conn), []string{model.FormatID(kept)}, w); err != nil {
+		t.Fatalf("runTrashRestore: %v", err)
+	}
+	var restored struct {
+		Data trashRestoreResult `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &restored); err != nil {
+		t.Fatalf("decoding output: %v\n%s", err, buf.String())
+	}
+	if restored.Data.ID != model.FormatID(kept) || len(restored.Data.Restored) != 1 {
+		t.Errorf("restore result = %+v", restored.Data)
+	}
+
+	emptyCmd := cmdWithDB(conn)
+	emptyCmd.Flags().String("older-than", "", "")
+	w, buf = bufWriter(true)
+	if err := runTrashEmpty(emptyCmd, nil, w); err != nil {
+		t.Fatalf("runTrashEmpty: %v", err)
+	}
+	var emptied struct {
+		Data trashEmptyResult `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &emptied); err != nil {
+		t.Fatalf("decoding output: %v\n%s", err, buf.String())
+	}
+	if emptied.Data.Total != 1 || emptied.Data.Deleted[0] != model.FormatID(gone) {
+		t.Errorf("empty result = %+v, want only %s", emptied.Data, model.FormatID(gone))
+	}
+
+	if _, err := db.GetIssue(conn, kept); err != nil {
+		t.Errorf("restored issue should survive emptying the trash: %v", err)
+	}
+	if n, err := db.CountTrashedIssues(conn); err != nil || n != 0 {
+		t.Errorf("CountTrashedIssues = %d, %v; want 0", n, err)
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"30d", 30 * 24 * time.Hour, true},
+		{"2w", 14 * 24 * time.Hour, true},
+		{"12h", 12 * time.Hour, true},
+		{"0d", 0, true},
+		{"d", 0, false},
+		{"-3d", 0, false},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := parseAge(tt.in)
+		if tt.ok && (err != nil || got != tt.want) {
+			t.Errorf("parseAge(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+		if !tt.ok && err == nil {
+			t.Errorf("parseAge(%q) = %v, want error", tt.in, got)
+		}
+	}
+}
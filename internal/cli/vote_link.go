@@ -32,7 +32,7 @@ var voteLinkCmd = &cobra.Command{
 		issueFlag, _ := cmd.Flags().GetString("issue")
 		issueID, err := model.ParseID(issueFlag)
 		if err != nil {
-			return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+			return cmdErr(err, output.ErrValidation)
 		}
 
 		if err := db.LinkProposalIssue(conn, proposalID, issueID); err != nil {
@@ -72,7 +72,7 @@ var voteUnlinkCmd = &cobra.Command{
 		issueFlag, _ := cmd.Flags().GetString("issue")
 		issueID, err := model.ParseID(issueFlag)
 		if err != nil {
-			return cmdErr(fmt.Errorf("invalid issue ID: %w", err), output.ErrValidation)
+			return cmdErr(err, output.ErrValidation)
 		}
 
 		if err := db.UnlinkProposalIssue(conn, proposalID, issueID); err != nil {
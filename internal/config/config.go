@@ -1,47 +1,221 @@
 package config
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/BurntSushi/toml"
 )
 
 const dbFileName = "issues.db"
 
 // Config holds resolved configuration for the docket directory and database.
 type Config struct {
-	DocketDir string // resolved .docket directory path
-	DBPath    string // full path to issues.db
-	EnvVarSet bool   // whether DOCKET_PATH was used
+	DocketDir   string // resolved .docket directory path
+	DBPath      string // full path to issues.db
+	EnvVarSet   bool   // whether DOCKET_PATH was used
+	FlagSet     bool   // whether --db was used
+	ReadOnly    bool   // whether DOCKET_READONLY requested read-only access
+	Actor       string // who changes are recorded as made by, from --actor or DOCKET_ACTOR
+	ProjectFile string // path of the project config file, if one was found
+	Prefix      string // issue ID prefix from the project config file
+	User        string // current user from the project config file
+	ListLimit   int    // default issue list limit from the project config file
+	AllStatuses bool   // whether issue lists include done issues by default
+
+	// MaxTitleLength is the longest issue title, in characters, from the
+	// project config file; 0 means model.DefaultMaxTitleLength.
+	MaxTitleLength int
+
+	// StatusColors maps status names to the color names they are drawn in,
+	// from the project config file's status_colors table.
+	StatusColors map[string]string
+
+	// MaxLabels is how many labels table rows and board cards show, from
+	// the project config file; 0 means render.DefaultMaxLabels.
+	MaxLabels int
+	// ImportantLabels are shown ahead of other labels when not all fit.
+	ImportantLabels []string
+
+	// DiffHunks is how many hunks of a description edit activity views
+	// show, from the project config file; 0 means render.DefaultDiffHunks.
+	DiffHunks int
+}
+
+// Options holds command-line settings, which take precedence over the
+// environment and the project config file.
+type Options struct {
+	DocketDir string // --db: the directory holding issues.db
+	Actor     string // --actor: who changes are recorded as made by
+}
+
+// Resolve returns the current configuration. The docket directory is taken
+// from opts, then DOCKET_PATH, then the project config file, and finally
+// falls back to .docket beside the project config file or in $PWD.
+// DOCKET_READONLY, when set to a true value such as "1" or "true", requests
+// read-only access. The actor is taken from opts, then DOCKET_ACTOR.
+func Resolve(opts Options) (*Config, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := FindProjectFile(cwd)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if project != nil {
+		cfg.ProjectFile = project.Path
+		cfg.Prefix = project.Prefix
+		cfg.User = project.User
+		cfg.ListLimit = project.ListLimit
+		cfg.AllStatuses = project.AllStatuses
+		cfg.MaxTitleLength = project.MaxTitleLength
+		cfg.StatusColors = project.StatusColors
+		cfg.MaxLabels = project.MaxLabels
+		cfg.ImportantLabels = project.ImportantLabels
+		cfg.DiffHunks = project.DiffHunks
+	}
+
+	switch {
+	case opts.DocketDir != "":
+		cfg.DocketDir = opts.DocketDir
+		cfg.FlagSet = true
+	case os.Getenv("DOCKET_PATH") != "":
+		cfg.DocketDir = os.Getenv("DOCKET_PATH")
+		cfg.EnvVarSet = true
+	case project != nil && project.DB != "":
+		cfg.DocketDir = project.DB
+	case project != nil:
+		cfg.DocketDir = filepath.Join(filepath.Dir(project.Path), ".docket")
+	default:
+		cfg.DocketDir = filepath.Join(cwd, ".docket")
+	}
+	cfg.DBPath = filepath.Join(cfg.DocketDir, dbFileName)
+
+	cfg.Actor = strings.TrimSpace(opts.Actor)
+	if cfg.Actor == "" {
+		cfg.Actor = strings.TrimSpace(os.Getenv("DOCKET_ACTOR"))
+	}
+
+	if v := os.Getenv("DOCKET_READONLY"); v != "" {
+		cfg.ReadOnly, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DOCKET_READONLY value %q: must be true or false", v)
+		}
+	}
+
+	return cfg, nil
+}
+
+// ProjectFileNames are the names of the project config file, in the order
+// they are looked for in each directory.
+var ProjectFileNames = []string{".docket.toml", ".docket.json"}
+
+// ProjectFile holds the settings read from a project config file. DB, when
+// relative, is resolved against the directory holding the file.
+type ProjectFile struct {
+	Path      string `toml:"-" json:"-"`
+	DB        string `toml:"db" json:"db"`
+	Prefix    string `toml:"prefix" json:"prefix"`
+	User      string `toml:"user" json:"user"`
+	ListLimit int    `toml:"list_limit" json:"list_limit"`
+
+	AllStatuses bool `toml:"all_statuses" json:"all_statuses"`
+
+	MaxTitleLength int `toml:"max_title_length" json:"max_title_length"`
+
+	StatusColors map[string]string `toml:"status_colors" json:"status_colors"`
+
+	MaxLabels       int      `toml:"max_labels" json:"max_labels"`
+	ImportantLabels []string `toml:"important_labels" json:"important_labels"`
+
+	DiffHunks int `toml:"diff_hunks" json:"diff_hunks"`
 }
 
-// Resolve returns the current configuration by checking DOCKET_PATH first,
-// then falling back to $PWD/.docket.
-func Resolve() (*Config, error) {
-	var docketDir string
-	var envVarSet bool
+// FindProjectFile looks for a project config file in dir and its parents,
+// stopping after the first directory that is a git repository root (one
+// that contains .git) or at the filesystem root. It returns nil when none
+// is found.
+func FindProjectFile(dir string) (*ProjectFile, error) {
+	for {
+		for _, name := range ProjectFileNames {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return LoadProjectFile(path)
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+		}
 
-	if envPath := os.Getenv("DOCKET_PATH"); envPath != "" {
-		docketDir = envPath
-		envVarSet = true
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return nil, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// LoadProjectFile reads the project config file at path, as TOML or JSON
+// depending on its extension. Unknown keys are an error, so that typos are
+// not silently ignored.
+func LoadProjectFile(path string) (*ProjectFile, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	var pf ProjectFile
+	if filepath.Ext(path) == ".json" {
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&pf); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
 	} else {
-		cwd, err := os.Getwd()
+		md, err := toml.NewDecoder(bytes.NewReader(raw)).Decode(&pf)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("parsing %s: unknown key %q", path, undecoded[0].String())
 		}
-		docketDir = filepath.Join(cwd, ".docket")
 	}
 
-	return &Config{
-		DocketDir: docketDir,
-		DBPath:    filepath.Join(docketDir, dbFileName),
-		EnvVarSet: envVarSet,
-	}, nil
+	if pf.ListLimit < 0 {
+		return nil, fmt.Errorf("parsing %s: list_limit must not be negative", path)
+	}
+	if pf.MaxTitleLength < 0 {
+		return nil, fmt.Errorf("parsing %s: max_title_length must not be negative", path)
+	}
+	if pf.MaxLabels < 0 {
+		return nil, fmt.Errorf("parsing %s: max_labels must not be negative", path)
+	}
+	if pf.DiffHunks < 0 {
+		return nil, fmt.Errorf("parsing %s: diff_hunks must not be negative", path)
+	}
+	pf.Path = path
+	pf.User = strings.TrimSpace(pf.User)
+	if pf.DB != "" && !filepath.IsAbs(pf.DB) {
+		pf.DB = filepath.Join(filepath.Dir(path), pf.DB)
+	}
+	return &pf, nil
 }
 
 // Exists checks if the docket directory and DB file both exist.
@@ -67,10 +241,25 @@ var (
 	defaultAuthorOnce sync.Once
 )
 
-// DefaultAuthor returns the default author for comments and activity.
-// It tries git config user.name first and falls back to the OS username.
-// The result is cached for the lifetime of the process.
+// actor, when set by SetActor, is returned by DefaultAuthor in place of the
+// git or OS user name.
+var actor string
+
+// SetActor makes DefaultAuthor return name, such as the agent or tool given
+// by --actor or DOCKET_ACTOR, for the rest of the process. An empty name
+// restores the default.
+func SetActor(name string) {
+	actor = strings.TrimSpace(name)
+}
+
+// DefaultAuthor returns the default author for comments and activity: the
+// actor set by SetActor if any, otherwise git config user.name, falling back
+// to the OS username. The looked-up name is cached for the lifetime of the
+// process.
 func DefaultAuthor() string {
+	if actor != "" {
+		return actor
+	}
 	defaultAuthorOnce.Do(func() {
 		defaultAuthor = resolveAuthor()
 	})
@@ -0,0 +1,211 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeFile creates path with content, creating parent directories.
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+}
+
+// projectDir returns a temporary git repository root with a nested working
+// directory, and changes into the nested directory.
+func projectDir(t *testing.T) (root, cwd string) {
+	t.Helper()
+	t.Setenv("DOCKET_PATH", "")
+	t.Setenv("DOCKET_READONLY", "")
+	root = t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatalf("Mkdir(.git): %v", err)
+	}
+	cwd = filepath.Join(root, "src", "pkg")
+	if err := os.MkdirAll(cwd, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	t.Chdir(cwd)
+	return root, cwd
+}
+
+func TestResolveProjectFileSetsDBPath(t *testing.T) {
+	root, _ := projectDir(t)
+	writeFile(t, filepath.Join(root, ".docket.toml"), `
+db = "tracker"
+prefix = "app"
+user = "jane"
+list_limit = 20
+all_statuses = true
+`)
+
+	cfg, err := Resolve(Options{})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if want := filepath.Join(root, "tracker", "issues.db"); cfg.DBPath != want {
+		t.Errorf("DBPath = %q, want %q", cfg.DBPath, want)
+	}
+	if cfg.ProjectFile != filepath.Join(root, ".docket.toml") {
+		t.Errorf("ProjectFile = %q", cfg.ProjectFile)
+	}
+	if cfg.Prefix != "app" || cfg.User != "jane" || cfg.ListLimit != 20 {
+		t.Errorf("got prefix %q, user %q, list limit %d", cfg.Prefix, cfg.User, cfg.ListLimit)
+	}
+	if !cfg.AllStatuses {
+		t.Error("AllStatuses = false, want true")
+	}
+	if cfg.EnvVarSet || cfg.FlagSet {
+		t.Errorf("EnvVarSet = %v, FlagSet = %v, want both false", cfg.EnvVarSet, cfg.FlagSet)
+	}
+}
+
+func TestResolveDBFlagOverridesProjectFile(t *testing.T) {
+	root, _ := projectDir(t)
+	writeFile(t, filepath.Join(root, ".docket.toml"), `db = "tracker"`)
+	t.Setenv("DOCKET_PATH", filepath.Join(root, "from-env"))
+
+	flagDir := filepath.Join(root, "from-flag")
+	cfg, err := Resolve(Options{DocketDir: flagDir})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if want := filepath.Join(flagDir, "issues.db"); cfg.DBPath != want {
+		t.Errorf("DBPath = %q, want %q", cfg.DBPath, want)
+	}
+	if !cfg.FlagSet {
+		t.Error("FlagSet = false, want true")
+	}
+}
+
+func TestResolveEnvOverridesProjectFile(t *testing.T) {
+	root, _ := projectDir(t)
+	writeFile(t, filepath.Join(root, ".docket.json"), `{"db": "tracker", "user": "jane"}`)
+	envDir := filepath.Join(root, "from-env")
+	t.Setenv("DOCKET_PATH", envDir)
+
+	cfg, err := Resolve(Options{})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if want := filepath.Join(envDir, "issues.db"); cfg.DBPath != want {
+		t.Errorf("DBPath = %q, want %q", cfg.DBPath, want)
+	}
+	if cfg.User != "jane" {
+		t.Errorf("User = %q, want jane", cfg.User)
+	}
+}
+
+func TestResolveProjectFileWithoutDBUsesItsDirectory(t *testing.T) {
+	root, _ := projectDir(t)
+	writeFile(t, filepath.Join(root, ".docket.toml"), `user = "jane"`)
+
+	cfg, err := Resolve(Options{})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if want := filepath.Join(root, ".docket", "issues.db"); cfg.DBPath != want {
+		t.Errorf("DBPath = %q, want %q", cfg.DBPath, want)
+	}
+}
+
+func TestFindProjectFileStopsAtRepoRoot(t *testing.T) {
+	root, cwd := projectDir(t)
+	// Above the repository root, so never found.
+	writeFile(t, filepath.Join(filepath.Dir(root), ".docket.toml"), `user = "outside"`)
+
+	pf, err := FindProjectFile(cwd)
+	if err != nil {
+		t.Fatalf("FindProjectFile: %v", err)
+	}
+	if pf != nil {
+		t.Errorf("found %s above the repository root", pf.Path)
+	}
+
+	cfg, err := Resolve(Options{})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if want := filepath.Join(cwd, ".docket", "issues.db"); cfg.DBPath != want {
+		t.Errorf("DBPath = %q, want %q", cfg.DBPath, want)
+	}
+}
+
+func TestLoadProjectFileRejectsUnknownKeys(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		".docket.toml": `usr = "jane"`,
+		".docket.json": `{"usr": "jane"}`,
+	} {
+		path := filepath.Join(dir, name)
+		writeFile(t, path, content)
+		_, err := LoadProjectFile(path)
+		if err == nil || !strings.Contains(err.Error(), "usr") {
+			t.Errorf("%s: error = %v, want one naming the unknown key", name, err)
+		}
+	}
+}
+
+func TestResolveProjectFileStatusColors(t *testing.T) {
+	root, _ := projectDir(t)
+	writeFile(t, filepath.Join(root, ".docket.toml"), `
+[status_colors]
+done = "blue"
+in-progress = "magenta"
+`)
+
+	cfg, err := Resolve(Options{})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if cfg.StatusColors["done"] != "blue" || cfg.StatusColors["in-progress"] != "magenta" || len(cfg.StatusColors) != 2 {
+		t.Errorf("StatusColors = %v", cfg.StatusColors)
+	}
+}
+
+func TestResolveProjectFileLabels(t *testing.T) {
+	root, _ := projectDir(t)
+	writeFile(t, filepath.Join(root, ".docket.toml"), `
+max_labels = 3
+important_labels = ["security", "customer-reported"]
+`)
+
+	cfg, err := Resolve(Options{})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if cfg.MaxLabels != 3 || !slices.Equal(cfg.ImportantLabels, []string{"security", "customer-reported"}) {
+		t.Errorf("MaxLabels = %d, ImportantLabels = %v", cfg.MaxLabels, cfg.ImportantLabels)
+	}
+
+	writeFile(t, filepath.Join(root, ".docket.toml"), "max_labels = -1\n")
+	if _, err := Resolve(Options{}); err == nil || !strings.Contains(err.Error(), "max_labels") {
+		t.Errorf("negative max_labels: error = %v", err)
+	}
+}
+
+func TestResolveProjectFileDiffHunks(t *testing.T) {
+	root, _ := projectDir(t)
+	writeFile(t, filepath.Join(root, ".docket.toml"), "diff_hunks = 5\n")
+
+	cfg, err := Resolve(Options{})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if cfg.DiffHunks != 5 {
+		t.Errorf("DiffHunks = %d, want 5", cfg.DiffHunks)
+	}
+
+	writeFile(t, filepath.Join(root, ".docket.toml"), "diff_hunks = -1\n")
+	if _, err := Resolve(Options{}); err == nil || !strings.Contains(err.Error(), "diff_hunks") {
+		t.Errorf("negative diff_hunks: error = %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+// Package cryptofile seals a payload with a passphrase so that exports can be
+// shared without exposing their contents.
+//
+// A sealed file is a fixed header followed by the AES-256-GCM ciphertext of
+// the payload:
+//
+//	magic   8 bytes  "DKTENC\x00\x01"
+//	time    4 bytes  Argon2id passes, big-endian
+//	memory  4 bytes  Argon2id memory in KiB, big-endian
+//	threads 1 byte   Argon2id parallelism
+//	salt    16 bytes
+//	nonce   12 bytes
+//
+// The key is derived from the passphrase and salt with Argon2id using the
+// cost parameters in the header. The whole header is authenticated as
+// additional data, so a changed cost parameter, salt or nonce fails to open
+// just like a changed ciphertext.
+package cryptofile
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Magic opens every sealed file.
+var Magic = []byte("DKTENC\x00\x01")
+
+const (
+	// Time, Memory and Threads are the Argon2id cost parameters used by
+	// Seal: passes over memory, memory in KiB, and parallelism.
+	Time    = 3
+	Memory  = 64 * 1024
+	Threads = 4
+
+	// maxTime and maxMemory bound the cost parameters Open accepts, so a
+	// crafted header cannot make opening a file take arbitrarily long or
+	// exhaust memory.
+	maxTime   = 16
+	maxMemory = 1024 * 1024
+
+	paramsSize = 4 + 4 + 1
+	saltSize   = 16
+	nonceSize  = 12
+	keySize    = 32
+	headerSize = 8 + paramsSize + saltSize + nonceSize
+)
+
+var (
+	// ErrNotSealed is returned by Open for data that does not start with
+	// Magic or is too short to hold a header.
+	ErrNotSealed = errors.New("not an encrypted docket file")
+
+	// ErrDecrypt is returned by Open when the passphrase is wrong or the
+	// file has been modified; GCM cannot tell the two apart.
+	ErrDecrypt = errors.New("wrong passphrase or corrupted file")
+)
+
+// IsSealed reports whether data starts with Magic.
+func IsSealed(data []byte) bool {
+	return bytes.HasPrefix(data, Magic)
+}
+
+// Seal encrypts plaintext with a key derived from passphrase under a fresh
+// random salt and nonce, and returns the header followed by the ciphertext.
+func Seal(plaintext, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("passphrase must not be empty")
+	}
+
+	header := make([]byte, headerSize)
+	copy(header, Magic)
+	params := header[len(Magic) : len(Magic)+paramsSize]
+	binary.BigEndian.PutUint32(params, Time)
+	binary.BigEndian.PutUint32(params[4:], Memory)
+	params[8] = Threads
+	salt := header[len(Magic)+paramsSize : len(Magic)+paramsSize+saltSize]
+	nonce := header[headerSize-nonceSize:]
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generating salt: %w", err)
+	}
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+
+	aead, err := newAEAD(passphrase, salt, Time, Memory, Threads)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(header, nonce, plaintext, header), nil
+}
+
+// Open decrypts data written by Seal. It returns ErrNotSealed when data has
+// no header and ErrDecrypt when the passphrase is wrong or the data was
+// tampered with.
+func Open(data, passphrase []byte) ([]byte, error) {
+	if len(data) < headerSize || !IsSealed(data) {
+		return nil, ErrNotSealed
+	}
+
+	header := data[:headerSize]
+	params := header[len(Magic) : len(Magic)+paramsSize]
+	time := binary.BigEndian.Uint32(params)
+	memory := binary.BigEndian.Uint32(params[4:])
+	threads := params[8]
+	if time == 0 || time > maxTime || threads == 0 || memory < 8*uint32(threads) || memory > maxMemory {
+		return nil, ErrDecrypt
+	}
+	salt := header[len(Magic)+paramsSize : len(Magic)+paramsSize+saltSize]
+	nonce := header[headerSize-nonceSize:]
+
+	aead, err := newAEAD(passphrase, salt, time, memory, threads)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, nonce, data[headerSize:], header)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+// newAEAD derives the key for passphrase and salt with the given Argon2id
+// cost parameters and returns an AES-GCM cipher using it.
+func newAEAD(passphrase, salt []byte, time, memory uint32, threads uint8) (cipher.AEAD, error) {
+	key := argon2.IDKey(passphrase, salt, time, memory, threads, keySize)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
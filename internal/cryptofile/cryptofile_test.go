@@ -0,0 +1,81 @@
+package cryptofile
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSealOpenRoundTrip(t *testing.T) {
+	plaintext := []byte(`{"version":1,"issues":[{"title":"Outage on db-3"}]}`)
+	sealed, err := Seal(plaintext, []byte("correct horse"))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if !IsSealed(sealed) {
+		t.Error("IsSealed(sealed) = false, want true")
+	}
+	if bytes.Contains(sealed, []byte("Outage")) {
+		t.Error("sealed data contains the plaintext")
+	}
+
+	got, err := Open(sealed, []byte("correct horse"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("Open = %q, want %q", got, plaintext)
+	}
+
+	again, err := Seal(plaintext, []byte("correct horse"))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if bytes.Equal(again, sealed) {
+		t.Error("two Seal calls produced identical output; salt and nonce must be random")
+	}
+}
+
+func TestOpenRejects(t *testing.T) {
+	sealed, err := Seal([]byte("secret"), []byte("pass"))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	flip := func(i int) []byte {
+		b := bytes.Clone(sealed)
+		b[i] ^= 0x01
+		return b
+	}
+
+	tests := []struct {
+		name       string
+		data       []byte
+		passphrase string
+		want       error
+	}{
+		{"wrong passphrase", sealed, "Pass", ErrDecrypt},
+		{"tampered ciphertext", flip(len(sealed) - 1), "pass", ErrDecrypt},
+		{"tampered time", flip(len(Magic) + 3), "pass", ErrDecrypt},
+		{"tampered memory", flip(len(Magic) + 7), "pass", ErrDecrypt},
+		{"tampered threads", flip(len(Magic) + 8), "pass", ErrDecrypt},
+		{"tampered salt", flip(len(Magic) + paramsSize), "pass", ErrDecrypt},
+		{"tampered nonce", flip(headerSize - 1), "pass", ErrDecrypt},
+		{"truncated", sealed[:headerSize+3], "pass", ErrDecrypt},
+		{"plaintext", []byte(`{"version":1}`), "pass", ErrNotSealed},
+		{"header only prefix", Magic, "pass", ErrNotSealed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Open(tt.data, []byte(tt.passphrase)); !errors.Is(err, tt.want) {
+				t.Errorf("Open error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSealEmptyPassphrase(t *testing.T) {
+	if _, err := Seal([]byte("x"), nil); err == nil {
+		t.Error("Seal with empty passphrase succeeded, want error")
+	}
+}
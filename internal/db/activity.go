@@ -73,25 +73,91 @@ func GetActivity(db *sql.DB, issueID int, limit int) ([]model.Activity, error) {
 	return activities, nil
 }
 
+// CountActivity returns how many activity log entries an issue has.
+func CountActivity(db *sql.DB, issueID int) (int, error) {
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM activity_log WHERE issue_id = ?`, issueID).Scan(&n); err != nil {
+		return 0, fmt.Errorf("counting activity: %w", err)
+	}
+	return n, nil
+}
+
+// ListActivitySince returns activity recorded at or after since on issues
+// outside the trash, oldest first, with each issue's title.
+func ListActivitySince(db *sql.DB, since time.Time) ([]model.IssueActivity, error) {
+	rows, err := db.Query(
+		`SELECT a.id, a.issue_id, a.field_changed, a.old_value, a.new_value, a.changed_by, a.created_at, i.title
+		 FROM activity_log a
+		 JOIN issues i ON i.id = a.issue_id
+		 WHERE a.created_at >= ? AND i.deleted_at IS NULL
+		 ORDER BY a.created_at ASC, a.id ASC`,
+		since.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying activity: %w", err)
+	}
+	defer rows.Close()
+
+	var activities []model.IssueActivity
+	for rows.Next() {
+		var a model.IssueActivity
+		var oldVal, newVal, changedBy sql.NullString
+		var createdAt string
+		if err := rows.Scan(&a.ID, &a.IssueID, &a.FieldChanged, &oldVal, &newVal, &changedBy, &createdAt, &a.IssueTitle); err != nil {
+			return nil, fmt.Errorf("scanning activity row: %w", err)
+		}
+		a.OldValue = oldVal.String
+		a.NewValue = newVal.String
+		a.ChangedBy = changedBy.String
+
+		t, err := time.Parse(time.RFC3339, createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("parsing activity created_at: %w", err)
+		}
+		a.CreatedAt = t
+
+		activities = append(activities, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating activity rows: %w", err)
+	}
+
+	return activities, nil
+}
+
 // ListAllActivity returns every activity_log row ordered by id ASC, for a full
 // export.
 func ListAllActivity(db *sql.DB) ([]*model.Activity, error) {
+	var activities []*model.Activity
+	err := StreamActivity(db, func(v *model.Activity) error {
+		activities = append(activities, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
+
+// StreamActivity calls fn for every activity_log row in the same order as
+// ListAllActivity without buffering the result set. fn runs while the cursor is
+// open and must not query db.
+func StreamActivity(db *sql.DB, fn func(*model.Activity) error) error {
 	rows, err := db.Query(
 		`SELECT id, issue_id, field_changed, old_value, new_value, changed_by, created_at
-		 FROM activity_log ORDER BY id ASC`,
+		 FROM activity_log WHERE issue_id IN ` + liveIssueIDs + ` ORDER BY id ASC`,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("querying all activity: %w", err)
+		return fmt.Errorf("querying all activity: %w", err)
 	}
 	defer rows.Close()
 
-	var activities []*model.Activity
 	for rows.Next() {
 		var a model.Activity
 		var oldVal, newVal, changedBy sql.NullString
 		var createdAt string
 		if err := rows.Scan(&a.ID, &a.IssueID, &a.FieldChanged, &oldVal, &newVal, &changedBy, &createdAt); err != nil {
-			return nil, fmt.Errorf("scanning activity row: %w", err)
+			return fmt.Errorf("scanning activity row: %w", err)
 		}
 		a.OldValue = oldVal.String
 		a.NewValue = newVal.String
@@ -99,17 +165,18 @@ func ListAllActivity(db *sql.DB) ([]*model.Activity, error) {
 
 		t, err := time.Parse(time.RFC3339, createdAt)
 		if err != nil {
-			return nil, fmt.Errorf("parsing activity created_at: %w", err)
+			return fmt.Errorf("parsing activity created_at: %w", err)
 		}
 		a.CreatedAt = t
 
-		activities = append(activities, &a)
+		if err := fn(&a); err != nil {
+			return err
+		}
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating activity rows: %w", err)
+		return fmt.Errorf("iterating activity rows: %w", err)
 	}
-
-	return activities, nil
+	return nil
 }
 
 // InsertActivityWithID inserts an activity_log row with a caller-supplied ID,
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/textdiff"
+)
+
+func TestListActivitySince(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	id := mustCreateIssue(t, d, "recent work")
+	if err := RecordActivity(d, id, "status", "todo", "done", "amy"); err != nil {
+		t.Fatalf("RecordActivity: %v", err)
+	}
+	old := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+	if _, err := d.Exec(
+		`INSERT INTO activity_log (issue_id, field_changed, old_value, new_value, changed_by, created_at)
+		 VALUES (?, 'title', 'a', 'b', 'amy', ?)`, id, old,
+	); err != nil {
+		t.Fatalf("inserting old activity: %v", err)
+	}
+
+	got, err := ListActivitySince(d, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("ListActivitySince: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d entries, want 2 (created + status)", len(got))
+	}
+	if got[0].FieldChanged != "created" || got[1].FieldChanged != "status" {
+		t.Errorf("entries = %q, %q; want created then status", got[0].FieldChanged, got[1].FieldChanged)
+	}
+	if got[1].IssueTitle != "recent work" || got[1].ChangedBy != "amy" {
+		t.Errorf("status entry = %+v", got[1])
+	}
+
+	if _, err := TrashIssue(d, id, "amy"); err != nil {
+		t.Fatalf("TrashIssue: %v", err)
+	}
+	got, err = ListActivitySince(d, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("ListActivitySince: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("activity on trashed issues should be hidden, got %d entries", len(got))
+	}
+}
+
+func TestUpdateIssueRecordsDescriptionDiff(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	id := mustCreateIssue(t, d, "old title")
+	if err := UpdateIssue(d, id, map[string]interface{}{"description": "one\ntwo\nthree"}, "amy"); err != nil {
+		t.Fatalf("UpdateIssue(description): %v", err)
+	}
+	if err := UpdateIssue(d, id, map[string]interface{}{"description": "one\n2\nthree\nfour", "title": "new title"}, "amy"); err != nil {
+		t.Fatalf("UpdateIssue(description, title): %v", err)
+	}
+
+	activity, err := GetActivity(d, id, 0)
+	if err != nil {
+		t.Fatalf("GetActivity: %v", err)
+	}
+	byField := make(map[string][]string)
+	for _, a := range activity {
+		if a.FieldChanged == "description" && a.OldValue != "" {
+			t.Errorf("description entry kept the old text: %q", a.OldValue)
+		}
+		byField[a.FieldChanged] = append(byField[a.FieldChanged], a.NewValue)
+	}
+
+	diffs := byField["description"]
+	if len(diffs) != 2 {
+		t.Fatalf("got %d description entries, want 2", len(diffs))
+	}
+	for i, want := range [][2]int{{3, 0}, {2, 1}} {
+		if !strings.HasPrefix(diffs[i], "--- description\n+++ description\n") {
+			t.Errorf("entry %d is not a diff: %q", i, diffs[i])
+		}
+		added, removed, ok := textdiff.Stats(diffs[i])
+		if !ok || added != want[0] || removed != want[1] {
+			t.Errorf("entry %d Stats = +%d/-%d (ok=%v), want +%d/-%d", i, added, removed, ok, want[0], want[1])
+		}
+	}
+
+	var oldTitle, newTitle string
+	for _, a := range activity {
+		if a.FieldChanged == "title" {
+			oldTitle, newTitle = a.OldValue, a.NewValue
+		}
+	}
+	if oldTitle != "old title" || newTitle != "new title" {
+		t.Errorf("title entry = %q -> %q, want full values", oldTitle, newTitle)
+	}
+}
@@ -0,0 +1,244 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// Operation kinds accepted by ApplyPatch.
+const (
+	PatchOpUpdate   = "update"
+	PatchOpComment  = "comment"
+	PatchOpLabelAdd = "label-add"
+	PatchOpRelate   = "relate"
+)
+
+// PatchOps lists the operation kinds accepted by ApplyPatch.
+var PatchOps = []string{PatchOpUpdate, PatchOpComment, PatchOpLabelAdd, PatchOpRelate}
+
+// PatchOp is one operation in a batch applied by ApplyPatch. The fields used
+// depend on Op:
+//
+//   - update: IssueID and Fields, keyed as for UpdateIssue
+//   - comment: IssueID and Body
+//   - label-add: IssueID and Labels
+//   - relate: IssueID as the source, TargetID and RelationType
+type PatchOp struct {
+	Op           string
+	IssueID      int
+	Fields       map[string]interface{}
+	Body         string
+	Labels       []string
+	TargetID     int
+	RelationType model.RelationType
+}
+
+// PatchResult describes the outcome of one applied PatchOp.
+type PatchResult struct {
+	Op      string
+	IssueID int
+	// CommentID is set for comment operations and RelationID for relate
+	// operations.
+	CommentID  int
+	RelationID int
+}
+
+// PatchError reports the operation that stopped ApplyPatch. Index is the
+// operation's zero-based position in the patch.
+type PatchError struct {
+	Index int
+	Op    string
+	Err   error
+}
+
+func (e *PatchError) Error() string {
+	return fmt.Sprintf("op %d (%s): %v", e.Index, e.Op, e.Err)
+}
+
+func (e *PatchError) Unwrap() error { return e.Err }
+
+// ApplyOptions controls ApplyPatch.
+type ApplyOptions struct {
+	// DryRun applies every operation and then rolls the transaction back, so
+	// the results show what would happen without changing anything.
+	DryRun bool
+	// ChangedBy is recorded as the author of every change and comment.
+	ChangedBy string
+}
+
+// ApplyPatch applies a batch of operations atomically. Every operation is
+// validated before any is applied: the operation kind, that each issue it
+// names is live, field names and enum values, and that relations are
+// neither self-referential, duplicates nor cycles. The operations are then
+// applied in order in a single transaction, where checks that depend on
+// earlier operations, such as status transitions and cycles formed within
+// the patch, run again. Any failure rolls back the whole patch and is
+// returned as a *PatchError wrapping the underlying error.
+func ApplyPatch(db *sql.DB, ops []PatchOp, opts ApplyOptions) ([]PatchResult, error) {
+	return withRetryValue(func() ([]PatchResult, error) { return applyPatch(db, ops, opts) })
+}
+
+func applyPatch(db *sql.DB, ops []PatchOp, opts ApplyOptions) ([]PatchResult, error) {
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("%w: patch has no operations", ErrValidation)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i, op := range ops {
+		if err := validatePatchOpTx(tx, op); err != nil {
+			return nil, &PatchError{Index: i, Op: op.Op, Err: err}
+		}
+	}
+
+	results := make([]PatchResult, len(ops))
+	for i, op := range ops {
+		result, err := applyPatchOpTx(tx, op, opts.ChangedBy)
+		if err != nil {
+			return nil, &PatchError{Index: i, Op: op.Op, Err: err}
+		}
+		results[i] = result
+	}
+
+	if opts.DryRun {
+		return results, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return results, nil
+}
+
+// validatePatchOpTx checks op against the database as it stood before the
+// patch.
+func validatePatchOpTx(tx *sql.Tx, op PatchOp) error {
+	switch op.Op {
+	case PatchOpUpdate:
+		if len(op.Fields) == 0 {
+			return fmt.Errorf("%w: update has no fields", ErrValidation)
+		}
+		for _, field := range slices.Sorted(maps.Keys(op.Fields)) {
+			if !validUpdateFields[field] {
+				return fmt.Errorf("%w: unknown field %q", ErrValidation, field)
+			}
+			if err := validatePatchFieldValue(field, op.Fields[field]); err != nil {
+				return err
+			}
+		}
+	case PatchOpComment:
+		if strings.TrimSpace(op.Body) == "" {
+			return fmt.Errorf("%w: comment body is empty", ErrValidation)
+		}
+	case PatchOpLabelAdd:
+		if len(op.Labels) == 0 {
+			return fmt.Errorf("%w: label-add has no labels", ErrValidation)
+		}
+		for _, name := range op.Labels {
+			if strings.TrimSpace(name) == "" {
+				return fmt.Errorf("%w: label name is empty", ErrValidation)
+			}
+		}
+	case PatchOpRelate:
+		if err := model.ValidateRelationType(op.RelationType); err != nil {
+			return fmt.Errorf("%w: %v", ErrValidation, err)
+		}
+		if op.IssueID == op.TargetID {
+			return ErrSelfRelation
+		}
+	default:
+		return fmt.Errorf("%w: unknown op %q (must be one of %s)", ErrValidation, op.Op, strings.Join(PatchOps, ", "))
+	}
+
+	if err := requireLiveIssueTx(tx, op.IssueID); err != nil {
+		return err
+	}
+
+	if op.Op == PatchOpRelate {
+		if err := requireLiveIssueTx(tx, op.TargetID); err != nil {
+			return err
+		}
+		if err := checkDuplicateTx(tx, op.IssueID, op.TargetID, op.RelationType); err != nil {
+			return err
+		}
+		switch op.RelationType {
+		case model.RelationBlocks, model.RelationDependsOn, model.RelationSupersedes:
+			hasCycle, path, err := checkCycleTx(tx, op.IssueID, op.TargetID, string(op.RelationType))
+			if err != nil {
+				return fmt.Errorf("checking for cycles: %w", err)
+			}
+			if hasCycle {
+				return &CycleError{Path: path}
+			}
+		}
+	}
+	return nil
+}
+
+// validatePatchFieldValue checks the value of one update field: the title
+// and enums must be valid and parent_id and milestone_id must be IDs or nil.
+func validatePatchFieldValue(field string, v interface{}) error {
+	var err error
+	switch field {
+	case "title":
+		err = model.ValidateTitle(fmt.Sprint(v))
+	case "status":
+		err = model.ValidateStatus(model.Status(fmt.Sprint(v)))
+	case "priority":
+		err = model.ValidatePriority(model.Priority(fmt.Sprint(v)))
+	case "kind":
+		err = model.ValidateIssueKind(model.IssueKind(fmt.Sprint(v)))
+	case "parent_id", "milestone_id":
+		switch v.(type) {
+		case nil, int:
+		default:
+			err = fmt.Errorf("%s must be an ID or null, got %T", field, v)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+	return nil
+}
+
+// requireLiveIssueTx returns an error wrapping ErrNotFound, naming the
+// issue, unless id is a live issue.
+func requireLiveIssueTx(tx *sql.Tx, id int) error {
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM issues WHERE id = ? AND deleted_at IS NULL)`, id).Scan(&exists); err != nil {
+		return fmt.Errorf("checking issue existence: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("issue %s %w", model.FormatID(id), ErrNotFound)
+	}
+	return nil
+}
+
+// applyPatchOpTx applies one validated operation within tx.
+func applyPatchOpTx(tx *sql.Tx, op PatchOp, author string) (PatchResult, error) {
+	result := PatchResult{Op: op.Op, IssueID: op.IssueID}
+	var err error
+	switch op.Op {
+	case PatchOpUpdate:
+		err = updateIssueTx(tx, op.IssueID, op.Fields, author)
+	case PatchOpComment:
+		result.CommentID, err = createCommentTx(tx, &model.Comment{IssueID: op.IssueID, Body: op.Body, Author: author})
+	case PatchOpLabelAdd:
+		_, err = addLabelsToIssueTx(tx, op.IssueID, op.Labels, "", author, true)
+	case PatchOpRelate:
+		result.RelationID, err = createRelationTx(tx, &model.Relation{
+			SourceIssueID: op.IssueID,
+			TargetIssueID: op.TargetID,
+			RelationType:  op.RelationType,
+		}, CreateRelationOptions{ChangedBy: author})
+	}
+	return result, err
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestApplyPatch(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	a := mustCreateIssue(t, d, "a")
+	b := mustCreateIssue(t, d, "b")
+
+	ops := []PatchOp{
+		{Op: PatchOpUpdate, IssueID: a, Fields: map[string]interface{}{"status": "todo", "priority": "high"}},
+		{Op: PatchOpComment, IssueID: b, Body: "see DKT-1"},
+		{Op: PatchOpLabelAdd, IssueID: a, Labels: []string{"bug", "ui"}},
+		{Op: PatchOpRelate, IssueID: a, TargetID: b, RelationType: model.RelationBlocks},
+	}
+	results, err := ApplyPatch(d, ops, ApplyOptions{ChangedBy: "agent"})
+	if err != nil {
+		t.Fatalf("ApplyPatch: %v", err)
+	}
+	if len(results) != 4 || results[1].CommentID == 0 || results[3].RelationID == 0 {
+		t.Fatalf("results = %+v", results)
+	}
+
+	issue, err := GetIssue(d, a)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if issue.Status != model.StatusTodo || issue.Priority != model.PriorityHigh {
+		t.Errorf("issue a = %s/%s, want todo/high", issue.Status, issue.Priority)
+	}
+	if labels, _ := GetIssueLabels(d, a); !slices.Equal(labels, []string{"bug", "ui"}) {
+		t.Errorf("labels = %v", labels)
+	}
+	if comments, _ := ListIssueComments(d, b); len(comments) != 1 || comments[0].Author != "agent" {
+		t.Errorf("comments = %+v", comments)
+	}
+	if rels, _ := GetIssueRelations(d, b); len(rels) != 1 {
+		t.Errorf("relations on b = %+v, want 1", rels)
+	}
+}
+
+func TestApplyPatchRollsBack(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	a := mustCreateIssue(t, d, "a")
+	b := mustCreateIssue(t, d, "b")
+	c := mustCreateIssue(t, d, "c")
+
+	tests := []struct {
+		name  string
+		ops   []PatchOp
+		index int
+		want  error
+	}{
+		{"missing issue", []PatchOp{
+			{Op: PatchOpComment, IssueID: a, Body: "ok"},
+			{Op: PatchOpLabelAdd, IssueID: 99, Labels: []string{"bug"}},
+		}, 1, ErrNotFound},
+		{"bad enum", []PatchOp{
+			{Op: PatchOpComment, IssueID: a, Body: "ok"},
+			{Op: PatchOpUpdate, IssueID: b, Fields: map[string]interface{}{"priority": "urgent"}},
+		}, 1, ErrValidation},
+		{"unknown op", []PatchOp{{Op: "delete", IssueID: a}}, 0, ErrValidation},
+		{"cycle within the patch", []PatchOp{
+			{Op: PatchOpComment, IssueID: a, Body: "ok"},
+			{Op: PatchOpRelate, IssueID: a, TargetID: b, RelationType: model.RelationBlocks},
+			{Op: PatchOpRelate, IssueID: b, TargetID: c, RelationType: model.RelationBlocks},
+			{Op: PatchOpRelate, IssueID: c, TargetID: a, RelationType: model.RelationBlocks},
+		}, 3, ErrCycleDetected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ApplyPatch(d, tt.ops, ApplyOptions{})
+			var pe *PatchError
+			if !errors.As(err, &pe) || pe.Index != tt.index || !errors.Is(err, tt.want) {
+				t.Fatalf("ApplyPatch error = %v, want op %d wrapping %v", err, tt.index, tt.want)
+			}
+			if comments, _ := ListIssueComments(d, a); len(comments) != 0 {
+				t.Errorf("failed patch left %d comment(s)", len(comments))
+			}
+		})
+	}
+}
+
+func TestApplyPatchDryRun(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	a := mustCreateIssue(t, d, "a")
+
+	results, err := ApplyPatch(d, []PatchOp{{Op: PatchOpComment, IssueID: a, Body: "maybe"}}, ApplyOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("ApplyPatch: %v", err)
+	}
+	if len(results) != 1 || results[0].CommentID == 0 {
+		t.Errorf("results = %+v", results)
+	}
+	if comments, _ := ListIssueComments(d, a); len(comments) != 0 {
+		t.Errorf("dry run left %d comment(s)", len(comments))
+	}
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// BoardQueryOptions selects the issues shown on the board. Every status,
+// including done, is included. Pinned issues lead each status and are
+// returned on every page, outside Limit and Offset.
+type BoardQueryOptions struct {
+	Priorities     []string  // filter by priority (multiple = OR)
+	Labels         []string  // filter by label name (multiple = AND)
+	Assignee       string    // filter by assignee
+	RootsOnly      bool      // only issues with no parent
+	IncludeSnoozed bool      // include currently snoozed issues (default: exclude)
+	SortKeys       []SortKey // order within each status; empty means the ListIssues default
+	Limit          int       // max issues fetched per status; 0 means no limit
+	Offset         int       // issues skipped at the top of each status
+}
+
+// BoardIssues is one page of the board: the fetched issues of each status
+// and the number of matching issues each status has in total.
+type BoardIssues struct {
+	Columns map[model.Status][]*model.Issue
+	Totals  map[model.Status]int
+}
+
+// ListBoardIssues returns up to opts.Limit issues per status, after skipping
+// opts.Offset, along with the true per-status totals. Rows are windowed per
+// status in SQL, so only the returned issues are loaded and hydrated with
+// labels and files no matter how large the board is.
+func ListBoardIssues(db *sql.DB, opts BoardQueryOptions) (BoardIssues, error) {
+	fromSQL, args := listFromClause(ListOptions{
+		Priorities:     opts.Priorities,
+		Labels:         opts.Labels,
+		Assignee:       opts.Assignee,
+		RootsOnly:      opts.RootsOnly,
+		IncludeDone:    true,
+		IncludeSnoozed: opts.IncludeSnoozed,
+	})
+	orderBySQL, err := orderByClause(opts.SortKeys)
+	if err != nil {
+		return BoardIssues{}, err
+	}
+
+	board := BoardIssues{
+		Columns: make(map[model.Status][]*model.Issue),
+		Totals:  make(map[model.Status]int),
+	}
+
+	if err := countBoardIssues(db, fromSQL, args, board.Totals); err != nil {
+		return BoardIssues{}, err
+	}
+
+	// Safe: fromSQL holds only placeholders and fixed clauses, and
+	// orderBySQL is built from allowlisted sort fields.
+	query := fmt.Sprintf(
+		`SELECT id, parent_id, title, description, status, priority, kind, assignee, milestone_id, created_at, updated_at, started_at, completed_at, snoozed_until, sort_order, pinned
+		 FROM (
+			SELECT i.id, i.parent_id, i.title, i.description, i.status, i.priority, i.kind, i.assignee, i.milestone_id, i.created_at, i.updated_at, i.started_at, i.completed_at, i.snoozed_until, i.sort_order, i.pinned,
+			       ROW_NUMBER() OVER (PARTITION BY i.status, i.pinned %s) AS board_row
+			%s
+		 )
+		 WHERE pinned = 1 OR (board_row > ?`,
+		orderBySQL, fromSQL,
+	)
+	queryArgs := append(append([]interface{}{}, args...), opts.Offset)
+	if opts.Limit > 0 {
+		query += " AND board_row <= ?"
+		queryArgs = append(queryArgs, opts.Offset+opts.Limit)
+	}
+	query += ") ORDER BY pinned DESC, board_row"
+
+	rows, err := db.Query(query, queryArgs...)
+	if err != nil {
+		return BoardIssues{}, fmt.Errorf("querying board issues: %w", err)
+	}
+	defer rows.Close()
+
+	var issues []*model.Issue
+	for rows.Next() {
+		issue, err := scanIssueRow(rows)
+		if err != nil {
+			return BoardIssues{}, err
+		}
+		issues = append(issues, issue)
+		board.Columns[issue.Status] = append(board.Columns[issue.Status], issue)
+	}
+	if err := rows.Err(); err != nil {
+		return BoardIssues{}, fmt.Errorf("iterating board issues: %w", err)
+	}
+
+	if err := HydrateLabels(db, issues); err != nil {
+		return BoardIssues{}, fmt.Errorf("hydrating labels: %w", err)
+	}
+	if err := HydrateFiles(db, issues); err != nil {
+		return BoardIssues{}, fmt.Errorf("hydrating files: %w", err)
+	}
+
+	return board, nil
+}
+
+// countBoardIssues stores the number of issues selected by fromSQL per
+// status in totals.
+func countBoardIssues(db *sql.DB, fromSQL string, args []interface{}, totals map[model.Status]int) error {
+	rows, err := db.Query(fmt.Sprintf(
+		`SELECT status, COUNT(*) FROM (SELECT i.id, i.status %s) GROUP BY status`, fromSQL,
+	), args...)
+	if err != nil {
+		return fmt.Errorf("counting board issues: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return fmt.Errorf("scanning board count: %w", err)
+		}
+		totals[model.Status(status)] = count
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating board counts: %w", err)
+	}
+	return nil
+}
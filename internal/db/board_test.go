@@ -0,0 +1,218 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func boardIDs(issues []*model.Issue) []int {
+	ids := make([]int, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids
+}
+
+func TestListBoardIssuesPagesEachStatus(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	for i := range 13 {
+		createTestIssue(t, db, fmt.Sprintf("todo %d", i), model.StatusTodo, model.PriorityMedium)
+	}
+	createTestIssue(t, db, "active 1", model.StatusInProgress, model.PriorityMedium)
+	createTestIssue(t, db, "active 2", model.StatusInProgress, model.PriorityMedium)
+	createTestIssue(t, db, "shipped", model.StatusDone, model.PriorityMedium)
+
+	first, err := ListBoardIssues(db, BoardQueryOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("ListBoardIssues: %v", err)
+	}
+	wantTotals := map[model.Status]int{model.StatusTodo: 13, model.StatusInProgress: 2, model.StatusDone: 1}
+	if !reflect.DeepEqual(first.Totals, wantTotals) {
+		t.Errorf("Totals = %v, want %v", first.Totals, wantTotals)
+	}
+	if got := len(first.Columns[model.StatusTodo]); got != 10 {
+		t.Errorf("fetched %d todo issues, want 10", got)
+	}
+	if got := len(first.Columns[model.StatusInProgress]); got != 2 {
+		t.Errorf("fetched %d in-progress issues, want 2", got)
+	}
+
+	// The second page picks up where the first left off in the full order.
+	all, err := ListBoardIssues(db, BoardQueryOptions{})
+	if err != nil {
+		t.Fatalf("ListBoardIssues: %v", err)
+	}
+	if got := len(all.Columns[model.StatusTodo]); got != 13 {
+		t.Fatalf("unlimited board fetched %d todo issues, want 13", got)
+	}
+	second, err := ListBoardIssues(db, BoardQueryOptions{Limit: 10, Offset: 10})
+	if err != nil {
+		t.Fatalf("ListBoardIssues: %v", err)
+	}
+	paged := append(boardIDs(first.Columns[model.StatusTodo]), boardIDs(second.Columns[model.StatusTodo])...)
+	if want := boardIDs(all.Columns[model.StatusTodo]); !reflect.DeepEqual(paged, want) {
+		t.Errorf("todo pages = %v, want %v", paged, want)
+	}
+	if got := second.Columns[model.StatusInProgress]; len(got) != 0 {
+		t.Errorf("second in-progress page = %v, want empty", boardIDs(got))
+	}
+	if second.Totals[model.StatusTodo] != 13 {
+		t.Errorf("second page todo total = %d, want 13", second.Totals[model.StatusTodo])
+	}
+}
+
+func TestListBoardIssuesFiltersAndHydrates(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	parent := createTestIssue(t, db, "parent", model.StatusTodo, model.PriorityHigh)
+	createTestIssueWithParent(t, db, "child", model.StatusTodo, model.PriorityHigh, parent)
+	other := createTestIssue(t, db, "other", model.StatusTodo, model.PriorityLow)
+	if err := AddLabelToIssue(db, parent, "backend", "", "tester"); err != nil {
+		t.Fatalf("AddLabelToIssue: %v", err)
+	}
+
+	board, err := ListBoardIssues(db, BoardQueryOptions{RootsOnly: true})
+	if err != nil {
+		t.Fatalf("ListBoardIssues: %v", err)
+	}
+	if got, want := boardIDs(board.Columns[model.StatusTodo]), []int{parent, other}; !reflect.DeepEqual(got, want) {
+		t.Errorf("roots = %v, want %v", got, want)
+	}
+	if board.Totals[model.StatusTodo] != 2 {
+		t.Errorf("roots total = %d, want 2", board.Totals[model.StatusTodo])
+	}
+	if labels := board.Columns[model.StatusTodo][0].Labels; !reflect.DeepEqual(labels, []string{"backend"}) {
+		t.Errorf("parent labels = %v, want [backend]", labels)
+	}
+
+	board, err = ListBoardIssues(db, BoardQueryOptions{Labels: []string{"backend"}})
+	if err != nil {
+		t.Fatalf("ListBoardIssues: %v", err)
+	}
+	if got := boardIDs(board.Columns[model.StatusTodo]); !reflect.DeepEqual(got, []int{parent}) {
+		t.Errorf("labelled = %v, want [%d]", got, parent)
+	}
+	if board.Totals[model.StatusTodo] != 1 {
+		t.Errorf("labelled total = %d, want 1", board.Totals[model.StatusTodo])
+	}
+}
+
+func TestListBoardIssuesSortKeys(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	seed := []struct {
+		priority model.Priority
+		created  time.Duration
+		updated  time.Duration
+	}{
+		{model.PriorityNone, 0, 9 * time.Hour},
+		{model.PriorityCritical, 3 * time.Hour, 3 * time.Hour},
+		{model.PriorityLow, time.Hour, 5 * time.Hour},
+		{model.PriorityLow, time.Hour, 5 * time.Hour},
+	}
+	for i, s := range seed {
+		id := createTestIssue(t, db, fmt.Sprintf("card %d", i+1), model.StatusTodo, s.priority)
+		if _, err := db.Exec(`UPDATE issues SET created_at = ?, updated_at = ? WHERE id = ?`,
+			base.Add(s.created).Format(time.RFC3339), base.Add(s.updated).Format(time.RFC3339), id); err != nil {
+			t.Fatalf("setting timestamps: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		keys []SortKey
+		want []int
+	}{
+		{"priority", []SortKey{{Field: "priority", Dir: "desc"}}, []int{2, 3, 4, 1}},
+		{"age", []SortKey{{Field: "created_at", Dir: "asc"}}, []int{1, 3, 4, 2}},
+		{"updated", []SortKey{{Field: "updated_at", Dir: "desc"}}, []int{1, 3, 4, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board, err := ListBoardIssues(db, BoardQueryOptions{SortKeys: tt.keys, Limit: 10})
+			if err != nil {
+				t.Fatalf("ListBoardIssues: %v", err)
+			}
+			if got := boardIDs(board.Columns[model.StatusTodo]); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// seedBoardBenchmark inserts n issues spread across every status.
+func seedBoardBenchmark(b *testing.B, n int) *sql.DB {
+	b.Helper()
+	db, err := Open(":memory:")
+	if err != nil {
+		b.Fatalf("Open: %v", err)
+	}
+	b.Cleanup(func() { db.Close() })
+	if err := Initialize(db); err != nil {
+		b.Fatalf("Initialize: %v", err)
+	}
+
+	statuses := []model.Status{model.StatusBacklog, model.StatusTodo, model.StatusInProgress, model.StatusReview, model.StatusDone}
+	now := time.Now().UTC().Format(time.RFC3339)
+	tx, err := db.Begin()
+	if err != nil {
+		b.Fatalf("Begin: %v", err)
+	}
+	defer tx.Rollback()
+	for i := range n {
+		if _, err := tx.Exec(
+			`INSERT INTO issues (title, status, priority, kind, created_at, updated_at) VALUES (?, ?, 'medium', 'task', ?, ?)`,
+			fmt.Sprintf("issue %d", i), statuses[i%len(statuses)], now, now,
+		); err != nil {
+			b.Fatalf("inserting issue: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		b.Fatalf("Commit: %v", err)
+	}
+	return db
+}
+
+// BenchmarkBoardListAllIssues measures the board's previous approach of
+// loading every issue and grouping by status in memory.
+func BenchmarkBoardListAllIssues(b *testing.B) {
+	db := seedBoardBenchmark(b, 5000)
+	b.ReportAllocs()
+	for b.Loop() {
+		issues, _, err := ListIssues(db, ListOptions{IncludeDone: true, RootsOnly: true})
+		if err != nil {
+			b.Fatal(err)
+		}
+		columns := make(map[model.Status][]*model.Issue)
+		for _, issue := range issues {
+			columns[issue.Status] = append(columns[issue.Status], issue)
+		}
+	}
+}
+
+// BenchmarkListBoardIssues measures fetching one page per status.
+func BenchmarkListBoardIssues(b *testing.B) {
+	db := seedBoardBenchmark(b, 5000)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := ListBoardIssues(db, BoardQueryOptions{RootsOnly: true, Limit: 10}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
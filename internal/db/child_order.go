@@ -0,0 +1,170 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// SetChildOrder ranks the children of parentID: the issues in orderedIDs
+// come first, in that order, followed by any remaining children in their
+// current order. Every ID in orderedIDs must be a child of parentID, and may
+// appear only once; anything else is reported as ErrValidation. A single
+// "child_order" activity entry on the parent records the old and new order.
+func SetChildOrder(db *sql.DB, parentID int, orderedIDs []int, changedBy string) error {
+	return WithRetry(func() error {
+		return reorderChildren(db, parentID, changedBy, func(current []int) ([]int, error) {
+			return leadingOrder(parentID, current, orderedIDs)
+		})
+	})
+}
+
+// MoveIssueBefore moves id to just before siblingID among their parent's
+// children. Both issues must share a parent; see SetChildOrder.
+func MoveIssueBefore(db *sql.DB, id, siblingID int, changedBy string) error {
+	return WithRetry(func() error { return moveIssueNextTo(db, id, siblingID, 0, changedBy) })
+}
+
+// MoveIssueAfter moves id to just after siblingID among their parent's
+// children. Both issues must share a parent; see SetChildOrder.
+func MoveIssueAfter(db *sql.DB, id, siblingID int, changedBy string) error {
+	return WithRetry(func() error { return moveIssueNextTo(db, id, siblingID, 1, changedBy) })
+}
+
+// moveIssueNextTo moves id to the position of siblingID plus offset, after
+// taking id out of the order.
+func moveIssueNextTo(db *sql.DB, id, siblingID, offset int, changedBy string) error {
+	if id == siblingID {
+		return fmt.Errorf("%w: cannot move %s relative to itself", ErrValidation, model.FormatID(id))
+	}
+
+	parentID, err := siblingParent(db, id, siblingID)
+	if err != nil {
+		return err
+	}
+
+	return reorderChildren(db, parentID, changedBy, func(current []int) ([]int, error) {
+		order := slices.DeleteFunc(slices.Clone(current), func(c int) bool { return c == id })
+		at := slices.Index(order, siblingID)
+		if at < 0 || len(order) == len(current) {
+			// Either issue was moved or trashed since siblingParent looked.
+			return nil, ErrNotFound
+		}
+		return slices.Insert(order, at+offset, id), nil
+	})
+}
+
+// siblingParent returns the parent shared by id and siblingID.
+func siblingParent(db *sql.DB, id, siblingID int) (int, error) {
+	issue, err := GetIssue(db, id)
+	if err != nil {
+		return 0, err
+	}
+	sibling, err := GetIssue(db, siblingID)
+	if err != nil {
+		return 0, err
+	}
+	if issue.ParentID == nil {
+		return 0, fmt.Errorf("%w: %s has no parent to order it under", ErrValidation, model.FormatID(id))
+	}
+	if sibling.ParentID == nil || *sibling.ParentID != *issue.ParentID {
+		return 0, fmt.Errorf("%w: %s and %s are not siblings", ErrValidation, model.FormatID(id), model.FormatID(siblingID))
+	}
+	return *issue.ParentID, nil
+}
+
+// leadingOrder returns current reordered so that orderedIDs come first.
+func leadingOrder(parentID int, current, orderedIDs []int) ([]int, error) {
+	order := make([]int, 0, len(current))
+	for _, id := range orderedIDs {
+		if !slices.Contains(current, id) {
+			return nil, fmt.Errorf("%w: %s is not a child of %s", ErrValidation, model.FormatID(id), model.FormatID(parentID))
+		}
+		if slices.Contains(order, id) {
+			return nil, fmt.Errorf("%w: %s is listed more than once", ErrValidation, model.FormatID(id))
+		}
+		order = append(order, id)
+	}
+	for _, id := range current {
+		if !slices.Contains(order, id) {
+			order = append(order, id)
+		}
+	}
+	return order, nil
+}
+
+// reorderChildren replaces the order of parentID's children with the one
+// reorder computes from their current order, ranking every child and
+// recording the change on the parent, in one transaction. It is a no-op
+// when the order does not change.
+func reorderChildren(db *sql.DB, parentID int, changedBy string, reorder func(current []int) ([]int, error)) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := getIssueTx(tx, parentID); err != nil {
+		return err
+	}
+	current, err := childIDsTx(tx, parentID)
+	if err != nil {
+		return err
+	}
+	order, err := reorder(current)
+	if err != nil {
+		return err
+	}
+	if slices.Equal(order, current) {
+		return nil
+	}
+
+	for rank, id := range order {
+		if _, err := tx.Exec(`UPDATE issues SET sort_order = ? WHERE id = ?`, rank+1, id); err != nil {
+			return fmt.Errorf("ranking %s: %w", model.FormatID(id), err)
+		}
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := tx.Exec(`UPDATE issues SET updated_at = ? WHERE id = ?`, now, parentID); err != nil {
+		return fmt.Errorf("updating parent: %w", err)
+	}
+	if err := RecordActivity(tx, parentID, "child_order", formatIDList(current), formatIDList(order), changedBy); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// childIDsTx returns the IDs of parentID's children in display order.
+func childIDsTx(tx *sql.Tx, parentID int) ([]int, error) {
+	rows, err := tx.Query(
+		`SELECT id FROM issues WHERE parent_id = ? AND deleted_at IS NULL
+		 ORDER BY sort_order IS NULL, sort_order, created_at, id`, parentID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying children: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning child id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// formatIDList formats ids as "DKT-1, DKT-2" for activity entries.
+func formatIDList(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = model.FormatID(id)
+	}
+	return strings.Join(parts, ", ")
+}
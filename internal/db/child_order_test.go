@@ -0,0 +1,195 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// subIssueIDs returns the IDs GetSubIssues returns for parentID, in order.
+func subIssueIDs(t *testing.T, conn *sql.DB, parentID int) []int {
+	t.Helper()
+	subs, err := GetSubIssues(conn, parentID)
+	if err != nil {
+		t.Fatalf("GetSubIssues: %v", err)
+	}
+	ids := make([]int, len(subs))
+	for i, sub := range subs {
+		ids[i] = sub.ID
+	}
+	return ids
+}
+
+func TestSetChildOrder(t *testing.T) {
+	conn := mustOpen(t)
+	if err := Initialize(conn); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	parent := createTestIssue(t, conn, "Runbook", model.StatusTodo, model.PriorityNone)
+	a := createTestIssueWithParent(t, conn, "Step A", model.StatusTodo, model.PriorityNone, parent)
+	b := createTestIssueWithParent(t, conn, "Step B", model.StatusTodo, model.PriorityNone, parent)
+	c := createTestIssueWithParent(t, conn, "Step C", model.StatusTodo, model.PriorityNone, parent)
+
+	if got := subIssueIDs(t, conn, parent); !slices.Equal(got, []int{a, b, c}) {
+		t.Fatalf("initial order = %v, want creation order %v", got, []int{a, b, c})
+	}
+
+	// Unlisted children keep their current order after the listed ones.
+	if err := SetChildOrder(conn, parent, []int{c, a}, "alice"); err != nil {
+		t.Fatalf("SetChildOrder: %v", err)
+	}
+	want := []int{c, a, b}
+	if got := subIssueIDs(t, conn, parent); !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+
+	tree, err := GetSubIssueTree(conn, parent, 0)
+	if err != nil {
+		t.Fatalf("GetSubIssueTree: %v", err)
+	}
+	var treeIDs []int
+	for _, issue := range tree {
+		treeIDs = append(treeIDs, issue.ID)
+	}
+	if !slices.Equal(treeIDs, want) {
+		t.Errorf("tree order = %v, want %v", treeIDs, want)
+	}
+
+	// A child created later follows the ranked ones.
+	d := createTestIssueWithParent(t, conn, "Step D", model.StatusTodo, model.PriorityNone, parent)
+	if got := subIssueIDs(t, conn, parent); !slices.Equal(got, append(want, d)) {
+		t.Errorf("order after new child = %v, want %v", got, append(want, d))
+	}
+
+	var count int
+	var oldValue, newValue string
+	if err := conn.QueryRow(
+		`SELECT COUNT(*), MAX(old_value), MAX(new_value) FROM activity_log
+		 WHERE issue_id = ? AND field_changed = 'child_order'`, parent,
+	).Scan(&count, &oldValue, &newValue); err != nil {
+		t.Fatalf("querying child_order activity: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("child_order activity entries = %d, want 1", count)
+	}
+	if wantOld := formatIDList([]int{a, b, c}); oldValue != wantOld {
+		t.Errorf("old_value = %q, want %q", oldValue, wantOld)
+	}
+	if wantNew := formatIDList(want); newValue != wantNew {
+		t.Errorf("new_value = %q, want %q", newValue, wantNew)
+	}
+}
+
+func TestSetChildOrderErrors(t *testing.T) {
+	conn := mustOpen(t)
+	if err := Initialize(conn); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	parent := createTestIssue(t, conn, "Runbook", model.StatusTodo, model.PriorityNone)
+	a := createTestIssueWithParent(t, conn, "Step A", model.StatusTodo, model.PriorityNone, parent)
+	b := createTestIssueWithParent(t, conn, "Step B", model.StatusTodo, model.PriorityNone, parent)
+	stranger := createTestIssue(t, conn, "Unrelated", model.StatusTodo, model.PriorityNone)
+
+	if err := SetChildOrder(conn, parent, []int{b, stranger}, "alice"); !errors.Is(err, ErrValidation) {
+		t.Errorf("non-child: expected ErrValidation, got %v", err)
+	}
+	if err := SetChildOrder(conn, parent, []int{b, b}, "alice"); !errors.Is(err, ErrValidation) {
+		t.Errorf("duplicate: expected ErrValidation, got %v", err)
+	}
+	if err := SetChildOrder(conn, 999, []int{a}, "alice"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing parent: expected ErrNotFound, got %v", err)
+	}
+
+	// Failed reorders leave the order, and the activity log, untouched.
+	if got := subIssueIDs(t, conn, parent); !slices.Equal(got, []int{a, b}) {
+		t.Errorf("order = %v, want %v", got, []int{a, b})
+	}
+	var count int
+	if err := conn.QueryRow(`SELECT COUNT(*) FROM activity_log WHERE field_changed = 'child_order'`).Scan(&count); err != nil {
+		t.Fatalf("counting activity: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("child_order activity entries = %d, want 0", count)
+	}
+}
+
+func TestMoveIssueBeforeAfter(t *testing.T) {
+	conn := mustOpen(t)
+	if err := Initialize(conn); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	parent := createTestIssue(t, conn, "Runbook", model.StatusTodo, model.PriorityNone)
+	a := createTestIssueWithParent(t, conn, "Step A", model.StatusTodo, model.PriorityNone, parent)
+	b := createTestIssueWithParent(t, conn, "Step B", model.StatusTodo, model.PriorityNone, parent)
+	c := createTestIssueWithParent(t, conn, "Step C", model.StatusTodo, model.PriorityNone, parent)
+
+	if err := MoveIssueBefore(conn, c, a, "alice"); err != nil {
+		t.Fatalf("MoveIssueBefore: %v", err)
+	}
+	if got, want := subIssueIDs(t, conn, parent), []int{c, a, b}; !slices.Equal(got, want) {
+		t.Errorf("after move before = %v, want %v", got, want)
+	}
+
+	if err := MoveIssueAfter(conn, c, b, "alice"); err != nil {
+		t.Fatalf("MoveIssueAfter: %v", err)
+	}
+	if got, want := subIssueIDs(t, conn, parent), []int{a, b, c}; !slices.Equal(got, want) {
+		t.Errorf("after move after = %v, want %v", got, want)
+	}
+
+	other := createTestIssue(t, conn, "Other parent", model.StatusTodo, model.PriorityNone)
+	cousin := createTestIssueWithParent(t, conn, "Cousin", model.StatusTodo, model.PriorityNone, other)
+	if err := MoveIssueBefore(conn, a, cousin, "alice"); !errors.Is(err, ErrValidation) {
+		t.Errorf("non-sibling: expected ErrValidation, got %v", err)
+	}
+	if err := MoveIssueBefore(conn, parent, other, "alice"); !errors.Is(err, ErrValidation) {
+		t.Errorf("no parent: expected ErrValidation, got %v", err)
+	}
+	if err := MoveIssueAfter(conn, a, a, "alice"); !errors.Is(err, ErrValidation) {
+		t.Errorf("self: expected ErrValidation, got %v", err)
+	}
+}
+
+func TestReparentClearsSortOrder(t *testing.T) {
+	conn := mustOpen(t)
+	if err := Initialize(conn); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	parent := createTestIssue(t, conn, "Runbook", model.StatusTodo, model.PriorityNone)
+	a := createTestIssueWithParent(t, conn, "Step A", model.StatusTodo, model.PriorityNone, parent)
+	b := createTestIssueWithParent(t, conn, "Step B", model.StatusTodo, model.PriorityNone, parent)
+	if err := SetChildOrder(conn, parent, []int{b, a}, "alice"); err != nil {
+		t.Fatalf("SetChildOrder: %v", err)
+	}
+
+	other := createTestIssue(t, conn, "Other parent", model.StatusTodo, model.PriorityNone)
+	if err := UpdateIssue(conn, b, map[string]interface{}{"parent_id": other}, "alice"); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+	moved, err := GetIssue(conn, b)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if moved.SortOrder != nil {
+		t.Errorf("SortOrder = %d after reparenting, want nil", *moved.SortOrder)
+	}
+
+	// Setting the same parent again keeps the rank.
+	if err := UpdateIssue(conn, a, map[string]interface{}{"parent_id": parent, "title": "Step A'"}, "alice"); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+	kept, err := GetIssue(conn, a)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if kept.SortOrder == nil {
+		t.Error("SortOrder = nil after a no-op reparent, want it kept")
+	}
+}
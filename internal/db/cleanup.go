@@ -0,0 +1,181 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// purgeBatchSize is how many issues PurgeIssues deletes per transaction, so
+// a large cleanup never holds the write lock for one giant transaction.
+const purgeBatchSize = 500
+
+// PurgeCounts reports how many rows PurgeIssues permanently removed.
+type PurgeCounts struct {
+	Issues    int `json:"issues"`
+	Comments  int `json:"comments"`
+	Relations int `json:"relations"`
+}
+
+// CleanupCandidates returns the done issues outside the trash that were
+// completed before cutoff (or, lacking a completion time, last updated
+// before it) and are safe to delete permanently: none blocks an open issue,
+// through a "blocks" relation or an open issue's "depends_on", and every
+// sub-issue, trashed or not, is itself a candidate, so deleting a candidate
+// never takes an open issue with it. Issues are ordered by ID.
+func CleanupCandidates(db *sql.DB, cutoff time.Time) ([]*model.Issue, error) {
+	rows, err := db.Query(
+		`SELECT id, parent_id, title, description, status, priority, kind, assignee, milestone_id, created_at, updated_at, started_at, completed_at, snoozed_until, sort_order, pinned
+		 FROM issues
+		 WHERE deleted_at IS NULL AND status = ? AND COALESCE(completed_at, updated_at) < ?
+		 ORDER BY id ASC`,
+		string(model.StatusDone), cutoff.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying done issues: %w", err)
+	}
+	var done []*model.Issue
+	for rows.Next() {
+		issue, err := scanIssueRow(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		done = append(done, issue)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("iterating issue rows: %w", err)
+	}
+	rows.Close()
+	if len(done) == 0 {
+		return nil, nil
+	}
+
+	keep := make(map[int]bool, len(done))
+	for _, issue := range done {
+		keep[issue.ID] = true
+	}
+
+	rows, err = db.Query(
+		`WITH edges(blocker, blocked) AS (` + blockingEdges + `)
+		 SELECT DISTINCT e.blocker FROM edges e JOIN issues b ON b.id = e.blocked
+		 WHERE b.status != 'done'`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying blockers of open issues: %w", err)
+	}
+	blockers, err := scanIDs(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range blockers {
+		delete(keep, id)
+	}
+
+	rows, err = db.Query(`SELECT id, parent_id FROM issues WHERE parent_id IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("querying sub-issues: %w", err)
+	}
+	type edge struct{ child, parent int }
+	var children []edge
+	for rows.Next() {
+		var e edge
+		if err := rows.Scan(&e.child, &e.parent); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning sub-issue: %w", err)
+		}
+		children = append(children, e)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("iterating sub-issues: %w", err)
+	}
+	rows.Close()
+
+	// Dropping a parent can leave its own parent with a kept-back child, so
+	// repeat until nothing changes.
+	for changed := true; changed; {
+		changed = false
+		for _, e := range children {
+			if keep[e.parent] && !keep[e.child] {
+				delete(keep, e.parent)
+				changed = true
+			}
+		}
+	}
+
+	candidates := make([]*model.Issue, 0, len(keep))
+	for _, issue := range done {
+		if keep[issue.ID] {
+			candidates = append(candidates, issue)
+		}
+	}
+	return candidates, nil
+}
+
+// PurgeIssues permanently deletes the given issues, purgeBatchSize at a
+// time, each batch in its own transaction. Foreign-key cascades remove their
+// comments, relations and other dependent rows; sub-issues not among ids are
+// made root issues. It returns the counts removed, including those of the
+// batches that completed before any error.
+func PurgeIssues(db *sql.DB, ids []int) (PurgeCounts, error) {
+	var total PurgeCounts
+	for start := 0; start < len(ids); start += purgeBatchSize {
+		batch := ids[start:min(start+purgeBatchSize, len(ids))]
+		counts, err := withRetryValue(func() (PurgeCounts, error) { return purgeBatch(db, batch) })
+		if err != nil {
+			return total, err
+		}
+		total.Issues += counts.Issues
+		total.Comments += counts.Comments
+		total.Relations += counts.Relations
+	}
+	return total, nil
+}
+
+func purgeBatch(db *sql.DB, ids []int) (PurgeCounts, error) {
+	var counts PurgeCounts
+
+	tx, err := db.Begin()
+	if err != nil {
+		return counts, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	placeholders := makePlaceholders(len(ids))
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	if err := tx.QueryRow(
+		`SELECT COUNT(*) FROM comments WHERE issue_id IN (`+placeholders+`)`, args...,
+	).Scan(&counts.Comments); err != nil {
+		return counts, fmt.Errorf("counting comments: %w", err)
+	}
+	if err := tx.QueryRow(
+		`SELECT COUNT(*) FROM issue_relations
+		 WHERE source_issue_id IN (`+placeholders+`) OR target_issue_id IN (`+placeholders+`)`,
+		append(args, args...)...,
+	).Scan(&counts.Relations); err != nil {
+		return counts, fmt.Errorf("counting relations: %w", err)
+	}
+
+	res, err := tx.Exec(`DELETE FROM issues WHERE id IN (`+placeholders+`)`, args...)
+	if err != nil {
+		return counts, fmt.Errorf("deleting issues: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return counts, fmt.Errorf("counting deleted issues: %w", err)
+	}
+	counts.Issues = int(n)
+
+	if err := tx.Commit(); err != nil {
+		return counts, fmt.Errorf("committing transaction: %w", err)
+	}
+	return counts, nil
+}
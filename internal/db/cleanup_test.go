@@ -0,0 +1,106 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestCleanupCandidates(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	old := createTestIssue(t, d, "old", model.StatusDone, model.PriorityLow)
+	recent := createTestIssue(t, d, "recent", model.StatusDone, model.PriorityLow)
+	open := createTestIssue(t, d, "open", model.StatusTodo, model.PriorityLow)
+	blocker := createTestIssue(t, d, "blocker", model.StatusDone, model.PriorityLow)
+	parent := createTestIssue(t, d, "parent", model.StatusDone, model.PriorityLow)
+	createTestIssueWithParent(t, d, "open child", model.StatusTodo, model.PriorityLow, parent)
+	grandparent := createTestIssue(t, d, "grandparent", model.StatusDone, model.PriorityLow)
+	doneParent := createTestIssueWithParent(t, d, "done parent", model.StatusDone, model.PriorityLow, grandparent)
+	createTestIssueWithParent(t, d, "open grandchild", model.StatusInProgress, model.PriorityLow, doneParent)
+	tree := createTestIssue(t, d, "done tree", model.StatusDone, model.PriorityLow)
+	leaf := createTestIssueWithParent(t, d, "done leaf", model.StatusDone, model.PriorityLow, tree)
+
+	if _, err := CreateRelation(d, &model.Relation{SourceIssueID: open, TargetIssueID: blocker, RelationType: model.RelationDependsOn}); err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+	// A done issue blocking another done issue is still a candidate.
+	if _, err := CreateRelation(d, &model.Relation{SourceIssueID: old, TargetIssueID: leaf, RelationType: model.RelationBlocks}); err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+
+	cutoff := time.Now().Add(-24 * time.Hour)
+	if _, err := d.Exec(`UPDATE issues SET completed_at = ? WHERE id != ?`, cutoff.Add(-time.Hour).UTC().Format(time.RFC3339), recent); err != nil {
+		t.Fatalf("backdating completed_at: %v", err)
+	}
+
+	candidates, err := CleanupCandidates(d, cutoff)
+	if err != nil {
+		t.Fatalf("CleanupCandidates: %v", err)
+	}
+	var got []int
+	for _, issue := range candidates {
+		got = append(got, issue.ID)
+	}
+	want := []int{old, tree, leaf}
+	if len(got) != len(want) {
+		t.Fatalf("candidates = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("candidates = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestPurgeIssuesCountsRemovedRows(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := createTestIssue(t, d, "a", model.StatusDone, model.PriorityLow)
+	b := createTestIssue(t, d, "b", model.StatusDone, model.PriorityLow)
+	keep := createTestIssue(t, d, "keep", model.StatusTodo, model.PriorityLow)
+
+	for _, id := range []int{a, a, b} {
+		if _, err := CreateComment(d, &model.Comment{IssueID: id, Body: "note", Author: "tester"}); err != nil {
+			t.Fatalf("CreateComment: %v", err)
+		}
+	}
+	for _, rel := range []model.Relation{
+		{SourceIssueID: a, TargetIssueID: b, RelationType: model.RelationBlocks},
+		{SourceIssueID: keep, TargetIssueID: a, RelationType: model.RelationRelatesTo},
+	} {
+		if _, err := CreateRelation(d, &rel); err != nil {
+			t.Fatalf("CreateRelation: %v", err)
+		}
+	}
+
+	counts, err := PurgeIssues(d, []int{a, b})
+	if err != nil {
+		t.Fatalf("PurgeIssues: %v", err)
+	}
+	if want := (PurgeCounts{Issues: 2, Comments: 3, Relations: 2}); counts != want {
+		t.Errorf("counts = %+v, want %+v", counts, want)
+	}
+
+	n, err := CountIssues(d)
+	if err != nil {
+		t.Fatalf("CountIssues: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("CountIssues = %d, want 1", n)
+	}
+	rels, err := GetIssueRelations(d, keep)
+	if err != nil {
+		t.Fatalf("GetIssueRelations: %v", err)
+	}
+	if len(rels) != 0 {
+		t.Errorf("relations to purged issues should be gone, got %d", len(rels))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ALT-F4-LLC/docket/internal/model"
@@ -11,17 +12,34 @@ import (
 
 // CreateComment inserts a new comment for an issue, records activity, and
 // returns its ID. The insert and activity log are wrapped in a single
-// transaction so they succeed or fail together.
+// transaction so they succeed or fail together. Anyone @mentioned in the
+// body other than the author is notified.
 func CreateComment(db *sql.DB, comment *model.Comment) (int, error) {
+	return withRetryValue(func() (int, error) { return createComment(db, comment) })
+}
+
+func createComment(db *sql.DB, comment *model.Comment) (int, error) {
 	tx, err := db.Begin()
 	if err != nil {
 		return 0, fmt.Errorf("beginning transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	id, err := createCommentTx(tx, comment)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing transaction: %w", err)
+	}
+	return id, nil
+}
+
+// createCommentTx is CreateComment within an existing transaction.
+func createCommentTx(tx *sql.Tx, comment *model.Comment) (int, error) {
 	// Verify the issue exists.
 	var exists bool
-	if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)", comment.IssueID).Scan(&exists); err != nil {
+	if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM issues WHERE id = ? AND deleted_at IS NULL)", comment.IssueID).Scan(&exists); err != nil {
 		return 0, fmt.Errorf("checking issue existence: %w", err)
 	}
 	if !exists {
@@ -47,6 +65,14 @@ func CreateComment(db *sql.DB, comment *model.Comment) (int, error) {
 		return 0, fmt.Errorf("getting last insert id: %w", err)
 	}
 
+	commentID := int(id64)
+	if err := syncReferencesTx(tx, comment.IssueID, model.ReferenceContextComment, &commentID, comment.Body); err != nil {
+		return 0, err
+	}
+	if err := notifyMentionsTx(tx, comment.IssueID, comment.Body, "", comment.Author); err != nil {
+		return 0, err
+	}
+
 	// Touch the issue's updated_at so recently-commented issues surface in sorted lists.
 	if _, err := tx.Exec(`UPDATE issues SET updated_at = ? WHERE id = ?`, now, comment.IssueID); err != nil {
 		return 0, fmt.Errorf("updating issue timestamp: %w", err)
@@ -56,18 +82,32 @@ func CreateComment(db *sql.DB, comment *model.Comment) (int, error) {
 		return 0, err
 	}
 
-	if err := tx.Commit(); err != nil {
-		return 0, fmt.Errorf("committing transaction: %w", err)
-	}
+	return commentID, nil
+}
+
+// ListIssueComments retrieves all comments for an issue, ordered by creation
+// time ascending. See ListComments to search comments across issues.
+func ListIssueComments(db *sql.DB, issueID int) ([]*model.Comment, error) {
+	return listIssueComments(db, issueID, false)
+}
 
-	return int(id64), nil
+// ListIssueCommentsNewestFirst is ListIssueComments ordered by creation time
+// descending.
+func ListIssueCommentsNewestFirst(db *sql.DB, issueID int) ([]*model.Comment, error) {
+	return listIssueComments(db, issueID, true)
 }
 
-// ListComments retrieves all comments for an issue, ordered by creation time ascending.
-func ListComments(db *sql.DB, issueID int) ([]*model.Comment, error) {
+// listIssueComments lists an issue's comments oldest first, or newest first
+// when desc is set. Comments created in the same second keep the order they
+// were added in, reversed along with the rest.
+func listIssueComments(db *sql.DB, issueID int, desc bool) ([]*model.Comment, error) {
+	dir := "ASC"
+	if desc {
+		dir = "DESC"
+	}
 	rows, err := db.Query(
 		`SELECT id, issue_id, body, author, created_at
-		 FROM comments WHERE issue_id = ? ORDER BY created_at ASC`, issueID,
+		 FROM comments WHERE issue_id = ? ORDER BY created_at `+dir+`, id `+dir, issueID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("querying comments: %w", err)
@@ -89,6 +129,139 @@ func ListComments(db *sql.DB, issueID int) ([]*model.Comment, error) {
 	return comments, nil
 }
 
+// CommentListOptions filters ListComments. Zero values disable a filter.
+type CommentListOptions struct {
+	Author   string    // exact author, ignoring case
+	IssueID  int       // only comments on this issue
+	Since    time.Time // created at or after
+	Until    time.Time // created before
+	Contains string    // body substring, ignoring ASCII case
+	Limit    int       // max results
+	Offset   int       // for pagination
+}
+
+// ListComments searches comments across issues outside the trash, newest
+// first, with the title of each comment's issue. It returns the page of
+// matching comments and the total number of matches ignoring Limit and
+// Offset.
+func ListComments(db *sql.DB, opts CommentListOptions) ([]model.IssueComment, int, error) {
+	var where []string
+	var args []any
+	if opts.Author != "" {
+		where = append(where, "c.author = ? COLLATE NOCASE")
+		args = append(args, opts.Author)
+	}
+	if opts.IssueID != 0 {
+		where = append(where, "c.issue_id = ?")
+		args = append(args, opts.IssueID)
+	}
+	if !opts.Since.IsZero() {
+		where = append(where, "c.created_at >= ?")
+		args = append(args, opts.Since.UTC().Format(time.RFC3339))
+	}
+	if !opts.Until.IsZero() {
+		where = append(where, "c.created_at < ?")
+		args = append(args, opts.Until.UTC().Format(time.RFC3339))
+	}
+	if opts.Contains != "" {
+		where = append(where, `c.body LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+likeEscaper.Replace(opts.Contains)+"%")
+	}
+
+	from := `FROM comments c JOIN issues i ON i.id = c.issue_id AND i.deleted_at IS NULL`
+	if len(where) > 0 {
+		from += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	var total int
+	if err := db.QueryRow("SELECT COUNT(*) "+from, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting comments: %w", err)
+	}
+
+	query := `SELECT c.id, c.issue_id, c.body, c.author, c.created_at, i.title ` + from +
+		` ORDER BY c.created_at DESC, c.id DESC`
+	if opts.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, opts.Limit)
+	}
+	if opts.Offset > 0 {
+		if opts.Limit <= 0 {
+			query += " LIMIT -1"
+		}
+		query += " OFFSET ?"
+		args = append(args, opts.Offset)
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("querying comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := make([]model.IssueComment, 0)
+	for rows.Next() {
+		var c model.IssueComment
+		var author sql.NullString
+		var createdAt string
+		if err := rows.Scan(&c.ID, &c.IssueID, &c.Body, &author, &createdAt, &c.IssueTitle); err != nil {
+			return nil, 0, fmt.Errorf("scanning comment row: %w", err)
+		}
+		c.Author = author.String
+		if c.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+			return nil, 0, fmt.Errorf("parsing created_at: %w", err)
+		}
+		comments = append(comments, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterating comment rows: %w", err)
+	}
+
+	return comments, total, nil
+}
+
+// likeEscaper escapes the LIKE wildcards in a literal, for patterns that
+// use ESCAPE '\'.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// GetCommentsByIssueIDs returns the comments of the given issues in a single
+// query, keyed by issue ID and in chronological order within each issue.
+// Issues without comments have no entry in the map.
+func GetCommentsByIssueIDs(db *sql.DB, ids []int) (map[int][]*model.Comment, error) {
+	byIssue := make(map[int][]*model.Comment)
+	if len(ids) == 0 {
+		return byIssue, nil
+	}
+
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	query := fmt.Sprintf(
+		`SELECT id, issue_id, body, author, created_at
+		 FROM comments WHERE issue_id IN (%s)
+		 ORDER BY created_at ASC, id ASC`, makePlaceholders(len(ids)),
+	)
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying comments: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		c, err := scanCommentFrom(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning comment row: %w", err)
+		}
+		byIssue[c.IssueID] = append(byIssue[c.IssueID], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating comment rows: %w", err)
+	}
+
+	return byIssue, nil
+}
+
 // GetComment retrieves a comment by ID.
 func GetComment(db *sql.DB, id int) (*model.Comment, error) {
 	row := db.QueryRow(
@@ -110,28 +283,107 @@ func GetComment(db *sql.DB, id int) (*model.Comment, error) {
 // ListAllComments returns every comment in the database across all issues,
 // ordered by created_at ascending.
 func ListAllComments(db *sql.DB) ([]*model.Comment, error) {
+	var comments []*model.Comment
+	err := StreamComments(db, func(v *model.Comment) error {
+		comments = append(comments, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// StreamComments calls fn for every comment row in the same order as
+// ListAllComments without buffering the result set. fn runs while the cursor is
+// open and must not query db.
+func StreamComments(db *sql.DB, fn func(*model.Comment) error) error {
 	rows, err := db.Query(
 		`SELECT id, issue_id, body, author, created_at
-		 FROM comments ORDER BY created_at ASC`,
+		 FROM comments WHERE issue_id IN ` + liveIssueIDs + ` ORDER BY created_at ASC`,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("querying all comments: %w", err)
+		return fmt.Errorf("querying all comments: %w", err)
 	}
 	defer rows.Close()
 
-	var comments []*model.Comment
 	for rows.Next() {
 		c, err := scanCommentFrom(rows)
 		if err != nil {
-			return nil, fmt.Errorf("scanning comment row: %w", err)
+			return fmt.Errorf("scanning comment row: %w", err)
+		}
+		if err := fn(c); err != nil {
+			return err
 		}
-		comments = append(comments, c)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating comment rows: %w", err)
+		return fmt.Errorf("iterating comment rows: %w", err)
 	}
+	return nil
+}
 
-	return comments, nil
+// CountCommentsBetween returns the number of comments on issues outside the
+// trash created in [since, until).
+func CountCommentsBetween(db *sql.DB, since, until time.Time) (int, error) {
+	var count int
+	err := db.QueryRow(
+		`SELECT COUNT(*) FROM comments
+		 WHERE issue_id IN `+liveIssueIDs+` AND created_at >= ? AND created_at < ?`,
+		since.UTC().Format(time.RFC3339), until.UTC().Format(time.RFC3339),
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("counting comments: %w", err)
+	}
+	return count, nil
+}
+
+// HydrateCommentCounts populates CommentCount and LastCommentAt for a set of
+// issues using a single grouped query. Issues without comments are reset to a
+// zero count and zero time.
+func HydrateCommentCounts(db *sql.DB, issues []*model.Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	ids := make([]any, len(issues))
+	issueMap := make(map[int]*model.Issue, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+		issueMap[issue.ID] = issue
+		issue.CommentCount = 0
+		issue.LastCommentAt = time.Time{}
+	}
+
+	query := fmt.Sprintf(
+		`SELECT issue_id, COUNT(*), MAX(created_at) FROM comments
+		 WHERE issue_id IN (%s)
+		 GROUP BY issue_id`, makePlaceholders(len(ids)),
+	)
+
+	rows, err := db.Query(query, ids...)
+	if err != nil {
+		return fmt.Errorf("querying comment counts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var issueID, count int
+		var lastAt string
+		if err := rows.Scan(&issueID, &count, &lastAt); err != nil {
+			return fmt.Errorf("scanning comment count: %w", err)
+		}
+		issue, ok := issueMap[issueID]
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, lastAt)
+		if err != nil {
+			return fmt.Errorf("parsing last comment time: %w", err)
+		}
+		issue.CommentCount = count
+		issue.LastCommentAt = t
+	}
+	return rows.Err()
 }
 
 // InsertCommentWithID inserts a comment with a specific ID (not auto-increment),
@@ -0,0 +1,310 @@
+package db
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestHydrateCommentCounts(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	busy := mustCreateIssue(t, db, "busy")
+	quiet := mustCreateIssue(t, db, "quiet")
+
+	first := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	last := time.Date(2026, 3, 4, 17, 30, 0, 0, time.UTC)
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	for i, at := range []time.Time{first, last, first.Add(time.Hour)} {
+		if _, err := InsertCommentWithID(tx, &model.Comment{
+			ID: i + 1, IssueID: busy, Body: "note", Author: "alice", CreatedAt: at,
+		}); err != nil {
+			t.Fatalf("InsertCommentWithID: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	// Stale values on the quiet issue must be cleared.
+	issues := []*model.Issue{
+		{ID: busy},
+		{ID: quiet, CommentCount: 7, LastCommentAt: last},
+	}
+	if err := HydrateCommentCounts(db, issues); err != nil {
+		t.Fatalf("HydrateCommentCounts: %v", err)
+	}
+
+	if issues[0].CommentCount != 3 {
+		t.Errorf("busy CommentCount = %d, want 3", issues[0].CommentCount)
+	}
+	if !issues[0].LastCommentAt.Equal(last) {
+		t.Errorf("busy LastCommentAt = %v, want %v", issues[0].LastCommentAt, last)
+	}
+	if issues[1].CommentCount != 0 {
+		t.Errorf("quiet CommentCount = %d, want 0", issues[1].CommentCount)
+	}
+	if !issues[1].LastCommentAt.IsZero() {
+		t.Errorf("quiet LastCommentAt = %v, want zero", issues[1].LastCommentAt)
+	}
+}
+
+func TestListIssuesSortByComments(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	none := mustCreateIssue(t, db, "none")
+	many := mustCreateIssue(t, db, "many")
+	one := mustCreateIssue(t, db, "one")
+	for _, id := range []int{many, many, one} {
+		if _, err := CreateComment(db, &model.Comment{IssueID: id, Body: "x", Author: "alice"}); err != nil {
+			t.Fatalf("CreateComment: %v", err)
+		}
+	}
+
+	for _, tc := range []struct {
+		dir  string
+		want []int
+	}{
+		{"desc", []int{many, one, none}},
+		{"asc", []int{none, one, many}},
+	} {
+		issues, _, err := ListIssues(db, ListOptions{Sort: "comments", SortDir: tc.dir})
+		if err != nil {
+			t.Fatalf("ListIssues(%s): %v", tc.dir, err)
+		}
+		if len(issues) != len(tc.want) {
+			t.Fatalf("%s: len = %d, want %d", tc.dir, len(issues), len(tc.want))
+		}
+		for i, id := range tc.want {
+			if issues[i].ID != id {
+				t.Errorf("%s: issues[%d].ID = %d, want %d", tc.dir, i, issues[i].ID, id)
+			}
+		}
+	}
+}
+
+func TestGetCommentsByIssueIDs(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	first := mustCreateIssue(t, db, "first")
+	second := mustCreateIssue(t, db, "second")
+	other := mustCreateIssue(t, db, "not requested")
+	quiet := mustCreateIssue(t, db, "no comments")
+
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	seed := []struct {
+		issueID int
+		offset  time.Duration
+	}{
+		{first, 2 * time.Hour},
+		{second, time.Hour},
+		{first, 0},
+		{other, time.Hour},
+		{second, 3 * time.Hour},
+		{first, time.Hour},
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	for i, s := range seed {
+		if _, err := InsertCommentWithID(tx, &model.Comment{
+			ID: i + 1, IssueID: s.issueID, Body: "note", Author: "alice", CreatedAt: base.Add(s.offset),
+		}); err != nil {
+			t.Fatalf("InsertCommentWithID: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	got, err := GetCommentsByIssueIDs(db, []int{first, second, quiet})
+	if err != nil {
+		t.Fatalf("GetCommentsByIssueIDs: %v", err)
+	}
+
+	commentIDs := func(comments []*model.Comment) []int {
+		ids := make([]int, len(comments))
+		for i, c := range comments {
+			if c.IssueID != comments[0].IssueID {
+				t.Errorf("comment %d grouped with issue %d", c.ID, comments[0].IssueID)
+			}
+			ids[i] = c.ID
+		}
+		return ids
+	}
+	want := map[int][]int{
+		first:  {3, 6, 1},
+		second: {2, 5},
+	}
+	if len(got) != len(want) {
+		t.Errorf("got comments for %d issues, want %d: %v", len(got), len(want), got)
+	}
+	for issueID, wantIDs := range want {
+		gotIDs := commentIDs(got[issueID])
+		if len(gotIDs) != len(wantIDs) {
+			t.Errorf("issue %d comments = %v, want %v", issueID, gotIDs, wantIDs)
+			continue
+		}
+		for i := range wantIDs {
+			if gotIDs[i] != wantIDs[i] {
+				t.Errorf("issue %d comments = %v, want %v", issueID, gotIDs, wantIDs)
+				break
+			}
+		}
+	}
+	if _, ok := got[quiet]; ok {
+		t.Errorf("issue without comments has an entry: %v", got[quiet])
+	}
+
+	empty, err := GetCommentsByIssueIDs(db, nil)
+	if err != nil {
+		t.Fatalf("GetCommentsByIssueIDs(nil): %v", err)
+	}
+	if len(empty) != 0 {
+		t.Errorf("GetCommentsByIssueIDs(nil) = %v, want empty", empty)
+	}
+}
+
+func TestListComments(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	login := mustCreateIssue(t, db, "Flaky login test")
+	cache := mustCreateIssue(t, db, "Cache warmup")
+	trashed := mustCreateIssue(t, db, "Trashed")
+
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	for i, c := range []model.Comment{
+		{IssueID: login, Body: "Fails 100% of the time on CI", Author: "bob"},
+		{IssueID: cache, Body: "Warmup takes 5s", Author: "alice"},
+		{IssueID: login, Body: "Retry_count helps", Author: "Bob"},
+		{IssueID: trashed, Body: "gone", Author: "bob"},
+	} {
+		c.ID, c.CreatedAt = i+1, base.Add(time.Duration(i)*time.Hour)
+		if _, err := InsertCommentWithID(tx, &c); err != nil {
+			t.Fatalf("InsertCommentWithID: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if _, err := TrashIssue(db, trashed, "alice"); err != nil {
+		t.Fatalf("TrashIssue: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		opts      CommentListOptions
+		wantIDs   []int
+		wantTotal int
+	}{
+		{"all, newest first", CommentListOptions{}, []int{3, 2, 1}, 3},
+		{"author ignores case", CommentListOptions{Author: "BOB"}, []int{3, 1}, 2},
+		{"issue", CommentListOptions{IssueID: cache}, []int{2}, 1},
+		{"since", CommentListOptions{Since: base.Add(time.Hour)}, []int{3, 2}, 2},
+		{"until", CommentListOptions{Until: base.Add(time.Hour)}, []int{1}, 1},
+		{"contains percent literally", CommentListOptions{Contains: "100%"}, []int{1}, 1},
+		{"contains underscore literally", CommentListOptions{Contains: "y_c"}, []int{3}, 1},
+		{"contains ignores case", CommentListOptions{Contains: "warmup"}, []int{2}, 1},
+		{"page", CommentListOptions{Limit: 1, Offset: 1}, []int{2}, 3},
+		{"offset without limit", CommentListOptions{Offset: 2}, []int{1}, 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			comments, total, err := ListComments(db, tc.opts)
+			if err != nil {
+				t.Fatalf("ListComments: %v", err)
+			}
+			var ids []int
+			for _, c := range comments {
+				ids = append(ids, c.ID)
+			}
+			if !slices.Equal(ids, tc.wantIDs) {
+				t.Errorf("ids = %v, want %v", ids, tc.wantIDs)
+			}
+			if total != tc.wantTotal {
+				t.Errorf("total = %d, want %d", total, tc.wantTotal)
+			}
+		})
+	}
+
+	comments, _, err := ListComments(db, CommentListOptions{IssueID: cache})
+	if err != nil {
+		t.Fatalf("ListComments: %v", err)
+	}
+	if len(comments) != 1 || comments[0].IssueTitle != "Cache warmup" {
+		t.Errorf("comments = %+v, want one with IssueTitle %q", comments, "Cache warmup")
+	}
+}
+
+func TestListIssueCommentsOrder(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	id := mustCreateIssue(t, db, "Ordering")
+
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	// The last two share a timestamp, so ID breaks the tie.
+	for i, at := range []time.Time{base.Add(time.Hour), base, base.Add(2 * time.Hour), base.Add(2 * time.Hour)} {
+		c := model.Comment{ID: i + 1, IssueID: id, Body: "note", Author: "tester", CreatedAt: at}
+		if _, err := InsertCommentWithID(tx, &c); err != nil {
+			t.Fatalf("InsertCommentWithID: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		list    func() ([]*model.Comment, error)
+		wantIDs []int
+		later   func(a, b time.Time) bool
+	}{
+		{"oldest first", func() ([]*model.Comment, error) { return ListIssueComments(db, id) }, []int{2, 1, 3, 4}, time.Time.After},
+		{"newest first", func() ([]*model.Comment, error) { return ListIssueCommentsNewestFirst(db, id) }, []int{4, 3, 1, 2}, time.Time.Before},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			comments, err := tc.list()
+			if err != nil {
+				t.Fatalf("list: %v", err)
+			}
+			var ids []int
+			for i, c := range comments {
+				ids = append(ids, c.ID)
+				if i > 0 && !tc.later(c.CreatedAt, comments[i-1].CreatedAt) && !c.CreatedAt.Equal(comments[i-1].CreatedAt) {
+					t.Errorf("comment %d at %v is out of order after %v", c.ID, c.CreatedAt, comments[i-1].CreatedAt)
+				}
+			}
+			if !slices.Equal(ids, tc.wantIDs) {
+				t.Errorf("IDs = %v, want %v", ids, tc.wantIDs)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"fmt"
+	"net/url"
 
 	_ "modernc.org/sqlite"
 )
@@ -34,3 +35,42 @@ func Open(dbPath string) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// OpenReadOnly opens the existing SQLite database at the given path without
+// write access. The connection is opened with mode=ro and PRAGMA query_only,
+// so any statement that would modify the database fails. Unlike Open it does
+// not switch the journal to WAL, which needs write access, and it does not
+// create the file if it is missing.
+func OpenReadOnly(dbPath string) (*sql.DB, error) {
+	dsn := (&url.URL{Scheme: "file", OmitHost: true, Path: dbPath, RawQuery: "mode=ro"}).String()
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+
+	db.SetMaxOpenConns(1)
+
+	pragmas := []string{
+		"PRAGMA query_only=ON",
+		"PRAGMA foreign_keys=ON",
+		"PRAGMA busy_timeout=5000",
+	}
+
+	for _, p := range pragmas {
+		if _, err := db.Exec(p); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("setting pragma %q: %w", p, err)
+		}
+	}
+
+	return db, nil
+}
+
+// IsReadOnly reports whether db was opened with OpenReadOnly.
+func IsReadOnly(db *sql.DB) (bool, error) {
+	var queryOnly bool
+	if err := db.QueryRow("PRAGMA query_only").Scan(&queryOnly); err != nil {
+		return false, fmt.Errorf("reading query_only: %w", err)
+	}
+	return queryOnly, nil
+}
@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"testing"
 	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
 )
 
 func mustOpen(t *testing.T) *sql.DB {
@@ -232,8 +234,8 @@ func TestMigrateV3ToV4_CleanDB(t *testing.T) {
 	if err != nil {
 		t.Fatalf("SchemaVersion failed: %v", err)
 	}
-	if v != 4 {
-		t.Errorf("schema_version = %d, want 4", v)
+	if v != currentSchemaVersion {
+		t.Errorf("schema_version = %d, want %d", v, currentSchemaVersion)
 	}
 
 	for _, tbl := range docV4Tables {
@@ -285,8 +287,8 @@ func TestMigrateV3ToV4_FromExistingV3DB(t *testing.T) {
 	if err != nil {
 		t.Fatalf("SchemaVersion failed: %v", err)
 	}
-	if v != 4 {
-		t.Errorf("schema_version = %d after v3→v4 Migrate, want 4", v)
+	if v != currentSchemaVersion {
+		t.Errorf("schema_version = %d after v3→v4 Migrate, want %d", v, currentSchemaVersion)
 	}
 	for _, tbl := range docV4Tables {
 		assertTableExists(t, db, tbl)
@@ -318,8 +320,8 @@ func TestMigrateV3ToV4_Idempotent(t *testing.T) {
 	if err != nil {
 		t.Fatalf("SchemaVersion failed: %v", err)
 	}
-	if v != 4 {
-		t.Errorf("schema_version = %d after two Migrates, want 4", v)
+	if v != currentSchemaVersion {
+		t.Errorf("schema_version = %d after two Migrates, want %d", v, currentSchemaVersion)
 	}
 	for _, tbl := range docV4Tables {
 		assertTableExists(t, db, tbl)
@@ -374,8 +376,395 @@ func TestMigrateV3ToV4_BuggyStampDefensive(t *testing.T) {
 	if err != nil {
 		t.Fatalf("SchemaVersion failed: %v", err)
 	}
-	if v != 4 {
-		t.Errorf("schema_version = %d after defensive Migrate, want 4", v)
+	if v != currentSchemaVersion {
+		t.Errorf("schema_version = %d after defensive Migrate, want %d", v, currentSchemaVersion)
+	}
+}
+
+func TestMigrateV4ToV5_FromExistingV4DB(t *testing.T) {
+	db := mustOpen(t)
+
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	for _, fn := range []func(*sql.Tx) error{migrateV1ToV2, migrateV2ToV3, migrateV3ToV4} {
+		if err := fn(tx); err != nil {
+			t.Fatalf("migration failed: %v", err)
+		}
+	}
+	// Simulate a database created before issue_references joined schemaDDL.
+	if _, err := tx.Exec(`DROP TABLE issue_references`); err != nil {
+		t.Fatalf("dropping issue_references failed: %v", err)
+	}
+	if _, err := tx.Exec(`UPDATE meta SET value = '4' WHERE key = 'schema_version'`); err != nil {
+		t.Fatalf("stamping v4 failed: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("v4→v5 Migrate failed: %v", err)
+	}
+
+	v, err := SchemaVersion(db)
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if v != currentSchemaVersion {
+		t.Errorf("schema_version = %d after v4→v5 Migrate, want %d", v, currentSchemaVersion)
+	}
+	assertTableExists(t, db, "issue_references")
+	assertTableExists(t, db, "issue_templates")
+}
+
+func TestMigrateV6ToV7_AddsMilestoneColumn(t *testing.T) {
+	db := mustOpen(t)
+
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	// Simulate a v6 database created before milestones existed.
+	for _, stmt := range []string{
+		`DROP INDEX idx_issues_milestone_id`,
+		`ALTER TABLE issues DROP COLUMN milestone_id`,
+		`DROP TABLE milestones`,
+		`UPDATE meta SET value = '6' WHERE key = 'schema_version'`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("v6→v7 Migrate failed: %v", err)
+	}
+
+	v, err := SchemaVersion(db)
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if v != currentSchemaVersion {
+		t.Errorf("schema_version = %d after v6→v7 Migrate, want %d", v, currentSchemaVersion)
+	}
+	assertTableExists(t, db, "milestones")
+
+	var hasColumn bool
+	if err := db.QueryRow(
+		`SELECT EXISTS(SELECT 1 FROM pragma_table_info('issues') WHERE name = 'milestone_id')`,
+	).Scan(&hasColumn); err != nil {
+		t.Fatalf("checking milestone_id column: %v", err)
+	}
+	if !hasColumn {
+		t.Error("issues.milestone_id missing after v6→v7 Migrate")
+	}
+}
+
+func TestMigrateV7ToV8_AddsIssueLinks(t *testing.T) {
+	db := mustOpen(t)
+
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	// Simulate a v7 database created before issue links existed.
+	for _, stmt := range []string{
+		`DROP TABLE issue_links`,
+		`UPDATE meta SET value = '7' WHERE key = 'schema_version'`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("v7→v8 Migrate failed: %v", err)
+	}
+
+	v, err := SchemaVersion(db)
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if v != currentSchemaVersion {
+		t.Errorf("schema_version = %d after v7→v8 Migrate, want %d", v, currentSchemaVersion)
+	}
+	assertTableExists(t, db, "issue_links")
+}
+
+func TestMigrateV8ToV9_AddsDeletedAt(t *testing.T) {
+	db := mustOpen(t)
+
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	// Simulate a v8 database created before the trash existed, and so
+	// before the sub-issue stats triggers that read deleted_at.
+	for _, stmt := range []string{
+		`DROP TRIGGER trg_sub_issue_stats_insert`,
+		`DROP TRIGGER trg_sub_issue_stats_update`,
+		`DROP TRIGGER trg_sub_issue_stats_delete`,
+		`DROP TABLE sub_issue_stats`,
+		`DROP INDEX idx_issues_deleted_at`,
+		`ALTER TABLE issues DROP COLUMN deleted_at`,
+		`UPDATE meta SET value = '8' WHERE key = 'schema_version'`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("v8→v9 Migrate failed: %v", err)
+	}
+
+	v, err := SchemaVersion(db)
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if v != currentSchemaVersion {
+		t.Errorf("schema_version = %d after v8→v9 Migrate, want %d", v, currentSchemaVersion)
+	}
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('issues') WHERE name = 'deleted_at'`).Scan(&n); err != nil {
+		t.Fatalf("checking deleted_at column: %v", err)
+	}
+	if n != 1 {
+		t.Error("issues.deleted_at column missing after migration")
+	}
+}
+
+func TestMigrateV12ToV13_AddsRelationNote(t *testing.T) {
+	db := mustOpen(t)
+
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	// Simulate a v12 database with a relation created before notes existed.
+	for _, stmt := range []string{
+		`ALTER TABLE issue_relations DROP COLUMN note`,
+		`INSERT INTO issues (title, status, priority, kind, created_at, updated_at) VALUES ('a', 'backlog', 'none', 'task', '` + now + `', '` + now + `')`,
+		`INSERT INTO issues (title, status, priority, kind, created_at, updated_at) VALUES ('b', 'backlog', 'none', 'task', '` + now + `', '` + now + `')`,
+		`INSERT INTO issue_relations (source_issue_id, target_issue_id, relation_type, created_at) VALUES (1, 2, 'blocks', '` + now + `')`,
+		`UPDATE meta SET value = '12' WHERE key = 'schema_version'`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("v12→v13 Migrate failed: %v", err)
+	}
+
+	v, err := SchemaVersion(db)
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if v != currentSchemaVersion {
+		t.Errorf("schema_version = %d after v12→v13 Migrate, want %d", v, currentSchemaVersion)
+	}
+
+	rels, err := GetIssueRelations(db, 1)
+	if err != nil {
+		t.Fatalf("GetIssueRelations: %v", err)
+	}
+	if len(rels) != 1 || rels[0].Note != "" {
+		t.Errorf("relations after migration = %+v, want one with an empty note", rels)
+	}
+}
+
+func TestMigrateV14ToV15_AddsSnoozedUntil(t *testing.T) {
+	db := mustOpen(t)
+
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	// Simulate a v14 database with an issue created before snoozing existed.
+	for _, stmt := range []string{
+		`DROP INDEX idx_issues_snoozed_until`,
+		`ALTER TABLE issues DROP COLUMN snoozed_until`,
+		`INSERT INTO issues (title, status, priority, kind, created_at, updated_at) VALUES ('a', 'backlog', 'none', 'task', '` + now + `', '` + now + `')`,
+		`UPDATE meta SET value = '14' WHERE key = 'schema_version'`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("v14→v15 Migrate failed: %v", err)
+	}
+
+	v, err := SchemaVersion(db)
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if v != currentSchemaVersion {
+		t.Errorf("schema_version = %d after v14→v15 Migrate, want %d", v, currentSchemaVersion)
+	}
+
+	issue, err := GetIssue(db, 1)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if !issue.SnoozedUntil.IsZero() {
+		t.Errorf("SnoozedUntil = %v after migration, want zero", issue.SnoozedUntil)
+	}
+}
+
+func TestMigrateV15ToV16_FillsSubIssueStats(t *testing.T) {
+	db := mustOpen(t)
+
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	// Simulate a v15 database with a parent and sub-issue but no stats.
+	for _, stmt := range []string{
+		`DROP TRIGGER trg_sub_issue_stats_insert`,
+		`DROP TRIGGER trg_sub_issue_stats_update`,
+		`DROP TRIGGER trg_sub_issue_stats_delete`,
+		`DROP TABLE sub_issue_stats`,
+		`DELETE FROM meta WHERE key = 'sub_issue_stats'`,
+		`INSERT INTO issues (title, status, priority, kind, created_at, updated_at) VALUES ('parent', 'todo', 'none', 'task', '` + now + `', '` + now + `')`,
+		`INSERT INTO issues (parent_id, title, status, priority, kind, created_at, updated_at) VALUES (1, 'child', 'done', 'none', 'task', '` + now + `', '` + now + `')`,
+		`UPDATE meta SET value = '15' WHERE key = 'schema_version'`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("v15→v16 Migrate failed: %v", err)
+	}
+
+	var done, total int
+	if err := db.QueryRow(`SELECT done, total FROM sub_issue_stats WHERE parent_id = 1`).Scan(&done, &total); err != nil {
+		t.Fatalf("reading sub_issue_stats: %v", err)
+	}
+	if done != 1 || total != 1 {
+		t.Errorf("stats after migration = %d/%d, want 1/1", done, total)
+	}
+}
+
+func TestMigrateV16ToV17_AddsSortOrder(t *testing.T) {
+	db := mustOpen(t)
+
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	// Simulate a v16 database with an issue created before manual ordering.
+	for _, stmt := range []string{
+		`ALTER TABLE issues DROP COLUMN sort_order`,
+		`INSERT INTO issues (title, status, priority, kind, created_at, updated_at) VALUES ('a', 'backlog', 'none', 'task', '` + now + `', '` + now + `')`,
+		`UPDATE meta SET value = '16' WHERE key = 'schema_version'`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("v16→v17 Migrate failed: %v", err)
+	}
+
+	v, err := SchemaVersion(db)
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if v != currentSchemaVersion {
+		t.Errorf("schema_version = %d after v16→v17 Migrate, want %d", v, currentSchemaVersion)
+	}
+
+	issue, err := GetIssue(db, 1)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if issue.SortOrder != nil {
+		t.Errorf("SortOrder = %d after migration, want nil", *issue.SortOrder)
+	}
+}
+
+func TestMigrateV17ToV18_AddsCommentAuthorIndex(t *testing.T) {
+	db := mustOpen(t)
+
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	// Simulate a v17 database without the index.
+	for _, stmt := range []string{
+		`DROP INDEX idx_comments_author_created_at`,
+		`UPDATE meta SET value = '17' WHERE key = 'schema_version'`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("v17→v18 Migrate failed: %v", err)
+	}
+
+	v, err := SchemaVersion(db)
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if v != currentSchemaVersion {
+		t.Errorf("schema_version = %d after v17→v18 Migrate, want %d", v, currentSchemaVersion)
+	}
+
+	var count int
+	if err := db.QueryRow(
+		`SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = 'idx_comments_author_created_at'`,
+	).Scan(&count); err != nil {
+		t.Fatalf("querying index: %v", err)
+	}
+	if count != 1 {
+		t.Error("idx_comments_author_created_at missing after migration")
 	}
 }
 
@@ -470,3 +859,259 @@ func TestParentIDSetNullOnDelete(t *testing.T) {
 		t.Errorf("expected NULL parent_id after parent delete, got %d", parentIDVal.Int64)
 	}
 }
+
+func TestMigrateV9ToV10_NormalizesEnums(t *testing.T) {
+	db := mustOpen(t)
+
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	fixable := mustCreateIssue(t, db, "fixable")
+	unknown := mustCreateIssue(t, db, "unknown")
+
+	// Simulate a v9 database holding values written before UpdateIssue
+	// validated them.
+	for _, stmt := range []struct {
+		query string
+		args  []any
+	}{
+		{`UPDATE issues SET status = 'In_Progress', priority = 'HIGH', kind = ' bug' WHERE id = ?`, []any{fixable}},
+		{`UPDATE issues SET status = 'blocked' WHERE id = ?`, []any{unknown}},
+		{`UPDATE meta SET value = '9' WHERE key = 'schema_version'`, nil},
+	} {
+		if _, err := db.Exec(stmt.query, stmt.args...); err != nil {
+			t.Fatalf("%s: %v", stmt.query, err)
+		}
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("v9→v10 Migrate failed: %v", err)
+	}
+
+	v, err := SchemaVersion(db)
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if v != currentSchemaVersion {
+		t.Errorf("schema_version = %d after v9→v10 Migrate, want %d", v, currentSchemaVersion)
+	}
+
+	issue, err := GetIssue(db, fixable)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if issue.Status != model.StatusInProgress || issue.Priority != model.PriorityHigh || issue.Kind != model.IssueKindBug {
+		t.Errorf("normalized issue = %s/%s/%s, want in-progress/high/bug", issue.Status, issue.Priority, issue.Kind)
+	}
+
+	invalid, err := FindInvalidEnumValues(db)
+	if err != nil {
+		t.Fatalf("FindInvalidEnumValues: %v", err)
+	}
+	if len(invalid) != 1 || invalid[0].IssueID != unknown || invalid[0].Value != "blocked" {
+		t.Errorf("remaining invalid values = %+v, want only issue %d status \"blocked\"", invalid, unknown)
+	}
+}
+
+func TestMigrateV10ToV11_BackfillsTimestamps(t *testing.T) {
+	db := mustOpen(t)
+
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	shipped := mustCreateIssue(t, db, "shipped")
+	closed := mustCreateIssue(t, db, "closed without history")
+	started := mustCreateIssue(t, db, "started")
+
+	// Simulate a v10 database whose history lives only in the activity log.
+	for _, stmt := range []struct {
+		query string
+		args  []any
+	}{
+		{`DROP INDEX idx_issues_completed_at`, nil},
+		{`ALTER TABLE issues DROP COLUMN started_at`, nil},
+		{`ALTER TABLE issues DROP COLUMN completed_at`, nil},
+		{`UPDATE issues SET status = 'done', updated_at = '2024-03-09T00:00:00Z' WHERE id IN (?, ?)`, []any{shipped, closed}},
+		{`UPDATE issues SET status = 'in-progress' WHERE id = ?`, []any{started}},
+		{`INSERT INTO activity_log (issue_id, field_changed, old_value, new_value, created_at) VALUES
+			(?, 'status', 'todo', 'in-progress', '2024-03-01T00:00:00Z'),
+			(?, 'status', 'in-progress', 'todo', '2024-03-02T00:00:00Z'),
+			(?, 'status', 'todo', 'in-progress', '2024-03-03T00:00:00Z'),
+			(?, 'status', 'in-progress', 'done', '2024-03-07T00:00:00Z'),
+			(?, 'status', 'todo', 'in-progress', '2024-03-05T00:00:00Z')`,
+			[]any{shipped, shipped, shipped, shipped, started}},
+		{`UPDATE meta SET value = '10' WHERE key = 'schema_version'`, nil},
+	} {
+		if _, err := db.Exec(stmt.query, stmt.args...); err != nil {
+			t.Fatalf("%s: %v", stmt.query, err)
+		}
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("v10→v11 Migrate failed: %v", err)
+	}
+
+	v, err := SchemaVersion(db)
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if v != currentSchemaVersion {
+		t.Errorf("schema_version = %d after v10→v11 Migrate, want %d", v, currentSchemaVersion)
+	}
+
+	date := func(day int) time.Time { return time.Date(2024, 3, day, 0, 0, 0, 0, time.UTC) }
+	for _, tt := range []struct {
+		id                 int
+		started, completed time.Time
+	}{
+		{shipped, date(1), date(7)},
+		{closed, time.Time{}, date(9)},
+		{started, date(5), time.Time{}},
+	} {
+		issue, err := GetIssue(db, tt.id)
+		if err != nil {
+			t.Fatalf("GetIssue: %v", err)
+		}
+		if !issue.StartedAt.Equal(tt.started) || !issue.CompletedAt.Equal(tt.completed) {
+			t.Errorf("issue %d: started %v, completed %v; want %v, %v",
+				tt.id, issue.StartedAt, issue.CompletedAt, tt.started, tt.completed)
+		}
+	}
+}
+
+func TestMigrateV18ToV19_AddsPinned(t *testing.T) {
+	db := mustOpen(t)
+
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	// Simulate a v18 database with an issue created before pinning.
+	for _, stmt := range []string{
+		`ALTER TABLE issues DROP COLUMN pinned`,
+		`INSERT INTO issues (title, status, priority, kind, created_at, updated_at) VALUES ('a', 'backlog', 'none', 'task', '` + now + `', '` + now + `')`,
+		`UPDATE meta SET value = '18' WHERE key = 'schema_version'`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("v18→v19 Migrate failed: %v", err)
+	}
+
+	v, err := SchemaVersion(db)
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if v != currentSchemaVersion {
+		t.Errorf("schema_version = %d after v18→v19 Migrate, want %d", v, currentSchemaVersion)
+	}
+
+	issue, err := GetIssue(db, 1)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if issue.Pinned {
+		t.Error("Pinned = true after migration, want false")
+	}
+}
+
+func TestMigrateV19ToV20_CreatesIssueWatchers(t *testing.T) {
+	db := mustOpen(t)
+
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	// Simulate a v19 database from before watchers.
+	for _, stmt := range []string{
+		`DROP TABLE issue_watchers`,
+		`UPDATE meta SET value = '19' WHERE key = 'schema_version'`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("v19→v20 Migrate failed: %v", err)
+	}
+
+	v, err := SchemaVersion(db)
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if v != currentSchemaVersion {
+		t.Errorf("schema_version = %d after v19→v20 Migrate, want %d", v, currentSchemaVersion)
+	}
+
+	var name string
+	if err := db.QueryRow(
+		"SELECT name FROM sqlite_master WHERE type='table' AND name='issue_watchers'",
+	).Scan(&name); err != nil {
+		t.Errorf("issue_watchers missing after migration: %v", err)
+	}
+}
+
+func TestMigrateV20ToV21_BackfillsLabelLastUsed(t *testing.T) {
+	db := mustOpen(t)
+
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	// Simulate a v20 database: "bug" was detached in the activity log after
+	// the issue carrying "ui" was created, and "idle" was never used.
+	for _, stmt := range []string{
+		`ALTER TABLE labels DROP COLUMN last_used_at`,
+		`INSERT INTO issues (title, status, priority, kind, created_at, updated_at) VALUES ('a', 'backlog', 'none', 'task', '2026-01-01T00:00:00Z', '2026-01-01T00:00:00Z')`,
+		`INSERT INTO labels (name) VALUES ('bug'), ('ui'), ('idle')`,
+		`INSERT INTO issue_labels (issue_id, label_id) SELECT 1, id FROM labels WHERE name = 'ui'`,
+		`INSERT INTO activity_log (issue_id, field_changed, old_value, new_value, changed_by, created_at) VALUES (1, 'label_removed', 'bug', '', 'tester', '2026-02-01T00:00:00Z')`,
+		`UPDATE meta SET value = '20' WHERE key = 'schema_version'`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("v20→v21 Migrate failed: %v", err)
+	}
+
+	labels, err := ListAllLabels(db)
+	if err != nil {
+		t.Fatalf("ListAllLabels: %v", err)
+	}
+	want := map[string]string{"bug": "2026-02-01", "ui": "2026-01-01", "idle": ""}
+	for _, l := range labels {
+		var got string
+		if l.LastUsedAt != nil {
+			got = l.LastUsedAt.Format("2006-01-02")
+		}
+		if got != want[l.Name] {
+			t.Errorf("%s last used %q, want %q", l.Name, got, want[l.Name])
+		}
+	}
+}
@@ -13,6 +13,10 @@ import (
 // existence check and insert run in a single transaction. Returns
 // ErrNotFound if the doc does not exist.
 func CreateDocComment(db *sql.DB, c *model.DocComment) (int, error) {
+	return withRetryValue(func() (int, error) { return createDocComment(db, c) })
+}
+
+func createDocComment(db *sql.DB, c *model.DocComment) (int, error) {
 	tx, err := db.Begin()
 	if err != nil {
 		return 0, fmt.Errorf("beginning transaction: %w", err)
@@ -125,27 +129,43 @@ func InsertDocCommentWithID(tx *sql.Tx, c *model.DocComment) (bool, error) {
 // ListAllDocComments returns every doc_comments row ordered by id ASC, for a
 // full export.
 func ListAllDocComments(db *sql.DB) ([]*model.DocComment, error) {
+	var comments []*model.DocComment
+	err := StreamDocComments(db, func(v *model.DocComment) error {
+		comments = append(comments, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// StreamDocComments calls fn for every doc comment row in the same order as
+// ListAllDocComments without buffering the result set. fn runs while the cursor
+// is open and must not query db.
+func StreamDocComments(db *sql.DB, fn func(*model.DocComment) error) error {
 	rows, err := db.Query(
 		`SELECT id, doc_id, body, author, created_at
 		 FROM doc_comments ORDER BY id ASC`,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("querying all doc comments: %w", err)
+		return fmt.Errorf("querying all doc comments: %w", err)
 	}
 	defer rows.Close()
 
-	var comments []*model.DocComment
 	for rows.Next() {
 		c, err := scanDocCommentFrom(rows)
 		if err != nil {
-			return nil, fmt.Errorf("scanning doc comment row: %w", err)
+			return fmt.Errorf("scanning doc comment row: %w", err)
+		}
+		if err := fn(c); err != nil {
+			return err
 		}
-		comments = append(comments, c)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating doc comment rows: %w", err)
+		return fmt.Errorf("iterating doc comment rows: %w", err)
 	}
-	return comments, nil
+	return nil
 }
 
 // scanDocCommentFrom scans a single doc_comments row from any scanner. Author
@@ -12,6 +12,10 @@ import (
 // LinkDocIssue links a doc to an issue. Returns ErrNotFound if either side is
 // missing; ErrConflict if the link already exists.
 func LinkDocIssue(db *sql.DB, docID, issueID int) error {
+	return WithRetry(func() error { return linkDocIssue(db, docID, issueID) })
+}
+
+func linkDocIssue(db *sql.DB, docID, issueID int) error {
 	if err := assertDocExists(db, docID); err != nil {
 		return err
 	}
@@ -35,6 +39,10 @@ func LinkDocIssue(db *sql.DB, docID, issueID int) error {
 // UnlinkDocIssue removes a doc↔issue link. Returns ErrNotFound if no such
 // link exists.
 func UnlinkDocIssue(db *sql.DB, docID, issueID int) error {
+	return WithRetry(func() error { return unlinkDocIssue(db, docID, issueID) })
+}
+
+func unlinkDocIssue(db *sql.DB, docID, issueID int) error {
 	res, err := db.Exec(
 		`DELETE FROM doc_issue_links WHERE doc_id = ? AND issue_id = ?`,
 		docID, issueID,
@@ -55,7 +63,7 @@ func UnlinkDocIssue(db *sql.DB, docID, issueID int) error {
 // GetDocIssues returns issue IDs linked to a doc, ordered by issue_id ASC.
 func GetDocIssues(db *sql.DB, docID int) ([]int, error) {
 	return queryLinkIDs(db,
-		`SELECT issue_id FROM doc_issue_links WHERE doc_id = ? ORDER BY issue_id ASC`,
+		`SELECT issue_id FROM doc_issue_links WHERE doc_id = ? AND issue_id IN `+liveIssueIDs+` ORDER BY issue_id ASC`,
 		docID,
 	)
 }
@@ -123,7 +131,7 @@ func HydrateLinkedIssues(db *sql.DB, docIDs []int) (map[int][]model.IssueRef, er
 	query := fmt.Sprintf(
 		`SELECT l.doc_id, i.id, i.kind, i.status, i.title
 		 FROM doc_issue_links l
-		 JOIN issues i ON i.id = l.issue_id
+		 JOIN issues i ON i.id = l.issue_id AND i.deleted_at IS NULL
 		 WHERE l.doc_id IN (%s)
 		 ORDER BY i.id ASC`, placeholders,
 	)
@@ -151,6 +159,10 @@ func HydrateLinkedIssues(db *sql.DB, docIDs []int) (map[int][]model.IssueRef, er
 // LinkProposalDoc links a proposal (vote) to a doc. Returns ErrNotFound if
 // either side is missing; ErrConflict if the link already exists.
 func LinkProposalDoc(db *sql.DB, proposalID, docID int) error {
+	return WithRetry(func() error { return linkProposalDoc(db, proposalID, docID) })
+}
+
+func linkProposalDoc(db *sql.DB, proposalID, docID int) error {
 	if err := assertProposalExists(db, proposalID); err != nil {
 		return err
 	}
@@ -174,6 +186,10 @@ func LinkProposalDoc(db *sql.DB, proposalID, docID int) error {
 // UnlinkProposalDoc removes a proposal↔doc link. Returns ErrNotFound if no
 // such link exists.
 func UnlinkProposalDoc(db *sql.DB, proposalID, docID int) error {
+	return WithRetry(func() error { return unlinkProposalDoc(db, proposalID, docID) })
+}
+
+func unlinkProposalDoc(db *sql.DB, proposalID, docID int) error {
 	res, err := db.Exec(
 		`DELETE FROM proposal_docs WHERE proposal_id = ? AND doc_id = ?`,
 		proposalID, docID,
@@ -240,53 +256,85 @@ func InsertProposalDocLink(tx *sql.Tx, proposalID, docID int, createdAt string)
 // ListAllDocIssueLinks returns every doc_issue_links row ordered by (doc_id,
 // issue_id), for a full export.
 func ListAllDocIssueLinks(db *sql.DB) ([]model.DocIssueLink, error) {
+	out := make([]model.DocIssueLink, 0)
+	err := StreamDocIssueLinks(db, func(v model.DocIssueLink) error {
+		out = append(out, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StreamDocIssueLinks calls fn for every doc-issue link row in the same order
+// as ListAllDocIssueLinks without buffering the result set. fn runs while the
+// cursor is open and must not query db.
+func StreamDocIssueLinks(db *sql.DB, fn func(model.DocIssueLink) error) error {
 	rows, err := db.Query(
 		`SELECT doc_id, issue_id, created_at
-		 FROM doc_issue_links ORDER BY doc_id ASC, issue_id ASC`,
+		 FROM doc_issue_links WHERE issue_id IN ` + liveIssueIDs + ` ORDER BY doc_id ASC, issue_id ASC`,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("querying all doc_issue_links: %w", err)
+		return fmt.Errorf("querying all doc_issue_links: %w", err)
 	}
 	defer rows.Close()
 
-	out := make([]model.DocIssueLink, 0)
 	for rows.Next() {
 		var l model.DocIssueLink
 		if err := rows.Scan(&l.DocID, &l.IssueID, &l.CreatedAt); err != nil {
-			return nil, fmt.Errorf("scanning doc_issue_link row: %w", err)
+			return fmt.Errorf("scanning doc_issue_link row: %w", err)
+		}
+		if err := fn(l); err != nil {
+			return err
 		}
-		out = append(out, l)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating doc_issue_link rows: %w", err)
+		return fmt.Errorf("iterating doc_issue_link rows: %w", err)
 	}
-	return out, nil
+	return nil
 }
 
 // ListAllProposalDocs returns every proposal_docs row ordered by (proposal_id,
 // doc_id), for a full export.
 func ListAllProposalDocs(db *sql.DB) ([]model.ProposalDocLink, error) {
+	out := make([]model.ProposalDocLink, 0)
+	err := StreamProposalDocs(db, func(v model.ProposalDocLink) error {
+		out = append(out, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StreamProposalDocs calls fn for every proposal-doc link row in the same order
+// as ListAllProposalDocs without buffering the result set. fn runs while the
+// cursor is open and must not query db.
+func StreamProposalDocs(db *sql.DB, fn func(model.ProposalDocLink) error) error {
 	rows, err := db.Query(
 		`SELECT proposal_id, doc_id, created_at
 		 FROM proposal_docs ORDER BY proposal_id ASC, doc_id ASC`,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("querying all proposal_docs: %w", err)
+		return fmt.Errorf("querying all proposal_docs: %w", err)
 	}
 	defer rows.Close()
 
-	out := make([]model.ProposalDocLink, 0)
 	for rows.Next() {
 		var l model.ProposalDocLink
 		if err := rows.Scan(&l.ProposalID, &l.DocID, &l.CreatedAt); err != nil {
-			return nil, fmt.Errorf("scanning proposal_doc row: %w", err)
+			return fmt.Errorf("scanning proposal_doc row: %w", err)
+		}
+		if err := fn(l); err != nil {
+			return err
 		}
-		out = append(out, l)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating proposal_doc rows: %w", err)
+		return fmt.Errorf("iterating proposal_doc rows: %w", err)
 	}
-	return out, nil
+	return nil
 }
 
 // --- helpers ---
@@ -304,7 +352,7 @@ func assertDocExists(db *sql.DB, id int) error {
 
 func assertIssueExists(db *sql.DB, id int) error {
 	var exists bool
-	if err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)", id).Scan(&exists); err != nil {
+	if err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM issues WHERE id = ? AND deleted_at IS NULL)", id).Scan(&exists); err != nil {
 		return fmt.Errorf("checking issue existence: %w", err)
 	}
 	if !exists {
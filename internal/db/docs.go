@@ -71,6 +71,10 @@ type DocSummary struct {
 // Type, Status, Title, Body, and Author set; CreatedAt/UpdatedAt are
 // stamped by this function.
 func CreateDoc(db *sql.DB, doc *model.Doc) (int, error) {
+	return withRetryValue(func() (int, error) { return createDoc(db, doc) })
+}
+
+func createDoc(db *sql.DB, doc *model.Doc) (int, error) {
 	tx, err := db.Begin()
 	if err != nil {
 		return 0, fmt.Errorf("beginning transaction: %w", err)
@@ -294,6 +298,10 @@ type DocUpdate struct {
 //
 // Returns ErrNotFound if id does not exist.
 func UpdateDoc(db *sql.DB, id int, upd DocUpdate) (int, error) {
+	return withRetryValue(func() (int, error) { return updateDoc(db, id, upd) })
+}
+
+func updateDoc(db *sql.DB, id int, upd DocUpdate) (int, error) {
 	tx, err := db.Begin()
 	if err != nil {
 		return 0, fmt.Errorf("beginning transaction: %w", err)
@@ -454,6 +462,10 @@ func ListDocRevisions(db *sql.DB, docID int) ([]*model.DocRevision, error) {
 // own history and never block deletion.
 // Returns ErrNotFound if no doc with that ID exists.
 func DeleteDoc(db *sql.DB, id int, cascade bool) error {
+	return WithRetry(func() error { return deleteDoc(db, id, cascade) })
+}
+
+func deleteDoc(db *sql.DB, id int, cascade bool) error {
 	if !cascade {
 		var existing int
 		err := db.QueryRow(
@@ -522,53 +534,85 @@ func InsertDocRevisionWithID(tx *sql.Tx, r *model.DocRevision) (bool, error) {
 
 // ListAllDocs returns every doc row ordered by id ASC, for a full export.
 func ListAllDocs(db *sql.DB) ([]*model.Doc, error) {
+	var docs []*model.Doc
+	err := StreamDocs(db, func(v *model.Doc) error {
+		docs = append(docs, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// StreamDocs calls fn for every doc row in the same order as ListAllDocs
+// without buffering the result set. fn runs while the cursor is open and must
+// not query db.
+func StreamDocs(db *sql.DB, fn func(*model.Doc) error) error {
 	rows, err := db.Query(
 		`SELECT id, type, status, title, body, author, created_at, updated_at
 		 FROM docs ORDER BY id ASC`,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("querying all docs: %w", err)
+		return fmt.Errorf("querying all docs: %w", err)
 	}
 	defer rows.Close()
 
-	var docs []*model.Doc
 	for rows.Next() {
 		d, err := scanDocFrom(rows)
 		if err != nil {
-			return nil, fmt.Errorf("scanning doc row: %w", err)
+			return fmt.Errorf("scanning doc row: %w", err)
+		}
+		if err := fn(d); err != nil {
+			return err
 		}
-		docs = append(docs, d)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating doc rows: %w", err)
+		return fmt.Errorf("iterating doc rows: %w", err)
 	}
-	return docs, nil
+	return nil
 }
 
 // ListAllDocRevisions returns every doc_revisions row ordered by id ASC, for a
 // full export.
 func ListAllDocRevisions(db *sql.DB) ([]*model.DocRevision, error) {
+	var revs []*model.DocRevision
+	err := StreamDocRevisions(db, func(v *model.DocRevision) error {
+		revs = append(revs, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revs, nil
+}
+
+// StreamDocRevisions calls fn for every doc revision row in the same order as
+// ListAllDocRevisions without buffering the result set. fn runs while the
+// cursor is open and must not query db.
+func StreamDocRevisions(db *sql.DB, fn func(*model.DocRevision) error) error {
 	rows, err := db.Query(
 		`SELECT id, doc_id, revision_number, body, change_kind, author, created_at
 		 FROM doc_revisions ORDER BY id ASC`,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("querying all doc revisions: %w", err)
+		return fmt.Errorf("querying all doc revisions: %w", err)
 	}
 	defer rows.Close()
 
-	var revs []*model.DocRevision
 	for rows.Next() {
 		r, err := scanDocRevisionFrom(rows)
 		if err != nil {
-			return nil, fmt.Errorf("scanning doc revision row: %w", err)
+			return fmt.Errorf("scanning doc revision row: %w", err)
+		}
+		if err := fn(r); err != nil {
+			return err
 		}
-		revs = append(revs, r)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating doc revision rows: %w", err)
+		return fmt.Errorf("iterating doc revision rows: %w", err)
 	}
-	return revs, nil
+	return nil
 }
 
 // --- private helpers ---
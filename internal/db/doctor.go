@@ -0,0 +1,189 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// InvalidEnumValue is an issue column holding a value outside its enum.
+type InvalidEnumValue struct {
+	IssueID int    `json:"issue_id"`
+	Field   string `json:"field"`
+	Value   string `json:"value"`
+	// Suggestion is the valid value the migration-time normalization maps
+	// Value to, or empty when there is none.
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// enumColumns lists the issue columns constrained to an enum, with the
+// validator for each.
+var enumColumns = []struct {
+	field    string
+	validate func(string) error
+}{
+	{"status", func(s string) error { return model.ValidateStatus(model.Status(s)) }},
+	{"priority", func(s string) error { return model.ValidatePriority(model.Priority(s)) }},
+	{"kind", func(s string) error { return model.ValidateIssueKind(model.IssueKind(s)) }},
+}
+
+// normalizeEnumValue maps common misspellings of an enum value, such as
+// "In_Progress" or "in progress", to the canonical lowercase, hyphenated form.
+func normalizeEnumValue(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.NewReplacer("_", "-", " ", "-").Replace(s)
+}
+
+// FindInvalidEnumValues returns every status, priority or kind value, on live
+// and trashed issues alike, that is not a recognized enum value, ordered by
+// issue ID and then field.
+func FindInvalidEnumValues(db *sql.DB) ([]InvalidEnumValue, error) {
+	return findInvalidEnumValues(db)
+}
+
+// queryer abstracts *sql.DB and *sql.Tx for multi-row queries.
+type queryer interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+func findInvalidEnumValues(q queryer) ([]InvalidEnumValue, error) {
+	rows, err := q.Query(`SELECT id, status, priority, kind FROM issues ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("querying issue enums: %w", err)
+	}
+	defer rows.Close()
+
+	var invalid []InvalidEnumValue
+	for rows.Next() {
+		var id int
+		values := make([]string, len(enumColumns))
+		if err := rows.Scan(&id, &values[0], &values[1], &values[2]); err != nil {
+			return nil, fmt.Errorf("scanning issue enums: %w", err)
+		}
+		for i, col := range enumColumns {
+			if col.validate(values[i]) == nil {
+				continue
+			}
+			bad := InvalidEnumValue{IssueID: id, Field: col.field, Value: values[i]}
+			if fixed := normalizeEnumValue(values[i]); col.validate(fixed) == nil {
+				bad.Suggestion = fixed
+			}
+			invalid = append(invalid, bad)
+		}
+	}
+	return invalid, rows.Err()
+}
+
+// InvalidTimestamp is a stored timestamp that is not RFC 3339, which makes
+// every read of its row fail.
+type InvalidTimestamp struct {
+	Table string `json:"table"`
+	RowID int    `json:"row_id"`
+	Field string `json:"field"`
+	Value string `json:"value"`
+	// Normalized is Value as RFC 3339 in UTC, as RepairTimestamps writes
+	// it, or empty when model.ParseTimestamp cannot read Value.
+	Normalized string `json:"normalized,omitempty"`
+}
+
+// timestampColumns lists the timestamp columns of each table that docket
+// parses as RFC 3339 when reading rows.
+var timestampColumns = []struct {
+	table  string
+	fields []string
+}{
+	{"issues", []string{"created_at", "updated_at", "started_at", "completed_at", "snoozed_until", "deleted_at"}},
+	{"comments", []string{"created_at"}},
+	{"issue_relations", []string{"created_at"}},
+}
+
+// FindInvalidTimestamps returns every issue, comment and relation timestamp,
+// on live and trashed issues alike, that is not RFC 3339, ordered by table,
+// row ID and field.
+func FindInvalidTimestamps(db *sql.DB) ([]InvalidTimestamp, error) {
+	return findInvalidTimestamps(db)
+}
+
+func findInvalidTimestamps(q queryer) ([]InvalidTimestamp, error) {
+	var invalid []InvalidTimestamp
+	for _, tc := range timestampColumns {
+		// Safe: table and field names are constants.
+		rows, err := q.Query(fmt.Sprintf(`SELECT id, %s FROM %s ORDER BY id`, strings.Join(tc.fields, ", "), tc.table))
+		if err != nil {
+			return nil, fmt.Errorf("querying %s timestamps: %w", tc.table, err)
+		}
+		for rows.Next() {
+			var id int
+			values := make([]sql.NullString, len(tc.fields))
+			dest := []any{&id}
+			for i := range values {
+				dest = append(dest, &values[i])
+			}
+			if err := rows.Scan(dest...); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("scanning %s timestamps: %w", tc.table, err)
+			}
+			for i, v := range values {
+				if !v.Valid {
+					continue
+				}
+				if _, err := time.Parse(time.RFC3339, v.String); err == nil {
+					continue
+				}
+				bad := InvalidTimestamp{Table: tc.table, RowID: id, Field: tc.fields[i], Value: v.String}
+				if t, err := model.ParseTimestamp(v.String); err == nil {
+					bad.Normalized = t.Format(time.RFC3339)
+				}
+				invalid = append(invalid, bad)
+			}
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("iterating %s timestamps: %w", tc.table, err)
+		}
+	}
+	return invalid, nil
+}
+
+// RepairTimestamps rewrites each timestamp FindInvalidTimestamps reports in
+// its normalized RFC 3339 form, and returns how many it rewrote. Timestamps
+// model.ParseTimestamp cannot read are left as they are.
+func RepairTimestamps(db *sql.DB) (int, error) {
+	return withRetryValue(func() (int, error) { return repairTimestamps(db) })
+}
+
+func repairTimestamps(db *sql.DB) (int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	invalid, err := findInvalidTimestamps(tx)
+	if err != nil {
+		return 0, err
+	}
+	repaired := 0
+	for _, bad := range invalid {
+		if bad.Normalized == "" {
+			continue
+		}
+		// Safe: table and field names come from timestampColumns.
+		if _, err := tx.Exec(
+			fmt.Sprintf(`UPDATE %s SET %s = ? WHERE id = ?`, bad.Table, bad.Field),
+			bad.Normalized, bad.RowID,
+		); err != nil {
+			return 0, fmt.Errorf("repairing %s.%s of row %d: %w", bad.Table, bad.Field, bad.RowID, err)
+		}
+		repaired++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing transaction: %w", err)
+	}
+	return repaired, nil
+}
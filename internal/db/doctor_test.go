@@ -0,0 +1,112 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestFindInvalidEnumValues(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	mustCreateIssue(t, db, "good")
+	bad := mustCreateIssue(t, db, "bad")
+	trashed := mustCreateIssue(t, db, "trashed")
+
+	// Write invalid values directly, as UpdateIssue now rejects them.
+	if _, err := db.Exec(`UPDATE issues SET status = 'in_progress', kind = 'story' WHERE id = ?`, bad); err != nil {
+		t.Fatalf("corrupting issue: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE issues SET priority = 'High ', deleted_at = '2026-01-01T00:00:00Z' WHERE id = ?`, trashed); err != nil {
+		t.Fatalf("corrupting issue: %v", err)
+	}
+
+	got, err := FindInvalidEnumValues(db)
+	if err != nil {
+		t.Fatalf("FindInvalidEnumValues: %v", err)
+	}
+	want := []InvalidEnumValue{
+		{IssueID: bad, Field: "status", Value: "in_progress", Suggestion: "in-progress"},
+		{IssueID: bad, Field: "kind", Value: "story"},
+		{IssueID: trashed, Field: "priority", Value: "High ", Suggestion: "high"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d invalid values %+v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("invalid[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRepairTimestamps(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, db, "a")
+	b := mustCreateIssue(t, db, "b")
+	mustCreateRelation(t, db, a, b, model.RelationBlocks)
+	if _, err := CreateComment(db, &model.Comment{IssueID: a, Body: "hi", Author: "amy"}); err != nil {
+		t.Fatalf("CreateComment: %v", err)
+	}
+
+	// Write timestamps as a hand edit of the database might leave them.
+	for _, stmt := range []string{
+		`UPDATE issues SET created_at = '2026-01-05 10:00:00', started_at = '2026-01-05' WHERE id = 1`,
+		`UPDATE issues SET updated_at = 1767607200, completed_at = 'someday' WHERE id = 2`,
+		`UPDATE comments SET created_at = '2026-01-05'`,
+		`UPDATE issue_relations SET created_at = '2026-01-05T10:00:00'`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	if _, err := GetIssue(db, a); err == nil {
+		t.Fatal("GetIssue read a corrupted row, want an error")
+	}
+
+	got, err := FindInvalidTimestamps(db)
+	if err != nil {
+		t.Fatalf("FindInvalidTimestamps: %v", err)
+	}
+	want := []InvalidTimestamp{
+		{Table: "issues", RowID: a, Field: "created_at", Value: "2026-01-05 10:00:00", Normalized: "2026-01-05T10:00:00Z"},
+		{Table: "issues", RowID: a, Field: "started_at", Value: "2026-01-05", Normalized: "2026-01-05T00:00:00Z"},
+		{Table: "issues", RowID: b, Field: "updated_at", Value: "1767607200", Normalized: "2026-01-05T10:00:00Z"},
+		{Table: "issues", RowID: b, Field: "completed_at", Value: "someday"},
+		{Table: "comments", RowID: 1, Field: "created_at", Value: "2026-01-05", Normalized: "2026-01-05T00:00:00Z"},
+		{Table: "issue_relations", RowID: 1, Field: "created_at", Value: "2026-01-05T10:00:00", Normalized: "2026-01-05T10:00:00Z"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d invalid timestamps %+v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("invalid[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	repaired, err := RepairTimestamps(db)
+	if err != nil {
+		t.Fatalf("RepairTimestamps: %v", err)
+	}
+	if repaired != 5 {
+		t.Errorf("repaired %d timestamps, want 5", repaired)
+	}
+	if _, err := GetIssue(db, a); err != nil {
+		t.Errorf("GetIssue after repair: %v", err)
+	}
+	got, err = FindInvalidTimestamps(db)
+	if err != nil {
+		t.Fatalf("FindInvalidTimestamps after repair: %v", err)
+	}
+	if len(got) != 1 || got[0].Value != "someday" {
+		t.Errorf("invalid timestamps after repair = %+v, want only the unreadable one", got)
+	}
+}
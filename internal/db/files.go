@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"fmt"
+	"path"
 	"slices"
 	"sort"
 	"strings"
@@ -15,6 +16,10 @@ import (
 // attachments are silently ignored (INSERT OR IGNORE). Activity is recorded
 // for each batch of newly attached files.
 func AttachFiles(db *sql.DB, issueID int, filePaths []string, changedBy string) error {
+	return WithRetry(func() error { return attachFiles(db, issueID, filePaths, changedBy) })
+}
+
+func attachFiles(db *sql.DB, issueID int, filePaths []string, changedBy string) error {
 	if len(filePaths) == 0 {
 		return nil
 	}
@@ -56,6 +61,10 @@ func AttachFiles(db *sql.DB, issueID int, filePaths []string, changedBy string)
 // DetachFiles deletes rows from issue_files matching the issue ID and file
 // paths. Activity is recorded for removed files.
 func DetachFiles(db *sql.DB, issueID int, filePaths []string, changedBy string) error {
+	return WithRetry(func() error { return detachFiles(db, issueID, filePaths, changedBy) })
+}
+
+func detachFiles(db *sql.DB, issueID int, filePaths []string, changedBy string) error {
 	if len(filePaths) == 0 {
 		return nil
 	}
@@ -119,6 +128,10 @@ func GetIssueFiles(db *sql.DB, issueID int) ([]string, error) {
 // SetIssueFiles replaces all files for an issue (delete existing, insert new).
 // Activity is recorded showing the change from old files to new files.
 func SetIssueFiles(db *sql.DB, issueID int, filePaths []string, changedBy string) error {
+	return WithRetry(func() error { return setIssueFiles(db, issueID, filePaths, changedBy) })
+}
+
+func setIssueFiles(db *sql.DB, issueID int, filePaths []string, changedBy string) error {
 	tx, err := db.Begin()
 	if err != nil {
 		return fmt.Errorf("beginning transaction: %w", err)
@@ -171,23 +184,46 @@ func HydrateFiles(db *sql.DB, issues []*model.Issue) error {
 		return nil
 	}
 
-	ids := make([]any, len(issues))
-	issueMap := make(map[int]*model.Issue, len(issues))
+	ids := make([]int, len(issues))
 	for i, issue := range issues {
 		ids[i] = issue.ID
-		issueMap[issue.ID] = issue
 	}
 
-	placeholders := makePlaceholders(len(ids))
+	files, err := GetFilesByIssueIDs(db, ids)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		if paths, ok := files[issue.ID]; ok {
+			issue.Files = paths
+		}
+	}
+	return nil
+}
+
+// GetFilesByIssueIDs returns the file paths attached to each of the given
+// issues in a single query, keyed by issue ID. Issues without files have no
+// entry in the map. Each slice is sorted by path.
+func GetFilesByIssueIDs(db *sql.DB, ids []int) (map[int][]string, error) {
+	files := make(map[int][]string)
+	if len(ids) == 0 {
+		return files, nil
+	}
+
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
 	query := fmt.Sprintf(
 		`SELECT issue_id, file_path FROM issue_files
 		 WHERE issue_id IN (%s)
-		 ORDER BY file_path`, placeholders,
+		 ORDER BY file_path`, makePlaceholders(len(args)),
 	)
 
-	rows, err := db.Query(query, ids...)
+	rows, err := db.Query(query, args...)
 	if err != nil {
-		return fmt.Errorf("querying files: %w", err)
+		return nil, fmt.Errorf("querying files: %w", err)
 	}
 	defer rows.Close()
 
@@ -195,39 +231,165 @@ func HydrateFiles(db *sql.DB, issues []*model.Issue) error {
 		var issueID int
 		var filePath string
 		if err := rows.Scan(&issueID, &filePath); err != nil {
-			return fmt.Errorf("scanning file: %w", err)
+			return nil, fmt.Errorf("scanning file: %w", err)
 		}
-		if issue, ok := issueMap[issueID]; ok {
-			issue.Files = append(issue.Files, filePath)
+		files[issueID] = append(files[issueID], filePath)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// FindIssuesByFilePrefix returns the open issues attached to files whose path
+// starts with prefix, such as a directory like "internal/db/", keyed by file
+// path. Files without open issues are omitted.
+func FindIssuesByFilePrefix(db *sql.DB, prefix string) (map[string][]*model.Issue, error) {
+	return findIssuesByFile(db, globEscaper.Replace(prefix)+"*", nil)
+}
+
+// FindIssuesByFileGlob returns the open issues attached to files matching
+// pattern, keyed by file path. Patterns use path.Match syntax, so "*" does
+// not cross directory separators. Files without open issues are omitted.
+func FindIssuesByFileGlob(db *sql.DB, pattern string) (map[string][]*model.Issue, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("%w: invalid file pattern %q", ErrValidation, pattern)
+	}
+	return findIssuesByFile(db, pattern, func(fp string) bool {
+		ok, _ := path.Match(pattern, fp)
+		return ok
+	})
+}
+
+// globEscaper quotes GLOB metacharacters so a string matches literally.
+var globEscaper = strings.NewReplacer("*", "[*]", "?", "[?]", "[", "[[]")
+
+// findIssuesByFile returns the open issues attached to files matching the
+// SQLite GLOB pattern, which can use the file_path index for its literal
+// prefix. When keep is non-nil, only files it accepts are returned.
+func findIssuesByFile(db *sql.DB, glob string, keep func(string) bool) (map[string][]*model.Issue, error) {
+	rows, err := db.Query(
+		`SELECT i.id, i.parent_id, i.title, i.description, i.status, i.priority, i.kind, i.assignee, i.milestone_id, i.created_at, i.updated_at, i.started_at, i.completed_at, i.snoozed_until, i.sort_order, i.pinned, f.file_path
+		 FROM issue_files f JOIN issues i ON i.id = f.issue_id
+		 WHERE f.file_path GLOB ? AND i.deleted_at IS NULL AND i.status != ?
+		 ORDER BY f.file_path, i.id`,
+		glob, string(model.StatusDone),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying issues by file: %w", err)
+	}
+	defer rows.Close()
+
+	byFile := make(map[string][]*model.Issue)
+	byID := make(map[int]*model.Issue)
+	var issues []*model.Issue
+	for rows.Next() {
+		var fp string
+		issue, err := scanIssueFrom(rows, &fp)
+		if err != nil {
+			return nil, fmt.Errorf("scanning issue row: %w", err)
+		}
+		if keep != nil && !keep(fp) {
+			continue
 		}
+		if seen, ok := byID[issue.ID]; ok {
+			issue = seen
+		} else {
+			byID[issue.ID] = issue
+			issues = append(issues, issue)
+		}
+		byFile[fp] = append(byFile[fp], issue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating issue rows: %w", err)
+	}
+
+	if err := HydrateLabels(db, issues); err != nil {
+		return nil, fmt.Errorf("hydrating labels: %w", err)
+	}
+	if err := HydrateFiles(db, issues); err != nil {
+		return nil, fmt.Errorf("hydrating files: %w", err)
 	}
-	return rows.Err()
+	return byFile, nil
+}
+
+// SharedOpenFiles returns the files attached to more than one open issue,
+// keyed by file path, with the IDs of those issues in ascending order. Done
+// and trashed issues are left out.
+func SharedOpenFiles(db *sql.DB) (map[string][]int, error) {
+	rows, err := db.Query(
+		`SELECT f.file_path, f.issue_id
+		 FROM issue_files f JOIN issues i ON i.id = f.issue_id
+		 WHERE i.deleted_at IS NULL AND i.status != ?
+		   AND f.file_path IN (
+			SELECT f2.file_path
+			FROM issue_files f2 JOIN issues i2 ON i2.id = f2.issue_id
+			WHERE i2.deleted_at IS NULL AND i2.status != ?
+			GROUP BY f2.file_path
+			HAVING COUNT(*) > 1
+		   )
+		 ORDER BY f.file_path, f.issue_id`,
+		string(model.StatusDone), string(model.StatusDone),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying shared files: %w", err)
+	}
+	defer rows.Close()
+
+	shared := make(map[string][]int)
+	for rows.Next() {
+		var fp string
+		var id int
+		if err := rows.Scan(&fp, &id); err != nil {
+			return nil, fmt.Errorf("scanning shared file: %w", err)
+		}
+		shared[fp] = append(shared[fp], id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating shared files: %w", err)
+	}
+	return shared, nil
 }
 
 // ListAllIssueFileMappings returns all rows from issue_files as
 // IssueFileMapping structs. This is needed by the export command.
 func ListAllIssueFileMappings(db *sql.DB) ([]model.IssueFileMapping, error) {
+	var mappings []model.IssueFileMapping
+	err := StreamIssueFileMappings(db, func(v model.IssueFileMapping) error {
+		mappings = append(mappings, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mappings, nil
+}
+
+// StreamIssueFileMappings calls fn for every issue-file mapping row in the same
+// order as ListAllIssueFileMappings without buffering the result set. fn runs
+// while the cursor is open and must not query db.
+func StreamIssueFileMappings(db *sql.DB, fn func(model.IssueFileMapping) error) error {
 	rows, err := db.Query(
-		`SELECT issue_id, file_path FROM issue_files ORDER BY issue_id, file_path`,
+		`SELECT issue_id, file_path FROM issue_files WHERE issue_id IN ` + liveIssueIDs + ` ORDER BY issue_id, file_path`,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("querying issue-file mappings: %w", err)
+		return fmt.Errorf("querying issue-file mappings: %w", err)
 	}
 	defer rows.Close()
 
-	var mappings []model.IssueFileMapping
 	for rows.Next() {
 		var m model.IssueFileMapping
 		if err := rows.Scan(&m.IssueID, &m.FilePath); err != nil {
-			return nil, fmt.Errorf("scanning issue-file mapping: %w", err)
+			return fmt.Errorf("scanning issue-file mapping: %w", err)
+		}
+		if err := fn(m); err != nil {
+			return err
 		}
-		mappings = append(mappings, m)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating issue-file mappings: %w", err)
+		return fmt.Errorf("iterating issue-file mappings: %w", err)
 	}
-
-	return mappings, nil
+	return nil
 }
 
 // queryFilePaths returns file paths for an issue within a transaction.
@@ -1,6 +1,8 @@
 package db
 
 import (
+	"errors"
+	"slices"
 	"testing"
 
 	"github.com/ALT-F4-LLC/docket/internal/model"
@@ -184,6 +186,50 @@ func TestGetIssueFilesSorted(t *testing.T) {
 	}
 }
 
+func TestGetFilesByIssueIDs(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	a := mustCreateIssue(t, db, "a")
+	b := mustCreateIssue(t, db, "b")
+	none := mustCreateIssue(t, db, "no files")
+	notRequested := mustCreateIssue(t, db, "not requested")
+
+	if err := AttachFiles(db, a, []string{"z.go", "a.go", "m.go"}, "alice"); err != nil {
+		t.Fatalf("AttachFiles: %v", err)
+	}
+	if err := AttachFiles(db, b, []string{"b.go"}, "alice"); err != nil {
+		t.Fatalf("AttachFiles: %v", err)
+	}
+	if err := AttachFiles(db, notRequested, []string{"x.go"}, "alice"); err != nil {
+		t.Fatalf("AttachFiles: %v", err)
+	}
+
+	files, err := GetFilesByIssueIDs(db, []int{a, b, none, 999})
+	if err != nil {
+		t.Fatalf("GetFilesByIssueIDs: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected entries for 2 issues, got %v", files)
+	}
+	if got := files[a]; !slices.Equal(got, []string{"a.go", "m.go", "z.go"}) {
+		t.Errorf("files[a] = %v, want alphabetical order", got)
+	}
+	if got := files[b]; !slices.Equal(got, []string{"b.go"}) {
+		t.Errorf("files[b] = %v", got)
+	}
+	if _, ok := files[none]; ok {
+		t.Error("issue without files has a map entry")
+	}
+
+	empty, err := GetFilesByIssueIDs(db, nil)
+	if err != nil || len(empty) != 0 {
+		t.Errorf("GetFilesByIssueIDs(nil) = %v, %v; want empty map", empty, err)
+	}
+}
+
 func TestSetIssueFiles(t *testing.T) {
 	db := mustOpen(t)
 	if err := Initialize(db); err != nil {
@@ -477,3 +523,115 @@ func TestDetachFilesRecordsActivity(t *testing.T) {
 		t.Errorf("expected 2 files activity entries, got %d", count)
 	}
 }
+
+func TestFindIssuesByFilePrefixAndGlob(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	open := mustCreateIssue(t, db, "open")
+	other := mustCreateIssue(t, db, "other")
+	done := mustCreateIssue(t, db, "done")
+	trashed := mustCreateIssue(t, db, "trashed")
+	mustAttach := func(id int, files ...string) {
+		t.Helper()
+		if err := AttachFiles(db, id, files, ""); err != nil {
+			t.Fatalf("AttachFiles: %v", err)
+		}
+	}
+	mustAttach(open, "internal/db/files.go", "internal/db/sub/deep.go", "internal/dbx.go")
+	mustAttach(other, "internal/db/files.go", "internal/render/detail.go")
+	mustAttach(done, "internal/db/schema.go")
+	mustAttach(trashed, "internal/db/meta.go")
+	if err := UpdateIssue(db, done, map[string]interface{}{"status": "done"}, ""); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+	if _, err := TrashIssue(db, trashed, ""); err != nil {
+		t.Fatalf("TrashIssue: %v", err)
+	}
+
+	owners := func(m map[string][]*model.Issue) map[string][]int {
+		got := make(map[string][]int)
+		for f, issues := range m {
+			for _, i := range issues {
+				got[f] = append(got[f], i.ID)
+			}
+		}
+		return got
+	}
+
+	byPrefix, err := FindIssuesByFilePrefix(db, "internal/db/")
+	if err != nil {
+		t.Fatalf("FindIssuesByFilePrefix: %v", err)
+	}
+	got := owners(byPrefix)
+	if len(got) != 2 || !slices.Equal(got["internal/db/files.go"], []int{open, other}) || !slices.Equal(got["internal/db/sub/deep.go"], []int{open}) {
+		t.Errorf("prefix owners = %v", got)
+	}
+	if files := byPrefix["internal/db/files.go"][0].Files; len(files) != 3 {
+		t.Errorf("owner files not hydrated: %v", files)
+	}
+
+	byGlob, err := FindIssuesByFileGlob(db, "internal/*/*.go")
+	if err != nil {
+		t.Fatalf("FindIssuesByFileGlob: %v", err)
+	}
+	got = owners(byGlob)
+	if len(got) != 2 || len(got["internal/db/files.go"]) != 2 || !slices.Equal(got["internal/render/detail.go"], []int{other}) {
+		t.Errorf("glob owners = %v, want files.go and detail.go only", got)
+	}
+
+	// Glob metacharacters in a prefix are matched literally.
+	if m, err := FindIssuesByFilePrefix(db, "internal/*"); err != nil || len(m) != 0 {
+		t.Errorf("FindIssuesByFilePrefix(literal *) = %v, %v; want no match", m, err)
+	}
+	if m, err := FindIssuesByFileGlob(db, "cmd/*.go"); err != nil || len(m) != 0 {
+		t.Errorf("FindIssuesByFileGlob(no match) = %v, %v; want empty", m, err)
+	}
+	if _, err := FindIssuesByFileGlob(db, "internal/[db"); !errors.Is(err, ErrValidation) {
+		t.Errorf("FindIssuesByFileGlob(bad pattern) error = %v, want ErrValidation", err)
+	}
+}
+
+func TestSharedOpenFiles(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	a := mustCreateIssue(t, db, "a")
+	b := mustCreateIssue(t, db, "b")
+	done := mustCreateIssue(t, db, "done")
+	trashed := mustCreateIssue(t, db, "trashed")
+	for id, files := range map[int][]string{
+		a:       {"issues.go", "labels.go", "only-a.go"},
+		b:       {"issues.go", "labels.go"},
+		done:    {"issues.go", "only-a.go"},
+		trashed: {"labels.go", "only-a.go"},
+	} {
+		if err := AttachFiles(db, id, files, ""); err != nil {
+			t.Fatalf("AttachFiles: %v", err)
+		}
+	}
+	if err := UpdateIssue(db, done, map[string]interface{}{"status": "done"}, ""); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+	if _, err := TrashIssue(db, trashed, ""); err != nil {
+		t.Fatalf("TrashIssue: %v", err)
+	}
+
+	shared, err := SharedOpenFiles(db)
+	if err != nil {
+		t.Fatalf("SharedOpenFiles: %v", err)
+	}
+	want := map[string][]int{"issues.go": {a, b}, "labels.go": {a, b}}
+	if len(shared) != len(want) {
+		t.Fatalf("SharedOpenFiles = %v, want %v", shared, want)
+	}
+	for f, ids := range want {
+		if !slices.Equal(shared[f], ids) {
+			t.Errorf("shared[%q] = %v, want %v", f, shared[f], ids)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/textdiff"
 )
 
 // safeIdentifier matches valid SQL column identifiers (lowercase letters and underscores only).
@@ -26,49 +27,119 @@ type scanner interface {
 
 // ListOptions holds filtering, sorting, and pagination options for ListIssues.
 type ListOptions struct {
-	Statuses    []string // filter by status (multiple = OR)
-	Priorities  []string // filter by priority (multiple = OR)
-	Labels      []string // filter by label name (multiple = AND)
-	Types       []string // filter by kind (multiple = OR)
-	Assignee    string   // filter by assignee
-	ParentID    *int     // filter by parent issue ID
-	RootsOnly   bool     // only issues with no parent
-	IncludeDone bool     // include done status (default: exclude)
-	Sort        string   // field name
-	SortDir     string   // "asc" or "desc"
-	Limit       int      // max results
-	Offset      int      // for pagination
+	Statuses       []string // filter by status (multiple = OR)
+	Priorities     []string // filter by priority (multiple = OR)
+	Labels         []string // filter by label name (multiple = AND)
+	Types          []string // filter by kind (multiple = OR)
+	Assignee       string   // filter by assignee
+	ParentID       *int     // filter by parent issue ID
+	MilestoneID    *int     // filter by milestone ID
+	RootsOnly      bool     // only issues with no parent
+	HasChildren    *bool    // true: only issues with sub-issues; false: only leaf issues
+	HasFiles       *bool    // true: only issues with attached files; false: only issues without
+	Pinned         *bool    // true: only pinned issues; false: only unpinned issues
+	IncludeDone    bool     // include done status (default: exclude)
+	IncludeSnoozed bool     // include issues snoozed until a future time (default: exclude)
+	Sort           string   // field name; superseded by SortKeys when set
+	SortDir        string   // "asc" or "desc"
+	Limit          int      // max results
+	Offset         int      // for pagination
+
+	// SortKeys orders results by each key in turn. When empty, Sort and
+	// SortDir give a single-key sort, and without those the default order
+	// applies.
+	SortKeys []SortKey
+
+	// CompletedSince and CompletedBefore, when non-zero, restrict results to
+	// issues completed in [CompletedSince, CompletedBefore). Either implies
+	// IncludeDone.
+	CompletedSince  time.Time
+	CompletedBefore time.Time
+
+	// UpdatedSince, when non-zero, restricts results to issues updated at or
+	// after it.
+	UpdatedSince time.Time
+
+	// PinnedFirst orders pinned issues ahead of all others; the sort keys
+	// still order issues within each group.
+	PinnedFirst bool
+
+	// CountOnly skips fetching and hydrating the matching issues, so
+	// ListIssues returns only their total count.
+	CountOnly bool
+}
+
+// WithAllStatuses returns a copy of o that includes done issues, which
+// ListIssues otherwise leaves out.
+func (o ListOptions) WithAllStatuses() ListOptions {
+	o.IncludeDone = true
+	return o
 }
 
 // validSortFields is the set of columns allowed for sorting.
 // WARNING: These keys are interpolated directly into SQL ORDER BY clauses.
 // Only add single-word column names that exactly match the issues table schema.
 var validSortFields = map[string]bool{
-	"id":         true,
-	"title":      true,
-	"status":     true,
-	"priority":   true,
-	"kind":       true,
-	"assignee":   true,
-	"created_at": true,
-	"updated_at": true,
+	"id":           true,
+	"title":        true,
+	"status":       true,
+	"priority":     true,
+	"kind":         true,
+	"assignee":     true,
+	"created_at":   true,
+	"updated_at":   true,
+	"started_at":   true,
+	"completed_at": true,
+}
+
+// computedSortFields maps sort keys that are not plain issue columns to the
+// fixed SQL expression they order by.
+var computedSortFields = map[string]string{
+	"comments": "(SELECT COUNT(*) FROM comments c WHERE c.issue_id = i.id)",
+	// The number of issues i blocks, counted as in GetRelationDegrees.
+	"blocks": `(SELECT COUNT(DISTINCT CASE WHEN r.relation_type = 'blocks' THEN r.target_issue_id ELSE r.source_issue_id END)
+		FROM issue_relations r
+		WHERE ((r.source_issue_id = i.id AND r.relation_type = 'blocks')
+		    OR (r.target_issue_id = i.id AND r.relation_type = 'depends_on'))
+		  AND ` + liveRelationEnds + `)`,
 }
 
+// descriptionDiffContext is the number of unchanged lines kept around each
+// change in the diff recorded for a description edit.
+const descriptionDiffContext = 1
+
 // validUpdateFields is the set of columns allowed in UpdateIssue.
 var validUpdateFields = map[string]bool{
-	"title":       true,
-	"description": true,
-	"status":      true,
-	"priority":    true,
-	"kind":        true,
-	"assignee":    true,
-	"parent_id":   true,
+	"title":        true,
+	"description":  true,
+	"status":       true,
+	"priority":     true,
+	"kind":         true,
+	"assignee":     true,
+	"parent_id":    true,
+	"milestone_id": true,
 }
 
 // CreateIssue inserts a new issue and returns its ID. Labels are created
 // (find-or-create) and linked to the issue within the same transaction.
 // Files are attached to the issue if provided.
 func CreateIssue(db *sql.DB, issue *model.Issue, labels []string, files []string) (int, error) {
+	return CreateIssueBy(db, issue, labels, files, "")
+}
+
+// CreateIssueBy is CreateIssue on behalf of createdBy, who is recorded as
+// the author of its activity. The assignee and anyone @mentioned in the
+// description are notified unless they are createdBy; an empty createdBy
+// notifies them all.
+func CreateIssueBy(db *sql.DB, issue *model.Issue, labels []string, files []string, createdBy string) (int, error) {
+	return withRetryValue(func() (int, error) { return createIssue(db, issue, labels, files, createdBy) })
+}
+
+func createIssue(db *sql.DB, issue *model.Issue, labels []string, files []string, createdBy string) (int, error) {
+	if err := model.ValidateIssue(issue); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+
 	now := time.Now().UTC().Format(time.RFC3339)
 
 	tx, err := db.Begin()
@@ -77,9 +148,17 @@ func CreateIssue(db *sql.DB, issue *model.Issue, labels []string, files []string
 	}
 	defer tx.Rollback()
 
+	var startedAt, completedAt interface{}
+	switch issue.Status {
+	case model.StatusInProgress:
+		startedAt = now
+	case model.StatusDone:
+		completedAt = now
+	}
+
 	res, err := tx.Exec(
-		`INSERT INTO issues (parent_id, title, description, status, priority, kind, assignee, created_at, updated_at)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		`INSERT INTO issues (parent_id, title, description, status, priority, kind, assignee, milestone_id, created_at, updated_at, started_at, completed_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		nilIfZeroPtr(issue.ParentID),
 		issue.Title,
 		issue.Description,
@@ -87,8 +166,11 @@ func CreateIssue(db *sql.DB, issue *model.Issue, labels []string, files []string
 		string(issue.Priority),
 		string(issue.Kind),
 		issue.Assignee,
+		nilIfZeroPtr(issue.MilestoneID),
 		now,
 		now,
+		startedAt,
+		completedAt,
 	)
 	if err != nil {
 		return 0, fmt.Errorf("inserting issue: %w", err)
@@ -112,6 +194,9 @@ func CreateIssue(db *sql.DB, issue *model.Issue, labels []string, files []string
 		); err != nil {
 			return 0, fmt.Errorf("linking label %q: %w", name, err)
 		}
+		if err := touchLabel(tx, labelID, now); err != nil {
+			return 0, err
+		}
 	}
 
 	// Attach files.
@@ -124,8 +209,19 @@ func CreateIssue(db *sql.DB, issue *model.Issue, labels []string, files []string
 		}
 	}
 
+	if err := syncReferencesTx(tx, id, model.ReferenceContextDescription, nil, issue.Description); err != nil {
+		return 0, err
+	}
+
+	if err := notifyTx(tx, issue.Assignee, id, model.NotificationAssigned, createdBy); err != nil {
+		return 0, err
+	}
+	if err := notifyMentionsTx(tx, id, issue.Description, "", createdBy); err != nil {
+		return 0, err
+	}
+
 	// Record creation activity.
-	if err := RecordActivity(tx, id, "created", "", "", ""); err != nil {
+	if err := RecordActivity(tx, id, "created", "", "", createdBy); err != nil {
 		return 0, err
 	}
 
@@ -133,7 +229,7 @@ func CreateIssue(db *sql.DB, issue *model.Issue, labels []string, files []string
 	if len(files) > 0 {
 		sorted := slices.Clone(files)
 		sort.Strings(sorted)
-		if err := RecordActivity(tx, id, "files", "", strings.Join(sorted, ", "), ""); err != nil {
+		if err := RecordActivity(tx, id, "files", "", strings.Join(sorted, ", "), createdBy); err != nil {
 			return 0, err
 		}
 	}
@@ -145,18 +241,19 @@ func CreateIssue(db *sql.DB, issue *model.Issue, labels []string, files []string
 	return id, nil
 }
 
-// GetIssue retrieves an issue by ID.
+// GetIssue retrieves an issue by ID. Issues in the trash are reported as
+// ErrNotFound.
 func GetIssue(db *sql.DB, id int) (*model.Issue, error) {
 	row := db.QueryRow(
-		`SELECT id, parent_id, title, description, status, priority, kind, assignee, created_at, updated_at
-		 FROM issues WHERE id = ?`, id,
+		`SELECT id, parent_id, title, description, status, priority, kind, assignee, milestone_id, created_at, updated_at, started_at, completed_at, snoozed_until, sort_order, pinned
+		 FROM issues WHERE id = ? AND deleted_at IS NULL`, id,
 	)
 	return scanIssue(row)
 }
 
 // GetIssuesByIDs retrieves multiple issues by their IDs in a single query.
 // The returned map is keyed by issue ID. IDs that don't exist are silently
-// skipped (no error for missing rows). Labels are hydrated on all returned issues.
+// skipped (no error for missing or trashed rows). Labels are hydrated on all returned issues.
 func GetIssuesByIDs(db *sql.DB, ids []int) (map[int]*model.Issue, error) {
 	if len(ids) == 0 {
 		return make(map[int]*model.Issue), nil
@@ -169,8 +266,8 @@ func GetIssuesByIDs(db *sql.DB, ids []int) (map[int]*model.Issue, error) {
 	}
 
 	query := fmt.Sprintf(
-		`SELECT id, parent_id, title, description, status, priority, kind, assignee, created_at, updated_at
-		 FROM issues WHERE id IN (%s)`, placeholders,
+		`SELECT id, parent_id, title, description, status, priority, kind, assignee, milestone_id, created_at, updated_at, started_at, completed_at, snoozed_until, sort_order, pinned
+		 FROM issues WHERE id IN (%s) AND deleted_at IS NULL`, placeholders,
 	)
 
 	rows, err := db.Query(query, args...)
@@ -206,10 +303,129 @@ func GetIssuesByIDs(db *sql.DB, ids []int) (map[int]*model.Issue, error) {
 	return result, nil
 }
 
+// GetIssueTitles returns the titles of the live issues among ids, keyed by
+// ID, in a single primary-key lookup. IDs that don't exist or are in the
+// trash are skipped.
+func GetIssueTitles(db *sql.DB, ids []int) (map[int]string, error) {
+	titles := make(map[int]string, len(ids))
+	if len(ids) == 0 {
+		return titles, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	rows, err := db.Query(
+		fmt.Sprintf(`SELECT id, title FROM issues WHERE id IN (%s) AND deleted_at IS NULL`, makePlaceholders(len(ids))),
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying issue titles: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var title string
+		if err := rows.Scan(&id, &title); err != nil {
+			return nil, fmt.Errorf("scanning issue title: %w", err)
+		}
+		titles[id] = title
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating issue titles: %w", err)
+	}
+	return titles, nil
+}
+
 // ListIssues retrieves issues matching the given filters. It returns the
 // matching issues, the total count of matching rows (ignoring Limit/Offset),
-// and an error.
+// and an error. With CountOnly it returns no issues, only the count.
 func ListIssues(db *sql.DB, opts ListOptions) ([]*model.Issue, int, error) {
+	fromSQL, args := listFromClause(opts)
+
+	// Count query (total matching rows for pagination).
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM (SELECT i.id %s)`, fromSQL)
+	var totalCount int
+	if err := db.QueryRow(countQuery, args...).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("counting issues: %w", err)
+	}
+	if opts.CountOnly {
+		return nil, totalCount, nil
+	}
+
+	// Determine sort. The single-field Sort and SortDir are kept for
+	// compatibility; an unknown single field falls back to the default order.
+	sortKeys := opts.SortKeys
+	if len(sortKeys) == 0 && isSortField(opts.Sort) {
+		sortKeys = []SortKey{{Field: opts.Sort, Dir: sortDir(opts.SortDir)}}
+	}
+	orderBySQL, err := orderByClause(sortKeys)
+	if err != nil {
+		return nil, 0, err
+	}
+	if opts.PinnedFirst {
+		orderBySQL = pinnedFirst(orderBySQL)
+	}
+
+	// Main query.
+	mainQuery := fmt.Sprintf(
+		`SELECT i.id, i.parent_id, i.title, i.description, i.status, i.priority, i.kind, i.assignee, i.milestone_id, i.created_at, i.updated_at, i.started_at, i.completed_at, i.snoozed_until, i.sort_order, i.pinned
+		 %s %s`,
+		fromSQL, orderBySQL,
+	)
+
+	mainArgs := make([]interface{}, len(args))
+	copy(mainArgs, args)
+
+	if opts.Limit > 0 {
+		mainQuery += " LIMIT ?"
+		mainArgs = append(mainArgs, opts.Limit)
+	}
+	if opts.Offset > 0 {
+		// SQLite only accepts OFFSET after a LIMIT; -1 means no limit.
+		if opts.Limit <= 0 {
+			mainQuery += " LIMIT -1"
+		}
+		mainQuery += " OFFSET ?"
+		mainArgs = append(mainArgs, opts.Offset)
+	}
+
+	rows, err := db.Query(mainQuery, mainArgs...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("querying issues: %w", err)
+	}
+	defer rows.Close()
+
+	issues := make([]*model.Issue, 0)
+	for rows.Next() {
+		issue, err := scanIssueRow(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		issues = append(issues, issue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterating issue rows: %w", err)
+	}
+
+	// Hydrate labels for all returned issues to avoid N+1 queries in callers.
+	if err := HydrateLabels(db, issues); err != nil {
+		return nil, 0, fmt.Errorf("hydrating labels: %w", err)
+	}
+
+	if err := HydrateFiles(db, issues); err != nil {
+		return nil, 0, fmt.Errorf("hydrating files: %w", err)
+	}
+
+	return issues, totalCount, nil
+}
+
+// listFromClause builds the FROM, WHERE, GROUP BY and HAVING clauses that
+// select the issues matching opts' filters, aliased as i, along with their
+// arguments. Sorting and pagination are left to the caller.
+func listFromClause(opts ListOptions) (string, []interface{}) {
 	var (
 		whereClauses []string
 		args         []interface{}
@@ -226,11 +442,35 @@ func ListIssues(db *sql.DB, opts ListOptions) ([]*model.Issue, int, error) {
 		}
 	}
 
-	// Exclude "done" by default.
-	if !opts.IncludeDone {
+	// Issues in the trash never appear in listings.
+	whereClauses = append(whereClauses, "i.deleted_at IS NULL")
+
+	// Exclude "done" by default, unless filtering on completion time.
+	completedFilter := !opts.CompletedSince.IsZero() || !opts.CompletedBefore.IsZero()
+	if !opts.IncludeDone && !completedFilter {
 		whereClauses = append(whereClauses, "i.status != 'done'")
 	}
 
+	// Exclude currently snoozed issues by default. A snooze that has run
+	// out needs no clean-up: the comparison with now lets the issue back in.
+	if !opts.IncludeSnoozed {
+		whereClauses = append(whereClauses, "(i.snoozed_until IS NULL OR i.snoozed_until <= ?)")
+		args = append(args, time.Now().UTC().Format(time.RFC3339))
+	}
+
+	if !opts.CompletedSince.IsZero() {
+		whereClauses = append(whereClauses, "i.completed_at >= ?")
+		args = append(args, opts.CompletedSince.UTC().Format(time.RFC3339))
+	}
+	if !opts.CompletedBefore.IsZero() {
+		whereClauses = append(whereClauses, "i.completed_at < ?")
+		args = append(args, opts.CompletedBefore.UTC().Format(time.RFC3339))
+	}
+	if !opts.UpdatedSince.IsZero() {
+		whereClauses = append(whereClauses, "i.updated_at >= ?")
+		args = append(args, opts.UpdatedSince.UTC().Format(time.RFC3339))
+	}
+
 	if len(opts.Statuses) > 0 {
 		placeholders := makePlaceholders(len(opts.Statuses))
 		whereClauses = append(whereClauses, fmt.Sprintf("i.status IN (%s)", placeholders))
@@ -265,10 +505,36 @@ func ListIssues(db *sql.DB, opts ListOptions) ([]*model.Issue, int, error) {
 		args = append(args, *opts.ParentID)
 	}
 
+	if opts.MilestoneID != nil {
+		whereClauses = append(whereClauses, "i.milestone_id = ?")
+		args = append(args, *opts.MilestoneID)
+	}
+
 	if opts.RootsOnly {
 		whereClauses = append(whereClauses, "i.parent_id IS NULL")
 	}
 
+	if opts.HasChildren != nil {
+		exists := "EXISTS (SELECT 1 FROM issues c WHERE c.parent_id = i.id AND c.deleted_at IS NULL)"
+		if !*opts.HasChildren {
+			exists = "NOT " + exists
+		}
+		whereClauses = append(whereClauses, exists)
+	}
+
+	if opts.HasFiles != nil {
+		exists := "EXISTS (SELECT 1 FROM issue_files f WHERE f.issue_id = i.id)"
+		if !*opts.HasFiles {
+			exists = "NOT " + exists
+		}
+		whereClauses = append(whereClauses, exists)
+	}
+
+	if opts.Pinned != nil {
+		whereClauses = append(whereClauses, "i.pinned = ?")
+		args = append(args, *opts.Pinned)
+	}
+
 	// Labels filter: AND logic — issue must have ALL specified labels.
 	if len(opts.Labels) > 0 {
 		joinClause = `JOIN issue_labels il ON il.issue_id = i.id
@@ -293,110 +559,29 @@ func ListIssues(db *sql.DB, opts ListOptions) ([]*model.Issue, int, error) {
 		havingSQL = fmt.Sprintf("HAVING COUNT(DISTINCT l.name) = %d", len(opts.Labels))
 	}
 
-	// Count query (total matching rows for pagination).
-	countQuery := fmt.Sprintf(
-		`SELECT COUNT(*) FROM (SELECT i.id FROM issues i %s %s %s %s)`,
-		joinClause, whereSQL, groupBySQL, havingSQL,
-	)
-	var totalCount int
-	if err := db.QueryRow(countQuery, args...).Scan(&totalCount); err != nil {
-		return nil, 0, fmt.Errorf("counting issues: %w", err)
-	}
-
-	// Determine sort.
-	var orderBySQL string
-	if opts.Sort != "" && validSortFields[opts.Sort] {
-		sortField := opts.Sort
-		// Defense-in-depth: reject any sort field that doesn't look like a plain column name,
-		// even if it passed the allowlist check above.
-		if !safeIdentifier.MatchString(sortField) {
-			return nil, 0, fmt.Errorf("invalid sort field %q", sortField)
-		}
-		sortDir := "DESC"
-		if strings.EqualFold(opts.SortDir, "asc") {
-			sortDir = "ASC"
-		}
-		// Safe: sortField validated against validSortFields and safeIdentifier; sortDir is "ASC" or "DESC".
-		orderBySQL = fmt.Sprintf("ORDER BY i.%s %s", sortField, sortDir)
-	} else {
-		// Default composite sort: status rank, then priority rank, then newest first.
-		orderBySQL = `ORDER BY
-			CASE i.status
-				WHEN 'in-progress' THEN 0
-				WHEN 'review'      THEN 1
-				WHEN 'todo'        THEN 2
-				WHEN 'backlog'     THEN 3
-				WHEN 'done'        THEN 4
-				ELSE 5
-			END ASC,
-			CASE i.priority
-				WHEN 'critical' THEN 0
-				WHEN 'high'     THEN 1
-				WHEN 'medium'   THEN 2
-				WHEN 'low'      THEN 3
-				WHEN 'none'     THEN 4
-				ELSE 5
-			END ASC,
-			i.created_at DESC`
-	}
-
-	// Main query.
-	mainQuery := fmt.Sprintf(
-		`SELECT i.id, i.parent_id, i.title, i.description, i.status, i.priority, i.kind, i.assignee, i.created_at, i.updated_at
-		 FROM issues i %s %s %s %s %s`,
-		joinClause, whereSQL, groupBySQL, havingSQL, orderBySQL,
-	)
-
-	mainArgs := make([]interface{}, len(args))
-	copy(mainArgs, args)
-
-	if opts.Limit > 0 {
-		mainQuery += " LIMIT ?"
-		mainArgs = append(mainArgs, opts.Limit)
-	}
-	if opts.Offset > 0 {
-		mainQuery += " OFFSET ?"
-		mainArgs = append(mainArgs, opts.Offset)
-	}
-
-	rows, err := db.Query(mainQuery, mainArgs...)
-	if err != nil {
-		return nil, 0, fmt.Errorf("querying issues: %w", err)
-	}
-	defer rows.Close()
-
-	issues := make([]*model.Issue, 0)
-	for rows.Next() {
-		issue, err := scanIssueRow(rows)
-		if err != nil {
-			return nil, 0, err
-		}
-		issues = append(issues, issue)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("iterating issue rows: %w", err)
-	}
-
-	// Hydrate labels for all returned issues to avoid N+1 queries in callers.
-	if err := HydrateLabels(db, issues); err != nil {
-		return nil, 0, fmt.Errorf("hydrating labels: %w", err)
-	}
-
-	if err := HydrateFiles(db, issues); err != nil {
-		return nil, 0, fmt.Errorf("hydrating files: %w", err)
-	}
-
-	return issues, totalCount, nil
+	return fmt.Sprintf("FROM issues i %s %s %s %s", joinClause, whereSQL, groupBySQL, havingSQL), args
 }
 
 // UpdateIssue updates an existing issue. Only keys present in the updates map
 // are modified. The updated_at timestamp is always set to the current time.
-// Activity is recorded for each changed field within the same transaction.
+// Activity is recorded for each changed field within the same transaction; a
+// description change is recorded as a unified diff rather than both texts.
+//
+// Field names are validated against validUpdateFields. Status, priority and
+// kind values must be valid enums, a status change must be allowed by the
+// configured transition map (see SetStatusTransitions), and a new parent must
+// be a live issue that is neither the issue itself nor one of its
+// descendants; violations return an error wrapping ErrValidation and leave
+// the issue unchanged.
 //
-// Field names are validated against validUpdateFields, but callers are responsible
-// for validating field values (e.g. ensuring status/priority/kind are valid enums)
-// before calling this function.
+// A new assignee is notified of the assignment, the assignee is notified of
+// a status change, and people newly @mentioned in the description are
+// notified, except where they are changedBy.
 func UpdateIssue(db *sql.DB, id int, updates map[string]interface{}, changedBy string) error {
+	return WithRetry(func() error { return updateIssue(db, id, updates, changedBy) })
+}
+
+func updateIssue(db *sql.DB, id int, updates map[string]interface{}, changedBy string) error {
 	if len(updates) == 0 {
 		return nil
 	}
@@ -407,12 +592,24 @@ func UpdateIssue(db *sql.DB, id int, updates map[string]interface{}, changedBy s
 	}
 	defer tx.Rollback()
 
+	if err := updateIssueTx(tx, id, updates, changedBy); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// updateIssueTx is UpdateIssue within an existing transaction.
+func updateIssueTx(tx *sql.Tx, id int, updates map[string]interface{}, changedBy string) error {
 	// Fetch old values for activity logging.
 	oldIssue, err := getIssueTx(tx, id)
 	if err != nil {
 		return err
 	}
 
+	if err := validateIssueUpdates(tx, oldIssue, updates); err != nil {
+		return err
+	}
+
 	var setClauses []string
 	var args []interface{}
 
@@ -431,8 +628,19 @@ func UpdateIssue(db *sql.DB, id int, updates map[string]interface{}, changedBy s
 		args = append(args, updates[field])
 	}
 
+	now := time.Now().UTC().Format(time.RFC3339)
+	if v, ok := updates["status"]; ok {
+		clauses, stampArgs := statusTimestamps(oldIssue, model.Status(fmt.Sprint(v)), now)
+		setClauses = append(setClauses, clauses...)
+		args = append(args, stampArgs...)
+	}
+	if v, ok := updates["parent_id"]; ok && !sameParent(oldIssue.ParentID, v) {
+		// A rank among the old siblings means nothing under a new parent.
+		setClauses = append(setClauses, "sort_order = NULL")
+	}
+
 	setClauses = append(setClauses, "updated_at = ?")
-	args = append(args, time.Now().UTC().Format(time.RFC3339))
+	args = append(args, now)
 	args = append(args, id)
 
 	query := fmt.Sprintf(
@@ -453,25 +661,184 @@ func UpdateIssue(db *sql.DB, id int, updates map[string]interface{}, changedBy s
 		return ErrNotFound
 	}
 
-	// Record activity for each changed field.
+	if desc, ok := updates["description"]; ok {
+		text, _ := desc.(string)
+		if err := syncReferencesTx(tx, id, model.ReferenceContextDescription, nil, text); err != nil {
+			return err
+		}
+		if err := notifyMentionsTx(tx, id, text, oldIssue.Description, changedBy); err != nil {
+			return err
+		}
+	}
+
+	if err := notifyUpdateTx(tx, oldIssue, updates, changedBy); err != nil {
+		return err
+	}
+
+	// Record activity for each changed field. Descriptions can be long, so
+	// their entries hold a diff in new_value instead of both full texts.
 	for _, field := range fields {
 		oldVal := getFieldValue(oldIssue, field)
 		newVal := fmt.Sprintf("%v", updates[field])
-		if oldVal != newVal {
-			if err := RecordActivity(tx, id, field, oldVal, newVal, changedBy); err != nil {
-				return err
-			}
+		if oldVal == newVal {
+			continue
+		}
+		if field == "description" {
+			oldVal, newVal = "", textdiff.Unified("description", oldVal, newVal, descriptionDiffContext)
+		}
+		if err := RecordActivity(tx, id, field, oldVal, newVal, changedBy); err != nil {
+			return err
 		}
 	}
 
-	return tx.Commit()
+	return nil
+}
+
+// notifyUpdateTx notifies a newly set assignee of their assignment, or,
+// when the assignee is unchanged, notifies the assignee of a status change.
+func notifyUpdateTx(tx *sql.Tx, old *model.Issue, updates map[string]interface{}, changedBy string) error {
+	if v, ok := updates["assignee"]; ok {
+		if assignee := fmt.Sprint(v); !strings.EqualFold(assignee, old.Assignee) {
+			return notifyTx(tx, assignee, old.ID, model.NotificationAssigned, changedBy)
+		}
+	}
+	if v, ok := updates["status"]; ok && model.Status(fmt.Sprint(v)) != old.Status {
+		return notifyTx(tx, old.Assignee, old.ID, model.NotificationStatusChanged, changedBy)
+	}
+	return nil
+}
+
+// statusTimestamps returns the SET clauses and arguments that keep
+// started_at and completed_at in step with a status change from old.Status
+// to status: started_at is set the first time the issue moves to
+// in-progress, completed_at each time it moves to done, and completed_at is
+// cleared when a done issue is reopened.
+func statusTimestamps(old *model.Issue, status model.Status, now string) ([]string, []interface{}) {
+	if status == old.Status {
+		return nil, nil
+	}
+	var clauses []string
+	var args []interface{}
+	if status == model.StatusInProgress && old.StartedAt.IsZero() {
+		clauses = append(clauses, "started_at = ?")
+		args = append(args, now)
+	}
+	switch {
+	case status == model.StatusDone:
+		clauses = append(clauses, "completed_at = ?")
+		args = append(args, now)
+	case old.Status == model.StatusDone:
+		clauses = append(clauses, "completed_at = NULL")
+	}
+	return clauses, args
+}
+
+// validateIssueUpdates checks the title, enum and parent values in updates for old,
+// and a status change against the configured transition map. Fields absent
+// from updates are not checked.
+func validateIssueUpdates(tx *sql.Tx, old *model.Issue, updates map[string]interface{}) error {
+	id := old.ID
+	if v, ok := updates["title"]; ok {
+		if err := model.ValidateTitle(fmt.Sprint(v)); err != nil {
+			return fmt.Errorf("%w: %v", ErrValidation, err)
+		}
+	}
+	if v, ok := updates["status"]; ok {
+		status := model.Status(fmt.Sprint(v))
+		if err := model.ValidateStatus(status); err != nil {
+			return fmt.Errorf("%w: %v", ErrValidation, err)
+		}
+		transitions, err := statusTransitions(tx)
+		if err != nil {
+			return err
+		}
+		if !transitions.Allows(old.Status, status) {
+			return fmt.Errorf("%w: status transition %s -> %s is not allowed (allowed from %s: %s)",
+				ErrValidation, old.Status, status, old.Status, formatStatuses(transitions[old.Status]))
+		}
+	}
+	if v, ok := updates["priority"]; ok {
+		if err := model.ValidatePriority(model.Priority(fmt.Sprint(v))); err != nil {
+			return fmt.Errorf("%w: %v", ErrValidation, err)
+		}
+	}
+	if v, ok := updates["kind"]; ok {
+		if err := model.ValidateIssueKind(model.IssueKind(fmt.Sprint(v))); err != nil {
+			return fmt.Errorf("%w: %v", ErrValidation, err)
+		}
+	}
+
+	v, ok := updates["parent_id"]
+	if !ok {
+		return nil
+	}
+	var parentID int
+	switch p := v.(type) {
+	case nil:
+		return nil
+	case int:
+		parentID = p
+	case *int:
+		if p == nil {
+			return nil
+		}
+		parentID = *p
+	default:
+		return fmt.Errorf("%w: parent_id must be an int, got %T", ErrValidation, v)
+	}
+
+	if parentID == id {
+		return fmt.Errorf("%w: cannot set parent to self", ErrValidation)
+	}
+	if _, err := getIssueTx(tx, parentID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("%w: parent issue %s not found", ErrValidation, model.FormatID(parentID))
+		}
+		return fmt.Errorf("checking parent issue: %w", err)
+	}
+	isCycle, err := isDescendant(tx, id, parentID)
+	if err != nil {
+		return err
+	}
+	if isCycle {
+		return fmt.Errorf("%w: cannot reparent %s under its descendant %s", ErrValidation, model.FormatID(id), model.FormatID(parentID))
+	}
+	return nil
+}
+
+// sameParent reports whether the parent_id update value v (nil, int or *int)
+// names the same parent as current.
+func sameParent(current *int, v interface{}) bool {
+	var next *int
+	switch p := v.(type) {
+	case int:
+		next = &p
+	case *int:
+		next = p
+	}
+	if current == nil || next == nil {
+		return current == nil && next == nil
+	}
+	return *current == *next
+}
+
+// formatStatuses joins statuses for error messages, or returns "none".
+func formatStatuses(statuses []model.Status) string {
+	if len(statuses) == 0 {
+		return "none"
+	}
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
 }
 
 // getIssueTx retrieves an issue by ID within a transaction.
 func getIssueTx(tx *sql.Tx, id int) (*model.Issue, error) {
 	row := tx.QueryRow(
-		`SELECT id, parent_id, title, description, status, priority, kind, assignee, created_at, updated_at
-		 FROM issues WHERE id = ?`, id,
+		`SELECT id, parent_id, title, description, status, priority, kind, assignee, milestone_id, created_at, updated_at, started_at, completed_at, snoozed_until, sort_order, pinned
+		 FROM issues WHERE id = ? AND deleted_at IS NULL`, id,
 	)
 	issue, err := scanIssueFrom(row)
 	if err != nil {
@@ -503,6 +870,11 @@ func getFieldValue(issue *model.Issue, field string) string {
 			return fmt.Sprintf("%d", *issue.ParentID)
 		}
 		return ""
+	case "milestone_id":
+		if issue.MilestoneID != nil {
+			return fmt.Sprintf("%d", *issue.MilestoneID)
+		}
+		return ""
 	default:
 		return ""
 	}
@@ -511,6 +883,10 @@ func getFieldValue(issue *model.Issue, field string) string {
 // DeleteIssue removes an issue by ID. Foreign key cascades handle cleanup of
 // related rows (comments, labels, activity, relations).
 func DeleteIssue(db *sql.DB, id int) error {
+	return WithRetry(func() error { return deleteIssue(db, id) })
+}
+
+func deleteIssue(db *sql.DB, id int) error {
 	res, err := db.Exec("DELETE FROM issues WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("deleting issue: %w", err)
@@ -527,11 +903,13 @@ func DeleteIssue(db *sql.DB, id int) error {
 	return nil
 }
 
-// GetSubIssues returns all direct children of an issue.
+// GetSubIssues returns all direct children of an issue, in their manual
+// order (see SetChildOrder) and then by creation time.
 func GetSubIssues(db *sql.DB, parentID int) ([]*model.Issue, error) {
 	rows, err := db.Query(
-		`SELECT id, parent_id, title, description, status, priority, kind, assignee, created_at, updated_at
-		 FROM issues WHERE parent_id = ? ORDER BY created_at ASC`, parentID,
+		`SELECT id, parent_id, title, description, status, priority, kind, assignee, milestone_id, created_at, updated_at, started_at, completed_at, snoozed_until, sort_order, pinned
+		 FROM issues WHERE parent_id = ? AND deleted_at IS NULL
+		 ORDER BY sort_order IS NULL, sort_order, created_at, id`, parentID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("querying sub-issues: %w", err)
@@ -549,17 +927,21 @@ func GetSubIssues(db *sql.DB, parentID int) ([]*model.Issue, error) {
 	return issues, rows.Err()
 }
 
-// GetSubIssueTree returns the full recursive tree of all descendants under an issue.
-func GetSubIssueTree(db *sql.DB, parentID int) ([]*model.Issue, error) {
+// GetSubIssueTree returns the recursive tree of descendants under an issue,
+// with siblings in their manual order and then by creation time. Direct
+// children are at depth 1; when maxDepth is positive, descendants deeper than
+// maxDepth are omitted.
+func GetSubIssueTree(db *sql.DB, parentID, maxDepth int) ([]*model.Issue, error) {
 	rows, err := db.Query(
-		`WITH RECURSIVE tree(id) AS (
-			SELECT id FROM issues WHERE parent_id = ?
+		`WITH RECURSIVE tree(id, depth) AS (
+			SELECT id, 1 FROM issues WHERE parent_id = ? AND deleted_at IS NULL
 			UNION ALL
-			SELECT i.id FROM issues i JOIN tree t ON i.parent_id = t.id
+			SELECT i.id, t.depth + 1 FROM issues i JOIN tree t ON i.parent_id = t.id
+			WHERE i.deleted_at IS NULL AND (? <= 0 OR t.depth < ?)
 		)
-		SELECT i.id, i.parent_id, i.title, i.description, i.status, i.priority, i.kind, i.assignee, i.created_at, i.updated_at
+		SELECT i.id, i.parent_id, i.title, i.description, i.status, i.priority, i.kind, i.assignee, i.milestone_id, i.created_at, i.updated_at, i.started_at, i.completed_at, i.snoozed_until, i.sort_order, i.pinned
 		FROM issues i JOIN tree t ON i.id = t.id
-		ORDER BY i.created_at ASC`, parentID,
+		ORDER BY i.sort_order IS NULL, i.sort_order, i.created_at ASC, i.id ASC`, parentID, maxDepth, maxDepth,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("querying sub-issue tree: %w", err)
@@ -582,9 +964,9 @@ func GetSubIssueProgress(db *sql.DB, parentID int) (int, int, error) {
 	var done, total int
 	err := db.QueryRow(
 		`WITH RECURSIVE tree(id) AS (
-			SELECT id FROM issues WHERE parent_id = ?
+			SELECT id FROM issues WHERE parent_id = ? AND deleted_at IS NULL
 			UNION ALL
-			SELECT i.id FROM issues i JOIN tree t ON i.parent_id = t.id
+			SELECT i.id FROM issues i JOIN tree t ON i.parent_id = t.id WHERE i.deleted_at IS NULL
 		)
 		SELECT
 			COALESCE(SUM(CASE WHEN i.status = 'done' THEN 1 ELSE 0 END), 0),
@@ -612,9 +994,9 @@ func GetBatchSubIssueProgress(conn *sql.DB, parentIDs []int) (map[int][2]int, er
 	}
 
 	query := `WITH RECURSIVE tree(id, root_parent_id) AS (
-		SELECT id, parent_id FROM issues WHERE parent_id IN (` + strings.Join(placeholders, ",") + `)
+		SELECT id, parent_id FROM issues WHERE parent_id IN (` + strings.Join(placeholders, ",") + `) AND deleted_at IS NULL
 		UNION ALL
-		SELECT i.id, t.root_parent_id FROM issues i JOIN tree t ON i.parent_id = t.id
+		SELECT i.id, t.root_parent_id FROM issues i JOIN tree t ON i.parent_id = t.id WHERE i.deleted_at IS NULL
 	)
 	SELECT
 		t.root_parent_id,
@@ -643,12 +1025,21 @@ func GetBatchSubIssueProgress(conn *sql.DB, parentIDs []int) (map[int][2]int, er
 // IsDescendant returns true if potentialDescendantID is a descendant of issueID.
 // This is used to detect cycles when reparenting an issue.
 func IsDescendant(db *sql.DB, issueID, potentialDescendantID int) (bool, error) {
+	return isDescendant(db, issueID, potentialDescendantID)
+}
+
+// queryRower abstracts *sql.DB and *sql.Tx for single-row queries.
+type queryRower interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+func isDescendant(q queryRower, issueID, potentialDescendantID int) (bool, error) {
 	var found bool
-	err := db.QueryRow(
+	err := q.QueryRow(
 		`WITH RECURSIVE tree(id) AS (
-			SELECT id FROM issues WHERE parent_id = ?
+			SELECT id FROM issues WHERE parent_id = ? AND deleted_at IS NULL
 			UNION ALL
-			SELECT i.id FROM issues i JOIN tree t ON i.parent_id = t.id
+			SELECT i.id FROM issues i JOIN tree t ON i.parent_id = t.id WHERE i.deleted_at IS NULL
 		)
 		SELECT EXISTS(SELECT 1 FROM tree WHERE id = ?)`, issueID, potentialDescendantID,
 	).Scan(&found)
@@ -661,12 +1052,24 @@ func IsDescendant(db *sql.DB, issueID, potentialDescendantID int) (bool, error)
 // OrphanSubIssues sets parent_id to NULL for all direct children of the given issue.
 // Activity is recorded for each affected child within a transaction.
 func OrphanSubIssues(db *sql.DB, parentID int, author string) error {
+	return WithRetry(func() error { return orphanSubIssues(db, parentID, author) })
+}
+
+func orphanSubIssues(db *sql.DB, parentID int, author string) error {
 	tx, err := db.Begin()
 	if err != nil {
 		return fmt.Errorf("beginning transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	if err := orphanSubIssuesTx(tx, parentID, author); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// orphanSubIssuesTx is OrphanSubIssues within an existing transaction.
+func orphanSubIssuesTx(tx *sql.Tx, parentID int, author string) error {
 	// Find all direct children before updating.
 	rows, err := tx.Query("SELECT id FROM issues WHERE parent_id = ?", parentID)
 	if err != nil {
@@ -702,7 +1105,7 @@ func OrphanSubIssues(db *sql.DB, parentID int, author string) error {
 		}
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 // CascadeDeleteIssue deletes an issue and all its descendants recursively
@@ -710,6 +1113,10 @@ func OrphanSubIssues(db *sql.DB, parentID int, author string) error {
 // ON DELETE CASCADE constraints on comments, issue_labels, issue_relations,
 // and activity_log handle cleanup of related rows automatically.
 func CascadeDeleteIssue(db *sql.DB, id int) error {
+	return WithRetry(func() error { return cascadeDeleteIssue(db, id) })
+}
+
+func cascadeDeleteIssue(db *sql.DB, id int) error {
 	tx, err := db.Begin()
 	if err != nil {
 		return fmt.Errorf("beginning transaction: %w", err)
@@ -744,18 +1151,20 @@ func CascadeDeleteIssue(db *sql.DB, id int) error {
 // --- helpers ---
 
 // scanIssueFrom scans a single issue from any scanner (*sql.Row or *sql.Rows).
-func scanIssueFrom(s scanner) (*model.Issue, error) {
+// Any extra destinations are scanned from columns following the issue's own.
+func scanIssueFrom(s scanner, extra ...any) (*model.Issue, error) {
 	var i model.Issue
-	var parentID sql.NullInt64
-	var description, assignee sql.NullString
+	var parentID, milestoneID, sortOrder sql.NullInt64
+	var description, assignee, startedAt, completedAt, snoozedUntil sql.NullString
 	var createdAt, updatedAt string
 
-	err := s.Scan(
+	dest := append([]any{
 		&i.ID, &parentID, &i.Title, &description,
 		&i.Status, &i.Priority, &i.Kind, &assignee,
-		&createdAt, &updatedAt,
-	)
-	if err != nil {
+		&milestoneID, &createdAt, &updatedAt, &startedAt, &completedAt, &snoozedUntil, &sortOrder,
+		&i.Pinned,
+	}, extra...)
+	if err := s.Scan(dest...); err != nil {
 		return nil, err
 	}
 
@@ -763,6 +1172,14 @@ func scanIssueFrom(s scanner) (*model.Issue, error) {
 		pid := int(parentID.Int64)
 		i.ParentID = &pid
 	}
+	if milestoneID.Valid {
+		mid := int(milestoneID.Int64)
+		i.MilestoneID = &mid
+	}
+	if sortOrder.Valid {
+		so := int(sortOrder.Int64)
+		i.SortOrder = &so
+	}
 	i.Description = description.String
 	i.Assignee = assignee.String
 
@@ -778,6 +1195,22 @@ func scanIssueFrom(s scanner) (*model.Issue, error) {
 	}
 	i.UpdatedAt = t
 
+	if startedAt.Valid {
+		if i.StartedAt, err = time.Parse(time.RFC3339, startedAt.String); err != nil {
+			return nil, fmt.Errorf("parsing started_at: %w", err)
+		}
+	}
+	if completedAt.Valid {
+		if i.CompletedAt, err = time.Parse(time.RFC3339, completedAt.String); err != nil {
+			return nil, fmt.Errorf("parsing completed_at: %w", err)
+		}
+	}
+	if snoozedUntil.Valid {
+		if i.SnoozedUntil, err = time.Parse(time.RFC3339, snoozedUntil.String); err != nil {
+			return nil, fmt.Errorf("parsing snoozed_until: %w", err)
+		}
+	}
+
 	return &i, nil
 }
 
@@ -834,6 +1267,15 @@ func nilIfZeroPtr(p *int) interface{} {
 	return *p
 }
 
+// nilIfZeroTime returns nil if t is zero, otherwise t formatted as RFC 3339
+// (for sql parameter binding).
+func nilIfZeroTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
 // makePlaceholders returns "?, ?, ..." with n placeholders.
 func makePlaceholders(n int) string {
 	if n <= 0 {
@@ -866,19 +1308,115 @@ func GetIssueLabels(db *sql.DB, issueID int) ([]string, error) {
 	return labels, rows.Err()
 }
 
-// ListAllIssues returns every issue in the database, including done issues,
+// ListAllIssues returns every issue outside the trash, including done issues,
 // with no filters, sorting, or pagination. Labels are hydrated on all results.
 func ListAllIssues(db *sql.DB) ([]*model.Issue, error) {
+	var issues []*model.Issue
+	err := StreamIssues(db, func(issue *model.Issue) error {
+		issues = append(issues, issue)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// streamBatchSize bounds how many issues StreamIssues holds in memory at once.
+const streamBatchSize = 500
+
+// StreamIssues calls fn for every issue outside the trash ordered by id ASC, with
+// labels and files hydrated. Issues are read in keyset-paginated batches so
+// memory stays flat regardless of database size, and no cursor is held open
+// while fn runs, so fn may query db. Returning an error from fn stops the
+// iteration and returns that error.
+func StreamIssues(db *sql.DB, fn func(*model.Issue) error) error {
+	lastID := 0
+	for {
+		rows, err := db.Query(
+			`SELECT id, parent_id, title, description, status, priority, kind, assignee, milestone_id, created_at, updated_at, started_at, completed_at, snoozed_until, sort_order, pinned
+			 FROM issues WHERE id > ? AND deleted_at IS NULL ORDER BY id ASC LIMIT ?`,
+			lastID, streamBatchSize,
+		)
+		if err != nil {
+			return fmt.Errorf("querying all issues: %w", err)
+		}
+
+		batch := make([]*model.Issue, 0, streamBatchSize)
+		for rows.Next() {
+			issue, err := scanIssueRow(rows)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			batch = append(batch, issue)
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return fmt.Errorf("iterating issue rows: %w", err)
+		}
+		rows.Close()
+
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := HydrateLabels(db, batch); err != nil {
+			return fmt.Errorf("hydrating labels: %w", err)
+		}
+
+		if err := HydrateFiles(db, batch); err != nil {
+			return fmt.Errorf("hydrating files: %w", err)
+		}
+
+		for _, issue := range batch {
+			if err := fn(issue); err != nil {
+				return err
+			}
+		}
+
+		if len(batch) < streamBatchSize {
+			return nil
+		}
+		lastID = batch[len(batch)-1].ID
+	}
+}
+
+// IssuesCreatedBetween returns the issues outside the trash created in
+// [since, until), oldest first.
+func IssuesCreatedBetween(db *sql.DB, since, until time.Time) ([]*model.Issue, error) {
+	return listIssuesBetween(db, "created_at", since, until)
+}
+
+// IssuesCompletedBetween returns the issues outside the trash completed in
+// [since, until), earliest completion first. Issues reopened since are not
+// included, because reopening clears completed_at.
+func IssuesCompletedBetween(db *sql.DB, since, until time.Time) ([]*model.Issue, error) {
+	return listIssuesBetween(db, "completed_at", since, until)
+}
+
+// IssuesDoneBetween returns the done issues outside the trash completed in
+// [since, until), earliest completion first. Unlike IssuesCompletedBetween,
+// issues without a completed_at, such as those completed before it was
+// recorded, count from their last status change to done in the activity
+// log, and that time is set as their CompletedAt.
+func IssuesDoneBetween(db *sql.DB, since, until time.Time) ([]*model.Issue, error) {
+	const doneAt = `COALESCE(completed_at, (
+		SELECT MAX(a.created_at) FROM activity_log a
+		WHERE a.issue_id = issues.id AND a.field_changed = 'status' AND a.new_value = 'done'))`
 	rows, err := db.Query(
-		`SELECT id, parent_id, title, description, status, priority, kind, assignee, created_at, updated_at
-		 FROM issues ORDER BY id ASC`,
+		`SELECT id, parent_id, title, description, status, priority, kind, assignee, milestone_id, created_at, updated_at, started_at, `+doneAt+`, snoozed_until, sort_order, pinned
+		 FROM issues
+		 WHERE deleted_at IS NULL AND status = 'done' AND `+doneAt+` >= ? AND `+doneAt+` < ?
+		 ORDER BY `+doneAt+` ASC, id ASC`,
+		since.UTC().Format(time.RFC3339), until.UTC().Format(time.RFC3339),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("querying all issues: %w", err)
+		return nil, fmt.Errorf("querying done issues: %w", err)
 	}
 	defer rows.Close()
 
-	var issues []*model.Issue
+	issues := make([]*model.Issue, 0)
 	for rows.Next() {
 		issue, err := scanIssueRow(rows)
 		if err != nil {
@@ -889,31 +1427,52 @@ func ListAllIssues(db *sql.DB) ([]*model.Issue, error) {
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterating issue rows: %w", err)
 	}
+	return issues, nil
+}
 
-	if err := HydrateLabels(db, issues); err != nil {
-		return nil, fmt.Errorf("hydrating labels: %w", err)
+// listIssuesBetween returns the issues outside the trash whose timestamp
+// column falls in [since, until), ordered by that column then by ID.
+func listIssuesBetween(db *sql.DB, column string, since, until time.Time) ([]*model.Issue, error) {
+	rows, err := db.Query(
+		`SELECT id, parent_id, title, description, status, priority, kind, assignee, milestone_id, created_at, updated_at, started_at, completed_at, snoozed_until, sort_order, pinned
+		 FROM issues
+		 WHERE deleted_at IS NULL AND `+column+` >= ? AND `+column+` < ?
+		 ORDER BY `+column+` ASC, id ASC`,
+		since.UTC().Format(time.RFC3339), until.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying issues by %s: %w", column, err)
 	}
+	defer rows.Close()
 
-	if err := HydrateFiles(db, issues); err != nil {
-		return nil, fmt.Errorf("hydrating files: %w", err)
+	issues := make([]*model.Issue, 0)
+	for rows.Next() {
+		issue, err := scanIssueRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, issue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating issue rows: %w", err)
 	}
-
 	return issues, nil
 }
 
-// CountIssues returns the total number of issues in the database.
+// CountIssues returns the total number of issues outside the trash.
 func CountIssues(db *sql.DB) (int, error) {
 	var count int
-	if err := db.QueryRow(`SELECT COUNT(*) FROM issues`).Scan(&count); err != nil {
+	if err := db.QueryRow(`SELECT COUNT(*) FROM issues WHERE deleted_at IS NULL`).Scan(&count); err != nil {
 		return 0, fmt.Errorf("counting issues: %w", err)
 	}
 	return count, nil
 }
 
-// CountRootIssues returns the number of issues with no parent.
+// CountRootIssues returns the number of issues outside the trash with no
+// parent.
 func CountRootIssues(db *sql.DB) (int, error) {
 	var count int
-	if err := db.QueryRow(`SELECT COUNT(*) FROM issues WHERE parent_id IS NULL`).Scan(&count); err != nil {
+	if err := db.QueryRow(`SELECT COUNT(*) FROM issues WHERE parent_id IS NULL AND deleted_at IS NULL`).Scan(&count); err != nil {
 		return 0, fmt.Errorf("counting root issues: %w", err)
 	}
 	return count, nil
@@ -921,7 +1480,7 @@ func CountRootIssues(db *sql.DB) (int, error) {
 
 // countByColumn returns a map of value -> count for the given column grouped by that column.
 func countByColumn(db *sql.DB, column string) (map[string]int, error) {
-	rows, err := db.Query(fmt.Sprintf(`SELECT %s, COUNT(*) FROM issues GROUP BY %s`, column, column))
+	rows, err := db.Query(fmt.Sprintf(`SELECT %s, COUNT(*) FROM issues WHERE deleted_at IS NULL GROUP BY %s`, column, column))
 	if err != nil {
 		return nil, fmt.Errorf("counting by %s: %w", column, err)
 	}
@@ -961,6 +1520,10 @@ func CountByPriority(db *sql.DB) (map[string]int, error) {
 // included; prior to v4 the latter three were silently omitted, which broke
 // `--replace` import on any DB containing proposals (TDD §5.4 S4 / R7).
 func ClearAllData(db *sql.DB) error {
+	return WithRetry(func() error { return clearAllData(db) })
+}
+
+func clearAllData(db *sql.DB) error {
 	tx, err := db.Begin()
 	if err != nil {
 		return fmt.Errorf("beginning transaction: %w", err)
@@ -976,6 +1539,7 @@ func ClearAllData(db *sql.DB) error {
 
 func ClearAllDataTx(tx *sql.Tx) error {
 	tables := []string{
+		"notifications",
 		"doc_comments",
 		"doc_revisions",
 		"proposal_docs",
@@ -986,10 +1550,12 @@ func ClearAllDataTx(tx *sql.Tx) error {
 		"proposals",
 		"activity_log",
 		"issue_relations",
+		"issue_links",
 		"issue_files",
 		"issue_labels",
 		"comments",
 		"issues",
+		"milestones",
 		"labels",
 	}
 	for _, table := range tables {
@@ -1001,6 +1567,11 @@ func ClearAllDataTx(tx *sql.Tx) error {
 		}
 	}
 
+	// The built-in relation types are part of the schema, not data.
+	if _, err := tx.Exec("DELETE FROM relation_types WHERE builtin = 0"); err != nil && !strings.Contains(err.Error(), "no such table") {
+		return fmt.Errorf("clearing relation_types: %w", err)
+	}
+
 	return nil
 }
 
@@ -1009,8 +1580,8 @@ func ClearAllDataTx(tx *sql.Tx) error {
 // Must be called within an existing transaction.
 func InsertIssueWithID(tx *sql.Tx, issue *model.Issue) (bool, error) {
 	res, err := tx.Exec(
-		`INSERT OR IGNORE INTO issues (id, parent_id, title, description, status, priority, kind, assignee, created_at, updated_at)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		`INSERT OR IGNORE INTO issues (id, parent_id, title, description, status, priority, kind, assignee, milestone_id, created_at, updated_at, started_at, completed_at, snoozed_until, sort_order, pinned)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		issue.ID,
 		nilIfZeroPtr(issue.ParentID),
 		issue.Title,
@@ -1019,8 +1590,14 @@ func InsertIssueWithID(tx *sql.Tx, issue *model.Issue) (bool, error) {
 		string(issue.Priority),
 		string(issue.Kind),
 		issue.Assignee,
+		nilIfZeroPtr(issue.MilestoneID),
 		issue.CreatedAt.UTC().Format(time.RFC3339),
 		issue.UpdatedAt.UTC().Format(time.RFC3339),
+		nilIfZeroTime(issue.StartedAt),
+		nilIfZeroTime(issue.CompletedAt),
+		nilIfZeroTime(issue.SnoozedUntil),
+		nilIfZeroPtr(issue.SortOrder),
+		issue.Pinned,
 	)
 	if err != nil {
 		return false, fmt.Errorf("inserting issue with id %d: %w", issue.ID, err)
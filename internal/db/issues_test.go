@@ -2,6 +2,9 @@ package db
 
 import (
 	"database/sql"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -138,11 +141,11 @@ func TestListIssuesExplicitSortOverridesDefault(t *testing.T) {
 	createTestIssue(t, db, "ip-low", model.StatusInProgress, model.PriorityLow)
 	createTestIssue(t, db, "todo-crit", model.StatusTodo, model.PriorityCritical)
 
-	// Explicit sort by priority ascending should put "low" before "critical",
-	// regardless of the default status-first ordering.
+	// Explicit sort by priority descending should put "critical" before
+	// "low", regardless of the default status-first ordering.
 	issues, _, err := ListIssues(db, ListOptions{
 		Sort:    "priority",
-		SortDir: "asc",
+		SortDir: "desc",
 	})
 	if err != nil {
 		t.Fatalf("ListIssues: %v", err)
@@ -150,13 +153,11 @@ func TestListIssuesExplicitSortOverridesDefault(t *testing.T) {
 	if len(issues) != 2 {
 		t.Fatalf("len = %d, want 2", len(issues))
 	}
-	// SQLite sorts "critical" < "low" alphabetically in ASC, so priority:asc
-	// yields critical first, low second.
 	if issues[0].Priority != model.PriorityCritical {
-		t.Errorf("explicit sort priority:asc — issues[0].Priority = %q, want critical", issues[0].Priority)
+		t.Errorf("explicit sort priority:desc — issues[0].Priority = %q, want critical", issues[0].Priority)
 	}
 	if issues[1].Priority != model.PriorityLow {
-		t.Errorf("explicit sort priority:asc — issues[1].Priority = %q, want low", issues[1].Priority)
+		t.Errorf("explicit sort priority:desc — issues[1].Priority = %q, want low", issues[1].Priority)
 	}
 }
 
@@ -280,3 +281,406 @@ func TestListIssues_ParentFetchingPattern(t *testing.T) {
 		t.Errorf("parent.Status = %q, want %q", parent.Status, model.StatusInProgress)
 	}
 }
+
+func TestListIssues_HasChildren(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	// epic -> story -> task, plus a standalone issue.
+	epic := createTestIssue(t, db, "epic", model.StatusTodo, model.PriorityHigh)
+	story := createTestIssueWithParent(t, db, "story", model.StatusTodo, model.PriorityMedium, epic)
+	task := createTestIssueWithParent(t, db, "task", model.StatusTodo, model.PriorityLow, story)
+	standalone := createTestIssue(t, db, "standalone", model.StatusTodo, model.PriorityLow)
+
+	idSet := func(issues []*model.Issue) map[int]bool {
+		ids := make(map[int]bool, len(issues))
+		for _, iss := range issues {
+			ids[iss.ID] = true
+		}
+		return ids
+	}
+
+	yes, no := true, false
+	tests := []struct {
+		name        string
+		hasChildren *bool
+		want        []int
+	}{
+		{"parents", &yes, []int{epic, story}},
+		{"leaves", &no, []int{task, standalone}},
+		{"unset", nil, []int{epic, story, task, standalone}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, total, err := ListIssues(db, ListOptions{HasChildren: tt.hasChildren})
+			if err != nil {
+				t.Fatalf("ListIssues: %v", err)
+			}
+			if total != len(tt.want) || len(issues) != len(tt.want) {
+				t.Fatalf("total = %d, len = %d, want %d", total, len(issues), len(tt.want))
+			}
+			got := idSet(issues)
+			for _, id := range tt.want {
+				if !got[id] {
+					t.Errorf("issue %d missing from results", id)
+				}
+			}
+		})
+	}
+
+	// Composes with other filters: roots that have children is just the epic.
+	issues, total, err := ListIssues(db, ListOptions{HasChildren: &yes, RootsOnly: true})
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	if total != 1 || len(issues) != 1 || issues[0].ID != epic {
+		t.Errorf("roots with children = %v (total %d), want only %d", idSet(issues), total, epic)
+	}
+}
+
+func TestListIssues_HasFiles(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	code := createTestIssue(t, db, "code", model.StatusTodo, model.PriorityHigh)
+	moreCode := createTestIssue(t, db, "more code", model.StatusInProgress, model.PriorityLow)
+	talk := createTestIssue(t, db, "discussion", model.StatusTodo, model.PriorityLow)
+	moreTalk := createTestIssue(t, db, "more discussion", model.StatusInProgress, model.PriorityHigh)
+	if err := AttachFiles(db, code, []string{"main.go", "go.mod"}, ""); err != nil {
+		t.Fatalf("AttachFiles: %v", err)
+	}
+	if err := AttachFiles(db, moreCode, []string{"README.md"}, ""); err != nil {
+		t.Fatalf("AttachFiles: %v", err)
+	}
+
+	yes, no := true, false
+	tests := []struct {
+		name string
+		opts ListOptions
+		want []int
+	}{
+		{"with files", ListOptions{HasFiles: &yes}, []int{code, moreCode}},
+		{"without files", ListOptions{HasFiles: &no}, []int{talk, moreTalk}},
+		{"unset", ListOptions{}, []int{code, moreCode, talk, moreTalk}},
+		{"with files and status", ListOptions{HasFiles: &yes, Statuses: []string{"todo"}}, []int{code}},
+		{"without files and priority", ListOptions{HasFiles: &no, Priorities: []string{"high"}}, []int{moreTalk}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, total, err := ListIssues(db, tt.opts)
+			if err != nil {
+				t.Fatalf("ListIssues: %v", err)
+			}
+			if total != len(tt.want) || len(issues) != len(tt.want) {
+				t.Fatalf("total = %d, len = %d, want %d", total, len(issues), len(tt.want))
+			}
+			got := make(map[int]bool, len(issues))
+			for _, iss := range issues {
+				got[iss.ID] = true
+			}
+			for _, id := range tt.want {
+				if !got[id] {
+					t.Errorf("issue %d missing from results", id)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateIssueValidatesTitle(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	for _, title := range []string{"", strings.Repeat("a", model.DefaultMaxTitleLength+1)} {
+		_, err := CreateIssue(db, &model.Issue{Title: title, Status: model.StatusTodo, Priority: model.PriorityNone, Kind: model.IssueKindTask}, nil, nil)
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("CreateIssue(%d-character title) error = %v, want ErrValidation", len(title), err)
+		}
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM issues`).Scan(&count); err != nil {
+		t.Fatalf("counting issues: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("issues = %d after rejected creates, want 0", count)
+	}
+
+	if _, err := CreateIssue(db, &model.Issue{Title: "Fix login redirect", Status: model.StatusTodo, Priority: model.PriorityNone, Kind: model.IssueKindTask}, nil, nil); err != nil {
+		t.Errorf("CreateIssue: %v", err)
+	}
+}
+
+func TestUpdateIssueRejectsInvalidValues(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	root := mustCreateIssue(t, db, "root")
+	child := createTestIssueWithParent(t, db, "child", model.StatusTodo, model.PriorityLow, root)
+	other := mustCreateIssue(t, db, "other")
+
+	tests := []struct {
+		name    string
+		updates map[string]interface{}
+	}{
+		{"empty title", map[string]interface{}{"title": " "}},
+		{"long title", map[string]interface{}{"title": strings.Repeat("a", model.DefaultMaxTitleLength+1)}},
+		{"status", map[string]interface{}{"status": "in_progress"}},
+		{"priority", map[string]interface{}{"priority": "urgent"}},
+		{"kind", map[string]interface{}{"kind": "story"}},
+		{"parent self", map[string]interface{}{"parent_id": root}},
+		{"parent missing", map[string]interface{}{"parent_id": 999}},
+		{"parent cycle", map[string]interface{}{"parent_id": child}},
+		{"valid field with invalid one", map[string]interface{}{"title": "renamed", "status": "open"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := UpdateIssue(db, root, tt.updates, "tester")
+			if !errors.Is(err, ErrValidation) {
+				t.Fatalf("UpdateIssue(%v) error = %v, want ErrValidation", tt.updates, err)
+			}
+		})
+	}
+
+	got, err := GetIssue(db, root)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if got.Title != "root" || got.Status != model.StatusBacklog || got.ParentID != nil {
+		t.Errorf("issue changed by rejected updates: %+v", got)
+	}
+
+	// Valid values, including clearing and setting a parent, still apply.
+	if err := UpdateIssue(db, root, map[string]interface{}{"status": "in-progress", "parent_id": other}, "tester"); err != nil {
+		t.Fatalf("valid UpdateIssue: %v", err)
+	}
+	if err := UpdateIssue(db, child, map[string]interface{}{"parent_id": nil}, "tester"); err != nil {
+		t.Fatalf("clearing parent: %v", err)
+	}
+	got, err = GetIssue(db, root)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if got.Status != model.StatusInProgress || got.ParentID == nil || *got.ParentID != other {
+		t.Errorf("valid update not applied: status=%s parent=%v", got.Status, got.ParentID)
+	}
+}
+
+func TestUpdateIssue_StatusTimestamps(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	id := createTestIssue(t, db, "ship it", model.StatusTodo, model.PriorityHigh)
+
+	setStatus := func(s model.Status) *model.Issue {
+		t.Helper()
+		if err := UpdateIssue(db, id, map[string]interface{}{"status": string(s)}, "amy"); err != nil {
+			t.Fatalf("UpdateIssue(%s): %v", s, err)
+		}
+		issue, err := GetIssue(db, id)
+		if err != nil {
+			t.Fatalf("GetIssue: %v", err)
+		}
+		return issue
+	}
+
+	issue := setStatus(model.StatusInProgress)
+	if issue.StartedAt.IsZero() || !issue.CompletedAt.IsZero() {
+		t.Fatalf("after in-progress: started %v, completed %v", issue.StartedAt, issue.CompletedAt)
+	}
+
+	// Force a distinguishable started_at so a second move to in-progress
+	// would be visible.
+	if _, err := db.Exec(`UPDATE issues SET started_at = '2024-01-01T00:00:00Z' WHERE id = ?`, id); err != nil {
+		t.Fatalf("backdating started_at: %v", err)
+	}
+	started := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	issue = setStatus(model.StatusDone)
+	if issue.CompletedAt.IsZero() || !issue.StartedAt.Equal(started) {
+		t.Fatalf("after done: started %v, completed %v", issue.StartedAt, issue.CompletedAt)
+	}
+
+	issue = setStatus(model.StatusInProgress)
+	if !issue.CompletedAt.IsZero() {
+		t.Errorf("reopening kept completed_at %v", issue.CompletedAt)
+	}
+	if !issue.StartedAt.Equal(started) {
+		t.Errorf("second start moved started_at to %v, want %v", issue.StartedAt, started)
+	}
+}
+
+func TestCreateIssue_StatusTimestamps(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	for _, tt := range []struct {
+		status             model.Status
+		started, completed bool
+	}{
+		{model.StatusTodo, false, false},
+		{model.StatusInProgress, true, false},
+		{model.StatusDone, false, true},
+	} {
+		issue, err := GetIssue(db, createTestIssue(t, db, string(tt.status), tt.status, model.PriorityNone))
+		if err != nil {
+			t.Fatalf("GetIssue: %v", err)
+		}
+		if !issue.StartedAt.IsZero() != tt.started || !issue.CompletedAt.IsZero() != tt.completed {
+			t.Errorf("created %s: started %v, completed %v", tt.status, issue.StartedAt, issue.CompletedAt)
+		}
+	}
+}
+
+func TestListIssues_Completed(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	old := createTestIssue(t, db, "old", model.StatusDone, model.PriorityNone)
+	recent := createTestIssue(t, db, "recent", model.StatusDone, model.PriorityNone)
+	createTestIssue(t, db, "open", model.StatusTodo, model.PriorityNone)
+	if _, err := db.Exec(`UPDATE issues SET completed_at = '2024-01-01T00:00:00Z' WHERE id = ?`, old); err != nil {
+		t.Fatalf("backdating completed_at: %v", err)
+	}
+
+	weekAgo := time.Now().Add(-7 * 24 * time.Hour)
+	tests := []struct {
+		name string
+		opts ListOptions
+		want []int
+	}{
+		{"since", ListOptions{CompletedSince: weekAgo}, []int{recent}},
+		{"before", ListOptions{CompletedBefore: weekAgo}, []int{old}},
+		{"sorted", ListOptions{CompletedSince: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), Sort: "completed_at", SortDir: "asc"}, []int{old, recent}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, total, err := ListIssues(db, tt.opts)
+			if err != nil {
+				t.Fatalf("ListIssues: %v", err)
+			}
+			got := make([]int, len(issues))
+			for i, iss := range issues {
+				got[i] = iss.ID
+			}
+			if total != len(tt.want) || !slices.Equal(got, tt.want) {
+				t.Errorf("got %v (total %d), want %v", got, total, tt.want)
+			}
+		})
+	}
+}
+
+func TestListIssues_UpdatedSince(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	since := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	stale := createTestIssue(t, db, "stale", model.StatusTodo, model.PriorityNone)
+	boundary := createTestIssue(t, db, "boundary", model.StatusTodo, model.PriorityNone)
+	newest := createTestIssue(t, db, "newest", model.StatusDone, model.PriorityNone)
+	middle := createTestIssue(t, db, "middle", model.StatusInProgress, model.PriorityNone)
+	for id, updated := range map[int]time.Time{
+		stale:    since.Add(-time.Second),
+		boundary: since,
+		newest:   since.Add(2 * time.Hour),
+		middle:   since.Add(time.Hour),
+	} {
+		if _, err := db.Exec(`UPDATE issues SET updated_at = ? WHERE id = ?`, updated.Format(time.RFC3339), id); err != nil {
+			t.Fatalf("setting updated_at: %v", err)
+		}
+	}
+
+	issues, total, err := ListIssues(db, ListOptions{
+		IncludeDone:  true,
+		UpdatedSince: since,
+		SortKeys:     []SortKey{{Field: "updated_at", Dir: "desc"}},
+	})
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	got := make([]int, len(issues))
+	for i, iss := range issues {
+		got[i] = iss.ID
+	}
+	if want := []int{newest, middle, boundary}; total != len(want) || !slices.Equal(got, want) {
+		t.Errorf("got %v (total %d), want %v", got, total, want)
+	}
+}
+
+func TestListIssues_CountOnly(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	createTestIssue(t, db, "a", model.StatusTodo, model.PriorityCritical)
+	createTestIssue(t, db, "b", model.StatusTodo, model.PriorityCritical)
+	createTestIssue(t, db, "c", model.StatusTodo, model.PriorityLow)
+	createTestIssue(t, db, "d", model.StatusDone, model.PriorityCritical)
+
+	issues, total, err := ListIssues(db, ListOptions{Priorities: []string{"critical"}, Limit: 1, CountOnly: true})
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	if issues != nil || total != 2 {
+		t.Errorf("got %d issues (total %d), want none and a total of 2", len(issues), total)
+	}
+}
+
+func TestIssuesDoneBetween(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	recent := createTestIssue(t, db, "recent", model.StatusDone, model.PriorityNone)
+	legacy := createTestIssue(t, db, "legacy", model.StatusTodo, model.PriorityNone)
+	open := createTestIssue(t, db, "open", model.StatusTodo, model.PriorityNone)
+	old := createTestIssue(t, db, "old", model.StatusDone, model.PriorityNone)
+
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 1, 0)
+	inWindow := since.Add(48 * time.Hour).Format(time.RFC3339)
+	for _, stmt := range []struct {
+		query string
+		args  []any
+	}{
+		{`UPDATE issues SET completed_at = ? WHERE id = ?`, []any{since.Add(72 * time.Hour).Format(time.RFC3339), recent}},
+		{`UPDATE issues SET completed_at = ? WHERE id = ?`, []any{since.AddDate(0, 0, -1).Format(time.RFC3339), old}},
+		// Done before completed_at was recorded: only the activity log knows when.
+		{`UPDATE issues SET status = 'done', completed_at = NULL WHERE id = ?`, []any{legacy}},
+		{`INSERT INTO activity_log (issue_id, field_changed, old_value, new_value, created_at) VALUES (?, 'status', 'todo', 'done', ?)`, []any{legacy, inWindow}},
+		// A status change to done on an issue since reopened does not count.
+		{`INSERT INTO activity_log (issue_id, field_changed, old_value, new_value, created_at) VALUES (?, 'status', 'todo', 'done', ?)`, []any{open, inWindow}},
+	} {
+		if _, err := db.Exec(stmt.query, stmt.args...); err != nil {
+			t.Fatalf("%s: %v", stmt.query, err)
+		}
+	}
+
+	issues, err := IssuesDoneBetween(db, since, until)
+	if err != nil {
+		t.Fatalf("IssuesDoneBetween: %v", err)
+	}
+	var ids []int
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	if want := []int{legacy, recent}; !slices.Equal(ids, want) {
+		t.Fatalf("IssuesDoneBetween = %v, want %v", ids, want)
+	}
+	if got := issues[0].CompletedAt.Format(time.RFC3339); got != inWindow {
+		t.Errorf("legacy CompletedAt = %s, want %s from the activity log", got, inWindow)
+	}
+}
@@ -16,38 +16,41 @@ var ErrNotAttached = errors.New("label not attached")
 // an existing label already has.
 var ErrLabelColorConflict = errors.New("label color conflict")
 
+// LabelColorConflict describes a label whose existing color was kept because
+// it differed from the requested one.
+type LabelColorConflict struct {
+	Label          string
+	ExistingColor  string
+	RequestedColor string
+}
+
 // GetLabelByName retrieves a label by its unique name, including the count of
 // issues currently attached to it. Returns ErrNotFound if no label with that
 // name exists.
 func GetLabelByName(db *sql.DB, name string) (*model.LabelWithCount, error) {
-	var lc model.LabelWithCount
-	var color sql.NullString
-
-	err := db.QueryRow(
-		`SELECT l.id, l.name, l.color, COUNT(il.issue_id) AS issue_count
+	lc, err := scanLabelWithCount(db.QueryRow(
+		`SELECT l.id, l.name, l.color, l.last_used_at, COUNT(il.issue_id) AS issue_count
 		 FROM labels l
-		 LEFT JOIN issue_labels il ON il.label_id = l.id
+		 LEFT JOIN issue_labels il ON il.label_id = l.id AND il.issue_id IN `+liveIssueIDs+`
 		 WHERE l.name = ?
 		 GROUP BY l.id`, name,
-	).Scan(&lc.ID, &lc.Name, &color, &lc.IssueCount)
+	))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
 		}
 		return nil, fmt.Errorf("querying label: %w", err)
 	}
-
-	lc.Color = color.String
-	return &lc, nil
+	return lc, nil
 }
 
-// ListAllLabels returns every label along with the count of issues using it,
-// sorted alphabetically by name.
+// ListAllLabels returns every label along with the count of issues using it
+// and when it was last used, sorted alphabetically by name.
 func ListAllLabels(db *sql.DB) ([]*model.LabelWithCount, error) {
 	rows, err := db.Query(
-		`SELECT l.id, l.name, l.color, COUNT(il.issue_id) AS issue_count
+		`SELECT l.id, l.name, l.color, l.last_used_at, COUNT(il.issue_id) AS issue_count
 		 FROM labels l
-		 LEFT JOIN issue_labels il ON il.label_id = l.id
+		 LEFT JOIN issue_labels il ON il.label_id = l.id AND il.issue_id IN ` + liveIssueIDs + `
 		 GROUP BY l.id
 		 ORDER BY l.name`,
 	)
@@ -58,13 +61,11 @@ func ListAllLabels(db *sql.DB) ([]*model.LabelWithCount, error) {
 
 	var labels []*model.LabelWithCount
 	for rows.Next() {
-		var lc model.LabelWithCount
-		var color sql.NullString
-		if err := rows.Scan(&lc.ID, &lc.Name, &color, &lc.IssueCount); err != nil {
+		lc, err := scanLabelWithCount(rows)
+		if err != nil {
 			return nil, fmt.Errorf("scanning label: %w", err)
 		}
-		lc.Color = color.String
-		labels = append(labels, &lc)
+		labels = append(labels, lc)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterating label rows: %w", err)
@@ -73,58 +74,116 @@ func ListAllLabels(db *sql.DB) ([]*model.LabelWithCount, error) {
 	return labels, nil
 }
 
+// scanLabelWithCount scans a label row selected as id, name, color,
+// last_used_at and issue count.
+func scanLabelWithCount(s scanner) (*model.LabelWithCount, error) {
+	var lc model.LabelWithCount
+	var color, lastUsed sql.NullString
+	if err := s.Scan(&lc.ID, &lc.Name, &color, &lastUsed, &lc.IssueCount); err != nil {
+		return nil, err
+	}
+	lc.Color = color.String
+	if lastUsed.Valid {
+		t, err := time.Parse(time.RFC3339, lastUsed.String)
+		if err != nil {
+			return nil, fmt.Errorf("parsing last_used_at: %w", err)
+		}
+		lc.LastUsedAt = &t
+	}
+	return &lc, nil
+}
+
+// touchLabel records now as the time labelID was last attached to or
+// detached from an issue.
+func touchLabel(tx *sql.Tx, labelID int, now string) error {
+	if _, err := tx.Exec(`UPDATE labels SET last_used_at = ? WHERE id = ?`, now, labelID); err != nil {
+		return fmt.Errorf("updating label last use: %w", err)
+	}
+	return nil
+}
+
 // ListAllLabelsRaw returns every label as a model.Label object (without issue
 // counts), sorted alphabetically by name.
 func ListAllLabelsRaw(db *sql.DB) ([]*model.Label, error) {
+	var labels []*model.Label
+	err := StreamLabels(db, func(v *model.Label) error {
+		labels = append(labels, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// StreamLabels calls fn for every label row in the same order as
+// ListAllLabelsRaw without buffering the result set. fn runs while the cursor
+// is open and must not query db.
+func StreamLabels(db *sql.DB, fn func(*model.Label) error) error {
 	rows, err := db.Query(
 		`SELECT id, name, color FROM labels ORDER BY name`,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("querying all labels: %w", err)
+		return fmt.Errorf("querying all labels: %w", err)
 	}
 	defer rows.Close()
 
-	var labels []*model.Label
 	for rows.Next() {
 		var l model.Label
 		var color sql.NullString
 		if err := rows.Scan(&l.ID, &l.Name, &color); err != nil {
-			return nil, fmt.Errorf("scanning label: %w", err)
+			return fmt.Errorf("scanning label: %w", err)
 		}
 		l.Color = color.String
-		labels = append(labels, &l)
+		if err := fn(&l); err != nil {
+			return err
+		}
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating label rows: %w", err)
+		return fmt.Errorf("iterating label rows: %w", err)
 	}
-
-	return labels, nil
+	return nil
 }
 
 // ListAllIssueLabelMappings returns all (issue_id, label_id) pairs from the
 // issue_labels table.
 func ListAllIssueLabelMappings(db *sql.DB) ([]model.IssueLabelMapping, error) {
+	var mappings []model.IssueLabelMapping
+	err := StreamIssueLabelMappings(db, func(v model.IssueLabelMapping) error {
+		mappings = append(mappings, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mappings, nil
+}
+
+// StreamIssueLabelMappings calls fn for every issue-label mapping row in the
+// same order as ListAllIssueLabelMappings without buffering the result set. fn
+// runs while the cursor is open and must not query db.
+func StreamIssueLabelMappings(db *sql.DB, fn func(model.IssueLabelMapping) error) error {
 	rows, err := db.Query(
-		`SELECT issue_id, label_id FROM issue_labels ORDER BY issue_id, label_id`,
+		`SELECT issue_id, label_id FROM issue_labels WHERE issue_id IN ` + liveIssueIDs + ` ORDER BY issue_id, label_id`,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("querying issue-label mappings: %w", err)
+		return fmt.Errorf("querying issue-label mappings: %w", err)
 	}
 	defer rows.Close()
 
-	var mappings []model.IssueLabelMapping
 	for rows.Next() {
 		var m model.IssueLabelMapping
 		if err := rows.Scan(&m.IssueID, &m.LabelID); err != nil {
-			return nil, fmt.Errorf("scanning issue-label mapping: %w", err)
+			return fmt.Errorf("scanning issue-label mapping: %w", err)
+		}
+		if err := fn(m); err != nil {
+			return err
 		}
-		mappings = append(mappings, m)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating issue-label mappings: %w", err)
+		return fmt.Errorf("iterating issue-label mappings: %w", err)
 	}
-
-	return mappings, nil
+	return nil
 }
 
 // InsertLabelWithID inserts a label with a specific ID (not auto-increment),
@@ -149,8 +208,9 @@ func InsertLabelWithID(tx *sql.Tx, label *model.Label) (bool, error) {
 }
 
 // InsertIssueLabelMapping inserts an issue_labels row linking an issue to a label,
-// skipping if the mapping already exists. Returns true if the row was inserted.
-// Must be called within an existing transaction.
+// skipping if the mapping already exists, and marks the label used now when
+// it inserts one. Returns true if the row was inserted. Must be called within
+// an existing transaction.
 func InsertIssueLabelMapping(tx *sql.Tx, issueID, labelID int) (bool, error) {
 	res, err := tx.Exec(
 		`INSERT OR IGNORE INTO issue_labels (issue_id, label_id) VALUES (?, ?)`,
@@ -160,13 +220,33 @@ func InsertIssueLabelMapping(tx *sql.Tx, issueID, labelID int) (bool, error) {
 		return false, fmt.Errorf("inserting issue-label mapping (issue=%d, label=%d): %w", issueID, labelID, err)
 	}
 	n, _ := res.RowsAffected()
-	return n > 0, nil
+	if n == 0 {
+		return false, nil
+	}
+	if err := touchLabel(tx, labelID, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// EnsureLabelTx returns the ID of the label named name, creating it without a
+// color if it does not exist. Must be called within an existing transaction.
+func EnsureLabelTx(tx *sql.Tx, name string) (int, error) {
+	id, err := findOrCreateLabel(tx, name)
+	if err != nil {
+		return 0, fmt.Errorf("label %q: %w", name, err)
+	}
+	return id, nil
 }
 
 // DeleteLabel removes a label by ID. CASCADE constraints handle cleanup of
 // issue_labels rows. Activity is recorded for each affected issue using the
 // provided name. Returns the list of issue IDs that were attached to the label.
 func DeleteLabel(db *sql.DB, labelID int, name, author string) ([]int, error) {
+	return withRetryValue(func() ([]int, error) { return deleteLabel(db, labelID, name, author) })
+}
+
+func deleteLabel(db *sql.DB, labelID int, name, author string) ([]int, error) {
 	tx, err := db.Begin()
 	if err != nil {
 		return nil, fmt.Errorf("beginning transaction: %w", err)
@@ -227,28 +307,61 @@ func AddLabelToIssue(db *sql.DB, issueID int, labelName, color string, author st
 // single transaction. Labels are created if they do not already exist (with the
 // given color). Activity is recorded for each newly attached label and the
 // issue's updated_at timestamp is touched once.
+// It returns ErrLabelColorConflict if color differs from an existing label's
+// color; use AddLabelsToIssueKeepingColors to attach such labels anyway.
 func AddLabelsToIssue(db *sql.DB, issueID int, labelNames []string, color string, author string) error {
+	return WithRetry(func() error {
+		_, err := addLabelsToIssue(db, issueID, labelNames, color, author, false)
+		return err
+	})
+}
+
+// AddLabelsToIssueKeepingColors is like AddLabelsToIssue but does not fail
+// when color differs from an existing label's color. Such labels keep their
+// existing color and are still attached; each one is reported in the returned
+// conflicts so the caller can warn about it.
+func AddLabelsToIssueKeepingColors(db *sql.DB, issueID int, labelNames []string, color string, author string) ([]LabelColorConflict, error) {
+	return withRetryValue(func() ([]LabelColorConflict, error) {
+		return addLabelsToIssue(db, issueID, labelNames, color, author, true)
+	})
+}
+
+func addLabelsToIssue(db *sql.DB, issueID int, labelNames []string, color string, author string, keepColors bool) ([]LabelColorConflict, error) {
 	tx, err := db.Begin()
 	if err != nil {
-		return fmt.Errorf("beginning transaction: %w", err)
+		return nil, fmt.Errorf("beginning transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	conflicts, err := addLabelsToIssueTx(tx, issueID, labelNames, color, author, keepColors)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// addLabelsToIssueTx is addLabelsToIssue within an existing transaction.
+func addLabelsToIssueTx(tx *sql.Tx, issueID int, labelNames []string, color string, author string, keepColors bool) ([]LabelColorConflict, error) {
 	// Verify the issue exists.
 	var exists bool
-	if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists); err != nil {
-		return fmt.Errorf("checking issue existence: %w", err)
+	if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM issues WHERE id = ? AND deleted_at IS NULL)`, issueID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("checking issue existence: %w", err)
 	}
 	if !exists {
-		return ErrNotFound
+		return nil, ErrNotFound
 	}
 
+	now := time.Now().UTC().Format(time.RFC3339)
+	var conflicts []LabelColorConflict
 	var anyAdded bool
 	for _, labelName := range labelNames {
 		// Find or create the label.
 		var labelID int
 		var existingColor sql.NullString
-		err = tx.QueryRow(`SELECT id, color FROM labels WHERE name = ?`, labelName).Scan(&labelID, &existingColor)
+		err := tx.QueryRow(`SELECT id, color FROM labels WHERE name = ?`, labelName).Scan(&labelID, &existingColor)
 		if errors.Is(err, sql.ErrNoRows) {
 			var colorVal any
 			if color != "" {
@@ -256,20 +369,27 @@ func AddLabelsToIssue(db *sql.DB, issueID int, labelNames []string, color string
 			}
 			res, err := tx.Exec(`INSERT INTO labels (name, color) VALUES (?, ?)`, labelName, colorVal)
 			if err != nil {
-				return fmt.Errorf("inserting label: %w", err)
+				return nil, fmt.Errorf("inserting label: %w", err)
 			}
 			id64, err := res.LastInsertId()
 			if err != nil {
-				return fmt.Errorf("getting label id: %w", err)
+				return nil, fmt.Errorf("getting label id: %w", err)
 			}
 			labelID = int(id64)
 		} else if err != nil {
-			return fmt.Errorf("querying label: %w", err)
+			return nil, fmt.Errorf("querying label: %w", err)
 		} else if color != "" && existingColor.Valid && existingColor.String != color {
-			return ErrLabelColorConflict
+			if !keepColors {
+				return nil, ErrLabelColorConflict
+			}
+			conflicts = append(conflicts, LabelColorConflict{
+				Label:          labelName,
+				ExistingColor:  existingColor.String,
+				RequestedColor: color,
+			})
 		} else if color != "" && !existingColor.Valid {
 			if _, err := tx.Exec(`UPDATE labels SET color = ? WHERE id = ?`, color, labelID); err != nil {
-				return fmt.Errorf("updating label color: %w", err)
+				return nil, fmt.Errorf("updating label color: %w", err)
 			}
 		}
 
@@ -279,17 +399,20 @@ func AddLabelsToIssue(db *sql.DB, issueID int, labelNames []string, color string
 			issueID, labelID,
 		)
 		if err != nil {
-			return fmt.Errorf("linking label: %w", err)
+			return nil, fmt.Errorf("linking label: %w", err)
 		}
 
 		n, err := res.RowsAffected()
 		if err != nil {
-			return fmt.Errorf("checking rows affected: %w", err)
+			return nil, fmt.Errorf("checking rows affected: %w", err)
 		}
 
 		if n > 0 {
 			if err := RecordActivity(tx, issueID, "label_added", "", labelName, author); err != nil {
-				return err
+				return nil, err
+			}
+			if err := touchLabel(tx, labelID, now); err != nil {
+				return nil, err
 			}
 			anyAdded = true
 		}
@@ -297,13 +420,12 @@ func AddLabelsToIssue(db *sql.DB, issueID int, labelNames []string, color string
 
 	// Touch updated_at once if any labels were actually added.
 	if anyAdded {
-		now := time.Now().UTC().Format(time.RFC3339)
 		if _, err := tx.Exec(`UPDATE issues SET updated_at = ? WHERE id = ?`, now, issueID); err != nil {
-			return fmt.Errorf("updating issue timestamp: %w", err)
+			return nil, fmt.Errorf("updating issue timestamp: %w", err)
 		}
 	}
 
-	return tx.Commit()
+	return conflicts, nil
 }
 
 // RemoveLabelFromIssue detaches a label from an issue. Returns an error if the
@@ -318,6 +440,10 @@ func RemoveLabelFromIssue(db *sql.DB, issueID int, labelName string, author stri
 // not attached — no labels are removed on failure. Activity is recorded for
 // each removed label and the issue's updated_at timestamp is touched once.
 func RemoveLabelsFromIssue(db *sql.DB, issueID int, labelNames []string, author string) error {
+	return WithRetry(func() error { return removeLabelsFromIssue(db, issueID, labelNames, author) })
+}
+
+func removeLabelsFromIssue(db *sql.DB, issueID int, labelNames []string, author string) error {
 	tx, err := db.Begin()
 	if err != nil {
 		return fmt.Errorf("beginning transaction: %w", err)
@@ -326,13 +452,14 @@ func RemoveLabelsFromIssue(db *sql.DB, issueID int, labelNames []string, author
 
 	// Verify the issue exists.
 	var exists bool
-	if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists); err != nil {
+	if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM issues WHERE id = ? AND deleted_at IS NULL)`, issueID).Scan(&exists); err != nil {
 		return fmt.Errorf("checking issue existence: %w", err)
 	}
 	if !exists {
 		return ErrNotFound
 	}
 
+	now := time.Now().UTC().Format(time.RFC3339)
 	for _, labelName := range labelNames {
 		// Find the label.
 		var labelID int
@@ -365,10 +492,12 @@ func RemoveLabelsFromIssue(db *sql.DB, issueID int, labelNames []string, author
 		if err := RecordActivity(tx, issueID, "label_removed", labelName, "", author); err != nil {
 			return err
 		}
+		if err := touchLabel(tx, labelID, now); err != nil {
+			return err
+		}
 	}
 
 	// Touch updated_at once.
-	now := time.Now().UTC().Format(time.RFC3339)
 	if _, err := tx.Exec(`UPDATE issues SET updated_at = ? WHERE id = ?`, now, issueID); err != nil {
 		return fmt.Errorf("updating issue timestamp: %w", err)
 	}
@@ -0,0 +1,158 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAddLabelsToIssueColorConflict(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, d, "issue A")
+	b := mustCreateIssue(t, d, "issue B")
+
+	if err := AddLabelsToIssue(d, a, []string{"bug"}, "#ff0000", "tester"); err != nil {
+		t.Fatalf("AddLabelsToIssue: %v", err)
+	}
+
+	err := AddLabelsToIssue(d, b, []string{"ui", "bug"}, "#00ff00", "tester")
+	if !errors.Is(err, ErrLabelColorConflict) {
+		t.Fatalf("expected ErrLabelColorConflict, got %v", err)
+	}
+
+	// The whole batch is rolled back on conflict.
+	labels, err := GetIssueLabels(d, b)
+	if err != nil {
+		t.Fatalf("GetIssueLabels: %v", err)
+	}
+	if len(labels) != 0 {
+		t.Errorf("labels on B = %v, want none after conflict", labels)
+	}
+}
+
+func TestAddLabelsToIssueKeepingColors(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, d, "issue A")
+	b := mustCreateIssue(t, d, "issue B")
+
+	if err := AddLabelsToIssue(d, a, []string{"bug"}, "#ff0000", "tester"); err != nil {
+		t.Fatalf("AddLabelsToIssue: %v", err)
+	}
+
+	conflicts, err := AddLabelsToIssueKeepingColors(d, b, []string{"ui", "bug"}, "#00ff00", "tester")
+	if err != nil {
+		t.Fatalf("AddLabelsToIssueKeepingColors: %v", err)
+	}
+	if len(conflicts) != 1 {
+		t.Fatalf("conflicts = %+v, want 1", conflicts)
+	}
+	want := LabelColorConflict{Label: "bug", ExistingColor: "#ff0000", RequestedColor: "#00ff00"}
+	if conflicts[0] != want {
+		t.Errorf("conflict = %+v, want %+v", conflicts[0], want)
+	}
+
+	labels, err := GetIssueLabels(d, b)
+	if err != nil {
+		t.Fatalf("GetIssueLabels: %v", err)
+	}
+	if len(labels) != 2 {
+		t.Errorf("labels on B = %v, want [bug ui]", labels)
+	}
+
+	bug, err := GetLabelByName(d, "bug")
+	if err != nil {
+		t.Fatalf("GetLabelByName(bug): %v", err)
+	}
+	if bug.Color != "#ff0000" {
+		t.Errorf("bug color = %q, want original #ff0000", bug.Color)
+	}
+	ui, err := GetLabelByName(d, "ui")
+	if err != nil {
+		t.Fatalf("GetLabelByName(ui): %v", err)
+	}
+	if ui.Color != "#00ff00" {
+		t.Errorf("ui color = %q, want #00ff00", ui.Color)
+	}
+}
+
+func TestLabelLastUsedAt(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, d, "issue A")
+	if _, err := d.Exec(`INSERT INTO labels (name) VALUES ('idle')`); err != nil {
+		t.Fatalf("inserting label: %v", err)
+	}
+	lastUsed := func(name string) *time.Time {
+		t.Helper()
+		l, err := GetLabelByName(d, name)
+		if err != nil {
+			t.Fatalf("GetLabelByName(%q): %v", name, err)
+		}
+		return l.LastUsedAt
+	}
+	if got := lastUsed("idle"); got != nil {
+		t.Errorf("unused label last used at %v, want nil", got)
+	}
+
+	before := time.Now().Add(-time.Second)
+	if err := AddLabelsToIssue(d, a, []string{"bug"}, "", "tester"); err != nil {
+		t.Fatalf("AddLabelsToIssue: %v", err)
+	}
+	if got := lastUsed("bug"); got == nil || got.Before(before) {
+		t.Errorf("attached label last used at %v, want after %v", got, before)
+	}
+
+	if _, err := d.Exec(`UPDATE labels SET last_used_at = '2020-01-01T00:00:00Z' WHERE name = 'bug'`); err != nil {
+		t.Fatalf("backdating label: %v", err)
+	}
+	if err := RemoveLabelsFromIssue(d, a, []string{"bug"}, "tester"); err != nil {
+		t.Fatalf("RemoveLabelsFromIssue: %v", err)
+	}
+	if got := lastUsed("bug"); got == nil || got.Before(before) {
+		t.Errorf("detached label last used at %v, want after %v", got, before)
+	}
+
+	idle, err := GetLabelByName(d, "idle")
+	if err != nil {
+		t.Fatalf("GetLabelByName(idle): %v", err)
+	}
+	tx, err := d.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	if _, err := InsertIssueLabelMapping(tx, a, idle.ID); err != nil {
+		tx.Rollback()
+		t.Fatalf("InsertIssueLabelMapping: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if got := lastUsed("idle"); got == nil || got.Before(before) {
+		t.Errorf("imported label last used at %v, want after %v", got, before)
+	}
+
+	b := mustCreateIssue(t, d, "issue B")
+	if err := AddLabelsToIssue(d, b, []string{"merged"}, "", "tester"); err != nil {
+		t.Fatalf("AddLabelsToIssue: %v", err)
+	}
+	if _, err := d.Exec(`UPDATE labels SET last_used_at = '2020-01-01T00:00:00Z' WHERE name = 'merged'`); err != nil {
+		t.Fatalf("backdating label: %v", err)
+	}
+	if _, err := MergeIssue(d, b, a, MergeOptions{ChangedBy: "tester"}); err != nil {
+		t.Fatalf("MergeIssue: %v", err)
+	}
+	if got := lastUsed("merged"); got == nil || got.Before(before) {
+		t.Errorf("merged label last used at %v, want after %v", got, before)
+	}
+}
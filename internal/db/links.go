@@ -0,0 +1,156 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// AddLink attaches a URL to an issue and returns the new link's ID. It
+// returns ErrNotFound if the issue does not exist and ErrConflict if the URL
+// is already attached to it. Activity is recorded and the issue's updated_at
+// timestamp is touched.
+func AddLink(db *sql.DB, issueID int, url, title, author string) (int, error) {
+	return withRetryValue(func() (int, error) { return addLink(db, issueID, url, title, author) })
+}
+
+func addLink(db *sql.DB, issueID int, url, title, author string) (int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM issues WHERE id = ? AND deleted_at IS NULL)`, issueID).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("checking issue existence: %w", err)
+	}
+	if !exists {
+		return 0, ErrNotFound
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	res, err := tx.Exec(
+		`INSERT INTO issue_links (issue_id, url, title, created_at) VALUES (?, ?, ?, ?)`,
+		issueID, url, title, now,
+	)
+	if err != nil {
+		if isUniqueOrPKConflict(err) {
+			return 0, ErrConflict
+		}
+		return 0, fmt.Errorf("inserting link: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("getting last insert id: %w", err)
+	}
+
+	if err := RecordActivity(tx, issueID, "links", "", url, author); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(`UPDATE issues SET updated_at = ? WHERE id = ?`, now, issueID); err != nil {
+		return 0, fmt.Errorf("updating issue timestamp: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing transaction: %w", err)
+	}
+	return int(id), nil
+}
+
+// GetIssueLinks returns the links attached to an issue in the order they
+// were added.
+func GetIssueLinks(db *sql.DB, issueID int) ([]model.IssueLink, error) {
+	rows, err := db.Query(
+		`SELECT id, issue_id, url, title, created_at FROM issue_links WHERE issue_id = ? ORDER BY id`,
+		issueID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying issue links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []model.IssueLink
+	for rows.Next() {
+		l, err := scanIssueLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating issue links: %w", err)
+	}
+	return links, nil
+}
+
+// ListAllIssueLinks returns every issue link ordered by ID. This is needed by
+// the export command.
+func ListAllIssueLinks(db *sql.DB) ([]model.IssueLink, error) {
+	var links []model.IssueLink
+	err := StreamIssueLinks(db, func(l model.IssueLink) error {
+		links = append(links, l)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// StreamIssueLinks calls fn for every issue link in the same order as
+// ListAllIssueLinks without buffering the result set. fn runs while the
+// cursor is open and must not query db.
+func StreamIssueLinks(db *sql.DB, fn func(model.IssueLink) error) error {
+	rows, err := db.Query(`SELECT id, issue_id, url, title, created_at FROM issue_links WHERE issue_id IN ` + liveIssueIDs + ` ORDER BY id`)
+	if err != nil {
+		return fmt.Errorf("querying all issue links: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		l, err := scanIssueLink(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(l); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating issue links: %w", err)
+	}
+	return nil
+}
+
+// InsertIssueLinkWithID inserts a link with a specific ID (not
+// auto-increment), skipping if the ID or the issue/URL pair already exists.
+// Returns true if the row was inserted. Must be called within an existing
+// transaction.
+func InsertIssueLinkWithID(tx *sql.Tx, l *model.IssueLink) (bool, error) {
+	res, err := tx.Exec(
+		`INSERT OR IGNORE INTO issue_links (id, issue_id, url, title, created_at) VALUES (?, ?, ?, ?, ?)`,
+		l.ID, l.IssueID, l.URL, l.Title, l.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return false, fmt.Errorf("inserting issue link with id %d: %w", l.ID, err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func scanIssueLink(s scanner) (model.IssueLink, error) {
+	var l model.IssueLink
+	var createdAt string
+	if err := s.Scan(&l.ID, &l.IssueID, &l.URL, &l.Title, &createdAt); err != nil {
+		return l, fmt.Errorf("scanning issue link: %w", err)
+	}
+	var err error
+	if l.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+		return l, fmt.Errorf("parsing link created_at: %w", err)
+	}
+	return l, nil
+}
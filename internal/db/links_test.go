@@ -0,0 +1,75 @@
+package db
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAddLinkAndGetIssueLinks(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, d, "issue A")
+	b := mustCreateIssue(t, d, "issue B")
+
+	first, err := AddLink(d, a, "https://example.com/pr/1", "Fix PR", "tester")
+	if err != nil {
+		t.Fatalf("AddLink: %v", err)
+	}
+	if _, err := AddLink(d, a, "https://example.com/design", "", "tester"); err != nil {
+		t.Fatalf("AddLink: %v", err)
+	}
+	if _, err := AddLink(d, b, "https://example.com/pr/1", "", "tester"); err != nil {
+		t.Fatalf("AddLink same URL on another issue: %v", err)
+	}
+
+	links, err := GetIssueLinks(d, a)
+	if err != nil {
+		t.Fatalf("GetIssueLinks: %v", err)
+	}
+	if len(links) != 2 {
+		t.Fatalf("got %d links, want 2", len(links))
+	}
+	if links[0].ID != first || links[0].URL != "https://example.com/pr/1" || links[0].Title != "Fix PR" {
+		t.Errorf("first link = %+v", links[0])
+	}
+	if links[1].URL != "https://example.com/design" || links[1].Title != "" {
+		t.Errorf("second link = %+v", links[1])
+	}
+
+	all, err := ListAllIssueLinks(d)
+	if err != nil {
+		t.Fatalf("ListAllIssueLinks: %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("ListAllIssueLinks returned %d links, want 3", len(all))
+	}
+}
+
+func TestAddLinkDuplicateConflicts(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	id := mustCreateIssue(t, d, "issue")
+	if _, err := AddLink(d, id, "https://example.com", "", "tester"); err != nil {
+		t.Fatalf("AddLink: %v", err)
+	}
+	if _, err := AddLink(d, id, "https://example.com", "again", "tester"); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+}
+
+func TestAddLinkMissingIssue(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	if _, err := AddLink(d, 999, "https://example.com", "", "tester"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
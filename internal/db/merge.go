@@ -0,0 +1,400 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/textdiff"
+)
+
+// MergeOptions controls how MergeIssue disposes of the merged issue.
+type MergeOptions struct {
+	// Trash moves the merged issue to the trash instead of closing it.
+	Trash bool
+	// ChangedBy is recorded as the author of every change.
+	ChangedBy string
+}
+
+// MergeResult describes what MergeIssue transferred from the merged issue to
+// the issue it was merged into.
+type MergeResult struct {
+	// Comments lists the IDs of the moved comments.
+	Comments []int
+	// Files and Labels list those newly attached to the winner; ones it
+	// already had are left out.
+	Files  []string
+	Labels []string
+	// SubIssues lists the live children reparented under the winner.
+	SubIssues []int
+	// Relations lists the relations rewritten to point at the winner, as
+	// they now stand.
+	Relations []model.Relation
+	// DroppedRelations lists the relations deleted because rewriting them
+	// would have made them self-referential, duplicated an existing
+	// relation, or closed a cycle.
+	DroppedRelations []model.Relation
+	// DescriptionAppended is true when the merged issue's description was
+	// appended to the winner's.
+	DescriptionAppended bool
+	// DuplicateRelationID is the ID of the "duplicates" relation recorded
+	// from the merged issue to the winner.
+	DuplicateRelationID int
+	// Trashed is true when the merged issue was moved to the trash rather
+	// than closed.
+	Trashed bool
+}
+
+// MergeIssue folds the issue loserID into winnerID in a single transaction.
+// The loser's comments, files, labels and live sub-issues move to the
+// winner; relations pointing at the loser are rewritten to point at the
+// winner, dropping any that would become self-referential, duplicate an
+// existing relation or close a cycle; and the loser's description is
+// appended to the winner's under a "Merged from" heading. A "loser
+// duplicates winner" relation is then recorded for history and the loser is
+// closed, or moved to the trash when opts.Trash is set. Each transfer is
+// recorded as activity on the winner.
+//
+// It returns ErrNotFound if either issue does not exist, and an error
+// wrapping ErrValidation when merging an issue into itself or into one of
+// its descendants.
+func MergeIssue(db *sql.DB, loserID, winnerID int, opts MergeOptions) (MergeResult, error) {
+	return withRetryValue(func() (MergeResult, error) { return mergeIssue(db, loserID, winnerID, opts) })
+}
+
+func mergeIssue(db *sql.DB, loserID, winnerID int, opts MergeOptions) (MergeResult, error) {
+	var result MergeResult
+	if loserID == winnerID {
+		return result, fmt.Errorf("%w: cannot merge %s into itself", ErrValidation, model.FormatID(loserID))
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return result, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	loser, err := getIssueTx(tx, loserID)
+	if err != nil {
+		return result, err
+	}
+	winner, err := getIssueTx(tx, winnerID)
+	if err != nil {
+		return result, err
+	}
+	isCycle, err := isDescendant(tx, loserID, winnerID)
+	if err != nil {
+		return result, err
+	}
+	if isCycle {
+		return result, fmt.Errorf("%w: cannot merge %s into its descendant %s", ErrValidation, model.FormatID(loserID), model.FormatID(winnerID))
+	}
+
+	author := opts.ChangedBy
+	if err := RecordActivity(tx, winnerID, "merged", "", model.FormatID(loserID), author); err != nil {
+		return result, err
+	}
+
+	steps := []func(*sql.Tx, int, int, string, *MergeResult) error{
+		mergeCommentsTx,
+		mergeFilesTx,
+		mergeLabelsTx,
+		mergeSubIssuesTx,
+		mergeRelationsTx,
+	}
+	for _, step := range steps {
+		if err := step(tx, loserID, winnerID, author, &result); err != nil {
+			return result, err
+		}
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if strings.TrimSpace(loser.Description) != "" {
+		desc := mergedDescription(winner.Description, loserID, loser.Description)
+		if _, err := tx.Exec(`UPDATE issues SET description = ? WHERE id = ?`, desc, winnerID); err != nil {
+			return result, fmt.Errorf("appending description: %w", err)
+		}
+		if err := syncReferencesTx(tx, winnerID, model.ReferenceContextDescription, nil, desc); err != nil {
+			return result, err
+		}
+		diff := textdiff.Unified("description", winner.Description, desc, descriptionDiffContext)
+		if err := RecordActivity(tx, winnerID, "description", "", diff, author); err != nil {
+			return result, err
+		}
+		result.DescriptionAppended = true
+	}
+	if _, err := tx.Exec(`UPDATE issues SET updated_at = ? WHERE id = ?`, now, winnerID); err != nil {
+		return result, fmt.Errorf("updating issue timestamp: %w", err)
+	}
+
+	res, err := tx.Exec(
+		`INSERT INTO issue_relations (source_issue_id, target_issue_id, relation_type, created_at)
+		 VALUES (?, ?, ?, ?)`,
+		loserID, winnerID, string(model.RelationDuplicates), now,
+	)
+	if err != nil {
+		return result, fmt.Errorf("inserting duplicates relation: %w", err)
+	}
+	id64, err := res.LastInsertId()
+	if err != nil {
+		return result, fmt.Errorf("getting last insert id: %w", err)
+	}
+	result.DuplicateRelationID = int(id64)
+	if err := recordRelationAddedTx(tx, loserID, winnerID, model.RelationDuplicates, author); err != nil {
+		return result, err
+	}
+
+	if opts.Trash {
+		if _, err := trashIssueTx(tx, loserID, author); err != nil {
+			return result, err
+		}
+		result.Trashed = true
+	} else if err := closeIssueTx(tx, loserID, author); err != nil {
+		return result, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return result, fmt.Errorf("committing transaction: %w", err)
+	}
+	return result, nil
+}
+
+// mergedDescription appends the loser's description to the winner's under a
+// heading naming the loser.
+func mergedDescription(winner string, loserID int, loser string) string {
+	section := fmt.Sprintf("## Merged from %s\n\n%s", model.FormatID(loserID), strings.TrimSpace(loser))
+	if strings.TrimSpace(winner) == "" {
+		return section
+	}
+	return strings.TrimRight(winner, "\n") + "\n\n" + section
+}
+
+// mergeCommentsTx moves the loser's comments, and the references found in
+// them, to the winner.
+func mergeCommentsTx(tx *sql.Tx, loserID, winnerID int, author string, result *MergeResult) error {
+	ids, err := queryIDsTx(tx, `SELECT id FROM comments WHERE issue_id = ? ORDER BY id`, loserID)
+	if err != nil {
+		return fmt.Errorf("querying comments: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	if _, err := tx.Exec(`UPDATE comments SET issue_id = ? WHERE issue_id = ?`, winnerID, loserID); err != nil {
+		return fmt.Errorf("moving comments: %w", err)
+	}
+	if _, err := tx.Exec(
+		`UPDATE issue_references SET from_issue_id = ? WHERE from_issue_id = ? AND comment_id IS NOT NULL`,
+		winnerID, loserID,
+	); err != nil {
+		return fmt.Errorf("moving comment references: %w", err)
+	}
+	result.Comments = ids
+	return RecordActivity(tx, winnerID, "comments", "", fmt.Sprintf("%d from %s", len(ids), model.FormatID(loserID)), author)
+}
+
+// mergeFilesTx moves the loser's attached files to the winner.
+func mergeFilesTx(tx *sql.Tx, loserID, winnerID int, author string, result *MergeResult) error {
+	added, err := queryStringsTx(tx,
+		`SELECT file_path FROM issue_files
+		 WHERE issue_id = ? AND file_path NOT IN (SELECT file_path FROM issue_files WHERE issue_id = ?)
+		 ORDER BY file_path`, loserID, winnerID,
+	)
+	if err != nil {
+		return fmt.Errorf("querying files: %w", err)
+	}
+	if _, err := tx.Exec(
+		`INSERT OR IGNORE INTO issue_files (issue_id, file_path) SELECT ?, file_path FROM issue_files WHERE issue_id = ?`,
+		winnerID, loserID,
+	); err != nil {
+		return fmt.Errorf("moving files: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM issue_files WHERE issue_id = ?`, loserID); err != nil {
+		return fmt.Errorf("detaching files: %w", err)
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	result.Files = added
+	return RecordActivity(tx, winnerID, "files", "", strings.Join(added, ", "), author)
+}
+
+// mergeLabelsTx moves the loser's labels to the winner, skipping those the
+// winner already has. The labels the winner gains are marked used now.
+func mergeLabelsTx(tx *sql.Tx, loserID, winnerID int, author string, result *MergeResult) error {
+	added, err := queryStringsTx(tx,
+		`SELECT l.name FROM issue_labels il JOIN labels l ON l.id = il.label_id
+		 WHERE il.issue_id = ? AND il.label_id NOT IN (SELECT label_id FROM issue_labels WHERE issue_id = ?)
+		 ORDER BY l.name`, loserID, winnerID,
+	)
+	if err != nil {
+		return fmt.Errorf("querying labels: %w", err)
+	}
+	if _, err := tx.Exec(
+		`UPDATE labels SET last_used_at = ? WHERE id IN (
+			SELECT label_id FROM issue_labels
+			WHERE issue_id = ? AND label_id NOT IN (SELECT label_id FROM issue_labels WHERE issue_id = ?))`,
+		time.Now().UTC().Format(time.RFC3339), loserID, winnerID,
+	); err != nil {
+		return fmt.Errorf("updating label last use: %w", err)
+	}
+	if _, err := tx.Exec(
+		`INSERT OR IGNORE INTO issue_labels (issue_id, label_id) SELECT ?, label_id FROM issue_labels WHERE issue_id = ?`,
+		winnerID, loserID,
+	); err != nil {
+		return fmt.Errorf("moving labels: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM issue_labels WHERE issue_id = ?`, loserID); err != nil {
+		return fmt.Errorf("detaching labels: %w", err)
+	}
+	for _, name := range added {
+		if err := RecordActivity(tx, winnerID, "label_added", "", name, author); err != nil {
+			return err
+		}
+	}
+	result.Labels = added
+	return nil
+}
+
+// mergeSubIssuesTx reparents the loser's live children under the winner.
+func mergeSubIssuesTx(tx *sql.Tx, loserID, winnerID int, author string, result *MergeResult) error {
+	ids, err := queryIDsTx(tx, `SELECT id FROM issues WHERE parent_id = ? AND deleted_at IS NULL ORDER BY id`, loserID)
+	if err != nil {
+		return fmt.Errorf("querying sub-issues: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := tx.Exec(
+		`UPDATE issues SET parent_id = ?, updated_at = ? WHERE parent_id = ? AND deleted_at IS NULL`,
+		winnerID, now, loserID,
+	); err != nil {
+		return fmt.Errorf("reparenting sub-issues: %w", err)
+	}
+
+	refs := make([]string, len(ids))
+	for i, id := range ids {
+		if err := RecordActivity(tx, id, "parent_id", strconv.Itoa(loserID), strconv.Itoa(winnerID), author); err != nil {
+			return err
+		}
+		refs[i] = model.FormatID(id)
+	}
+	result.SubIssues = ids
+	return RecordActivity(tx, winnerID, "sub_issues", "", strings.Join(refs, ", "), author)
+}
+
+// mergeRelationsTx rewrites the loser's relations to point at the winner,
+// deleting those that would become self-referential, duplicate an existing
+// relation or close a cycle.
+func mergeRelationsTx(tx *sql.Tx, loserID, winnerID int, author string, result *MergeResult) error {
+	rels, err := queryRelationsTx(tx,
+		`SELECT id, source_issue_id, target_issue_id, relation_type, note, created_at
+		 FROM issue_relations WHERE source_issue_id = ? OR target_issue_id = ? ORDER BY id`, loserID, loserID,
+	)
+	if err != nil {
+		return fmt.Errorf("querying relations: %w", err)
+	}
+
+	for _, rel := range rels {
+		moved := rel
+		if moved.SourceIssueID == loserID {
+			moved.SourceIssueID = winnerID
+		}
+		if moved.TargetIssueID == loserID {
+			moved.TargetIssueID = winnerID
+		}
+
+		drop := moved.SourceIssueID == moved.TargetIssueID
+		if !drop {
+			err := checkDuplicateTx(tx, moved.SourceIssueID, moved.TargetIssueID, moved.RelationType)
+			var dup *DuplicateRelationError
+			switch {
+			case errors.As(err, &dup):
+				drop = true
+			case err != nil:
+				return err
+			}
+		}
+		if !drop {
+			switch moved.RelationType {
+			case model.RelationBlocks, model.RelationDependsOn, model.RelationSupersedes:
+				hasCycle, _, err := checkCycleTx(tx, moved.SourceIssueID, moved.TargetIssueID, string(moved.RelationType))
+				if err != nil {
+					return fmt.Errorf("checking for cycles: %w", err)
+				}
+				drop = hasCycle
+			}
+		}
+
+		if err := recordRelationRemovedTx(tx, rel.SourceIssueID, rel.TargetIssueID, rel.RelationType, author); err != nil {
+			return err
+		}
+		if drop {
+			if _, err := tx.Exec(`DELETE FROM issue_relations WHERE id = ?`, rel.ID); err != nil {
+				return fmt.Errorf("deleting relation: %w", err)
+			}
+			result.DroppedRelations = append(result.DroppedRelations, rel)
+			continue
+		}
+
+		if _, err := tx.Exec(
+			`UPDATE issue_relations SET source_issue_id = ?, target_issue_id = ? WHERE id = ?`,
+			moved.SourceIssueID, moved.TargetIssueID, rel.ID,
+		); err != nil {
+			return fmt.Errorf("rewriting relation: %w", err)
+		}
+		if err := recordRelationAddedTx(tx, moved.SourceIssueID, moved.TargetIssueID, moved.RelationType, author); err != nil {
+			return err
+		}
+		result.Relations = append(result.Relations, moved)
+	}
+	return nil
+}
+
+// queryRelationsTx runs a query returning issue_relations rows within tx.
+func queryRelationsTx(tx *sql.Tx, query string, args ...any) ([]model.Relation, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rels []model.Relation
+	for rows.Next() {
+		var r model.Relation
+		var rt, createdAt string
+		if err := rows.Scan(&r.ID, &r.SourceIssueID, &r.TargetIssueID, &rt, &r.Note, &createdAt); err != nil {
+			return nil, fmt.Errorf("scanning relation: %w", err)
+		}
+		r.RelationType = model.RelationType(rt)
+		if r.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+			return nil, fmt.Errorf("parsing relation created_at: %w", err)
+		}
+		rels = append(rels, r)
+	}
+	return rels, rows.Err()
+}
+
+// queryStringsTx runs a query returning a single text column within tx.
+func queryStringsTx(tx *sql.Tx, query string, args ...any) ([]string, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,189 @@
+package db
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestMergeIssue(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	winner := mustCreateIssue(t, d, "winner")
+	loser := mustCreateIssue(t, d, "loser")
+	other := mustCreateIssue(t, d, "other")
+	third := mustCreateIssue(t, d, "third")
+	child, err := CreateIssue(d, &model.Issue{Title: "child", ParentID: &loser, Status: model.StatusTodo, Priority: model.PriorityNone, Kind: model.IssueKindTask}, nil, nil)
+	if err != nil {
+		t.Fatalf("CreateIssue(child): %v", err)
+	}
+
+	if err := UpdateIssue(d, winner, map[string]interface{}{"description": "Winner text."}, "amy"); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+	if err := UpdateIssue(d, loser, map[string]interface{}{"description": "Loser text, see DKT-4."}, "amy"); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+	if err := AddLabelsToIssue(d, winner, []string{"bug"}, "", "amy"); err != nil {
+		t.Fatalf("AddLabelsToIssue: %v", err)
+	}
+	if err := AddLabelsToIssue(d, loser, []string{"bug", "ui"}, "", "amy"); err != nil {
+		t.Fatalf("AddLabelsToIssue: %v", err)
+	}
+	if err := AttachFiles(d, winner, []string{"a.go"}, "amy"); err != nil {
+		t.Fatalf("AttachFiles: %v", err)
+	}
+	if err := AttachFiles(d, loser, []string{"a.go", "b.go"}, "amy"); err != nil {
+		t.Fatalf("AttachFiles: %v", err)
+	}
+	commentID, err := CreateComment(d, &model.Comment{IssueID: loser, Body: "repro steps", Author: "bob"})
+	if err != nil {
+		t.Fatalf("CreateComment: %v", err)
+	}
+
+	// Rewritten: loser blocks other.
+	mustCreateRelation(t, d, loser, other, model.RelationBlocks)
+	// Dropped as a duplicate of winner relates_to third.
+	mustCreateRelation(t, d, winner, third, model.RelationRelatesTo)
+	mustCreateRelation(t, d, third, loser, model.RelationRelatesTo)
+	// Dropped as self-referential.
+	mustCreateRelation(t, d, loser, winner, model.RelationDependsOn)
+
+	result, err := MergeIssue(d, loser, winner, MergeOptions{ChangedBy: "jane"})
+	if err != nil {
+		t.Fatalf("MergeIssue: %v", err)
+	}
+
+	if !slices.Equal(result.Comments, []int{commentID}) {
+		t.Errorf("Comments = %v, want [%d]", result.Comments, commentID)
+	}
+	if !slices.Equal(result.Files, []string{"b.go"}) {
+		t.Errorf("Files = %v, want [b.go]", result.Files)
+	}
+	if !slices.Equal(result.Labels, []string{"ui"}) {
+		t.Errorf("Labels = %v, want [ui]", result.Labels)
+	}
+	if !slices.Equal(result.SubIssues, []int{child}) {
+		t.Errorf("SubIssues = %v, want [%d]", result.SubIssues, child)
+	}
+	if len(result.Relations) != 1 || result.Relations[0].SourceIssueID != winner || result.Relations[0].TargetIssueID != other {
+		t.Errorf("Relations = %+v, want winner blocks other", result.Relations)
+	}
+	if len(result.DroppedRelations) != 2 {
+		t.Errorf("DroppedRelations = %+v, want 2", result.DroppedRelations)
+	}
+	if !result.DescriptionAppended || result.DuplicateRelationID == 0 || result.Trashed {
+		t.Errorf("result = %+v", result)
+	}
+
+	got, err := GetIssue(d, winner)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if want := "Winner text.\n\n## Merged from DKT-2\n\nLoser text, see DKT-4."; got.Description != want {
+		t.Errorf("description = %q, want %q", got.Description, want)
+	}
+	if labels, _ := GetIssueLabels(d, winner); !slices.Equal(labels, []string{"bug", "ui"}) {
+		t.Errorf("winner labels = %v", labels)
+	}
+	if files, _ := GetIssueFiles(d, winner); !slices.Equal(files, []string{"a.go", "b.go"}) {
+		t.Errorf("winner files = %v", files)
+	}
+	if comments, _ := ListIssueComments(d, winner); len(comments) != 1 {
+		t.Errorf("winner has %d comments, want 1", len(comments))
+	}
+	if c, _ := GetIssue(d, child); c.ParentID == nil || *c.ParentID != winner {
+		t.Errorf("child parent = %v, want %d", c.ParentID, winner)
+	}
+
+	old, err := GetIssue(d, loser)
+	if err != nil {
+		t.Fatalf("GetIssue(loser): %v", err)
+	}
+	if old.Status != model.StatusDone {
+		t.Errorf("loser status = %s, want done", old.Status)
+	}
+	rels, err := GetIssueRelations(d, loser)
+	if err != nil {
+		t.Fatalf("GetIssueRelations: %v", err)
+	}
+	if len(rels) != 1 || rels[0].RelationType != model.RelationDuplicates || rels[0].TargetIssueID != winner {
+		t.Errorf("loser relations = %+v, want only duplicates %s", rels, model.FormatID(winner))
+	}
+
+	activity, err := GetActivity(d, winner, 0)
+	if err != nil {
+		t.Fatalf("GetActivity: %v", err)
+	}
+	fields := make(map[string]bool)
+	for _, a := range activity {
+		if a.ChangedBy == "jane" {
+			fields[a.FieldChanged] = true
+		}
+	}
+	for _, f := range []string{"merged", "comments", "files", "label_added", "sub_issues", "relation_added", "description"} {
+		if !fields[f] {
+			t.Errorf("no %q activity recorded on the winner", f)
+		}
+	}
+}
+
+func TestMergeIssueTrash(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	winner := mustCreateIssue(t, d, "winner")
+	loser := mustCreateIssue(t, d, "loser")
+
+	result, err := MergeIssue(d, loser, winner, MergeOptions{Trash: true})
+	if err != nil {
+		t.Fatalf("MergeIssue: %v", err)
+	}
+	if !result.Trashed || result.DescriptionAppended {
+		t.Errorf("result = %+v", result)
+	}
+	if _, err := GetIssue(d, loser); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetIssue(loser) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMergeIssueRejectsConflicts(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	parent := mustCreateIssue(t, d, "parent")
+	child, err := CreateIssue(d, &model.Issue{Title: "child", ParentID: &parent, Status: model.StatusTodo, Priority: model.PriorityNone, Kind: model.IssueKindTask}, nil, nil)
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name          string
+		loser, winner int
+		want          error
+		msg           string
+	}{
+		{"self", parent, parent, ErrValidation, "into itself"},
+		{"descendant", parent, child, ErrValidation, "into its descendant"},
+		{"missing", parent, 99, ErrNotFound, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := MergeIssue(d, tc.loser, tc.winner, MergeOptions{})
+			if !errors.Is(err, tc.want) || !strings.Contains(err.Error(), tc.msg) {
+				t.Errorf("MergeIssue error = %v, want %v containing %q", err, tc.want, tc.msg)
+			}
+		})
+	}
+
+	if c, _ := GetIssue(d, child); c.ParentID == nil || *c.ParentID != parent {
+		t.Error("rejected merge changed the child's parent")
+	}
+}
@@ -0,0 +1,373 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// metaCurrentUser is the meta key holding the configured current user.
+const metaCurrentUser = "current_user"
+
+// metaStatusTransitions is the meta key holding the JSON-encoded status
+// transition map.
+const metaStatusTransitions = "status_transitions"
+
+// metaLinkTemplate is the meta key holding the issue link template.
+const metaLinkTemplate = "link_template"
+
+// metaAnonymousAuthor is the meta key holding the name shown for comments
+// and activity recorded without an author.
+const metaAnonymousAuthor = "anonymous_author"
+
+// metaDefaultSort is the meta key holding the default issue list sort, in
+// the syntax ParseSortKeys accepts.
+const metaDefaultSort = "default_sort"
+
+// metaReleaseNotesMarker is the meta key holding the time up to which
+// release notes were last generated, in RFC 3339.
+const metaReleaseNotesMarker = "release_notes_marker"
+
+// metaStartID is the meta key holding the number that issue IDs were set to
+// start from when the database was initialized.
+const metaStartID = "start_id"
+
+// MeAssignee is the assignee alias that resolves to the current user.
+const MeAssignee = "me"
+
+// ErrNoCurrentUser is returned by ResolveAssignee when MeAssignee is used
+// before a current user has been configured.
+var ErrNoCurrentUser = errors.New("no current user configured")
+
+// CurrentUser returns the configured current user, or "" when none is set.
+func CurrentUser(db *sql.DB) (string, error) {
+	var name string
+	err := db.QueryRow(`SELECT value FROM meta WHERE key = ?`, metaCurrentUser).Scan(&name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading current user: %w", err)
+	}
+	return name, nil
+}
+
+// SetCurrentUser stores name as the current user. An empty name clears it.
+func SetCurrentUser(db *sql.DB, name string) error {
+	return WithRetry(func() error {
+		var err error
+		if name == "" {
+			_, err = db.Exec(`DELETE FROM meta WHERE key = ?`, metaCurrentUser)
+		} else {
+			_, err = db.Exec(
+				`INSERT INTO meta (key, value) VALUES (?, ?)
+				 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+				metaCurrentUser, name,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("setting current user: %w", err)
+		}
+		return nil
+	})
+}
+
+// ResolveAssignee returns the current user when assignee is MeAssignee (in
+// any case) and assignee unchanged otherwise. It returns ErrNoCurrentUser
+// when "me" is used with no current user configured.
+func ResolveAssignee(db *sql.DB, assignee string) (string, error) {
+	if !strings.EqualFold(strings.TrimSpace(assignee), MeAssignee) {
+		return assignee, nil
+	}
+	name, err := CurrentUser(db)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", ErrNoCurrentUser
+	}
+	return name, nil
+}
+
+// StatusTransitions returns the configured status transition map, or nil
+// when none is set and every transition is allowed.
+func StatusTransitions(db *sql.DB) (model.Transitions, error) {
+	return statusTransitions(db)
+}
+
+func statusTransitions(q queryRower) (model.Transitions, error) {
+	var raw string
+	err := q.QueryRow(`SELECT value FROM meta WHERE key = ?`, metaStatusTransitions).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading status transitions: %w", err)
+	}
+	var t model.Transitions
+	if err := json.Unmarshal([]byte(raw), &t); err != nil {
+		return nil, fmt.Errorf("decoding status transitions: %w", err)
+	}
+	return t, nil
+}
+
+// SetStatusTransitions stores t as the status transition map enforced by
+// UpdateIssue. A nil map clears it so every transition is allowed again.
+func SetStatusTransitions(db *sql.DB, t model.Transitions) error {
+	if err := t.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+	return WithRetry(func() error {
+		var err error
+		if t == nil {
+			_, err = db.Exec(`DELETE FROM meta WHERE key = ?`, metaStatusTransitions)
+		} else {
+			var raw []byte
+			raw, err = json.Marshal(t)
+			if err != nil {
+				return fmt.Errorf("encoding status transitions: %w", err)
+			}
+			_, err = db.Exec(
+				`INSERT INTO meta (key, value) VALUES (?, ?)
+				 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+				metaStatusTransitions, string(raw),
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("setting status transitions: %w", err)
+		}
+		return nil
+	})
+}
+
+// LinkTemplate returns the configured issue link template, or "" when none
+// is set.
+func LinkTemplate(db *sql.DB) (string, error) {
+	var tmpl string
+	err := db.QueryRow(`SELECT value FROM meta WHERE key = ?`, metaLinkTemplate).Scan(&tmpl)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading link template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// SetLinkTemplate stores tmpl as the issue link template, in which %s stands
+// for an issue ID such as DKT-12. An empty template clears it.
+func SetLinkTemplate(db *sql.DB, tmpl string) error {
+	if tmpl != "" {
+		if !strings.Contains(tmpl, "%s") {
+			return fmt.Errorf("%w: link template %q must contain %%s for the issue ID", ErrValidation, tmpl)
+		}
+		if strings.ContainsFunc(tmpl, unicode.IsControl) {
+			return fmt.Errorf("%w: link template must not contain control characters", ErrValidation)
+		}
+	}
+	return WithRetry(func() error {
+		var err error
+		if tmpl == "" {
+			_, err = db.Exec(`DELETE FROM meta WHERE key = ?`, metaLinkTemplate)
+		} else {
+			_, err = db.Exec(
+				`INSERT INTO meta (key, value) VALUES (?, ?)
+				 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+				metaLinkTemplate, tmpl,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("setting link template: %w", err)
+		}
+		return nil
+	})
+}
+
+// AnonymousAuthor returns the configured name for comments and activity
+// recorded without an author, or "" when none is set.
+func AnonymousAuthor(db *sql.DB) (string, error) {
+	var name string
+	err := db.QueryRow(`SELECT value FROM meta WHERE key = ?`, metaAnonymousAuthor).Scan(&name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading anonymous author: %w", err)
+	}
+	return name, nil
+}
+
+// SetAnonymousAuthor stores name as the name shown for comments and activity
+// recorded without an author. An empty name clears it.
+func SetAnonymousAuthor(db *sql.DB, name string) error {
+	if strings.ContainsFunc(name, unicode.IsControl) {
+		return fmt.Errorf("%w: anonymous author must not contain control characters", ErrValidation)
+	}
+	return WithRetry(func() error {
+		var err error
+		if name == "" {
+			_, err = db.Exec(`DELETE FROM meta WHERE key = ?`, metaAnonymousAuthor)
+		} else {
+			_, err = db.Exec(
+				`INSERT INTO meta (key, value) VALUES (?, ?)
+				 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+				metaAnonymousAuthor, name,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("setting anonymous author: %w", err)
+		}
+		return nil
+	})
+}
+
+// ReleaseNotesMarker returns the time saved by SetReleaseNotesMarker, or
+// the zero time when none has been saved.
+func ReleaseNotesMarker(db *sql.DB) (time.Time, error) {
+	var value string
+	err := db.QueryRow(`SELECT value FROM meta WHERE key = ?`, metaReleaseNotesMarker).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("reading release notes marker: %w", err)
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing release notes marker %q: %w", value, err)
+	}
+	return t, nil
+}
+
+// SetReleaseNotesMarker saves t as the time up to which release notes have
+// been generated, for the next run to start from.
+func SetReleaseNotesMarker(db *sql.DB, t time.Time) error {
+	return WithRetry(func() error {
+		_, err := db.Exec(
+			`INSERT INTO meta (key, value) VALUES (?, ?)
+			 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+			metaReleaseNotesMarker, t.UTC().Format(time.RFC3339),
+		)
+		if err != nil {
+			return fmt.Errorf("setting release notes marker: %w", err)
+		}
+		return nil
+	})
+}
+
+// DefaultSort returns the configured default issue list sort, or nil when
+// none is set and the built-in order applies.
+func DefaultSort(db *sql.DB) ([]SortKey, error) {
+	var spec string
+	err := db.QueryRow(`SELECT value FROM meta WHERE key = ?`, metaDefaultSort).Scan(&spec)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading default sort: %w", err)
+	}
+	keys, err := ParseSortKeys(spec)
+	if err != nil {
+		return nil, fmt.Errorf("reading default sort: %w", err)
+	}
+	return keys, nil
+}
+
+// SetDefaultSort stores keys as the default issue list sort. Nil keys clear
+// it so the built-in order applies again.
+func SetDefaultSort(db *sql.DB, keys []SortKey) error {
+	for _, k := range keys {
+		if err := validateSortKey(k); err != nil {
+			return err
+		}
+	}
+	return WithRetry(func() error {
+		var err error
+		if len(keys) == 0 {
+			_, err = db.Exec(`DELETE FROM meta WHERE key = ?`, metaDefaultSort)
+		} else {
+			_, err = db.Exec(
+				`INSERT INTO meta (key, value) VALUES (?, ?)
+				 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+				metaDefaultSort, FormatSortKeys(keys),
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("setting default sort: %w", err)
+		}
+		return nil
+	})
+}
+
+// StartID returns the number issue IDs were set to start from by
+// SetStartID, or 0 when none was set.
+func StartID(db *sql.DB) (int, error) {
+	var start int
+	err := db.QueryRow(`SELECT CAST(value AS INTEGER) FROM meta WHERE key = ?`, metaStartID).Scan(&start)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("reading start ID: %w", err)
+	}
+	return start, nil
+}
+
+// SetStartID makes new issues be numbered from start, by raising the issues
+// AUTOINCREMENT sequence to start-1, and records start as the configured
+// start. Issues that already have higher IDs keep the sequence past them, so
+// no ID is ever reused.
+func SetStartID(db *sql.DB, start int) error {
+	if start < 1 {
+		return fmt.Errorf("%w: start ID must be a positive number", ErrValidation)
+	}
+	return WithRetry(func() error {
+		tx, err := db.Begin()
+		if err != nil {
+			return fmt.Errorf("beginning transaction: %w", err)
+		}
+		defer tx.Rollback()
+
+		res, err := tx.Exec(`UPDATE sqlite_sequence SET seq = MAX(seq, ?) WHERE name = 'issues'`, start-1)
+		if err != nil {
+			return fmt.Errorf("setting issue sequence: %w", err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return fmt.Errorf("checking rows affected: %w", err)
+		} else if n == 0 {
+			// The sequence row appears with the first issue insert.
+			if _, err := tx.Exec(`INSERT INTO sqlite_sequence (name, seq) VALUES ('issues', ?)`, start-1); err != nil {
+				return fmt.Errorf("setting issue sequence: %w", err)
+			}
+		}
+
+		if _, err := tx.Exec(
+			`INSERT INTO meta (key, value) VALUES (?, ?)
+			 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+			metaStartID, start,
+		); err != nil {
+			return fmt.Errorf("setting start ID: %w", err)
+		}
+		return tx.Commit()
+	})
+}
+
+// GetNextIssueID returns the ID the next created issue will get. IDs of
+// deleted issues are never reused, so this is one past the highest ID ever
+// assigned, or the configured start ID for a fresh database.
+func GetNextIssueID(db *sql.DB) (int, error) {
+	var next int
+	err := db.QueryRow(
+		`SELECT COALESCE((SELECT seq FROM sqlite_sequence WHERE name = 'issues'), 0) + 1`,
+	).Scan(&next)
+	if err != nil {
+		return 0, fmt.Errorf("reading next issue ID: %w", err)
+	}
+	return next, nil
+}
@@ -0,0 +1,224 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestCurrentUserAndResolveAssignee(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	if _, err := ResolveAssignee(db, "me"); !errors.Is(err, ErrNoCurrentUser) {
+		t.Fatalf("ResolveAssignee(me) with no user: err = %v, want ErrNoCurrentUser", err)
+	}
+	if got, err := ResolveAssignee(db, "bob"); err != nil || got != "bob" {
+		t.Errorf("ResolveAssignee(bob) = %q, %v; want bob", got, err)
+	}
+
+	if err := SetCurrentUser(db, "jane"); err != nil {
+		t.Fatalf("SetCurrentUser: %v", err)
+	}
+	if err := SetCurrentUser(db, "janet"); err != nil {
+		t.Fatalf("SetCurrentUser again: %v", err)
+	}
+	for _, alias := range []string{"me", "Me", " me "} {
+		if got, err := ResolveAssignee(db, alias); err != nil || got != "janet" {
+			t.Errorf("ResolveAssignee(%q) = %q, %v; want janet", alias, got, err)
+		}
+	}
+
+	if err := SetCurrentUser(db, ""); err != nil {
+		t.Fatalf("clearing current user: %v", err)
+	}
+	if got, err := CurrentUser(db); err != nil || got != "" {
+		t.Errorf("CurrentUser after clearing = %q, %v; want empty", got, err)
+	}
+}
+
+func TestStatusTransitionsEnforcedByUpdateIssue(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	if tr, err := StatusTransitions(db); err != nil || tr != nil {
+		t.Fatalf("StatusTransitions by default = %v, %v; want nil", tr, err)
+	}
+
+	// Without a map, any jump is allowed.
+	free := mustCreateIssue(t, db, "free")
+	if err := UpdateIssue(db, free, map[string]interface{}{"status": "done"}, ""); err != nil {
+		t.Fatalf("UpdateIssue backlog->done without a map: %v", err)
+	}
+
+	restrictive := model.Transitions{
+		model.StatusBacklog:    {model.StatusTodo},
+		model.StatusTodo:       {model.StatusInProgress},
+		model.StatusInProgress: {model.StatusReview},
+		model.StatusReview:     {model.StatusDone},
+	}
+	if err := SetStatusTransitions(db, restrictive); err != nil {
+		t.Fatalf("SetStatusTransitions: %v", err)
+	}
+	if tr, err := StatusTransitions(db); err != nil || !tr.Allows(model.StatusReview, model.StatusDone) || tr.Allows(model.StatusBacklog, model.StatusDone) {
+		t.Fatalf("StatusTransitions round trip = %v, %v", tr, err)
+	}
+
+	id := mustCreateIssue(t, db, "workflow")
+	err := UpdateIssue(db, id, map[string]interface{}{"status": "done"}, "")
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("UpdateIssue backlog->done = %v, want ErrValidation", err)
+	}
+	if issue, _ := GetIssue(db, id); issue.Status != model.StatusBacklog {
+		t.Errorf("status after rejected move = %s, want backlog", issue.Status)
+	}
+
+	for _, s := range []string{"todo", "in-progress", "review", "done"} {
+		if err := UpdateIssue(db, id, map[string]interface{}{"status": s}, ""); err != nil {
+			t.Fatalf("UpdateIssue -> %s: %v", s, err)
+		}
+	}
+	// done has no rule, so it cannot be left; other fields still update.
+	if err := UpdateIssue(db, id, map[string]interface{}{"status": "backlog"}, ""); !errors.Is(err, ErrValidation) {
+		t.Errorf("UpdateIssue done->backlog = %v, want ErrValidation", err)
+	}
+	if err := UpdateIssue(db, id, map[string]interface{}{"status": "done", "title": "renamed"}, ""); err != nil {
+		t.Errorf("UpdateIssue keeping status: %v", err)
+	}
+
+	if err := SetStatusTransitions(db, model.Transitions{"later": nil}); !errors.Is(err, ErrValidation) {
+		t.Errorf("SetStatusTransitions(invalid) = %v, want ErrValidation", err)
+	}
+	if err := SetStatusTransitions(db, nil); err != nil {
+		t.Fatalf("SetStatusTransitions(nil): %v", err)
+	}
+	if err := UpdateIssue(db, id, map[string]interface{}{"status": "backlog"}, ""); err != nil {
+		t.Errorf("UpdateIssue after clearing the map: %v", err)
+	}
+}
+
+func TestLinkTemplate(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	if got, err := LinkTemplate(db); err != nil || got != "" {
+		t.Fatalf("LinkTemplate by default = %q, %v; want empty", got, err)
+	}
+	for _, bad := range []string{"https://tracker.example/issues", "https://tracker.example/\x1b%s"} {
+		if err := SetLinkTemplate(db, bad); !errors.Is(err, ErrValidation) {
+			t.Errorf("SetLinkTemplate(%q) = %v, want ErrValidation", bad, err)
+		}
+	}
+
+	const tmpl = "https://tracker.example/issues/%s"
+	if err := SetLinkTemplate(db, tmpl); err != nil {
+		t.Fatalf("SetLinkTemplate: %v", err)
+	}
+	if got, err := LinkTemplate(db); err != nil || got != tmpl {
+		t.Errorf("LinkTemplate = %q, %v; want %q", got, err, tmpl)
+	}
+
+	if err := SetLinkTemplate(db, ""); err != nil {
+		t.Fatalf("clearing link template: %v", err)
+	}
+	if got, err := LinkTemplate(db); err != nil || got != "" {
+		t.Errorf("LinkTemplate after clearing = %q, %v; want empty", got, err)
+	}
+}
+
+func TestAnonymousAuthor(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	if got, err := AnonymousAuthor(db); err != nil || got != "" {
+		t.Fatalf("AnonymousAuthor by default = %q, %v; want empty", got, err)
+	}
+	if err := SetAnonymousAuthor(db, "bot\x1b"); !errors.Is(err, ErrValidation) {
+		t.Errorf("SetAnonymousAuthor with a control character = %v, want ErrValidation", err)
+	}
+
+	if err := SetAnonymousAuthor(db, "system"); err != nil {
+		t.Fatalf("SetAnonymousAuthor: %v", err)
+	}
+	if got, err := AnonymousAuthor(db); err != nil || got != "system" {
+		t.Errorf("AnonymousAuthor = %q, %v; want %q", got, err, "system")
+	}
+
+	if err := SetAnonymousAuthor(db, ""); err != nil {
+		t.Fatalf("clearing anonymous author: %v", err)
+	}
+	if got, err := AnonymousAuthor(db); err != nil || got != "" {
+		t.Errorf("AnonymousAuthor after clearing = %q, %v; want empty", got, err)
+	}
+}
+
+func TestReleaseNotesMarker(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	if got, err := ReleaseNotesMarker(db); err != nil || !got.IsZero() {
+		t.Fatalf("ReleaseNotesMarker by default = %v, %v; want zero", got, err)
+	}
+	at := time.Date(2026, 2, 1, 12, 30, 0, 0, time.UTC)
+	if err := SetReleaseNotesMarker(db, at); err != nil {
+		t.Fatalf("SetReleaseNotesMarker: %v", err)
+	}
+	if got, err := ReleaseNotesMarker(db); err != nil || !got.Equal(at) {
+		t.Errorf("ReleaseNotesMarker = %v, %v; want %v", got, err, at)
+	}
+}
+
+func TestStartIDAndGetNextIssueID(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	if next, err := GetNextIssueID(db); err != nil || next != 1 {
+		t.Fatalf("GetNextIssueID on a fresh database = %d, %v; want 1", next, err)
+	}
+	if start, err := StartID(db); err != nil || start != 0 {
+		t.Fatalf("StartID by default = %d, %v; want 0", start, err)
+	}
+	if err := SetStartID(db, 0); !errors.Is(err, ErrValidation) {
+		t.Errorf("SetStartID(0) = %v, want ErrValidation", err)
+	}
+
+	if err := SetStartID(db, 10000); err != nil {
+		t.Fatalf("SetStartID: %v", err)
+	}
+	if start, err := StartID(db); err != nil || start != 10000 {
+		t.Errorf("StartID = %d, %v; want 10000", start, err)
+	}
+	if next, err := GetNextIssueID(db); err != nil || next != 10000 {
+		t.Errorf("GetNextIssueID = %d, %v; want 10000", next, err)
+	}
+
+	id := createTestIssue(t, db, "First", model.StatusTodo, model.PriorityLow)
+	if id != 10000 {
+		t.Errorf("first issue ID = %d, want 10000", id)
+	}
+	if next, err := GetNextIssueID(db); err != nil || next != 10001 {
+		t.Errorf("GetNextIssueID after one issue = %d, %v; want 10001", next, err)
+	}
+
+	// A lower start never rewinds the sequence onto used IDs.
+	if err := SetStartID(db, 50); err != nil {
+		t.Fatalf("SetStartID(50): %v", err)
+	}
+	if next, err := GetNextIssueID(db); err != nil || next != 10001 {
+		t.Errorf("GetNextIssueID after a lower start = %d, %v; want 10001", next, err)
+	}
+}
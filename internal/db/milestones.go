@@ -0,0 +1,351 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// milestoneColumns is the column list read by scanMilestoneFrom.
+const milestoneColumns = `id, name, description, due_date, closed, created_at, updated_at`
+
+// CreateMilestone inserts a new milestone and returns its ID. It returns
+// ErrConflict if a milestone with the same name already exists.
+func CreateMilestone(db *sql.DB, m *model.Milestone) (int, error) {
+	return withRetryValue(func() (int, error) { return createMilestone(db, m) })
+}
+
+func createMilestone(db *sql.DB, m *model.Milestone) (int, error) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	res, err := db.Exec(
+		`INSERT INTO milestones (name, description, due_date, closed, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		m.Name, m.Description, dueDateValue(m), m.Closed, now, now,
+	)
+	if err != nil {
+		if isUniqueOrPKConflict(err) {
+			return 0, fmt.Errorf("%w: milestone %q already exists", ErrConflict, m.Name)
+		}
+		return 0, fmt.Errorf("inserting milestone: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("getting last insert id: %w", err)
+	}
+	return int(id), nil
+}
+
+// GetMilestone retrieves a milestone by ID, returning ErrNotFound if it does
+// not exist.
+func GetMilestone(db *sql.DB, id int) (*model.Milestone, error) {
+	row := db.QueryRow(`SELECT `+milestoneColumns+` FROM milestones WHERE id = ?`, id)
+	return scanMilestone(row)
+}
+
+// GetMilestoneByName retrieves a milestone by its unique name, returning
+// ErrNotFound if it does not exist.
+func GetMilestoneByName(db *sql.DB, name string) (*model.Milestone, error) {
+	row := db.QueryRow(`SELECT `+milestoneColumns+` FROM milestones WHERE name = ?`, name)
+	return scanMilestone(row)
+}
+
+// ListMilestones returns milestones with the done/total counts of their
+// issues, ordered by due date (undated last) and then name. Closed
+// milestones are only included when includeClosed is set.
+func ListMilestones(db *sql.DB, includeClosed bool) ([]model.MilestoneSummary, error) {
+	where := "WHERE m.closed = 0"
+	if includeClosed {
+		where = ""
+	}
+
+	rows, err := db.Query(
+		`SELECT m.id, m.name, m.description, m.due_date, m.closed, m.created_at, m.updated_at,
+			COALESCE(SUM(CASE WHEN i.status = 'done' THEN 1 ELSE 0 END), 0),
+			COUNT(i.id)
+		 FROM milestones m
+		 LEFT JOIN issues i ON i.milestone_id = m.id AND i.deleted_at IS NULL
+		 ` + where + `
+		 GROUP BY m.id
+		 ORDER BY m.due_date IS NULL, m.due_date ASC, m.name ASC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying milestones: %w", err)
+	}
+	defer rows.Close()
+
+	summaries := make([]model.MilestoneSummary, 0)
+	for rows.Next() {
+		var s model.MilestoneSummary
+		m, err := scanMilestoneFrom(rows, &s.Done, &s.Total)
+		if err != nil {
+			return nil, fmt.Errorf("scanning milestone row: %w", err)
+		}
+		s.Milestone = m
+		summaries = append(summaries, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating milestone rows: %w", err)
+	}
+	return summaries, nil
+}
+
+// GetMilestoneProgress returns (done, total) counts for the issues assigned
+// to a milestone.
+func GetMilestoneProgress(db *sql.DB, id int) (int, int, error) {
+	var done, total int
+	err := db.QueryRow(
+		`SELECT
+			COALESCE(SUM(CASE WHEN status = 'done' THEN 1 ELSE 0 END), 0),
+			COUNT(*)
+		 FROM issues WHERE milestone_id = ? AND deleted_at IS NULL`, id,
+	).Scan(&done, &total)
+	if err != nil {
+		return 0, 0, fmt.Errorf("querying milestone progress: %w", err)
+	}
+	return done, total, nil
+}
+
+// CloseMilestone marks a milestone as closed. When moveTo is non-nil, every
+// issue of the milestone that is not done is first reassigned to moveTo, with
+// activity recorded per issue. It returns the number of issues moved, or
+// ErrNotFound if the milestone does not exist.
+func CloseMilestone(db *sql.DB, id int, moveTo *int, author string) (int, error) {
+	return withRetryValue(func() (int, error) { return closeMilestone(db, id, moveTo, author) })
+}
+
+func closeMilestone(db *sql.DB, id int, moveTo *int, author string) (int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	res, err := tx.Exec(
+		`UPDATE milestones SET closed = 1, updated_at = ? WHERE id = ?`, now, id,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("closing milestone: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("checking rows affected: %w", err)
+	}
+	if n == 0 {
+		return 0, ErrNotFound
+	}
+
+	if moveTo == nil {
+		return 0, tx.Commit()
+	}
+
+	rows, err := tx.Query(
+		`SELECT id FROM issues WHERE milestone_id = ? AND status != 'done' AND deleted_at IS NULL ORDER BY id`, id,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("querying open issues: %w", err)
+	}
+	var issueIDs []int
+	for rows.Next() {
+		var issueID int
+		if err := rows.Scan(&issueID); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scanning issue id: %w", err)
+		}
+		issueIDs = append(issueIDs, issueID)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, fmt.Errorf("iterating open issues: %w", err)
+	}
+	rows.Close()
+
+	if _, err := tx.Exec(
+		`UPDATE issues SET milestone_id = ?, updated_at = ? WHERE milestone_id = ? AND status != 'done'`,
+		*moveTo, now, id,
+	); err != nil {
+		return 0, fmt.Errorf("moving open issues: %w", err)
+	}
+
+	oldVal, newVal := fmt.Sprintf("%d", id), fmt.Sprintf("%d", *moveTo)
+	for _, issueID := range issueIDs {
+		if err := RecordActivity(tx, issueID, "milestone_id", oldVal, newVal, author); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing transaction: %w", err)
+	}
+	return len(issueIDs), nil
+}
+
+// HydrateMilestones sets the Milestone name on every issue that has a
+// MilestoneID, using a single query.
+func HydrateMilestones(db *sql.DB, issues []*model.Issue) error {
+	ids := make([]any, 0, len(issues))
+	seen := make(map[int]bool)
+	for _, issue := range issues {
+		if issue.MilestoneID != nil && !seen[*issue.MilestoneID] {
+			seen[*issue.MilestoneID] = true
+			ids = append(ids, *issue.MilestoneID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := db.Query(
+		fmt.Sprintf(`SELECT id, name FROM milestones WHERE id IN (%s)`, makePlaceholders(len(ids))),
+		ids...,
+	)
+	if err != nil {
+		return fmt.Errorf("querying milestone names: %w", err)
+	}
+	defer rows.Close()
+
+	names := make(map[int]string, len(ids))
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return fmt.Errorf("scanning milestone name: %w", err)
+		}
+		names[id] = name
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating milestone names: %w", err)
+	}
+
+	for _, issue := range issues {
+		if issue.MilestoneID != nil {
+			issue.Milestone = names[*issue.MilestoneID]
+		}
+	}
+	return nil
+}
+
+// ListAllMilestones returns every milestone ordered by ID.
+func ListAllMilestones(db *sql.DB) ([]*model.Milestone, error) {
+	var milestones []*model.Milestone
+	err := StreamMilestones(db, func(m *model.Milestone) error {
+		milestones = append(milestones, m)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return milestones, nil
+}
+
+// StreamMilestones calls fn for every milestone in the same order as
+// ListAllMilestones without buffering the result set. fn runs while the
+// cursor is open and must not query db.
+func StreamMilestones(db *sql.DB, fn func(*model.Milestone) error) error {
+	rows, err := db.Query(`SELECT ` + milestoneColumns + ` FROM milestones ORDER BY id`)
+	if err != nil {
+		return fmt.Errorf("querying all milestones: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		m, err := scanMilestoneFrom(rows)
+		if err != nil {
+			return fmt.Errorf("scanning milestone: %w", err)
+		}
+		if err := fn(m); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating milestone rows: %w", err)
+	}
+	return nil
+}
+
+// InsertMilestoneWithID inserts a milestone with a specific ID (not
+// auto-increment), skipping if the ID or name already exists. Returns true if
+// the row was inserted. Must be called within an existing transaction.
+func InsertMilestoneWithID(tx *sql.Tx, m *model.Milestone) (bool, error) {
+	res, err := tx.Exec(
+		`INSERT OR IGNORE INTO milestones (id, name, description, due_date, closed, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		m.ID,
+		m.Name,
+		m.Description,
+		dueDateValue(m),
+		m.Closed,
+		m.CreatedAt.UTC().Format(time.RFC3339),
+		m.UpdatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return false, fmt.Errorf("inserting milestone with id %d: %w", m.ID, err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// MilestoneExistsTx reports whether a milestone with the given ID exists.
+func MilestoneExistsTx(tx *sql.Tx, id int) (bool, error) {
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM milestones WHERE id = ?)`, id).Scan(&exists); err != nil {
+		return false, fmt.Errorf("checking milestone %d: %w", id, err)
+	}
+	return exists, nil
+}
+
+// dueDateValue returns the milestone's due date for sql parameter binding,
+// or nil when it has none.
+func dueDateValue(m *model.Milestone) interface{} {
+	if m.DueDate == nil {
+		return nil
+	}
+	return m.FormatDueDate()
+}
+
+// scanMilestone scans a single milestone from a *sql.Row, returning
+// ErrNotFound for sql.ErrNoRows.
+func scanMilestone(row *sql.Row) (*model.Milestone, error) {
+	m, err := scanMilestoneFrom(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("scanning milestone: %w", err)
+	}
+	return m, nil
+}
+
+// scanMilestoneFrom scans the milestoneColumns from any scanner, followed by
+// any extra destinations the query selects after them.
+func scanMilestoneFrom(s scanner, extra ...any) (*model.Milestone, error) {
+	var m model.Milestone
+	var dueDate sql.NullString
+	var createdAt, updatedAt string
+
+	dest := append([]any{&m.ID, &m.Name, &m.Description, &dueDate, &m.Closed, &createdAt, &updatedAt}, extra...)
+	if err := s.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	if dueDate.Valid && dueDate.String != "" {
+		due, err := model.ParseDueDate(dueDate.String)
+		if err != nil {
+			return nil, err
+		}
+		m.DueDate = &due
+	}
+
+	var err error
+	if m.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+		return nil, fmt.Errorf("parsing created_at: %w", err)
+	}
+	if m.UpdatedAt, err = time.Parse(time.RFC3339, updatedAt); err != nil {
+		return nil, fmt.Errorf("parsing updated_at: %w", err)
+	}
+	return &m, nil
+}
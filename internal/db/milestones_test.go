@@ -0,0 +1,174 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func newMilestoneTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	return db
+}
+
+func mustCreateMilestone(t *testing.T, db *sql.DB, name string) int {
+	t.Helper()
+	id, err := CreateMilestone(db, &model.Milestone{Name: name})
+	if err != nil {
+		t.Fatalf("CreateMilestone(%q): %v", name, err)
+	}
+	return id
+}
+
+func assignMilestone(t *testing.T, db *sql.DB, issueID, milestoneID int) {
+	t.Helper()
+	if err := UpdateIssue(db, issueID, map[string]interface{}{"milestone_id": milestoneID}, "tester"); err != nil {
+		t.Fatalf("UpdateIssue(milestone_id): %v", err)
+	}
+}
+
+func TestCreateMilestoneRoundTripsAndRejectsDuplicateName(t *testing.T) {
+	db := newMilestoneTestDB(t)
+
+	due := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+	id, err := CreateMilestone(db, &model.Milestone{Name: "v1.4", Description: "summer", DueDate: &due})
+	if err != nil {
+		t.Fatalf("CreateMilestone: %v", err)
+	}
+
+	got, err := GetMilestoneByName(db, "v1.4")
+	if err != nil {
+		t.Fatalf("GetMilestoneByName: %v", err)
+	}
+	if got.ID != id || got.Description != "summer" || got.FormatDueDate() != "2026-06-30" || got.Closed {
+		t.Errorf("unexpected milestone: %+v", got)
+	}
+
+	if _, err := CreateMilestone(db, &model.Milestone{Name: "v1.4"}); !errors.Is(err, ErrConflict) {
+		t.Errorf("duplicate CreateMilestone err = %v, want ErrConflict", err)
+	}
+	if _, err := GetMilestoneByName(db, "v9"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetMilestoneByName(missing) err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMilestoneProgressAndListFilter(t *testing.T) {
+	db := newMilestoneTestDB(t)
+
+	msID := mustCreateMilestone(t, db, "v1.4")
+	other := mustCreateMilestone(t, db, "v2.0")
+
+	done := createTestIssue(t, db, "shipped", model.StatusDone, model.PriorityHigh)
+	open := createTestIssue(t, db, "pending", model.StatusTodo, model.PriorityHigh)
+	createTestIssue(t, db, "unplanned", model.StatusTodo, model.PriorityHigh)
+	assignMilestone(t, db, done, msID)
+	assignMilestone(t, db, open, msID)
+
+	d, total, err := GetMilestoneProgress(db, msID)
+	if err != nil {
+		t.Fatalf("GetMilestoneProgress: %v", err)
+	}
+	if d != 1 || total != 2 {
+		t.Errorf("progress = %d/%d, want 1/2", d, total)
+	}
+
+	issues, count, err := ListIssues(db, ListOptions{MilestoneID: &msID, IncludeDone: true})
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	if count != 2 || len(issues) != 2 {
+		t.Fatalf("ListIssues(milestone) = %d issues (total %d), want 2", len(issues), count)
+	}
+	for _, issue := range issues {
+		if issue.MilestoneID == nil || *issue.MilestoneID != msID {
+			t.Errorf("issue %d milestone_id = %v, want %d", issue.ID, issue.MilestoneID, msID)
+		}
+	}
+
+	if err := HydrateMilestones(db, issues); err != nil {
+		t.Fatalf("HydrateMilestones: %v", err)
+	}
+	if issues[0].Milestone != "v1.4" {
+		t.Errorf("hydrated milestone = %q, want v1.4", issues[0].Milestone)
+	}
+
+	summaries, err := ListMilestones(db, false)
+	if err != nil {
+		t.Fatalf("ListMilestones: %v", err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("ListMilestones = %d, want 2", len(summaries))
+	}
+	for _, s := range summaries {
+		switch s.ID {
+		case msID:
+			if s.Done != 1 || s.Total != 2 {
+				t.Errorf("v1.4 summary = %d/%d, want 1/2", s.Done, s.Total)
+			}
+		case other:
+			if s.Done != 0 || s.Total != 0 {
+				t.Errorf("v2.0 summary = %d/%d, want 0/0", s.Done, s.Total)
+			}
+		}
+	}
+}
+
+func TestCloseMilestoneMovesOpenIssues(t *testing.T) {
+	db := newMilestoneTestDB(t)
+
+	from := mustCreateMilestone(t, db, "v1.4")
+	to := mustCreateMilestone(t, db, "v1.5")
+
+	done := createTestIssue(t, db, "shipped", model.StatusDone, model.PriorityHigh)
+	open := createTestIssue(t, db, "slipped", model.StatusInProgress, model.PriorityHigh)
+	assignMilestone(t, db, done, from)
+	assignMilestone(t, db, open, from)
+
+	moved, err := CloseMilestone(db, from, &to, "tester")
+	if err != nil {
+		t.Fatalf("CloseMilestone: %v", err)
+	}
+	if moved != 1 {
+		t.Errorf("moved = %d, want 1", moved)
+	}
+
+	m, err := GetMilestone(db, from)
+	if err != nil {
+		t.Fatalf("GetMilestone: %v", err)
+	}
+	if !m.Closed {
+		t.Error("milestone not closed")
+	}
+
+	for id, want := range map[int]int{done: from, open: to} {
+		issue, err := GetIssue(db, id)
+		if err != nil {
+			t.Fatalf("GetIssue(%d): %v", id, err)
+		}
+		if issue.MilestoneID == nil || *issue.MilestoneID != want {
+			t.Errorf("issue %d milestone_id = %v, want %d", id, issue.MilestoneID, want)
+		}
+	}
+
+	summaries, err := ListMilestones(db, false)
+	if err != nil {
+		t.Fatalf("ListMilestones: %v", err)
+	}
+	if len(summaries) != 1 || summaries[0].ID != to {
+		t.Errorf("open milestones = %+v, want only v1.5", summaries)
+	}
+
+	if _, err := CloseMilestone(db, 999, nil, "tester"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("CloseMilestone(missing) err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,144 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// notifyTx records a notification of kind for recipient about issueID.
+// Nothing is recorded when recipient is empty or is the actor (compared
+// case-insensitively), so nobody is notified of their own actions. Must be
+// called within an existing transaction.
+func notifyTx(tx *sql.Tx, recipient string, issueID int, kind model.NotificationKind, actor string) error {
+	recipient = strings.TrimSpace(recipient)
+	if recipient == "" || strings.EqualFold(recipient, strings.TrimSpace(actor)) {
+		return nil
+	}
+	if _, err := tx.Exec(
+		`INSERT INTO notifications (recipient, issue_id, kind, actor, created_at) VALUES (?, ?, ?, ?, ?)`,
+		recipient, issueID, string(kind), actor, time.Now().UTC().Format(time.RFC3339),
+	); err != nil {
+		return fmt.Errorf("recording notification: %w", err)
+	}
+	return nil
+}
+
+// notifyMentionsTx notifies everyone @mentioned in text about issueID,
+// skipping names already mentioned in previous so that editing a
+// description does not notify the same people again. Must be called within
+// an existing transaction.
+func notifyMentionsTx(tx *sql.Tx, issueID int, text, previous, actor string) error {
+	already := make(map[string]bool)
+	for _, name := range model.ExtractMentions(previous) {
+		already[strings.ToLower(name)] = true
+	}
+	for _, name := range model.ExtractMentions(text) {
+		if already[strings.ToLower(name)] {
+			continue
+		}
+		if err := notifyTx(tx, name, issueID, model.NotificationMentioned, actor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListNotifications returns recipient's notifications, newest first, with
+// the title and status of each issue. Recipients are matched
+// case-insensitively, and notifications about issues in the trash are
+// omitted. With unreadOnly, notifications already marked read are omitted
+// too.
+func ListNotifications(db *sql.DB, recipient string, unreadOnly bool) ([]*model.Notification, error) {
+	query := `SELECT n.id, n.recipient, n.issue_id, n.kind, n.actor, n.created_at, n.read_at, i.title, i.status
+		 FROM notifications n
+		 JOIN issues i ON i.id = n.issue_id
+		 WHERE n.recipient = ? COLLATE NOCASE AND i.deleted_at IS NULL`
+	if unreadOnly {
+		query += ` AND n.read_at IS NULL`
+	}
+	query += ` ORDER BY n.created_at DESC, n.id DESC`
+
+	rows, err := db.Query(query, recipient)
+	if err != nil {
+		return nil, fmt.Errorf("querying notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := make([]*model.Notification, 0)
+	for rows.Next() {
+		var (
+			n         model.Notification
+			kind      string
+			actor     sql.NullString
+			createdAt string
+			readAt    sql.NullString
+			status    string
+		)
+		if err := rows.Scan(&n.ID, &n.Recipient, &n.IssueID, &kind, &actor, &createdAt, &readAt, &n.IssueTitle, &status); err != nil {
+			return nil, fmt.Errorf("scanning notification row: %w", err)
+		}
+		n.Kind = model.NotificationKind(kind)
+		n.Actor = actor.String
+		n.IssueStatus = model.Status(status)
+		if n.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+			return nil, fmt.Errorf("parsing notification created_at: %w", err)
+		}
+		if readAt.Valid {
+			if n.ReadAt, err = time.Parse(time.RFC3339, readAt.String); err != nil {
+				return nil, fmt.Errorf("parsing notification read_at: %w", err)
+			}
+		}
+		notifications = append(notifications, &n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating notification rows: %w", err)
+	}
+	return notifications, nil
+}
+
+// MarkNotificationRead marks recipient's notification id as read. It returns
+// ErrNotFound when recipient has no notification with that ID. Marking an
+// already read notification again keeps its original read time.
+func MarkNotificationRead(db *sql.DB, recipient string, id int) error {
+	return WithRetry(func() error {
+		var exists bool
+		if err := db.QueryRow(
+			`SELECT EXISTS(SELECT 1 FROM notifications WHERE id = ? AND recipient = ? COLLATE NOCASE)`, id, recipient,
+		).Scan(&exists); err != nil {
+			return fmt.Errorf("checking notification existence: %w", err)
+		}
+		if !exists {
+			return ErrNotFound
+		}
+		if _, err := db.Exec(
+			`UPDATE notifications SET read_at = ? WHERE id = ? AND read_at IS NULL`,
+			time.Now().UTC().Format(time.RFC3339), id,
+		); err != nil {
+			return fmt.Errorf("marking notification read: %w", err)
+		}
+		return nil
+	})
+}
+
+// MarkAllNotificationsRead marks every unread notification of recipient as
+// read and returns how many were marked.
+func MarkAllNotificationsRead(db *sql.DB, recipient string) (int, error) {
+	return withRetryValue(func() (int, error) {
+		res, err := db.Exec(
+			`UPDATE notifications SET read_at = ? WHERE recipient = ? COLLATE NOCASE AND read_at IS NULL`,
+			time.Now().UTC().Format(time.RFC3339), recipient,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("marking notifications read: %w", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("checking rows affected: %w", err)
+		}
+		return int(n), nil
+	})
+}
@@ -0,0 +1,202 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func mustListNotifications(t *testing.T, conn *sql.DB, recipient string, unreadOnly bool) []*model.Notification {
+	t.Helper()
+	notifications, err := ListNotifications(conn, recipient, unreadOnly)
+	if err != nil {
+		t.Fatalf("ListNotifications(%q): %v", recipient, err)
+	}
+	return notifications
+}
+
+func notificationKinds(notifications []*model.Notification) []model.NotificationKind {
+	kinds := make([]model.NotificationKind, len(notifications))
+	for i, n := range notifications {
+		kinds[len(notifications)-1-i] = n.Kind // oldest first
+	}
+	return kinds
+}
+
+func TestNotificationsOnCreate(t *testing.T) {
+	conn := mustOpen(t)
+	if err := Initialize(conn); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	id, err := CreateIssueBy(conn, &model.Issue{
+		Title:       "Fix login",
+		Description: "@carol knows the auth code; mail dave@example.com. Thanks @me-myself",
+		Status:      model.StatusTodo,
+		Priority:    model.PriorityHigh,
+		Kind:        model.IssueKindBug,
+		Assignee:    "bob",
+	}, nil, nil, "me-myself")
+	if err != nil {
+		t.Fatalf("CreateIssueBy: %v", err)
+	}
+
+	bob := mustListNotifications(t, conn, "BOB", false)
+	if len(bob) != 1 || bob[0].Kind != model.NotificationAssigned || bob[0].IssueID != id {
+		t.Fatalf("bob's notifications = %+v, want one assignment to %d", bob, id)
+	}
+	if bob[0].Actor != "me-myself" || bob[0].IssueTitle != "Fix login" || bob[0].IssueStatus != model.StatusTodo {
+		t.Errorf("notification context = %+v", bob[0])
+	}
+	if !bob[0].Unread() {
+		t.Error("new notification should be unread")
+	}
+
+	if carol := mustListNotifications(t, conn, "carol", false); len(carol) != 1 || carol[0].Kind != model.NotificationMentioned {
+		t.Errorf("carol's notifications = %+v, want one mention", carol)
+	}
+	if got := mustListNotifications(t, conn, "example.com", false); len(got) != 0 {
+		t.Errorf("email address produced notifications: %+v", got)
+	}
+	if got := mustListNotifications(t, conn, "me-myself", false); len(got) != 0 {
+		t.Errorf("actor was notified of their own action: %+v", got)
+	}
+}
+
+func TestNotificationsOnUpdate(t *testing.T) {
+	conn := mustOpen(t)
+	if err := Initialize(conn); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	id := createTestIssue(t, conn, "Ship it", model.StatusTodo, model.PriorityMedium)
+
+	if err := UpdateIssue(conn, id, map[string]interface{}{"assignee": "bob"}, "alice"); err != nil {
+		t.Fatalf("assigning: %v", err)
+	}
+	if err := UpdateIssue(conn, id, map[string]interface{}{"status": string(model.StatusInProgress)}, "alice"); err != nil {
+		t.Fatalf("changing status: %v", err)
+	}
+	// Bob changing his own issue does not notify him.
+	if err := UpdateIssue(conn, id, map[string]interface{}{"status": string(model.StatusReview)}, "bob"); err != nil {
+		t.Fatalf("changing status as owner: %v", err)
+	}
+
+	got := notificationKinds(mustListNotifications(t, conn, "bob", false))
+	want := []model.NotificationKind{model.NotificationAssigned, model.NotificationStatusChanged}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("bob's notification kinds = %v, want %v", got, want)
+	}
+
+	// Re-saving a description only notifies newly mentioned names.
+	if err := UpdateIssue(conn, id, map[string]interface{}{"description": "ask @carol"}, "alice"); err != nil {
+		t.Fatalf("setting description: %v", err)
+	}
+	if err := UpdateIssue(conn, id, map[string]interface{}{"description": "ask @carol or @dave"}, "alice"); err != nil {
+		t.Fatalf("editing description: %v", err)
+	}
+	if carol := mustListNotifications(t, conn, "carol", false); len(carol) != 1 {
+		t.Errorf("carol got %d notifications, want 1", len(carol))
+	}
+	if dave := mustListNotifications(t, conn, "dave", false); len(dave) != 1 {
+		t.Errorf("dave got %d notifications, want 1", len(dave))
+	}
+}
+
+func TestNotificationsOnComment(t *testing.T) {
+	conn := mustOpen(t)
+	if err := Initialize(conn); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	id := createTestIssue(t, conn, "Discuss", model.StatusTodo, model.PriorityLow)
+
+	body := "@bob see this:\n```\n@carol is not a mention here\n```\nalso `@dave`, and @alice"
+	if _, err := CreateComment(conn, &model.Comment{IssueID: id, Body: body, Author: "alice"}); err != nil {
+		t.Fatalf("CreateComment: %v", err)
+	}
+
+	if got := mustListNotifications(t, conn, "bob", false); len(got) != 1 || got[0].Kind != model.NotificationMentioned {
+		t.Errorf("bob's notifications = %+v, want one mention", got)
+	}
+	for _, name := range []string{"carol", "dave", "alice"} {
+		if got := mustListNotifications(t, conn, name, false); len(got) != 0 {
+			t.Errorf("%s got %d notifications, want none", name, len(got))
+		}
+	}
+}
+
+func TestMarkNotificationsRead(t *testing.T) {
+	conn := mustOpen(t)
+	if err := Initialize(conn); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	id := createTestIssue(t, conn, "Review", model.StatusTodo, model.PriorityLow)
+	for _, body := range []string{"@bob one", "@bob two", "@bob three"} {
+		if _, err := CreateComment(conn, &model.Comment{IssueID: id, Body: body, Author: "alice"}); err != nil {
+			t.Fatalf("CreateComment: %v", err)
+		}
+	}
+	all := mustListNotifications(t, conn, "bob", false)
+	if len(all) != 3 {
+		t.Fatalf("got %d notifications, want 3", len(all))
+	}
+
+	if err := MarkNotificationRead(conn, "bob", all[0].ID); err != nil {
+		t.Fatalf("MarkNotificationRead: %v", err)
+	}
+	if err := MarkNotificationRead(conn, "carol", all[1].ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("marking another user's notification: err = %v, want ErrNotFound", err)
+	}
+	if err := MarkNotificationRead(conn, "bob", 9999); !errors.Is(err, ErrNotFound) {
+		t.Errorf("marking a missing notification: err = %v, want ErrNotFound", err)
+	}
+	if unread := mustListNotifications(t, conn, "bob", true); len(unread) != 2 {
+		t.Errorf("got %d unread, want 2", len(unread))
+	}
+
+	marked, err := MarkAllNotificationsRead(conn, "Bob")
+	if err != nil {
+		t.Fatalf("MarkAllNotificationsRead: %v", err)
+	}
+	if marked != 2 {
+		t.Errorf("marked = %d, want 2", marked)
+	}
+	if unread := mustListNotifications(t, conn, "bob", true); len(unread) != 0 {
+		t.Errorf("got %d unread after marking all, want 0", len(unread))
+	}
+	if all := mustListNotifications(t, conn, "bob", false); len(all) != 3 {
+		t.Errorf("read notifications should still be listed, got %d", len(all))
+	}
+}
+
+func TestNotificationsHiddenForTrashedAndCleared(t *testing.T) {
+	conn := mustOpen(t)
+	if err := Initialize(conn); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	kept := createTestIssue(t, conn, "Kept", model.StatusTodo, model.PriorityLow)
+	trashed := createTestIssue(t, conn, "Trashed", model.StatusTodo, model.PriorityLow)
+	for _, id := range []int{kept, trashed} {
+		if _, err := CreateComment(conn, &model.Comment{IssueID: id, Body: "@bob", Author: "alice"}); err != nil {
+			t.Fatalf("CreateComment: %v", err)
+		}
+	}
+	if _, _, err := DeleteIssues(conn, []int{trashed}, false, "alice"); err != nil {
+		t.Fatalf("DeleteIssues: %v", err)
+	}
+	if got := mustListNotifications(t, conn, "bob", false); len(got) != 1 || got[0].IssueID != kept {
+		t.Errorf("notifications = %+v, want only the one for %d", got, kept)
+	}
+
+	if err := ClearAllData(conn); err != nil {
+		t.Fatalf("ClearAllData: %v", err)
+	}
+	var count int
+	if err := conn.QueryRow("SELECT COUNT(*) FROM notifications").Scan(&count); err != nil {
+		t.Fatalf("counting notifications: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("notifications after ClearAllData = %d, want 0", count)
+	}
+}
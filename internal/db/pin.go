@@ -0,0 +1,49 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// PinIssue pins an issue so that it sorts ahead of every other issue in
+// lists and boards, and records a "pinned" activity entry. Pinning an issue
+// that is already pinned is a no-op.
+func PinIssue(db *sql.DB, id int, changedBy string) error {
+	return WithRetry(func() error { return setPinned(db, id, true, changedBy) })
+}
+
+// UnpinIssue unpins an issue and records a "pinned" activity entry. It is a
+// no-op for an issue that is not pinned.
+func UnpinIssue(db *sql.DB, id int, changedBy string) error {
+	return WithRetry(func() error { return setPinned(db, id, false, changedBy) })
+}
+
+// setPinned sets the pinned flag of an issue to pinned.
+func setPinned(db *sql.DB, id int, pinned bool, changedBy string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	issue, err := getIssueTx(tx, id)
+	if err != nil {
+		return err
+	}
+	if issue.Pinned == pinned {
+		return nil
+	}
+
+	if _, err := tx.Exec(
+		`UPDATE issues SET pinned = ?, updated_at = ? WHERE id = ?`,
+		pinned, time.Now().UTC().Format(time.RFC3339), id,
+	); err != nil {
+		return fmt.Errorf("updating pinned: %w", err)
+	}
+	if err := RecordActivity(tx, id, "pinned", strconv.FormatBool(issue.Pinned), strconv.FormatBool(pinned), changedBy); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestPinIssue_RecordsActivity(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	id := mustCreateIssue(t, d, "a")
+
+	if err := PinIssue(d, id, "alice"); err != nil {
+		t.Fatalf("PinIssue: %v", err)
+	}
+	// Pinning a pinned issue records nothing.
+	if err := PinIssue(d, id, "alice"); err != nil {
+		t.Fatalf("PinIssue again: %v", err)
+	}
+	issue, err := GetIssue(d, id)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if !issue.Pinned {
+		t.Error("Pinned = false after PinIssue, want true")
+	}
+
+	if err := UnpinIssue(d, id, "bob"); err != nil {
+		t.Fatalf("UnpinIssue: %v", err)
+	}
+
+	activity, err := GetActivity(d, id, 0)
+	if err != nil {
+		t.Fatalf("GetActivity: %v", err)
+	}
+	var changes []string
+	for _, a := range activity {
+		if a.FieldChanged == "pinned" {
+			changes = append(changes, a.OldValue+"->"+a.NewValue+":"+a.ChangedBy)
+		}
+	}
+	// Both entries may share a timestamp, so compare them in sorted order.
+	slices.Sort(changes)
+	if want := []string{"false->true:alice", "true->false:bob"}; !reflect.DeepEqual(changes, want) {
+		t.Errorf("pinned activity = %v, want %v", changes, want)
+	}
+
+	if err := PinIssue(d, 999, "alice"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("pinning a missing issue: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestListIssues_PinnedFirst(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	low := createTestIssue(t, d, "low", model.StatusTodo, model.PriorityLow)
+	high := createTestIssue(t, d, "high", model.StatusTodo, model.PriorityHigh)
+	pinnedLow := createTestIssue(t, d, "pinned low", model.StatusTodo, model.PriorityLow)
+	pinnedHigh := createTestIssue(t, d, "pinned high", model.StatusTodo, model.PriorityHigh)
+	for _, id := range []int{pinnedLow, pinnedHigh} {
+		if err := PinIssue(d, id, "alice"); err != nil {
+			t.Fatalf("PinIssue: %v", err)
+		}
+	}
+
+	byPriority := []SortKey{{Field: "priority", Dir: "desc"}}
+	if got, want := listIDs(t, d, ListOptions{SortKeys: byPriority}), []int{high, pinnedHigh, low, pinnedLow}; !reflect.DeepEqual(got, want) {
+		t.Errorf("without PinnedFirst = %v, want %v", got, want)
+	}
+	// Pinned issues lead, and the sort still orders each group.
+	if got, want := listIDs(t, d, ListOptions{SortKeys: byPriority, PinnedFirst: true}), []int{pinnedHigh, pinnedLow, high, low}; !reflect.DeepEqual(got, want) {
+		t.Errorf("with PinnedFirst = %v, want %v", got, want)
+	}
+
+	pinned, unpinned := true, false
+	if got, want := listIDs(t, d, ListOptions{SortKeys: byPriority, Pinned: &pinned}), []int{pinnedHigh, pinnedLow}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Pinned filter = %v, want %v", got, want)
+	}
+	if got, want := listIDs(t, d, ListOptions{SortKeys: byPriority, Pinned: &unpinned}), []int{high, low}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unpinned filter = %v, want %v", got, want)
+	}
+}
+
+func TestListBoardIssues_PinnedOutsidePage(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	var ids []int
+	for i := range 5 {
+		ids = append(ids, createTestIssue(t, d, fmt.Sprintf("todo %d", i), model.StatusTodo, model.PriorityMedium))
+	}
+	// The first issue sorts last by ID descending; pinned, it leads every
+	// page.
+	byID := []SortKey{{Field: "id", Dir: "desc"}}
+	pinned := ids[0]
+	if err := PinIssue(d, pinned, "alice"); err != nil {
+		t.Fatalf("PinIssue: %v", err)
+	}
+
+	first, err := ListBoardIssues(d, BoardQueryOptions{SortKeys: byID, Limit: 2})
+	if err != nil {
+		t.Fatalf("ListBoardIssues: %v", err)
+	}
+	if got, want := boardIDs(first.Columns[model.StatusTodo]), []int{pinned, ids[4], ids[3]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("first page = %v, want %v", got, want)
+	}
+	second, err := ListBoardIssues(d, BoardQueryOptions{SortKeys: byID, Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("ListBoardIssues: %v", err)
+	}
+	if got, want := boardIDs(second.Columns[model.StatusTodo]), []int{pinned, ids[2], ids[1]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("second page = %v, want %v", got, want)
+	}
+	if second.Totals[model.StatusTodo] != 5 {
+		t.Errorf("todo total = %d, want 5", second.Totals[model.StatusTodo])
+	}
+}
@@ -27,6 +27,10 @@ type CastVoteResult struct {
 
 // CreateProposal inserts a new proposal and returns its ID.
 func CreateProposal(db *sql.DB, p *model.Proposal) (int, error) {
+	return withRetryValue(func() (int, error) { return createProposal(db, p) })
+}
+
+func createProposal(db *sql.DB, p *model.Proposal) (int, error) {
 	now := time.Now().UTC().Format(time.RFC3339)
 
 	domainTagsJSON, err := json.Marshal(p.DomainTags)
@@ -149,6 +153,10 @@ func ListProposals(db *sql.DB, status string, criticality string, domainTag stri
 // Returns ErrNotFound if the proposal does not exist.
 // Returns ErrConflict if the voter already voted or the proposal is already finalized.
 func CastVote(db *sql.DB, v *model.Vote) (*CastVoteResult, error) {
+	return withRetryValue(func() (*CastVoteResult, error) { return castVote(db, v) })
+}
+
+func castVote(db *sql.DB, v *model.Vote) (*CastVoteResult, error) {
 	tx, err := db.Begin()
 	if err != nil {
 		return nil, fmt.Errorf("beginning transaction: %w", err)
@@ -346,6 +354,10 @@ func GetProposalVotes(db *sql.DB, proposalID int) ([]*model.Vote, error) {
 // Returns ErrNotFound if the proposal or issue does not exist.
 // Returns ErrConflict if the link already exists.
 func LinkProposalIssue(db *sql.DB, proposalID, issueID int) error {
+	return WithRetry(func() error { return linkProposalIssue(db, proposalID, issueID) })
+}
+
+func linkProposalIssue(db *sql.DB, proposalID, issueID int) error {
 	// Check proposal exists.
 	var proposalExists bool
 	if err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM proposals WHERE id = ?)", proposalID).Scan(&proposalExists); err != nil {
@@ -357,7 +369,7 @@ func LinkProposalIssue(db *sql.DB, proposalID, issueID int) error {
 
 	// Check issue exists.
 	var issueExists bool
-	if err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)", issueID).Scan(&issueExists); err != nil {
+	if err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM issues WHERE id = ? AND deleted_at IS NULL)", issueID).Scan(&issueExists); err != nil {
 		return fmt.Errorf("checking issue existence: %w", err)
 	}
 	if !issueExists {
@@ -381,6 +393,10 @@ func LinkProposalIssue(db *sql.DB, proposalID, issueID int) error {
 // UnlinkProposalIssue removes a link between a proposal and an issue.
 // Returns ErrNotFound if the link does not exist.
 func UnlinkProposalIssue(db *sql.DB, proposalID, issueID int) error {
+	return WithRetry(func() error { return unlinkProposalIssue(db, proposalID, issueID) })
+}
+
+func unlinkProposalIssue(db *sql.DB, proposalID, issueID int) error {
 	res, err := db.Exec(
 		"DELETE FROM proposal_issues WHERE proposal_id = ? AND issue_id = ?",
 		proposalID, issueID,
@@ -403,7 +419,7 @@ func UnlinkProposalIssue(db *sql.DB, proposalID, issueID int) error {
 // GetProposalIssues returns the issue IDs linked to a proposal.
 func GetProposalIssues(db *sql.DB, proposalID int) ([]int, error) {
 	rows, err := db.Query(
-		"SELECT issue_id FROM proposal_issues WHERE proposal_id = ? ORDER BY issue_id ASC",
+		"SELECT issue_id FROM proposal_issues WHERE proposal_id = ? AND issue_id IN "+liveIssueIDs+" ORDER BY issue_id ASC",
 		proposalID,
 	)
 	if err != nil {
@@ -460,6 +476,10 @@ func GetIssueProposals(db *sql.DB, issueID int) ([]model.Proposal, error) {
 // CommitProposal transitions an approved proposal to committed status with a final outcome.
 // If escalationReason is non-empty, it is stored on the proposal.
 func CommitProposal(db *sql.DB, id int, outcome string, escalationReason string) error {
+	return WithRetry(func() error { return commitProposal(db, id, outcome, escalationReason) })
+}
+
+func commitProposal(db *sql.DB, id int, outcome string, escalationReason string) error {
 	tx, err := db.Begin()
 	if err != nil {
 		return fmt.Errorf("beginning transaction: %w", err)
@@ -596,6 +616,21 @@ func scanVoteFrom(s scanner) (*model.Vote, error) {
 // ListAllProposals returns every proposal row ordered by id ASC, for a full
 // export.
 func ListAllProposals(db *sql.DB) ([]*model.Proposal, error) {
+	var proposals []*model.Proposal
+	err := StreamProposals(db, func(v *model.Proposal) error {
+		proposals = append(proposals, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return proposals, nil
+}
+
+// StreamProposals calls fn for every proposal row in the same order as
+// ListAllProposals without buffering the result set. fn runs while the cursor
+// is open and must not query db.
+func StreamProposals(db *sql.DB, fn func(*model.Proposal) error) error {
 	rows, err := db.Query(
 		`SELECT id, description, rationale, domain_tags, files_changed, criticality,
 		        status, final_outcome, escalation_reason, required_voters, threshold,
@@ -603,74 +638,107 @@ func ListAllProposals(db *sql.DB) ([]*model.Proposal, error) {
 		 FROM proposals ORDER BY id ASC`,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("querying all proposals: %w", err)
+		return fmt.Errorf("querying all proposals: %w", err)
 	}
 	defer rows.Close()
 
-	var proposals []*model.Proposal
 	for rows.Next() {
 		p, err := scanProposalFrom(rows)
 		if err != nil {
-			return nil, fmt.Errorf("scanning proposal row: %w", err)
+			return fmt.Errorf("scanning proposal row: %w", err)
+		}
+		if err := fn(p); err != nil {
+			return err
 		}
-		proposals = append(proposals, p)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating proposal rows: %w", err)
+		return fmt.Errorf("iterating proposal rows: %w", err)
 	}
-	return proposals, nil
+	return nil
 }
 
 // ListAllVotes returns every vote row ordered by id ASC, for a full export.
 func ListAllVotes(db *sql.DB) ([]*model.Vote, error) {
+	var votes []*model.Vote
+	err := StreamVotes(db, func(v *model.Vote) error {
+		votes = append(votes, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return votes, nil
+}
+
+// StreamVotes calls fn for every vote row in the same order as ListAllVotes
+// without buffering the result set. fn runs while the cursor is open and must
+// not query db.
+func StreamVotes(db *sql.DB, fn func(*model.Vote) error) error {
 	rows, err := db.Query(
 		`SELECT id, proposal_id, voter_name, voter_role, verdict, confidence,
 		        domain_relevance, findings, findings_json, summary, created_at
 		 FROM votes ORDER BY id ASC`,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("querying all votes: %w", err)
+		return fmt.Errorf("querying all votes: %w", err)
 	}
 	defer rows.Close()
 
-	var votes []*model.Vote
 	for rows.Next() {
 		v, err := scanVoteFrom(rows)
 		if err != nil {
-			return nil, fmt.Errorf("scanning vote row: %w", err)
+			return fmt.Errorf("scanning vote row: %w", err)
+		}
+		if err := fn(v); err != nil {
+			return err
 		}
-		votes = append(votes, v)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating vote rows: %w", err)
+		return fmt.Errorf("iterating vote rows: %w", err)
 	}
-	return votes, nil
+	return nil
 }
 
 // ListAllProposalIssues returns every proposal_issues row ordered by
 // (proposal_id, issue_id), for a full export.
 func ListAllProposalIssues(db *sql.DB) ([]model.ProposalIssueLink, error) {
+	out := make([]model.ProposalIssueLink, 0)
+	err := StreamProposalIssues(db, func(v model.ProposalIssueLink) error {
+		out = append(out, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StreamProposalIssues calls fn for every proposal-issue link row in the same
+// order as ListAllProposalIssues without buffering the result set. fn runs
+// while the cursor is open and must not query db.
+func StreamProposalIssues(db *sql.DB, fn func(model.ProposalIssueLink) error) error {
 	rows, err := db.Query(
 		`SELECT proposal_id, issue_id
-		 FROM proposal_issues ORDER BY proposal_id ASC, issue_id ASC`,
+		 FROM proposal_issues WHERE issue_id IN ` + liveIssueIDs + ` ORDER BY proposal_id ASC, issue_id ASC`,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("querying all proposal_issues: %w", err)
+		return fmt.Errorf("querying all proposal_issues: %w", err)
 	}
 	defer rows.Close()
 
-	out := make([]model.ProposalIssueLink, 0)
 	for rows.Next() {
 		var l model.ProposalIssueLink
 		if err := rows.Scan(&l.ProposalID, &l.IssueID); err != nil {
-			return nil, fmt.Errorf("scanning proposal_issue row: %w", err)
+			return fmt.Errorf("scanning proposal_issue row: %w", err)
+		}
+		if err := fn(l); err != nil {
+			return err
 		}
-		out = append(out, l)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating proposal_issue rows: %w", err)
+		return fmt.Errorf("iterating proposal_issue rows: %w", err)
 	}
-	return out, nil
+	return nil
 }
 
 // InsertProposalWithID inserts a proposal row with a caller-supplied ID,
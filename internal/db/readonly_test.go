@@ -0,0 +1,81 @@
+package db
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestOpenReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.db")
+
+	rw, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := Initialize(rw); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	if err := Migrate(rw); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	mustCreateIssue(t, rw, "existing")
+	rw.Close()
+
+	ro, err := OpenReadOnly(path)
+	if err != nil {
+		t.Fatalf("OpenReadOnly: %v", err)
+	}
+	t.Cleanup(func() { ro.Close() })
+
+	if readOnly, err := IsReadOnly(ro); err != nil || !readOnly {
+		t.Errorf("IsReadOnly = %v, %v; want true", readOnly, err)
+	}
+	if n, err := CountIssues(ro); err != nil || n != 1 {
+		t.Errorf("CountIssues = %d, %v; want 1", n, err)
+	}
+	if err := CheckSchemaCurrent(ro); err != nil {
+		t.Errorf("CheckSchemaCurrent: %v", err)
+	}
+	if _, err := CreateIssue(ro, &model.Issue{
+		Title:    "new",
+		Status:   model.StatusBacklog,
+		Priority: model.PriorityNone,
+		Kind:     model.IssueKindTask,
+	}, nil, nil); err == nil {
+		t.Error("CreateIssue succeeded on a read-only database")
+	}
+
+	st, err := GetLockStatus(ro)
+	if err != nil {
+		t.Fatalf("GetLockStatus: %v", err)
+	}
+	if !st.ReadOnly || st.WriteLocked {
+		t.Errorf("lock status = %+v, want read-only and unlocked", st)
+	}
+}
+
+func TestOpenReadOnlyMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.db")
+	db, err := OpenReadOnly(path)
+	if err == nil {
+		// sql.Open is lazy; the pragmas should already have failed.
+		db.Close()
+		t.Fatal("OpenReadOnly succeeded for a missing file")
+	}
+}
+
+func TestCheckSchemaCurrentOutdated(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE meta SET value = '9' WHERE key = 'schema_version'`); err != nil {
+		t.Fatalf("stamping v9: %v", err)
+	}
+	if err := CheckSchemaCurrent(db); !errors.Is(err, ErrSchemaOutdated) {
+		t.Errorf("CheckSchemaCurrent = %v, want ErrSchemaOutdated", err)
+	}
+}
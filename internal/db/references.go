@@ -0,0 +1,152 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// syncReferencesTx replaces the references recorded for one piece of text
+// (an issue description, or a single comment when commentID is non-nil) with
+// the issue IDs currently mentioned in it. Mentions of the issue itself and of
+// nonexistent issues are ignored. Must be called within an existing transaction.
+func syncReferencesTx(tx *sql.Tx, fromIssueID int, context model.ReferenceContext, commentID *int, text string) error {
+	var err error
+	if commentID != nil {
+		_, err = tx.Exec(`DELETE FROM issue_references WHERE comment_id = ?`, *commentID)
+	} else {
+		_, err = tx.Exec(
+			`DELETE FROM issue_references WHERE from_issue_id = ? AND context = ? AND comment_id IS NULL`,
+			fromIssueID, string(context),
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("clearing issue references: %w", err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, toID := range model.ExtractIssueRefs(text) {
+		if toID == fromIssueID {
+			continue
+		}
+		var exists bool
+		if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM issues WHERE id = ? AND deleted_at IS NULL)", toID).Scan(&exists); err != nil {
+			return fmt.Errorf("checking referenced issue existence: %w", err)
+		}
+		if !exists {
+			continue
+		}
+		if _, err := tx.Exec(
+			`INSERT INTO issue_references (from_issue_id, to_issue_id, context, comment_id, created_at)
+			 VALUES (?, ?, ?, ?, ?)`,
+			fromIssueID, toID, string(context), commentID, now,
+		); err != nil {
+			return fmt.Errorf("inserting issue reference: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetIssueReferences returns every reference from or to the given issue,
+// ordered by creation time. Callers distinguish outgoing references
+// (FromIssueID == issueID) from incoming ones.
+func GetIssueReferences(db *sql.DB, issueID int) ([]model.IssueReference, error) {
+	rows, err := db.Query(
+		`SELECT from_issue_id, to_issue_id, context, comment_id, created_at
+		 FROM issue_references
+		 WHERE (from_issue_id = ? OR to_issue_id = ?)
+		   AND from_issue_id IN `+liveIssueIDs+` AND to_issue_id IN `+liveIssueIDs+`
+		 ORDER BY created_at ASC, id ASC`, issueID, issueID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying issue references: %w", err)
+	}
+	defer rows.Close()
+
+	refs := make([]model.IssueReference, 0)
+	for rows.Next() {
+		var r model.IssueReference
+		var context, createdAt string
+		var commentID sql.NullInt64
+		if err := rows.Scan(&r.FromIssueID, &r.ToIssueID, &context, &commentID, &createdAt); err != nil {
+			return nil, fmt.Errorf("scanning issue reference: %w", err)
+		}
+		r.Context = model.ReferenceContext(context)
+		if commentID.Valid {
+			cid := int(commentID.Int64)
+			r.CommentID = &cid
+		}
+		t, err := time.Parse(time.RFC3339, createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("parsing created_at: %w", err)
+		}
+		r.CreatedAt = t
+		refs = append(refs, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating issue references: %w", err)
+	}
+	return refs, nil
+}
+
+// BackfillReferences rebuilds the issue_references table from every existing
+// issue description and comment, returning the number of references stored.
+func BackfillReferences(db *sql.DB) (int, error) {
+	return withRetryValue(func() (int, error) { return backfillReferences(db) })
+}
+
+func backfillReferences(db *sql.DB) (int, error) {
+	type source struct {
+		issueID   int
+		commentID *int
+		text      string
+	}
+
+	var sources []source
+	err := StreamIssues(db, func(issue *model.Issue) error {
+		sources = append(sources, source{issueID: issue.ID, text: issue.Description})
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	err = StreamComments(db, func(c *model.Comment) error {
+		cid := c.ID
+		sources = append(sources, source{issueID: c.IssueID, commentID: &cid, text: c.Body})
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM issue_references`); err != nil {
+		return 0, fmt.Errorf("clearing issue references: %w", err)
+	}
+	for _, src := range sources {
+		context := model.ReferenceContextDescription
+		if src.commentID != nil {
+			context = model.ReferenceContextComment
+		}
+		if err := syncReferencesTx(tx, src.issueID, context, src.commentID, src.text); err != nil {
+			return 0, err
+		}
+	}
+
+	var n int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM issue_references`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("counting issue references: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing transaction: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestIssueReferencesFromDescriptionAndComments(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	target := mustCreateIssue(t, db, "target")
+	src, err := CreateIssue(db, &model.Issue{
+		Title:       "source",
+		Description: "see DKT-1, dkt-1 again, itself DKT-2, and missing DKT-999",
+		Status:      model.StatusBacklog,
+		Priority:    model.PriorityNone,
+		Kind:        model.IssueKindTask,
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	commentID, err := CreateComment(db, &model.Comment{IssueID: target, Body: "duplicate of DKT-2", Author: "alice"})
+	if err != nil {
+		t.Fatalf("CreateComment: %v", err)
+	}
+
+	refs, err := GetIssueReferences(db, target)
+	if err != nil {
+		t.Fatalf("GetIssueReferences: %v", err)
+	}
+	if len(refs) != 2 {
+		t.Fatalf("len(refs) = %d, want 2: %+v", len(refs), refs)
+	}
+	if r := refs[0]; r.FromIssueID != src || r.ToIssueID != target || r.Context != model.ReferenceContextDescription || r.CommentID != nil {
+		t.Errorf("refs[0] = %+v, want description reference from %d", r, src)
+	}
+	if r := refs[1]; r.FromIssueID != target || r.ToIssueID != src || r.Context != model.ReferenceContextComment || r.CommentID == nil || *r.CommentID != commentID {
+		t.Errorf("refs[1] = %+v, want comment reference to %d", r, src)
+	}
+
+	// Editing the description replaces its references.
+	if err := UpdateIssue(db, src, map[string]interface{}{"description": "no links now"}, "tester"); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+	refs, err = GetIssueReferences(db, target)
+	if err != nil {
+		t.Fatalf("GetIssueReferences: %v", err)
+	}
+	if len(refs) != 1 || refs[0].Context != model.ReferenceContextComment {
+		t.Errorf("after edit refs = %+v, want only the comment reference", refs)
+	}
+
+	// Deleting the referencing issue cascades.
+	if err := DeleteIssue(db, target); err != nil {
+		t.Fatalf("DeleteIssue: %v", err)
+	}
+	refs, err = GetIssueReferences(db, src)
+	if err != nil {
+		t.Fatalf("GetIssueReferences: %v", err)
+	}
+	if len(refs) != 0 {
+		t.Errorf("after delete refs = %+v, want none", refs)
+	}
+}
+
+func TestBackfillReferences(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, db, "a")
+	b := mustCreateIssue(t, db, "b")
+
+	// Write text directly so no references are recorded yet.
+	if _, err := db.Exec(`UPDATE issues SET description = 'blocked on DKT-2' WHERE id = ?`, a); err != nil {
+		t.Fatalf("setting description: %v", err)
+	}
+	if _, err := db.Exec(
+		`INSERT INTO comments (issue_id, body, author, created_at) VALUES (?, 'see DKT-1', 'bob', '2026-01-01T00:00:00Z')`, b,
+	); err != nil {
+		t.Fatalf("inserting comment: %v", err)
+	}
+
+	n, err := BackfillReferences(db)
+	if err != nil {
+		t.Fatalf("BackfillReferences: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("BackfillReferences = %d, want 2", n)
+	}
+
+	// Running again is idempotent.
+	if n, err = BackfillReferences(db); err != nil || n != 2 {
+		t.Errorf("second BackfillReferences = %d, %v; want 2, nil", n, err)
+	}
+}
@@ -0,0 +1,176 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// RelationImportLine is one relation read from line Line of an import file.
+type RelationImportLine struct {
+	Line     int
+	Relation model.Relation
+}
+
+// RelationImportOutcome is what ImportRelations did with one line. For a
+// created relation Relation.ID is set, except in a dry run; for a skipped one
+// Reason says why.
+type RelationImportOutcome struct {
+	Line     int
+	Relation model.Relation
+	Reason   string
+}
+
+// RelationImportResult lists the lines ImportRelations created relations
+// for and the lines it skipped, each in file order.
+type RelationImportResult struct {
+	Created []RelationImportOutcome
+	Skipped []RelationImportOutcome
+}
+
+// RelationLineError reports why line Line of an import file was rejected.
+type RelationLineError struct {
+	Line int
+	Err  error
+}
+
+func (e *RelationLineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RelationLineError) Unwrap() error { return e.Err }
+
+// RelationImportError reports every rejected line of an import, in file
+// order. It unwraps to the error of each line.
+type RelationImportError struct {
+	Lines []*RelationLineError
+}
+
+func (e *RelationImportError) Error() string {
+	msgs := make([]string, len(e.Lines))
+	for i, le := range e.Lines {
+		msgs[i] = le.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *RelationImportError) Unwrap() []error {
+	errs := make([]error, len(e.Lines))
+	for i, le := range e.Lines {
+		errs[i] = le
+	}
+	return errs
+}
+
+// RelationImportOptions controls ImportRelations.
+type RelationImportOptions struct {
+	// DryRun checks and creates every relation and then rolls the
+	// transaction back, so the result shows what would happen.
+	DryRun bool
+	// ChangedBy is recorded as the author of the activity entries.
+	ChangedBy string
+}
+
+// ImportRelations creates the relations in lines in a single transaction.
+// Each line is checked in order against the database as changed by the lines
+// before it, so a cycle formed within the batch is reported on the line that
+// closes it: issues must be live, types known, and directional relations
+// must not form cycles. A relation that already exists, in either
+// direction, including one created by an earlier line, is skipped rather
+// than rejected, so an import can be run again. Every rejected line is
+// reported in a *RelationImportError, and then nothing is created.
+func ImportRelations(db *sql.DB, lines []RelationImportLine, opts RelationImportOptions) (*RelationImportResult, error) {
+	return withRetryValue(func() (*RelationImportResult, error) { return importRelations(db, lines, opts) })
+}
+
+func importRelations(db *sql.DB, lines []RelationImportLine, opts RelationImportOptions) (*RelationImportResult, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result := &RelationImportResult{Created: []RelationImportOutcome{}, Skipped: []RelationImportOutcome{}}
+	var rejected []*RelationLineError
+	for _, line := range lines {
+		outcome, skipped, err := importRelationTx(tx, line, opts)
+		switch {
+		case err != nil:
+			if !isRelationLineError(err) {
+				return nil, err
+			}
+			rejected = append(rejected, &RelationLineError{Line: line.Line, Err: err})
+		case skipped:
+			result.Skipped = append(result.Skipped, outcome)
+		default:
+			result.Created = append(result.Created, outcome)
+		}
+	}
+
+	if len(rejected) > 0 {
+		return nil, &RelationImportError{Lines: rejected}
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return result, nil
+}
+
+// importRelationTx creates the relation on one import line, or reports that
+// it already exists. A dry run rolls back every relation it creates, so its
+// outcomes cite relations by pair and type only, never by ID.
+func importRelationTx(tx *sql.Tx, line RelationImportLine, opts RelationImportOptions) (RelationImportOutcome, bool, error) {
+	rel := line.Relation
+	outcome := RelationImportOutcome{Line: line.Line, Relation: rel}
+	if rel.SourceIssueID == rel.TargetIssueID {
+		return outcome, false, ErrSelfRelation
+	}
+	if _, err := relationTypeDirectionalTx(tx, rel.RelationType); err != nil {
+		return outcome, false, err
+	}
+	for _, id := range []int{rel.SourceIssueID, rel.TargetIssueID} {
+		if err := requireLiveIssueTx(tx, id); err != nil {
+			return outcome, false, err
+		}
+	}
+
+	if err := checkDuplicateTx(tx, rel.SourceIssueID, rel.TargetIssueID, rel.RelationType); err != nil {
+		var dup *DuplicateRelationError
+		if !errors.As(err, &dup) {
+			return outcome, false, err
+		}
+		outcome.Reason = "already exists as " + formatRelation(dup.Existing)
+		if !opts.DryRun {
+			outcome.Reason += fmt.Sprintf(" (#%d)", dup.Existing.ID)
+		}
+		return outcome, true, nil
+	}
+
+	id, err := createRelationTx(tx, &rel, CreateRelationOptions{ChangedBy: opts.ChangedBy})
+	if err != nil {
+		return outcome, false, err
+	}
+	if !opts.DryRun {
+		outcome.Relation.ID = id
+	}
+	return outcome, false, nil
+}
+
+// isRelationLineError reports whether err rejects a single import line, as
+// opposed to a database failure that aborts the import.
+func isRelationLineError(err error) bool {
+	return errors.Is(err, ErrValidation) || errors.Is(err, ErrNotFound) ||
+		errors.Is(err, ErrSelfRelation) || errors.Is(err, ErrCycleDetected)
+}
+
+// formatRelation formats r as "DKT-3 blocks DKT-7".
+func formatRelation(r model.Relation) string {
+	return fmt.Sprintf("%s %s %s",
+		model.FormatID(r.SourceIssueID), r.RelationType, model.FormatID(r.TargetIssueID))
+}
@@ -0,0 +1,286 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// importLines builds import lines numbered from 1 from relations.
+func importLines(rels ...model.Relation) []RelationImportLine {
+	lines := make([]RelationImportLine, len(rels))
+	for i, rel := range rels {
+		lines[i] = RelationImportLine{Line: i + 1, Relation: rel}
+	}
+	return lines
+}
+
+func edge(source int, rt model.RelationType, target int) model.Relation {
+	return model.Relation{SourceIssueID: source, TargetIssueID: target, RelationType: rt}
+}
+
+// mustImportError runs ImportRelations expecting it to reject lines, and
+// returns the rejected lines.
+func mustImportError(t *testing.T, d *sql.DB, lines []RelationImportLine) []*RelationLineError {
+	t.Helper()
+	result, err := ImportRelations(d, lines, RelationImportOptions{})
+	var importErr *RelationImportError
+	if !errors.As(err, &importErr) {
+		t.Fatalf("ImportRelations: got (%v, %v), want *RelationImportError", result, err)
+	}
+	return importErr.Lines
+}
+
+func assertRelationCount(t *testing.T, d *sql.DB, want int) {
+	t.Helper()
+	rels, err := GetAllRelations(d)
+	if err != nil {
+		t.Fatalf("GetAllRelations: %v", err)
+	}
+	if len(rels) != want {
+		t.Errorf("got %d relations, want %d: %v", len(rels), want, rels)
+	}
+}
+
+func TestImportRelations_CreatesInOneBatch(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	a, b, c := mustCreateIssue(t, d, "A"), mustCreateIssue(t, d, "B"), mustCreateIssue(t, d, "C")
+
+	result, err := ImportRelations(d, importLines(
+		edge(a, model.RelationBlocks, b),
+		edge(b, model.RelationBlocks, c),
+		edge(a, model.RelationRelatesTo, c),
+	), RelationImportOptions{ChangedBy: "alice"})
+	if err != nil {
+		t.Fatalf("ImportRelations: %v", err)
+	}
+	if len(result.Created) != 3 || len(result.Skipped) != 0 {
+		t.Fatalf("got %d created, %d skipped, want 3 and 0", len(result.Created), len(result.Skipped))
+	}
+	for i, o := range result.Created {
+		if o.Line != i+1 || o.Relation.ID == 0 {
+			t.Errorf("Created[%d] = %+v, want line %d with an ID", i, o, i+1)
+		}
+	}
+	assertRelationCount(t, d, 3)
+}
+
+func TestImportRelations_CycleWithinBatch(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	a, b, c := mustCreateIssue(t, d, "A"), mustCreateIssue(t, d, "B"), mustCreateIssue(t, d, "C")
+
+	// No line forms a cycle with the database alone; the third closes one
+	// with the two before it.
+	rejected := mustImportError(t, d, importLines(
+		edge(a, model.RelationBlocks, b),
+		edge(b, model.RelationBlocks, c),
+		edge(c, model.RelationBlocks, a),
+	))
+	if len(rejected) != 1 || rejected[0].Line != 3 {
+		t.Fatalf("rejected = %v, want only line 3", rejected)
+	}
+	var cycleErr *CycleError
+	if !errors.As(rejected[0], &cycleErr) {
+		t.Fatalf("line 3 error = %v, want *CycleError", rejected[0].Err)
+	}
+	if len(cycleErr.Path) == 0 {
+		t.Error("CycleError.Path is empty")
+	}
+	assertRelationCount(t, d, 0)
+}
+
+func TestImportRelations_CycleReportedOnClosingLine(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	a, b, c := mustCreateIssue(t, d, "A"), mustCreateIssue(t, d, "B"), mustCreateIssue(t, d, "C")
+
+	// The same three edges in a different order: the cycle is reported on
+	// whichever line completes it.
+	rejected := mustImportError(t, d, importLines(
+		edge(c, model.RelationBlocks, a),
+		edge(a, model.RelationBlocks, b),
+		edge(b, model.RelationBlocks, c),
+	))
+	if len(rejected) != 1 || rejected[0].Line != 3 || !errors.Is(rejected[0], ErrCycleDetected) {
+		t.Fatalf("rejected = %v, want a cycle on line 3", rejected)
+	}
+	assertRelationCount(t, d, 0)
+}
+
+func TestImportRelations_CycleWithExistingRelations(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	a, b, c := mustCreateIssue(t, d, "A"), mustCreateIssue(t, d, "B"), mustCreateIssue(t, d, "C")
+	mustCreateRelation(t, d, a, b, model.RelationBlocks)
+
+	rejected := mustImportError(t, d, importLines(
+		edge(b, model.RelationBlocks, c),
+		edge(c, model.RelationBlocks, a),
+	))
+	if len(rejected) != 1 || rejected[0].Line != 2 || !errors.Is(rejected[0], ErrCycleDetected) {
+		t.Fatalf("rejected = %v, want a cycle on line 2", rejected)
+	}
+	assertRelationCount(t, d, 1)
+}
+
+func TestImportRelations_NonDirectionalLoopAllowed(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	a, b, c := mustCreateIssue(t, d, "A"), mustCreateIssue(t, d, "B"), mustCreateIssue(t, d, "C")
+
+	result, err := ImportRelations(d, importLines(
+		edge(a, model.RelationRelatesTo, b),
+		edge(b, model.RelationRelatesTo, c),
+		edge(c, model.RelationRelatesTo, a),
+	), RelationImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportRelations: %v", err)
+	}
+	if len(result.Created) != 3 {
+		t.Errorf("got %d created, want 3", len(result.Created))
+	}
+}
+
+func TestImportRelations_SkipsDuplicates(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	a, b, c := mustCreateIssue(t, d, "A"), mustCreateIssue(t, d, "B"), mustCreateIssue(t, d, "C")
+	mustCreateRelation(t, d, a, b, model.RelationBlocks)
+
+	result, err := ImportRelations(d, importLines(
+		edge(a, model.RelationBlocks, b),    // exists
+		edge(b, model.RelationRelatesTo, c), // new
+		edge(c, model.RelationRelatesTo, b), // inverse of line 2
+		edge(b, model.RelationRelatesTo, c), // repeat of line 2
+	), RelationImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportRelations: %v", err)
+	}
+	if len(result.Created) != 1 || result.Created[0].Line != 2 {
+		t.Fatalf("Created = %+v, want only line 2", result.Created)
+	}
+	var skippedLines []int
+	for _, o := range result.Skipped {
+		if o.Reason == "" {
+			t.Errorf("line %d skipped without a reason", o.Line)
+		}
+		skippedLines = append(skippedLines, o.Line)
+	}
+	if len(skippedLines) != 3 || skippedLines[0] != 1 || skippedLines[1] != 3 || skippedLines[2] != 4 {
+		t.Errorf("skipped lines = %v, want [1 3 4]", skippedLines)
+	}
+	assertRelationCount(t, d, 2)
+}
+
+func TestImportRelations_ReportsEveryRejectedLine(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	a, b := mustCreateIssue(t, d, "A"), mustCreateIssue(t, d, "B")
+	if _, err := TrashIssue(d, b, "alice"); err != nil {
+		t.Fatalf("TrashIssue: %v", err)
+	}
+
+	rejected := mustImportError(t, d, importLines(
+		edge(a, model.RelationBlocks, 999),
+		edge(a, model.RelationBlocks, a),
+		edge(a, "unknown_type", 999),
+		edge(a, model.RelationBlocks, b),
+	))
+	want := []error{ErrNotFound, ErrSelfRelation, ErrValidation, ErrNotFound}
+	if len(rejected) != len(want) {
+		t.Fatalf("rejected = %v, want %d lines", rejected, len(want))
+	}
+	for i, le := range rejected {
+		if le.Line != i+1 || !errors.Is(le, want[i]) {
+			t.Errorf("rejected[%d] = %v, want line %d: %v", i, le, i+1, want[i])
+		}
+	}
+	assertRelationCount(t, d, 0)
+}
+
+func TestImportRelations_DryRun(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	a, b, c := mustCreateIssue(t, d, "A"), mustCreateIssue(t, d, "B"), mustCreateIssue(t, d, "C")
+
+	lines := importLines(
+		edge(a, model.RelationBlocks, b),
+		edge(b, model.RelationBlocks, c),
+	)
+	result, err := ImportRelations(d, lines, RelationImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("ImportRelations dry run: %v", err)
+	}
+	if len(result.Created) != 2 {
+		t.Errorf("dry run got %d created, want 2", len(result.Created))
+	}
+	assertRelationCount(t, d, 0)
+
+	// A dry run still rejects a cycle closed within the batch.
+	lines = append(lines, RelationImportLine{Line: 3, Relation: edge(c, model.RelationBlocks, a)})
+	if _, err := ImportRelations(d, lines, RelationImportOptions{DryRun: true}); !errors.Is(err, ErrCycleDetected) {
+		t.Errorf("dry run with cycle: got %v, want ErrCycleDetected", err)
+	}
+	assertRelationCount(t, d, 0)
+}
+
+func TestImportRelations_DryRunOmitsRelationIDs(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	a, b, c := mustCreateIssue(t, d, "A"), mustCreateIssue(t, d, "B"), mustCreateIssue(t, d, "C")
+	mustCreateRelation(t, d, a, b, model.RelationBlocks)
+
+	lines := importLines(
+		edge(a, model.RelationBlocks, b),    // exists
+		edge(b, model.RelationRelatesTo, c), // new
+		edge(b, model.RelationRelatesTo, c), // repeat of line 2, never written
+	)
+	result, err := ImportRelations(d, lines, RelationImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("ImportRelations dry run: %v", err)
+	}
+	for _, o := range result.Created {
+		if o.Relation.ID != 0 {
+			t.Errorf("dry run line %d created relation ID %d, want none", o.Line, o.Relation.ID)
+		}
+	}
+	if len(result.Skipped) != 2 {
+		t.Fatalf("Skipped = %+v, want lines 1 and 3", result.Skipped)
+	}
+	for _, o := range result.Skipped {
+		if o.Reason == "" || strings.Contains(o.Reason, "#") {
+			t.Errorf("dry run line %d reason = %q, want the pair and type only", o.Line, o.Reason)
+		}
+	}
+
+	result, err = ImportRelations(d, lines, RelationImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportRelations: %v", err)
+	}
+	if len(result.Skipped) != 2 || !strings.HasSuffix(result.Skipped[0].Reason, "(#1)") {
+		t.Errorf("Skipped = %+v, want line 1 to cite relation #1", result.Skipped)
+	}
+}
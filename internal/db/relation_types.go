@@ -0,0 +1,137 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// ListRelationTypes returns every relation type, built-in types first, then
+// custom types by name.
+func ListRelationTypes(db *sql.DB) ([]model.RelationTypeDef, error) {
+	rows, err := db.Query(
+		`SELECT name, inverse_name, directional, color, builtin
+		 FROM relation_types
+		 ORDER BY builtin DESC, name ASC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying relation types: %w", err)
+	}
+	defer rows.Close()
+
+	defs := make([]model.RelationTypeDef, 0)
+	for rows.Next() {
+		var d model.RelationTypeDef
+		var name string
+		if err := rows.Scan(&name, &d.Inverse, &d.Directional, &d.Color, &d.Builtin); err != nil {
+			return nil, fmt.Errorf("scanning relation type row: %w", err)
+		}
+		d.Name = model.RelationType(name)
+		defs = append(defs, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating relation type rows: %w", err)
+	}
+	return defs, nil
+}
+
+// ListCustomRelationTypes returns the custom relation types by name. These
+// are the types an export carries; the built-ins exist in every database.
+func ListCustomRelationTypes(db *sql.DB) ([]model.RelationTypeDef, error) {
+	defs, err := ListRelationTypes(db)
+	if err != nil {
+		return nil, err
+	}
+	custom := make([]model.RelationTypeDef, 0, len(defs))
+	for _, d := range defs {
+		if !d.Builtin {
+			custom = append(custom, d)
+		}
+	}
+	return custom, nil
+}
+
+// CreateRelationType registers a custom relation type. Its name and inverse
+// must be valid relation type names; the inverse defaults to the name, which
+// makes the type symmetric. It returns an error wrapping ErrValidation for
+// invalid names and ErrConflict when the name or inverse is already used by
+// another type, as a name or an inverse.
+func CreateRelationType(db *sql.DB, def *model.RelationTypeDef) error {
+	return WithRetry(func() error { return createRelationType(db, def) })
+}
+
+func createRelationType(db *sql.DB, def *model.RelationTypeDef) error {
+	if def.Inverse == "" {
+		def.Inverse = string(def.Name)
+	}
+	for _, name := range []model.RelationType{def.Name, model.RelationType(def.Inverse)} {
+		if err := model.ValidateRelationTypeName(name); err != nil {
+			return fmt.Errorf("%w: %v", ErrValidation, err)
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var taken string
+	err = tx.QueryRow(
+		`SELECT name FROM relation_types
+		 WHERE name IN (?, ?) OR inverse_name IN (?, ?)
+		 LIMIT 1`,
+		string(def.Name), def.Inverse, string(def.Name), def.Inverse,
+	).Scan(&taken)
+	if err == nil {
+		return fmt.Errorf("%w: relation type %q already uses that name or inverse", ErrConflict, taken)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("checking relation type names: %w", err)
+	}
+
+	if _, err := tx.Exec(
+		`INSERT INTO relation_types (name, inverse_name, directional, color, builtin) VALUES (?, ?, ?, ?, 0)`,
+		string(def.Name), def.Inverse, def.Directional, def.Color,
+	); err != nil {
+		return fmt.Errorf("inserting relation type: %w", err)
+	}
+	def.Builtin = false
+	return tx.Commit()
+}
+
+// InsertRelationTypeTx inserts a custom relation type from an import,
+// skipping it if a type with that name already exists. An empty inverse
+// defaults to the name. Returns true if the row was inserted. Must be called
+// within an existing transaction.
+func InsertRelationTypeTx(tx *sql.Tx, def *model.RelationTypeDef) (bool, error) {
+	inverse := def.Inverse
+	if inverse == "" {
+		inverse = string(def.Name)
+	}
+	res, err := tx.Exec(
+		`INSERT OR IGNORE INTO relation_types (name, inverse_name, directional, color, builtin) VALUES (?, ?, ?, ?, 0)`,
+		string(def.Name), inverse, def.Directional, def.Color,
+	)
+	if err != nil {
+		return false, fmt.Errorf("inserting relation type %q: %w", def.Name, err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// relationTypeDirectionalTx reports whether relation type rt is directional.
+// It returns an error wrapping ErrValidation when rt is not registered.
+func relationTypeDirectionalTx(tx *sql.Tx, rt model.RelationType) (bool, error) {
+	var directional bool
+	err := tx.QueryRow(`SELECT directional FROM relation_types WHERE name = ?`, string(rt)).Scan(&directional)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, fmt.Errorf("%w: unknown relation type %q", ErrValidation, rt)
+	}
+	if err != nil {
+		return false, fmt.Errorf("looking up relation type: %w", err)
+	}
+	return directional, nil
+}
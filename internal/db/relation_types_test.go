@@ -0,0 +1,126 @@
+package db
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestListRelationTypesSeedsBuiltins(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	defs, err := ListRelationTypes(d)
+	if err != nil {
+		t.Fatalf("ListRelationTypes: %v", err)
+	}
+	if len(defs) != 5 {
+		t.Fatalf("got %d relation types, want 5 built-ins", len(defs))
+	}
+	for _, def := range defs {
+		if !def.Builtin {
+			t.Errorf("%s: Builtin = false, want true", def.Name)
+		}
+		if def.Inverse != def.Name.Inverse() {
+			t.Errorf("%s: inverse = %q, want %q", def.Name, def.Inverse, def.Name.Inverse())
+		}
+		if def.Directional != def.Name.IsDirectional() {
+			t.Errorf("%s: directional = %v, want %v", def.Name, def.Directional, def.Name.IsDirectional())
+		}
+	}
+
+	custom, err := ListCustomRelationTypes(d)
+	if err != nil {
+		t.Fatalf("ListCustomRelationTypes: %v", err)
+	}
+	if len(custom) != 0 {
+		t.Errorf("got %d custom relation types, want 0", len(custom))
+	}
+}
+
+func TestCreateRelationType(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	def := &model.RelationTypeDef{Name: "tested_by", Inverse: "tests", Directional: true, Color: "green"}
+	if err := CreateRelationType(d, def); err != nil {
+		t.Fatalf("CreateRelationType: %v", err)
+	}
+
+	custom, err := ListCustomRelationTypes(d)
+	if err != nil {
+		t.Fatalf("ListCustomRelationTypes: %v", err)
+	}
+	if len(custom) != 1 || custom[0] != *def {
+		t.Fatalf("custom types = %+v, want [%+v]", custom, *def)
+	}
+
+	symmetric := &model.RelationTypeDef{Name: "pairs_with"}
+	if err := CreateRelationType(d, symmetric); err != nil {
+		t.Fatalf("CreateRelationType(symmetric): %v", err)
+	}
+	if symmetric.Inverse != "pairs_with" {
+		t.Errorf("symmetric inverse = %q, want pairs_with", symmetric.Inverse)
+	}
+
+	tests := []struct {
+		name    string
+		def     model.RelationTypeDef
+		wantErr error
+	}{
+		{"builtin name", model.RelationTypeDef{Name: "blocks", Inverse: "stops"}, ErrConflict},
+		{"builtin inverse", model.RelationTypeDef{Name: "gates", Inverse: "blocked_by"}, ErrConflict},
+		{"existing name", model.RelationTypeDef{Name: "tested_by", Inverse: "checks"}, ErrConflict},
+		{"existing inverse", model.RelationTypeDef{Name: "verifies", Inverse: "tested_by"}, ErrConflict},
+		{"invalid name", model.RelationTypeDef{Name: "Tested By"}, ErrValidation},
+		{"invalid inverse", model.RelationTypeDef{Name: "verifies", Inverse: "tests!"}, ErrValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CreateRelationType(d, &tt.def)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateRelationType(%+v) error = %v, want %v", tt.def, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateRelationCustomType(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, d, "feature")
+	b := mustCreateIssue(t, d, "qa")
+	c := mustCreateIssue(t, d, "follow-up")
+
+	_, err := CreateRelation(d, &model.Relation{SourceIssueID: a, TargetIssueID: b, RelationType: "tested_by"})
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("unregistered type: error = %v, want ErrValidation", err)
+	}
+
+	if err := CreateRelationType(d, &model.RelationTypeDef{Name: "tested_by", Inverse: "tests", Directional: true}); err != nil {
+		t.Fatalf("CreateRelationType: %v", err)
+	}
+	if err := CreateRelationType(d, &model.RelationTypeDef{Name: "pairs_with"}); err != nil {
+		t.Fatalf("CreateRelationType: %v", err)
+	}
+
+	mustCreateRelation(t, d, a, b, "tested_by")
+	mustCreateRelation(t, d, b, c, "tested_by")
+	_, err = CreateRelation(d, &model.Relation{SourceIssueID: c, TargetIssueID: a, RelationType: "tested_by"})
+	if !errors.Is(err, ErrCycleDetected) {
+		t.Errorf("directional custom cycle: error = %v, want ErrCycleDetected", err)
+	}
+
+	// Symmetric custom types are not checked for cycles.
+	mustCreateRelation(t, d, a, b, "pairs_with")
+	mustCreateRelation(t, d, b, c, "pairs_with")
+	mustCreateRelation(t, d, c, a, "pairs_with")
+}
@@ -32,15 +32,57 @@ func (e *CycleError) Error() string {
 
 func (e *CycleError) Unwrap() error { return ErrCycleDetected }
 
+// DuplicateRelationError wraps ErrDuplicateRelation and carries the existing
+// relation that conflicts with the one being created. Inverse is true when the
+// existing relation runs in the opposite direction (e.g. "B blocks A" already
+// exists when creating "A blocks B").
+type DuplicateRelationError struct {
+	Existing model.Relation
+	Inverse  bool
+}
+
+func (e *DuplicateRelationError) Error() string {
+	return fmt.Sprintf("duplicate relation: %s %s %s (#%d)",
+		model.FormatID(e.Existing.SourceIssueID), string(e.Existing.RelationType),
+		model.FormatID(e.Existing.TargetIssueID), e.Existing.ID)
+}
+
+func (e *DuplicateRelationError) Unwrap() error { return ErrDuplicateRelation }
+
+// CreateRelationOptions controls optional side effects of CreateRelationWithOptions.
+type CreateRelationOptions struct {
+	// CloseSuperseded sets the target of a supersedes relation to done in
+	// the same transaction. It is an error for any other relation type.
+	CloseSuperseded bool
+	// ChangedBy is recorded as the author of the relation's activity and of
+	// any status change.
+	ChangedBy string
+}
+
 // CreateRelation inserts a new relation between two issues within a single
 // transaction. It validates that both issues exist, rejects self-referential
-// and duplicate relations, runs cycle detection for blocks/depends_on types,
-// and records activity on both issues.
+// and duplicate relations, runs cycle detection for directional types, and
+// records activity on both issues. A type missing from relation_types is an
+// error wrapping ErrValidation.
+// rel.Note, if set, is stored with the relation.
 func CreateRelation(db *sql.DB, rel *model.Relation) (int, error) {
+	return CreateRelationWithOptions(db, rel, CreateRelationOptions{})
+}
+
+// CreateRelationWithOptions is CreateRelation with optional side effects
+// applied in the same transaction.
+func CreateRelationWithOptions(db *sql.DB, rel *model.Relation, opts CreateRelationOptions) (int, error) {
+	return withRetryValue(func() (int, error) { return createRelation(db, rel, opts) })
+}
+
+func createRelation(db *sql.DB, rel *model.Relation, opts CreateRelationOptions) (int, error) {
 	// Reject self-referential relations before starting a transaction.
 	if rel.SourceIssueID == rel.TargetIssueID {
 		return 0, ErrSelfRelation
 	}
+	if opts.CloseSuperseded && rel.RelationType != model.RelationSupersedes {
+		return 0, fmt.Errorf("%w: only supersedes relations can close their target, not %s", ErrValidation, rel.RelationType)
+	}
 
 	tx, err := db.Begin()
 	if err != nil {
@@ -48,10 +90,28 @@ func CreateRelation(db *sql.DB, rel *model.Relation) (int, error) {
 	}
 	defer tx.Rollback()
 
+	id, err := createRelationTx(tx, rel, opts)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing transaction: %w", err)
+	}
+	return id, nil
+}
+
+// createRelationTx is CreateRelationWithOptions within an existing
+// transaction, after the checks that need no database access.
+func createRelationTx(tx *sql.Tx, rel *model.Relation, opts CreateRelationOptions) (int, error) {
+	directional, err := relationTypeDirectionalTx(tx, rel.RelationType)
+	if err != nil {
+		return 0, err
+	}
+
 	// Verify both issues exist.
 	for _, issueID := range []int{rel.SourceIssueID, rel.TargetIssueID} {
 		var exists bool
-		if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)", issueID).Scan(&exists); err != nil {
+		if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM issues WHERE id = ? AND deleted_at IS NULL)", issueID).Scan(&exists); err != nil {
 			return 0, fmt.Errorf("checking issue existence: %w", err)
 		}
 		if !exists {
@@ -64,9 +124,11 @@ func CreateRelation(db *sql.DB, rel *model.Relation) (int, error) {
 		return 0, err
 	}
 
-	// Cycle detection for directional relation types only. Symmetric types
-	// (relates_to, duplicates) do not form DAGs, so cycles are meaningless.
-	if rel.RelationType == model.RelationBlocks || rel.RelationType == model.RelationDependsOn {
+	// Cycle detection for directional relation types only: blocks,
+	// depends_on, supersedes and custom types registered as directional.
+	// Symmetric types (relates_to, duplicates) do not form DAGs, so cycles
+	// are meaningless.
+	if directional {
 		hasCycle, path, err := checkCycleTx(tx, rel.SourceIssueID, rel.TargetIssueID, string(rel.RelationType))
 		if err != nil {
 			return 0, fmt.Errorf("checking for cycles: %w", err)
@@ -79,11 +141,12 @@ func CreateRelation(db *sql.DB, rel *model.Relation) (int, error) {
 	now := time.Now().UTC().Format(time.RFC3339)
 
 	res, err := tx.Exec(
-		`INSERT INTO issue_relations (source_issue_id, target_issue_id, relation_type, created_at)
-		 VALUES (?, ?, ?, ?)`,
+		`INSERT INTO issue_relations (source_issue_id, target_issue_id, relation_type, note, created_at)
+		 VALUES (?, ?, ?, ?, ?)`,
 		rel.SourceIssueID,
 		rel.TargetIssueID,
 		string(rel.RelationType),
+		rel.Note,
 		now,
 	)
 	if err != nil {
@@ -95,28 +158,49 @@ func CreateRelation(db *sql.DB, rel *model.Relation) (int, error) {
 		return 0, fmt.Errorf("getting last insert id: %w", err)
 	}
 
-	// Record activity on the source issue.
-	sourceActivity := fmt.Sprintf("%s %s", string(rel.RelationType), model.FormatID(rel.TargetIssueID))
-	if err := RecordActivity(tx, rel.SourceIssueID, "relation_added", "", sourceActivity, ""); err != nil {
-		return 0, err
-	}
-
-	// Record activity on the target issue with the inverse relation type.
-	targetActivity := fmt.Sprintf("%s %s", rel.RelationType.Inverse(), model.FormatID(rel.SourceIssueID))
-	if err := RecordActivity(tx, rel.TargetIssueID, "relation_added", "", targetActivity, ""); err != nil {
+	if err := recordRelationAddedTx(tx, rel.SourceIssueID, rel.TargetIssueID, rel.RelationType, opts.ChangedBy); err != nil {
 		return 0, err
 	}
 
-	if err := tx.Commit(); err != nil {
-		return 0, fmt.Errorf("committing transaction: %w", err)
+	if opts.CloseSuperseded {
+		if err := closeIssueTx(tx, rel.TargetIssueID, opts.ChangedBy); err != nil {
+			return 0, err
+		}
 	}
 
 	return int(id64), nil
 }
 
+// closeIssueTx moves an issue to done through updateIssueTx, so the change
+// is validated, timestamped, recorded and notified like any other status
+// change. It is a no-op when the issue is already done, and fails with
+// ErrConflict when the configured transitions do not allow the move.
+func closeIssueTx(tx *sql.Tx, id int, changedBy string) error {
+	issue, err := getIssueTx(tx, id)
+	if err != nil {
+		return err
+	}
+	if issue.Status == model.StatusDone {
+		return nil
+	}
+	transitions, err := statusTransitions(tx)
+	if err != nil {
+		return err
+	}
+	if !transitions.Allows(issue.Status, model.StatusDone) {
+		return fmt.Errorf("%w: cannot close %s: status transition %s -> %s is not allowed",
+			ErrConflict, model.FormatID(id), issue.Status, model.StatusDone)
+	}
+	return updateIssueTx(tx, id, map[string]interface{}{"status": string(model.StatusDone)}, changedBy)
+}
+
 // DeleteRelation removes a relation matching the given source, target, and type.
-// Activity is recorded on both issues within a single transaction.
-func DeleteRelation(db *sql.DB, sourceID, targetID int, relType string) error {
+// Activity is recorded on both issues, by author, within a single transaction.
+func DeleteRelation(db *sql.DB, sourceID, targetID int, relType, author string) error {
+	return WithRetry(func() error { return deleteRelation(db, sourceID, targetID, relType, author) })
+}
+
+func deleteRelation(db *sql.DB, sourceID, targetID int, relType, author string) error {
 	tx, err := db.Begin()
 	if err != nil {
 		return fmt.Errorf("beginning transaction: %w", err)
@@ -139,40 +223,177 @@ func DeleteRelation(db *sql.DB, sourceID, targetID int, relType string) error {
 		return ErrNotFound
 	}
 
-	rt := model.RelationType(relType)
+	if err := recordRelationRemovedTx(tx, sourceID, targetID, model.RelationType(relType), author); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteRelationByID removes the relation with the given ID and records
+// removal activity on both issues, using the inverse relation type for the
+// target. Returns ErrNotFound if no relation has that ID.
+func DeleteRelationByID(db *sql.DB, relationID int, author string) error {
+	return WithRetry(func() error { return deleteRelationByID(db, relationID, author) })
+}
+
+func deleteRelationByID(db *sql.DB, relationID int, author string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var sourceID, targetID int
+	var relType string
+	err = tx.QueryRow(
+		`SELECT source_issue_id, target_issue_id, relation_type FROM issue_relations WHERE id = ?`,
+		relationID,
+	).Scan(&sourceID, &targetID, &relType)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("looking up relation: %w", err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM issue_relations WHERE id = ?`, relationID); err != nil {
+		return fmt.Errorf("deleting relation: %w", err)
+	}
 
-	// Record activity on the source issue.
-	sourceActivity := fmt.Sprintf("%s %s", relType, model.FormatID(targetID))
-	if err := RecordActivity(tx, sourceID, "relation_removed", sourceActivity, "", ""); err != nil {
+	if err := recordRelationRemovedTx(tx, sourceID, targetID, model.RelationType(relType), author); err != nil {
 		return err
 	}
 
-	// Record activity on the target issue with the inverse relation type.
-	targetActivity := fmt.Sprintf("%s %s", rt.Inverse(), model.FormatID(sourceID))
-	if err := RecordActivity(tx, targetID, "relation_removed", targetActivity, "", ""); err != nil {
+	return tx.Commit()
+}
+
+// ChangeRelationType changes the type of the relation with the given ID in
+// place, keeping its ID, note and creation time. The new type must be
+// registered, must not duplicate an existing relation between the same
+// issues, and, if directional, must not close a cycle. Activity is recorded
+// on both issues. Returns ErrNotFound if no relation has that ID; changing a
+// relation to its current type is a no-op.
+func ChangeRelationType(db *sql.DB, relationID int, newType model.RelationType, author string) error {
+	return WithRetry(func() error { return changeRelationType(db, relationID, newType, author) })
+}
+
+func changeRelationType(db *sql.DB, relationID int, newType model.RelationType, author string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var sourceID, targetID int
+	var oldType string
+	err = tx.QueryRow(
+		`SELECT source_issue_id, target_issue_id, relation_type FROM issue_relations WHERE id = ?`,
+		relationID,
+	).Scan(&sourceID, &targetID, &oldType)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("looking up relation: %w", err)
+	}
+	if model.RelationType(oldType) == newType {
+		return nil
+	}
+
+	directional, err := relationTypeDirectionalTx(tx, newType)
+	if err != nil {
+		return err
+	}
+	if err := checkDuplicateTx(tx, sourceID, targetID, newType); err != nil {
+		return err
+	}
+	if directional {
+		hasCycle, path, err := checkCycleTx(tx, sourceID, targetID, string(newType))
+		if err != nil {
+			return fmt.Errorf("checking for cycles: %w", err)
+		}
+		if hasCycle {
+			return &CycleError{Path: path}
+		}
+	}
+
+	if _, err := tx.Exec(
+		`UPDATE issue_relations SET relation_type = ? WHERE id = ?`,
+		string(newType), relationID,
+	); err != nil {
+		return fmt.Errorf("updating relation type: %w", err)
+	}
+
+	if err := recordRelationChangedTx(tx, sourceID, targetID, model.RelationType(oldType), newType, author); err != nil {
 		return err
 	}
 
 	return tx.Commit()
 }
 
+// recordRelationAddedTx records relation_added activity on both ends of a
+// relation, with the inverse relation type on the target issue.
+func recordRelationAddedTx(tx *sql.Tx, sourceID, targetID int, rt model.RelationType, author string) error {
+	sourceActivity := fmt.Sprintf("%s %s", string(rt), model.FormatID(targetID))
+	if err := RecordActivity(tx, sourceID, "relation_added", "", sourceActivity, author); err != nil {
+		return err
+	}
+
+	targetActivity := fmt.Sprintf("%s %s", rt.Inverse(), model.FormatID(sourceID))
+	return RecordActivity(tx, targetID, "relation_added", "", targetActivity, author)
+}
+
+// recordRelationRemovedTx records relation_removed activity on both ends of a
+// relation, with the inverse relation type on the target issue.
+func recordRelationRemovedTx(tx *sql.Tx, sourceID, targetID int, rt model.RelationType, author string) error {
+	sourceActivity := fmt.Sprintf("%s %s", string(rt), model.FormatID(targetID))
+	if err := RecordActivity(tx, sourceID, "relation_removed", sourceActivity, "", author); err != nil {
+		return err
+	}
+
+	targetActivity := fmt.Sprintf("%s %s", rt.Inverse(), model.FormatID(sourceID))
+	return RecordActivity(tx, targetID, "relation_removed", targetActivity, "", author)
+}
+
+// recordRelationChangedTx records relation_changed activity on both ends of a
+// relation whose type changed from oldType to newType, with the inverse
+// relation types on the target issue.
+func recordRelationChangedTx(tx *sql.Tx, sourceID, targetID int, oldType, newType model.RelationType, author string) error {
+	if err := RecordActivity(tx, sourceID, "relation_changed",
+		fmt.Sprintf("%s %s", string(oldType), model.FormatID(targetID)),
+		fmt.Sprintf("%s %s", string(newType), model.FormatID(targetID)),
+		author,
+	); err != nil {
+		return err
+	}
+
+	return RecordActivity(tx, targetID, "relation_changed",
+		fmt.Sprintf("%s %s", oldType.Inverse(), model.FormatID(sourceID)),
+		fmt.Sprintf("%s %s", newType.Inverse(), model.FormatID(sourceID)),
+		author,
+	)
+}
+
 // IssueExists returns true if an issue with the given ID exists.
 func IssueExists(db *sql.DB, issueID int) (bool, error) {
 	var exists bool
-	if err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)", issueID).Scan(&exists); err != nil {
+	if err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM issues WHERE id = ? AND deleted_at IS NULL)", issueID).Scan(&exists); err != nil {
 		return false, fmt.Errorf("checking issue existence: %w", err)
 	}
 	return exists, nil
 }
 
 // GetIssueRelations returns all relations where the given issue is either the
-// source or the target, ordered by creation time ascending.
+// source or the target, ordered by creation time ascending with ID as a
+// tiebreaker.
 func GetIssueRelations(db *sql.DB, issueID int) ([]model.Relation, error) {
 	rows, err := db.Query(
-		`SELECT id, source_issue_id, target_issue_id, relation_type, created_at
+		`SELECT id, source_issue_id, target_issue_id, relation_type, note, created_at
 		 FROM issue_relations
-		 WHERE source_issue_id = ? OR target_issue_id = ?
-		 ORDER BY created_at ASC`,
+		 WHERE (source_issue_id = ? OR target_issue_id = ?)
+		   AND `+liveRelationEnds+`
+		 ORDER BY created_at ASC, id ASC`,
 		issueID, issueID,
 	)
 	if err != nil {
@@ -185,7 +406,7 @@ func GetIssueRelations(db *sql.DB, issueID int) ([]model.Relation, error) {
 		var r model.Relation
 		var relType string
 		var createdAt string
-		if err := rows.Scan(&r.ID, &r.SourceIssueID, &r.TargetIssueID, &relType, &createdAt); err != nil {
+		if err := rows.Scan(&r.ID, &r.SourceIssueID, &r.TargetIssueID, &relType, &r.Note, &createdAt); err != nil {
 			return nil, fmt.Errorf("scanning relation row: %w", err)
 		}
 		r.RelationType = model.RelationType(relType)
@@ -203,14 +424,108 @@ func GetIssueRelations(db *sql.DB, issueID int) ([]model.Relation, error) {
 	return relations, nil
 }
 
+// GetBlockedBy returns the IDs of the live issues that issueID blocks: the
+// targets of its "blocks" relations and the sources of "depends_on"
+// relations that point at it. IDs are distinct and sorted ascending.
+func GetBlockedBy(db *sql.DB, issueID int) ([]int, error) {
+	rows, err := db.Query(
+		`SELECT target_issue_id FROM issue_relations
+		 WHERE source_issue_id = ? AND relation_type = ? AND `+liveRelationEnds+`
+		 UNION
+		 SELECT source_issue_id FROM issue_relations
+		 WHERE target_issue_id = ? AND relation_type = ? AND `+liveRelationEnds+`
+		 ORDER BY 1`,
+		issueID, string(model.RelationBlocks),
+		issueID, string(model.RelationDependsOn),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying blocked issues: %w", err)
+	}
+	return scanIDs(rows)
+}
+
+// blockingEdges selects the directional relations between live issues as
+// distinct (blocker, blocked) pairs: "A blocks B" and "B depends_on A" are
+// both the pair (A, B), and are counted once when both exist.
+const blockingEdges = `SELECT source_issue_id AS blocker, target_issue_id AS blocked FROM issue_relations
+		 WHERE relation_type = 'blocks' AND ` + liveRelationEnds + `
+		 UNION
+		 SELECT target_issue_id, source_issue_id FROM issue_relations
+		 WHERE relation_type = 'depends_on' AND ` + liveRelationEnds
+
+// GetRelationDegrees returns, for each of ids, how many live issues block it
+// and how many it blocks, as (blockedByCount, blocksCount), counting "blocks"
+// and "depends_on" relations as GetBlockedBy does. Issues without either are
+// left out.
+func GetRelationDegrees(db *sql.DB, ids []int) (map[int][2]int, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := make([]any, 0, 2*len(ids))
+	for range 2 {
+		for _, id := range ids {
+			args = append(args, id)
+		}
+	}
+	placeholders := makePlaceholders(len(ids))
+	rows, err := db.Query(
+		`WITH edges(blocker, blocked) AS (`+blockingEdges+`)
+		 SELECT id, SUM(blocked_by), SUM(blocks) FROM (
+			SELECT blocked AS id, 1 AS blocked_by, 0 AS blocks FROM edges WHERE blocked IN (`+placeholders+`)
+			UNION ALL
+			SELECT blocker, 0, 1 FROM edges WHERE blocker IN (`+placeholders+`)
+		 )
+		 GROUP BY id`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying relation degrees: %w", err)
+	}
+	defer rows.Close()
+
+	degrees := make(map[int][2]int)
+	for rows.Next() {
+		var id, blockedBy, blocks int
+		if err := rows.Scan(&id, &blockedBy, &blocks); err != nil {
+			return nil, fmt.Errorf("scanning relation degrees: %w", err)
+		}
+		degrees[id] = [2]int{blockedBy, blocks}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating relation degrees: %w", err)
+	}
+	return degrees, nil
+}
+
+// HydrateRelationDegrees sets BlockedByCount and BlocksCount on each issue
+// from GetRelationDegrees.
+func HydrateRelationDegrees(db *sql.DB, issues []*model.Issue) error {
+	ids := make([]int, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	degrees, err := GetRelationDegrees(db, ids)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		d := degrees[issue.ID]
+		issue.BlockedByCount, issue.BlocksCount = d[0], d[1]
+	}
+	return nil
+}
+
 // GetAllDirectionalRelations returns all relations where the relation type is
-// "blocks" or "depends_on", ordered by creation time ascending.
+// "blocks" or "depends_on", ordered by creation time ascending with ID as a
+// tiebreaker.
 func GetAllDirectionalRelations(db *sql.DB) ([]model.Relation, error) {
 	rows, err := db.Query(
-		`SELECT id, source_issue_id, target_issue_id, relation_type, created_at
+		`SELECT id, source_issue_id, target_issue_id, relation_type, note, created_at
 		 FROM issue_relations
 		 WHERE relation_type IN (?, ?)
-		 ORDER BY created_at ASC`,
+		   AND `+liveRelationEnds+`
+		 ORDER BY created_at ASC, id ASC`,
 		string(model.RelationBlocks), string(model.RelationDependsOn),
 	)
 	if err != nil {
@@ -223,7 +538,7 @@ func GetAllDirectionalRelations(db *sql.DB) ([]model.Relation, error) {
 		var r model.Relation
 		var relType string
 		var createdAt string
-		if err := rows.Scan(&r.ID, &r.SourceIssueID, &r.TargetIssueID, &relType, &createdAt); err != nil {
+		if err := rows.Scan(&r.ID, &r.SourceIssueID, &r.TargetIssueID, &relType, &r.Note, &createdAt); err != nil {
 			return nil, fmt.Errorf("scanning relation row: %w", err)
 		}
 		r.RelationType = model.RelationType(relType)
@@ -242,39 +557,55 @@ func GetAllDirectionalRelations(db *sql.DB) ([]model.Relation, error) {
 }
 
 // GetAllRelations returns every relation in the database, ordered by creation
-// time ascending.
+// time ascending with ID as a tiebreaker so exports are stable.
 func GetAllRelations(db *sql.DB) ([]model.Relation, error) {
+	var relations []model.Relation
+	err := StreamRelations(db, func(v model.Relation) error {
+		relations = append(relations, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return relations, nil
+}
+
+// StreamRelations calls fn for every relation row in the same order as
+// GetAllRelations without buffering the result set. fn runs while the cursor is
+// open and must not query db.
+func StreamRelations(db *sql.DB, fn func(model.Relation) error) error {
 	rows, err := db.Query(
-		`SELECT id, source_issue_id, target_issue_id, relation_type, created_at
+		`SELECT id, source_issue_id, target_issue_id, relation_type, note, created_at
 		 FROM issue_relations
-		 ORDER BY created_at ASC`,
+		 WHERE ` + liveRelationEnds + `
+		 ORDER BY created_at ASC, id ASC`,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("querying all relations: %w", err)
+		return fmt.Errorf("querying all relations: %w", err)
 	}
 	defer rows.Close()
 
-	var relations []model.Relation
 	for rows.Next() {
 		var r model.Relation
 		var relType string
 		var createdAt string
-		if err := rows.Scan(&r.ID, &r.SourceIssueID, &r.TargetIssueID, &relType, &createdAt); err != nil {
-			return nil, fmt.Errorf("scanning relation row: %w", err)
+		if err := rows.Scan(&r.ID, &r.SourceIssueID, &r.TargetIssueID, &relType, &r.Note, &createdAt); err != nil {
+			return fmt.Errorf("scanning relation row: %w", err)
 		}
 		r.RelationType = model.RelationType(relType)
 		t, err := time.Parse(time.RFC3339, createdAt)
 		if err != nil {
-			return nil, fmt.Errorf("parsing created_at: %w", err)
+			return fmt.Errorf("parsing created_at: %w", err)
 		}
 		r.CreatedAt = t
-		relations = append(relations, r)
+		if err := fn(r); err != nil {
+			return err
+		}
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating relation rows: %w", err)
+		return fmt.Errorf("iterating relation rows: %w", err)
 	}
-
-	return relations, nil
+	return nil
 }
 
 // InsertRelationWithID inserts a relation with a specific ID (not auto-increment),
@@ -282,12 +613,13 @@ func GetAllRelations(db *sql.DB) ([]model.Relation, error) {
 // Must be called within an existing transaction.
 func InsertRelationWithID(tx *sql.Tx, rel *model.Relation) (bool, error) {
 	res, err := tx.Exec(
-		`INSERT OR IGNORE INTO issue_relations (id, source_issue_id, target_issue_id, relation_type, created_at)
-		 VALUES (?, ?, ?, ?, ?)`,
+		`INSERT OR IGNORE INTO issue_relations (id, source_issue_id, target_issue_id, relation_type, note, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
 		rel.ID,
 		rel.SourceIssueID,
 		rel.TargetIssueID,
 		string(rel.RelationType),
+		rel.Note,
 		rel.CreatedAt.UTC().Format(time.RFC3339),
 	)
 	if err != nil {
@@ -301,33 +633,66 @@ func InsertRelationWithID(tx *sql.Tx, rel *model.Relation) (bool, error) {
 // relation between sourceID and targetID of the given type. For any relation
 // type, both the exact direction and the reverse direction between the same pair
 // are considered duplicates (e.g. "A blocks B" conflicts with "B blocks A").
+// A conflict is reported as a *DuplicateRelationError describing the existing
+// relation.
 //
 // The schema enforces both levels: a UNIQUE constraint prevents exact-direction
 // duplicates, and a BEFORE INSERT trigger (trg_no_inverse_duplicate_relation)
 // rejects inverse pairs. This application-level check provides a friendlier
 // error message and avoids relying solely on constraint violations.
 func checkDuplicateTx(tx *sql.Tx, sourceID, targetID int, relType model.RelationType) error {
-	var count int
-	err := tx.QueryRow(
-		`SELECT COUNT(*) FROM issue_relations
+	dup, err := findDuplicateRelation(tx, sourceID, targetID, relType)
+	if err != nil {
+		return err
+	}
+	if dup != nil {
+		return dup
+	}
+	return nil
+}
+
+// RelationExists reports whether a relation of relType between sourceID and
+// targetID already exists in either direction, i.e. whether creating it would
+// fail with ErrDuplicateRelation.
+func RelationExists(db *sql.DB, sourceID, targetID int, relType model.RelationType) (bool, error) {
+	dup, err := findDuplicateRelation(db, sourceID, targetID, relType)
+	if err != nil {
+		return false, err
+	}
+	return dup != nil, nil
+}
+
+// findDuplicateRelation returns the relation checkDuplicateTx would report as
+// a conflict, or nil if there is none.
+func findDuplicateRelation(q queryRower, sourceID, targetID int, relType model.RelationType) (*DuplicateRelationError, error) {
+	var r model.Relation
+	var rt, createdAt string
+	err := q.QueryRow(
+		`SELECT id, source_issue_id, target_issue_id, relation_type, note, created_at
+		 FROM issue_relations
 		 WHERE relation_type = ?
 		   AND ((source_issue_id = ? AND target_issue_id = ?)
-		     OR (source_issue_id = ? AND target_issue_id = ?))`,
+		     OR (source_issue_id = ? AND target_issue_id = ?))
+		 ORDER BY id LIMIT 1`,
 		string(relType), sourceID, targetID, targetID, sourceID,
-	).Scan(&count)
-	if err != nil {
-		return fmt.Errorf("checking duplicate relation: %w", err)
+	).Scan(&r.ID, &r.SourceIssueID, &r.TargetIssueID, &rt, &r.Note, &createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
 	}
-	if count > 0 {
-		return ErrDuplicateRelation
+	if err != nil {
+		return nil, fmt.Errorf("checking duplicate relation: %w", err)
 	}
 
-	return nil
+	r.RelationType = model.RelationType(rt)
+	if r.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+		return nil, fmt.Errorf("parsing relation created_at: %w", err)
+	}
+	return &DuplicateRelationError{Existing: r, Inverse: r.SourceIssueID != sourceID}, nil
 }
 
 // checkCycleTx uses a recursive CTE to detect whether adding an edge from
 // sourceID to targetID of the given relType would create a cycle. Only called
-// for "blocks" and "depends_on" relation types.
+// for directional relation types.
 //
 // Note: cycle detection is scoped to a single relation type. Cross-type cycles
 // (e.g. "A blocks B" + "B depends_on A") are not detected because blocks and
@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"errors"
+	"slices"
 	"testing"
 
 	"github.com/ALT-F4-LLC/docket/internal/model"
@@ -80,6 +81,49 @@ func TestCreateRelation(t *testing.T) {
 	}
 }
 
+func TestCreateRelationNote(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, d, "issue A")
+	b := mustCreateIssue(t, d, "issue B")
+	c := mustCreateIssue(t, d, "issue C")
+
+	if _, err := CreateRelation(d, &model.Relation{
+		SourceIssueID: a,
+		TargetIssueID: b,
+		RelationType:  model.RelationBlocks,
+		Note:          "hard blocker",
+	}); err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+	mustCreateRelation(t, d, a, c, model.RelationRelatesTo)
+
+	rels, err := GetIssueRelations(d, a)
+	if err != nil {
+		t.Fatalf("GetIssueRelations: %v", err)
+	}
+	if len(rels) != 2 {
+		t.Fatalf("got %d relations, want 2", len(rels))
+	}
+	if rels[0].Note != "hard blocker" {
+		t.Errorf("note = %q, want %q", rels[0].Note, "hard blocker")
+	}
+	if rels[1].Note != "" {
+		t.Errorf("note of relation created without one = %q, want empty", rels[1].Note)
+	}
+
+	all, err := GetAllRelations(d)
+	if err != nil {
+		t.Fatalf("GetAllRelations: %v", err)
+	}
+	if len(all) != 2 || all[0].Note != "hard blocker" {
+		t.Errorf("GetAllRelations = %+v, want the note carried through", all)
+	}
+}
+
 func TestCreateRelationSelfReferential(t *testing.T) {
 	d := mustOpen(t)
 	if err := Initialize(d); err != nil {
@@ -140,6 +184,88 @@ func TestCreateRelationInverseDuplicate(t *testing.T) {
 	}
 }
 
+func TestCreateRelationDuplicateErrorDescribesExisting(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, d, "issue A")
+	b := mustCreateIssue(t, d, "issue B")
+
+	relID := mustCreateRelation(t, d, b, a, model.RelationBlocks)
+
+	tests := []struct {
+		name    string
+		source  int
+		target  int
+		inverse bool
+	}{
+		{"same direction", b, a, false},
+		{"inverse direction", a, b, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateRelation(d, &model.Relation{
+				SourceIssueID: tt.source,
+				TargetIssueID: tt.target,
+				RelationType:  model.RelationBlocks,
+			})
+			var dup *DuplicateRelationError
+			if !errors.As(err, &dup) {
+				t.Fatalf("expected *DuplicateRelationError, got %v", err)
+			}
+			if !errors.Is(err, ErrDuplicateRelation) {
+				t.Errorf("DuplicateRelationError does not unwrap to ErrDuplicateRelation")
+			}
+			if dup.Existing.ID != relID || dup.Existing.SourceIssueID != b || dup.Existing.TargetIssueID != a ||
+				dup.Existing.RelationType != model.RelationBlocks {
+				t.Errorf("existing = %+v, want #%d %d blocks %d", dup.Existing, relID, b, a)
+			}
+			if dup.Inverse != tt.inverse {
+				t.Errorf("Inverse = %v, want %v", dup.Inverse, tt.inverse)
+			}
+		})
+	}
+}
+
+func TestRelationExists(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, d, "issue A")
+	b := mustCreateIssue(t, d, "issue B")
+	c := mustCreateIssue(t, d, "issue C")
+
+	mustCreateRelation(t, d, a, b, model.RelationBlocks)
+
+	tests := []struct {
+		name    string
+		source  int
+		target  int
+		relType model.RelationType
+		want    bool
+	}{
+		{"exact", a, b, model.RelationBlocks, true},
+		{"inverse", b, a, model.RelationBlocks, true},
+		{"other type", a, b, model.RelationRelatesTo, false},
+		{"other pair", a, c, model.RelationBlocks, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RelationExists(d, tt.source, tt.target, tt.relType)
+			if err != nil {
+				t.Fatalf("RelationExists: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RelationExists(%d, %d, %s) = %v, want %v", tt.source, tt.target, tt.relType, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCreateRelationDependsOnInverseDuplicate(t *testing.T) {
 	d := mustOpen(t)
 	if err := Initialize(d); err != nil {
@@ -380,6 +506,145 @@ func TestCycleDetectionDependsOn(t *testing.T) {
 	}
 }
 
+func TestCycleDetectionSupersedes(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, d, "issue A")
+	b := mustCreateIssue(t, d, "issue B")
+	c := mustCreateIssue(t, d, "issue C")
+	mustCreateRelation(t, d, a, b, model.RelationSupersedes)
+	mustCreateRelation(t, d, b, c, model.RelationSupersedes)
+
+	_, err := CreateRelation(d, &model.Relation{
+		SourceIssueID: c,
+		TargetIssueID: a,
+		RelationType:  model.RelationSupersedes,
+	})
+	if !errors.Is(err, ErrCycleDetected) {
+		t.Errorf("expected ErrCycleDetected, got %v", err)
+	}
+}
+
+func TestCreateRelationCloseSuperseded(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	newer := mustCreateIssue(t, d, "newer")
+	older := mustCreateIssue(t, d, "older")
+	other := mustCreateIssue(t, d, "other")
+
+	// The option is rejected for other relation types and changes nothing.
+	_, err := CreateRelationWithOptions(d, &model.Relation{
+		SourceIssueID: newer,
+		TargetIssueID: other,
+		RelationType:  model.RelationRelatesTo,
+	}, CreateRelationOptions{CloseSuperseded: true})
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("relates_to with CloseSuperseded: got %v, want ErrValidation", err)
+	}
+
+	// Without the option, the superseded issue keeps its status.
+	mustCreateRelation(t, d, other, newer, model.RelationSupersedes)
+	if issue, err := GetIssue(d, newer); err != nil || issue.Status != model.StatusBacklog {
+		t.Fatalf("newer after plain supersedes = %+v, %v; want backlog", issue, err)
+	}
+
+	if _, err := CreateRelationWithOptions(d, &model.Relation{
+		SourceIssueID: newer,
+		TargetIssueID: older,
+		RelationType:  model.RelationSupersedes,
+	}, CreateRelationOptions{CloseSuperseded: true, ChangedBy: "alice"}); err != nil {
+		t.Fatalf("CreateRelationWithOptions: %v", err)
+	}
+
+	issue, err := GetIssue(d, older)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if issue.Status != model.StatusDone {
+		t.Errorf("superseded issue status = %s, want done", issue.Status)
+	}
+
+	activity, err := GetActivity(d, older, 0)
+	if err != nil {
+		t.Fatalf("GetActivity: %v", err)
+	}
+	var found bool
+	for _, a := range activity {
+		if a.FieldChanged == "status" && a.OldValue == "backlog" && a.NewValue == "done" && a.ChangedBy == "alice" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("no status activity recorded for the superseded issue: %+v", activity)
+	}
+}
+
+func TestCloseIssueFollowsStatusRules(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	newer := mustCreateIssue(t, d, "newer")
+	older := mustCreateIssue(t, d, "older")
+	if err := UpdateIssue(d, older, map[string]interface{}{"assignee": "bob"}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+
+	// Closing notifies the assignee, as any other status change does.
+	if _, err := CreateRelationWithOptions(d, &model.Relation{
+		SourceIssueID: newer,
+		TargetIssueID: older,
+		RelationType:  model.RelationSupersedes,
+	}, CreateRelationOptions{CloseSuperseded: true, ChangedBy: "alice"}); err != nil {
+		t.Fatalf("CreateRelationWithOptions: %v", err)
+	}
+	var notified bool
+	for _, n := range mustListNotifications(t, d, "bob", false) {
+		if n.IssueID == older && n.Kind == model.NotificationStatusChanged {
+			notified = true
+		}
+	}
+	if !notified {
+		t.Error("closing a superseded issue did not notify its assignee")
+	}
+
+	if err := SetStatusTransitions(d, model.Transitions{
+		model.StatusBacklog: {model.StatusTodo},
+	}); err != nil {
+		t.Fatalf("SetStatusTransitions: %v", err)
+	}
+
+	stale := mustCreateIssue(t, d, "stale")
+	_, err := CreateRelationWithOptions(d, &model.Relation{
+		SourceIssueID: newer,
+		TargetIssueID: stale,
+		RelationType:  model.RelationSupersedes,
+	}, CreateRelationOptions{CloseSuperseded: true, ChangedBy: "alice"})
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("closing against the transitions = %v, want ErrConflict", err)
+	}
+	if rels, _ := GetIssueRelations(d, stale); len(rels) != 0 {
+		t.Errorf("relation was created despite the failed close: %+v", rels)
+	}
+
+	dup := mustCreateIssue(t, d, "dup")
+	if _, err := MergeIssue(d, dup, newer, MergeOptions{ChangedBy: "alice"}); !errors.Is(err, ErrConflict) {
+		t.Fatalf("MergeIssue against the transitions = %v, want ErrConflict", err)
+	}
+	for _, id := range []int{stale, dup} {
+		if issue, _ := GetIssue(d, id); issue.Status != model.StatusBacklog {
+			t.Errorf("%s status = %s, want it left in backlog", model.FormatID(id), issue.Status)
+		}
+	}
+}
+
 func TestDeleteRelation(t *testing.T) {
 	d := mustOpen(t)
 	if err := Initialize(d); err != nil {
@@ -391,7 +656,7 @@ func TestDeleteRelation(t *testing.T) {
 
 	mustCreateRelation(t, d, a, b, model.RelationBlocks)
 
-	if err := DeleteRelation(d, a, b, string(model.RelationBlocks)); err != nil {
+	if err := DeleteRelation(d, a, b, string(model.RelationBlocks), "tester"); err != nil {
 		t.Fatalf("DeleteRelation: %v", err)
 	}
 
@@ -413,7 +678,7 @@ func TestDeleteRelationNotFound(t *testing.T) {
 		t.Fatalf("Initialize: %v", err)
 	}
 
-	err := DeleteRelation(d, 999, 888, string(model.RelationBlocks))
+	err := DeleteRelation(d, 999, 888, string(model.RelationBlocks), "tester")
 	if !errors.Is(err, ErrNotFound) {
 		t.Errorf("expected ErrNotFound, got %v", err)
 	}
@@ -492,6 +757,122 @@ func TestGetIssueRelationsEmpty(t *testing.T) {
 	}
 }
 
+func TestGetBlockedBy(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, d, "issue A")
+	b := mustCreateIssue(t, d, "issue B")
+	c := mustCreateIssue(t, d, "issue C")
+	dd := mustCreateIssue(t, d, "issue D")
+	e := mustCreateIssue(t, d, "issue E")
+
+	mustCreateRelation(t, d, a, c, model.RelationBlocks)
+	mustCreateRelation(t, d, b, a, model.RelationDependsOn)
+	mustCreateRelation(t, d, a, dd, model.RelationRelatesTo)
+	mustCreateRelation(t, d, e, a, model.RelationBlocks)
+
+	got, err := GetBlockedBy(d, a)
+	if err != nil {
+		t.Fatalf("GetBlockedBy: %v", err)
+	}
+	if want := []int{b, c}; !slices.Equal(got, want) {
+		t.Errorf("GetBlockedBy(A) = %v, want %v", got, want)
+	}
+
+	got, err = GetBlockedBy(d, dd)
+	if err != nil {
+		t.Fatalf("GetBlockedBy: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("GetBlockedBy(D) = %v, want none", got)
+	}
+}
+
+func TestGetRelationDegrees(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	// A chain A -> B -> C -> D, with B -> C given both ways round, a
+	// relates_to that does not count, and a blocker in the trash.
+	a := mustCreateIssue(t, d, "head")
+	b := mustCreateIssue(t, d, "second")
+	c := mustCreateIssue(t, d, "third")
+	dd := mustCreateIssue(t, d, "leaf")
+	loose := mustCreateIssue(t, d, "unrelated")
+	trashed := mustCreateIssue(t, d, "trashed")
+
+	mustCreateRelation(t, d, a, b, model.RelationBlocks)
+	mustCreateRelation(t, d, b, c, model.RelationBlocks)
+	mustCreateRelation(t, d, c, b, model.RelationDependsOn)
+	mustCreateRelation(t, d, dd, c, model.RelationDependsOn)
+	mustCreateRelation(t, d, a, loose, model.RelationRelatesTo)
+	mustCreateRelation(t, d, trashed, dd, model.RelationBlocks)
+	if _, err := TrashIssue(d, trashed, "alice"); err != nil {
+		t.Fatalf("TrashIssue: %v", err)
+	}
+
+	got, err := GetRelationDegrees(d, []int{a, b, c, dd, loose})
+	if err != nil {
+		t.Fatalf("GetRelationDegrees: %v", err)
+	}
+	want := map[int][2]int{
+		a:  {0, 1},
+		b:  {1, 1},
+		c:  {1, 1},
+		dd: {1, 0},
+	}
+	if len(got) != len(want) {
+		t.Errorf("got degrees for %d issues, want %d: %v", len(got), len(want), got)
+	}
+	for id, w := range want {
+		if got[id] != w {
+			t.Errorf("degrees of %s = %v, want %v", model.FormatID(id), got[id], w)
+		}
+	}
+
+	issues := []*model.Issue{{ID: a}, {ID: dd}, {ID: loose}}
+	if err := HydrateRelationDegrees(d, issues); err != nil {
+		t.Fatalf("HydrateRelationDegrees: %v", err)
+	}
+	for i, w := range [][2]int{{0, 1}, {1, 0}, {0, 0}} {
+		if got := [2]int{issues[i].BlockedByCount, issues[i].BlocksCount}; got != w {
+			t.Errorf("hydrated degrees of %s = %v, want %v", model.FormatID(issues[i].ID), got, w)
+		}
+	}
+}
+
+func TestListIssuesSortByBlocks(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, d, "blocks one")
+	b := mustCreateIssue(t, d, "blocks two")
+	c := mustCreateIssue(t, d, "blocks none")
+	e := mustCreateIssue(t, d, "leaf")
+	mustCreateRelation(t, d, a, e, model.RelationBlocks)
+	mustCreateRelation(t, d, b, c, model.RelationBlocks)
+	mustCreateRelation(t, d, e, b, model.RelationDependsOn)
+
+	issues, _, err := ListIssues(d, ListOptions{SortKeys: []SortKey{{Field: "blocks", Dir: "desc"}}})
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	var got []int
+	for _, issue := range issues {
+		got = append(got, issue.ID)
+	}
+	if want := []int{b, a, c, e}; !slices.Equal(got, want) {
+		t.Errorf("sorted by blocks = %v, want %v", got, want)
+	}
+}
+
 func TestCreateRelationRecordsActivity(t *testing.T) {
 	d := mustOpen(t)
 	if err := Initialize(d); err != nil {
@@ -537,7 +918,7 @@ func TestDeleteRelationRecordsActivity(t *testing.T) {
 
 	mustCreateRelation(t, d, a, b, model.RelationBlocks)
 
-	if err := DeleteRelation(d, a, b, string(model.RelationBlocks)); err != nil {
+	if err := DeleteRelation(d, a, b, string(model.RelationBlocks), "tester"); err != nil {
 		t.Fatalf("DeleteRelation: %v", err)
 	}
 
@@ -563,3 +944,216 @@ func TestDeleteRelationRecordsActivity(t *testing.T) {
 		t.Errorf("expected 1 relation_removed activity on issue B, got %d", countB)
 	}
 }
+
+func TestDeleteRelationByID(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, d, "issue A")
+	b := mustCreateIssue(t, d, "issue B")
+
+	relID := mustCreateRelation(t, d, a, b, model.RelationBlocks)
+	keepID := mustCreateRelation(t, d, a, b, model.RelationRelatesTo)
+
+	if err := DeleteRelationByID(d, relID, "alice"); err != nil {
+		t.Fatalf("DeleteRelationByID: %v", err)
+	}
+
+	rels, err := GetIssueRelations(d, a)
+	if err != nil {
+		t.Fatalf("GetIssueRelations: %v", err)
+	}
+	if len(rels) != 1 || rels[0].ID != keepID {
+		t.Errorf("expected only relation %d to remain, got %+v", keepID, rels)
+	}
+
+	for _, tc := range []struct {
+		issueID int
+		want    string
+	}{
+		{a, "blocks " + model.FormatID(b)},
+		{b, "blocked_by " + model.FormatID(a)},
+	} {
+		var oldValue, changedBy string
+		if err := d.QueryRow(
+			`SELECT old_value, changed_by FROM activity_log
+			 WHERE issue_id = ? AND field_changed = 'relation_removed'`, tc.issueID,
+		).Scan(&oldValue, &changedBy); err != nil {
+			t.Fatalf("querying relation_removed activity for %s: %v", model.FormatID(tc.issueID), err)
+		}
+		if oldValue != tc.want {
+			t.Errorf("%s: expected old_value %q, got %q", model.FormatID(tc.issueID), tc.want, oldValue)
+		}
+		if changedBy != "alice" {
+			t.Errorf("%s: expected changed_by %q, got %q", model.FormatID(tc.issueID), "alice", changedBy)
+		}
+	}
+}
+
+func TestDeleteRelationByIDNotFound(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	err := DeleteRelationByID(d, 999, "alice")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestChangeRelationType(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, d, "issue A")
+	b := mustCreateIssue(t, d, "issue B")
+	relID := mustCreateRelation(t, d, a, b, model.RelationRelatesTo)
+
+	if err := ChangeRelationType(d, relID, model.RelationBlocks, "alice"); err != nil {
+		t.Fatalf("ChangeRelationType: %v", err)
+	}
+
+	rels, err := GetIssueRelations(d, a)
+	if err != nil {
+		t.Fatalf("GetIssueRelations: %v", err)
+	}
+	if len(rels) != 1 || rels[0].ID != relID || rels[0].RelationType != model.RelationBlocks {
+		t.Fatalf("expected relation %d to be blocks, got %+v", relID, rels)
+	}
+
+	for _, tc := range []struct {
+		issueID  int
+		old, new string
+	}{
+		{a, "relates_to " + model.FormatID(b), "blocks " + model.FormatID(b)},
+		{b, "relates_to " + model.FormatID(a), "blocked_by " + model.FormatID(a)},
+	} {
+		var oldValue, newValue, changedBy string
+		if err := d.QueryRow(
+			`SELECT old_value, new_value, changed_by FROM activity_log
+			 WHERE issue_id = ? AND field_changed = 'relation_changed'`, tc.issueID,
+		).Scan(&oldValue, &newValue, &changedBy); err != nil {
+			t.Fatalf("querying relation_changed activity for %s: %v", model.FormatID(tc.issueID), err)
+		}
+		if oldValue != tc.old || newValue != tc.new {
+			t.Errorf("%s: expected %q -> %q, got %q -> %q", model.FormatID(tc.issueID), tc.old, tc.new, oldValue, newValue)
+		}
+		if changedBy != "alice" {
+			t.Errorf("%s: expected changed_by %q, got %q", model.FormatID(tc.issueID), "alice", changedBy)
+		}
+	}
+}
+
+func TestChangeRelationTypeRejectsCycle(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, d, "issue A")
+	b := mustCreateIssue(t, d, "issue B")
+	c := mustCreateIssue(t, d, "issue C")
+	mustCreateRelation(t, d, a, b, model.RelationBlocks)
+	mustCreateRelation(t, d, b, c, model.RelationBlocks)
+	relID := mustCreateRelation(t, d, c, a, model.RelationRelatesTo)
+
+	err := ChangeRelationType(d, relID, model.RelationBlocks, "alice")
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("expected CycleError, got %v", err)
+	}
+	if !errors.Is(err, ErrCycleDetected) {
+		t.Errorf("expected error to wrap ErrCycleDetected")
+	}
+
+	var relType string
+	if err := d.QueryRow(`SELECT relation_type FROM issue_relations WHERE id = ?`, relID).Scan(&relType); err != nil {
+		t.Fatalf("querying relation: %v", err)
+	}
+	if relType != string(model.RelationRelatesTo) {
+		t.Errorf("expected relation to stay relates_to, got %q", relType)
+	}
+}
+
+func TestChangeRelationTypeErrors(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, d, "issue A")
+	b := mustCreateIssue(t, d, "issue B")
+	relID := mustCreateRelation(t, d, a, b, model.RelationRelatesTo)
+	mustCreateRelation(t, d, b, a, model.RelationBlocks)
+
+	if err := ChangeRelationType(d, 999, model.RelationBlocks, "alice"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing relation: expected ErrNotFound, got %v", err)
+	}
+	if err := ChangeRelationType(d, relID, "nonsense", "alice"); !errors.Is(err, ErrValidation) {
+		t.Errorf("unknown type: expected ErrValidation, got %v", err)
+	}
+	if err := ChangeRelationType(d, relID, model.RelationBlocks, "alice"); !errors.Is(err, ErrDuplicateRelation) {
+		t.Errorf("inverse duplicate: expected ErrDuplicateRelation, got %v", err)
+	}
+}
+
+func TestRelationQueriesOrderByIDWithinSameTimestamp(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	a := mustCreateIssue(t, d, "A")
+	b := mustCreateIssue(t, d, "B")
+	c := mustCreateIssue(t, d, "C")
+
+	// Insert out of ID order, with IDs 3 and 1 sharing a timestamp and ID 2
+	// created earlier than both.
+	const same = "2026-01-01T00:00:00Z"
+	for _, row := range []struct {
+		id       int
+		src, tgt int
+		relType  string
+		created  string
+	}{
+		{3, a, c, "blocks", same},
+		{1, a, b, "blocks", same},
+		{2, b, c, "depends_on", "2025-12-31T00:00:00Z"},
+	} {
+		if _, err := d.Exec(
+			`INSERT INTO issue_relations (id, source_issue_id, target_issue_id, relation_type, created_at) VALUES (?, ?, ?, ?, ?)`,
+			row.id, row.src, row.tgt, row.relType, row.created,
+		); err != nil {
+			t.Fatalf("inserting relation %d: %v", row.id, err)
+		}
+	}
+
+	ids := func(rels []model.Relation) []int {
+		out := make([]int, 0, len(rels))
+		for _, r := range rels {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+	check := func(name string, fetch func() ([]model.Relation, error), want []int) {
+		t.Helper()
+		for range 5 {
+			rels, err := fetch()
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			if got := ids(rels); !slices.Equal(got, want) {
+				t.Fatalf("%s order = %v, want %v", name, got, want)
+			}
+		}
+	}
+
+	check("GetAllRelations", func() ([]model.Relation, error) { return GetAllRelations(d) }, []int{2, 1, 3})
+	check("GetAllDirectionalRelations", func() ([]model.Relation, error) { return GetAllDirectionalRelations(d) }, []int{2, 1, 3})
+	check("GetIssueRelations", func() ([]model.Relation, error) { return GetIssueRelations(d, a) }, []int{1, 3})
+}
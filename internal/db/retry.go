@@ -0,0 +1,92 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Exported write functions run their work through WithRetry; the unexported
+// lowercase counterparts (createIssue for CreateIssue, and so on) hold the
+// single-attempt bodies.
+
+// ErrBusy is matched (via errors.Is) by every error returned after a write
+// gave up waiting for another process's lock.
+var ErrBusy = errors.New("database is busy")
+
+// BusyError reports that a write still hit SQLITE_BUSY or SQLITE_LOCKED after
+// retrying for Waited. It matches ErrBusy and unwraps to the last driver error.
+type BusyError struct {
+	Waited time.Duration
+	Err    error
+}
+
+func (e *BusyError) Error() string {
+	return fmt.Sprintf("database is busy — another docket process is writing; retried for %s", e.Waited.Round(time.Second))
+}
+
+func (e *BusyError) Unwrap() error { return e.Err }
+
+func (e *BusyError) Is(target error) bool { return target == ErrBusy }
+
+// Retry tuning. busy_timeout already blocks inside SQLite for up to 5s per
+// attempt; these bound the retries layered on top for the cases SQLite
+// reports immediately (e.g. a deferred transaction that cannot upgrade to a
+// write lock).
+var (
+	retryBudget     = 10 * time.Second
+	retryBaseDelay  = 25 * time.Millisecond
+	retryMaxBackoff = time.Second
+)
+
+// IsBusy reports whether err is an SQLITE_BUSY or SQLITE_LOCKED error from the
+// driver, including extended result codes such as SQLITE_BUSY_SNAPSHOT.
+func IsBusy(err error) bool {
+	var se *sqlite.Error
+	if !errors.As(err, &se) {
+		return false
+	}
+	switch se.Code() & 0xff {
+	case sqlite3.SQLITE_BUSY, sqlite3.SQLITE_LOCKED:
+		return true
+	}
+	return false
+}
+
+// WithRetry runs fn, re-running it with jittered exponential backoff while it
+// fails with a busy error, for up to retryBudget. fn must be safe to repeat,
+// which holds for a function that does all of its work in one transaction
+// since a busy failure rolls the transaction back. Once the budget is spent
+// the last error is wrapped in a *BusyError; other errors return immediately.
+func WithRetry(fn func() error) error {
+	start := time.Now()
+	delay := retryBaseDelay
+	for {
+		err := fn()
+		if err == nil || !IsBusy(err) {
+			return err
+		}
+		waited := time.Since(start)
+		if waited >= retryBudget {
+			return &BusyError{Waited: waited, Err: err}
+		}
+		sleep := delay/2 + rand.N(delay/2+1)
+		time.Sleep(min(sleep, retryBudget-waited))
+		delay = min(delay*2, retryMaxBackoff)
+	}
+}
+
+// withRetryValue is WithRetry for functions that also return a value.
+func withRetryValue[T any](fn func() (T, error)) (T, error) {
+	var v T
+	err := WithRetry(func() error {
+		var err error
+		v, err = fn()
+		return err
+	})
+	return v, err
+}
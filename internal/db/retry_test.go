@@ -0,0 +1,205 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// mustOpenFile opens (creating if needed) a file-backed database at path.
+// Unlike :memory:, separate handles to the same path share one database, so
+// they contend for the same write lock.
+func mustOpenFile(t *testing.T, path string) *sql.DB {
+	t.Helper()
+	db, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open(%s): %v", path, err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// holdWriteLock takes the write lock on db and returns a function that
+// releases it.
+func holdWriteLock(t *testing.T, db *sql.DB) func() {
+	t.Helper()
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("Conn: %v", err)
+	}
+	if _, err := conn.ExecContext(context.Background(), "BEGIN IMMEDIATE"); err != nil {
+		t.Fatalf("BEGIN IMMEDIATE: %v", err)
+	}
+	return func() {
+		conn.ExecContext(context.Background(), "ROLLBACK")
+		conn.Close()
+	}
+}
+
+// setRetryBudget shortens the retry budget for the duration of a test.
+func setRetryBudget(t *testing.T, d time.Duration) {
+	t.Helper()
+	old := retryBudget
+	retryBudget = d
+	t.Cleanup(func() { retryBudget = old })
+}
+
+func newFileDBPair(t *testing.T) (holder, writer *sql.DB) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "docket.db")
+	holder = mustOpenFile(t, path)
+	if err := Initialize(holder); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	if err := Migrate(holder); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	writer = mustOpenFile(t, path)
+	// Fail fast inside SQLite so the test exercises WithRetry, not busy_timeout.
+	if _, err := writer.Exec("PRAGMA busy_timeout=0"); err != nil {
+		t.Fatalf("busy_timeout: %v", err)
+	}
+	return holder, writer
+}
+
+func TestWithRetryReturnsBusyErrorWhenLockIsHeld(t *testing.T) {
+	setRetryBudget(t, 200*time.Millisecond)
+	holder, writer := newFileDBPair(t)
+
+	release := holdWriteLock(t, holder)
+	defer release()
+
+	_, err := CreateIssue(writer, &model.Issue{
+		Title: "blocked", Status: model.StatusBacklog, Priority: model.PriorityNone, Kind: model.IssueKindTask,
+	}, nil, nil)
+	if !errors.Is(err, ErrBusy) {
+		t.Fatalf("CreateIssue error = %v, want ErrBusy", err)
+	}
+	var be *BusyError
+	if !errors.As(err, &be) || be.Waited < 200*time.Millisecond {
+		t.Errorf("expected *BusyError waited >= budget, got %#v", err)
+	}
+	if !IsBusy(be.Err) {
+		t.Errorf("BusyError should unwrap to the driver busy error, got %v", be.Err)
+	}
+}
+
+func TestWithRetrySucceedsOnceLockIsReleased(t *testing.T) {
+	setRetryBudget(t, 5*time.Second)
+	holder, writer := newFileDBPair(t)
+
+	release := holdWriteLock(t, holder)
+	time.AfterFunc(150*time.Millisecond, release)
+
+	id, err := CreateIssue(writer, &model.Issue{
+		Title: "eventually", Status: model.StatusBacklog, Priority: model.PriorityNone, Kind: model.IssueKindTask,
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if id == 0 {
+		t.Error("expected a non-zero issue ID")
+	}
+}
+
+func TestWithRetryDoesNotRetryOtherErrors(t *testing.T) {
+	calls := 0
+	want := errors.New("boom")
+	err := WithRetry(func() error {
+		calls++
+		return want
+	})
+	if err != want || calls != 1 {
+		t.Errorf("WithRetry = %v after %d calls, want %v after 1", err, calls, want)
+	}
+	if IsBusy(err) || errors.Is(err, ErrBusy) {
+		t.Error("non-driver error must not be classified as busy")
+	}
+}
+
+func TestConcurrentCreateIssueAcrossConnections(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docket.db")
+	setup := mustOpenFile(t, path)
+	if err := Initialize(setup); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	if err := Migrate(setup); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	seed := mustCreateIssue(t, setup, "seed")
+
+	const workers, perWorker = 8, 10
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*perWorker*2)
+	for w := 0; w < workers; w++ {
+		conn := mustOpenFile(t, path)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				if _, err := CreateIssue(conn, &model.Issue{
+					Title: "concurrent", Description: "see DKT-1", Status: model.StatusTodo,
+					Priority: model.PriorityLow, Kind: model.IssueKindTask,
+				}, []string{"load"}, nil); err != nil {
+					errs <- err
+				}
+				// A read-then-write transaction, which can fail with
+				// SQLITE_BUSY_SNAPSHOT regardless of busy_timeout.
+				if err := UpdateIssue(conn, seed, map[string]interface{}{"assignee": "w"}, "tester"); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("concurrent write failed: %v", err)
+	}
+
+	n, err := CountIssues(setup)
+	if err != nil {
+		t.Fatalf("CountIssues: %v", err)
+	}
+	if want := 1 + workers*perWorker; n != want {
+		t.Errorf("CountIssues = %d, want %d", n, want)
+	}
+}
+
+func TestGetLockStatus(t *testing.T) {
+	holder, probe := newFileDBPair(t)
+
+	st, err := GetLockStatus(probe)
+	if err != nil {
+		t.Fatalf("GetLockStatus: %v", err)
+	}
+	if st.JournalMode != "wal" || st.WriteLocked {
+		t.Errorf("unlocked status = %+v, want wal and not locked", st)
+	}
+
+	release := holdWriteLock(t, holder)
+	defer release()
+
+	st, err = GetLockStatus(probe)
+	if err != nil {
+		t.Fatalf("GetLockStatus while locked: %v", err)
+	}
+	if !st.WriteLocked {
+		t.Errorf("status while locked = %+v, want WriteLocked", st)
+	}
+
+	// The probe must restore the connection's own busy timeout.
+	var timeout int
+	if err := probe.QueryRow("PRAGMA busy_timeout").Scan(&timeout); err != nil {
+		t.Fatalf("busy_timeout: %v", err)
+	}
+	if timeout != 0 {
+		t.Errorf("busy_timeout after probe = %d, want 0", timeout)
+	}
+}
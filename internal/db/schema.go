@@ -2,11 +2,12 @@ package db
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
 )
 
-const currentSchemaVersion = 4
+const currentSchemaVersion = 21
 
 // schemaDDL contains the CREATE TABLE statements for the initial schema.
 const schemaDDL = `
@@ -24,8 +25,15 @@ CREATE TABLE IF NOT EXISTS issues (
 	priority    TEXT NOT NULL DEFAULT 'none',
 	kind        TEXT NOT NULL DEFAULT 'task',
 	assignee    TEXT,
+	milestone_id INTEGER REFERENCES milestones(id) ON DELETE SET NULL,
 	created_at  TEXT NOT NULL,
-	updated_at  TEXT NOT NULL
+	updated_at  TEXT NOT NULL,
+	deleted_at  TEXT,
+	started_at  TEXT,
+	completed_at TEXT,
+	snoozed_until TEXT,
+	sort_order  INTEGER,
+	pinned      INTEGER NOT NULL DEFAULT 0
 );
 
 CREATE TABLE IF NOT EXISTS comments (
@@ -39,7 +47,8 @@ CREATE TABLE IF NOT EXISTS comments (
 CREATE TABLE IF NOT EXISTS labels (
 	id    INTEGER PRIMARY KEY AUTOINCREMENT,
 	name  TEXT NOT NULL UNIQUE,
-	color TEXT
+	color TEXT,
+	last_used_at TEXT
 );
 
 CREATE TABLE IF NOT EXISTS issue_labels (
@@ -54,6 +63,7 @@ CREATE TABLE IF NOT EXISTS issue_relations (
 	target_issue_id INTEGER NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
 	relation_type   TEXT NOT NULL,
 	created_at      TEXT NOT NULL,
+	note            TEXT NOT NULL DEFAULT '',
 	UNIQUE(source_issue_id, target_issue_id, relation_type)
 );
 
@@ -92,6 +102,223 @@ CREATE TABLE IF NOT EXISTS issue_files (
 	PRIMARY KEY (issue_id, file_path)
 );
 CREATE INDEX IF NOT EXISTS idx_issue_files_file_path ON issue_files(file_path);
+` + issueReferencesDDL + milestonesDDL + milestoneIndexDDL + issueLinksDDL + trashIndexDDL + completedIndexDDL + notificationsDDL + relationTypesDDL + snoozedIndexDDL + subIssueStatsDDL + commentAuthorIndexDDL + issueWatchersDDL
+
+// issueReferencesDDL creates the issue_references table, which records issue
+// IDs mentioned in descriptions and comments. It is part of schemaDDL and is
+// also applied by migrateV4ToV5 for existing databases.
+const issueReferencesDDL = `
+CREATE TABLE IF NOT EXISTS issue_references (
+	id            INTEGER PRIMARY KEY AUTOINCREMENT,
+	from_issue_id INTEGER NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+	to_issue_id   INTEGER NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+	context       TEXT NOT NULL,
+	comment_id    INTEGER REFERENCES comments(id) ON DELETE CASCADE,
+	created_at    TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_issue_references_from ON issue_references(from_issue_id);
+CREATE INDEX IF NOT EXISTS idx_issue_references_to ON issue_references(to_issue_id);
+CREATE INDEX IF NOT EXISTS idx_issue_references_comment_id ON issue_references(comment_id);
+`
+
+// milestonesDDL creates the milestones table. Like issueReferencesDDL it is
+// part of schemaDDL and is also applied by migrateV6ToV7.
+const milestonesDDL = `
+CREATE TABLE IF NOT EXISTS milestones (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	name        TEXT NOT NULL UNIQUE,
+	description TEXT NOT NULL DEFAULT '',
+	due_date    TEXT,
+	closed      INTEGER NOT NULL DEFAULT 0,
+	created_at  TEXT NOT NULL,
+	updated_at  TEXT NOT NULL
+);
+`
+
+// milestoneIndexDDL indexes issues.milestone_id. It is kept apart from
+// milestonesDDL because migrateV6ToV7 must add the column first.
+const milestoneIndexDDL = `
+CREATE INDEX IF NOT EXISTS idx_issues_milestone_id ON issues(milestone_id);
+`
+
+// issueLinksDDL creates the issue_links table, which holds URLs attached to
+// issues. It is part of schemaDDL and is also applied by migrateV7ToV8.
+const issueLinksDDL = `
+CREATE TABLE IF NOT EXISTS issue_links (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	issue_id   INTEGER NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+	url        TEXT NOT NULL,
+	title      TEXT NOT NULL DEFAULT '',
+	created_at TEXT NOT NULL,
+	UNIQUE(issue_id, url)
+);
+`
+
+// trashIndexDDL indexes issues.deleted_at, which is set while an issue is in
+// the trash. It is kept apart from the issues table because migrateV8ToV9
+// must add the column first.
+const trashIndexDDL = `
+CREATE INDEX IF NOT EXISTS idx_issues_deleted_at ON issues(deleted_at);
+`
+
+// completedIndexDDL indexes issues.completed_at for "what shipped" queries.
+// It is kept apart from the issues table because migrateV10ToV11 must add
+// the column first.
+const completedIndexDDL = `
+CREATE INDEX IF NOT EXISTS idx_issues_completed_at ON issues(completed_at);
+`
+
+// snoozedIndexDDL indexes issues.snoozed_until, which listings compare
+// against the current time. It is kept apart from the issues table because
+// migrateV14ToV15 must add the column first.
+const snoozedIndexDDL = `
+CREATE INDEX IF NOT EXISTS idx_issues_snoozed_until ON issues(snoozed_until);
+`
+
+// subIssueStatsDDL creates sub_issue_stats, which caches the done and total
+// counts of each issue's descendants for GetCachedSubIssueProgress, and the
+// triggers that keep it up to date. As in GetBatchSubIssueProgress, an
+// issue's descendants are those reachable through sub-issues that are not in
+// the trash. Every write to an issue's status, parent or trash state moves
+// the issue's own count plus its cached descendant counts from the old chain
+// of ancestors to the new one, in the same transaction as the write. The
+// chain stops above the first ancestor in the trash. It is part of schemaDDL
+// and is also applied by migrateV15ToV16.
+const subIssueStatsDDL = `
+CREATE TABLE IF NOT EXISTS sub_issue_stats (
+	parent_id INTEGER PRIMARY KEY,
+	done      INTEGER NOT NULL DEFAULT 0,
+	total     INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TRIGGER IF NOT EXISTS trg_sub_issue_stats_insert
+AFTER INSERT ON issues
+WHEN NEW.parent_id IS NOT NULL AND NEW.deleted_at IS NULL
+BEGIN
+	INSERT INTO sub_issue_stats (parent_id, done, total)
+	SELECT id,
+		COALESCE((SELECT done FROM sub_issue_stats WHERE parent_id = NEW.id), 0) + (NEW.status = 'done'),
+		COALESCE((SELECT total FROM sub_issue_stats WHERE parent_id = NEW.id), 0) + 1
+	FROM (
+		WITH RECURSIVE chain(id, parent_id, live) AS (
+			SELECT id, parent_id, deleted_at IS NULL FROM issues WHERE id = NEW.parent_id
+			UNION
+			SELECT i.id, i.parent_id, i.deleted_at IS NULL FROM issues i JOIN chain c ON i.id = c.parent_id WHERE c.live
+		)
+		SELECT id FROM chain
+	)
+	WHERE true
+	ON CONFLICT(parent_id) DO UPDATE SET done = done + excluded.done, total = total + excluded.total;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_sub_issue_stats_update
+AFTER UPDATE OF status, parent_id, deleted_at ON issues
+WHEN OLD.status IS NOT NEW.status
+  OR OLD.parent_id IS NOT NEW.parent_id
+  OR (OLD.deleted_at IS NULL) != (NEW.deleted_at IS NULL)
+BEGIN
+	INSERT INTO sub_issue_stats (parent_id, done, total)
+	SELECT id,
+		-(COALESCE((SELECT done FROM sub_issue_stats WHERE parent_id = OLD.id), 0) + (OLD.status = 'done')),
+		-(COALESCE((SELECT total FROM sub_issue_stats WHERE parent_id = OLD.id), 0) + 1)
+	FROM (
+		WITH RECURSIVE chain(id, parent_id, live) AS (
+			SELECT id, parent_id, deleted_at IS NULL FROM issues WHERE id = OLD.parent_id
+			UNION
+			SELECT i.id, i.parent_id, i.deleted_at IS NULL FROM issues i JOIN chain c ON i.id = c.parent_id WHERE c.live
+		)
+		SELECT id FROM chain
+	)
+	WHERE OLD.deleted_at IS NULL
+	ON CONFLICT(parent_id) DO UPDATE SET done = done + excluded.done, total = total + excluded.total;
+	INSERT INTO sub_issue_stats (parent_id, done, total)
+	SELECT id,
+		COALESCE((SELECT done FROM sub_issue_stats WHERE parent_id = NEW.id), 0) + (NEW.status = 'done'),
+		COALESCE((SELECT total FROM sub_issue_stats WHERE parent_id = NEW.id), 0) + 1
+	FROM (
+		WITH RECURSIVE chain(id, parent_id, live) AS (
+			SELECT id, parent_id, deleted_at IS NULL FROM issues WHERE id = NEW.parent_id
+			UNION
+			SELECT i.id, i.parent_id, i.deleted_at IS NULL FROM issues i JOIN chain c ON i.id = c.parent_id WHERE c.live
+		)
+		SELECT id FROM chain
+	)
+	WHERE NEW.deleted_at IS NULL
+	ON CONFLICT(parent_id) DO UPDATE SET done = done + excluded.done, total = total + excluded.total;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_sub_issue_stats_delete
+BEFORE DELETE ON issues
+BEGIN
+	INSERT INTO sub_issue_stats (parent_id, done, total)
+	SELECT id,
+		-(COALESCE((SELECT done FROM sub_issue_stats WHERE parent_id = OLD.id), 0) + (OLD.status = 'done')),
+		-(COALESCE((SELECT total FROM sub_issue_stats WHERE parent_id = OLD.id), 0) + 1)
+	FROM (
+		WITH RECURSIVE chain(id, parent_id, live) AS (
+			SELECT id, parent_id, deleted_at IS NULL FROM issues WHERE id = OLD.parent_id
+			UNION
+			SELECT i.id, i.parent_id, i.deleted_at IS NULL FROM issues i JOIN chain c ON i.id = c.parent_id WHERE c.live
+		)
+		SELECT id FROM chain
+	)
+	WHERE OLD.deleted_at IS NULL
+	ON CONFLICT(parent_id) DO UPDATE SET done = done + excluded.done, total = total + excluded.total;
+	DELETE FROM sub_issue_stats WHERE parent_id = OLD.id;
+END;
+`
+
+// commentAuthorIndexDDL indexes comments by author, ignoring case as
+// ListComments does, and time, for searching comments across issues. It is
+// part of schemaDDL and is also applied by migrateV17ToV18.
+const commentAuthorIndexDDL = `
+CREATE INDEX IF NOT EXISTS idx_comments_author_created_at ON comments(author COLLATE NOCASE, created_at);
+`
+
+// issueWatchersDDL creates the issue_watchers table, which lists the people
+// following each issue. It is part of schemaDDL and is also applied by
+// migrateV19ToV20.
+const issueWatchersDDL = `
+CREATE TABLE IF NOT EXISTS issue_watchers (
+	issue_id   INTEGER NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+	username   TEXT NOT NULL,
+	created_at TEXT NOT NULL,
+	PRIMARY KEY (issue_id, username)
+);
+`
+
+// notificationsDDL creates the notifications table behind `docket inbox`.
+// It is part of schemaDDL and is also applied by migrateV11ToV12.
+const notificationsDDL = `
+CREATE TABLE IF NOT EXISTS notifications (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	recipient  TEXT NOT NULL,
+	issue_id   INTEGER NOT NULL REFERENCES issues(id) ON DELETE CASCADE,
+	kind       TEXT NOT NULL,
+	actor      TEXT,
+	created_at TEXT NOT NULL,
+	read_at    TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_notifications_recipient ON notifications(recipient COLLATE NOCASE, read_at);
+`
+
+// relationTypesDDL creates the relation_types table and seeds it with the
+// built-in relation types. It is part of schemaDDL and is also applied by
+// migrateV13ToV14.
+const relationTypesDDL = `
+CREATE TABLE IF NOT EXISTS relation_types (
+	name         TEXT PRIMARY KEY,
+	inverse_name TEXT NOT NULL,
+	directional  INTEGER NOT NULL DEFAULT 0,
+	color        TEXT NOT NULL DEFAULT '',
+	builtin      INTEGER NOT NULL DEFAULT 0
+);
+INSERT OR IGNORE INTO relation_types (name, inverse_name, directional, color, builtin) VALUES
+	('blocks', 'blocked_by', 1, 'red', 1),
+	('depends_on', 'dependency_of', 1, 'yellow', 1),
+	('relates_to', 'relates_to', 0, 'blue', 1),
+	('duplicates', 'duplicate_of', 0, 'gray', 1),
+	('supersedes', 'superseded_by', 1, 'magenta', 1);
 `
 
 // Initialize creates all tables if they don't exist and sets the schema version.
@@ -137,9 +364,26 @@ func SchemaVersion(db *sql.DB) (int, error) {
 // migrations is a list of migration functions keyed by the version they migrate TO.
 // For example, migrations[2] migrates from version 1 to version 2.
 var migrations = map[int]func(tx *sql.Tx) error{
-	2: migrateV1ToV2,
-	3: migrateV2ToV3,
-	4: migrateV3ToV4,
+	2:  migrateV1ToV2,
+	3:  migrateV2ToV3,
+	4:  migrateV3ToV4,
+	5:  migrateV4ToV5,
+	6:  migrateV5ToV6,
+	7:  migrateV6ToV7,
+	8:  migrateV7ToV8,
+	9:  migrateV8ToV9,
+	10: migrateV9ToV10,
+	11: migrateV10ToV11,
+	12: migrateV11ToV12,
+	13: migrateV12ToV13,
+	14: migrateV13ToV14,
+	15: migrateV14ToV15,
+	16: migrateV15ToV16,
+	17: migrateV16ToV17,
+	18: migrateV17ToV18,
+	19: migrateV18ToV19,
+	20: migrateV19ToV20,
+	21: migrateV20ToV21,
 }
 
 // migrateV1ToV2 creates the proposals, votes, and proposal_issues tables.
@@ -270,6 +514,303 @@ CREATE INDEX IF NOT EXISTS idx_proposal_docs_doc_id ON proposal_docs(doc_id);
 	return err
 }
 
+// migrateV4ToV5 creates the issue_references table for databases initialized
+// before it joined schemaDDL.
+func migrateV4ToV5(tx *sql.Tx) error {
+	_, err := tx.Exec(issueReferencesDDL)
+	return err
+}
+
+// migrateV5ToV6 creates the issue_templates table used by
+// `docket issue create --template`.
+func migrateV5ToV6(tx *sql.Tx) error {
+	const ddl = `
+CREATE TABLE IF NOT EXISTS issue_templates (
+	name          TEXT PRIMARY KEY,
+	title_pattern TEXT NOT NULL DEFAULT '',
+	description   TEXT NOT NULL DEFAULT '',
+	kind          TEXT NOT NULL DEFAULT 'task',
+	priority      TEXT NOT NULL DEFAULT 'none',
+	labels_json   TEXT NOT NULL DEFAULT '[]',
+	created_at    TEXT NOT NULL,
+	updated_at    TEXT NOT NULL
+);
+`
+	_, err := tx.Exec(ddl)
+	return err
+}
+
+// migrateV6ToV7 creates the milestones table and adds issues.milestone_id.
+// The column is only added when missing, since databases created after it
+// joined schemaDDL already have it.
+func migrateV6ToV7(tx *sql.Tx) error {
+	if _, err := tx.Exec(milestonesDDL); err != nil {
+		return err
+	}
+
+	var hasColumn bool
+	err := tx.QueryRow(
+		`SELECT EXISTS(SELECT 1 FROM pragma_table_info('issues') WHERE name = 'milestone_id')`,
+	).Scan(&hasColumn)
+	if err != nil {
+		return fmt.Errorf("checking issues.milestone_id: %w", err)
+	}
+	if !hasColumn {
+		if _, err := tx.Exec(
+			`ALTER TABLE issues ADD COLUMN milestone_id INTEGER REFERENCES milestones(id) ON DELETE SET NULL`,
+		); err != nil {
+			return fmt.Errorf("migrating v6 to v7: ALTER TABLE issues failed: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(milestoneIndexDDL)
+	return err
+}
+
+// migrateV7ToV8 creates the issue_links table.
+func migrateV7ToV8(tx *sql.Tx) error {
+	_, err := tx.Exec(issueLinksDDL)
+	return err
+}
+
+// migrateV8ToV9 adds issues.deleted_at so deleted issues can be kept in the
+// trash until it is emptied.
+func migrateV8ToV9(tx *sql.Tx) error {
+	var hasColumn bool
+	err := tx.QueryRow(
+		`SELECT EXISTS(SELECT 1 FROM pragma_table_info('issues') WHERE name = 'deleted_at')`,
+	).Scan(&hasColumn)
+	if err != nil {
+		return fmt.Errorf("checking issues.deleted_at: %w", err)
+	}
+	if !hasColumn {
+		if _, err := tx.Exec(`ALTER TABLE issues ADD COLUMN deleted_at TEXT`); err != nil {
+			return fmt.Errorf("migrating v8 to v9: ALTER TABLE issues failed: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(trashIndexDDL)
+	return err
+}
+
+// migrateV9ToV10 rewrites status, priority and kind values that were stored
+// misspelled before UpdateIssue validated them, e.g. "in_progress", to their
+// canonical form. Values that do not normalize to a valid enum are left for
+// `docket doctor` to report.
+func migrateV9ToV10(tx *sql.Tx) error {
+	invalid, err := findInvalidEnumValues(tx)
+	if err != nil {
+		return err
+	}
+	for _, bad := range invalid {
+		if bad.Suggestion == "" {
+			continue
+		}
+		// bad.Field comes from enumColumns, never from user input.
+		if _, err := tx.Exec(`UPDATE issues SET `+bad.Field+` = ? WHERE id = ?`, bad.Suggestion, bad.IssueID); err != nil {
+			return fmt.Errorf("migrating v9 to v10: normalizing %s of issue %d: %w", bad.Field, bad.IssueID, err)
+		}
+	}
+	return nil
+}
+
+// migrateV10ToV11 adds issues.started_at and issues.completed_at and
+// backfills them from the activity log as best it can: started_at from the
+// first move to in-progress, and completed_at for done issues from the last
+// move to done, falling back to updated_at when no such entry exists.
+func migrateV10ToV11(tx *sql.Tx) error {
+	for _, column := range []string{"started_at", "completed_at"} {
+		var hasColumn bool
+		err := tx.QueryRow(
+			`SELECT EXISTS(SELECT 1 FROM pragma_table_info('issues') WHERE name = ?)`, column,
+		).Scan(&hasColumn)
+		if err != nil {
+			return fmt.Errorf("checking issues.%s: %w", column, err)
+		}
+		if !hasColumn {
+			if _, err := tx.Exec(`ALTER TABLE issues ADD COLUMN ` + column + ` TEXT`); err != nil {
+				return fmt.Errorf("migrating v10 to v11: ALTER TABLE issues failed: %w", err)
+			}
+		}
+	}
+
+	for _, stmt := range []string{
+		`UPDATE issues SET started_at = (
+			SELECT MIN(a.created_at) FROM activity_log a
+			WHERE a.issue_id = issues.id AND a.field_changed = 'status' AND a.new_value = 'in-progress'
+		) WHERE started_at IS NULL`,
+		`UPDATE issues SET completed_at = COALESCE((
+			SELECT MAX(a.created_at) FROM activity_log a
+			WHERE a.issue_id = issues.id AND a.field_changed = 'status' AND a.new_value = 'done'
+		), updated_at) WHERE completed_at IS NULL AND status = 'done'`,
+	} {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("migrating v10 to v11: backfilling timestamps: %w", err)
+		}
+	}
+
+	_, err := tx.Exec(completedIndexDDL)
+	return err
+}
+
+// migrateV11ToV12 creates the notifications table.
+func migrateV11ToV12(tx *sql.Tx) error {
+	_, err := tx.Exec(notificationsDDL)
+	return err
+}
+
+// migrateV12ToV13 adds the note column to issue_relations. Existing relations
+// get an empty note.
+func migrateV12ToV13(tx *sql.Tx) error {
+	var hasColumn bool
+	if err := tx.QueryRow(
+		`SELECT EXISTS(SELECT 1 FROM pragma_table_info('issue_relations') WHERE name = 'note')`,
+	).Scan(&hasColumn); err != nil {
+		return fmt.Errorf("checking issue_relations.note: %w", err)
+	}
+	if hasColumn {
+		return nil
+	}
+	if _, err := tx.Exec(`ALTER TABLE issue_relations ADD COLUMN note TEXT NOT NULL DEFAULT ''`); err != nil {
+		return fmt.Errorf("migrating v12 to v13: ALTER TABLE issue_relations failed: %w", err)
+	}
+	return nil
+}
+
+// migrateV13ToV14 creates the relation_types table with the built-in types.
+func migrateV13ToV14(tx *sql.Tx) error {
+	_, err := tx.Exec(relationTypesDDL)
+	return err
+}
+
+// migrateV14ToV15 adds issues.snoozed_until, which hides an issue from
+// listings until that time.
+func migrateV14ToV15(tx *sql.Tx) error {
+	var hasColumn bool
+	if err := tx.QueryRow(
+		`SELECT EXISTS(SELECT 1 FROM pragma_table_info('issues') WHERE name = 'snoozed_until')`,
+	).Scan(&hasColumn); err != nil {
+		return fmt.Errorf("checking issues.snoozed_until: %w", err)
+	}
+	if !hasColumn {
+		if _, err := tx.Exec(`ALTER TABLE issues ADD COLUMN snoozed_until TEXT`); err != nil {
+			return fmt.Errorf("migrating v14 to v15: ALTER TABLE issues failed: %w", err)
+		}
+	}
+
+	_, err := tx.Exec(snoozedIndexDDL)
+	return err
+}
+
+// migrateV15ToV16 creates the sub_issue_stats cache and its triggers, and
+// fills it from the existing issues.
+func migrateV15ToV16(tx *sql.Tx) error {
+	if _, err := tx.Exec(subIssueStatsDDL); err != nil {
+		return fmt.Errorf("migrating v15 to v16: %w", err)
+	}
+	return rebuildSubIssueStats(tx)
+}
+
+// migrateV16ToV17 adds issues.sort_order, a manual rank among siblings that
+// takes precedence over creation order when set.
+func migrateV16ToV17(tx *sql.Tx) error {
+	var hasColumn bool
+	if err := tx.QueryRow(
+		`SELECT EXISTS(SELECT 1 FROM pragma_table_info('issues') WHERE name = 'sort_order')`,
+	).Scan(&hasColumn); err != nil {
+		return fmt.Errorf("checking issues.sort_order: %w", err)
+	}
+	if hasColumn {
+		return nil
+	}
+	if _, err := tx.Exec(`ALTER TABLE issues ADD COLUMN sort_order INTEGER`); err != nil {
+		return fmt.Errorf("migrating v16 to v17: ALTER TABLE issues failed: %w", err)
+	}
+	return nil
+}
+
+// migrateV17ToV18 indexes comments by author and time for ListComments.
+func migrateV17ToV18(tx *sql.Tx) error {
+	_, err := tx.Exec(commentAuthorIndexDDL)
+	return err
+}
+
+// migrateV18ToV19 adds issues.pinned, which keeps an issue ahead of every
+// other issue in lists and boards.
+func migrateV18ToV19(tx *sql.Tx) error {
+	var hasColumn bool
+	if err := tx.QueryRow(
+		`SELECT EXISTS(SELECT 1 FROM pragma_table_info('issues') WHERE name = 'pinned')`,
+	).Scan(&hasColumn); err != nil {
+		return fmt.Errorf("checking issues.pinned: %w", err)
+	}
+	if hasColumn {
+		return nil
+	}
+	if _, err := tx.Exec(`ALTER TABLE issues ADD COLUMN pinned INTEGER NOT NULL DEFAULT 0`); err != nil {
+		return fmt.Errorf("migrating v18 to v19: ALTER TABLE issues failed: %w", err)
+	}
+	return nil
+}
+
+// migrateV19ToV20 creates the issue_watchers table.
+func migrateV19ToV20(tx *sql.Tx) error {
+	_, err := tx.Exec(issueWatchersDDL)
+	return err
+}
+
+// migrateV20ToV21 adds labels.last_used_at, when a label was last attached
+// to or detached from an issue. Existing labels take the latest such time the
+// activity log records, or failing that the creation time of the newest issue
+// carrying them.
+func migrateV20ToV21(tx *sql.Tx) error {
+	var hasColumn bool
+	if err := tx.QueryRow(
+		`SELECT EXISTS(SELECT 1 FROM pragma_table_info('labels') WHERE name = 'last_used_at')`,
+	).Scan(&hasColumn); err != nil {
+		return fmt.Errorf("checking labels.last_used_at: %w", err)
+	}
+	if !hasColumn {
+		if _, err := tx.Exec(`ALTER TABLE labels ADD COLUMN last_used_at TEXT`); err != nil {
+			return fmt.Errorf("migrating v20 to v21: ALTER TABLE labels failed: %w", err)
+		}
+	}
+	if _, err := tx.Exec(
+		`UPDATE labels SET last_used_at = (
+			SELECT MAX(t) FROM (
+				SELECT created_at AS t FROM activity_log
+				WHERE (field_changed = 'label_added' AND new_value = labels.name)
+				   OR (field_changed = 'label_removed' AND old_value = labels.name)
+				UNION ALL
+				SELECT i.created_at FROM issue_labels il JOIN issues i ON i.id = il.issue_id
+				WHERE il.label_id = labels.id
+			)
+		)
+		WHERE last_used_at IS NULL`,
+	); err != nil {
+		return fmt.Errorf("migrating v20 to v21: filling labels.last_used_at failed: %w", err)
+	}
+	return nil
+}
+
+// ErrSchemaOutdated is returned by CheckSchemaCurrent when the database needs
+// migrations that cannot be applied, e.g. because it was opened read-only.
+var ErrSchemaOutdated = errors.New("database schema is out of date")
+
+// CheckSchemaCurrent returns an error wrapping ErrSchemaOutdated when the
+// database is older than the current schema version. It never modifies the
+// database; use Migrate to bring it up to date.
+func CheckSchemaCurrent(db *sql.DB) error {
+	version, err := SchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if version < currentSchemaVersion {
+		return fmt.Errorf("%w: version %d, want %d", ErrSchemaOutdated, version, currentSchemaVersion)
+	}
+	return nil
+}
+
 // Migrate checks the current schema version and applies any pending migrations
 // sequentially. It is a no-op when already at the latest version.
 func Migrate(db *sql.DB) error {
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// SnoozeIssue hides an issue from listings until until, without changing its
+// status, and records a "snoozed" activity entry. Snoozing an issue that is
+// already snoozed moves the time. Done issues cannot be snoozed, and until
+// must be in the future; both are reported as ErrValidation.
+func SnoozeIssue(db *sql.DB, id int, until time.Time, changedBy string) error {
+	return WithRetry(func() error { return setSnooze(db, id, until, changedBy) })
+}
+
+// UnsnoozeIssue clears an issue's snooze and records an "unsnoozed" activity
+// entry. It is a no-op for an issue that is not snoozed.
+func UnsnoozeIssue(db *sql.DB, id int, changedBy string) error {
+	return WithRetry(func() error { return setSnooze(db, id, time.Time{}, changedBy) })
+}
+
+// setSnooze sets snoozed_until to until, or clears it when until is zero.
+func setSnooze(db *sql.DB, id int, until time.Time, changedBy string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	issue, err := getIssueTx(tx, id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	field, oldVal, newVal := "snoozed", "", until.UTC().Format(time.RFC3339)
+	if issue.IsSnoozed(now) {
+		oldVal = issue.SnoozedUntil.UTC().Format(time.RFC3339)
+	}
+	if until.IsZero() {
+		if oldVal == "" {
+			return nil
+		}
+		field, newVal = "unsnoozed", ""
+	} else {
+		if issue.Status == model.StatusDone {
+			return fmt.Errorf("%w: %s is done and cannot be snoozed", ErrValidation, model.FormatID(id))
+		}
+		if !until.After(now) {
+			return fmt.Errorf("%w: snooze time %s is not in the future", ErrValidation, newVal)
+		}
+	}
+
+	if _, err := tx.Exec(
+		`UPDATE issues SET snoozed_until = ?, updated_at = ? WHERE id = ?`,
+		nilIfZeroTime(until), now.Format(time.RFC3339), id,
+	); err != nil {
+		return fmt.Errorf("updating snooze: %w", err)
+	}
+	if err := RecordActivity(tx, id, field, oldVal, newVal, changedBy); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
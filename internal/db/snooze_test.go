@@ -0,0 +1,127 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// listIDs returns the IDs ListIssues returns for opts.
+func listIDs(t *testing.T, d *sql.DB, opts ListOptions) []int {
+	t.Helper()
+	issues, _, err := ListIssues(d, opts)
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	ids := make([]int, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids
+}
+
+func TestSnoozeIssue_HidesUntilExpiry(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	a := mustCreateIssue(t, d, "a")
+	b := mustCreateIssue(t, d, "b")
+
+	until := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	if err := SnoozeIssue(d, a, until, "alice"); err != nil {
+		t.Fatalf("SnoozeIssue: %v", err)
+	}
+
+	if got := listIDs(t, d, ListOptions{}); len(got) != 1 || got[0] != b {
+		t.Errorf("ListIssues = %v, want [%d]", got, b)
+	}
+	if got := listIDs(t, d, ListOptions{IncludeSnoozed: true}); len(got) != 2 {
+		t.Errorf("ListIssues with IncludeSnoozed = %v, want both issues", got)
+	}
+
+	issue, err := GetIssue(d, a)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if !issue.SnoozedUntil.Equal(until) {
+		t.Errorf("SnoozedUntil = %v, want %v", issue.SnoozedUntil, until)
+	}
+
+	// A snooze that has passed no longer hides the issue.
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	if _, err := d.Exec(`UPDATE issues SET snoozed_until = ? WHERE id = ?`, past, a); err != nil {
+		t.Fatalf("expiring snooze: %v", err)
+	}
+	if got := listIDs(t, d, ListOptions{}); len(got) != 2 {
+		t.Errorf("ListIssues after expiry = %v, want both issues", got)
+	}
+}
+
+func TestSnoozeIssue_RecordsActivity(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	id := mustCreateIssue(t, d, "a")
+
+	until := time.Now().Add(24 * time.Hour)
+	if err := SnoozeIssue(d, id, until, "alice"); err != nil {
+		t.Fatalf("SnoozeIssue: %v", err)
+	}
+	if err := UnsnoozeIssue(d, id, "bob"); err != nil {
+		t.Fatalf("UnsnoozeIssue: %v", err)
+	}
+	// Unsnoozing an issue that is not snoozed records nothing.
+	if err := UnsnoozeIssue(d, id, "bob"); err != nil {
+		t.Fatalf("UnsnoozeIssue again: %v", err)
+	}
+
+	activity, err := GetActivity(d, id, 0)
+	if err != nil {
+		t.Fatalf("GetActivity: %v", err)
+	}
+	var fields []string
+	for _, a := range activity {
+		if a.FieldChanged == "snoozed" || a.FieldChanged == "unsnoozed" {
+			fields = append(fields, a.FieldChanged+":"+a.ChangedBy)
+		}
+	}
+	if len(fields) != 2 {
+		t.Fatalf("snooze activity = %v, want one snoozed and one unsnoozed entry", fields)
+	}
+
+	issue, err := GetIssue(d, id)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if !issue.SnoozedUntil.IsZero() {
+		t.Errorf("SnoozedUntil = %v after unsnooze, want zero", issue.SnoozedUntil)
+	}
+}
+
+func TestSnoozeIssue_Validation(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	id := mustCreateIssue(t, d, "a")
+
+	if err := SnoozeIssue(d, id, time.Now().Add(-time.Hour), "alice"); !errors.Is(err, ErrValidation) {
+		t.Errorf("snoozing into the past: err = %v, want ErrValidation", err)
+	}
+
+	if err := UpdateIssue(d, id, map[string]interface{}{"status": model.StatusDone}, "alice"); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+	if err := SnoozeIssue(d, id, time.Now().Add(time.Hour), "alice"); !errors.Is(err, ErrValidation) {
+		t.Errorf("snoozing a done issue: err = %v, want ErrValidation", err)
+	}
+
+	if err := SnoozeIssue(d, 999, time.Now().Add(time.Hour), "alice"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("snoozing a missing issue: err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,169 @@
+package db
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// SortKey is one key of a multi-key issue sort. Dir is "asc" or "desc";
+// empty means "desc".
+type SortKey struct {
+	Field string
+	Dir   string
+}
+
+// String formats k as "field:dir", the syntax ParseSortKeys accepts.
+func (k SortKey) String() string {
+	return k.Field + ":" + sortDir(k.Dir)
+}
+
+// rankSortFields maps the text-valued enum columns to CASE expressions
+// ranking their values by importance, matching statusRank and priorityRank
+// in internal/render: 0 is the most important. Sorting these fields "desc"
+// puts the most important values (in-progress, critical) first.
+var rankSortFields = map[string]string{
+	"status": `CASE i.status
+				WHEN 'in-progress' THEN 0
+				WHEN 'review'      THEN 1
+				WHEN 'todo'        THEN 2
+				WHEN 'backlog'     THEN 3
+				WHEN 'done'        THEN 4
+				ELSE 5
+			END`,
+	"priority": `CASE i.priority
+				WHEN 'critical' THEN 0
+				WHEN 'high'     THEN 1
+				WHEN 'medium'   THEN 2
+				WHEN 'low'      THEN 3
+				WHEN 'none'     THEN 4
+				ELSE 5
+			END`,
+}
+
+// defaultSortKeys is the order ListIssues uses when no sort is given: most
+// active status first, then most urgent priority, then newest first.
+var defaultSortKeys = []SortKey{
+	{Field: "status", Dir: "desc"},
+	{Field: "priority", Dir: "desc"},
+	{Field: "created_at", Dir: "desc"},
+}
+
+// ParseSortKeys parses a comma-separated sort specification such as
+// "priority:desc,updated_at:desc". The direction of each key is optional
+// and defaults to desc. Every field must be a sortable issue field.
+func ParseSortKeys(spec string) ([]SortKey, error) {
+	var keys []SortKey
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		field, dir, _ := strings.Cut(part, ":")
+		key := SortKey{Field: strings.TrimSpace(field), Dir: strings.ToLower(strings.TrimSpace(dir))}
+		if err := validateSortKey(key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: sort specification is empty", ErrValidation)
+	}
+	return keys, nil
+}
+
+// FormatSortKeys formats keys in the syntax ParseSortKeys accepts.
+func FormatSortKeys(keys []SortKey) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// validateSortKey checks that k names a sortable field and a valid direction.
+func validateSortKey(k SortKey) error {
+	if !isSortField(k.Field) {
+		return fmt.Errorf("%w: invalid sort field %q (must be one of %s)", ErrValidation, k.Field, strings.Join(SortFields(), ", "))
+	}
+	if dir := strings.ToLower(k.Dir); dir != "" && dir != "asc" && dir != "desc" {
+		return fmt.Errorf("%w: invalid sort direction %q for %s (must be asc or desc)", ErrValidation, k.Dir, k.Field)
+	}
+	return nil
+}
+
+// SortFields returns the fields issues can be sorted by, sorted by name.
+func SortFields() []string {
+	fields := slices.Collect(maps.Keys(validSortFields))
+	fields = append(fields, slices.Collect(maps.Keys(computedSortFields))...)
+	slices.Sort(fields)
+	return fields
+}
+
+func isSortField(field string) bool {
+	_, computed := computedSortFields[field]
+	return validSortFields[field] || computed
+}
+
+// sortDir returns "asc" when dir is asc in any case and "desc" otherwise.
+func sortDir(dir string) string {
+	if strings.EqualFold(dir, "asc") {
+		return "asc"
+	}
+	return "desc"
+}
+
+// orderByClause builds the ORDER BY clause for keys, or for defaultSortKeys
+// when keys is empty. Issue ID ascending is appended as a final tiebreaker
+// so the order is deterministic.
+func orderByClause(keys []SortKey) (string, error) {
+	if len(keys) == 0 {
+		keys = defaultSortKeys
+	}
+
+	terms := make([]string, 0, len(keys)+1)
+	byID := false
+	for _, k := range keys {
+		if err := validateSortKey(k); err != nil {
+			return "", err
+		}
+		dir := strings.ToUpper(sortDir(k.Dir))
+
+		var expr string
+		if rank, ok := rankSortFields[k.Field]; ok {
+			// Rank 0 is the most important value, so "desc" (most
+			// important first) orders the rank ascending.
+			expr = rank
+			if dir == "DESC" {
+				dir = "ASC"
+			} else {
+				dir = "DESC"
+			}
+		} else if computed, ok := computedSortFields[k.Field]; ok {
+			expr = computed
+		} else {
+			// Defense-in-depth: reject any sort field that doesn't look like a
+			// plain column name, even if it passed the allowlist check above.
+			if !safeIdentifier.MatchString(k.Field) {
+				return "", fmt.Errorf("invalid sort field %q", k.Field)
+			}
+			expr = "i." + k.Field
+		}
+		byID = byID || k.Field == "id"
+
+		// Safe: expr is a constant or an allowlisted column name; dir is
+		// "ASC" or "DESC".
+		terms = append(terms, expr+" "+dir)
+	}
+	if !byID {
+		terms = append(terms, "i.id ASC")
+	}
+	return "ORDER BY " + strings.Join(terms, ", "), nil
+}
+
+// pinnedFirst prefixes orderBy, a clause from orderByClause, so that pinned
+// issues sort ahead of the rest.
+func pinnedFirst(orderBy string) string {
+	return strings.Replace(orderBy, "ORDER BY ", "ORDER BY i.pinned DESC, ", 1)
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestParseSortKeys(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []SortKey
+		wantErr bool
+	}{
+		{"priority:desc,updated_at:desc", []SortKey{{"priority", "desc"}, {"updated_at", "desc"}}, false},
+		{" title : ASC , id", []SortKey{{"title", "asc"}, {"id", ""}}, false},
+		{"comments:asc", []SortKey{{"comments", "asc"}}, false},
+		{"bogus:asc", nil, true},
+		{"priority:sideways", nil, true},
+		{"id;drop table issues", nil, true},
+		{" , ", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseSortKeys(tt.spec)
+			if tt.wantErr {
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("ParseSortKeys(%q) error = %v, want ErrValidation", tt.spec, err)
+				}
+				return
+			}
+			if err != nil || !slices.Equal(got, tt.want) {
+				t.Errorf("ParseSortKeys(%q) = %v, %v; want %v", tt.spec, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderByClause(t *testing.T) {
+	got, err := orderByClause([]SortKey{{"updated_at", "asc"}, {"comments", ""}})
+	if err != nil {
+		t.Fatalf("orderByClause: %v", err)
+	}
+	want := "ORDER BY i.updated_at ASC, (SELECT COUNT(*) FROM comments c WHERE c.issue_id = i.id) DESC, i.id ASC"
+	if got != want {
+		t.Errorf("orderByClause = %q, want %q", got, want)
+	}
+
+	// Rank fields invert the direction so desc means most important first,
+	// and no tiebreaker is added when sorting by id.
+	got, err = orderByClause([]SortKey{{"priority", "desc"}, {"id", "desc"}})
+	if err != nil {
+		t.Fatalf("orderByClause: %v", err)
+	}
+	if !strings.HasPrefix(got, "ORDER BY CASE i.priority") || !strings.HasSuffix(got, "END ASC, i.id DESC") {
+		t.Errorf("orderByClause = %q, want priority rank ascending then i.id DESC", got)
+	}
+
+	if _, err := orderByClause([]SortKey{{"description", "asc"}}); !errors.Is(err, ErrValidation) {
+		t.Errorf("orderByClause(description) error = %v, want ErrValidation", err)
+	}
+}
+
+func TestListIssuesMultiKeyRankSort(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	low := createTestIssue(t, db, "low", model.StatusTodo, model.PriorityLow)
+	critA := createTestIssue(t, db, "crit-a", model.StatusBacklog, model.PriorityCritical)
+	high := createTestIssue(t, db, "high", model.StatusReview, model.PriorityHigh)
+	critB := createTestIssue(t, db, "crit-b", model.StatusInProgress, model.PriorityCritical)
+
+	tests := []struct {
+		name string
+		keys []SortKey
+		want []int
+	}{
+		// Alphabetically "critical" < "high" < "low"; by rank critical is
+		// first when descending and last when ascending.
+		{"priority desc then title asc", []SortKey{{"priority", "desc"}, {"title", "asc"}}, []int{critA, critB, high, low}},
+		{"priority asc then status desc", []SortKey{{"priority", "asc"}, {"status", "desc"}}, []int{low, high, critB, critA}},
+		{"status desc", []SortKey{{"status", "desc"}}, []int{critB, high, low, critA}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, _, err := ListIssues(db, ListOptions{SortKeys: tt.keys})
+			if err != nil {
+				t.Fatalf("ListIssues: %v", err)
+			}
+			got := make([]int, len(issues))
+			for i, issue := range issues {
+				got[i] = issue.ID
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, _, err := ListIssues(db, ListOptions{SortKeys: []SortKey{{"nope", "asc"}}}); !errors.Is(err, ErrValidation) {
+		t.Errorf("ListIssues with an invalid sort key: err = %v, want ErrValidation", err)
+	}
+}
+
+func TestDefaultSort(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	if keys, err := DefaultSort(db); err != nil || keys != nil {
+		t.Fatalf("DefaultSort by default = %v, %v; want nil", keys, err)
+	}
+	if err := SetDefaultSort(db, []SortKey{{"nope", "asc"}}); !errors.Is(err, ErrValidation) {
+		t.Errorf("SetDefaultSort(nope) = %v, want ErrValidation", err)
+	}
+
+	want := []SortKey{{"priority", "desc"}, {"updated_at", "desc"}}
+	if err := SetDefaultSort(db, want); err != nil {
+		t.Fatalf("SetDefaultSort: %v", err)
+	}
+	if keys, err := DefaultSort(db); err != nil || !slices.Equal(keys, want) {
+		t.Errorf("DefaultSort = %v, %v; want %v", keys, err, want)
+	}
+
+	if err := SetDefaultSort(db, nil); err != nil {
+		t.Fatalf("clearing default sort: %v", err)
+	}
+	if keys, err := DefaultSort(db); err != nil || keys != nil {
+		t.Errorf("DefaultSort after clearing = %v, %v; want nil", keys, err)
+	}
+}
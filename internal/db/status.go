@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// lockProbeTimeout bounds how long GetLockStatus waits for the write lock.
+const lockProbeTimeout = 50 * time.Millisecond
+
+// LockStatus describes the connection settings and lock state that govern
+// concurrent access to the database.
+type LockStatus struct {
+	JournalMode string
+	BusyTimeout time.Duration
+	WriteLocked bool
+	// ReadOnly is true for connections opened with OpenReadOnly, which
+	// cannot take the write lock, so it is not probed.
+	ReadOnly bool
+}
+
+// GetLockStatus reports the journal mode and busy timeout of db and whether
+// another connection currently holds the write lock. The lock is probed with
+// BEGIN IMMEDIATE under a short busy timeout and released immediately, unless
+// db is read-only.
+func GetLockStatus(db *sql.DB) (*LockStatus, error) {
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquiring connection: %w", err)
+	}
+	defer conn.Close()
+
+	var st LockStatus
+	if err := conn.QueryRowContext(ctx, "PRAGMA journal_mode").Scan(&st.JournalMode); err != nil {
+		return nil, fmt.Errorf("reading journal_mode: %w", err)
+	}
+	var timeoutMS int
+	if err := conn.QueryRowContext(ctx, "PRAGMA busy_timeout").Scan(&timeoutMS); err != nil {
+		return nil, fmt.Errorf("reading busy_timeout: %w", err)
+	}
+	st.BusyTimeout = time.Duration(timeoutMS) * time.Millisecond
+
+	if err := conn.QueryRowContext(ctx, "PRAGMA query_only").Scan(&st.ReadOnly); err != nil {
+		return nil, fmt.Errorf("reading query_only: %w", err)
+	}
+	if st.ReadOnly {
+		return &st, nil
+	}
+
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("PRAGMA busy_timeout=%d", lockProbeTimeout.Milliseconds())); err != nil {
+		return nil, fmt.Errorf("setting probe busy_timeout: %w", err)
+	}
+	defer conn.ExecContext(ctx, fmt.Sprintf("PRAGMA busy_timeout=%d", timeoutMS))
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		if !IsBusy(err) {
+			return nil, fmt.Errorf("probing write lock: %w", err)
+		}
+		st.WriteLocked = true
+		return &st, nil
+	}
+	if _, err := conn.ExecContext(ctx, "ROLLBACK"); err != nil {
+		return nil, fmt.Errorf("releasing write lock probe: %w", err)
+	}
+	return &st, nil
+}
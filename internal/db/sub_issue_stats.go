@@ -0,0 +1,103 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// metaSubIssueStats is the meta key set to "ready" once sub_issue_stats has
+// been filled, after which the triggers in subIssueStatsDDL keep it current.
+const metaSubIssueStats = "sub_issue_stats"
+
+// GetCachedSubIssueProgress returns the same (done, total) descendant counts
+// as GetBatchSubIssueProgress, read from the sub_issue_stats cache instead of
+// walking the issue tree. It falls back to GetBatchSubIssueProgress when the
+// cache has not been filled. Parents without sub-issues are left out.
+func GetCachedSubIssueProgress(conn *sql.DB, parentIDs []int) (map[int][2]int, error) {
+	if len(parentIDs) == 0 {
+		return nil, nil
+	}
+
+	var state string
+	err := conn.QueryRow(`SELECT value FROM meta WHERE key = ?`, metaSubIssueStats).Scan(&state)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("reading sub-issue stats state: %w", err)
+	}
+	if state != "ready" {
+		return GetBatchSubIssueProgress(conn, parentIDs)
+	}
+
+	args := make([]interface{}, len(parentIDs))
+	for i, id := range parentIDs {
+		args[i] = id
+	}
+	rows, err := conn.Query(
+		`SELECT parent_id, done, total FROM sub_issue_stats
+		 WHERE parent_id IN (`+makePlaceholders(len(parentIDs))+`) AND total > 0`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying sub-issue stats: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[int][2]int)
+	for rows.Next() {
+		var parentID, done, total int
+		if err := rows.Scan(&parentID, &done, &total); err != nil {
+			return nil, fmt.Errorf("scanning sub-issue stats: %w", err)
+		}
+		result[parentID] = [2]int{done, total}
+	}
+	return result, rows.Err()
+}
+
+// RebuildSubIssueStats refills the sub_issue_stats cache from the issue
+// tree. The cache is kept current as issues change, so this is only needed
+// to repair it, e.g. after editing the database by hand.
+func RebuildSubIssueStats(db *sql.DB) error {
+	return WithRetry(func() error {
+		tx, err := db.Begin()
+		if err != nil {
+			return fmt.Errorf("beginning transaction: %w", err)
+		}
+		defer tx.Rollback()
+
+		if err := rebuildSubIssueStats(tx); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+// rebuildSubIssueStats replaces the contents of sub_issue_stats with counts
+// computed as GetBatchSubIssueProgress does, for every issue at once, and
+// marks the cache ready.
+func rebuildSubIssueStats(ex execer) error {
+	if _, err := ex.Exec(`DELETE FROM sub_issue_stats`); err != nil {
+		return fmt.Errorf("clearing sub-issue stats: %w", err)
+	}
+	if _, err := ex.Exec(`INSERT INTO sub_issue_stats (parent_id, done, total)
+		WITH RECURSIVE tree(id, root_parent_id) AS (
+			SELECT id, parent_id FROM issues WHERE parent_id IS NOT NULL AND deleted_at IS NULL
+			UNION ALL
+			SELECT i.id, t.root_parent_id FROM issues i JOIN tree t ON i.parent_id = t.id WHERE i.deleted_at IS NULL
+		)
+		SELECT
+			t.root_parent_id,
+			COALESCE(SUM(CASE WHEN i.status = 'done' THEN 1 ELSE 0 END), 0),
+			COUNT(*)
+		FROM issues i JOIN tree t ON i.id = t.id
+		GROUP BY t.root_parent_id`); err != nil {
+		return fmt.Errorf("computing sub-issue stats: %w", err)
+	}
+	if _, err := ex.Exec(
+		`INSERT INTO meta (key, value) VALUES (?, 'ready')
+		 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+		metaSubIssueStats,
+	); err != nil {
+		return fmt.Errorf("marking sub-issue stats ready: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// assertStatsConsistent checks that the sub_issue_stats cache gives the same
+// progress as walking the issue tree, for every issue.
+func assertStatsConsistent(t *testing.T, d *sql.DB, step string) {
+	t.Helper()
+	var ids []int
+	rows, err := d.Query(`SELECT id FROM issues`)
+	if err != nil {
+		t.Fatalf("%s: listing issue IDs: %v", step, err)
+	}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			t.Fatalf("%s: scanning issue ID: %v", step, err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	want, err := GetBatchSubIssueProgress(d, ids)
+	if err != nil {
+		t.Fatalf("%s: GetBatchSubIssueProgress: %v", step, err)
+	}
+	got, err := GetCachedSubIssueProgress(d, ids)
+	if err != nil {
+		t.Fatalf("%s: GetCachedSubIssueProgress: %v", step, err)
+	}
+	if len(want) == 0 && len(got) == 0 {
+		return
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("%s: cached progress = %v, want %v", step, got, want)
+	}
+}
+
+func TestSubIssueStats_StayConsistent(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	if err := Migrate(d); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	//   root
+	//   ├── a
+	//   │   ├── a1
+	//   │   └── a2 (done)
+	//   │       └── a2x
+	//   └── b (done)
+	root := createTestIssue(t, d, "root", model.StatusTodo, model.PriorityNone)
+	a := createTestIssueWithParent(t, d, "a", model.StatusTodo, model.PriorityNone, root)
+	a1 := createTestIssueWithParent(t, d, "a1", model.StatusTodo, model.PriorityNone, a)
+	a2 := createTestIssueWithParent(t, d, "a2", model.StatusDone, model.PriorityNone, a)
+	a2x := createTestIssueWithParent(t, d, "a2x", model.StatusTodo, model.PriorityNone, a2)
+	b := createTestIssueWithParent(t, d, "b", model.StatusDone, model.PriorityNone, root)
+	other := createTestIssue(t, d, "other", model.StatusTodo, model.PriorityNone)
+	assertStatsConsistent(t, d, "create")
+
+	got, err := GetCachedSubIssueProgress(d, []int{root, a, a2, b})
+	if err != nil {
+		t.Fatalf("GetCachedSubIssueProgress: %v", err)
+	}
+	if want := map[int][2]int{root: {2, 5}, a: {1, 3}, a2: {0, 1}}; !maps.Equal(got, want) {
+		t.Errorf("progress = %v, want %v", got, want)
+	}
+
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{"close leaf", func() error {
+			return UpdateIssue(d, a2x, map[string]interface{}{"status": model.StatusDone}, "t")
+		}},
+		{"reopen", func() error {
+			return UpdateIssue(d, a2, map[string]interface{}{"status": model.StatusTodo}, "t")
+		}},
+		{"reparent subtree", func() error {
+			return UpdateIssue(d, a2, map[string]interface{}{"parent_id": other}, "t")
+		}},
+		{"make root", func() error {
+			return UpdateIssue(d, a, map[string]interface{}{"parent_id": nil}, "t")
+		}},
+		{"reparent back", func() error {
+			return UpdateIssue(d, a, map[string]interface{}{"parent_id": root}, "t")
+		}},
+		{"trash middle", func() error {
+			_, err := TrashIssue(d, a2, "t")
+			return err
+		}},
+		{"restore", func() error {
+			_, err := RestoreIssue(d, a2, "t")
+			return err
+		}},
+		{"trash leaf", func() error {
+			_, err := TrashIssue(d, a1, "t")
+			return err
+		}},
+		{"empty trash", func() error {
+			_, err := EmptyTrash(d, time.Time{})
+			return err
+		}},
+		{"orphan", func() error { return OrphanSubIssues(d, other, "t") }},
+		{"merge", func() error {
+			_, err := MergeIssue(d, a, b, MergeOptions{ChangedBy: "t"})
+			return err
+		}},
+		{"hard delete parent", func() error { return DeleteIssue(d, b) }},
+		{"hard delete leaf", func() error { return DeleteIssue(d, a2x) }},
+	}
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		assertStatsConsistent(t, d, step.name)
+	}
+}
+
+func TestRebuildSubIssueStats(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	if err := Migrate(d); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	parent := createTestIssue(t, d, "parent", model.StatusTodo, model.PriorityNone)
+	createTestIssueWithParent(t, d, "child", model.StatusDone, model.PriorityNone, parent)
+
+	// Corrupt the cache, then repair it.
+	if _, err := d.Exec(`UPDATE sub_issue_stats SET done = 7, total = 9`); err != nil {
+		t.Fatalf("corrupting stats: %v", err)
+	}
+	if err := RebuildSubIssueStats(d); err != nil {
+		t.Fatalf("RebuildSubIssueStats: %v", err)
+	}
+	assertStatsConsistent(t, d, "rebuild")
+}
+
+func TestGetCachedSubIssueProgress_FallsBackWhenNotReady(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	if err := Migrate(d); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	parent := createTestIssue(t, d, "parent", model.StatusTodo, model.PriorityNone)
+	createTestIssueWithParent(t, d, "child", model.StatusDone, model.PriorityNone, parent)
+
+	if _, err := d.Exec(`DELETE FROM sub_issue_stats`); err != nil {
+		t.Fatalf("clearing stats: %v", err)
+	}
+	if _, err := d.Exec(`DELETE FROM meta WHERE key = ?`, metaSubIssueStats); err != nil {
+		t.Fatalf("clearing stats state: %v", err)
+	}
+	got, err := GetCachedSubIssueProgress(d, []int{parent})
+	if err != nil {
+		t.Fatalf("GetCachedSubIssueProgress: %v", err)
+	}
+	if want := map[int][2]int{parent: {1, 1}}; !maps.Equal(got, want) {
+		t.Errorf("progress = %v, want %v", got, want)
+	}
+}
+
+// seedSubIssueBenchmark creates a tree of n issues, each having up to fanout
+// children, and returns the first 50 IDs as the parents to query. Walking
+// the tree grows faster than n, so even 1000 issues show the difference.
+func seedSubIssueBenchmark(b *testing.B, n, fanout int) (*sql.DB, []int) {
+	b.Helper()
+	d, err := Open(":memory:")
+	if err != nil {
+		b.Fatalf("Open: %v", err)
+	}
+	b.Cleanup(func() { d.Close() })
+	if err := Initialize(d); err != nil {
+		b.Fatalf("Initialize: %v", err)
+	}
+	if err := Migrate(d); err != nil {
+		b.Fatalf("Migrate: %v", err)
+	}
+
+	tx, err := d.Begin()
+	if err != nil {
+		b.Fatalf("Begin: %v", err)
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	var parents []int
+	for id := 1; id <= n; id++ {
+		var parent any
+		if id > 1 {
+			parent = (id-2)/fanout + 1
+		}
+		status := "todo"
+		if id%3 == 0 {
+			status = "done"
+		}
+		if _, err := tx.Exec(
+			`INSERT INTO issues (id, parent_id, title, status, priority, kind, created_at, updated_at)
+			 VALUES (?, ?, ?, ?, 'none', 'task', ?, ?)`,
+			id, parent, fmt.Sprintf("issue %d", id), status, now, now,
+		); err != nil {
+			b.Fatalf("inserting issue %d: %v", id, err)
+		}
+		if id <= 50 {
+			parents = append(parents, id)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		b.Fatalf("Commit: %v", err)
+	}
+	return d, parents
+}
+
+// BenchmarkGetBatchSubIssueProgress measures walking the issue tree for the
+// progress of a page of parents.
+func BenchmarkGetBatchSubIssueProgress(b *testing.B) {
+	d, parents := seedSubIssueBenchmark(b, 1000, 4)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := GetBatchSubIssueProgress(d, parents); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetCachedSubIssueProgress measures reading the same progress from
+// the sub_issue_stats cache.
+func BenchmarkGetCachedSubIssueProgress(b *testing.B) {
+	d, parents := seedSubIssueBenchmark(b, 1000, 4)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := GetCachedSubIssueProgress(d, parents); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
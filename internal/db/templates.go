@@ -0,0 +1,113 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// SaveTemplate creates the named issue template, or replaces its fields if
+// one with that name already exists. The original created_at is preserved.
+func SaveTemplate(db *sql.DB, t *model.IssueTemplate) error {
+	return WithRetry(func() error { return saveTemplate(db, t) })
+}
+
+func saveTemplate(db *sql.DB, t *model.IssueTemplate) error {
+	labels := t.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	labelsJSON, err := json.Marshal(labels)
+	if err != nil {
+		return fmt.Errorf("marshaling labels: %w", err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	_, err = db.Exec(
+		`INSERT INTO issue_templates (name, title_pattern, description, kind, priority, labels_json, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(name) DO UPDATE SET
+			title_pattern = excluded.title_pattern,
+			description   = excluded.description,
+			kind          = excluded.kind,
+			priority      = excluded.priority,
+			labels_json   = excluded.labels_json,
+			updated_at    = excluded.updated_at`,
+		t.Name, t.TitlePattern, t.Description, string(t.Kind), string(t.Priority), string(labelsJSON), now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("saving template %q: %w", t.Name, err)
+	}
+	return nil
+}
+
+// GetTemplate retrieves an issue template by name, returning ErrNotFound if
+// none exists.
+func GetTemplate(db *sql.DB, name string) (*model.IssueTemplate, error) {
+	row := db.QueryRow(
+		`SELECT name, title_pattern, description, kind, priority, labels_json, created_at, updated_at
+		 FROM issue_templates WHERE name = ?`, name,
+	)
+	t, err := scanTemplateFrom(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("scanning template: %w", err)
+	}
+	return t, nil
+}
+
+// ListTemplates returns every issue template ordered by name.
+func ListTemplates(db *sql.DB) ([]*model.IssueTemplate, error) {
+	rows, err := db.Query(
+		`SELECT name, title_pattern, description, kind, priority, labels_json, created_at, updated_at
+		 FROM issue_templates ORDER BY name`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := make([]*model.IssueTemplate, 0)
+	for rows.Next() {
+		t, err := scanTemplateFrom(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning template row: %w", err)
+		}
+		templates = append(templates, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating template rows: %w", err)
+	}
+	return templates, nil
+}
+
+// scanTemplateFrom scans a single issue template from any scanner.
+func scanTemplateFrom(s scanner) (*model.IssueTemplate, error) {
+	var t model.IssueTemplate
+	var kind, priority, labelsJSON, createdAt, updatedAt string
+
+	if err := s.Scan(&t.Name, &t.TitlePattern, &t.Description, &kind, &priority, &labelsJSON, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	t.Kind = model.IssueKind(kind)
+	t.Priority = model.Priority(priority)
+
+	if err := json.Unmarshal([]byte(labelsJSON), &t.Labels); err != nil {
+		return nil, fmt.Errorf("parsing labels_json: %w", err)
+	}
+
+	var err error
+	if t.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+		return nil, fmt.Errorf("parsing created_at: %w", err)
+	}
+	if t.UpdatedAt, err = time.Parse(time.RFC3339, updatedAt); err != nil {
+		return nil, fmt.Errorf("parsing updated_at: %w", err)
+	}
+	return &t, nil
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestSaveTemplateUpsertsAndLists(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	bug := &model.IssueTemplate{
+		Name:         "bug",
+		TitlePattern: "Bug: {title}",
+		Kind:         model.IssueKindBug,
+		Priority:     model.PriorityHigh,
+		Labels:       []string{"triage"},
+	}
+	if err := SaveTemplate(db, bug); err != nil {
+		t.Fatalf("SaveTemplate: %v", err)
+	}
+	if err := SaveTemplate(db, &model.IssueTemplate{Name: "rfc", Kind: model.IssueKindFeature, Priority: model.PriorityNone}); err != nil {
+		t.Fatalf("SaveTemplate(rfc): %v", err)
+	}
+
+	// Saving again under the same name replaces the fields.
+	bug.Priority = model.PriorityCritical
+	bug.Labels = []string{"triage", "regression"}
+	if err := SaveTemplate(db, bug); err != nil {
+		t.Fatalf("SaveTemplate(update): %v", err)
+	}
+
+	got, err := GetTemplate(db, "bug")
+	if err != nil {
+		t.Fatalf("GetTemplate: %v", err)
+	}
+	if got.Priority != model.PriorityCritical || got.Kind != model.IssueKindBug || len(got.Labels) != 2 || got.TitlePattern != "Bug: {title}" {
+		t.Errorf("GetTemplate = %+v", got)
+	}
+
+	all, err := ListTemplates(db)
+	if err != nil {
+		t.Fatalf("ListTemplates: %v", err)
+	}
+	if len(all) != 2 || all[0].Name != "bug" || all[1].Name != "rfc" {
+		t.Errorf("ListTemplates = %+v, want bug then rfc", all)
+	}
+	if all[1].Labels == nil {
+		t.Error("template without labels should decode to an empty slice")
+	}
+
+	if _, err := GetTemplate(db, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetTemplate(missing) = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,328 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// liveIssueIDs selects the IDs of issues that are not in the trash. Queries
+// over tables keyed by issue ID filter on it so that a trashed issue's
+// comments, labels, relations and links stay in place, but hidden, until the
+// trash is emptied.
+const liveIssueIDs = `(SELECT id FROM issues WHERE deleted_at IS NULL)`
+
+// liveRelationEnds restricts issue_relations rows to those whose source and
+// target are both outside the trash.
+const liveRelationEnds = `source_issue_id IN ` + liveIssueIDs + ` AND target_issue_id IN ` + liveIssueIDs
+
+// RestoreResult describes the outcome of RestoreIssue.
+type RestoreResult struct {
+	// Restored lists the restored issue followed by any descendants that
+	// were trashed along with it.
+	Restored []int
+	// Reparented is true when the issue's parent was still in the trash, so
+	// the issue was restored as a root issue.
+	Reparented bool
+}
+
+// TrashIssue moves an issue and all of its descendants outside the trash into
+// the trash by setting deleted_at. Nothing is removed, so foreign-key
+// cascades do not fire and the issues' comments, relations and other rows
+// survive until the trash is emptied. It returns the IDs trashed, root first,
+// or ErrNotFound if the issue does not exist or is already trashed.
+func TrashIssue(db *sql.DB, id int, author string) ([]int, error) {
+	return withRetryValue(func() ([]int, error) { return trashIssue(db, id, author) })
+}
+
+func trashIssue(db *sql.DB, id int, author string) ([]int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ids, err := trashIssueTx(tx, id, author)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return ids, nil
+}
+
+// trashIssueTx is TrashIssue within an existing transaction.
+func trashIssueTx(tx *sql.Tx, id int, author string) ([]int, error) {
+	ids, err := queryIDsTx(tx,
+		`WITH RECURSIVE tree(id, depth) AS (
+			SELECT id, 0 FROM issues WHERE id = ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT i.id, t.depth + 1 FROM issues i JOIN tree t ON i.parent_id = t.id WHERE i.deleted_at IS NULL
+		)
+		SELECT id FROM tree ORDER BY depth, id`, id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("collecting issues to trash: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, ErrNotFound
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, issueID := range ids {
+		if _, err := tx.Exec(`UPDATE issues SET deleted_at = ? WHERE id = ?`, now, issueID); err != nil {
+			return nil, fmt.Errorf("trashing issue %d: %w", issueID, err)
+		}
+		if err := RecordActivity(tx, issueID, "deleted_at", "", now, author); err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// DeleteIssues moves each issue in ids to the trash in a single transaction.
+// With cascade, each issue's live descendants are trashed with it; otherwise
+// its direct children first become root issues, as with OrphanSubIssues.
+// IDs that do not name a live issue are returned in missing rather than
+// failing the batch. deleted counts every issue trashed, descendants
+// included.
+func DeleteIssues(db *sql.DB, ids []int, cascade bool, author string) (deleted int, missing []int, err error) {
+	err = WithRetry(func() error {
+		deleted, missing, err = deleteIssues(db, ids, cascade, author)
+		return err
+	})
+	return deleted, missing, err
+}
+
+func deleteIssues(db *sql.DB, ids []int, cascade bool, author string) (int, []int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Sort out missing IDs before trashing anything, so an issue trashed
+	// as a descendant of an earlier one is not reported as missing.
+	var live []int
+	var missing []int
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		var exists bool
+		if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM issues WHERE id = ? AND deleted_at IS NULL)`, id).Scan(&exists); err != nil {
+			return 0, nil, fmt.Errorf("checking issue existence: %w", err)
+		}
+		if exists {
+			live = append(live, id)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	deleted := 0
+	for _, id := range live {
+		if !cascade {
+			if err := orphanSubIssuesTx(tx, id, author); err != nil {
+				return 0, nil, err
+			}
+		}
+		trashed, err := trashIssueTx(tx, id, author)
+		if errors.Is(err, ErrNotFound) {
+			// Already trashed along with an ancestor earlier in ids.
+			continue
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		deleted += len(trashed)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return deleted, missing, nil
+}
+
+// ListTrashedIssues returns every issue in the trash, most recently deleted
+// first.
+func ListTrashedIssues(db *sql.DB) ([]model.TrashedIssue, error) {
+	rows, err := db.Query(
+		`SELECT id, parent_id, title, description, status, priority, kind, assignee, milestone_id, created_at, updated_at, started_at, completed_at, snoozed_until, sort_order, pinned, deleted_at
+		 FROM issues WHERE deleted_at IS NOT NULL
+		 ORDER BY deleted_at DESC, id ASC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying trashed issues: %w", err)
+	}
+	defer rows.Close()
+
+	var trashed []model.TrashedIssue
+	for rows.Next() {
+		var deletedAt string
+		issue, err := scanIssueFrom(rows, &deletedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scanning trashed issue: %w", err)
+		}
+		t, err := time.Parse(time.RFC3339, deletedAt)
+		if err != nil {
+			return nil, fmt.Errorf("parsing deleted_at: %w", err)
+		}
+		trashed = append(trashed, model.TrashedIssue{Issue: issue, DeletedAt: t})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating trashed issues: %w", err)
+	}
+	return trashed, nil
+}
+
+// CountTrashedIssues returns the number of issues in the trash.
+func CountTrashedIssues(db *sql.DB) (int, error) {
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM issues WHERE deleted_at IS NOT NULL`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting trashed issues: %w", err)
+	}
+	return count, nil
+}
+
+// RestoreIssue takes an issue out of the trash together with the descendants
+// that were trashed along with it. If the issue's parent is still in the
+// trash the issue is restored as a root issue. It returns ErrNotFound if the
+// issue is not in the trash.
+func RestoreIssue(db *sql.DB, id int, author string) (RestoreResult, error) {
+	return withRetryValue(func() (RestoreResult, error) { return restoreIssue(db, id, author) })
+}
+
+func restoreIssue(db *sql.DB, id int, author string) (RestoreResult, error) {
+	var result RestoreResult
+
+	tx, err := db.Begin()
+	if err != nil {
+		return result, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var deletedAt string
+	var parentID sql.NullInt64
+	err = tx.QueryRow(
+		`SELECT deleted_at, parent_id FROM issues WHERE id = ? AND deleted_at IS NOT NULL`, id,
+	).Scan(&deletedAt, &parentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return result, ErrNotFound
+		}
+		return result, fmt.Errorf("looking up trashed issue: %w", err)
+	}
+
+	// Descendants trashed in the same operation share the root's deleted_at.
+	result.Restored, err = queryIDsTx(tx,
+		`WITH RECURSIVE tree(id, depth) AS (
+			SELECT ?, 0
+			UNION ALL
+			SELECT i.id, t.depth + 1 FROM issues i JOIN tree t ON i.parent_id = t.id WHERE i.deleted_at = ?
+		)
+		SELECT id FROM tree ORDER BY depth, id`, id, deletedAt,
+	)
+	if err != nil {
+		return result, fmt.Errorf("collecting issues to restore: %w", err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	if parentID.Valid {
+		var parentTrashed bool
+		if err := tx.QueryRow(
+			`SELECT EXISTS(SELECT 1 FROM issues WHERE id = ? AND deleted_at IS NOT NULL)`, parentID.Int64,
+		).Scan(&parentTrashed); err != nil {
+			return result, fmt.Errorf("checking parent issue: %w", err)
+		}
+		if parentTrashed {
+			if _, err := tx.Exec(`UPDATE issues SET parent_id = NULL WHERE id = ?`, id); err != nil {
+				return result, fmt.Errorf("detaching from trashed parent: %w", err)
+			}
+			if err := RecordActivity(tx, id, "parent_id", fmt.Sprintf("%d", parentID.Int64), "", author); err != nil {
+				return result, err
+			}
+			result.Reparented = true
+		}
+	}
+
+	for _, issueID := range result.Restored {
+		if _, err := tx.Exec(`UPDATE issues SET deleted_at = NULL, updated_at = ? WHERE id = ?`, now, issueID); err != nil {
+			return result, fmt.Errorf("restoring issue %d: %w", issueID, err)
+		}
+		if err := RecordActivity(tx, issueID, "deleted_at", deletedAt, "", author); err != nil {
+			return result, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return result, fmt.Errorf("committing transaction: %w", err)
+	}
+	return result, nil
+}
+
+// EmptyTrash permanently deletes trashed issues with CascadeDeleteIssue,
+// which also removes their comments, relations and other dependent rows. When
+// cutoff is non-zero only issues deleted at or before cutoff are removed. It
+// returns the IDs of the issues deleted.
+func EmptyTrash(db *sql.DB, cutoff time.Time) ([]int, error) {
+	query := `SELECT id FROM issues WHERE deleted_at IS NOT NULL`
+	var args []any
+	if !cutoff.IsZero() {
+		query += ` AND deleted_at <= ?`
+		args = append(args, cutoff.UTC().Format(time.RFC3339))
+	}
+	query += ` ORDER BY id`
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying trashed issues: %w", err)
+	}
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// A sub-issue is never trashed later than its parent, so deleting a
+	// parent's subtree only removes issues that also passed the cutoff.
+	for _, id := range ids {
+		if err := CascadeDeleteIssue(db, id); err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("deleting issue %d: %w", id, err)
+		}
+	}
+	return ids, nil
+}
+
+// queryIDsTx runs a query returning a single integer column within tx.
+func queryIDsTx(tx *sql.Tx, query string, args ...any) ([]int, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanIDs(rows)
+}
+
+// scanIDs reads a single integer column from rows and closes them.
+func scanIDs(rows *sql.Rows) ([]int, error) {
+	defer rows.Close()
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating ids: %w", err)
+	}
+	return ids, nil
+}
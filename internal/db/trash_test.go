@@ -0,0 +1,248 @@
+package db
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestTrashIssueHidesSubtreeAndKeepsRows(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	parent := mustCreateIssue(t, d, "parent")
+	child := createTestIssueWithParent(t, d, "child", model.StatusTodo, model.PriorityLow, parent)
+	other := mustCreateIssue(t, d, "other")
+
+	if _, err := CreateComment(d, &model.Comment{IssueID: child, Body: "note", Author: "tester"}); err != nil {
+		t.Fatalf("CreateComment: %v", err)
+	}
+	if _, err := CreateRelation(d, &model.Relation{SourceIssueID: other, TargetIssueID: child, RelationType: model.RelationBlocks}); err != nil {
+		t.Fatalf("CreateRelation: %v", err)
+	}
+
+	trashed, err := TrashIssue(d, parent, "tester")
+	if err != nil {
+		t.Fatalf("TrashIssue: %v", err)
+	}
+	if !slices.Equal(trashed, []int{parent, child}) {
+		t.Errorf("trashed = %v, want [%d %d]", trashed, parent, child)
+	}
+
+	if _, err := GetIssue(d, child); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetIssue on trashed issue: expected ErrNotFound, got %v", err)
+	}
+	issues, total, err := ListIssues(d, ListOptions{})
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	if total != 1 || len(issues) != 1 || issues[0].ID != other {
+		t.Errorf("ListIssues returned %d issue(s) (total %d), want only %d", len(issues), total, other)
+	}
+	rels, err := GetIssueRelations(d, other)
+	if err != nil {
+		t.Fatalf("GetIssueRelations: %v", err)
+	}
+	if len(rels) != 0 {
+		t.Errorf("relations to trashed issues should be hidden, got %d", len(rels))
+	}
+	comments, err := ListAllComments(d)
+	if err != nil {
+		t.Fatalf("ListAllComments: %v", err)
+	}
+	if len(comments) != 0 {
+		t.Errorf("comments on trashed issues should be hidden from export, got %d", len(comments))
+	}
+
+	if _, err := TrashIssue(d, parent, "tester"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("trashing a trashed issue: expected ErrNotFound, got %v", err)
+	}
+
+	list, err := ListTrashedIssues(d)
+	if err != nil {
+		t.Fatalf("ListTrashedIssues: %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("ListTrashedIssues returned %d issues, want 2", len(list))
+	}
+	if list[0].DeletedAt.IsZero() {
+		t.Error("DeletedAt should be set on trashed issues")
+	}
+
+	// Restoring brings the comment and relation back with the issue.
+	res, err := RestoreIssue(d, parent, "tester")
+	if err != nil {
+		t.Fatalf("RestoreIssue: %v", err)
+	}
+	if !slices.Equal(res.Restored, []int{parent, child}) || res.Reparented {
+		t.Errorf("RestoreIssue = %+v, want both issues restored without reparenting", res)
+	}
+	comments, err = ListIssueComments(d, child)
+	if err != nil {
+		t.Fatalf("ListComments: %v", err)
+	}
+	if len(comments) != 1 {
+		t.Errorf("got %d comments after restore, want 1", len(comments))
+	}
+	rels, err = GetIssueRelations(d, other)
+	if err != nil {
+		t.Fatalf("GetIssueRelations: %v", err)
+	}
+	if len(rels) != 1 {
+		t.Errorf("got %d relations after restore, want 1", len(rels))
+	}
+}
+
+func TestRestoreIssueWithTrashedParentBecomesRoot(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	parent := mustCreateIssue(t, d, "parent")
+	child := createTestIssueWithParent(t, d, "child", model.StatusTodo, model.PriorityLow, parent)
+	if _, err := TrashIssue(d, parent, "tester"); err != nil {
+		t.Fatalf("TrashIssue: %v", err)
+	}
+
+	res, err := RestoreIssue(d, child, "tester")
+	if err != nil {
+		t.Fatalf("RestoreIssue: %v", err)
+	}
+	if !res.Reparented {
+		t.Error("expected Reparented when the parent is still trashed")
+	}
+	issue, err := GetIssue(d, child)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if issue.ParentID != nil {
+		t.Errorf("ParentID = %d, want nil", *issue.ParentID)
+	}
+
+	if _, err := RestoreIssue(d, child, "tester"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("restoring a live issue: expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestEmptyTrashRespectsCutoff(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	old := mustCreateIssue(t, d, "old")
+	recent := mustCreateIssue(t, d, "recent")
+	for _, id := range []int{old, recent} {
+		if _, err := TrashIssue(d, id, "tester"); err != nil {
+			t.Fatalf("TrashIssue: %v", err)
+		}
+	}
+	past := time.Now().Add(-60 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	if _, err := d.Exec(`UPDATE issues SET deleted_at = ? WHERE id = ?`, past, old); err != nil {
+		t.Fatalf("backdating deleted_at: %v", err)
+	}
+
+	deleted, err := EmptyTrash(d, time.Now().Add(-30*24*time.Hour))
+	if err != nil {
+		t.Fatalf("EmptyTrash: %v", err)
+	}
+	if !slices.Equal(deleted, []int{old}) {
+		t.Errorf("EmptyTrash with cutoff deleted %v, want [%d]", deleted, old)
+	}
+	if n, err := CountTrashedIssues(d); err != nil || n != 1 {
+		t.Errorf("CountTrashedIssues = %d, %v; want 1", n, err)
+	}
+
+	deleted, err = EmptyTrash(d, time.Time{})
+	if err != nil {
+		t.Fatalf("EmptyTrash: %v", err)
+	}
+	if !slices.Equal(deleted, []int{recent}) {
+		t.Errorf("EmptyTrash deleted %v, want [%d]", deleted, recent)
+	}
+	var remaining int
+	if err := d.QueryRow(`SELECT COUNT(*) FROM issues`).Scan(&remaining); err != nil {
+		t.Fatalf("counting issues: %v", err)
+	}
+	if remaining != 0 {
+		t.Errorf("%d issue rows remain after emptying the trash, want 0", remaining)
+	}
+}
+
+func TestDeleteIssuesCascadeVsOrphan(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		cascade     bool
+		wantDeleted int
+	}{
+		{"cascade", true, 3},
+		{"orphan", false, 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			d := mustOpen(t)
+			if err := Initialize(d); err != nil {
+				t.Fatalf("Initialize: %v", err)
+			}
+			parent := mustCreateIssue(t, d, "parent")
+			child := createTestIssueWithParent(t, d, "child", model.StatusTodo, model.PriorityLow, parent)
+			grandchild := createTestIssueWithParent(t, d, "grandchild", model.StatusTodo, model.PriorityLow, child)
+
+			deleted, missing, err := DeleteIssues(d, []int{parent}, tt.cascade, "tester")
+			if err != nil {
+				t.Fatalf("DeleteIssues: %v", err)
+			}
+			if deleted != tt.wantDeleted || len(missing) != 0 {
+				t.Errorf("deleted = %d, missing = %v; want %d, none", deleted, missing, tt.wantDeleted)
+			}
+
+			c, err := GetIssue(d, child)
+			if tt.cascade {
+				if !errors.Is(err, ErrNotFound) {
+					t.Errorf("GetIssue(child) error = %v, want ErrNotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetIssue(child): %v", err)
+			}
+			if c.ParentID != nil {
+				t.Errorf("child parent = %d, want root", *c.ParentID)
+			}
+			if g, err := GetIssue(d, grandchild); err != nil || g.ParentID == nil || *g.ParentID != child {
+				t.Errorf("grandchild = %+v, %v; want still under child", g, err)
+			}
+		})
+	}
+}
+
+func TestDeleteIssuesReportsMissing(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	parent := mustCreateIssue(t, d, "parent")
+	child := createTestIssueWithParent(t, d, "child", model.StatusTodo, model.PriorityLow, parent)
+	trashed := mustCreateIssue(t, d, "already trashed")
+	if _, err := TrashIssue(d, trashed, "tester"); err != nil {
+		t.Fatalf("TrashIssue: %v", err)
+	}
+
+	// The child is trashed with its parent, so it is neither missing nor
+	// counted twice.
+	deleted, missing, err := DeleteIssues(d, []int{parent, 99, child, trashed, parent}, true, "tester")
+	if err != nil {
+		t.Fatalf("DeleteIssues: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("deleted = %d, want 2", deleted)
+	}
+	if !slices.Equal(missing, []int{99, trashed}) {
+		t.Errorf("missing = %v, want [99 %d]", missing, trashed)
+	}
+}
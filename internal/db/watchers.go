@@ -0,0 +1,95 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// AddWatcher adds username to the people watching an issue and reports
+// whether they were added. Adding someone who already watches the issue is
+// a no-op that returns false. It returns ErrNotFound if the issue does not
+// exist. Activity is recorded for a new watcher.
+func AddWatcher(db *sql.DB, issueID int, username, changedBy string) (bool, error) {
+	return withRetryValue(func() (bool, error) { return addWatcher(db, issueID, username, changedBy) })
+}
+
+func addWatcher(db *sql.DB, issueID int, username, changedBy string) (bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := getIssueTx(tx, issueID); err != nil {
+		return false, err
+	}
+
+	res, err := tx.Exec(
+		`INSERT OR IGNORE INTO issue_watchers (issue_id, username, created_at) VALUES (?, ?, ?)`,
+		issueID, username, time.Now().UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return false, fmt.Errorf("adding watcher: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return false, nil
+	}
+	if err := RecordActivity(tx, issueID, "watchers", "", username, changedBy); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// RemoveWatcher removes username from the people watching an issue and
+// reports whether they were watching it. It returns ErrNotFound if the
+// issue does not exist. Activity is recorded for a removed watcher.
+func RemoveWatcher(db *sql.DB, issueID int, username, changedBy string) (bool, error) {
+	return withRetryValue(func() (bool, error) { return removeWatcher(db, issueID, username, changedBy) })
+}
+
+func removeWatcher(db *sql.DB, issueID int, username, changedBy string) (bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := getIssueTx(tx, issueID); err != nil {
+		return false, err
+	}
+
+	res, err := tx.Exec(`DELETE FROM issue_watchers WHERE issue_id = ? AND username = ?`, issueID, username)
+	if err != nil {
+		return false, fmt.Errorf("removing watcher: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return false, nil
+	}
+	if err := RecordActivity(tx, issueID, "watchers", username, "", changedBy); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// GetWatchers returns the people watching an issue, sorted by name.
+func GetWatchers(db *sql.DB, issueID int) ([]string, error) {
+	rows, err := db.Query(`SELECT username FROM issue_watchers WHERE issue_id = ? ORDER BY username`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("querying watchers: %w", err)
+	}
+	defer rows.Close()
+
+	var watchers []string
+	for rows.Next() {
+		var username string
+		if err := rows.Scan(&username); err != nil {
+			return nil, fmt.Errorf("scanning watcher: %w", err)
+		}
+		watchers = append(watchers, username)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating watchers: %w", err)
+	}
+	return watchers, nil
+}
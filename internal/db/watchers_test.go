@@ -0,0 +1,67 @@
+package db
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestWatchers(t *testing.T) {
+	db := mustOpen(t)
+	if err := Initialize(db); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	id := mustCreateIssue(t, db, "watched")
+
+	for _, name := range []string{"jane", "bob"} {
+		added, err := AddWatcher(db, id, name, "alice")
+		if err != nil || !added {
+			t.Fatalf("AddWatcher(%q) = %v, %v; want true", name, added, err)
+		}
+	}
+	added, err := AddWatcher(db, id, "jane", "alice")
+	if err != nil || added {
+		t.Errorf("AddWatcher(jane) again = %v, %v; want an idempotent false", added, err)
+	}
+
+	watchers, err := GetWatchers(db, id)
+	if err != nil {
+		t.Fatalf("GetWatchers: %v", err)
+	}
+	if !slices.Equal(watchers, []string{"bob", "jane"}) {
+		t.Errorf("GetWatchers = %v, want [bob jane]", watchers)
+	}
+
+	removed, err := RemoveWatcher(db, id, "bob", "alice")
+	if err != nil || !removed {
+		t.Fatalf("RemoveWatcher(bob) = %v, %v; want true", removed, err)
+	}
+	removed, err = RemoveWatcher(db, id, "bob", "alice")
+	if err != nil || removed {
+		t.Errorf("RemoveWatcher(bob) again = %v, %v; want false", removed, err)
+	}
+	if watchers, _ := GetWatchers(db, id); !slices.Equal(watchers, []string{"jane"}) {
+		t.Errorf("GetWatchers after remove = %v, want [jane]", watchers)
+	}
+
+	activity, err := GetActivity(db, id, 0)
+	if err != nil {
+		t.Fatalf("GetActivity: %v", err)
+	}
+	var changes int
+	for _, a := range activity {
+		if a.FieldChanged == "watchers" {
+			changes++
+		}
+	}
+	if changes != 3 {
+		t.Errorf("watchers activity entries = %d, want 3 (two adds, one removal)", changes)
+	}
+
+	if _, err := AddWatcher(db, 999, "jane", "alice"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("AddWatcher(missing issue) error = %v, want ErrNotFound", err)
+	}
+}
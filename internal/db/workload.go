@@ -0,0 +1,60 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// UnassignedBucket is the WorkloadByAssignee key for issues with no assignee.
+const UnassignedBucket = "(unassigned)"
+
+// WorkloadStats counts one assignee's issues by progress. Open covers backlog
+// and todo, InProgress covers in-progress and review.
+type WorkloadStats struct {
+	Open       int `json:"open"`
+	InProgress int `json:"in_progress"`
+	Done       int `json:"done"`
+	Total      int `json:"total"`
+}
+
+// WorkloadByAssignee returns per-assignee issue counts for issues outside the
+// trash. Issues without an assignee are counted under UnassignedBucket.
+func WorkloadByAssignee(db *sql.DB) (map[string]WorkloadStats, error) {
+	rows, err := db.Query(
+		`SELECT COALESCE(NULLIF(TRIM(assignee), ''), ?), status, COUNT(*)
+		 FROM issues
+		 WHERE deleted_at IS NULL
+		 GROUP BY 1, 2`,
+		UnassignedBucket,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("counting workload: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]WorkloadStats)
+	for rows.Next() {
+		var assignee, status string
+		var count int
+		if err := rows.Scan(&assignee, &status, &count); err != nil {
+			return nil, fmt.Errorf("scanning workload row: %w", err)
+		}
+		stats := result[assignee]
+		switch model.Status(status) {
+		case model.StatusDone:
+			stats.Done += count
+		case model.StatusInProgress, model.StatusReview:
+			stats.InProgress += count
+		default:
+			stats.Open += count
+		}
+		stats.Total += count
+		result[assignee] = stats
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating workload rows: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestWorkloadByAssignee(t *testing.T) {
+	d := mustOpen(t)
+	if err := Initialize(d); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	for _, tc := range []struct {
+		assignee string
+		status   model.Status
+	}{
+		{"alice", model.StatusBacklog},
+		{"alice", model.StatusTodo},
+		{"alice", model.StatusInProgress},
+		{"alice", model.StatusDone},
+		{"bob", model.StatusReview},
+		{"bob", model.StatusDone},
+		{"bob", model.StatusDone},
+		{"", model.StatusTodo},
+		{"  ", model.StatusInProgress},
+	} {
+		if _, err := CreateIssue(d, &model.Issue{
+			Title:    "work",
+			Status:   tc.status,
+			Priority: model.PriorityNone,
+			Kind:     model.IssueKindTask,
+			Assignee: tc.assignee,
+		}, nil, nil); err != nil {
+			t.Fatalf("CreateIssue: %v", err)
+		}
+	}
+
+	trashed, err := CreateIssue(d, &model.Issue{
+		Title: "gone", Status: model.StatusTodo, Priority: model.PriorityNone, Kind: model.IssueKindTask, Assignee: "alice",
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if _, err := TrashIssue(d, trashed, "tester"); err != nil {
+		t.Fatalf("TrashIssue: %v", err)
+	}
+
+	got, err := WorkloadByAssignee(d)
+	if err != nil {
+		t.Fatalf("WorkloadByAssignee: %v", err)
+	}
+
+	want := map[string]WorkloadStats{
+		"alice":          {Open: 2, InProgress: 1, Done: 1, Total: 4},
+		"bob":            {Open: 0, InProgress: 1, Done: 2, Total: 3},
+		UnassignedBucket: {Open: 1, InProgress: 1, Done: 0, Total: 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d buckets (%v), want %d", len(got), got, len(want))
+	}
+	for assignee, w := range want {
+		if got[assignee] != w {
+			t.Errorf("%s = %+v, want %+v", assignee, got[assignee], w)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package digest
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/planner"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+)
+
+// DefaultStaleLimit is the number of stale issues a digest lists unless
+// Input.StaleLimit says otherwise.
+const DefaultStaleLimit = 5
+
+// Input is the data a digest is built from.
+type Input struct {
+	Since, Until time.Time
+	// Created and Completed are the issues created and completed in the
+	// period, as returned by db.IssuesCreatedBetween and
+	// db.IssuesCompletedBetween.
+	Created   []*model.Issue
+	Completed []*model.Issue
+	// Activity is the activity recorded since Since; entries at or after
+	// Until are ignored.
+	Activity    []model.IssueActivity
+	NewComments int
+	// Issues and Relations describe the whole database, for the blocked and
+	// stale sections.
+	Issues    []*model.Issue
+	Relations []model.Relation
+	// StaleLimit caps the stale section; zero means DefaultStaleLimit.
+	StaleLimit int
+}
+
+// Completed is an issue completed in the period with its cycle time: from
+// when work started, or from creation for issues never moved to in-progress.
+type Completed struct {
+	Issue     *model.Issue
+	CycleTime time.Duration
+}
+
+// StatusChange is the net status movement of one issue over the period:
+// the status before its first change and after its last.
+type StatusChange struct {
+	IssueID    int
+	IssueTitle string
+	From, To   model.Status
+	Count      int
+}
+
+// Blocked is an open issue with the open issues blocking it.
+type Blocked struct {
+	Issue     *model.Issue
+	BlockedBy []*model.Issue
+}
+
+// Stale is an open issue not updated during the period, with how long it
+// has been idle at the end of the period.
+type Stale struct {
+	Issue *model.Issue
+	Idle  time.Duration
+}
+
+// Digest summarizes the changes to an issue database over a period.
+type Digest struct {
+	Since, Until  time.Time
+	Created       []*model.Issue
+	Completed     []Completed
+	StatusChanges []StatusChange
+	NewComments   int
+	Blocked       []Blocked
+	Stale         []Stale
+}
+
+// Build assembles a digest from in. Status changes are listed in order of
+// each issue's first change, blocked issues by ID, and stale issues from the
+// longest idle.
+func Build(in Input) *Digest {
+	d := &Digest{
+		Since:         in.Since,
+		Until:         in.Until,
+		Created:       in.Created,
+		Completed:     make([]Completed, 0, len(in.Completed)),
+		StatusChanges: statusChanges(in.Activity, in.Until),
+		NewComments:   in.NewComments,
+		Blocked:       blocked(in.Issues, in.Relations),
+		Stale:         stale(in.Issues, in.Since, in.Until, cmp.Or(in.StaleLimit, DefaultStaleLimit)),
+	}
+	if d.Created == nil {
+		d.Created = []*model.Issue{}
+	}
+	for _, issue := range in.Completed {
+		start := issue.StartedAt
+		if start.IsZero() {
+			start = issue.CreatedAt
+		}
+		d.Completed = append(d.Completed, Completed{Issue: issue, CycleTime: issue.CompletedAt.Sub(start)})
+	}
+	return d
+}
+
+// statusChanges collapses the status activity before until into one change
+// per issue.
+func statusChanges(activity []model.IssueActivity, until time.Time) []StatusChange {
+	changes := make([]StatusChange, 0)
+	index := make(map[int]int)
+	for _, a := range activity {
+		if a.FieldChanged != "status" || !a.CreatedAt.Before(until) {
+			continue
+		}
+		if i, ok := index[a.IssueID]; ok {
+			changes[i].To = model.Status(a.NewValue)
+			changes[i].Count++
+			continue
+		}
+		index[a.IssueID] = len(changes)
+		changes = append(changes, StatusChange{
+			IssueID:    a.IssueID,
+			IssueTitle: a.IssueTitle,
+			From:       model.Status(a.OldValue),
+			To:         model.Status(a.NewValue),
+			Count:      1,
+		})
+	}
+	return changes
+}
+
+// blocked returns the open issues that have open blockers.
+func blocked(issues []*model.Issue, relations []model.Relation) []Blocked {
+	dag := planner.BuildDAG(issues, relations)
+	result := make([]Blocked, 0)
+	for _, issue := range issues {
+		if issue.Status == model.StatusDone {
+			continue
+		}
+		if blockers := planner.OpenBlockers(dag, issue.ID); len(blockers) > 0 {
+			result = append(result, Blocked{Issue: issue, BlockedBy: blockers})
+		}
+	}
+	slices.SortFunc(result, func(a, b Blocked) int { return cmp.Compare(a.Issue.ID, b.Issue.ID) })
+	return result
+}
+
+// stale returns up to limit open issues last updated before since, longest
+// idle first.
+func stale(issues []*model.Issue, since, until time.Time, limit int) []Stale {
+	result := make([]Stale, 0)
+	for _, issue := range issues {
+		if issue.Status == model.StatusDone || !issue.UpdatedAt.Before(since) {
+			continue
+		}
+		result = append(result, Stale{Issue: issue, Idle: until.Sub(issue.UpdatedAt)})
+	}
+	slices.SortFunc(result, func(a, b Stale) int {
+		return cmp.Or(cmp.Compare(b.Idle, a.Idle), cmp.Compare(a.Issue.ID, b.Issue.ID))
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// FormatDuration formats d in days and hours, e.g. "3d 4h", "5h" or "<1h".
+func FormatDuration(d time.Duration) string {
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	switch {
+	case days > 0 && hours > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return "<1h"
+	}
+}
+
+// Markdown renders the digest as a Markdown document with one section per
+// part of the digest. Issue IDs are followed by their titles, and are
+// Markdown links when a link template is set.
+func Markdown(d *Digest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Docket digest: %s to %s\n", d.Since.UTC().Format(time.DateOnly), d.Until.UTC().Format(time.DateOnly))
+
+	section(&b, "Created", len(d.Created))
+	for _, issue := range d.Created {
+		fmt.Fprintf(&b, "- %s (%s, %s)\n", issueItem(issue.ID, issue.Title), issue.Kind, issue.Priority)
+	}
+
+	section(&b, "Completed", len(d.Completed))
+	for _, c := range d.Completed {
+		fmt.Fprintf(&b, "- %s (cycle time %s)\n", issueItem(c.Issue.ID, c.Issue.Title), FormatDuration(c.CycleTime))
+	}
+
+	section(&b, "Status changes", len(d.StatusChanges))
+	for _, c := range d.StatusChanges {
+		line := fmt.Sprintf("- %s: %s → %s", issueItem(c.IssueID, c.IssueTitle), c.From, c.To)
+		if c.Count > 1 {
+			line += fmt.Sprintf(" (%d changes)", c.Count)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n## Comments\n\n")
+	switch d.NewComments {
+	case 0:
+		b.WriteString("No new comments.\n")
+	case 1:
+		b.WriteString("1 new comment.\n")
+	default:
+		fmt.Fprintf(&b, "%d new comments.\n", d.NewComments)
+	}
+
+	section(&b, "Blocked", len(d.Blocked))
+	for _, bl := range d.Blocked {
+		ids := make([]string, len(bl.BlockedBy))
+		for i, blocker := range bl.BlockedBy {
+			ids[i] = markdownID(blocker.ID)
+		}
+		fmt.Fprintf(&b, "- %s, blocked by %s\n", issueItem(bl.Issue.ID, bl.Issue.Title), strings.Join(ids, ", "))
+	}
+
+	section(&b, "Stale", len(d.Stale))
+	for _, s := range d.Stale {
+		fmt.Fprintf(&b, "- %s (%s, no updates for %s)\n", issueItem(s.Issue.ID, s.Issue.Title), s.Issue.Status, FormatDuration(s.Idle))
+	}
+
+	return b.String()
+}
+
+// section writes a section heading with its item count, and a placeholder
+// line when the section is empty.
+func section(b *strings.Builder, title string, n int) {
+	fmt.Fprintf(b, "\n## %s (%d)\n\n", title, n)
+	if n == 0 {
+		b.WriteString("None.\n")
+	}
+}
+
+// issueItem formats an issue ID and its escaped title.
+func issueItem(id int, title string) string {
+	return markdownID(id) + " " + render.EscapeMarkdown(title)
+}
+
+// markdownID returns the display ID of issue id, as a Markdown link when a
+// link template is set.
+func markdownID(id int) string {
+	if url := render.LinkURL(id); url != "" {
+		return fmt.Sprintf("[%s](%s)", model.FormatID(id), url)
+	}
+	return model.FormatID(id)
+}
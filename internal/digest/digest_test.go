@@ -0,0 +1,45 @@
+package digest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestFormatDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		30 * time.Minute:           "<1h",
+		5 * time.Hour:              "5h",
+		48 * time.Hour:             "2d",
+		76*time.Hour + time.Minute: "3d 4h",
+	} {
+		if got := FormatDuration(d); got != want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestBuildIgnoresStatusChangesAfterUntil(t *testing.T) {
+	until := time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC)
+	change := func(at time.Time, from, to string) model.IssueActivity {
+		return model.IssueActivity{Activity: model.Activity{
+			IssueID: 1, FieldChanged: "status", OldValue: from, NewValue: to, CreatedAt: at,
+		}}
+	}
+
+	d := Build(Input{
+		Since: until.AddDate(0, 0, -7),
+		Until: until,
+		Activity: []model.IssueActivity{
+			change(until.Add(-time.Hour), "todo", "in-progress"),
+			change(until, "in-progress", "done"),
+		},
+	})
+	if len(d.StatusChanges) != 1 {
+		t.Fatalf("got %d status changes, want 1", len(d.StatusChanges))
+	}
+	if c := d.StatusChanges[0]; c.To != model.StatusInProgress || c.Count != 1 {
+		t.Errorf("change = %+v, want one move to in-progress", c)
+	}
+}
@@ -0,0 +1,111 @@
+package digest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/render"
+)
+
+// ReleaseNotes lists the issues completed over a period in sections.
+type ReleaseNotes struct {
+	Since, Until time.Time
+	Sections     []ReleaseSection
+}
+
+// ReleaseSection is one heading of release notes with its issues, in order
+// of completion.
+type ReleaseSection struct {
+	Title  string
+	Issues []*model.Issue
+}
+
+// kindSections are the release notes headings for each issue kind, in the
+// order they are listed.
+var kindSections = []struct {
+	kind  model.IssueKind
+	title string
+}{
+	{model.IssueKindFeature, "Features"},
+	{model.IssueKindBug, "Bugs"},
+	{model.IssueKindTask, "Tasks"},
+	{model.IssueKindChore, "Chores"},
+	{model.IssueKindEpic, "Epics"},
+}
+
+// unlabeledSection is the heading for issues without labels when release
+// notes are grouped by label.
+const unlabeledSection = "Unlabeled"
+
+// BuildReleaseNotes groups completed, the issues completed in [since,
+// until) as returned by db.IssuesDoneBetween, into sections. By default
+// there is a section per issue kind; with byLabel there is one per label,
+// in alphabetical order, and an issue with several labels is listed under
+// each. Empty sections are left out.
+func BuildReleaseNotes(completed []*model.Issue, since, until time.Time, byLabel bool) *ReleaseNotes {
+	n := &ReleaseNotes{Since: since, Until: until, Sections: []ReleaseSection{}}
+	if byLabel {
+		byName := make(map[string][]*model.Issue)
+		var unlabeled []*model.Issue
+		for _, issue := range completed {
+			if len(issue.Labels) == 0 {
+				unlabeled = append(unlabeled, issue)
+			}
+			for _, label := range issue.Labels {
+				byName[label] = append(byName[label], issue)
+			}
+		}
+		names := make([]string, 0, len(byName))
+		for name := range byName {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			n.Sections = append(n.Sections, ReleaseSection{Title: name, Issues: byName[name]})
+		}
+		if len(unlabeled) > 0 {
+			n.Sections = append(n.Sections, ReleaseSection{Title: unlabeledSection, Issues: unlabeled})
+		}
+		return n
+	}
+
+	for _, ks := range kindSections {
+		var issues []*model.Issue
+		for _, issue := range completed {
+			if issue.Kind == ks.kind {
+				issues = append(issues, issue)
+			}
+		}
+		if len(issues) > 0 {
+			n.Sections = append(n.Sections, ReleaseSection{Title: ks.title, Issues: issues})
+		}
+	}
+	return n
+}
+
+// ReleaseNotesMarkdown renders release notes as a Markdown document with a
+// section per heading and an entry such as "- DKT-42 Fix login timeout
+// (@alice)" per issue, naming the assignee when there is one.
+func ReleaseNotesMarkdown(n *ReleaseNotes) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Release notes: %s to %s\n", n.Since.UTC().Format(time.DateOnly), n.Until.UTC().Format(time.DateOnly))
+
+	if len(n.Sections) == 0 {
+		b.WriteString("\nNo issues were completed in this period.\n")
+		return b.String()
+	}
+	for _, s := range n.Sections {
+		fmt.Fprintf(&b, "\n## %s\n\n", render.EscapeMarkdown(s.Title))
+		for _, issue := range s.Issues {
+			line := "- " + issueItem(issue.ID, issue.Title)
+			if issue.Assignee != "" {
+				line += " (@" + issue.Assignee + ")"
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	return b.String()
+}
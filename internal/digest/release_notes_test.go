@@ -0,0 +1,69 @@
+package digest
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func releaseNotesFixture() []*model.Issue {
+	return []*model.Issue{
+		{ID: 41, Title: "Tidy *logs*", Kind: model.IssueKindChore},
+		{ID: 42, Title: "Fix login timeout", Kind: model.IssueKindBug, Assignee: "alice", Labels: []string{"auth"}},
+		{ID: 43, Title: "Add SSO", Kind: model.IssueKindFeature, Labels: []string{"ui", "auth"}},
+	}
+}
+
+func TestReleaseNotesMarkdownByKind(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	notes := BuildReleaseNotes(releaseNotesFixture(), since, since.AddDate(0, 1, 0), false)
+
+	want := `# Release notes: 2026-01-01 to 2026-02-01
+
+## Features
+
+- DKT-43 Add SSO
+
+## Bugs
+
+- DKT-42 Fix login timeout (@alice)
+
+## Chores
+
+- DKT-41 Tidy \*logs\*
+`
+	if got := ReleaseNotesMarkdown(notes); got != want {
+		t.Errorf("ReleaseNotesMarkdown =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBuildReleaseNotesByLabel(t *testing.T) {
+	notes := BuildReleaseNotes(releaseNotesFixture(), time.Time{}, time.Now(), true)
+
+	want := map[string][]int{"auth": {42, 43}, "ui": {43}, unlabeledSection: {41}}
+	var titles []string
+	for _, s := range notes.Sections {
+		titles = append(titles, s.Title)
+		var ids []int
+		for _, issue := range s.Issues {
+			ids = append(ids, issue.ID)
+		}
+		if !slices.Equal(ids, want[s.Title]) {
+			t.Errorf("section %q = %v, want %v", s.Title, ids, want[s.Title])
+		}
+	}
+	if wantTitles := []string{"auth", "ui", unlabeledSection}; !slices.Equal(titles, wantTitles) {
+		t.Errorf("section titles = %v, want %v", titles, wantTitles)
+	}
+}
+
+func TestReleaseNotesMarkdownEmpty(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	got := ReleaseNotesMarkdown(BuildReleaseNotes(nil, since, since.AddDate(0, 0, 7), false))
+	want := "# Release notes: 2026-01-01 to 2026-01-08\n\nNo issues were completed in this period.\n"
+	if got != want {
+		t.Errorf("ReleaseNotesMarkdown = %q, want %q", got, want)
+	}
+}
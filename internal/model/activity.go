@@ -17,6 +17,13 @@ type Activity struct {
 	CreatedAt    time.Time
 }
 
+// ActorOrSystem returns who made the change, falling back to the anonymous
+// author set by SetAnonymousAuthor ("system" by default) when none was
+// recorded.
+func (a Activity) ActorOrSystem() string {
+	return authorOr(a.ChangedBy, "system")
+}
+
 // activityJSON is the JSON wire format for Activity.
 type activityJSON struct {
 	ID           int    `json:"id"`
@@ -69,3 +76,10 @@ func (a *Activity) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// IssueActivity is an activity entry together with the title of the issue it
+// belongs to, for reports that span many issues.
+type IssueActivity struct {
+	Activity
+	IssueTitle string
+}
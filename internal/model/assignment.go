@@ -0,0 +1,76 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// AssignmentChange is one change of an issue's assignee, read from its
+// "assignee" activity entries. An empty From or To means unassigned.
+type AssignmentChange struct {
+	From string
+	To   string
+	By   string
+	At   time.Time
+}
+
+// AssigneeTenure is the total time one assignee has held an issue. Current
+// reports whether they hold it now.
+type AssigneeTenure struct {
+	Assignee string
+	Held     time.Duration
+	Current  bool
+}
+
+// AssignmentChanges returns the assignee changes recorded in activity,
+// oldest first.
+func AssignmentChanges(activity []Activity) []AssignmentChange {
+	var changes []AssignmentChange
+	for _, a := range activity {
+		if a.FieldChanged != "assignee" {
+			continue
+		}
+		changes = append(changes, AssignmentChange{From: a.OldValue, To: a.NewValue, By: a.ChangedBy, At: a.CreatedAt})
+	}
+	slices.SortStableFunc(changes, func(a, b AssignmentChange) int { return a.At.Compare(b.At) })
+	return changes
+}
+
+// AssignmentTenures totals how long each assignee held an issue created at
+// created, given its assignee changes oldest first and its current assignee.
+// Before the first change the issue belonged to that change's From, or to
+// current when there are no changes; the last holder's stretch runs up to
+// now. Stretches without an assignee count for nobody. Tenures are ordered
+// by when each assignee first took the issue.
+func AssignmentTenures(created time.Time, changes []AssignmentChange, current string, now time.Time) []AssigneeTenure {
+	holder := current
+	if len(changes) > 0 {
+		holder = changes[0].From
+	}
+
+	var tenures []AssigneeTenure
+	index := make(map[string]int)
+	credit := func(assignee string, from, to time.Time) {
+		if assignee == "" {
+			return
+		}
+		i, ok := index[assignee]
+		if !ok {
+			i = len(tenures)
+			index[assignee] = i
+			tenures = append(tenures, AssigneeTenure{Assignee: assignee})
+		}
+		tenures[i].Held += max(to.Sub(from), 0)
+	}
+
+	since := created
+	for _, c := range changes {
+		credit(holder, since, c.At)
+		holder, since = c.To, c.At
+	}
+	credit(holder, since, now)
+	if i, ok := index[holder]; ok {
+		tenures[i].Current = true
+	}
+	return tenures
+}
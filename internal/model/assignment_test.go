@@ -0,0 +1,93 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAssignmentChanges(t *testing.T) {
+	t0 := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	activity := []Activity{
+		{FieldChanged: "assignee", OldValue: "alice", NewValue: "bob", ChangedBy: "carol", CreatedAt: t0.Add(2 * time.Hour)},
+		{FieldChanged: "status", OldValue: "todo", NewValue: "done", CreatedAt: t0.Add(3 * time.Hour)},
+		{FieldChanged: "assignee", OldValue: "", NewValue: "alice", ChangedBy: "alice", CreatedAt: t0.Add(time.Hour)},
+	}
+	want := []AssignmentChange{
+		{From: "", To: "alice", By: "alice", At: t0.Add(time.Hour)},
+		{From: "alice", To: "bob", By: "carol", At: t0.Add(2 * time.Hour)},
+	}
+	if got := AssignmentChanges(activity); !reflect.DeepEqual(got, want) {
+		t.Errorf("AssignmentChanges = %+v, want %+v", got, want)
+	}
+}
+
+func TestAssignmentTenures(t *testing.T) {
+	created := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return created.Add(time.Duration(h) * time.Hour) }
+	now := at(100)
+
+	tests := []struct {
+		name    string
+		changes []AssignmentChange
+		current string
+		want    []AssigneeTenure
+	}{
+		{
+			name:    "never assigned",
+			current: "",
+			want:    nil,
+		},
+		{
+			name:    "assigned at creation, never changed",
+			current: "alice",
+			want:    []AssigneeTenure{{Assignee: "alice", Held: 100 * time.Hour, Current: true}},
+		},
+		{
+			name: "handed over, current holder open to now",
+			changes: []AssignmentChange{
+				{From: "alice", To: "bob", At: at(10)},
+			},
+			current: "bob",
+			want: []AssigneeTenure{
+				{Assignee: "alice", Held: 10 * time.Hour},
+				{Assignee: "bob", Held: 90 * time.Hour, Current: true},
+			},
+		},
+		{
+			name: "unassigned gaps count for nobody",
+			changes: []AssignmentChange{
+				{From: "", To: "alice", At: at(5)},
+				{From: "alice", To: "", At: at(15)},
+				{From: "", To: "bob", At: at(40)},
+				{From: "bob", To: "alice", At: at(60)},
+				{From: "alice", To: "", At: at(70)},
+			},
+			current: "",
+			want: []AssigneeTenure{
+				{Assignee: "alice", Held: 20 * time.Hour},
+				{Assignee: "bob", Held: 20 * time.Hour},
+			},
+		},
+		{
+			name: "returning holder is current",
+			changes: []AssignmentChange{
+				{From: "alice", To: "bob", At: at(30)},
+				{From: "bob", To: "alice", At: at(50)},
+			},
+			current: "alice",
+			want: []AssigneeTenure{
+				{Assignee: "alice", Held: 80 * time.Hour, Current: true},
+				{Assignee: "bob", Held: 20 * time.Hour},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AssignmentTenures(created, tt.changes, tt.current, now)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AssignmentTenures = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
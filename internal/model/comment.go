@@ -15,22 +15,44 @@ type Comment struct {
 	CreatedAt time.Time
 }
 
-// AuthorOrAnonymous returns the author name, falling back to "anonymous"
-// when the field is empty.
-func (c Comment) AuthorOrAnonymous() string {
-	if c.Author == "" {
-		return "anonymous"
+// anonymousAuthor, when set, is shown in place of an empty comment author or
+// activity actor instead of "anonymous" or "system".
+var anonymousAuthor string
+
+// SetAnonymousAuthor sets the name shown for comments and activity recorded
+// without an author. An empty name restores the defaults, "anonymous" for
+// comments and "system" for activity.
+func SetAnonymousAuthor(name string) {
+	anonymousAuthor = name
+}
+
+// authorOr returns author, or the configured anonymous author when author is
+// empty, or fallback when none is configured.
+func authorOr(author, fallback string) string {
+	switch {
+	case author != "":
+		return author
+	case anonymousAuthor != "":
+		return anonymousAuthor
+	default:
+		return fallback
 	}
-	return c.Author
+}
+
+// AuthorOrAnonymous returns the author name, falling back to the anonymous
+// author set by SetAnonymousAuthor ("anonymous" by default) when the field is
+// empty.
+func (c Comment) AuthorOrAnonymous() string {
+	return authorOr(c.Author, "anonymous")
 }
 
 // commentJSON is the JSON wire format for Comment.
 type commentJSON struct {
-	ID        int    `json:"id"`
-	IssueID   string `json:"issue_id"`
-	Body      string `json:"body"`
-	Author    string `json:"author"`
-	CreatedAt string `json:"created_at"`
+	ID        int           `json:"id"`
+	IssueID   string        `json:"issue_id"`
+	Body      string        `json:"body"`
+	Author    string        `json:"author"`
+	CreatedAt timestampJSON `json:"created_at"`
 }
 
 // MarshalJSON implements custom JSON serialization for Comment.
@@ -40,7 +62,7 @@ func (c Comment) MarshalJSON() ([]byte, error) {
 		IssueID:   FormatID(c.IssueID),
 		Body:      c.Body,
 		Author:    c.AuthorOrAnonymous(),
-		CreatedAt: c.CreatedAt.UTC().Format(time.RFC3339),
+		CreatedAt: formatTimestamp(c.CreatedAt),
 	})
 }
 
@@ -62,11 +84,33 @@ func (c *Comment) UnmarshalJSON(data []byte) error {
 	c.Body = j.Body
 	c.Author = j.Author
 
-	createdAt, err := time.Parse(time.RFC3339, j.CreatedAt)
-	if err != nil {
+	if c.CreatedAt, err = ParseTimestamp(string(j.CreatedAt)); err != nil {
 		return fmt.Errorf("parsing created_at: %w", err)
 	}
-	c.CreatedAt = createdAt
 
 	return nil
 }
+
+// IssueComment is a comment together with the title of its issue, for
+// listings that span issues.
+type IssueComment struct {
+	Comment
+	IssueTitle string
+}
+
+// MarshalJSON serializes the comment like Comment, adding issue_title.
+func (c IssueComment) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		commentJSON
+		IssueTitle string `json:"issue_title"`
+	}{
+		commentJSON: commentJSON{
+			ID:        c.ID,
+			IssueID:   FormatID(c.IssueID),
+			Body:      c.Body,
+			Author:    c.AuthorOrAnonymous(),
+			CreatedAt: formatTimestamp(c.CreatedAt),
+		},
+		IssueTitle: c.IssueTitle,
+	})
+}
@@ -219,6 +219,12 @@ type DocComment struct {
 	CreatedAt time.Time
 }
 
+// AuthorOrAnonymous returns the author name, falling back like
+// Comment.AuthorOrAnonymous when the field is empty.
+func (c DocComment) AuthorOrAnonymous() string {
+	return authorOr(c.Author, "anonymous")
+}
+
 // docCommentJSON is the JSON wire format for DocComment.
 type docCommentJSON struct {
 	ID        int    `json:"id"`
@@ -13,15 +13,23 @@ type IssueFileMapping struct {
 }
 
 // ExportData is the top-level structure for a full database export.
+// ExportedAt is omitted from stable exports so that exporting an unchanged
+// database twice produces identical output. RelationTypes holds only custom
+// relation types, and is omitted when there are none. Since is the cutoff of
+// an incremental export, so a consumer can pass it on to the next one.
 type ExportData struct {
 	Version            int                 `json:"version"`
-	ExportedAt         string              `json:"exported_at"`
+	ExportedAt         string              `json:"exported_at,omitempty"`
+	Since              string              `json:"since,omitempty"`
 	Issues             []*Issue            `json:"issues"`
 	Comments           []*Comment          `json:"comments"`
+	RelationTypes      []RelationTypeDef   `json:"relation_types,omitempty"`
 	Relations          []Relation          `json:"relations"`
 	Labels             []*Label            `json:"labels"`
+	Milestones         []*Milestone        `json:"milestones"`
 	IssueLabelMappings []IssueLabelMapping `json:"issue_label_mappings"`
 	IssueFileMappings  []IssueFileMapping  `json:"issue_file_mappings"`
+	IssueLinks         []IssueLink         `json:"issue_links"`
 	ActivityLog        []*Activity         `json:"activity_log"`
 	Docs               []*Doc              `json:"docs"`
 	DocRevisions       []*DocRevision      `json:"doc_revisions"`
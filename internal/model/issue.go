@@ -2,14 +2,39 @@ package model
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
+// DefaultIDPrefix is the issue ID prefix used unless a project config file
+// sets another with SetIDPrefix.
+const DefaultIDPrefix = "DKT"
+
 // IDPrefix is the prefix used for issue IDs in display and JSON output.
-const IDPrefix = "DKT"
+var IDPrefix = DefaultIDPrefix
+
+// idPrefixPattern matches valid issue ID prefixes: a letter followed by
+// letters and digits.
+var idPrefixPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+
+// SetIDPrefix sets the prefix used to display, parse and detect issue IDs.
+// The prefix is uppercased; an empty prefix restores DefaultIDPrefix.
+func SetIDPrefix(prefix string) error {
+	if prefix == "" {
+		prefix = DefaultIDPrefix
+	}
+	if !idPrefixPattern.MatchString(prefix) {
+		return fmt.Errorf("invalid issue ID prefix %q: must be a letter followed by letters or digits", prefix)
+	}
+	IDPrefix = strings.ToUpper(prefix)
+	issueRefPattern = compileIssueRefPattern(IDPrefix)
+	return nil
+}
 
 // Status represents the workflow state of an issue.
 type Status string
@@ -76,6 +101,66 @@ func (s Status) Icon() string {
 	}
 }
 
+// Transitions maps each status to the statuses an issue may move to from it,
+// for teams that enforce a workflow. A nil map allows every transition; in a
+// non-nil map, a status without an entry may not be left. Keeping the same
+// status is always allowed.
+type Transitions map[Status][]Status
+
+// Allows reports whether an issue may move from one status to another.
+func (t Transitions) Allows(from, to Status) bool {
+	if t == nil || from == to {
+		return true
+	}
+	for _, s := range t[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns an error if any status in t is not a recognized status.
+func (t Transitions) Validate() error {
+	for from, tos := range t {
+		if err := ValidateStatus(from); err != nil {
+			return err
+		}
+		for _, to := range tos {
+			if err := ValidateStatus(to); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ParseTransitionRule parses a rule such as "backlog=todo,in-progress" into
+// the status it applies to and the statuses it allows moving to. An empty
+// right-hand side, as in "done=", allows no moves.
+func ParseTransitionRule(rule string) (Status, []Status, error) {
+	fromStr, toStr, ok := strings.Cut(rule, "=")
+	if !ok {
+		return "", nil, fmt.Errorf("invalid transition rule %q: want from=to[,to...]", rule)
+	}
+	from := Status(strings.TrimSpace(fromStr))
+	if err := ValidateStatus(from); err != nil {
+		return "", nil, err
+	}
+	to := []Status{}
+	for _, s := range strings.Split(toStr, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if err := ValidateStatus(Status(s)); err != nil {
+			return "", nil, err
+		}
+		to = append(to, Status(s))
+	}
+	return from, to, nil
+}
+
 // Priority represents the urgency of an issue.
 type Priority string
 
@@ -211,31 +296,228 @@ func FormatID(id int) string {
 	return fmt.Sprintf("%s-%d", IDPrefix, id)
 }
 
-// ParseID accepts both "DKT-5" and "5" and returns the numeric ID.
-// The prefix check is case-insensitive; len(prefix) is safe to use for
-// slicing because IDPrefix is ASCII and ToUpper preserves its byte length.
+// ErrInvalidID is wrapped by the errors ParseID returns.
+var ErrInvalidID = errors.New("invalid issue ID")
+
+// IDError describes input that ParseID could not read as an issue ID.
+type IDError struct {
+	Input  string
+	Reason string
+}
+
+func (e *IDError) Error() string {
+	if strings.Trim(e.Input, idTrimChars) == "" {
+		return "empty issue ID"
+	}
+	return fmt.Sprintf("invalid issue ID %q: %s", e.Input, e.Reason)
+}
+
+func (e *IDError) Unwrap() error { return ErrInvalidID }
+
+// idTrimChars are stripped from both ends of an ID before parsing, so that
+// IDs pasted from prose or log lines ("(DKT-5)", "#5", "DKT-5:") still parse.
+const idTrimChars = " \t\r\n#:;,.()[]{}<>\"'`"
+
+// ParseID accepts "DKT-5", "dkt-5", "DKT5" and "5", ignoring surrounding
+// whitespace and punctuation, and returns the numeric ID. Malformed input
+// returns an *IDError. The prefix check is case-insensitive.
+// DefaultIDPrefix is accepted alongside a configured IDPrefix, so exports
+// from databases without one still import.
 func ParseID(input string) (int, error) {
-	s := strings.TrimSpace(input)
+	s := strings.Trim(input, idTrimChars)
 	if s == "" {
-		return 0, fmt.Errorf("empty issue ID")
+		return 0, &IDError{Input: input, Reason: "empty"}
 	}
 
-	prefix := IDPrefix + "-"
-	if strings.HasPrefix(strings.ToUpper(s), prefix) {
-		s = s[len(prefix):]
+	for _, prefix := range []string{IDPrefix, DefaultIDPrefix} {
+		if rest, ok := cutIDPrefix(s, prefix); ok {
+			s = strings.TrimPrefix(rest, "-")
+			break
+		}
+	}
+	if s == "" {
+		return 0, &IDError{Input: input, Reason: "missing number after " + IDPrefix + "-"}
+	}
+	if strings.HasPrefix(s, "-") {
+		return 0, &IDError{Input: input, Reason: "must be positive"}
+	}
+	if strings.TrimLeft(s, "0123456789") != "" {
+		return 0, &IDError{Input: input, Reason: fmt.Sprintf("expected %s-<number> or a number", IDPrefix)}
 	}
 
 	id, err := strconv.Atoi(s)
 	if err != nil {
-		return 0, fmt.Errorf("invalid issue ID %q: %w", input, err)
+		return 0, &IDError{Input: input, Reason: "number out of range"}
 	}
 	if id <= 0 {
-		return 0, fmt.Errorf("invalid issue ID %q: must be positive", input)
+		return 0, &IDError{Input: input, Reason: "must be positive"}
 	}
 
 	return id, nil
 }
 
+// MaxIDRange is the most IDs ParseIDRange expands a range to, so that a
+// typo such as "DKT-1..DKT-10000" fails instead of naming every issue.
+const MaxIDRange = 1000
+
+// ParseIDRange parses an inclusive range of issue IDs such as "DKT-1..DKT-4"
+// or "DKT-1..4" into its IDs in ascending order. A single ID, in any form
+// ParseID accepts, gives just that ID. A range whose end comes before its
+// start, or that spans more than MaxIDRange IDs, is an error.
+func ParseIDRange(input string) ([]int, error) {
+	startPart, endPart, isRange := strings.Cut(input, "..")
+	if !isRange {
+		id, err := ParseID(input)
+		if err != nil {
+			return nil, err
+		}
+		return []int{id}, nil
+	}
+
+	start, err := ParseID(startPart)
+	if err != nil {
+		return nil, &IDError{Input: input, Reason: "range start: " + rangeReason(err)}
+	}
+	end, err := ParseID(endPart)
+	if err != nil {
+		return nil, &IDError{Input: input, Reason: "range end: " + rangeReason(err)}
+	}
+	if end < start {
+		return nil, &IDError{Input: input, Reason: fmt.Sprintf("range ends at %s, before it starts at %s", FormatID(end), FormatID(start))}
+	}
+	if end-start >= MaxIDRange {
+		return nil, &IDError{Input: input, Reason: fmt.Sprintf("range spans more than %d IDs", MaxIDRange)}
+	}
+
+	ids := make([]int, 0, end-start+1)
+	for id := start; id <= end; id++ {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ParseIDList parses issue IDs and ranges of them, as ParseIDRange does,
+// combining them in the order given with repeated IDs dropped.
+func ParseIDList(inputs []string) ([]int, error) {
+	var ids []int
+	seen := make(map[int]bool)
+	for _, input := range inputs {
+		parsed, err := ParseIDRange(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range parsed {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// rangeReason returns why one end of a range failed to parse.
+func rangeReason(err error) string {
+	var idErr *IDError
+	if !errors.As(err, &idErr) {
+		return err.Error()
+	}
+	if strings.Trim(idErr.Input, idTrimChars) == "" {
+		return "missing issue ID"
+	}
+	return idErr.Reason
+}
+
+// cutIDPrefix returns s without prefix, matched case-insensitively, when
+// what follows it is empty, a hyphen or a digit.
+func cutIDPrefix(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return "", false
+	}
+	rest := s[len(prefix):]
+	if rest != "" && rest[0] != '-' && (rest[0] < '0' || rest[0] > '9') {
+		return "", false
+	}
+	return rest, true
+}
+
+// NearMissIDs returns IDs that id is a plausible typo of: the ID with one
+// digit dropped, with two adjacent digits swapped, or with a digit
+// doubled, followed by the neighbouring IDs id-1 and id+1. The result is
+// ordered by that preference, without duplicates, id itself or
+// non-positive IDs.
+func NearMissIDs(id int) []int {
+	digits := strconv.Itoa(id)
+	var variants []string
+	for i := range digits {
+		variants = append(variants, digits[:i]+digits[i+1:])
+	}
+	for i := 0; i+1 < len(digits); i++ {
+		b := []byte(digits)
+		b[i], b[i+1] = b[i+1], b[i]
+		variants = append(variants, string(b))
+	}
+	for i := range digits {
+		variants = append(variants, digits[:i+1]+digits[i:])
+	}
+	variants = append(variants, strconv.Itoa(id-1), strconv.Itoa(id+1))
+
+	seen := map[int]bool{id: true}
+	var ids []int
+	for _, v := range variants {
+		// Variants with a leading zero read as a different, shorter ID.
+		if v == "" || v[0] == '0' {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || seen[n] {
+			continue
+		}
+		seen[n] = true
+		ids = append(ids, n)
+	}
+	return ids
+}
+
+// DefaultMaxTitleLength is the longest issue title, in runes, that
+// ValidateIssue accepts unless SetMaxTitleLength sets another limit.
+const DefaultMaxTitleLength = 200
+
+// MaxTitleLength is the longest issue title, in runes, that ValidateIssue
+// accepts.
+var MaxTitleLength = DefaultMaxTitleLength
+
+// SetMaxTitleLength sets the longest title ValidateIssue accepts; 0 restores
+// DefaultMaxTitleLength.
+func SetMaxTitleLength(n int) error {
+	if n < 0 {
+		return fmt.Errorf("invalid max title length %d: must not be negative", n)
+	}
+	if n == 0 {
+		n = DefaultMaxTitleLength
+	}
+	MaxTitleLength = n
+	return nil
+}
+
+// ValidateTitle returns an error if title is blank or longer than
+// MaxTitleLength runes.
+func ValidateTitle(title string) error {
+	if strings.TrimSpace(title) == "" {
+		return errors.New("title must not be empty")
+	}
+	if n := utf8.RuneCountInString(title); n > MaxTitleLength {
+		return fmt.Errorf("title is %d characters long: must be at most %d", n, MaxTitleLength)
+	}
+	return nil
+}
+
+// ValidateIssue returns an error if issue is not valid to store. It
+// currently checks only the title; see ValidateTitle.
+func ValidateIssue(issue *Issue) error {
+	return ValidateTitle(issue.Title)
+}
+
 // Issue represents a tracked issue.
 type Issue struct {
 	ID          int
@@ -249,25 +531,72 @@ type Issue struct {
 	Labels      []string
 	Files       []string
 	Docs        []DocRef
+	Links       []IssueLink
+	Watchers    []string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+
+	// StartedAt is when the issue first moved to in-progress and CompletedAt
+	// when it last moved to done; either is zero when that has not happened.
+	// CompletedAt is cleared when a done issue is reopened.
+	StartedAt   time.Time
+	CompletedAt time.Time
+
+	// SnoozedUntil hides the issue from listings until that time; zero means
+	// the issue is not snoozed. A past time is the same as zero.
+	SnoozedUntil time.Time
+
+	// SortOrder ranks the issue among its siblings under the same parent;
+	// nil means it follows the ranked siblings in creation order.
+	SortOrder *int
+
+	// Pinned keeps the issue ahead of every other issue in lists and boards,
+	// whatever sort is chosen.
+	Pinned bool
+
+	// MilestoneID links the issue to a milestone; Milestone holds that
+	// milestone's name and is populated by db.HydrateMilestones.
+	MilestoneID *int
+	Milestone   string
+
+	// CommentCount and LastCommentAt are populated on demand by
+	// db.HydrateCommentCounts; LastCommentAt is zero when there are no comments.
+	CommentCount  int
+	LastCommentAt time.Time
+
+	// BlockedByCount and BlocksCount count the issues that block this one
+	// and that it blocks; they are populated on demand by
+	// db.HydrateRelationDegrees.
+	BlockedByCount int
+	BlocksCount    int
 }
 
 // issueJSON is the JSON wire format for Issue.
 type issueJSON struct {
-	ID          string   `json:"id"`
-	ParentID    *string  `json:"parent_id,omitempty"`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Status      string   `json:"status"`
-	Priority    string   `json:"priority"`
-	Kind        string   `json:"kind"`
-	Assignee    string   `json:"assignee"`
-	Labels      []string `json:"labels"`
-	Files       []string `json:"files"`
-	Docs        []DocRef `json:"docs"`
-	CreatedAt   string   `json:"created_at"`
-	UpdatedAt   string   `json:"updated_at"`
+	ID            string         `json:"id"`
+	ParentID      *string        `json:"parent_id,omitempty"`
+	Title         string         `json:"title"`
+	Description   string         `json:"description"`
+	Status        string         `json:"status"`
+	Priority      string         `json:"priority"`
+	Kind          string         `json:"kind"`
+	Assignee      string         `json:"assignee"`
+	Labels        []string       `json:"labels"`
+	Files         []string       `json:"files"`
+	Docs          []DocRef       `json:"docs"`
+	Links         []IssueLink    `json:"links,omitempty"`
+	Watchers      []string       `json:"watchers,omitempty"`
+	MilestoneID   *int           `json:"milestone_id,omitempty"`
+	Milestone     string         `json:"milestone,omitempty"`
+	CommentCount  int            `json:"comment_count,omitempty"`
+	LastCommentAt *timestampJSON `json:"last_comment_at,omitempty"`
+	CreatedAt     timestampJSON  `json:"created_at"`
+	UpdatedAt     timestampJSON  `json:"updated_at"`
+	StartedAt     *timestampJSON `json:"started_at,omitempty"`
+	CompletedAt   *timestampJSON `json:"completed_at,omitempty"`
+	SnoozedUntil  *timestampJSON `json:"snoozed_until,omitempty"`
+	SortOrder     *int           `json:"sort_order,omitempty"`
+	Pinned        bool           `json:"pinned,omitempty"`
 }
 
 // MarshalJSON implements custom JSON serialization for Issue.
@@ -288,18 +617,25 @@ func (i Issue) MarshalJSON() ([]byte, error) {
 	}
 
 	j := issueJSON{
-		ID:          FormatID(i.ID),
-		Title:       i.Title,
-		Description: i.Description,
-		Status:      string(i.Status),
-		Priority:    string(i.Priority),
-		Kind:        string(i.Kind),
-		Assignee:    i.Assignee,
-		Labels:      labels,
-		Files:       files,
-		Docs:        docs,
-		CreatedAt:   i.CreatedAt.UTC().Format(time.RFC3339),
-		UpdatedAt:   i.UpdatedAt.UTC().Format(time.RFC3339),
+		ID:           FormatID(i.ID),
+		Title:        i.Title,
+		Description:  i.Description,
+		Status:       string(i.Status),
+		Priority:     string(i.Priority),
+		Kind:         string(i.Kind),
+		Assignee:     i.Assignee,
+		Labels:       labels,
+		Files:        files,
+		Docs:         docs,
+		Links:        i.Links,
+		Watchers:     i.Watchers,
+		MilestoneID:  i.MilestoneID,
+		Milestone:    i.Milestone,
+		CommentCount: i.CommentCount,
+		SortOrder:    i.SortOrder,
+		Pinned:       i.Pinned,
+		CreatedAt:    formatTimestamp(i.CreatedAt),
+		UpdatedAt:    formatTimestamp(i.UpdatedAt),
 	}
 
 	if i.ParentID != nil {
@@ -307,6 +643,11 @@ func (i Issue) MarshalJSON() ([]byte, error) {
 		j.ParentID = &pid
 	}
 
+	j.LastCommentAt = optionalTime(i.LastCommentAt)
+	j.StartedAt = optionalTime(i.StartedAt)
+	j.CompletedAt = optionalTime(i.CompletedAt)
+	j.SnoozedUntil = optionalTime(i.SnoozedUntil)
+
 	return json.Marshal(j)
 }
 
@@ -351,22 +692,59 @@ func (i *Issue) UnmarshalJSON(data []byte) error {
 	i.Assignee = j.Assignee
 	i.Labels = j.Labels
 	i.Files = j.Files
-
-	createdAt, err := time.Parse(time.RFC3339, j.CreatedAt)
-	if err != nil {
+	i.Links = j.Links
+	i.Watchers = j.Watchers
+	i.MilestoneID = j.MilestoneID
+	i.Milestone = j.Milestone
+	i.CommentCount = j.CommentCount
+	i.SortOrder = j.SortOrder
+	i.Pinned = j.Pinned
+
+	if i.LastCommentAt, err = parseOptionalTime(j.LastCommentAt); err != nil {
+		return fmt.Errorf("parsing last_comment_at: %w", err)
+	}
+	if i.CreatedAt, err = ParseTimestamp(string(j.CreatedAt)); err != nil {
 		return fmt.Errorf("parsing created_at: %w", err)
 	}
-	i.CreatedAt = createdAt
-
-	updatedAt, err := time.Parse(time.RFC3339, j.UpdatedAt)
-	if err != nil {
+	if i.UpdatedAt, err = ParseTimestamp(string(j.UpdatedAt)); err != nil {
 		return fmt.Errorf("parsing updated_at: %w", err)
 	}
-	i.UpdatedAt = updatedAt
+	if i.StartedAt, err = parseOptionalTime(j.StartedAt); err != nil {
+		return fmt.Errorf("parsing started_at: %w", err)
+	}
+	if i.CompletedAt, err = parseOptionalTime(j.CompletedAt); err != nil {
+		return fmt.Errorf("parsing completed_at: %w", err)
+	}
+	if i.SnoozedUntil, err = parseOptionalTime(j.SnoozedUntil); err != nil {
+		return fmt.Errorf("parsing snoozed_until: %w", err)
+	}
 
 	return nil
 }
 
+// IsSnoozed reports whether the issue is snoozed at now.
+func (i *Issue) IsSnoozed(now time.Time) bool {
+	return i.SnoozedUntil.After(now)
+}
+
+// optionalTime formats t as RFC 3339, or returns nil when t is zero.
+func optionalTime(t time.Time) *timestampJSON {
+	if t.IsZero() {
+		return nil
+	}
+	ts := formatTimestamp(t)
+	return &ts
+}
+
+// parseOptionalTime parses a timestamp with ParseTimestamp, returning the
+// zero time when ts is nil.
+func parseOptionalTime(ts *timestampJSON) (time.Time, error) {
+	if ts == nil {
+		return time.Time{}, nil
+	}
+	return ParseTimestamp(string(*ts))
+}
+
 type IssueRef struct {
 	ID     int
 	Kind   string
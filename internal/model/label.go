@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // Label represents a label that can be attached to an issue.
 type Label struct {
 	ID    int    `json:"id"`
@@ -7,8 +9,10 @@ type Label struct {
 	Color string `json:"color,omitempty"`
 }
 
-// LabelWithCount extends Label with the number of issues using it.
+// LabelWithCount extends Label with the number of issues using it and when
+// it was last attached to or detached from an issue, nil if never.
 type LabelWithCount struct {
 	Label
-	IssueCount int `json:"issue_count"`
+	IssueCount int        `json:"issue_count"`
+	LastUsedAt *time.Time `json:"last_used_at"`
 }
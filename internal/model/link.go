@@ -0,0 +1,76 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// IssueLink is a URL attached to an issue, such as a pull request or an
+// external design document.
+type IssueLink struct {
+	ID        int
+	IssueID   int
+	URL       string
+	Title     string
+	CreatedAt time.Time
+}
+
+// ValidateLinkURL returns an error unless s is an absolute http or https URL
+// with a host.
+func ValidateLinkURL(s string) error {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("invalid link %q: must be an http:// or https:// URL", s)
+	}
+	return nil
+}
+
+// issueLinkJSON is the JSON wire format for IssueLink.
+type issueLinkJSON struct {
+	ID        int    `json:"id"`
+	IssueID   string `json:"issue_id"`
+	URL       string `json:"url"`
+	Title     string `json:"title"`
+	CreatedAt string `json:"created_at"`
+}
+
+// MarshalJSON implements custom JSON serialization for IssueLink.
+func (l IssueLink) MarshalJSON() ([]byte, error) {
+	return json.Marshal(issueLinkJSON{
+		ID:        l.ID,
+		IssueID:   FormatID(l.IssueID),
+		URL:       l.URL,
+		Title:     l.Title,
+		CreatedAt: l.CreatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// UnmarshalJSON implements custom JSON deserialization for IssueLink.
+func (l *IssueLink) UnmarshalJSON(data []byte) error {
+	var j issueLinkJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	l.ID = j.ID
+
+	issueID, err := ParseID(j.IssueID)
+	if err != nil {
+		return fmt.Errorf("parsing issue id: %w", err)
+	}
+	l.IssueID = issueID
+
+	l.URL = j.URL
+	l.Title = j.Title
+
+	createdAt, err := time.Parse(time.RFC3339, j.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("parsing created_at: %w", err)
+	}
+	l.CreatedAt = createdAt
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionPattern matches "@name" tokens. The leading group requires the "@"
+// to start the text or follow a character that cannot appear in an email
+// address, so "alice@example.com" is not a mention of "example.com".
+var mentionPattern = regexp.MustCompile(`(^|[^A-Za-z0-9._%+\-@])@([A-Za-z0-9][A-Za-z0-9._\-]*)`)
+
+// fencedCodePattern matches Markdown fenced code blocks, including an
+// unterminated fence running to the end of the text.
+var fencedCodePattern = regexp.MustCompile("(?s)```.*?(```|$)")
+
+// inlineCodePattern matches Markdown inline code spans.
+var inlineCodePattern = regexp.MustCompile("`[^`\n]*`")
+
+// ExtractMentions returns the distinct names mentioned as "@name" in text,
+// in order of first appearance. Names are compared case-insensitively and
+// returned as first written. Email addresses and anything inside Markdown
+// code spans or fenced code blocks are ignored, as is trailing sentence
+// punctuation such as the period in "thanks @alice.".
+func ExtractMentions(text string) []string {
+	text = fencedCodePattern.ReplaceAllString(text, " ")
+	text = inlineCodePattern.ReplaceAllString(text, " ")
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		name := strings.TrimRight(m[2], ".-_")
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		names = append(names, name)
+	}
+	return names
+}
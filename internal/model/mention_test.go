@@ -0,0 +1,37 @@
+package model
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExtractMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"single", "ping @alice", []string{"alice"}},
+		{"start of text", "@bob please look", []string{"bob"}},
+		{"multiple in order", "@carol and @alice, then @bob", []string{"carol", "alice", "bob"}},
+		{"trailing punctuation", "thanks @alice. And @bob!", []string{"alice", "bob"}},
+		{"dotted name", "cc @jane.doe for review", []string{"jane.doe"}},
+		{"parenthesized", "(@alice)", []string{"alice"}},
+		{"duplicates case-insensitive", "@Alice then @alice then @ALICE", []string{"Alice"}},
+		{"email ignored", "mail alice@example.com", nil},
+		{"email and mention", "write to bob@example.com or ask @bob", []string{"bob"}},
+		{"bare at sign", "meet @ noon", nil},
+		{"double at", "@@alice", nil},
+		{"inline code", "run `git log --author @alice` or ask @bob", []string{"bob"}},
+		{"fenced code", "see\n```\n@decorator\ndef f(): pass\n```\nthen @carol", []string{"carol"}},
+		{"unterminated fence", "@dave\n```\n@ignored", []string{"dave"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractMentions(tt.text); !slices.Equal(got, tt.want) {
+				t.Errorf("ExtractMentions(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
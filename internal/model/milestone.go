@@ -0,0 +1,124 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DueDateLayout is the calendar-date format used for milestone due dates.
+const DueDateLayout = "2006-01-02"
+
+// Milestone is a named target release that issues can be grouped under,
+// such as "v1.4".
+type Milestone struct {
+	ID          int
+	Name        string
+	Description string
+	DueDate     *time.Time
+	Closed      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// MilestoneSummary pairs a milestone with the done/total counts of the
+// issues assigned to it.
+type MilestoneSummary struct {
+	*Milestone
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// MarshalJSON flattens the embedded milestone alongside the counts.
+func (s MilestoneSummary) MarshalJSON() ([]byte, error) {
+	j := s.Milestone.toJSON()
+	return json.Marshal(struct {
+		milestoneJSON
+		Done  int `json:"done"`
+		Total int `json:"total"`
+	}{j, s.Done, s.Total})
+}
+
+// ParseDueDate parses a YYYY-MM-DD date as midnight UTC.
+func ParseDueDate(s string) (time.Time, error) {
+	t, err := time.Parse(DueDateLayout, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid due date %q: must be YYYY-MM-DD", s)
+	}
+	return t, nil
+}
+
+// FormatDueDate returns the due date as YYYY-MM-DD, or "" when unset.
+func (m *Milestone) FormatDueDate() string {
+	if m.DueDate == nil {
+		return ""
+	}
+	return m.DueDate.UTC().Format(DueDateLayout)
+}
+
+// milestoneJSON is the JSON wire format for Milestone.
+type milestoneJSON struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	DueDate     *string `json:"due_date,omitempty"`
+	Closed      bool    `json:"closed"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+func (m *Milestone) toJSON() milestoneJSON {
+	j := milestoneJSON{
+		ID:          m.ID,
+		Name:        m.Name,
+		Description: m.Description,
+		Closed:      m.Closed,
+		CreatedAt:   m.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:   m.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+	if due := m.FormatDueDate(); due != "" {
+		j.DueDate = &due
+	}
+	return j
+}
+
+// MarshalJSON implements custom JSON serialization for Milestone.
+func (m Milestone) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.toJSON())
+}
+
+// UnmarshalJSON implements custom JSON deserialization for Milestone.
+func (m *Milestone) UnmarshalJSON(data []byte) error {
+	var j milestoneJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	m.ID = j.ID
+	m.Name = j.Name
+	m.Description = j.Description
+	m.Closed = j.Closed
+
+	if j.DueDate != nil {
+		due, err := ParseDueDate(*j.DueDate)
+		if err != nil {
+			return err
+		}
+		m.DueDate = &due
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, j.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("parsing created_at: %w", err)
+	}
+	m.CreatedAt = createdAt
+
+	updatedAt, err := time.Parse(time.RFC3339, j.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("parsing updated_at: %w", err)
+	}
+	m.UpdatedAt = updatedAt
+
+	return nil
+}
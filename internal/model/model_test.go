@@ -2,6 +2,9 @@ package model
 
 import (
 	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -30,6 +33,19 @@ func TestParseID(t *testing.T) {
 		{"abc", 0, true},
 		{"DKT-0", 0, true},
 		{"DKT--1", 0, true},
+		{"dkt-12", 12, false},
+		{"DKT12", 12, false},
+		{"Dkt12", 12, false},
+		{"  DKT-12: ", 12, false},
+		{"(DKT-12)", 12, false},
+		{"#12", 12, false},
+		{"DKT-12.", 12, false},
+		{"+12", 0, true},
+		{"1 2", 0, true},
+		{"DKT-12a", 0, true},
+		{"DKTX12", 0, true},
+		{"99999999999999999999", 0, true},
+		{":", 0, true},
 	}
 
 	for _, tt := range tests {
@@ -44,6 +60,132 @@ func TestParseID(t *testing.T) {
 	}
 }
 
+func TestParseIDErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "empty issue ID"},
+		{" : ", "empty issue ID"},
+		{"DKT-", `invalid issue ID "DKT-": missing number after DKT-`},
+		{"DKT--3", `invalid issue ID "DKT--3": must be positive`},
+		{"0", `invalid issue ID "0": must be positive`},
+		{"abc", `invalid issue ID "abc": expected DKT-<number> or a number`},
+	}
+	for _, tt := range tests {
+		_, err := ParseID(tt.input)
+		var idErr *IDError
+		if !errors.As(err, &idErr) || !errors.Is(err, ErrInvalidID) {
+			t.Errorf("ParseID(%q) error = %v, want an *IDError", tt.input, err)
+			continue
+		}
+		if err.Error() != tt.want {
+			t.Errorf("ParseID(%q) error = %q, want %q", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestParseIDRange(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []int
+	}{
+		{"DKT-1..DKT-4", []int{1, 2, 3, 4}},
+		{"DKT-7..9", []int{7, 8, 9}},
+		{"3..3", []int{3}},
+		{"DKT-5", []int{5}},
+	}
+	for _, tt := range tests {
+		got, err := ParseIDRange(tt.input)
+		if err != nil {
+			t.Errorf("ParseIDRange(%q) error: %v", tt.input, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ParseIDRange(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseIDRangeErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"DKT-4..DKT-1", `invalid issue ID "DKT-4..DKT-1": range ends at DKT-1, before it starts at DKT-4`},
+		{"DKT-1..", `invalid issue ID "DKT-1..": range end: missing issue ID`},
+		{"x..DKT-3", `invalid issue ID "x..DKT-3": range start: expected DKT-<number> or a number`},
+		{"1..5000", `invalid issue ID "1..5000": range spans more than 1000 IDs`},
+	}
+	for _, tt := range tests {
+		_, err := ParseIDRange(tt.input)
+		if !errors.Is(err, ErrInvalidID) {
+			t.Errorf("ParseIDRange(%q) error = %v, want ErrInvalidID", tt.input, err)
+			continue
+		}
+		if err.Error() != tt.want {
+			t.Errorf("ParseIDRange(%q) error = %q, want %q", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestParseIDList(t *testing.T) {
+	got, err := ParseIDList([]string{"DKT-9", "DKT-2..DKT-4", "3", "DKT-9..10"})
+	if err != nil {
+		t.Fatalf("ParseIDList: %v", err)
+	}
+	if want := []int{9, 2, 3, 4, 10}; !slices.Equal(got, want) {
+		t.Errorf("ParseIDList = %v, want %v", got, want)
+	}
+
+	if _, err := ParseIDList([]string{"DKT-1", "DKT-3..DKT-2"}); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("ParseIDList with an inverted range error = %v, want ErrInvalidID", err)
+	}
+}
+
+func TestSetIDPrefix(t *testing.T) {
+	t.Cleanup(func() { SetIDPrefix("") })
+
+	if err := SetIDPrefix("app"); err != nil {
+		t.Fatalf("SetIDPrefix: %v", err)
+	}
+	if got := FormatID(5); got != "APP-5" {
+		t.Errorf("FormatID(5) = %q, want APP-5", got)
+	}
+	for _, input := range []string{"APP-5", "app5", "DKT-5", "5"} {
+		if id, err := ParseID(input); err != nil || id != 5 {
+			t.Errorf("ParseID(%q) = %d, %v; want 5", input, id, err)
+		}
+	}
+	if got := ExtractIssueRefs("see APP-3 and DKT-4"); !slices.Equal(got, []int{3}) {
+		t.Errorf("ExtractIssueRefs = %v, want [3]", got)
+	}
+
+	if err := SetIDPrefix("1x"); err == nil {
+		t.Error("SetIDPrefix(1x): expected an error")
+	}
+	if IDPrefix != "APP" {
+		t.Errorf("IDPrefix = %q after a rejected prefix, want APP", IDPrefix)
+	}
+}
+
+func TestNearMissIDs(t *testing.T) {
+	tests := []struct {
+		id   int
+		want []int
+	}{
+		{112, []int{12, 11, 121, 1112, 1122, 111, 113}},
+		{12, []int{2, 1, 21, 112, 122, 11, 13}},
+		{1, []int{11, 2}},
+		{10, []int{1, 110, 100, 9, 11}},
+	}
+	for _, tt := range tests {
+		if got := NearMissIDs(tt.id); !slices.Equal(got, tt.want) {
+			t.Errorf("NearMissIDs(%d) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
 func TestFormatParseRoundTrip(t *testing.T) {
 	for _, id := range []int{1, 5, 42, 999} {
 		formatted := FormatID(id)
@@ -70,6 +212,53 @@ func TestValidateStatus(t *testing.T) {
 	}
 }
 
+func TestTransitionsAllows(t *testing.T) {
+	var all Transitions
+	if !all.Allows(StatusBacklog, StatusDone) {
+		t.Error("nil Transitions should allow every move")
+	}
+
+	tr := Transitions{
+		StatusBacklog: {StatusTodo},
+		StatusTodo:    {StatusInProgress, StatusBacklog},
+	}
+	tests := []struct {
+		from, to Status
+		want     bool
+	}{
+		{StatusBacklog, StatusTodo, true},
+		{StatusBacklog, StatusDone, false},
+		{StatusTodo, StatusBacklog, true},
+		{StatusReview, StatusDone, false}, // no rule: cannot be left
+		{StatusReview, StatusReview, true},
+	}
+	for _, tt := range tests {
+		if got := tr.Allows(tt.from, tt.to); got != tt.want {
+			t.Errorf("Allows(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestParseTransitionRule(t *testing.T) {
+	from, to, err := ParseTransitionRule("todo = in-progress, backlog")
+	if err != nil {
+		t.Fatalf("ParseTransitionRule: %v", err)
+	}
+	if from != StatusTodo || len(to) != 2 || to[0] != StatusInProgress || to[1] != StatusBacklog {
+		t.Errorf("ParseTransitionRule = %s, %v", from, to)
+	}
+
+	if from, to, err := ParseTransitionRule("done="); err != nil || from != StatusDone || len(to) != 0 {
+		t.Errorf("ParseTransitionRule(done=) = %s, %v, %v; want done with no moves", from, to, err)
+	}
+
+	for _, bad := range []string{"todo", "todo=finished", "later=todo"} {
+		if _, _, err := ParseTransitionRule(bad); err == nil {
+			t.Errorf("ParseTransitionRule(%q) expected error", bad)
+		}
+	}
+}
+
 func TestValidatePriority(t *testing.T) {
 	valid := []Priority{PriorityCritical, PriorityHigh, PriorityMedium, PriorityLow, PriorityNone}
 	for _, p := range valid {
@@ -94,6 +283,47 @@ func TestValidateIssueKind(t *testing.T) {
 	}
 }
 
+func TestValidateIssue(t *testing.T) {
+	tests := []struct {
+		name    string
+		title   string
+		wantErr bool
+	}{
+		{"normal", "Fix login redirect", false},
+		{"at the limit", strings.Repeat("é", DefaultMaxTitleLength), false},
+		{"empty", "", true},
+		{"blank", "   ", true},
+		{"over the limit", strings.Repeat("a", DefaultMaxTitleLength+1), true},
+	}
+	for _, tt := range tests {
+		err := ValidateIssue(&Issue{Title: tt.title})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: ValidateIssue error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSetMaxTitleLength(t *testing.T) {
+	t.Cleanup(func() { SetMaxTitleLength(0) })
+
+	if err := SetMaxTitleLength(10); err != nil {
+		t.Fatalf("SetMaxTitleLength: %v", err)
+	}
+	if err := ValidateTitle("eleven char"); err == nil {
+		t.Error("expected an 11-character title to be rejected with a limit of 10")
+	}
+	if err := ValidateTitle("ten chars!"); err != nil {
+		t.Errorf("ValidateTitle: %v", err)
+	}
+
+	if err := SetMaxTitleLength(-1); err == nil {
+		t.Error("expected a negative limit to be rejected")
+	}
+	if err := SetMaxTitleLength(0); err != nil || MaxTitleLength != DefaultMaxTitleLength {
+		t.Errorf("SetMaxTitleLength(0) = %v, MaxTitleLength = %d; want the default", err, MaxTitleLength)
+	}
+}
+
 func TestStatusColor(t *testing.T) {
 	tests := []struct {
 		status Status
@@ -275,6 +505,7 @@ func TestParseRelationType(t *testing.T) {
 		{"relates_to", RelationRelatesTo, false},
 		{"relates-to", RelationRelatesTo, false},
 		{"duplicates", RelationDuplicates, false},
+		{"supersedes", RelationSupersedes, false},
 		{"invalid", "", true},
 	}
 
@@ -299,6 +530,7 @@ func TestRelationTypeInverse(t *testing.T) {
 		{RelationDependsOn, "dependency_of"},
 		{RelationRelatesTo, "relates_to"},
 		{RelationDuplicates, "duplicate_of"},
+		{RelationSupersedes, "superseded_by"},
 	}
 
 	for _, tt := range tests {
@@ -309,19 +541,22 @@ func TestRelationTypeInverse(t *testing.T) {
 }
 
 func TestIssueJSONRoundTrip(t *testing.T) {
-	parentID := 1
+	parentID, sortOrder := 1, 3
 	now := time.Date(2026, 2, 13, 12, 0, 0, 0, time.UTC)
 	issue := Issue{
-		ID:          5,
-		ParentID:    &parentID,
-		Title:       "Fix the bug",
-		Description: "Something is broken",
-		Status:      StatusInProgress,
-		Priority:    PriorityHigh,
-		Kind:        IssueKindBug,
-		Assignee:    "alice",
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:           5,
+		ParentID:     &parentID,
+		Title:        "Fix the bug",
+		Description:  "Something is broken",
+		Status:       StatusInProgress,
+		Priority:     PriorityHigh,
+		Kind:         IssueKindBug,
+		Assignee:     "alice",
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		StartedAt:    now,
+		SnoozedUntil: now.Add(24 * time.Hour),
+		SortOrder:    &sortOrder,
 	}
 
 	data, err := json.Marshal(issue)
@@ -341,6 +576,15 @@ func TestIssueJSONRoundTrip(t *testing.T) {
 	if raw["status"] != "in-progress" {
 		t.Errorf("JSON status = %v, want %q", raw["status"], "in-progress")
 	}
+	if raw["started_at"] != "2026-02-13T12:00:00Z" {
+		t.Errorf("JSON started_at = %v, want %q", raw["started_at"], "2026-02-13T12:00:00Z")
+	}
+	if _, ok := raw["completed_at"]; ok {
+		t.Errorf("JSON completed_at = %v, want omitted", raw["completed_at"])
+	}
+	if raw["snoozed_until"] != "2026-02-14T12:00:00Z" {
+		t.Errorf("JSON snoozed_until = %v, want %q", raw["snoozed_until"], "2026-02-14T12:00:00Z")
+	}
 
 	// Unmarshal back
 	var issue2 Issue
@@ -356,6 +600,15 @@ func TestIssueJSONRoundTrip(t *testing.T) {
 	if issue2.Status != StatusInProgress {
 		t.Errorf("Unmarshaled Status = %q, want %q", issue2.Status, StatusInProgress)
 	}
+	if !issue2.StartedAt.Equal(now) || !issue2.CompletedAt.IsZero() {
+		t.Errorf("Unmarshaled StartedAt = %v, CompletedAt = %v", issue2.StartedAt, issue2.CompletedAt)
+	}
+	if !issue2.SnoozedUntil.Equal(now.Add(24 * time.Hour)) {
+		t.Errorf("Unmarshaled SnoozedUntil = %v, want %v", issue2.SnoozedUntil, now.Add(24*time.Hour))
+	}
+	if issue2.SortOrder == nil || *issue2.SortOrder != 3 {
+		t.Errorf("Unmarshaled SortOrder = %v, want 3", issue2.SortOrder)
+	}
 }
 
 func TestIssueJSONNoParent(t *testing.T) {
@@ -508,3 +761,59 @@ func TestIssueRef_MarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestExtractIssueRefs(t *testing.T) {
+	got := ExtractIssueRefs("dup of DKT-12; see dkt-3 and DKT-12 again, not XDKT-4 or DKT-0 or DKT-5x")
+	want := []int{12, 3}
+	if len(got) != len(want) {
+		t.Fatalf("ExtractIssueRefs = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ExtractIssueRefs[%d] = %d, want %d", i, got[i], want[i])
+		}
+	}
+}
+
+func TestIssueTemplateApplyTitle(t *testing.T) {
+	tests := []struct {
+		pattern, title, want string
+	}{
+		{"Bug: {title}", "crash", "Bug: crash"},
+		{"Weekly sync", "", "Weekly sync"},
+		{"Weekly sync", "explicit", "explicit"},
+		{"", "plain", "plain"},
+	}
+	for _, tt := range tests {
+		tpl := IssueTemplate{TitlePattern: tt.pattern}
+		if got := tpl.ApplyTitle(tt.title); got != tt.want {
+			t.Errorf("ApplyTitle(%q) with pattern %q = %q, want %q", tt.title, tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestValidateLinkURL(t *testing.T) {
+	valid := []string{
+		"https://example.com",
+		"http://example.com/pr/12?x=1",
+	}
+	for _, s := range valid {
+		if err := ValidateLinkURL(s); err != nil {
+			t.Errorf("ValidateLinkURL(%q) = %v, want nil", s, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"example.com",
+		"not a url",
+		"ftp://example.com/file",
+		"https://",
+		"/relative/path",
+	}
+	for _, s := range invalid {
+		if err := ValidateLinkURL(s); err == nil {
+			t.Errorf("ValidateLinkURL(%q) = nil, want error", s)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// NotificationKind is the event a notification reports.
+type NotificationKind string
+
+const (
+	// NotificationAssigned: the recipient was made the issue's assignee.
+	NotificationAssigned NotificationKind = "assigned"
+	// NotificationMentioned: the recipient was @mentioned in the issue's
+	// description or a comment.
+	NotificationMentioned NotificationKind = "mentioned"
+	// NotificationStatusChanged: the status of an issue assigned to the
+	// recipient changed.
+	NotificationStatusChanged NotificationKind = "status_changed_on_owned"
+)
+
+// Notification is an inbox entry telling Recipient that Actor did something
+// to an issue that concerns them. IssueTitle and IssueStatus describe the
+// issue when the notification is listed. ReadAt is zero while unread.
+type Notification struct {
+	ID          int
+	Recipient   string
+	IssueID     int
+	Kind        NotificationKind
+	Actor       string
+	CreatedAt   time.Time
+	ReadAt      time.Time
+	IssueTitle  string
+	IssueStatus Status
+}
+
+// Unread reports whether the notification has not been marked read.
+func (n Notification) Unread() bool {
+	return n.ReadAt.IsZero()
+}
+
+// notificationJSON is the JSON wire format for Notification.
+type notificationJSON struct {
+	ID          int     `json:"id"`
+	Recipient   string  `json:"recipient"`
+	IssueID     string  `json:"issue_id"`
+	IssueTitle  string  `json:"issue_title"`
+	IssueStatus string  `json:"issue_status"`
+	Kind        string  `json:"kind"`
+	Actor       string  `json:"actor"`
+	CreatedAt   string  `json:"created_at"`
+	ReadAt      *string `json:"read_at"`
+}
+
+// MarshalJSON implements custom JSON serialization for Notification.
+func (n Notification) MarshalJSON() ([]byte, error) {
+	j := notificationJSON{
+		ID:          n.ID,
+		Recipient:   n.Recipient,
+		IssueID:     FormatID(n.IssueID),
+		IssueTitle:  n.IssueTitle,
+		IssueStatus: string(n.IssueStatus),
+		Kind:        string(n.Kind),
+		Actor:       n.Actor,
+		CreatedAt:   n.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if !n.ReadAt.IsZero() {
+		readAt := n.ReadAt.UTC().Format(time.RFC3339)
+		j.ReadAt = &readAt
+	}
+	return json.Marshal(j)
+}
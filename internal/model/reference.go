@@ -0,0 +1,104 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// ReferenceContext records where an issue reference was found.
+type ReferenceContext string
+
+const (
+	ReferenceContextDescription ReferenceContext = "description"
+	ReferenceContextComment     ReferenceContext = "comment"
+)
+
+// issueRefPattern matches issue IDs such as "DKT-12" or "dkt-12" in free text.
+// SetIDPrefix recompiles it for a configured prefix.
+var issueRefPattern = compileIssueRefPattern(IDPrefix)
+
+// compileIssueRefPattern returns the pattern matching issue IDs with prefix.
+func compileIssueRefPattern(prefix string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(prefix) + `-(\d+)\b`)
+}
+
+// ExtractIssueRefs returns the distinct issue IDs mentioned in text, in order
+// of first appearance.
+func ExtractIssueRefs(text string) []int {
+	var ids []int
+	seen := make(map[int]bool)
+	for _, m := range issueRefPattern.FindAllStringSubmatch(text, -1) {
+		id, err := strconv.Atoi(m[1])
+		if err != nil || id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// IssueReference is an informal mention of one issue in another issue's
+// description or comments. Unlike a Relation it is derived from text and
+// maintained automatically.
+type IssueReference struct {
+	FromIssueID int
+	ToIssueID   int
+	Context     ReferenceContext
+	CommentID   *int
+	CreatedAt   time.Time
+}
+
+// issueReferenceJSON is the JSON wire format for IssueReference.
+type issueReferenceJSON struct {
+	FromIssueID string `json:"from_issue_id"`
+	ToIssueID   string `json:"to_issue_id"`
+	Context     string `json:"context"`
+	CommentID   *int   `json:"comment_id,omitempty"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// MarshalJSON implements custom JSON serialization for IssueReference.
+func (r IssueReference) MarshalJSON() ([]byte, error) {
+	return json.Marshal(issueReferenceJSON{
+		FromIssueID: FormatID(r.FromIssueID),
+		ToIssueID:   FormatID(r.ToIssueID),
+		Context:     string(r.Context),
+		CommentID:   r.CommentID,
+		CreatedAt:   r.CreatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// UnmarshalJSON implements custom JSON deserialization for IssueReference.
+func (r *IssueReference) UnmarshalJSON(data []byte) error {
+	var j issueReferenceJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	fromID, err := ParseID(j.FromIssueID)
+	if err != nil {
+		return fmt.Errorf("parsing from issue id: %w", err)
+	}
+	r.FromIssueID = fromID
+
+	toID, err := ParseID(j.ToIssueID)
+	if err != nil {
+		return fmt.Errorf("parsing to issue id: %w", err)
+	}
+	r.ToIssueID = toID
+
+	r.Context = ReferenceContext(j.Context)
+	r.CommentID = j.CommentID
+
+	createdAt, err := time.Parse(time.RFC3339, j.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("parsing created_at: %w", err)
+	}
+	r.CreatedAt = createdAt
+
+	return nil
+}
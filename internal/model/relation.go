@@ -3,6 +3,9 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 )
@@ -15,6 +18,7 @@ const (
 	RelationDependsOn  RelationType = "depends_on"
 	RelationRelatesTo  RelationType = "relates_to"
 	RelationDuplicates RelationType = "duplicates"
+	RelationSupersedes RelationType = "supersedes"
 )
 
 var validRelationTypes = []RelationType{
@@ -22,22 +26,124 @@ var validRelationTypes = []RelationType{
 	RelationDependsOn,
 	RelationRelatesTo,
 	RelationDuplicates,
+	RelationSupersedes,
 }
 
-// ValidateRelationType returns an error if rt is not a recognized relation type.
-func ValidateRelationType(rt RelationType) error {
-	for _, v := range validRelationTypes {
-		if rt == v {
-			return nil
+// RelationTypeDef describes a relation type: the name shown from the target's
+// side, whether it forms a dependency graph that must stay acyclic, and the
+// color it is rendered in. The built-in types are always defined; custom
+// types are registered with SetCustomRelationTypes.
+type RelationTypeDef struct {
+	Name        RelationType
+	Inverse     string
+	Directional bool
+	Color       string
+	Builtin     bool
+}
+
+// relationTypeDefJSON is the JSON wire format for RelationTypeDef.
+type relationTypeDefJSON struct {
+	Name        string `json:"name"`
+	Inverse     string `json:"inverse"`
+	Directional bool   `json:"directional"`
+	Color       string `json:"color"`
+	Builtin     bool   `json:"builtin,omitempty"`
+}
+
+// MarshalJSON implements custom JSON serialization for RelationTypeDef.
+func (d RelationTypeDef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(relationTypeDefJSON{
+		Name:        string(d.Name),
+		Inverse:     d.Inverse,
+		Directional: d.Directional,
+		Color:       d.Color,
+		Builtin:     d.Builtin,
+	})
+}
+
+// UnmarshalJSON implements custom JSON deserialization for RelationTypeDef.
+func (d *RelationTypeDef) UnmarshalJSON(data []byte) error {
+	var j relationTypeDefJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*d = RelationTypeDef{
+		Name:        NormalizeRelationType(j.Name),
+		Inverse:     string(NormalizeRelationType(j.Inverse)),
+		Directional: j.Directional,
+		Color:       j.Color,
+		Builtin:     j.Builtin,
+	}
+	return nil
+}
+
+// customRelationTypes holds the custom relation types registered with
+// SetCustomRelationTypes, keyed by name.
+var customRelationTypes = map[RelationType]RelationTypeDef{}
+
+// SetCustomRelationTypes replaces the registered custom relation types with
+// defs. Built-in types in defs are ignored: they are always defined.
+func SetCustomRelationTypes(defs []RelationTypeDef) {
+	customRelationTypes = make(map[RelationType]RelationTypeDef, len(defs))
+	for _, d := range defs {
+		if d.Builtin || slices.Contains(validRelationTypes, d.Name) {
+			continue
 		}
+		customRelationTypes[d.Name] = d
 	}
-	return fmt.Errorf("invalid relation type %q: must be one of %v", rt, validRelationTypes)
+}
+
+// LookupCustomRelationType returns the registered custom relation type rt.
+func LookupCustomRelationType(rt RelationType) (RelationTypeDef, bool) {
+	d, ok := customRelationTypes[rt]
+	return d, ok
+}
+
+// relationTypeNamePattern matches valid relation type names once normalized:
+// lowercase words joined by underscores.
+var relationTypeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+// ValidateRelationTypeName returns an error if name cannot name a relation
+// type. Names are lowercase letters and digits, with words joined by
+// underscores (hyphens are accepted and normalized by NormalizeRelationType).
+func ValidateRelationTypeName(name RelationType) error {
+	if !relationTypeNamePattern.MatchString(string(name)) {
+		return fmt.Errorf("invalid relation type name %q: use lowercase letters and digits, with words joined by - or _", name)
+	}
+	return nil
+}
+
+// IsBuiltinRelationType reports whether rt is one of the built-in types.
+func IsBuiltinRelationType(rt RelationType) bool {
+	return slices.Contains(validRelationTypes, rt)
+}
+
+// ValidateRelationType returns an error if rt is neither a built-in relation
+// type nor a registered custom one.
+func ValidateRelationType(rt RelationType) error {
+	if IsBuiltinRelationType(rt) {
+		return nil
+	}
+	if _, ok := customRelationTypes[rt]; ok {
+		return nil
+	}
+	names := slices.Clone(validRelationTypes)
+	for _, name := range slices.Sorted(maps.Keys(customRelationTypes)) {
+		names = append(names, name)
+	}
+	return fmt.Errorf("invalid relation type %q: must be one of %v", rt, names)
+}
+
+// NormalizeRelationType returns the canonical form of a relation type name:
+// trimmed, lowercased, and with hyphens replaced by underscores.
+func NormalizeRelationType(input string) RelationType {
+	return RelationType(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(input)), "-", "_"))
 }
 
 // ParseRelationType accepts both hyphenated ("depends-on") and underscored ("depends_on")
 // forms and returns the canonical underscored RelationType.
 func ParseRelationType(input string) (RelationType, error) {
-	normalized := RelationType(strings.ReplaceAll(strings.TrimSpace(input), "-", "_"))
+	normalized := NormalizeRelationType(input)
 	if err := ValidateRelationType(normalized); err != nil {
 		return "", err
 	}
@@ -46,7 +152,8 @@ func ParseRelationType(input string) (RelationType, error) {
 
 // Inverse returns the display name for the inverse direction of a relation.
 // For example, "blocks" returns "blocked_by" and "depends_on" returns "dependency_of".
-// Symmetric relations ("relates_to") return themselves.
+// Symmetric relations ("relates_to") return themselves, as do unregistered
+// types.
 func (rt RelationType) Inverse() string {
 	switch rt {
 	case RelationBlocks:
@@ -57,27 +164,48 @@ func (rt RelationType) Inverse() string {
 		return "relates_to"
 	case RelationDuplicates:
 		return "duplicate_of"
-	default:
-		return string(rt)
+	case RelationSupersedes:
+		return "superseded_by"
+	}
+	if d, ok := customRelationTypes[rt]; ok && d.Inverse != "" {
+		return d.Inverse
+	}
+	return string(rt)
+}
+
+// IsDirectional reports whether relations of type rt form a dependency graph
+// that cycle detection keeps acyclic: blocks, depends_on, supersedes, and
+// custom types registered as directional.
+func (rt RelationType) IsDirectional() bool {
+	switch rt {
+	case RelationBlocks, RelationDependsOn, RelationSupersedes:
+		return true
+	case RelationRelatesTo, RelationDuplicates:
+		return false
 	}
+	return customRelationTypes[rt].Directional
 }
 
-// Relation represents a relationship between two issues.
+// Relation represents a relationship between two issues. Note is an
+// optional free-text annotation, such as "hard blocker", and is empty when
+// unset.
 type Relation struct {
 	ID            int
 	SourceIssueID int
 	TargetIssueID int
 	RelationType  RelationType
+	Note          string
 	CreatedAt     time.Time
 }
 
 // relationJSON is the JSON wire format for Relation.
 type relationJSON struct {
-	ID            int    `json:"id"`
-	SourceIssueID string `json:"source_issue_id"`
-	TargetIssueID string `json:"target_issue_id"`
-	RelationType  string `json:"relation_type"`
-	CreatedAt     string `json:"created_at"`
+	ID            int           `json:"id"`
+	SourceIssueID string        `json:"source_issue_id"`
+	TargetIssueID string        `json:"target_issue_id"`
+	RelationType  string        `json:"relation_type"`
+	Note          string        `json:"note,omitempty"`
+	CreatedAt     timestampJSON `json:"created_at"`
 }
 
 // MarshalJSON implements custom JSON serialization for Relation.
@@ -87,7 +215,8 @@ func (r Relation) MarshalJSON() ([]byte, error) {
 		SourceIssueID: FormatID(r.SourceIssueID),
 		TargetIssueID: FormatID(r.TargetIssueID),
 		RelationType:  string(r.RelationType),
-		CreatedAt:     r.CreatedAt.UTC().Format(time.RFC3339),
+		Note:          r.Note,
+		CreatedAt:     formatTimestamp(r.CreatedAt),
 	})
 }
 
@@ -112,17 +241,14 @@ func (r *Relation) UnmarshalJSON(data []byte) error {
 	}
 	r.TargetIssueID = targetID
 
-	rt, err := ParseRelationType(j.RelationType)
-	if err != nil {
-		return err
-	}
-	r.RelationType = rt
+	// The type is validated by the importer, which knows the custom types
+	// an export file declares.
+	r.RelationType = NormalizeRelationType(j.RelationType)
+	r.Note = j.Note
 
-	createdAt, err := time.Parse(time.RFC3339, j.CreatedAt)
-	if err != nil {
+	if r.CreatedAt, err = ParseTimestamp(string(j.CreatedAt)); err != nil {
 		return fmt.Errorf("parsing created_at: %w", err)
 	}
-	r.CreatedAt = createdAt
 
 	return nil
 }
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxSlugLen is the longest slug Slug returns, ID included, unless the ID
+// alone is longer.
+const maxSlugLen = 50
+
+// slugFolds spells out letters that do not decompose into an ASCII letter
+// plus combining marks.
+var slugFolds = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d",
+	'þ': "th", 'ł': "l", 'ı': "i", 'ħ': "h", 'ŧ': "t",
+}
+
+// Slug returns a short name for issue that is safe to use as a git branch,
+// e.g. "dkt-42-fix-login-timeout": the lowercased ID followed by the title
+// folded to ASCII, with every run of other characters turned into a single
+// dash. Words are dropped from the end to keep it within 50 characters. A
+// title with nothing usable, such as only punctuation or emoji, gives the ID
+// alone. The ID keeps slugs unique across issues.
+func Slug(issue *Issue) string {
+	id := strings.ToLower(FormatID(issue.ID))
+
+	var words []string
+	var word strings.Builder
+	endWord := func() {
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range norm.NFD.String(strings.ToLower(issue.Title)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Combining marks left over from decomposing "é" into "e".
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			word.WriteRune(r)
+		case slugFolds[r] != "":
+			word.WriteString(slugFolds[r])
+		default:
+			endWord()
+		}
+	}
+	endWord()
+
+	slug := id
+	for _, w := range words {
+		if len(slug)+1+len(w) > maxSlugLen {
+			break
+		}
+		slug += "-" + w
+	}
+	return slug
+}
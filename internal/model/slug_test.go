@@ -0,0 +1,59 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{"simple", "Fix login timeout", "dkt-42-fix-login-timeout"},
+		{"punctuation collapses", "Fix: login -- timeout (again)!", "dkt-42-fix-login-timeout-again"},
+		{"surrounding space", "   padded title   ", "dkt-42-padded-title"},
+		{"digits kept", "Bump Go to 1.26", "dkt-42-bump-go-to-1-26"},
+		{"accents folded", "Café crème brûlée", "dkt-42-cafe-creme-brulee"},
+		{"special letters", "Straße Øresund Łódź", "dkt-42-strasse-oresund-lodz"},
+		{"unfoldable script dropped", "修复 login バグ", "dkt-42-login"},
+		{"emoji dropped", "🚀 Ship it 🎉", "dkt-42-ship-it"},
+		{"all punctuation", "!!! ??? ...", "dkt-42"},
+		{"only emoji", "🔥🔥🔥", "dkt-42"},
+		{"empty", "", "dkt-42"},
+		{"git-unsafe characters", "refs/heads/~main^:*?[x]@{y}\\..lock", "dkt-42-refs-heads-main-x-y-lock"},
+		{
+			"truncated at a word",
+			"Refactor the database migration framework to support rollbacks",
+			"dkt-42-refactor-the-database-migration-framework",
+		},
+		{
+			"long word dropped whole",
+			"Use " + strings.Repeat("x", 60),
+			"dkt-42-use",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Slug(&Issue{ID: 42, Title: tt.title})
+			if got != tt.want {
+				t.Errorf("Slug(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlug_BranchSafe(t *testing.T) {
+	safe := regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	for _, title := range []string{
+		"Ünïcödé everywhere", "tab\tand\nnewline", "a/b\\c", "-leading and trailing-",
+		strings.Repeat("word ", 30), "日本語のタイトル", "x.lock", "..",
+	} {
+		got := Slug(&Issue{ID: 7, Title: title})
+		if !safe.MatchString(got) || len(got) > maxSlugLen {
+			t.Errorf("Slug(%q) = %q: not a safe branch name within %d characters", title, got, maxSlugLen)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// TitlePlaceholder is replaced by the user-supplied title when a template's
+// title pattern is applied, e.g. "Bug: {title}".
+const TitlePlaceholder = "{title}"
+
+// IssueTemplate holds reusable defaults for creating issues of a recurring
+// shape, such as bug reports or RFCs.
+type IssueTemplate struct {
+	Name         string    `json:"name"`
+	TitlePattern string    `json:"title_pattern"`
+	Description  string    `json:"description"`
+	Kind         IssueKind `json:"kind"`
+	Priority     Priority  `json:"priority"`
+	Labels       []string  `json:"labels"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// HasTitlePlaceholder reports whether the title pattern expects a title to
+// be substituted into it.
+func (t *IssueTemplate) HasTitlePlaceholder() bool {
+	return strings.Contains(t.TitlePattern, TitlePlaceholder)
+}
+
+// ApplyTitle returns the issue title produced by the template. A pattern with
+// a placeholder wraps title; a fixed pattern is used only when title is empty.
+func (t *IssueTemplate) ApplyTitle(title string) string {
+	switch {
+	case t.HasTitlePlaceholder():
+		return strings.ReplaceAll(t.TitlePattern, TitlePlaceholder, title)
+	case title == "":
+		return t.TitlePattern
+	default:
+		return title
+	}
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timestampLayouts are the text layouts ParseTimestamp accepts, tried in
+// order. Layouts without a zone are read as UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	time.DateTime,
+	"2006-01-02T15:04:05",
+	time.DateOnly,
+}
+
+// ParseTimestamp parses a timestamp as written by hand or by other tools:
+// RFC 3339 (with or without fractional seconds), "2006-01-02 15:04:05",
+// "2006-01-02" or Unix seconds. The result is in UTC with fractional seconds
+// dropped, so formatting it as RFC 3339 normalizes it.
+func ParseTimestamp(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if secs, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(secs, 0).UTC(), nil
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC().Truncate(time.Second), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp %q: use RFC 3339, YYYY-MM-DD [HH:MM:SS] or Unix seconds", s)
+}
+
+// ParseTimestampJSON parses a JSON timestamp value, either a string that
+// ParseTimestamp accepts or a number of Unix seconds.
+func ParseTimestampJSON(data []byte) (time.Time, error) {
+	var ts timestampJSON
+	if err := ts.UnmarshalJSON(data); err != nil {
+		return time.Time{}, err
+	}
+	return ParseTimestamp(string(ts))
+}
+
+// timestampJSON is a timestamp in the JSON wire format. It is written as an
+// RFC 3339 string and read from a string or a JSON number, leaving the
+// parsing to ParseTimestamp.
+type timestampJSON string
+
+// UnmarshalJSON implements json.Unmarshaler for timestampJSON.
+func (t *timestampJSON) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*t = timestampJSON(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("timestamp must be a string or a number, got %s", data)
+	}
+	*t = timestampJSON(n)
+	return nil
+}
+
+// formatTimestamp returns t as an RFC 3339 timestampJSON in UTC.
+func formatTimestamp(t time.Time) timestampJSON {
+	return timestampJSON(t.UTC().Format(time.RFC3339))
+}
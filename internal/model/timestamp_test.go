@@ -0,0 +1,54 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	ten := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2026-01-05T10:00:00Z", ten},
+		{"2026-01-05T12:00:00+02:00", ten},
+		{"2026-01-05T10:00:00.999999999Z", ten},
+		{"2026-01-05 10:00:00", ten},
+		{"2026-01-05T10:00:00", ten},
+		{"2026-01-05", time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"1767607200", ten},
+		{" 1767607200 ", ten},
+	}
+	for _, tt := range tests {
+		got, err := ParseTimestamp(tt.in)
+		if err != nil {
+			t.Errorf("ParseTimestamp(%q): %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) || got.Location() != time.UTC {
+			t.Errorf("ParseTimestamp(%q) = %v, want %v in UTC", tt.in, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "yesterday", "05/01/2026", "2026-13-01"} {
+		if _, err := ParseTimestamp(bad); err == nil {
+			t.Errorf("ParseTimestamp(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestParseTimestampJSON(t *testing.T) {
+	ten := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	for _, in := range []string{`"2026-01-05 10:00:00"`, `1767607200`} {
+		got, err := ParseTimestampJSON([]byte(in))
+		if err != nil || !got.Equal(ten) {
+			t.Errorf("ParseTimestampJSON(%s) = %v, %v; want %v", in, got, err, ten)
+		}
+	}
+	for _, bad := range []string{`true`, `{}`, `1.5`} {
+		if _, err := ParseTimestampJSON([]byte(bad)); err == nil {
+			t.Errorf("ParseTimestampJSON(%s) succeeded, want an error", bad)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TrashedIssue is an issue in the trash and the time it was deleted.
+type TrashedIssue struct {
+	*Issue
+	DeletedAt time.Time
+}
+
+// trashedIssueJSON is the JSON wire format for TrashedIssue.
+type trashedIssueJSON struct {
+	ID        string  `json:"id"`
+	ParentID  *string `json:"parent_id,omitempty"`
+	Title     string  `json:"title"`
+	Status    string  `json:"status"`
+	Priority  string  `json:"priority"`
+	Kind      string  `json:"kind"`
+	DeletedAt string  `json:"deleted_at"`
+}
+
+// MarshalJSON implements custom JSON serialization for TrashedIssue.
+func (t TrashedIssue) MarshalJSON() ([]byte, error) {
+	j := trashedIssueJSON{
+		ID:        FormatID(t.ID),
+		Title:     t.Title,
+		Status:    string(t.Status),
+		Priority:  string(t.Priority),
+		Kind:      string(t.Kind),
+		DeletedAt: t.DeletedAt.UTC().Format(time.RFC3339),
+	}
+	if t.ParentID != nil {
+		pid := FormatID(*t.ParentID)
+		j.ParentID = &pid
+	}
+	return json.Marshal(j)
+}
@@ -14,6 +14,7 @@ const (
 	ErrNotFound   ErrorCode = "NOT_FOUND"
 	ErrValidation ErrorCode = "VALIDATION_ERROR"
 	ErrConflict   ErrorCode = "CONFLICT"
+	ErrBusy       ErrorCode = "BUSY"
 )
 
 // Exit code constants.
@@ -23,6 +24,7 @@ const (
 	ExitNotFound   = 2
 	ExitValidation = 3
 	ExitConflict   = 4
+	ExitBusy       = 5
 )
 
 // ExitCodeForError maps an ErrorCode to its corresponding exit code.
@@ -34,6 +36,8 @@ func ExitCodeForError(code ErrorCode) int {
 		return ExitValidation
 	case ErrConflict:
 		return ExitConflict
+	case ErrBusy:
+		return ExitBusy
 	default:
 		return ExitGeneral
 	}
@@ -51,6 +55,7 @@ type errorEnvelope struct {
 	OK    bool      `json:"ok"`
 	Error string    `json:"error"`
 	Code  ErrorCode `json:"code"`
+	Data  any       `json:"data,omitempty"`
 }
 
 // writeJSONSuccess writes a success envelope to w.
@@ -66,11 +71,18 @@ func writeJSONSuccess(w io.Writer, data any, message string) {
 
 // writeJSONError writes an error envelope to w.
 func writeJSONError(w io.Writer, err error, code ErrorCode) {
+	writeJSONErrorData(w, err, code, nil)
+}
+
+// writeJSONErrorData writes an error envelope to w with structured data
+// describing the error. A nil data omits the field.
+func writeJSONErrorData(w io.Writer, err error, code ErrorCode, data any) {
 	enc := json.NewEncoder(w)
 	enc.SetEscapeHTML(false)
 	enc.Encode(errorEnvelope{
 		OK:    false,
 		Error: err.Error(),
 		Code:  code,
+		Data:  data,
 	})
 }
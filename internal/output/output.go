@@ -46,8 +46,14 @@ func (w *Writer) Success(data any, message string) {
 // with an "Error: " prefix. The corresponding exit code is returned so the
 // caller can pass it to os.Exit.
 func (w *Writer) Error(err error, code ErrorCode) int {
+	return w.ErrorWithData(err, code, nil)
+}
+
+// ErrorWithData is like Error but attaches data to the JSON error envelope so
+// callers can act on the details of the failure. Human output is unchanged.
+func (w *Writer) ErrorWithData(err error, code ErrorCode, data any) int {
 	if w.JSONMode {
-		writeJSONError(w.Stdout, err, code)
+		writeJSONErrorData(w.Stdout, err, code, data)
 	} else {
 		writeHumanError(w.Stderr, err)
 	}
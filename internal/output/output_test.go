@@ -87,6 +87,37 @@ func TestWriterErrorJSON(t *testing.T) {
 	}
 }
 
+func TestWriterErrorWithDataJSON(t *testing.T) {
+	var stdout bytes.Buffer
+	w := &Writer{JSONMode: true, Stdout: &stdout, Stderr: &bytes.Buffer{}}
+
+	code := w.ErrorWithData(errors.New("already exists"), ErrConflict, map[string]int{"id": 14})
+	if code != ExitConflict {
+		t.Errorf("exit code = %d, want %d", code, ExitConflict)
+	}
+
+	var env struct {
+		Code ErrorCode      `json:"code"`
+		Data map[string]int `json:"data"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &env); err != nil {
+		t.Fatalf("failed to unmarshal error envelope: %v", err)
+	}
+	if env.Code != ErrConflict || env.Data["id"] != 14 {
+		t.Errorf("envelope = %+v, want CONFLICT with data.id 14", env)
+	}
+}
+
+func TestWriterErrorJSONOmitsData(t *testing.T) {
+	var stdout bytes.Buffer
+	w := &Writer{JSONMode: true, Stdout: &stdout, Stderr: &bytes.Buffer{}}
+
+	w.Error(errors.New("boom"), ErrGeneral)
+	if bytes.Contains(stdout.Bytes(), []byte(`"data"`)) {
+		t.Errorf("error envelope without data should omit the field: %s", stdout.String())
+	}
+}
+
 func TestWriterErrorHuman(t *testing.T) {
 	t.Setenv("NO_COLOR", "1")
 
@@ -143,6 +174,7 @@ func TestExitCodeForErrorMapping(t *testing.T) {
 		{ErrNotFound, ExitNotFound},
 		{ErrValidation, ExitValidation},
 		{ErrConflict, ExitConflict},
+		{ErrBusy, ExitBusy},
 		{ErrorCode("unknown"), ExitGeneral},
 	}
 
@@ -39,18 +39,8 @@ func BuildDAG(issues []*model.Issue, relations []model.Relation) *DAG {
 	}
 
 	for _, rel := range relations {
-		var fromID, toID int
-
-		switch rel.RelationType {
-		case model.RelationBlocks:
-			// source blocks target: edge from source (blocker) to target (blocked)
-			fromID = rel.SourceIssueID
-			toID = rel.TargetIssueID
-		case model.RelationDependsOn:
-			// source depends_on target: edge from target (blocker) to source (blocked)
-			fromID = rel.TargetIssueID
-			toID = rel.SourceIssueID
-		default:
+		fromID, toID, ok := BlockingEdge(rel)
+		if !ok {
 			continue
 		}
 
@@ -67,6 +57,19 @@ func BuildDAG(issues []*model.Issue, relations []model.Relation) *DAG {
 	return dag
 }
 
+// BlockingEdge returns the blocker and blocked issue of a directional
+// relation: "A blocks B" gives (A, B) and "A depends_on B" gives (B, A). ok
+// is false for any other relation type.
+func BlockingEdge(rel model.Relation) (blocker, blocked int, ok bool) {
+	switch rel.RelationType {
+	case model.RelationBlocks:
+		return rel.SourceIssueID, rel.TargetIssueID, true
+	case model.RelationDependsOn:
+		return rel.TargetIssueID, rel.SourceIssueID, true
+	}
+	return 0, 0, false
+}
+
 // BuildAdjacency constructs forward and backward adjacency lists from relations.
 // forward[A] contains IDs that A blocks (downstream). backward[A] contains IDs
 // that block A (upstream). Relations are normalized so both "blocks" and
@@ -76,14 +79,9 @@ func BuildAdjacency(relations []model.Relation) (forward, backward map[int][]int
 	backward = make(map[int][]int)
 
 	for _, rel := range relations {
-		switch rel.RelationType {
-		case model.RelationBlocks:
-			forward[rel.SourceIssueID] = append(forward[rel.SourceIssueID], rel.TargetIssueID)
-			backward[rel.TargetIssueID] = append(backward[rel.TargetIssueID], rel.SourceIssueID)
-		case model.RelationDependsOn:
-			// "A depends_on B" means "B blocks A"
-			forward[rel.TargetIssueID] = append(forward[rel.TargetIssueID], rel.SourceIssueID)
-			backward[rel.SourceIssueID] = append(backward[rel.SourceIssueID], rel.TargetIssueID)
+		if from, to, ok := BlockingEdge(rel); ok {
+			forward[from] = append(forward[from], to)
+			backward[to] = append(backward[to], from)
 		}
 	}
 
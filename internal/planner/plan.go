@@ -1,6 +1,7 @@
 package planner
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/ALT-F4-LLC/docket/internal/filter"
@@ -19,6 +20,12 @@ type Plan struct {
 	TotalIssues    int
 	TotalPhases    int
 	MaxParallelism int
+	// Context holds the IDs of issues included only because they block an
+	// issue matching PlanFilters.Assignees. It is nil without that filter.
+	Context map[int]struct{}
+	// Pinned maps the planned issues pinned past phase 1 by
+	// PlanFilters.PinnedPhase to their pins. It is nil without such pins.
+	Pinned map[int]int
 }
 
 // PlanFilters controls which issues are included in the generated plan.
@@ -26,12 +33,35 @@ type PlanFilters struct {
 	Statuses []string
 	Labels   []string
 	RootID   *int
+	// Assignees limits the plan to issues assigned to any of these people.
+	// Open issues that block a matching issue, directly or transitively, are
+	// kept as context regardless of the other filters.
+	Assignees []string
+	// PinnedPhase maps issue IDs to the earliest phase, counting from 1,
+	// they may be planned in. Issues they block move after them. Pins on
+	// issues outside the plan are ignored.
+	PinnedPhase map[int]int
+}
+
+// PinConflictError is returned when an issue is pinned to a phase earlier
+// than its blockers allow.
+type PinConflictError struct {
+	ID        int // the pinned issue
+	Pin       int // the phase it is pinned to
+	BlockerID int // the blocker that keeps it out of that phase
+	Earliest  int // the earliest phase the blocker allows
+}
+
+func (e *PinConflictError) Error() string {
+	return fmt.Sprintf("%s is pinned to phase %d, but it depends on %s and cannot be planned before phase %d",
+		model.FormatID(e.ID), e.Pin, model.FormatID(e.BlockerID), e.Earliest)
 }
 
 // GeneratePlan builds an execution plan from the DAG. It uses topological
 // level grouping to create phases: phase 1 contains issues with no blockers,
 // phase N contains issues whose blockers are all in earlier phases. Issues
-// already done are skipped, and optional status/label/root filters are applied.
+// already done are skipped, and optional status/label/root/assignee filters
+// are applied.
 func GeneratePlan(dag *DAG, filters PlanFilters) (*Plan, error) {
 	// When RootID is set, scope the DAG to the root and its descendants.
 	if filters.RootID != nil {
@@ -41,58 +71,78 @@ func GeneratePlan(dag *DAG, filters PlanFilters) (*Plan, error) {
 	// Build filter sets for O(1) lookup.
 	statusSet := filter.ToStringSet(filters.Statuses)
 	labelSet := filter.ToStringSet(filters.Labels)
+	assigneeSet := filter.ToStringSet(filters.Assignees)
 
 	levels, err := TopoSort(dag)
 	if err != nil {
 		return nil, err
 	}
+	if levels, err = pinLevels(dag, levels, filters.PinnedPhase); err != nil {
+		return nil, err
+	}
 
 	plan := &Plan{}
 
-	for _, level := range levels {
-		var phaseIssues []*model.Issue
-		for _, id := range level {
-			node, ok := dag.Nodes[id]
-			if !ok {
-				continue
+	include := make(map[int]struct{})
+	for id, node := range dag.Nodes {
+		if matchesPlanFilters(node.Issue, statusSet, labelSet, assigneeSet) {
+			include[id] = struct{}{}
+		}
+	}
+	if len(assigneeSet) > 0 {
+		plan.Context = openBlockersOf(dag, include)
+		for id := range plan.Context {
+			include[id] = struct{}{}
+		}
+	}
+
+	for id, pin := range filters.PinnedPhase {
+		if _, ok := include[id]; ok && pin > 1 {
+			if plan.Pinned == nil {
+				plan.Pinned = make(map[int]int)
 			}
-			issue := node.Issue
+			plan.Pinned[id] = pin
+		}
+	}
 
-			// Skip done issues.
-			if issue.Status == model.StatusDone {
-				continue
+	for _, level := range levels {
+		var pending []*model.Issue
+		for _, id := range level {
+			if _, ok := include[id]; ok {
+				pending = append(pending, dag.Nodes[id].Issue)
 			}
+		}
 
-			// Apply status filter.
-			if len(statusSet) > 0 {
-				if _, ok := statusSet[string(issue.Status)]; !ok {
-					continue
+		for len(pending) > 0 {
+			// Issues pinned past the next phase wait for a later one. When
+			// every issue waits, the phase is left empty to hold them back.
+			next := len(plan.Phases) + 1
+			var phaseIssues, waiting []*model.Issue
+			for _, issue := range pending {
+				if plan.Pinned[issue.ID] > next {
+					waiting = append(waiting, issue)
+				} else {
+					phaseIssues = append(phaseIssues, issue)
 				}
 			}
-
-			// Apply label filter (AND logic: issue must have all labels).
-			if len(labelSet) > 0 && !filter.HasAllLabels(issue, labelSet) {
+			pending = waiting
+			if len(phaseIssues) == 0 {
+				plan.Phases = append(plan.Phases, Phase{Number: next})
 				continue
 			}
 
-			phaseIssues = append(phaseIssues, issue)
-		}
-
-		if len(phaseIssues) == 0 {
-			continue
-		}
-
-		sortIssues(phaseIssues)
-
-		// Split the phase by file collisions. Issues that touch the same
-		// file(s) are placed in separate sub-phases so no two concurrent
-		// issues modify the same file.
-		subPhases := splitByFileCollision(phaseIssues)
-		for _, sp := range subPhases {
-			plan.Phases = append(plan.Phases, Phase{
-				Number: len(plan.Phases) + 1,
-				Issues: sp,
-			})
+			sortIssues(phaseIssues)
+
+			// Split the phase by file collisions. Issues that touch the same
+			// file(s) are placed in separate sub-phases so no two concurrent
+			// issues modify the same file.
+			subPhases := splitByFileCollision(phaseIssues)
+			for _, sp := range subPhases {
+				plan.Phases = append(plan.Phases, Phase{
+					Number: len(plan.Phases) + 1,
+					Issues: sp,
+				})
+			}
 		}
 	}
 
@@ -108,6 +158,37 @@ func GeneratePlan(dag *DAG, filters PlanFilters) (*Plan, error) {
 	return plan, nil
 }
 
+// AssigneeGroup is the issues of one phase assigned to one person.
+type AssigneeGroup struct {
+	// Assignee is empty for unassigned issues.
+	Assignee string
+	Issues   []*model.Issue
+}
+
+// GroupByAssignee splits issues by assignee, keeping their order within each
+// group. Groups are sorted by assignee with unassigned issues last.
+func GroupByAssignee(issues []*model.Issue) []AssigneeGroup {
+	var groups []AssigneeGroup
+	index := make(map[string]int)
+	for _, issue := range issues {
+		i, ok := index[issue.Assignee]
+		if !ok {
+			i = len(groups)
+			index[issue.Assignee] = i
+			groups = append(groups, AssigneeGroup{Assignee: issue.Assignee})
+		}
+		groups[i].Issues = append(groups[i].Issues, issue)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i].Assignee, groups[j].Assignee
+		if (a == "") != (b == "") {
+			return b == ""
+		}
+		return a < b
+	})
+	return groups
+}
+
 // FindReady returns issues that are work-ready: their status is in the
 // provided list (default: backlog, todo), all blockers are done, and they
 // have no children (leaf tasks only). Results are sorted by priority
@@ -163,8 +244,173 @@ func FindReady(dag *DAG, statuses []string) []*model.Issue {
 	return ready
 }
 
+// Unblocked returns the open issues that id blocks whose other blockers are
+// all done, i.e. the dependents that become work-ready once id is done. id
+// itself is treated as done regardless of its status in dag. Results are
+// sorted by ID.
+func Unblocked(dag *DAG, id int) []*model.Issue {
+	node, ok := dag.Nodes[id]
+	if !ok {
+		return nil
+	}
+
+	var unblocked []*model.Issue
+	for depID := range node.Forward {
+		dep := dag.Nodes[depID]
+		if dep.Issue.Status == model.StatusDone {
+			continue
+		}
+
+		allBlockersDone := true
+		for blockerID := range dep.Reverse {
+			if blockerID == id {
+				continue
+			}
+			if dag.Nodes[blockerID].Issue.Status != model.StatusDone {
+				allBlockersDone = false
+				break
+			}
+		}
+		if allBlockersDone {
+			unblocked = append(unblocked, dep.Issue)
+		}
+	}
+
+	sortByID(unblocked)
+	return unblocked
+}
+
+// OpenBlockers returns the issues blocking id that are not done, sorted by
+// ID.
+func OpenBlockers(dag *DAG, id int) []*model.Issue {
+	node, ok := dag.Nodes[id]
+	if !ok {
+		return nil
+	}
+
+	var open []*model.Issue
+	for blockerID := range node.Reverse {
+		blocker := dag.Nodes[blockerID].Issue
+		if blocker.Status != model.StatusDone {
+			open = append(open, blocker)
+		}
+	}
+
+	sortByID(open)
+	return open
+}
+
 // --- helpers ---
 
+// matchesPlanFilters reports whether issue is open and passes the status,
+// label (all required) and assignee filters. Empty sets match everything.
+func matchesPlanFilters(issue *model.Issue, statusSet, labelSet, assigneeSet map[string]struct{}) bool {
+	if issue.Status == model.StatusDone {
+		return false
+	}
+	if len(statusSet) > 0 {
+		if _, ok := statusSet[string(issue.Status)]; !ok {
+			return false
+		}
+	}
+	if len(labelSet) > 0 && !filter.HasAllLabels(issue, labelSet) {
+		return false
+	}
+	if len(assigneeSet) > 0 {
+		if _, ok := assigneeSet[issue.Assignee]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// pinLevels regroups topological levels so that every pinned issue is at
+// least at level pin-1, the level planned as phase pin when no level is
+// skipped, and every issue stays at a later level than its blockers. Without
+// pins the levels are returned as they are. It returns a PinConflictError
+// when a blocker already keeps a pinned issue past its pin, or an error for
+// a pin below 1.
+func pinLevels(dag *DAG, levels [][]int, pins map[int]int) ([][]int, error) {
+	if len(pins) == 0 {
+		return levels, nil
+	}
+	for _, id := range sortedKeys(pins) {
+		if pin := pins[id]; pin < 1 {
+			return nil, fmt.Errorf("%s is pinned to phase %d: phases start at 1", model.FormatID(id), pin)
+		}
+	}
+
+	// Levels list the issues in topological order, so every blocker's level
+	// is known before the issues it blocks.
+	level := make(map[int]int, len(dag.Nodes))
+	var regrouped [][]int
+	for _, ids := range levels {
+		for _, id := range ids {
+			earliest, blockerID := 0, 0
+			for b := range dag.Nodes[id].Reverse {
+				if l := level[b] + 1; l > earliest || (l == earliest && b < blockerID) {
+					earliest, blockerID = l, b
+				}
+			}
+			l := earliest
+			if pin, ok := pins[id]; ok {
+				if pin-1 < earliest {
+					return nil, &PinConflictError{ID: id, Pin: pin, BlockerID: blockerID, Earliest: earliest + 1}
+				}
+				l = pin - 1
+			}
+			level[id] = l
+			for len(regrouped) <= l {
+				regrouped = append(regrouped, nil)
+			}
+			regrouped[l] = append(regrouped[l], id)
+		}
+	}
+	for _, ids := range regrouped {
+		sort.Ints(ids)
+	}
+	return regrouped, nil
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys(m map[int]int) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// openBlockersOf returns the IDs of open issues outside ids that block an
+// issue in ids, directly or through other open blockers.
+func openBlockersOf(dag *DAG, ids map[int]struct{}) map[int]struct{} {
+	blockers := make(map[int]struct{})
+	queue := make([]int, 0, len(ids))
+	for id := range ids {
+		queue = append(queue, id)
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for blockerID := range dag.Nodes[current].Reverse {
+			blocker, ok := dag.Nodes[blockerID]
+			if !ok || blocker.Issue.Status == model.StatusDone {
+				continue
+			}
+			if _, ok := ids[blockerID]; ok {
+				continue
+			}
+			if _, ok := blockers[blockerID]; ok {
+				continue
+			}
+			blockers[blockerID] = struct{}{}
+			queue = append(queue, blockerID)
+		}
+	}
+	return blockers
+}
+
 // priorityRank returns a numeric rank for sorting: lower rank = higher priority.
 func priorityRank(p model.Priority) int {
 	switch p {
@@ -301,3 +547,10 @@ func scopeToDescendants(dag *DAG, rootID int) *DAG {
 
 	return scoped
 }
+
+// sortByID sorts issues in place by ascending ID.
+func sortByID(issues []*model.Issue) {
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].ID < issues[j].ID
+	})
+}
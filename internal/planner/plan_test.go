@@ -1,6 +1,8 @@
 package planner
 
 import (
+	"errors"
+	"slices"
 	"testing"
 
 	"github.com/ALT-F4-LLC/docket/internal/model"
@@ -145,3 +147,280 @@ func TestSplitByFileCollisionNoFilesNeverCollide(t *testing.T) {
 		t.Errorf("phase 2: expected issue 4, got %v", result[1])
 	}
 }
+
+func TestUnblocked(t *testing.T) {
+	// 1 blocks 2; 1 and 3 block 4; 5 depends_on 1 and is already done.
+	issues := []*model.Issue{
+		{ID: 1, Status: model.StatusDone},
+		{ID: 2, Status: model.StatusTodo},
+		{ID: 3, Status: model.StatusInProgress},
+		{ID: 4, Status: model.StatusBacklog},
+		{ID: 5, Status: model.StatusDone},
+	}
+	relations := []model.Relation{
+		{SourceIssueID: 1, TargetIssueID: 2, RelationType: model.RelationBlocks},
+		{SourceIssueID: 1, TargetIssueID: 4, RelationType: model.RelationBlocks},
+		{SourceIssueID: 3, TargetIssueID: 4, RelationType: model.RelationBlocks},
+		{SourceIssueID: 5, TargetIssueID: 1, RelationType: model.RelationDependsOn},
+	}
+	dag := BuildDAG(issues, relations)
+
+	got := Unblocked(dag, 1)
+	if len(got) != 1 || got[0].ID != 2 {
+		t.Fatalf("Unblocked(1) = %v, want [2]", issueIDs(got))
+	}
+
+	// Once 3 is done too, closing it unblocks 4.
+	issues[2].Status = model.StatusDone
+	got = Unblocked(dag, 3)
+	if len(got) != 1 || got[0].ID != 4 {
+		t.Fatalf("Unblocked(3) = %v, want [4]", issueIDs(got))
+	}
+
+	if got := Unblocked(dag, 2); len(got) != 0 {
+		t.Errorf("Unblocked(2) = %v, want none", issueIDs(got))
+	}
+	if got := Unblocked(dag, 99); got != nil {
+		t.Errorf("Unblocked(99) = %v, want nil", issueIDs(got))
+	}
+}
+
+func TestUnblockedTreatsClosedIssueAsDone(t *testing.T) {
+	issues := []*model.Issue{
+		{ID: 1, Status: model.StatusInProgress},
+		{ID: 2, Status: model.StatusTodo},
+		{ID: 3, Status: model.StatusTodo},
+	}
+	relations := []model.Relation{
+		{SourceIssueID: 1, TargetIssueID: 2, RelationType: model.RelationBlocks},
+		{SourceIssueID: 1, TargetIssueID: 3, RelationType: model.RelationBlocks},
+	}
+
+	got := Unblocked(BuildDAG(issues, relations), 1)
+	if len(got) != 2 || got[0].ID != 2 || got[1].ID != 3 {
+		t.Errorf("Unblocked(1) = %v, want [2 3]", issueIDs(got))
+	}
+}
+
+func TestOpenBlockers(t *testing.T) {
+	issues := []*model.Issue{
+		{ID: 1, Status: model.StatusDone},
+		{ID: 2, Status: model.StatusTodo},
+		{ID: 3, Status: model.StatusDone},
+		{ID: 4, Status: model.StatusBacklog},
+	}
+	relations := []model.Relation{
+		{SourceIssueID: 4, TargetIssueID: 1, RelationType: model.RelationBlocks},
+		{SourceIssueID: 1, TargetIssueID: 2, RelationType: model.RelationDependsOn},
+		{SourceIssueID: 3, TargetIssueID: 1, RelationType: model.RelationBlocks},
+	}
+	dag := BuildDAG(issues, relations)
+
+	got := OpenBlockers(dag, 1)
+	if len(got) != 2 || got[0].ID != 2 || got[1].ID != 4 {
+		t.Errorf("OpenBlockers(1) = %v, want [2 4]", issueIDs(got))
+	}
+	if got := OpenBlockers(dag, 3); len(got) != 0 {
+		t.Errorf("OpenBlockers(3) = %v, want none", issueIDs(got))
+	}
+}
+
+func issueIDs(issues []*model.Issue) []int {
+	ids := make([]int, 0, len(issues))
+	for _, i := range issues {
+		ids = append(ids, i.ID)
+	}
+	return ids
+}
+
+func TestGeneratePlanAssigneeFilterKeepsBlockersAsContext(t *testing.T) {
+	// 1 (bob) blocks 2 (carol), which blocks 3 (alice); 4 (done) blocks 3;
+	// 5 (alice) and 6 (bob) are independent.
+	issues := []*model.Issue{
+		{ID: 1, Status: model.StatusTodo, Assignee: "bob"},
+		{ID: 2, Status: model.StatusInProgress, Assignee: "carol"},
+		{ID: 3, Status: model.StatusTodo, Assignee: "alice"},
+		{ID: 4, Status: model.StatusDone, Assignee: "dave"},
+		{ID: 5, Status: model.StatusBacklog, Assignee: "alice"},
+		{ID: 6, Status: model.StatusTodo, Assignee: "bob"},
+	}
+	relations := []model.Relation{
+		{SourceIssueID: 1, TargetIssueID: 2, RelationType: model.RelationBlocks},
+		{SourceIssueID: 2, TargetIssueID: 3, RelationType: model.RelationBlocks},
+		{SourceIssueID: 4, TargetIssueID: 3, RelationType: model.RelationBlocks},
+	}
+
+	plan, err := GeneratePlan(BuildDAG(issues, relations), PlanFilters{
+		Assignees: []string{"alice"},
+		Statuses:  []string{"todo", "backlog"},
+	})
+	if err != nil {
+		t.Fatalf("GeneratePlan: %v", err)
+	}
+
+	var phases [][]int
+	for _, p := range plan.Phases {
+		phases = append(phases, issueIDs(p.Issues))
+	}
+	want := [][]int{{1, 5}, {2}, {3}}
+	if len(phases) != len(want) {
+		t.Fatalf("phases = %v, want %v", phases, want)
+	}
+	for i := range want {
+		if !slices.Equal(phases[i], want[i]) {
+			t.Errorf("phase %d = %v, want %v", i+1, phases[i], want[i])
+		}
+	}
+
+	if len(plan.Context) != 2 {
+		t.Errorf("Context = %v, want blockers 1 and 2", plan.Context)
+	}
+	for _, id := range []int{1, 2} {
+		if _, ok := plan.Context[id]; !ok {
+			t.Errorf("blocker %d missing from Context", id)
+		}
+	}
+	if plan.TotalIssues != 4 {
+		t.Errorf("TotalIssues = %d, want 4", plan.TotalIssues)
+	}
+}
+
+func TestGeneratePlanWithoutAssigneeFilterHasNoContext(t *testing.T) {
+	issues := []*model.Issue{
+		{ID: 1, Status: model.StatusTodo, Assignee: "bob"},
+		{ID: 2, Status: model.StatusTodo},
+	}
+	relations := []model.Relation{
+		{SourceIssueID: 1, TargetIssueID: 2, RelationType: model.RelationBlocks},
+	}
+
+	plan, err := GeneratePlan(BuildDAG(issues, relations), PlanFilters{})
+	if err != nil {
+		t.Fatalf("GeneratePlan: %v", err)
+	}
+	if plan.TotalIssues != 2 || plan.Context != nil {
+		t.Errorf("TotalIssues = %d, Context = %v; want 2 and nil", plan.TotalIssues, plan.Context)
+	}
+}
+
+func TestGroupByAssignee(t *testing.T) {
+	issues := []*model.Issue{
+		{ID: 1, Assignee: "zoe"},
+		{ID: 2},
+		{ID: 3, Assignee: "amy"},
+		{ID: 4, Assignee: "zoe"},
+	}
+
+	groups := GroupByAssignee(issues)
+
+	var got []string
+	for _, g := range groups {
+		got = append(got, g.Assignee)
+	}
+	if want := []string{"amy", "zoe", ""}; !slices.Equal(got, want) {
+		t.Fatalf("assignees = %q, want %q", got, want)
+	}
+	if ids := issueIDs(groups[1].Issues); !slices.Equal(ids, []int{1, 4}) {
+		t.Errorf("zoe's issues = %v, want [1 4]", ids)
+	}
+}
+
+// planPhaseIDs returns the issue IDs of each phase of plan.
+func planPhaseIDs(plan *Plan) [][]int {
+	phases := make([][]int, len(plan.Phases))
+	for i, p := range plan.Phases {
+		phases[i] = issueIDs(p.Issues)
+	}
+	return phases
+}
+
+func TestGeneratePlanPinnedPhase(t *testing.T) {
+	// 1 blocks 2; 3 blocks 4; 5 is independent. 3 is pinned to phase 3.
+	issues := []*model.Issue{
+		{ID: 1, Status: model.StatusTodo},
+		{ID: 2, Status: model.StatusTodo},
+		{ID: 3, Status: model.StatusTodo},
+		{ID: 4, Status: model.StatusTodo},
+		{ID: 5, Status: model.StatusTodo},
+	}
+	relations := []model.Relation{
+		{SourceIssueID: 1, TargetIssueID: 2, RelationType: model.RelationBlocks},
+		{SourceIssueID: 3, TargetIssueID: 4, RelationType: model.RelationBlocks},
+	}
+
+	plan, err := GeneratePlan(BuildDAG(issues, relations), PlanFilters{PinnedPhase: map[int]int{3: 3}})
+	if err != nil {
+		t.Fatalf("GeneratePlan: %v", err)
+	}
+	got := planPhaseIDs(plan)
+	want := [][]int{{1, 5}, {2}, {3}, {4}}
+	if len(got) != len(want) {
+		t.Fatalf("phases = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Errorf("phase %d = %v, want %v", i+1, got[i], want[i])
+		}
+	}
+	if plan.Pinned[3] != 3 || len(plan.Pinned) != 1 {
+		t.Errorf("Pinned = %v, want map[3:3]", plan.Pinned)
+	}
+}
+
+func TestGeneratePlanPinnedPhaseHoldsEmptyPhases(t *testing.T) {
+	// With nothing else to plan first, earlier phases are left empty so the
+	// pinned issue still lands in its phase, and its dependent after it.
+	issues := []*model.Issue{
+		{ID: 1, Status: model.StatusTodo},
+		{ID: 2, Status: model.StatusTodo},
+	}
+	relations := []model.Relation{
+		{SourceIssueID: 2, TargetIssueID: 1, RelationType: model.RelationDependsOn},
+	}
+
+	plan, err := GeneratePlan(BuildDAG(issues, relations), PlanFilters{PinnedPhase: map[int]int{1: 3}})
+	if err != nil {
+		t.Fatalf("GeneratePlan: %v", err)
+	}
+	got := planPhaseIDs(plan)
+	if len(got) != 4 || len(got[0]) != 0 || len(got[1]) != 0 || !slices.Equal(got[2], []int{1}) || !slices.Equal(got[3], []int{2}) {
+		t.Fatalf("phases = %v, want [[] [] [1] [2]]", got)
+	}
+	if plan.TotalIssues != 2 || plan.TotalPhases != 4 || plan.MaxParallelism != 1 {
+		t.Errorf("TotalIssues = %d, TotalPhases = %d, MaxParallelism = %d; want 2, 4, 1",
+			plan.TotalIssues, plan.TotalPhases, plan.MaxParallelism)
+	}
+}
+
+func TestGeneratePlanPinnedPhaseConflict(t *testing.T) {
+	// 1 blocks 2, which blocks 3, so 3 cannot come before phase 3.
+	issues := []*model.Issue{
+		{ID: 1, Status: model.StatusTodo},
+		{ID: 2, Status: model.StatusTodo},
+		{ID: 3, Status: model.StatusTodo},
+	}
+	relations := []model.Relation{
+		{SourceIssueID: 1, TargetIssueID: 2, RelationType: model.RelationBlocks},
+		{SourceIssueID: 2, TargetIssueID: 3, RelationType: model.RelationBlocks},
+	}
+	dag := BuildDAG(issues, relations)
+
+	_, err := GeneratePlan(dag, PlanFilters{PinnedPhase: map[int]int{3: 2}})
+	var pinErr *PinConflictError
+	if !errors.As(err, &pinErr) {
+		t.Fatalf("GeneratePlan error = %v, want a PinConflictError", err)
+	}
+	if pinErr.ID != 3 || pinErr.BlockerID != 2 || pinErr.Earliest != 3 {
+		t.Errorf("PinConflictError = %+v, want 3 blocked by 2 until phase 3", pinErr)
+	}
+
+	// A blocker pinned later pushes the issues it blocks past their pins.
+	_, err = GeneratePlan(dag, PlanFilters{PinnedPhase: map[int]int{2: 4, 3: 4}})
+	if !errors.As(err, &pinErr) || pinErr.ID != 3 || pinErr.Earliest != 5 {
+		t.Errorf("GeneratePlan error = %v, want 3 unable to start before phase 5", err)
+	}
+
+	if _, err := GeneratePlan(dag, PlanFilters{PinnedPhase: map[int]int{1: 0}}); err == nil {
+		t.Error("expected an error for a pin below phase 1")
+	}
+}
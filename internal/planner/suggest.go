@@ -0,0 +1,101 @@
+package planner
+
+import (
+	"sort"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// LinkSuggestion proposes that Dependent depends_on Dependency because both
+// open issues are attached to the same Files.
+type LinkSuggestion struct {
+	Dependency *model.Issue
+	Dependent  *model.Issue
+	Files      []string // shared file paths, sorted
+}
+
+// SuggestLinks pairs open issues attached to the same files, given the
+// issues sharing each file path (as from db.SharedOpenFiles), every issue
+// by ID for looking up parents, and the existing relations. Pairs that are
+// already related in either direction, or where one issue is an ancestor of
+// the other, are skipped. In each pair the issue further along the workflow
+// is suggested as the dependency, the older one when both are equally far.
+// Suggestions are ordered by the number of shared files, most first, then
+// by dependency and dependent ID.
+func SuggestLinks(shared map[string][]int, issues map[int]*model.Issue, relations []model.Relation) []LinkSuggestion {
+	type pair struct{ low, high int }
+
+	related := make(map[pair]bool, len(relations))
+	for _, rel := range relations {
+		related[pair{min(rel.SourceIssueID, rel.TargetIssueID), max(rel.SourceIssueID, rel.TargetIssueID)}] = true
+	}
+
+	files := make(map[pair][]string)
+	for fp, ids := range shared {
+		for i, a := range ids {
+			for _, b := range ids[i+1:] {
+				if a == b {
+					continue
+				}
+				p := pair{min(a, b), max(a, b)}
+				files[p] = append(files[p], fp)
+			}
+		}
+	}
+
+	var suggestions []LinkSuggestion
+	for p, paths := range files {
+		a, b := issues[p.low], issues[p.high]
+		if a == nil || b == nil || a.Status == model.StatusDone || b.Status == model.StatusDone {
+			continue
+		}
+		if related[p] || isAncestor(issues, a.ID, b) || isAncestor(issues, b.ID, a) {
+			continue
+		}
+		dependency, dependent := a, b
+		if workflowRank(b.Status) > workflowRank(a.Status) {
+			dependency, dependent = b, a
+		}
+		sort.Strings(paths)
+		suggestions = append(suggestions, LinkSuggestion{Dependency: dependency, Dependent: dependent, Files: paths})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		si, sj := suggestions[i], suggestions[j]
+		if len(si.Files) != len(sj.Files) {
+			return len(si.Files) > len(sj.Files)
+		}
+		if si.Dependency.ID != sj.Dependency.ID {
+			return si.Dependency.ID < sj.Dependency.ID
+		}
+		return si.Dependent.ID < sj.Dependent.ID
+	})
+	return suggestions
+}
+
+// isAncestor reports whether the issue with ID ancestorID is a parent,
+// grandparent, and so on of issue.
+func isAncestor(issues map[int]*model.Issue, ancestorID int, issue *model.Issue) bool {
+	seen := make(map[int]bool)
+	for issue != nil && issue.ParentID != nil && !seen[issue.ID] {
+		if *issue.ParentID == ancestorID {
+			return true
+		}
+		seen[issue.ID] = true
+		issue = issues[*issue.ParentID]
+	}
+	return false
+}
+
+// workflowRank orders open statuses by how far along the workflow they are.
+func workflowRank(s model.Status) int {
+	switch s {
+	case model.StatusReview:
+		return 3
+	case model.StatusInProgress:
+		return 2
+	case model.StatusTodo:
+		return 1
+	}
+	return 0
+}
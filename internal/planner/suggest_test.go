@@ -0,0 +1,65 @@
+package planner
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestSuggestLinks(t *testing.T) {
+	epic := 1
+	issues := map[int]*model.Issue{
+		1:  {ID: 1, Status: model.StatusInProgress},
+		2:  {ID: 2, Status: model.StatusTodo, ParentID: &epic},
+		9:  {ID: 9, Status: model.StatusInProgress},
+		14: {ID: 14, Status: model.StatusTodo},
+		20: {ID: 20, Status: model.StatusBacklog},
+		21: {ID: 21, Status: model.StatusBacklog},
+		30: {ID: 30, Status: model.StatusDone},
+		40: {ID: 40, Status: model.StatusTodo},
+	}
+	shared := map[string][]int{
+		"issues.go": {9, 14, 20},
+		"labels.go": {9, 14},
+		"db.go":     {9, 14, 30},
+		"epic.go":   {1, 2},
+		"other.go":  {20, 21, 40},
+	}
+	relations := []model.Relation{
+		{SourceIssueID: 40, TargetIssueID: 21, RelationType: model.RelationRelatesTo},
+	}
+
+	got := SuggestLinks(shared, issues, relations)
+
+	type want struct {
+		dependency, dependent int
+		files                 []string
+	}
+	wants := []want{
+		{9, 14, []string{"db.go", "issues.go", "labels.go"}},
+		{9, 20, []string{"issues.go"}},
+		{14, 20, []string{"issues.go"}},
+		{20, 21, []string{"other.go"}},
+		{40, 20, []string{"other.go"}},
+	}
+	if len(got) != len(wants) {
+		for _, s := range got {
+			t.Logf("got %d <- %d %v", s.Dependency.ID, s.Dependent.ID, s.Files)
+		}
+		t.Fatalf("got %d suggestions, want %d", len(got), len(wants))
+	}
+	for i, w := range wants {
+		s := got[i]
+		if s.Dependency.ID != w.dependency || s.Dependent.ID != w.dependent || !slices.Equal(s.Files, w.files) {
+			t.Errorf("suggestion %d = %d <- %d %v, want %d <- %d %v",
+				i, s.Dependency.ID, s.Dependent.ID, s.Files, w.dependency, w.dependent, w.files)
+		}
+	}
+}
+
+func TestSuggestLinksNone(t *testing.T) {
+	if got := SuggestLinks(nil, nil, nil); len(got) != 0 {
+		t.Errorf("SuggestLinks(nil) = %v, want none", got)
+	}
+}
@@ -0,0 +1,102 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/textdiff"
+)
+
+// DefaultDiffHunks is how many hunks of a description edit activity views
+// show before summarizing the rest as "… N more changes".
+const DefaultDiffHunks = 3
+
+// activityDiffContext is how many unchanged lines surround each change when
+// an entry holding full old and new values is diffed for display. It matches
+// the context kept when description edits are recorded.
+const activityDiffContext = 1
+
+// activityDiffText returns the unified diff an activity entry records. For
+// description edits recorded before they were stored as diffs, it diffs the
+// full old and new values instead. ok is false for other entries.
+func activityDiffText(a model.Activity) (diff string, ok bool) {
+	if _, _, isDiff := textdiff.Stats(a.NewValue); isDiff && a.OldValue == "" {
+		return a.NewValue, true
+	}
+	if a.FieldChanged == "description" && a.OldValue != a.NewValue {
+		return textdiff.Unified(a.FieldChanged, a.OldValue, a.NewValue, activityDiffContext), true
+	}
+	return "", false
+}
+
+// ActivityDiff renders the line diff of a description edit for an activity
+// view, each line indented by indent: removed lines prefixed "-" in red,
+// added lines "+" in green and unchanged context dimmed, under each hunk's
+// "@@" header. Only the first opts.DiffHunks hunks (DefaultDiffHunks when
+// 0, all when negative) are shown, followed by "… N more changes". ok is
+// false for entries that are not description edits or that change nothing.
+func ActivityDiff(a model.Activity, opts LayoutOptions, indent string) (diff string, ok bool) {
+	if a.FieldChanged != "description" {
+		return "", false
+	}
+	raw, ok := activityDiffText(a)
+	if !ok {
+		return "", false
+	}
+	hunks, ok := textdiff.Hunks(raw)
+	if !ok || len(hunks) == 0 {
+		return "", false
+	}
+
+	limit := opts.DiffHunks
+	if limit == 0 {
+		limit = DefaultDiffHunks
+	}
+	var hidden int
+	if limit > 0 && len(hunks) > limit {
+		hunks, hidden = hunks[:limit], len(hunks)-limit
+	}
+
+	colors := ColorsEnabled()
+	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+	contextStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	removedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	addedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+
+	var lines []string
+	for _, h := range hunks {
+		header := h.Header
+		if colors {
+			header = headerStyle.Render(header)
+		}
+		lines = append(lines, indent+header)
+		for _, l := range h.Lines {
+			line := string(l.Kind) + l.Text
+			if colors {
+				switch l.Kind {
+				case '-':
+					line = removedStyle.Render(line)
+				case '+':
+					line = addedStyle.Render(line)
+				default:
+					line = contextStyle.Render(line)
+				}
+			}
+			lines = append(lines, indent+line)
+		}
+	}
+	if hidden > 0 {
+		more := fmt.Sprintf("… %d more changes", hidden)
+		if hidden == 1 {
+			more = "… 1 more change"
+		}
+		if colors {
+			more = contextStyle.Render(more)
+		}
+		lines = append(lines, indent+more)
+	}
+	return strings.Join(lines, "\n"), true
+}
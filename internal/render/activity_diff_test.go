@@ -0,0 +1,70 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/textdiff"
+)
+
+func TestActivityDiffPlain(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	a := model.Activity{FieldChanged: "description", NewValue: textdiff.Unified("description", "a\nb\nc", "a\nB\nc", 1)}
+
+	got, ok := ActivityDiff(a, LayoutOptions{}, "  ")
+	if !ok {
+		t.Fatal("ActivityDiff rejected a description diff")
+	}
+	if want := "  @@ -1,3 +1,3 @@\n   a\n  -b\n  +B\n   c"; got != want {
+		t.Errorf("ActivityDiff() = %q, want %q", got, want)
+	}
+}
+
+func TestActivityDiffLimitsHunks(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	oldText := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13"
+	newText := "x\n2\n3\n4\n5\n6\ny\n8\n9\n10\n11\n12\nz"
+	a := model.Activity{FieldChanged: "description", NewValue: textdiff.Unified("description", oldText, newText, 1)}
+
+	got, _ := ActivityDiff(a, LayoutOptions{DiffHunks: 1}, "")
+	if strings.Count(got, "@@ ") != 1 || !strings.HasSuffix(got, "… 2 more changes") {
+		t.Errorf("DiffHunks 1:\n%s", got)
+	}
+	got, _ = ActivityDiff(a, LayoutOptions{DiffHunks: 2}, "")
+	if !strings.HasSuffix(got, "… 1 more change") {
+		t.Errorf("DiffHunks 2:\n%s", got)
+	}
+	got, _ = ActivityDiff(a, LayoutOptions{DiffHunks: -1}, "")
+	if strings.Count(got, "@@ ") != 3 || strings.Contains(got, "more change") {
+		t.Errorf("DiffHunks -1:\n%s", got)
+	}
+}
+
+func TestActivityDiffFullValues(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	// Entries recorded before edits were stored as diffs hold both values.
+	a := model.Activity{FieldChanged: "description", OldValue: "first\nsecond", NewValue: "first\nthird"}
+	got, ok := ActivityDiff(a, LayoutOptions{}, "")
+	if !ok || !strings.Contains(got, "-second\n+third") {
+		t.Errorf("full values: ActivityDiff() = %q (ok=%v)", got, ok)
+	}
+	if summary, ok := ActivityDiffSummary(a); !ok || summary != "+1/-1 lines" {
+		t.Errorf("ActivityDiffSummary() = %q (ok=%v), want +1/-1 lines", summary, ok)
+	}
+
+	cleared := model.Activity{FieldChanged: "description", OldValue: "gone"}
+	if got, ok := ActivityDiff(cleared, LayoutOptions{}, ""); !ok || got != "@@ -1,1 +0,0 @@\n-gone" {
+		t.Errorf("cleared: ActivityDiff() = %q (ok=%v)", got, ok)
+	}
+
+	for _, other := range []model.Activity{
+		{FieldChanged: "title", OldValue: "old", NewValue: "new"},
+		{FieldChanged: "description", OldValue: "same", NewValue: "same"},
+	} {
+		if got, ok := ActivityDiff(other, LayoutOptions{}, ""); ok {
+			t.Errorf("ActivityDiff(%+v) = %q, want none", other, got)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// RenderAssignmentHistory renders who has held an issue: one line per
+// assignee change, oldest first, e.g. "alice → bob, 3d ago, by carol", then
+// the total time each assignee held it as of now.
+func RenderAssignmentHistory(issue *model.Issue, changes []model.AssignmentChange, tenures []model.AssigneeTenure, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s  %s\n", model.FormatID(issue.ID), issue.Title)
+
+	b.WriteString("\nAssignment history\n")
+	if len(changes) == 0 {
+		if issue.Assignee == "" {
+			b.WriteString("  Never assigned\n")
+		} else {
+			fmt.Fprintf(&b, "  %s since creation, %s\n", issue.Assignee, formatAgo(now.Sub(issue.CreatedAt)))
+		}
+	}
+	for _, c := range changes {
+		fmt.Fprintf(&b, "  %s → %s, %s", assigneeOrUnassigned(c.From), assigneeOrUnassigned(c.To), formatAgo(now.Sub(c.At)))
+		if c.By != "" {
+			fmt.Fprintf(&b, ", by %s", c.By)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(tenures) > 0 {
+		width := 0
+		for _, t := range tenures {
+			width = max(width, len(t.Assignee))
+		}
+		b.WriteString("\nTime held\n")
+		for _, t := range tenures {
+			held := "<1m"
+			if t.Held >= time.Minute {
+				held = formatAge(t.Held)
+			}
+			fmt.Fprintf(&b, "  %-*s  %s", width, t.Assignee, held)
+			if t.Current {
+				b.WriteString(" (current)")
+			}
+			b.WriteString("\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// formatAgo renders how long ago something happened, e.g. "3d ago", or
+// "just now" within the last minute.
+func formatAgo(d time.Duration) string {
+	if d < time.Minute {
+		return "just now"
+	}
+	return formatAge(d) + " ago"
+}
+
+// assigneeOrUnassigned returns assignee, or "unassigned" when it is empty.
+func assigneeOrUnassigned(assignee string) string {
+	if assignee == "" {
+		return "unassigned"
+	}
+	return assignee
+}
@@ -0,0 +1,31 @@
+package render
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestRenderAssignmentHistory(t *testing.T) {
+	now := time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+	issue := &model.Issue{ID: 5, Title: "Rotate keys", Assignee: "bob", CreatedAt: now.Add(-10 * 24 * time.Hour)}
+	changes := []model.AssignmentChange{
+		{From: "", To: "alice", By: "alice", At: now.Add(-7 * 24 * time.Hour)},
+		{From: "alice", To: "bob", By: "carol", At: now.Add(-3 * 24 * time.Hour)},
+	}
+	tenures := model.AssignmentTenures(issue.CreatedAt, changes, issue.Assignee, now)
+
+	out := RenderAssignmentHistory(issue, changes, tenures, now)
+	for _, want := range []string{
+		"unassigned → alice, 7d ago, by alice",
+		"alice → bob, 3d ago, by carol",
+		"alice  4d",
+		"bob    3d (current)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"golang.org/x/term"
@@ -11,11 +12,15 @@ import (
 	"github.com/ALT-F4-LLC/docket/internal/model"
 )
 
+// MaxCardsPerColumn is the number of cards a board column shows by default
+// before collapsing the rest into a "+N more" line.
+const MaxCardsPerColumn = 10
+
 const (
-	maxCardsPerColumn = 10
-	minColumnWidth    = 20
-	defaultTermWidth  = 100
-	cardPadding       = 2 // left+right padding inside cards
+	minColumnWidth   = 20
+	defaultTermWidth = 100
+	cardPadding      = 2 // left+right padding inside cards
+	maxCardAssignee  = 16
 )
 
 // StatusOrder defines the left-to-right column order for the board.
@@ -44,8 +49,24 @@ type SubIssueProgress struct {
 
 // BoardOptions configures board rendering behavior.
 type BoardOptions struct {
-	Expand   bool
-	Progress map[int]SubIssueProgress // keyed by parent issue ID
+	Expand       bool
+	Progress     map[int]SubIssueProgress // keyed by parent issue ID
+	ShowAssignee bool                     // add the (truncated) assignee to each card
+	ShowAge      bool                     // add a compact age such as "3d" to each card
+	Layout       LayoutOptions            // board width and card title truncation
+
+	// PerColumn caps the cards shown in each column; 0 means
+	// MaxCardsPerColumn and a negative value shows every card. Offset is the number of cards the caller skipped at
+	// the top of each column, and Totals, when set, holds each column's full
+	// count, so that headers and "+N more" reflect the whole board when
+	// issues is only one page of it.
+	PerColumn int
+	Offset    int
+	Totals    map[model.Status]int
+
+	// StatusColors overrides the colors of column headers and card borders;
+	// nil keeps each status's default color.
+	StatusColors StatusColors
 }
 
 // RenderBoard renders a list of issues as a Kanban board with columns per status.
@@ -62,7 +83,11 @@ func RenderBoard(issues []*model.Issue, opts BoardOptions) string {
 }
 
 // terminalWidth returns the current terminal width, falling back to a default.
-func terminalWidth() int {
+// A positive override is returned as-is so output does not depend on a TTY.
+func terminalWidth(override int) int {
+	if override > 0 {
+		return override
+	}
 	w, _, err := term.GetSize(int(os.Stdout.Fd()))
 	if err != nil || w <= 0 {
 		return defaultTermWidth
@@ -94,7 +119,7 @@ func renderColorBoard(issues []*model.Issue, opts BoardOptions) string {
 		return ""
 	}
 
-	tw := terminalWidth()
+	tw := terminalWidth(opts.Layout.Width)
 	// Account for gaps between columns (1 space each).
 	gaps := len(activeStatuses) - 1
 	colWidth := (tw - gaps) / len(activeStatuses)
@@ -116,21 +141,12 @@ func renderColorBoard(issues []*model.Issue, opts BoardOptions) string {
 
 func renderColorColumn(status model.Status, issues []*model.Issue, colWidth, contentWidth int, opts BoardOptions) string {
 	// Column header
-	headerStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(ColorFromName(status.Color())).
-		Width(colWidth).
-		Align(lipgloss.Center)
-
-	header := headerStyle.Render(fmt.Sprintf("%s %s (%d)", status.Icon(), strings.ToUpper(string(status)), len(issues)))
+	headerStyle := columnHeaderStyle(status, colWidth, opts)
 
 	// Render cards up to the maximum.
-	visible := issues
-	overflow := 0
-	if len(issues) > maxCardsPerColumn {
-		visible = issues[:maxCardsPerColumn]
-		overflow = len(issues) - maxCardsPerColumn
-	}
+	visible, total, overflow := columnPage(status, issues, opts)
+
+	header := headerStyle.Render(fmt.Sprintf("%s %s (%d)", status.Icon(), strings.ToUpper(string(status)), total))
 
 	cards := make([]string, 0, len(visible)+2) // +2 for header and possible overflow
 	cards = append(cards, header)
@@ -151,6 +167,16 @@ func renderColorColumn(status model.Status, issues []*model.Issue, colWidth, con
 	return lipgloss.JoinVertical(lipgloss.Left, cards...)
 }
 
+// columnHeaderStyle returns the style of a status column's header, in the
+// status's color from opts.StatusColors.
+func columnHeaderStyle(status model.Status, colWidth int, opts BoardOptions) lipgloss.Style {
+	return lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorFromName(opts.StatusColors.Color(status))).
+		Width(colWidth).
+		Align(lipgloss.Center)
+}
+
 func renderColorCard(issue *model.Issue, colWidth, contentWidth int, opts BoardOptions) string {
 	if contentWidth < 5 {
 		contentWidth = 5
@@ -160,19 +186,22 @@ func renderColorCard(issue *model.Issue, colWidth, contentWidth int, opts BoardO
 	kindIcon := lipgloss.NewStyle().
 		Foreground(ColorFromName(issue.Kind.Color())).
 		Render(issue.Kind.Icon())
-	idStr := model.FormatID(issue.ID)
+	idStr := LinkedID(issue.ID)
 	priIcon := lipgloss.NewStyle().
 		Foreground(ColorFromName(issue.Priority.Color())).
 		Render(issue.Priority.Icon())
 	line1 := fmt.Sprintf("%s %s %s", kindIcon, idStr, priIcon)
+	if issue.Pinned {
+		line1 += " 📌"
+	}
 
-	// Line 2: Title (truncated)
-	line2 := truncate(issue.Title, contentWidth)
+	// Line 2: Title (truncated unless disabled, in which case the card wraps it)
+	line2 := opts.Layout.fitTitle(issue.Title, contentWidth)
 
 	// Line 3: Labels
 	var line3 string
 	if len(issue.Labels) > 0 {
-		line3 = truncate(strings.Join(issue.Labels, ", "), contentWidth)
+		line3 = truncate(opts.Layout.fitLabels(issue.Labels), contentWidth)
 	}
 
 	// Line 4: Sub-issue progress (if applicable)
@@ -183,6 +212,14 @@ func renderColorCard(issue *model.Issue, colWidth, contentWidth int, opts BoardO
 		}
 	}
 
+	// Line 5: Assignee and age
+	var line5 string
+	if meta := cardMeta(issue, opts); meta != "" {
+		line5 = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("8")).
+			Render(truncate(meta, contentWidth))
+	}
+
 	// Assemble card body.
 	var lines []string
 	lines = append(lines, line1, line2)
@@ -192,17 +229,58 @@ func renderColorCard(issue *model.Issue, colWidth, contentWidth int, opts BoardO
 	if line4 != "" {
 		lines = append(lines, line4)
 	}
+	if line5 != "" {
+		lines = append(lines, line5)
+	}
 	body := strings.Join(lines, "\n")
 
 	cardStyle := lipgloss.NewStyle().
 		Width(colWidth - 2). // account for outer spacing
 		Padding(0, 1).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(ColorFromName(issue.Status.Color()))
+		BorderForeground(ColorFromName(opts.StatusColors.Color(issue.Status)))
 
 	return cardStyle.Render(body)
 }
 
+// cardMeta returns the assignee/age line of a card, e.g. "@alice · 3d", or
+// an empty string when neither is enabled or available.
+func cardMeta(issue *model.Issue, opts BoardOptions) string {
+	var parts []string
+	if opts.ShowAssignee && issue.Assignee != "" {
+		parts = append(parts, "@"+truncate(issue.Assignee, maxCardAssignee))
+	}
+	if opts.ShowAge && !issue.CreatedAt.IsZero() {
+		parts = append(parts, formatAge(time.Since(issue.CreatedAt)))
+	}
+	if issue.IsSnoozed(time.Now()) {
+		parts = append(parts, "snoozed until "+SnoozeTime(issue.SnoozedUntil))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// formatAge renders a duration in the compact form used on board cards:
+// "now", "45m", "5h", "3d", "2w", "4mo", or "1y".
+func formatAge(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < day:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	case d < 14*day:
+		return fmt.Sprintf("%dd", int(d/day))
+	case d < 60*day:
+		return fmt.Sprintf("%dw", int(d/(7*day)))
+	case d < 365*day:
+		return fmt.Sprintf("%dmo", int(d/(30*day)))
+	default:
+		return fmt.Sprintf("%dy", int(d/(365*day)))
+	}
+}
+
 // formatProgressBar renders a text-based progress bar like "Sub: ###-- 3/5".
 func formatProgressBar(done, total, maxWidth int) string {
 	prefix := "Sub: "
@@ -228,6 +306,36 @@ func formatProgressBar(done, total, maxWidth int) string {
 	return prefix + bar + suffix
 }
 
+// columnPage returns the cards to show for a status column holding issues,
+// the column's total count for its header, and how many cards beyond the
+// visible ones the column has. Pinned cards lead the column and are always
+// shown; they do not count toward the per-column cap.
+func columnPage(status model.Status, issues []*model.Issue, opts BoardOptions) (visible []*model.Issue, total, overflow int) {
+	limit := opts.PerColumn
+	if limit == 0 {
+		limit = MaxCardsPerColumn
+	}
+	var rest []*model.Issue
+	for _, issue := range issues {
+		if issue.Pinned {
+			visible = append(visible, issue)
+		} else {
+			rest = append(rest, issue)
+		}
+	}
+	if limit > 0 && len(rest) > limit {
+		rest = rest[:limit]
+	}
+	visible = append(visible, rest...)
+
+	total = len(issues)
+	if opts.Totals != nil {
+		total = opts.Totals[status]
+	}
+	overflow = max(total-opts.Offset-len(visible), 0)
+	return visible, total, overflow
+}
+
 // --- Plain text fallback ---
 
 func renderPlainBoard(issues []*model.Issue, opts BoardOptions) string {
@@ -251,15 +359,8 @@ func renderPlainBoard(issues []*model.Issue, opts BoardOptions) string {
 			b.WriteString("\n")
 		}
 
-		issuesInCol := groups[status]
-		fmt.Fprintf(&b, "=== %s %s (%d) ===\n", status.Icon(), strings.ToUpper(string(status)), len(issuesInCol))
-
-		visible := issuesInCol
-		overflow := 0
-		if len(issuesInCol) > maxCardsPerColumn {
-			visible = issuesInCol[:maxCardsPerColumn]
-			overflow = len(issuesInCol) - maxCardsPerColumn
-		}
+		visible, total, overflow := columnPage(status, groups[status], opts)
+		fmt.Fprintf(&b, "=== %s %s (%d) ===\n", status.Icon(), strings.ToUpper(string(status)), total)
 
 		for _, issue := range visible {
 			renderPlainCard(&b, issue, opts)
@@ -274,11 +375,11 @@ func renderPlainBoard(issues []*model.Issue, opts BoardOptions) string {
 }
 
 func renderPlainCard(b *strings.Builder, issue *model.Issue, opts BoardOptions) {
-	fmt.Fprintf(b, "  %s [%s] (%s)\n", model.FormatID(issue.ID), string(issue.Priority), string(issue.Kind))
-	fmt.Fprintf(b, "  %s\n", truncate(issue.Title, maxTitleWidth))
+	fmt.Fprintf(b, "  %s%s [%s] (%s)\n", pinnedMarker(issue), model.FormatID(issue.ID), string(issue.Priority), string(issue.Kind))
+	fmt.Fprintf(b, "  %s\n", opts.Layout.title(issue.Title))
 
 	if len(issue.Labels) > 0 {
-		fmt.Fprintf(b, "  %s\n", strings.Join(issue.Labels, ", "))
+		fmt.Fprintf(b, "  %s\n", opts.Layout.fitLabels(issue.Labels))
 	}
 
 	if opts.Progress != nil {
@@ -287,5 +388,9 @@ func renderPlainCard(b *strings.Builder, issue *model.Issue, opts BoardOptions)
 		}
 	}
 
+	if meta := cardMeta(issue, opts); meta != "" {
+		fmt.Fprintf(b, "  %s\n", meta)
+	}
+
 	b.WriteString("\n")
 }
@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
+
 	"github.com/ALT-F4-LLC/docket/internal/model"
 )
 
@@ -103,7 +105,7 @@ func TestRenderPlainBoardTitleTruncation(t *testing.T) {
 
 	got := RenderBoard(issues, BoardOptions{})
 
-	// The plain-text card uses truncate(title, maxTitleWidth=40), so titles >40 chars
+	// The plain-text card uses truncate(title, DefaultTitleWidth=40), so titles >40 chars
 	// should be truncated with "..."
 	if strings.Contains(got, longTitle) {
 		t.Error("expected long title to be truncated, but found full title in output")
@@ -181,7 +183,7 @@ func TestRenderPlainBoardOverflow(t *testing.T) {
 	if !strings.Contains(got, "TODO (13) ===") {
 		t.Errorf("expected TODO (13) header, got:\n%s", got)
 	}
-	// Should show "+3 more" (13 - maxCardsPerColumn=10 = 3 overflow)
+	// Should show "+3 more" (13 - MaxCardsPerColumn=10 = 3 overflow)
 	if !strings.Contains(got, "+3 more") {
 		t.Errorf("expected '+3 more' overflow indicator, got:\n%s", got)
 	}
@@ -190,7 +192,7 @@ func TestRenderPlainBoardOverflow(t *testing.T) {
 func TestRenderPlainBoardExactlyMaxCards(t *testing.T) {
 	t.Setenv("NO_COLOR", "1")
 
-	// Create exactly maxCardsPerColumn (10) issues
+	// Create exactly MaxCardsPerColumn (10) issues
 	var issues []*model.Issue
 	for i := 1; i <= 10; i++ {
 		issues = append(issues, makeIssue(i, "Task", model.StatusTodo, model.PriorityMedium))
@@ -204,6 +206,29 @@ func TestRenderPlainBoardExactlyMaxCards(t *testing.T) {
 	}
 }
 
+func TestRenderPlainBoardUsesTotalsForPage(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	// One page of a 25-issue column: cards 11-15 after skipping ten.
+	var issues []*model.Issue
+	for i := 11; i <= 15; i++ {
+		issues = append(issues, makeIssue(i, "Task", model.StatusTodo, model.PriorityMedium))
+	}
+
+	got := RenderBoard(issues, BoardOptions{
+		PerColumn: 5,
+		Offset:    10,
+		Totals:    map[model.Status]int{model.StatusTodo: 25},
+	})
+
+	if !strings.Contains(got, "TODO (25) ===") {
+		t.Errorf("expected TODO (25) header from Totals, got:\n%s", got)
+	}
+	if !strings.Contains(got, "+10 more") {
+		t.Errorf("expected '+10 more' after the page, got:\n%s", got)
+	}
+}
+
 func TestRenderPlainBoardAllIssuesOneStatus(t *testing.T) {
 	t.Setenv("NO_COLOR", "1")
 
@@ -507,3 +532,194 @@ func TestRenderPlainBoardCardKindAllTypes(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderPlainBoardAssigneeAndAge(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	issue := makeIssue(1, "Owned task", model.StatusTodo, model.PriorityMedium)
+	issue.Assignee = "alice"
+	issue.CreatedAt = time.Now().Add(-3*24*time.Hour - time.Hour)
+
+	got := RenderBoard([]*model.Issue{issue}, BoardOptions{ShowAssignee: true, ShowAge: true})
+	if !strings.Contains(got, "  @alice · 3d\n") {
+		t.Errorf("expected assignee and age line '@alice · 3d', got:\n%s", got)
+	}
+
+	got = RenderBoard([]*model.Issue{issue}, BoardOptions{ShowAssignee: true})
+	if !strings.Contains(got, "  @alice\n") || strings.Contains(got, "3d") {
+		t.Errorf("expected assignee without age, got:\n%s", got)
+	}
+
+	got = RenderBoard([]*model.Issue{issue}, BoardOptions{})
+	if strings.Contains(got, "@alice") || strings.Contains(got, "3d") {
+		t.Errorf("expected no assignee or age when both are disabled, got:\n%s", got)
+	}
+}
+
+func TestRenderPlainBoardUnassignedOmitsAssignee(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	issue := makeIssue(1, "Nobody's task", model.StatusTodo, model.PriorityMedium)
+	got := RenderBoard([]*model.Issue{issue}, BoardOptions{ShowAssignee: true})
+	if strings.Contains(got, "@") {
+		t.Errorf("expected no assignee line for an unassigned issue, got:\n%s", got)
+	}
+}
+
+func TestRenderColorCardAssigneeAndAge(t *testing.T) {
+	issue := makeIssue(1, "Owned task", model.StatusTodo, model.PriorityMedium)
+	issue.Assignee = "a-very-long-assignee-name"
+	issue.CreatedAt = time.Now().Add(-5 * time.Hour)
+
+	got := renderColorCard(issue, 60, 56, BoardOptions{ShowAssignee: true, ShowAge: true})
+	if !strings.Contains(got, "@a-very-long-a...") {
+		t.Errorf("expected truncated assignee in color card, got:\n%s", got)
+	}
+	if !strings.Contains(got, "5h") {
+		t.Errorf("expected age '5h' in color card, got:\n%s", got)
+	}
+
+	got = renderColorCard(issue, 60, 56, BoardOptions{})
+	if strings.Contains(got, "@a-very") {
+		t.Errorf("expected no assignee in color card when disabled, got:\n%s", got)
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	const day = 24 * time.Hour
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{30 * time.Second, "now"},
+		{45 * time.Minute, "45m"},
+		{5 * time.Hour, "5h"},
+		{3 * day, "3d"},
+		{20 * day, "2w"},
+		{90 * day, "3mo"},
+		{400 * day, "1y"},
+	}
+	for _, tt := range tests {
+		if got := formatAge(tt.d); got != tt.want {
+			t.Errorf("formatAge(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestRenderPlainBoardNoTruncate(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	longTitle := "This is a very long title that exceeds the maximum width allowed"
+	issues := []*model.Issue{
+		makeIssue(1, longTitle, model.StatusTodo, model.PriorityMedium),
+	}
+
+	got := RenderBoard(issues, BoardOptions{Layout: LayoutOptions{NoTruncate: true}})
+	if !strings.Contains(got, longTitle) {
+		t.Errorf("expected full title with NoTruncate, got:\n%s", got)
+	}
+}
+
+func TestRenderColorBoardWidthOverride(t *testing.T) {
+	issues := []*model.Issue{
+		makeIssue(1, "Task A", model.StatusTodo, model.PriorityHigh),
+		makeIssue(2, "Task B", model.StatusDone, model.PriorityLow),
+	}
+
+	boardWidth := func(width int) int {
+		got := renderColorBoard(issues, BoardOptions{Layout: LayoutOptions{Width: width}})
+		widest := 0
+		for _, line := range strings.Split(got, "\n") {
+			widest = max(widest, lipgloss.Width(line))
+		}
+		return widest
+	}
+
+	// Two columns of (width-1)/2 each.
+	if got := boardWidth(61); got != 60 {
+		t.Errorf("board width with --width 61 = %d, want 60", got)
+	}
+	if got := boardWidth(121); got != 120 {
+		t.Errorf("board width with --width 121 = %d, want 120", got)
+	}
+	if boardWidth(61) != boardWidth(61) {
+		t.Error("board width is not deterministic")
+	}
+}
+
+func TestRenderColorCardNoTruncateWrapsTitle(t *testing.T) {
+	longTitle := "Refactor the storage layer so that every query retries"
+	issue := makeIssue(1, longTitle, model.StatusTodo, model.PriorityHigh)
+
+	if got := renderColorCard(issue, 24, 20, BoardOptions{}); !strings.Contains(got, "...") {
+		t.Errorf("expected truncated title by default, got:\n%s", got)
+	}
+
+	got := renderColorCard(issue, 24, 20, BoardOptions{Layout: LayoutOptions{NoTruncate: true}})
+	if strings.Contains(got, "...") {
+		t.Errorf("expected no ellipsis with NoTruncate, got:\n%s", got)
+	}
+	for _, word := range strings.Fields(longTitle) {
+		if !strings.Contains(got, word) {
+			t.Errorf("expected wrapped card to keep %q, got:\n%s", word, got)
+		}
+	}
+}
+
+func TestColumnHeaderStyle_StatusColors(t *testing.T) {
+	opts := BoardOptions{StatusColors: StatusColors{model.StatusDone: "blue"}}
+
+	if got, want := columnHeaderStyle(model.StatusDone, 20, opts).GetForeground(), ColorFromName("blue"); got != want {
+		t.Errorf("done header color = %v, want override %v", got, want)
+	}
+	if got, want := columnHeaderStyle(model.StatusTodo, 20, opts).GetForeground(), ColorFromName(model.StatusTodo.Color()); got != want {
+		t.Errorf("todo header color = %v, want default %v", got, want)
+	}
+	if got, want := columnHeaderStyle(model.StatusDone, 20, BoardOptions{}).GetForeground(), ColorFromName(model.StatusDone.Color()); got != want {
+		t.Errorf("done header color without overrides = %v, want default %v", got, want)
+	}
+}
+
+func TestParseStatusColors(t *testing.T) {
+	colors, err := ParseStatusColors(map[string]string{"done": " Blue ", "in-progress": "magenta"})
+	if err != nil {
+		t.Fatalf("ParseStatusColors: %v", err)
+	}
+	if colors.Color(model.StatusDone) != "blue" || colors.Color(model.StatusInProgress) != "magenta" {
+		t.Errorf("overrides = %v", colors)
+	}
+	if got := colors.Color(model.StatusReview); got != model.StatusReview.Color() {
+		t.Errorf("review color = %q, want default %q", got, model.StatusReview.Color())
+	}
+
+	for _, raw := range []map[string]string{{"finished": "blue"}, {"done": "teal"}} {
+		if _, err := ParseStatusColors(raw); err == nil {
+			t.Errorf("ParseStatusColors(%v) succeeded, want an error", raw)
+		}
+	}
+}
+
+func TestRenderPlainBoardShowsPinnedPastLimit(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	var issues []*model.Issue
+	for i := 1; i <= 4; i++ {
+		issues = append(issues, makeIssue(i, "Task", model.StatusTodo, model.PriorityMedium))
+	}
+	// The pinned card sorts last here but is still shown, first, and does
+	// not take one of the two slots.
+	issues[3].Pinned = true
+
+	got := RenderBoard(issues, BoardOptions{PerColumn: 2})
+	for _, want := range []string{"[pinned] DKT-4", "DKT-1", "DKT-2", "+1 more"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "DKT-3") {
+		t.Errorf("expected DKT-3 to overflow, got:\n%s", got)
+	}
+	if strings.Index(got, "DKT-4") > strings.Index(got, "DKT-1") {
+		t.Errorf("expected the pinned card first, got:\n%s", got)
+	}
+}
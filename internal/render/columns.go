@@ -0,0 +1,212 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// TableColumns lists issue table columns by key in display order. See
+// TableColumnKeys for the valid keys.
+type TableColumns []string
+
+// TableColumnKeys lists the valid issue table column keys.
+var TableColumnKeys = []string{"id", "status", "priority", "type", "title", "assignee", "comments", "deps", "updated", "labels"}
+
+// ParseTableColumns parses a comma-separated list of column keys such as
+// "id,title,labels", rejecting unknown and repeated keys.
+func ParseTableColumns(s string) (TableColumns, error) {
+	var cols TableColumns
+	seen := make(map[string]bool)
+	for _, key := range strings.Split(s, ",") {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+		if _, ok := issueColumnsByKey[key]; !ok {
+			return nil, fmt.Errorf("unknown column %q: must be one of %s", key, strings.Join(TableColumnKeys, ", "))
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("column %q listed more than once", key)
+		}
+		seen[key] = true
+		cols = append(cols, key)
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("no columns given: choose from %s", strings.Join(TableColumnKeys, ", "))
+	}
+	return cols, nil
+}
+
+// issueColumn describes one column of an issue table.
+type issueColumn struct {
+	header string
+	// headerWidth and cellWidth pad plain-text headers and cells in flat
+	// tables, sectionHeaderWidth and sectionCellWidth in grouped sections.
+	// The last column is never padded.
+	headerWidth, cellWidth               int
+	sectionHeaderWidth, sectionCellWidth int
+	cell                                 func(issue *model.Issue, opts LayoutOptions) string
+	// style adds the column's color styling to s for issue.
+	style func(s lipgloss.Style, issue *model.Issue, opts LayoutOptions) lipgloss.Style
+}
+
+var issueColumnsByKey = map[string]issueColumn{
+	"id": {
+		header: "ID", headerWidth: 10, cellWidth: 10, sectionHeaderWidth: 9, sectionCellWidth: 9,
+		cell: func(issue *model.Issue, _ LayoutOptions) string { return LinkedID(issue.ID) },
+		style: func(s lipgloss.Style, _ *model.Issue, _ LayoutOptions) lipgloss.Style {
+			return s.Foreground(lipgloss.Color("15"))
+		},
+	},
+	"status": {
+		header: "Status", headerWidth: 14, cellWidth: 16, sectionHeaderWidth: 15, sectionCellWidth: 17,
+		cell: func(issue *model.Issue, _ LayoutOptions) string { return statusLabel(issue.Status) },
+		style: func(s lipgloss.Style, issue *model.Issue, opts LayoutOptions) lipgloss.Style {
+			return s.Foreground(ColorFromName(opts.StatusColors.Color(issue.Status)))
+		},
+	},
+	"priority": {
+		header: "Priority", headerWidth: 18, cellWidth: 18, sectionHeaderWidth: 17, sectionCellWidth: 17,
+		cell: func(issue *model.Issue, _ LayoutOptions) string {
+			return fmt.Sprintf("%s %s", issue.Priority.Icon(), string(issue.Priority))
+		},
+		style: func(s lipgloss.Style, issue *model.Issue, _ LayoutOptions) lipgloss.Style {
+			return s.Foreground(ColorFromName(issue.Priority.Color()))
+		},
+	},
+	"type": {
+		header: "Type", headerWidth: 10, cellWidth: 12, sectionHeaderWidth: 11, sectionCellWidth: 13,
+		cell: func(issue *model.Issue, _ LayoutOptions) string {
+			return fmt.Sprintf("%s %s", issue.Kind.Icon(), string(issue.Kind))
+		},
+		style: func(s lipgloss.Style, issue *model.Issue, _ LayoutOptions) lipgloss.Style {
+			return s.Foreground(ColorFromName(issue.Kind.Color()))
+		},
+	},
+	"title": {
+		header: "Title", headerWidth: 40, cellWidth: 40, sectionHeaderWidth: 39, sectionCellWidth: 39,
+		cell: func(issue *model.Issue, opts LayoutOptions) string {
+			return opts.markedTitle(issue)
+		},
+		style: func(s lipgloss.Style, _ *model.Issue, _ LayoutOptions) lipgloss.Style {
+			return s.Bold(true)
+		},
+	},
+	"assignee": {
+		header: "Assignee", headerWidth: 15, cellWidth: 15, sectionHeaderWidth: 14, sectionCellWidth: 14,
+		cell: func(issue *model.Issue, _ LayoutOptions) string { return issue.Assignee },
+	},
+	"comments": {
+		header: "Comments", headerWidth: 9, cellWidth: 9, sectionHeaderWidth: 9, sectionCellWidth: 9,
+		cell: func(issue *model.Issue, _ LayoutOptions) string { return commentCell(issue) },
+	},
+	"deps": {
+		header: "Deps", headerWidth: 8, cellWidth: 8, sectionHeaderWidth: 8, sectionCellWidth: 8,
+		cell: func(issue *model.Issue, _ LayoutOptions) string { return degreeCell(issue) },
+		style: func(s lipgloss.Style, _ *model.Issue, _ LayoutOptions) lipgloss.Style {
+			return s.Foreground(lipgloss.Color("11"))
+		},
+	},
+	"updated": {
+		header: "Updated", headerWidth: 14, cellWidth: 14, sectionHeaderWidth: 13, sectionCellWidth: 13,
+		cell: func(issue *model.Issue, _ LayoutOptions) string { return humanize.Time(issue.UpdatedAt) },
+	},
+	"labels": {
+		header: "Labels", headerWidth: 20, cellWidth: 20, sectionHeaderWidth: 19, sectionCellWidth: 19,
+		cell: func(issue *model.Issue, o LayoutOptions) string { return o.fitLabels(issue.Labels) },
+		style: func(s lipgloss.Style, _ *model.Issue, _ LayoutOptions) lipgloss.Style {
+			return s.Foreground(lipgloss.Color("13"))
+		},
+	},
+}
+
+// issueColumns returns the columns selected by o.Columns. Without a
+// selection it returns the default layout, which gains a comment-count
+// column when any of issues has comments.
+func (o LayoutOptions) issueColumns(issues []*model.Issue) []issueColumn {
+	keys := []string(o.Columns)
+	if len(keys) == 0 {
+		keys = []string{"id", "status", "priority", "type", "title", "assignee", "updated"}
+		if anyComments(issues) {
+			keys = []string{"id", "status", "priority", "type", "title", "assignee", "comments", "updated"}
+		}
+	}
+	cols := make([]issueColumn, 0, len(keys))
+	for _, key := range keys {
+		if c, ok := issueColumnsByKey[key]; ok {
+			cols = append(cols, c)
+		}
+	}
+	return cols
+}
+
+// columnHeaders returns the header text of each column.
+func columnHeaders(columns []issueColumn) []string {
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.header
+	}
+	return headers
+}
+
+// issueRows returns the cell text of each issue for each column.
+func issueRows(issues []*model.Issue, columns []issueColumn, opts LayoutOptions) [][]string {
+	rows := make([][]string, 0, len(issues))
+	for _, issue := range issues {
+		row := make([]string, len(columns))
+		for i, c := range columns {
+			row[i] = c.cell(issue, opts)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// issueCellStyle returns a lipgloss table StyleFunc that colors each cell
+// according to its column and issue.
+func issueCellStyle(issues []*model.Issue, columns []issueColumn, opts LayoutOptions) func(row, col int) lipgloss.Style {
+	return func(row, col int) lipgloss.Style {
+		s := lipgloss.NewStyle().PaddingLeft(1).PaddingRight(1)
+
+		if row == table.HeaderRow {
+			return s.Bold(true).Foreground(lipgloss.Color("15"))
+		}
+
+		if row < 0 || row >= len(issues) || col < 0 || col >= len(columns) || columns[col].style == nil {
+			return s
+		}
+		return columns[col].style(s, issues[row], opts)
+	}
+}
+
+// plainTableWidth returns the width of a plain-text table row holding
+// columns: each column at the wider of its header and cell widths, one space
+// between them. section selects the widths used in grouped sections.
+func plainTableWidth(columns []issueColumn, section bool) int {
+	width := max(len(columns)-1, 0)
+	for _, c := range columns {
+		if section {
+			width += max(c.sectionHeaderWidth, c.sectionCellWidth)
+		} else {
+			width += max(c.headerWidth, c.cellWidth)
+		}
+	}
+	return width
+}
+
+// writePlainCell writes text padded to width followed by a separating space,
+// or unpadded when it is the last cell of the line.
+func writePlainCell(b *strings.Builder, text string, width int, last bool) {
+	if last {
+		b.WriteString(text)
+		return
+	}
+	fmt.Fprintf(b, "%-*s ", width, text)
+}
@@ -0,0 +1,44 @@
+package render
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	humanize "github.com/dustin/go-humanize"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// commentSnippetLen is how many runes of a comment body RenderCommentSearch
+// shows.
+const commentSnippetLen = 80
+
+// RenderCommentSearch renders comments from across issues one per line, as
+// "DKT-7 · bob · 2h ago: " followed by the start of the body on one line.
+func RenderCommentSearch(comments []model.IssueComment) string {
+	idStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
+	authorStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	timeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+
+	lines := make([]string, 0, len(comments))
+	for _, c := range comments {
+		id, author, when := model.FormatID(c.IssueID), c.AuthorOrAnonymous(), humanize.Time(c.CreatedAt)
+		if ColorsEnabled() {
+			id, author, when = idStyle.Render(LinkedID(c.IssueID)), authorStyle.Render(author), timeStyle.Render(when)
+		}
+		lines = append(lines, id+" · "+author+" · "+when+": "+commentSnippet(c.Body))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// commentSnippet returns body with its whitespace collapsed to single
+// spaces, cut to its first 80 runes with "…" when longer.
+func commentSnippet(body string) string {
+	s := strings.Join(strings.Fields(body), " ")
+	if utf8.RuneCountInString(s) <= commentSnippetLen {
+		return s
+	}
+	return strings.TrimRight(string([]rune(s)[:commentSnippetLen]), " ") + "…"
+}
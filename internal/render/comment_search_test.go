@@ -0,0 +1,33 @@
+package render
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestRenderCommentSearch(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	long := strings.Repeat("word ", 30)
+	out := RenderCommentSearch([]model.IssueComment{
+		{Comment: model.Comment{IssueID: 7, Author: "bob", Body: "Line one\n\nline   two", CreatedAt: time.Now().Add(-2 * time.Hour)}},
+		{Comment: model.Comment{IssueID: 8, Body: long, CreatedAt: time.Now()}},
+	})
+
+	lines := strings.Split(out, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d:\n%s", len(lines), out)
+	}
+	if want := "DKT-7 · bob · 2 hours ago: Line one line two"; lines[0] != want {
+		t.Errorf("line 0 = %q, want %q", lines[0], want)
+	}
+	if !strings.HasPrefix(lines[1], "DKT-8 · anonymous · ") {
+		t.Errorf("line 1 = %q, want the anonymous author", lines[1])
+	}
+	if want := strings.TrimSpace(long[:80]) + "…"; !strings.HasSuffix(lines[1], ": "+want) {
+		t.Errorf("line 1 = %q, want the body cut to %q", lines[1], want)
+	}
+}
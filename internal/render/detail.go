@@ -3,6 +3,7 @@ package render
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	humanize "github.com/dustin/go-humanize"
 
@@ -10,13 +11,18 @@ import (
 	"github.com/charmbracelet/lipgloss/tree"
 
 	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/textdiff"
 )
 
 // RenderDetail renders a full issue detail view including metadata, description,
 // sub-issues, relations, linked proposals, comments, and recent activity.
-func RenderDetail(issue *model.Issue, subIssues []*model.Issue, relations []model.Relation, linkedProposals []model.Proposal, comments []*model.Comment, activity []model.Activity) string {
+// Sub-issue titles are fitted according to opts. Activity, most recent first,
+// is followed by a hint naming the hidden older entries left out of it, if
+// any.
+func RenderDetail(issue *model.Issue, subIssues []*model.Issue, relations []model.Relation, references []model.IssueReference, linkedProposals []model.Proposal, comments []*model.Comment, activity []model.Activity, hidden int, opts LayoutOptions) string {
+	relations = collapseSymmetricRelations(relations)
 	if !ColorsEnabled() {
-		return renderPlainDetail(issue, subIssues, relations, linkedProposals, comments, activity)
+		return renderPlainDetail(issue, subIssues, relations, references, linkedProposals, comments, activity, hidden, opts)
 	}
 
 	var sections []string
@@ -29,7 +35,11 @@ func RenderDetail(issue *model.Issue, subIssues []*model.Issue, relations []mode
 
 	// Files
 	if len(issue.Files) > 0 {
-		sections = append(sections, renderFiles(issue.Files))
+		sections = append(sections, renderFiles(issue.Files, opts))
+	}
+
+	if len(issue.Links) > 0 {
+		sections = append(sections, renderLinks(issue.Links))
 	}
 
 	if len(issue.Docs) > 0 {
@@ -43,7 +53,7 @@ func RenderDetail(issue *model.Issue, subIssues []*model.Issue, relations []mode
 
 	// Sub-issues
 	if len(subIssues) > 0 {
-		sections = append(sections, renderSubIssues(subIssues))
+		sections = append(sections, renderSubIssues(subIssues, opts))
 	}
 
 	// Relations
@@ -51,6 +61,14 @@ func RenderDetail(issue *model.Issue, subIssues []*model.Issue, relations []mode
 		sections = append(sections, renderRelations(issue.ID, relations))
 	}
 
+	outgoing, incoming := splitReferences(issue.ID, references)
+	if len(outgoing) > 0 {
+		sections = append(sections, renderReferences("References", outgoing, true))
+	}
+	if len(incoming) > 0 {
+		sections = append(sections, renderReferences("Referenced by", incoming, false))
+	}
+
 	if len(linkedProposals) > 0 {
 		sections = append(sections, renderLinkedProposals(linkedProposals))
 	}
@@ -62,7 +80,7 @@ func RenderDetail(issue *model.Issue, subIssues []*model.Issue, relations []mode
 
 	// Activity
 	if len(activity) > 0 {
-		sections = append(sections, renderActivity(activity))
+		sections = append(sections, renderActivity(issue.ID, activity, hidden, opts))
 	}
 
 	return strings.Join(sections, "\n\n")
@@ -83,7 +101,7 @@ func renderHeader(issue *model.Issue) string {
 
 	return fmt.Sprintf("%s %s  %s\n%s  %s",
 		kindStyle.Render(issue.Kind.Icon()),
-		idStyle.Render(model.FormatID(issue.ID)),
+		idStyle.Render(LinkedID(issue.ID)),
 		titleStyle.Render(issue.Title),
 		statusStyle.Render(statusLabel(issue.Status)),
 		priorityStyle.Render(fmt.Sprintf("%s %s", issue.Priority.Icon(), string(issue.Priority))),
@@ -102,28 +120,88 @@ func renderMetadata(issue *model.Issue) string {
 		lines = append(lines, fmt.Sprintf("%s %s", labelStyle.Render("Assignee:"), issue.Assignee))
 	}
 
+	if len(issue.Watchers) > 0 {
+		lines = append(lines, fmt.Sprintf("%s %s", labelStyle.Render("Watchers:"), strings.Join(issue.Watchers, ", ")))
+	}
+
 	if len(issue.Labels) > 0 {
 		lines = append(lines, fmt.Sprintf("%s %s", labelStyle.Render("Labels:"), strings.Join(issue.Labels, ", ")))
 	}
 
 	if issue.ParentID != nil {
-		lines = append(lines, fmt.Sprintf("%s %s", labelStyle.Render("Parent:"), model.FormatID(*issue.ParentID)))
+		lines = append(lines, fmt.Sprintf("%s %s", labelStyle.Render("Parent:"), LinkedID(*issue.ParentID)))
+	}
+
+	if issue.Milestone != "" {
+		lines = append(lines, fmt.Sprintf("%s %s", labelStyle.Render("Milestone:"), issue.Milestone))
 	}
 
 	lines = append(lines, fmt.Sprintf("%s %s", labelStyle.Render("Created:"), humanize.Time(issue.CreatedAt)))
 	lines = append(lines, fmt.Sprintf("%s %s", labelStyle.Render("Updated:"), humanize.Time(issue.UpdatedAt)))
+	if label, span := statusSpan(issue, time.Now()); label != "" {
+		lines = append(lines, fmt.Sprintf("%s %s", labelStyle.Render(label), span))
+	}
+	if issue.IsSnoozed(time.Now()) {
+		lines = append(lines, fmt.Sprintf("%s %s", labelStyle.Render("Snoozed until:"), SnoozeTime(issue.SnoozedUntil)))
+	}
 
 	return strings.Join(lines, "\n")
 }
 
-func renderFiles(files []string) string {
+// statusSpan describes how long a done issue took, e.g. ("Completed in",
+// "6d"), or how long an in-progress or in-review issue has been underway,
+// e.g. ("In progress for", "3d"). Cycle time runs from StartedAt, or from
+// CreatedAt for issues that never passed through in-progress. It returns an
+// empty label when neither applies.
+func statusSpan(issue *model.Issue, now time.Time) (label, span string) {
+	switch {
+	case issue.Status == model.StatusDone && !issue.CompletedAt.IsZero():
+		start := issue.StartedAt
+		if start.IsZero() || start.After(issue.CompletedAt) {
+			start = issue.CreatedAt
+		}
+		return "Completed in", formatSpan(issue.CompletedAt.Sub(start))
+	case (issue.Status == model.StatusInProgress || issue.Status == model.StatusReview) && !issue.StartedAt.IsZero():
+		return "In progress for", formatSpan(now.Sub(issue.StartedAt))
+	}
+	return "", ""
+}
+
+// formatSpan renders a duration like formatAge, but as "<1m" rather than
+// "now" for spans under a minute.
+func formatSpan(d time.Duration) string {
+	if d < time.Minute {
+		return "<1m"
+	}
+	return formatAge(d)
+}
+
+func renderFiles(files []string, opts LayoutOptions) string {
 	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
 	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
 	header := sectionStyle.Render("Files")
 
 	var lines []string
 	for _, f := range files {
-		lines = append(lines, "  "+dimStyle.Render("▸ "+f))
+		lines = append(lines, "  "+dimStyle.Render("▸ "+opts.filePath(f, 4)))
+	}
+
+	return header + "\n" + strings.Join(lines, "\n")
+}
+
+func renderLinks(links []model.IssueLink) string {
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	header := sectionStyle.Render("Links")
+
+	var lines []string
+	for _, l := range links {
+		if l.Title != "" {
+			lines = append(lines, "  "+dimStyle.Render("▸ ")+titleStyle.Render(l.Title)+" "+dimStyle.Render(l.URL))
+		} else {
+			lines = append(lines, "  "+dimStyle.Render("▸ "+l.URL))
+		}
 	}
 
 	return header + "\n" + strings.Join(lines, "\n")
@@ -178,7 +256,7 @@ func renderLinkedProposals(proposals []model.Proposal) string {
 			dimStyle.Render("▸"),
 			idStyle.Render(id)+strings.Repeat(" ", idWidth-len(id)),
 			status+strings.Repeat(" ", statusWidth-len(status)),
-			truncate(p.Description, maxTitleWidth),
+			truncate(p.Description, DefaultTitleWidth),
 		)
 		lines = append(lines, line)
 	}
@@ -198,7 +276,7 @@ func renderDescription(description string) string {
 	return header + "\n" + rendered
 }
 
-func renderSubIssues(subIssues []*model.Issue) string {
+func renderSubIssues(subIssues []*model.Issue, opts LayoutOptions) string {
 	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
 
 	// Count done issues for progress summary.
@@ -217,29 +295,31 @@ func renderSubIssues(subIssues []*model.Issue) string {
 
 	t := tree.New().Root(rootLabel)
 	for _, sub := range subIssues {
-		label := formatSubIssueNode(sub)
+		label := formatSubIssueNode(sub, opts)
 		t.Child(label)
 	}
 
 	return t.String()
 }
 
-func formatSubIssueNode(issue *model.Issue) string {
-	statusStyle := lipgloss.NewStyle().Foreground(ColorFromName(issue.Status.Color()))
+func formatSubIssueNode(issue *model.Issue, opts LayoutOptions) string {
 	priorityStyle := lipgloss.NewStyle().Foreground(ColorFromName(issue.Priority.Color()))
 	kindStyle := lipgloss.NewStyle().Foreground(ColorFromName(issue.Kind.Color()))
 
-	return fmt.Sprintf("%s %s %s %s %s",
-		statusStyle.Render(statusLabel(issue.Status)),
+	return fmt.Sprintf("%s %s %s",
 		priorityStyle.Render(issue.Priority.Icon()),
 		kindStyle.Render(issue.Kind.Icon()),
-		model.FormatID(issue.ID),
-		truncate(issue.Title, maxTitleWidth),
+		opts.IssueOneLine(issue),
 	)
 }
 
 // RelationArrow returns a directional arrow for the given relation type.
+// Custom types get a plain arrow pointing away from the source when
+// directional, and a double-headed one otherwise.
 func RelationArrow(rt model.RelationType, isSource bool) string {
+	if d, ok := model.LookupCustomRelationType(rt); ok && !d.Directional {
+		return "\u2194" // ↔
+	}
 	if isSource {
 		switch rt {
 		case model.RelationBlocks:
@@ -250,6 +330,8 @@ func RelationArrow(rt model.RelationType, isSource bool) string {
 			return "\u2194" // ↔
 		case model.RelationDuplicates:
 			return "\u2261" // ≡
+		case model.RelationSupersedes:
+			return "\u21d2" // ⇒
 		default:
 			return "\u2192" // →
 		}
@@ -264,6 +346,8 @@ func RelationArrow(rt model.RelationType, isSource bool) string {
 		return "\u2194" // ↔
 	case model.RelationDuplicates:
 		return "\u2261" // ≡
+	case model.RelationSupersedes:
+		return "\u21d0" // ⇐
 	default:
 		return "\u2190" // ←
 	}
@@ -282,7 +366,7 @@ func renderRelations(issueID int, relations []model.Relation) string {
 			line = fmt.Sprintf("  %s %s %s",
 				arrow,
 				typeStyle.Render(string(rel.RelationType)),
-				model.FormatID(rel.TargetIssueID),
+				LinkedID(rel.TargetIssueID),
 			)
 		} else {
 			typeStyle := lipgloss.NewStyle().Foreground(ColorFromName(RelationColor(rel.RelationType)))
@@ -290,16 +374,85 @@ func renderRelations(issueID int, relations []model.Relation) string {
 			line = fmt.Sprintf("  %s %s %s",
 				arrow,
 				typeStyle.Render(rel.RelationType.Inverse()),
-				model.FormatID(rel.SourceIssueID),
+				LinkedID(rel.SourceIssueID),
 			)
 		}
+		if rel.Note != "" {
+			line += " " + lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render("("+rel.Note+")")
+		}
 		lines = append(lines, line)
 	}
 
 	return header + "\n" + strings.Join(lines, "\n")
 }
 
-// RelationColor returns a color name for the given relation type.
+// collapseSymmetricRelations drops the second of any two symmetric
+// relations (relates_to, duplicates) linking the same pair of issues, so
+// legacy data holding both "A relates_to B" and "B relates_to A" renders a
+// single line. Directional relations are kept as they are.
+func collapseSymmetricRelations(relations []model.Relation) []model.Relation {
+	type pair struct {
+		low, high int
+		rt        model.RelationType
+	}
+	seen := make(map[pair]bool)
+	collapsed := make([]model.Relation, 0, len(relations))
+	for _, rel := range relations {
+		if !rel.RelationType.IsDirectional() {
+			key := pair{min(rel.SourceIssueID, rel.TargetIssueID), max(rel.SourceIssueID, rel.TargetIssueID), rel.RelationType}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		collapsed = append(collapsed, rel)
+	}
+	return collapsed
+}
+
+// splitReferences separates references made by issueID from references to it.
+func splitReferences(issueID int, references []model.IssueReference) (outgoing, incoming []model.IssueReference) {
+	for _, r := range references {
+		if r.FromIssueID == issueID {
+			outgoing = append(outgoing, r)
+		} else {
+			incoming = append(incoming, r)
+		}
+	}
+	return outgoing, incoming
+}
+
+// referenceLine formats a single reference as "→ DKT-12  in comment #4",
+// naming the target for outgoing references and the source otherwise.
+func referenceLine(r model.IssueReference, outgoing bool) (arrow, id, where string) {
+	if outgoing {
+		arrow, id = "→", LinkedID(r.ToIssueID)
+	} else {
+		arrow, id = "←", LinkedID(r.FromIssueID)
+	}
+	where = "in " + string(r.Context)
+	if r.CommentID != nil {
+		where = fmt.Sprintf("in comment #%d", *r.CommentID)
+	}
+	return arrow, id, where
+}
+
+func renderReferences(title string, references []model.IssueReference, outgoing bool) string {
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	header := sectionStyle.Render(title)
+
+	var lines []string
+	for _, r := range references {
+		arrow, id, where := referenceLine(r, outgoing)
+		lines = append(lines, fmt.Sprintf("  %s %s  %s", arrow, id, dimStyle.Render(where)))
+	}
+
+	return header + "\n" + strings.Join(lines, "\n")
+}
+
+// RelationColor returns a color name for the given relation type: the
+// color registered for a custom type, or white when it has none.
 func RelationColor(rt model.RelationType) string {
 	switch rt {
 	case model.RelationBlocks:
@@ -310,9 +463,13 @@ func RelationColor(rt model.RelationType) string {
 		return "blue"
 	case model.RelationDuplicates:
 		return "gray"
-	default:
-		return "white"
+	case model.RelationSupersedes:
+		return "magenta"
 	}
+	if d, ok := model.LookupCustomRelationType(rt); ok && d.Color != "" {
+		return d.Color
+	}
+	return "white"
 }
 
 // RenderCommentList renders a styled comment list. Exported for reuse by the
@@ -346,6 +503,12 @@ func renderComments(comments []*model.Comment) string {
 	return header + "\n" + strings.Join(parts, "\n\n")
 }
 
+// activityActor returns who made an activity entry, or the anonymous author
+// ("system" by default) when no author was recorded.
+func activityActor(a model.Activity) string {
+	return a.ActorOrSystem()
+}
+
 // activityIcon returns a semantic icon for an activity entry.
 func activityIcon(a model.Activity) string {
 	if a.FieldChanged == "created" {
@@ -360,7 +523,30 @@ func activityIcon(a model.Activity) string {
 	return "\u270e" // ✎
 }
 
-func renderActivity(activity []model.Activity) string {
+// ActivityDiffSummary returns a line count summary such as "+12/-3 lines"
+// for an activity entry that records a diff, as description edits do. ok is
+// false for entries that hold full old and new values, other than
+// description edits recorded before they were stored as diffs.
+func ActivityDiffSummary(a model.Activity) (summary string, ok bool) {
+	diff, ok := activityDiffText(a)
+	if !ok {
+		return "", false
+	}
+	added, removed, ok := textdiff.Stats(diff)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("+%d/-%d lines", added, removed), true
+}
+
+// moreActivityHint points at 'docket issue log' for the hidden activity
+// entries the detail view left out, e.g. "… and 14 more (docket issue log
+// DKT-5)".
+func moreActivityHint(issueID, hidden int) string {
+	return fmt.Sprintf("… and %d more (docket issue log %s)", hidden, model.FormatID(issueID))
+}
+
+func renderActivity(issueID int, activity []model.Activity, hidden int, opts LayoutOptions) string {
 	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
 	fieldStyle := lipgloss.NewStyle().Bold(true)
 	timeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
@@ -376,26 +562,38 @@ func renderActivity(activity []model.Activity) string {
 				icon,
 				timeStyle.Render(humanize.Time(a.CreatedAt)),
 			)
+		} else if summary, ok := ActivityDiffSummary(a); ok {
+			line = fmt.Sprintf("  %s %s edited %s (%s)  %s",
+				icon,
+				activityActor(a),
+				fieldStyle.Render(a.FieldChanged),
+				summary,
+				timeStyle.Render(humanize.Time(a.CreatedAt)),
+			)
 		} else {
-			actor := a.ChangedBy
-			if actor == "" {
-				actor = "system"
-			}
 			line = fmt.Sprintf("  %s %s changed %s  %s",
 				icon,
-				actor,
+				activityActor(a),
 				fieldStyle.Render(a.FieldChanged),
 				timeStyle.Render(humanize.Time(a.CreatedAt)),
 			)
 		}
 		lines = append(lines, line)
+		if diff, ok := ActivityDiff(a, opts, "      "); ok {
+			lines = append(lines, diff)
+		}
+	}
+
+	if hidden > 0 {
+		lines = append(lines, "  "+timeStyle.Render(moreActivityHint(issueID, hidden)))
 	}
 
 	return header + "\n" + strings.Join(lines, "\n")
 }
 
 // renderPlainDetail renders a detail view without any color or styling.
-func renderPlainDetail(issue *model.Issue, subIssues []*model.Issue, relations []model.Relation, linkedProposals []model.Proposal, comments []*model.Comment, activity []model.Activity) string {
+// hidden is the number of activity entries left out of activity.
+func renderPlainDetail(issue *model.Issue, subIssues []*model.Issue, relations []model.Relation, references []model.IssueReference, linkedProposals []model.Proposal, comments []*model.Comment, activity []model.Activity, hidden int, opts LayoutOptions) string {
 	var b strings.Builder
 
 	// Header
@@ -408,20 +606,43 @@ func renderPlainDetail(issue *model.Issue, subIssues []*model.Issue, relations [
 	if issue.Assignee != "" {
 		fmt.Fprintf(&b, "Assignee: %s\n", issue.Assignee)
 	}
+	if len(issue.Watchers) > 0 {
+		fmt.Fprintf(&b, "Watchers: %s\n", strings.Join(issue.Watchers, ", "))
+	}
 	if len(issue.Labels) > 0 {
 		fmt.Fprintf(&b, "Labels: %s\n", strings.Join(issue.Labels, ", "))
 	}
 	if issue.ParentID != nil {
 		fmt.Fprintf(&b, "Parent: %s\n", model.FormatID(*issue.ParentID))
 	}
+	if issue.Milestone != "" {
+		fmt.Fprintf(&b, "Milestone: %s\n", issue.Milestone)
+	}
 	fmt.Fprintf(&b, "Created: %s\n", humanize.Time(issue.CreatedAt))
 	fmt.Fprintf(&b, "Updated: %s\n", humanize.Time(issue.UpdatedAt))
+	if label, span := statusSpan(issue, time.Now()); label != "" {
+		fmt.Fprintf(&b, "%s %s\n", label, span)
+	}
+	if issue.IsSnoozed(time.Now()) {
+		fmt.Fprintf(&b, "Snoozed until: %s\n", SnoozeTime(issue.SnoozedUntil))
+	}
 
 	// Files
 	if len(issue.Files) > 0 {
 		b.WriteString("\nFiles\n")
 		for _, f := range issue.Files {
-			fmt.Fprintf(&b, "  > %s\n", f)
+			fmt.Fprintf(&b, "  > %s\n", opts.filePath(f, 4))
+		}
+	}
+
+	if len(issue.Links) > 0 {
+		b.WriteString("\nLinks\n")
+		for _, l := range issue.Links {
+			if l.Title != "" {
+				fmt.Fprintf(&b, "  > %s %s\n", l.Title, l.URL)
+			} else {
+				fmt.Fprintf(&b, "  > %s\n", l.URL)
+			}
 		}
 	}
 
@@ -458,13 +679,7 @@ func renderPlainDetail(issue *model.Issue, subIssues []*model.Issue, relations [
 		}
 		fmt.Fprintf(&b, "\nSub-issues (%d/%d done)\n", doneCount, len(subIssues))
 		for _, sub := range subIssues {
-			fmt.Fprintf(&b, "  %s %s %s %s %s\n",
-				statusLabel(sub.Status),
-				sub.Priority.Icon(),
-				sub.Kind.Icon(),
-				model.FormatID(sub.ID),
-				truncate(sub.Title, maxTitleWidth),
-			)
+			fmt.Fprintf(&b, "  %s %s %s\n", sub.Priority.Icon(), sub.Kind.Icon(), opts.IssueOneLine(sub))
 		}
 	}
 
@@ -472,16 +687,39 @@ func renderPlainDetail(issue *model.Issue, subIssues []*model.Issue, relations [
 	if len(relations) > 0 {
 		b.WriteString("\nRelations\n")
 		for _, rel := range relations {
+			note := ""
+			if rel.Note != "" {
+				note = " (" + rel.Note + ")"
+			}
 			if rel.SourceIssueID == issue.ID {
 				arrow := RelationArrow(rel.RelationType, true)
-				fmt.Fprintf(&b, "  %s %s %s\n", arrow, string(rel.RelationType), model.FormatID(rel.TargetIssueID))
+				fmt.Fprintf(&b, "  %s %s %s%s\n", arrow, string(rel.RelationType), model.FormatID(rel.TargetIssueID), note)
 			} else {
 				arrow := RelationArrow(rel.RelationType, false)
-				fmt.Fprintf(&b, "  %s %s %s\n", arrow, rel.RelationType.Inverse(), model.FormatID(rel.SourceIssueID))
+				fmt.Fprintf(&b, "  %s %s %s%s\n", arrow, rel.RelationType.Inverse(), model.FormatID(rel.SourceIssueID), note)
 			}
 		}
 	}
 
+	outgoing, incoming := splitReferences(issue.ID, references)
+	for _, section := range []struct {
+		title    string
+		refs     []model.IssueReference
+		outgoing bool
+	}{
+		{"References", outgoing, true},
+		{"Referenced by", incoming, false},
+	} {
+		if len(section.refs) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s\n", section.title)
+		for _, r := range section.refs {
+			arrow, id, where := referenceLine(r, section.outgoing)
+			fmt.Fprintf(&b, "  %s %s  %s\n", arrow, id, where)
+		}
+	}
+
 	if len(linkedProposals) > 0 {
 		var idWidth, statusWidth int
 		for _, p := range linkedProposals {
@@ -493,7 +731,7 @@ func renderPlainDetail(issue *model.Issue, subIssues []*model.Issue, relations [
 			fmt.Fprintf(&b, "  > %-*s   %-*s   %s\n",
 				idWidth, model.FormatProposalID(p.ID),
 				statusWidth, string(p.Status),
-				truncate(p.Description, maxTitleWidth),
+				truncate(p.Description, DefaultTitleWidth),
 			)
 		}
 	}
@@ -513,14 +751,19 @@ func renderPlainDetail(issue *model.Issue, subIssues []*model.Issue, relations [
 			icon := activityIcon(a)
 			if a.FieldChanged == "created" {
 				fmt.Fprintf(&b, "  %s Issue created  %s\n", icon, humanize.Time(a.CreatedAt))
+			} else if summary, ok := ActivityDiffSummary(a); ok {
+				fmt.Fprintf(&b, "  %s %s edited %s (%s)  %s\n",
+					icon, activityActor(a), a.FieldChanged, summary, humanize.Time(a.CreatedAt))
 			} else {
-				actor := a.ChangedBy
-				if actor == "" {
-					actor = "system"
-				}
 				fmt.Fprintf(&b, "  %s %s changed %s  %s\n",
-					icon, actor, a.FieldChanged, humanize.Time(a.CreatedAt))
+					icon, activityActor(a), a.FieldChanged, humanize.Time(a.CreatedAt))
 			}
+			if diff, ok := ActivityDiff(a, opts, "      "); ok {
+				b.WriteString(diff + "\n")
+			}
+		}
+		if hidden > 0 {
+			fmt.Fprintf(&b, "  %s\n", moreActivityHint(issue.ID, hidden))
 		}
 	}
 
@@ -1,10 +1,13 @@
 package render
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ALT-F4-LLC/docket/internal/model"
+	"github.com/ALT-F4-LLC/docket/internal/textdiff"
 )
 
 func issueWithDocs(docs []model.DocRef) *model.Issue {
@@ -21,7 +24,7 @@ func TestRenderDetail_PlainLinkedDocsAfterFilesBeforeDescription(t *testing.T) {
 	issue.Files = []string{"internal/db/doc_links.go"}
 	issue.Description = "the description"
 
-	out := RenderDetail(issue, nil, nil, nil, nil, nil)
+	out := RenderDetail(issue, nil, nil, nil, nil, nil, nil, 0, LayoutOptions{})
 
 	if !strings.Contains(out, "\nLinked Docs\n") {
 		t.Fatalf("missing Linked Docs header:\n%s", out)
@@ -44,7 +47,7 @@ func TestRenderDetail_PlainLinkedDocsAligned(t *testing.T) {
 		{ID: 100, Type: "ux", Status: "draft", Title: "Beta"},
 	})
 
-	out := RenderDetail(issue, nil, nil, nil, nil, nil)
+	out := RenderDetail(issue, nil, nil, nil, nil, nil, nil, 0, LayoutOptions{})
 
 	wantLines := []string{
 		"  > DOC-3     tdd   approved   Alpha",
@@ -60,7 +63,7 @@ func TestRenderDetail_PlainLinkedDocsAligned(t *testing.T) {
 func TestRenderDetail_PlainOmitsLinkedDocsWhenEmpty(t *testing.T) {
 	t.Setenv("NO_COLOR", "1")
 	issue := issueWithDocs(nil)
-	out := RenderDetail(issue, nil, nil, nil, nil, nil)
+	out := RenderDetail(issue, nil, nil, nil, nil, nil, nil, 0, LayoutOptions{})
 	if strings.Contains(out, "Linked Docs") {
 		t.Errorf("empty docs should omit section:\n%s", out)
 	}
@@ -72,7 +75,7 @@ func TestRenderDetail_StyledLinkedDocsUsesArrowGlyph(t *testing.T) {
 		{ID: 3, Type: "tdd", Status: "approved", Title: "Docket Doc CLI"},
 	})
 
-	out := RenderDetail(issue, nil, nil, nil, nil, nil)
+	out := RenderDetail(issue, nil, nil, nil, nil, nil, nil, 0, LayoutOptions{})
 
 	if !strings.Contains(out, "Linked Docs") {
 		t.Fatalf("missing Linked Docs header:\n%s", out)
@@ -89,3 +92,214 @@ func TestRenderDetail_StyledLinkedDocsUsesArrowGlyph(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderDetail_PlainReferenceSections(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	issue := makeTestIssue(5, "Issue", model.StatusTodo, model.PriorityHigh, model.IssueKindFeature, nil)
+	commentID := 4
+	refs := []model.IssueReference{
+		{FromIssueID: 5, ToIssueID: 12, Context: model.ReferenceContextDescription},
+		{FromIssueID: 7, ToIssueID: 5, Context: model.ReferenceContextComment, CommentID: &commentID},
+	}
+
+	out := RenderDetail(issue, nil, nil, refs, nil, nil, nil, 0, LayoutOptions{})
+
+	for _, want := range []string{
+		"\nReferences\n  → DKT-12  in description\n",
+		"\nReferenced by\n  ← DKT-7  in comment #4\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Relations") {
+		t.Errorf("references must not render as relations:\n%s", out)
+	}
+}
+
+func TestRenderDetail_PlainCollapsesReciprocalRelatesTo(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	issue := makeTestIssue(1, "Issue", model.StatusTodo, model.PriorityHigh, model.IssueKindTask, nil)
+	relations := []model.Relation{
+		{ID: 1, SourceIssueID: 1, TargetIssueID: 2, RelationType: model.RelationRelatesTo},
+		{ID: 2, SourceIssueID: 2, TargetIssueID: 1, RelationType: model.RelationRelatesTo},
+		{ID: 3, SourceIssueID: 1, TargetIssueID: 3, RelationType: model.RelationBlocks},
+		{ID: 4, SourceIssueID: 3, TargetIssueID: 1, RelationType: model.RelationBlocks},
+	}
+
+	out := RenderDetail(issue, nil, relations, nil, nil, nil, nil, 0, LayoutOptions{})
+
+	if n := strings.Count(out, "relates_to DKT-2"); n != 1 {
+		t.Errorf("relates_to DKT-2 rendered %d times, want 1:\n%s", n, out)
+	}
+	for _, want := range []string{"blocks DKT-3", "blocked_by DKT-3"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("directional relation %q missing:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderDetail_PlainRelationNotes(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	issue := makeTestIssue(1, "Issue", model.StatusTodo, model.PriorityHigh, model.IssueKindTask, nil)
+	relations := []model.Relation{
+		{ID: 1, SourceIssueID: 1, TargetIssueID: 2, RelationType: model.RelationBlocks, Note: "needs the schema first"},
+		{ID: 2, SourceIssueID: 3, TargetIssueID: 1, RelationType: model.RelationBlocks, Note: "waits on the API"},
+		{ID: 3, SourceIssueID: 1, TargetIssueID: 4, RelationType: model.RelationRelatesTo},
+	}
+
+	out := RenderDetail(issue, nil, relations, nil, nil, nil, nil, 0, LayoutOptions{})
+
+	for _, want := range []string{
+		"blocks DKT-2 (needs the schema first)\n",
+		"blocked_by DKT-3 (waits on the API)\n",
+		"relates_to DKT-4\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in plain detail:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderDetail_SubIssueTitlesHonorNoTruncate(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	issue := makeTestIssue(1, "Parent", model.StatusTodo, model.PriorityHigh, model.IssueKindEpic, nil)
+	longTitle := "A sub-issue whose title is far longer than the default forty runes"
+	sub := makeTestIssue(2, longTitle, model.StatusTodo, model.PriorityHigh, model.IssueKindTask, intPtr(1))
+
+	if out := RenderDetail(issue, []*model.Issue{sub}, nil, nil, nil, nil, nil, 0, LayoutOptions{}); strings.Contains(out, longTitle) {
+		t.Errorf("expected sub-issue title truncated by default:\n%s", out)
+	}
+	out := RenderDetail(issue, []*model.Issue{sub}, nil, nil, nil, nil, nil, 0, LayoutOptions{NoTruncate: true})
+	if !strings.Contains(out, longTitle) {
+		t.Errorf("expected full sub-issue title with NoTruncate:\n%s", out)
+	}
+}
+
+func TestRenderDetail_PlainDescriptionDiffActivity(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	issue := makeTestIssue(5, "Issue", model.StatusTodo, model.PriorityHigh, model.IssueKindFeature, nil)
+	activity := []model.Activity{
+		{FieldChanged: "description", NewValue: textdiff.Unified("description", "a\nb", "a\nc\nd", 1), ChangedBy: "amy"},
+		{FieldChanged: "title", OldValue: "old", NewValue: "new", ChangedBy: "amy"},
+	}
+
+	out := RenderDetail(issue, nil, nil, nil, nil, nil, activity, 0, LayoutOptions{})
+
+	for _, want := range []string{"amy edited description (+2/-1 lines)", "      -b\n      +c\n      +d", "amy changed title"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderDetail_ActivityLimit(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	issue := makeTestIssue(5, "Issue", model.StatusTodo, model.PriorityHigh, model.IssueKindFeature, nil)
+	activity := make([]model.Activity, 10)
+	for i := range activity {
+		activity[i] = model.Activity{FieldChanged: fmt.Sprintf("field%d", i), ChangedBy: "amy"}
+	}
+
+	out := RenderDetail(issue, nil, nil, nil, nil, nil, activity[:3], 7, LayoutOptions{})
+	if got := strings.Count(out, "amy changed"); got != 3 {
+		t.Errorf("rendered %d activity entries, want 3:\n%s", got, out)
+	}
+	if !strings.Contains(out, "field2") || strings.Contains(out, "field3") {
+		t.Errorf("expected the 3 most recent entries:\n%s", out)
+	}
+	if !strings.Contains(out, "… and 7 more (docket issue log DKT-5)") {
+		t.Errorf("missing more hint:\n%s", out)
+	}
+
+	out = RenderDetail(issue, nil, nil, nil, nil, nil, activity[:2], 0, LayoutOptions{})
+	if got := strings.Count(out, "amy changed"); got != 2 {
+		t.Errorf("rendered %d activity entries, want 2:\n%s", got, out)
+	}
+	if strings.Contains(out, "more (docket issue log") {
+		t.Errorf("unexpected more hint with nothing hidden:\n%s", out)
+	}
+}
+
+func TestRenderDetail_PlainFilesKeepDirAndFilename(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	issue := makeTestIssue(1, "Paths", model.StatusTodo, model.PriorityLow, model.IssueKindTask, nil)
+	path := "internal/some/deeply/nested/package/that/goes/on/and/on/for/a/while/files.go"
+	issue.Files = []string{path}
+
+	out := RenderDetail(issue, nil, nil, nil, nil, nil, nil, 0, LayoutOptions{Width: 40})
+	if !strings.Contains(out, "  > internal/.../on/for/a/while/files.go\n") {
+		t.Errorf("long path not elided in the middle:\n%s", out)
+	}
+
+	out = RenderDetail(issue, nil, nil, nil, nil, nil, nil, 0, LayoutOptions{Width: 40, NoTruncate: true})
+	if !strings.Contains(out, "  > "+path+"\n") {
+		t.Errorf("--no-truncate should keep the full path:\n%s", out)
+	}
+}
+
+func TestStatusSpan(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := created.Add(10 * 24 * time.Hour)
+	tests := []struct {
+		name                string
+		status              model.Status
+		started, completed  time.Time
+		wantLabel, wantSpan string
+	}{
+		{"in progress", model.StatusInProgress, created.Add(7 * 24 * time.Hour), time.Time{}, "In progress for", "3d"},
+		{"in review", model.StatusReview, created.Add(9 * 24 * time.Hour), time.Time{}, "In progress for", "1d"},
+		{"completed", model.StatusDone, created.Add(2 * 24 * time.Hour), created.Add(8 * 24 * time.Hour), "Completed in", "6d"},
+		{"completed without start", model.StatusDone, time.Time{}, created.Add(5 * time.Hour), "Completed in", "5h"},
+		{"not started", model.StatusTodo, time.Time{}, time.Time{}, "", ""},
+		{"reopened", model.StatusTodo, created, time.Time{}, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := makeTestIssue(1, "Issue", tt.status, model.PriorityNone, model.IssueKindTask, nil)
+			issue.StartedAt, issue.CompletedAt = tt.started, tt.completed
+			label, span := statusSpan(issue, now)
+			if label != tt.wantLabel || span != tt.wantSpan {
+				t.Errorf("statusSpan = (%q, %q), want (%q, %q)", label, span, tt.wantLabel, tt.wantSpan)
+			}
+		})
+	}
+}
+
+func TestRenderDetail_PlainStatusSpan(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	issue := makeTestIssue(1, "Shipped", model.StatusDone, model.PriorityLow, model.IssueKindTask, nil)
+	issue.StartedAt = issue.CreatedAt.Add(24 * time.Hour)
+	issue.CompletedAt = issue.CreatedAt.Add(4 * 24 * time.Hour)
+
+	out := RenderDetail(issue, nil, nil, nil, nil, nil, nil, 0, LayoutOptions{})
+	if !strings.Contains(out, "Completed in 3d\n") {
+		t.Errorf("missing cycle time:\n%s", out)
+	}
+}
+
+func TestRenderDetail_PlainAnonymousAuthor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	t.Cleanup(func() { model.SetAnonymousAuthor("") })
+	issue := makeTestIssue(5, "Issue", model.StatusTodo, model.PriorityHigh, model.IssueKindFeature, nil)
+	comments := []*model.Comment{{IssueID: 5, Body: "note"}}
+	activity := []model.Activity{{FieldChanged: "title", OldValue: "old", NewValue: "new"}}
+
+	out := RenderDetail(issue, nil, nil, nil, nil, comments, activity, 0, LayoutOptions{})
+	for _, want := range []string{"anonymous", "system changed title"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("default: missing %q:\n%s", want, out)
+		}
+	}
+
+	model.SetAnonymousAuthor("bot")
+	out = RenderDetail(issue, nil, nil, nil, nil, comments, activity, 0, LayoutOptions{})
+	for _, want := range []string{"  bot  ", "bot changed title"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("configured: missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "anonymous") || strings.Contains(out, "system") {
+		t.Errorf("configured: default placeholder still shown:\n%s", out)
+	}
+}
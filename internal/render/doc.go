@@ -64,7 +64,7 @@ func docToRow(r DocRow) []string {
 		model.FormatDocID(r.Doc.ID),
 		r.Doc.Type,
 		r.Doc.Status,
-		truncate(r.Doc.Title, maxTitleWidth),
+		truncate(r.Doc.Title, DefaultTitleWidth),
 		r.Doc.Author,
 		fmt.Sprintf("%d", r.RevisionsCount),
 		humanize.Time(r.Doc.UpdatedAt),
@@ -83,7 +83,7 @@ func renderPlainDocList(rows []DocRow) string {
 			model.FormatDocID(r.Doc.ID),
 			r.Doc.Type,
 			r.Doc.Status,
-			truncate(r.Doc.Title, maxTitleWidth),
+			truncate(r.Doc.Title, DefaultTitleWidth),
 			r.Doc.Author,
 			r.RevisionsCount,
 			humanize.Time(r.Doc.UpdatedAt),
@@ -220,13 +220,8 @@ func renderDocComments(comments []*model.DocComment) string {
 			body = c.Body
 		}
 
-		author := c.Author
-		if author == "" {
-			author = "anonymous"
-		}
-
 		commentHeader := fmt.Sprintf("%s  %s",
-			authorStyle.Render(author),
+			authorStyle.Render(c.AuthorOrAnonymous()),
 			timeStyle.Render(humanize.Time(c.CreatedAt)),
 		)
 
@@ -335,11 +330,7 @@ func renderPlainDocDetail(doc *model.Doc, revisions []*model.DocRevision, commen
 	if len(comments) > 0 {
 		b.WriteString("\nComments\n")
 		for _, c := range comments {
-			author := c.Author
-			if author == "" {
-				author = "anonymous"
-			}
-			fmt.Fprintf(&b, "  %s  %s\n  %s\n\n", author, humanize.Time(c.CreatedAt), c.Body)
+			fmt.Fprintf(&b, "  %s  %s\n  %s\n\n", c.AuthorOrAnonymous(), humanize.Time(c.CreatedAt), c.Body)
 		}
 	}
 
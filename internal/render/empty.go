@@ -0,0 +1,72 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+)
+
+// createIssueHint is the empty-state hint shown when no issues exist at all.
+const createIssueHint = "Create one with: docket issue create"
+
+// ActiveFilter is a filter flag that was set on a listing command.
+type ActiveFilter struct {
+	Flag  string // flag name without dashes, e.g. "label"
+	Value string // the flag's value, or "" for boolean flags
+}
+
+// String formats the filter as "name=value", or just "name" for boolean
+// flags.
+func (f ActiveFilter) String() string {
+	if f.Value == "" {
+		return f.Flag
+	}
+	return f.Flag + "=" + f.Value
+}
+
+// FilterSummary describes the filters behind an issue listing, so that an
+// empty result can tell "nothing matches" apart from "nothing exists".
+type FilterSummary struct {
+	// Filters are the active filters, in the order the command lists its
+	// flags.
+	Filters []ActiveFilter
+	// IncludeDoneFlag, when set, is the flag that would bring back the done
+	// issues the listing left out, e.g. "--all".
+	IncludeDoneFlag string
+	// TotalIssues is the number of issues in the database. Only when it is
+	// zero does the empty state suggest creating one.
+	TotalIssues int
+}
+
+// IssuesEmptyState renders the empty state of an issue listing. With no
+// issues in the database it shows message and a hint to create one; with
+// active filters it names them and suggests which flags to drop; otherwise
+// it shows message, pointing at f.IncludeDoneFlag when done issues were
+// left out.
+func IssuesEmptyState(message string, f FilterSummary) string {
+	if f.TotalIssues == 0 {
+		return EmptyState(message, createIssueHint, false)
+	}
+
+	if len(f.Filters) == 0 {
+		if f.IncludeDoneFlag == "" {
+			return EmptyState(message, "", false)
+		}
+		return EmptyState(message, fmt.Sprintf("Use %s to include done issues.", f.IncludeDoneFlag), false)
+	}
+
+	names := make([]string, len(f.Filters))
+	flags := make([]string, len(f.Filters))
+	for i, filter := range f.Filters {
+		names[i] = filter.String()
+		flags[i] = "--" + filter.Flag
+	}
+	hint := "Try removing " + strings.Join(flags, ", ")
+	if f.IncludeDoneFlag != "" {
+		hint += " or use " + f.IncludeDoneFlag
+	}
+	return EmptyState(
+		fmt.Sprintf("No issues match your filters (%s).", strings.Join(names, ", ")),
+		hint+".",
+		false,
+	)
+}
@@ -0,0 +1,63 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIssuesEmptyStateFiltered(t *testing.T) {
+	summary := FilterSummary{
+		Filters: []ActiveFilter{
+			{Flag: "status", Value: "in-progress"},
+			{Flag: "label", Value: "backend"},
+			{Flag: "roots"},
+		},
+		IncludeDoneFlag: "--all",
+		TotalIssues:     3,
+	}
+
+	t.Run("plain", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		got := IssuesEmptyState("No issues found.", summary)
+		want := "No issues match your filters (status=in-progress, label=backend, roots).\n" +
+			"Try removing --status, --label, --roots or use --all."
+		if got != want {
+			t.Errorf("IssuesEmptyState = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("color", func(t *testing.T) {
+		t.Setenv("TERM", "xterm-256color")
+		got := IssuesEmptyState("No issues found.", summary)
+		if !strings.Contains(got, "status=in-progress, label=backend, roots") {
+			t.Errorf("colored empty state does not name the filters: %q", got)
+		}
+		if strings.Contains(got, createIssueHint) {
+			t.Errorf("colored empty state suggests creating an issue: %q", got)
+		}
+	})
+}
+
+func TestIssuesEmptyStateNoIssues(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	// Filters do not matter when the database is empty.
+	got := IssuesEmptyState("No issues on the board.", FilterSummary{
+		Filters: []ActiveFilter{{Flag: "label", Value: "backend"}},
+	})
+	if want := "No issues on the board.\n" + createIssueHint; got != want {
+		t.Errorf("IssuesEmptyState = %q, want %q", got, want)
+	}
+}
+
+func TestIssuesEmptyStateUnfiltered(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	if got := IssuesEmptyState("No issues to plan.", FilterSummary{TotalIssues: 2}); got != "No issues to plan." {
+		t.Errorf("without done flag = %q, want the bare message", got)
+	}
+	got := IssuesEmptyState("No issues found.", FilterSummary{IncludeDoneFlag: "--all", TotalIssues: 2})
+	if want := "No issues found.\nUse --all to include done issues."; got != want {
+		t.Errorf("with done flag = %q, want %q", got, want)
+	}
+}
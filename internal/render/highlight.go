@@ -0,0 +1,69 @@
+package render
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// highlightStyle emphasizes matched search terms when colors are enabled.
+var highlightStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("11"))
+
+// HighlightTerms wraps each case-insensitive occurrence of any of terms in
+// text in the highlight style, or in **...** when colors are disabled.
+// Overlapping and adjacent matches are merged into a single highlight, and
+// matching is done on runes so multibyte characters are never split. Text
+// with no matches is returned unchanged.
+func HighlightTerms(text string, terms []string) string {
+	runes := []rune(text)
+	matched := make([]bool, len(runes))
+	found := false
+	for _, term := range terms {
+		pattern := []rune(strings.TrimSpace(term))
+		if len(pattern) == 0 {
+			continue
+		}
+		for i := 0; i+len(pattern) <= len(runes); i++ {
+			if foldEqual(runes[i:i+len(pattern)], pattern) {
+				for j := i; j < i+len(pattern); j++ {
+					matched[j] = true
+				}
+				found = true
+			}
+		}
+	}
+	if !found {
+		return text
+	}
+
+	wrap := func(s string) string { return "**" + s + "**" }
+	if ColorsEnabled() {
+		wrap = func(s string) string { return highlightStyle.Render(s) }
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && matched[j] == matched[i] {
+			j++
+		}
+		if matched[i] {
+			b.WriteString(wrap(string(runes[i:j])))
+		} else {
+			b.WriteString(string(runes[i:j]))
+		}
+		i = j
+	}
+	return b.String()
+}
+
+// foldEqual reports whether a and b are equal ignoring case.
+func foldEqual(a, b []rune) bool {
+	for i := range a {
+		if unicode.ToLower(a[i]) != unicode.ToLower(b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHighlightTermsPlain(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	tests := []struct {
+		name  string
+		text  string
+		terms []string
+		want  string
+	}{
+		{"case insensitive", "Fix Login bug", []string{"login"}, "Fix **Login** bug"},
+		{"every occurrence", "go to go", []string{"GO"}, "**go** to **go**"},
+		{"overlapping", "database", []string{"data", "tab"}, "**datab**ase"},
+		{"adjacent", "foobar", []string{"foo", "bar"}, "**foobar**"},
+		{"multibyte", "Ünïcode naïve", []string{"ïCO", "NAÏ"}, "Ün**ïco**de **naï**ve"},
+		{"no match", "Refactor config", []string{"login"}, "Refactor config"},
+		{"blank term", "Refactor config", []string{" ", ""}, "Refactor config"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HighlightTerms(tt.text, tt.terms); got != tt.want {
+				t.Errorf("HighlightTerms(%q, %q) = %q, want %q", tt.text, tt.terms, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlightTermsColor(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+
+	got := HighlightTerms("Fix login bug", []string{"login"})
+	if !strings.HasPrefix(got, "Fix ") || !strings.HasSuffix(got, " bug") {
+		t.Errorf("non-matching text changed: %q", got)
+	}
+	if !strings.Contains(got, "login") || strings.Contains(got, "**") {
+		t.Errorf("match not styled: %q", got)
+	}
+	if got := HighlightTerms("Refactor config", []string{"login"}); got != "Refactor config" {
+		t.Errorf("text without matches = %q, want it unchanged", got)
+	}
+}
@@ -0,0 +1,124 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// notificationEvent describes what a notification reports, from the
+// recipient's point of view.
+func notificationEvent(n *model.Notification) string {
+	switch n.Kind {
+	case model.NotificationAssigned:
+		return "assigned you"
+	case model.NotificationMentioned:
+		return "mentioned you"
+	case model.NotificationStatusChanged:
+		return "changed status"
+	default:
+		return string(n.Kind)
+	}
+}
+
+// notificationActor returns who triggered n, or "someone" when unknown.
+func notificationActor(n *model.Notification) string {
+	if n.Actor == "" {
+		return "someone"
+	}
+	return n.Actor
+}
+
+// RenderInbox renders notifications with the issue each one concerns.
+// Unread notifications are marked with "*". Titles are fitted according to
+// opts.
+func RenderInbox(notifications []*model.Notification, opts LayoutOptions) string {
+	if len(notifications) == 0 {
+		return EmptyState("Inbox is empty.", "", false)
+	}
+
+	if !ColorsEnabled() {
+		return renderPlainInbox(notifications, opts)
+	}
+
+	headers := []string{"", "#", "Issue", "Title", "Status", "Event", "When"}
+
+	rows := make([][]string, 0, len(notifications))
+	for _, n := range notifications {
+		marker := ""
+		if n.Unread() {
+			marker = "*"
+		}
+		rows = append(rows, []string{
+			marker,
+			fmt.Sprint(n.ID),
+			LinkedID(n.IssueID),
+			opts.title(n.IssueTitle),
+			statusLabel(n.IssueStatus),
+			notificationActor(n) + " " + notificationEvent(n),
+			humanize.Time(n.CreatedAt),
+		})
+	}
+
+	tbl := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("8"))).
+		Headers(headers...).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			s := lipgloss.NewStyle().PaddingLeft(1).PaddingRight(1)
+
+			if row == table.HeaderRow {
+				return s.Bold(true).Foreground(lipgloss.Color("15"))
+			}
+
+			unread := notifications[row].Unread()
+			switch col {
+			case 0:
+				return s.Bold(true).Foreground(lipgloss.Color("11"))
+			case 2:
+				return s.Bold(true).Foreground(lipgloss.Color("15"))
+			case 3, 5:
+				return s.Bold(unread)
+			default:
+				return s.Foreground(lipgloss.Color("8"))
+			}
+		})
+	if opts.Width > 0 {
+		tbl = tbl.Width(opts.Width)
+	}
+
+	return tbl.Render()
+}
+
+func renderPlainInbox(notifications []*model.Notification, opts LayoutOptions) string {
+	var b strings.Builder
+
+	titleWidth := opts.titleWidth()
+	fmt.Fprintf(&b, "  %-5s %-8s %-*s %-12s %s\n", "#", "Issue", titleWidth, "Title", "Status", "Event")
+	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", 2+6+9+titleWidth+13+24))
+
+	for _, n := range notifications {
+		marker := " "
+		if n.Unread() {
+			marker = "*"
+		}
+		fmt.Fprintf(&b, "%s %-5d %-8s %-*s %-12s %s %s, %s\n",
+			marker,
+			n.ID,
+			model.FormatID(n.IssueID),
+			titleWidth, opts.title(n.IssueTitle),
+			string(n.IssueStatus),
+			notificationActor(n), notificationEvent(n),
+			humanize.Time(n.CreatedAt),
+		)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,97 @@
+package render
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// DefaultMaxLabels is how many labels table rows and board cards show
+// before summarizing the rest as "+k".
+const DefaultMaxLabels = 2
+
+// FitLabels joins labels for a table cell or board card, showing at most
+// limit of them followed by "+k" for the k left out, as in
+// "api, backend +2". Labels named in important are shown first, in that
+// order; the rest follow shortest first, ties broken by name, so the same
+// labels are chosen however the issue stores them. Labels that all fit keep
+// their given order. A limit of 0 or less shows every label.
+func FitLabels(labels []string, limit int, important []string) string {
+	if limit <= 0 || len(labels) <= limit {
+		return strings.Join(labels, ", ")
+	}
+
+	rank := make(map[string]int, len(important))
+	for i, name := range important {
+		if _, ok := rank[name]; !ok {
+			rank[name] = i
+		}
+	}
+	sorted := slices.Clone(labels)
+	slices.SortStableFunc(sorted, func(a, b string) int {
+		ra, aImportant := rank[a]
+		rb, bImportant := rank[b]
+		switch {
+		case aImportant && bImportant:
+			return cmp.Compare(ra, rb)
+		case aImportant:
+			return -1
+		case bImportant:
+			return 1
+		}
+		if c := cmp.Compare(utf8.RuneCountInString(a), utf8.RuneCountInString(b)); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+	return fmt.Sprintf("%s +%d", strings.Join(sorted[:limit], ", "), len(labels)-limit)
+}
+
+// fitLabels joins an issue's labels as FitLabels does, showing o.MaxLabels
+// of them (DefaultMaxLabels when 0, all when negative) with
+// o.ImportantLabels first. NoTruncate shows them all.
+func (o LayoutOptions) fitLabels(labels []string) string {
+	if o.NoTruncate {
+		return strings.Join(labels, ", ")
+	}
+	limit := o.MaxLabels
+	if limit == 0 {
+		limit = DefaultMaxLabels
+	}
+	return FitLabels(labels, limit, o.ImportantLabels)
+}
+
+// RenderLabelLegend renders a one-line key of labels, each with a swatch in
+// its color, e.g. "Labels: ■ backend  ■ ui". Labels are sorted by name;
+// those without a color get a gray swatch. Without colors each label's color
+// is named in parentheses instead.
+func RenderLabelLegend(labels []*model.Label) string {
+	sorted := slices.Clone(labels)
+	slices.SortFunc(sorted, func(a, b *model.Label) int { return strings.Compare(a.Name, b.Name) })
+
+	entries := make([]string, len(sorted))
+	if !ColorsEnabled() {
+		for i, l := range sorted {
+			entries[i] = l.Name
+			if l.Color != "" {
+				entries[i] += " (" + l.Color + ")"
+			}
+		}
+		return "Labels: " + strings.Join(entries, ", ")
+	}
+
+	for i, l := range sorted {
+		color := lipgloss.Color("8")
+		if l.Color != "" {
+			color = lipgloss.Color(l.Color)
+		}
+		entries[i] = lipgloss.NewStyle().Foreground(color).Render("■") + " " + l.Name
+	}
+	return lipgloss.NewStyle().Bold(true).Render("Labels:") + " " + strings.Join(entries, "  ")
+}
@@ -0,0 +1,69 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestFitLabels(t *testing.T) {
+	tests := []struct {
+		name      string
+		labels    []string
+		limit     int
+		important []string
+		want      string
+	}{
+		{"zero labels", nil, 2, nil, ""},
+		{"exactly limit keeps order", []string{"needs-design", "api"}, 2, nil, "needs-design, api"},
+		{"shortest first", []string{"backend", "infrastructure", "needs-design", "ui"}, 2, nil, "ui, backend +2"},
+		{"ties by name", []string{"zeta", "beta", "alfa"}, 2, nil, "alfa, beta +1"},
+		{"important first", []string{"backend", "infrastructure", "customer-reported"}, 2, []string{"customer-reported"}, "customer-reported, backend +1"},
+		{"important in configured order", []string{"b", "a", "c"}, 2, []string{"c", "a"}, "c, a +1"},
+		{"unicode names count runes", []string{"ベータ版", "backend", "ux"}, 2, nil, "ux, ベータ版 +1"},
+		{"no limit", []string{"backend", "api", "ui"}, 0, nil, "backend, api, ui"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FitLabels(tt.labels, tt.limit, tt.important); got != tt.want {
+				t.Errorf("FitLabels(%q, %d, %q) = %q, want %q", tt.labels, tt.limit, tt.important, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLayoutFitLabels(t *testing.T) {
+	labels := []string{"backend", "infrastructure", "needs-design"}
+	if got := (LayoutOptions{}).fitLabels(labels); got != "backend, needs-design +1" {
+		t.Errorf("default = %q", got)
+	}
+	if got := (LayoutOptions{MaxLabels: -1}).fitLabels(labels); got != "backend, infrastructure, needs-design" {
+		t.Errorf("negative MaxLabels = %q", got)
+	}
+	if got := (LayoutOptions{NoTruncate: true}).fitLabels(labels); got != "backend, infrastructure, needs-design" {
+		t.Errorf("NoTruncate = %q", got)
+	}
+}
+
+func TestRenderBoard_PlainCardsFitLabels(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	issue := makeTestIssue(1, "Card", model.StatusTodo, model.PriorityHigh, model.IssueKindTask, nil)
+	issue.Labels = []string{"backend", "infrastructure", "needs-design", "customer-reported"}
+
+	out := RenderBoard([]*model.Issue{issue}, BoardOptions{})
+	if !strings.Contains(out, "  backend, needs-design +2\n") {
+		t.Errorf("card labels not fitted:\n%s", out)
+	}
+}
+
+func TestRenderLabelLegend_Plain(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	got := RenderLabelLegend([]*model.Label{
+		{Name: "ui", Color: "magenta"},
+		{Name: "backend"},
+	})
+	if want := "Labels: backend, ui (magenta)"; got != want {
+		t.Errorf("RenderLabelLegend = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,44 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// linkTemplate is the template LinkedID builds hyperlink targets from, with
+// %s standing for the issue ID. Empty disables hyperlinks.
+var linkTemplate string
+
+// SetLinkTemplate sets the template LinkedID uses to turn issue IDs into
+// terminal hyperlinks, e.g. "https://tracker.example/issues/%s". An empty
+// template disables them.
+func SetLinkTemplate(tmpl string) {
+	linkTemplate = tmpl
+}
+
+// LinkedID returns the display ID of issue id. When colors are enabled and a
+// link template is set, the ID is wrapped in an OSC 8 hyperlink so that
+// terminals which support them make it clickable; otherwise it is the plain
+// ID with no escape sequences.
+func LinkedID(id int) string {
+	formatted := model.FormatID(id)
+	if linkTemplate == "" || !ColorsEnabled() {
+		return formatted
+	}
+	return hyperlink(LinkURL(id), formatted)
+}
+
+// LinkURL returns the link template's URL for issue id, or "" when no link
+// template is set.
+func LinkURL(id int) string {
+	if linkTemplate == "" {
+		return ""
+	}
+	return strings.ReplaceAll(linkTemplate, "%s", model.FormatID(id))
+}
+
+// hyperlink wraps text in an OSC 8 hyperlink to url.
+func hyperlink(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
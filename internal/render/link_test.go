@@ -0,0 +1,93 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+const testLinkTemplate = "https://tracker.example/issues/%s"
+
+func setTestLinkTemplate(t *testing.T, tmpl string) {
+	t.Helper()
+	SetLinkTemplate(tmpl)
+	t.Cleanup(func() { SetLinkTemplate("") })
+}
+
+func TestLinkedID(t *testing.T) {
+	tests := []struct {
+		name    string
+		noColor bool
+		term    string
+		tmpl    string
+		want    string
+	}{
+		{"colors with template", false, "xterm-256color", testLinkTemplate,
+			"\x1b]8;;https://tracker.example/issues/DKT-7\x1b\\DKT-7\x1b]8;;\x1b\\"},
+		{"no template", false, "xterm-256color", "", "DKT-7"},
+		{"NO_COLOR", true, "xterm-256color", testLinkTemplate, "DKT-7"},
+		{"dumb terminal", false, "dumb", testLinkTemplate, "DKT-7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TERM", tt.term)
+			if tt.noColor {
+				t.Setenv("NO_COLOR", "1")
+			}
+			setTestLinkTemplate(t, tt.tmpl)
+			if got := LinkedID(7); got != tt.want {
+				t.Errorf("LinkedID(7) = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlainOutputHasNoHyperlinks(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	setTestLinkTemplate(t, testLinkTemplate)
+
+	parent := 1
+	issues := []*model.Issue{
+		makeTestIssue(1, "Parent", model.StatusTodo, model.PriorityHigh, model.IssueKindFeature, nil),
+		makeTestIssue(2, "Child", model.StatusInProgress, model.PriorityLow, model.IssueKindTask, &parent),
+	}
+	relations := []model.Relation{{SourceIssueID: 1, TargetIssueID: 2, RelationType: model.RelationBlocks}}
+
+	outputs := map[string]string{
+		"table":  RenderTable(issues, false, LayoutOptions{}),
+		"tree":   RenderTreeList(issues, LayoutOptions{}),
+		"board":  RenderBoard(issues, BoardOptions{}),
+		"detail": RenderDetail(issues[0], issues[1:], relations, nil, nil, nil, nil, 0, LayoutOptions{}),
+	}
+	for name, out := range outputs {
+		if strings.Contains(out, "\x1b]8;") {
+			t.Errorf("%s output contains an OSC 8 hyperlink: %q", name, out)
+		}
+	}
+}
+
+func TestColorTableLinksIDsWithoutChangingLayout(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+
+	issues := []*model.Issue{
+		makeTestIssue(1, "First", model.StatusTodo, model.PriorityHigh, model.IssueKindFeature, nil),
+		makeTestIssue(12, "Second", model.StatusDone, model.PriorityLow, model.IssueKindBug, nil),
+	}
+	opts := LayoutOptions{Width: 100}
+	plain := RenderTable(issues, false, opts)
+
+	setTestLinkTemplate(t, testLinkTemplate)
+	linked := RenderTable(issues, false, opts)
+	if !strings.Contains(linked, "\x1b]8;;https://tracker.example/issues/DKT-12\x1b\\") {
+		t.Fatalf("table does not link DKT-12:\n%q", linked)
+	}
+
+	stripped := strings.ReplaceAll(linked, "\x1b]8;;\x1b\\", "")
+	for _, id := range []string{"DKT-1", "DKT-12"} {
+		stripped = strings.ReplaceAll(stripped, "\x1b]8;;https://tracker.example/issues/"+id+"\x1b\\", "")
+	}
+	if stripped != plain {
+		t.Errorf("hyperlinks changed the table layout:\nwith links:    %q\nwithout links: %q", stripped, plain)
+	}
+}
@@ -38,3 +38,25 @@ func RenderMarkdown(content string) (string, error) {
 
 	return strings.TrimSpace(rendered), nil
 }
+
+// markdownEscaper backslash-escapes characters that have special meaning in
+// Markdown.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`#`, `\#`,
+	`*`, `\*`,
+	`_`, `\_`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `\<`,
+	`>`, `\>`,
+	"`", "\\`",
+	`|`, `\|`,
+)
+
+// EscapeMarkdown replaces characters that have special meaning in Markdown so
+// that arbitrary user text can be safely embedded in headings, list items and
+// inline spans.
+func EscapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
@@ -0,0 +1,153 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// milestoneBarWidth is the number of cells in a milestone progress bar.
+const milestoneBarWidth = 20
+
+// RenderMilestoneList renders milestones with their due dates and progress.
+func RenderMilestoneList(summaries []model.MilestoneSummary) string {
+	if len(summaries) == 0 {
+		return EmptyState("No milestones found.", "Create one with: docket milestone create <name>", false)
+	}
+
+	if !ColorsEnabled() {
+		return renderPlainMilestoneList(summaries)
+	}
+
+	headers := []string{"Name", "Due", "State", "Progress"}
+
+	rows := make([][]string, 0, len(summaries))
+	for _, s := range summaries {
+		rows = append(rows, []string{
+			s.Name,
+			dueCell(s.Milestone),
+			milestoneState(s.Milestone),
+			MilestoneProgressBar(s.Done, s.Total, true),
+		})
+	}
+
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("8"))).
+		Headers(headers...).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			s := lipgloss.NewStyle().PaddingLeft(1).PaddingRight(1)
+
+			if row == table.HeaderRow {
+				return s.Bold(true).Foreground(lipgloss.Color("15"))
+			}
+
+			switch col {
+			case 0:
+				return s.Bold(true).Foreground(lipgloss.Color("15"))
+			case 2:
+				if summaries[row].Closed {
+					return s.Foreground(lipgloss.Color("8"))
+				}
+				return s.Foreground(lipgloss.Color("10"))
+			default:
+				return s
+			}
+		})
+
+	return t.Render()
+}
+
+func renderPlainMilestoneList(summaries []model.MilestoneSummary) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%-20s %-12s %-8s %s\n", "Name", "Due", "State", "Progress")
+	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", 72))
+
+	for _, s := range summaries {
+		fmt.Fprintf(&b, "%-20s %-12s %-8s %s\n",
+			truncate(s.Name, 20),
+			dueCell(s.Milestone),
+			milestoneState(s.Milestone),
+			MilestoneProgressBar(s.Done, s.Total, false),
+		)
+	}
+
+	return b.String()
+}
+
+// RenderMilestoneDetail renders a milestone header with a progress bar,
+// followed by its issues grouped by parent.
+func RenderMilestoneDetail(m *model.Milestone, done, total int, issues []*model.Issue, opts LayoutOptions) string {
+	var header string
+	if ColorsEnabled() {
+		nameStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
+		dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+		header = nameStyle.Render(m.Name) + "  " + dimStyle.Render(milestoneMeta(m))
+	} else {
+		header = m.Name + "  " + milestoneMeta(m)
+	}
+
+	lines := []string{header}
+	if m.Description != "" {
+		lines = append(lines, m.Description)
+	}
+	lines = append(lines, MilestoneProgressBar(done, total, ColorsEnabled()))
+
+	body := EmptyState("No issues in this milestone.", "Assign one with: docket issue update <id> --milestone "+m.Name, false)
+	if len(issues) > 0 {
+		body = RenderGroupedTable(issues, nil, nil, opts)
+	}
+
+	return strings.Join(lines, "\n") + "\n\n" + body
+}
+
+// MilestoneProgressBar renders done/total as a fixed-width bar followed by
+// the counts and percentage, e.g. "▰▰▰▱▱ 3/5 (60%)". The plain form uses
+// "#" and "-" so it survives terminals without Unicode support.
+func MilestoneProgressBar(done, total int, color bool) string {
+	filled := 0
+	percent := 0
+	if total > 0 {
+		filled = done * milestoneBarWidth / total
+		percent = done * 100 / total
+	}
+	empty := milestoneBarWidth - filled
+	suffix := fmt.Sprintf(" %d/%d (%d%%)", done, total, percent)
+
+	if !color {
+		return "[" + strings.Repeat("#", filled) + strings.Repeat("-", empty) + "]" + suffix
+	}
+
+	filledStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	emptyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	return filledStyle.Render(strings.Repeat("▰", filled)) +
+		emptyStyle.Render(strings.Repeat("▱", empty)) + suffix
+}
+
+// milestoneMeta summarizes a milestone's state and due date for its header.
+func milestoneMeta(m *model.Milestone) string {
+	if due := m.FormatDueDate(); due != "" {
+		return fmt.Sprintf("%s · due %s", milestoneState(m), due)
+	}
+	return milestoneState(m)
+}
+
+func milestoneState(m *model.Milestone) string {
+	if m.Closed {
+		return "closed"
+	}
+	return "open"
+}
+
+func dueCell(m *model.Milestone) string {
+	if due := m.FormatDueDate(); due != "" {
+		return due
+	}
+	return "-"
+}
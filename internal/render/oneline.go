@@ -0,0 +1,28 @@
+package render
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// IssueOneLine formats an issue on one line as "DKT-5 [● todo] title", with
+// the title truncated to DefaultTitleWidth. The ID and status are colored
+// when colors are enabled.
+func IssueOneLine(issue *model.Issue) string {
+	return LayoutOptions{}.IssueOneLine(issue)
+}
+
+// IssueOneLine formats an issue like the package-level IssueOneLine,
+// truncating the title according to o.
+func (o LayoutOptions) IssueOneLine(issue *model.Issue) string {
+	id := model.FormatID(issue.ID)
+	status := "[" + statusLabel(issue.Status) + "]"
+	if ColorsEnabled() {
+		id = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15")).Render(LinkedID(issue.ID))
+		status = lipgloss.NewStyle().Foreground(ColorFromName(o.StatusColors.Color(issue.Status))).Render(status)
+	}
+	return fmt.Sprintf("%s %s %s", id, status, o.title(issue.Title))
+}
@@ -0,0 +1,46 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestIssueOneLine_Plain(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	issue := makeTestIssue(5, "Fix login", model.StatusInProgress, model.PriorityHigh, model.IssueKindBug, nil)
+
+	if got, want := IssueOneLine(issue), "DKT-5 [◐ in-progress] Fix login"; got != want {
+		t.Errorf("IssueOneLine = %q, want %q", got, want)
+	}
+}
+
+func TestIssueOneLine_TruncatesTitle(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	long := strings.Repeat("x", DefaultTitleWidth+10)
+	issue := makeTestIssue(7, long, model.StatusTodo, model.PriorityLow, model.IssueKindTask, nil)
+
+	got := IssueOneLine(issue)
+	if want := "DKT-7 [● todo] " + strings.Repeat("x", DefaultTitleWidth-3) + "..."; got != want {
+		t.Errorf("IssueOneLine = %q, want %q", got, want)
+	}
+	if got := (LayoutOptions{NoTruncate: true}).IssueOneLine(issue); !strings.HasSuffix(got, long) {
+		t.Errorf("NoTruncate IssueOneLine = %q, want the full title", got)
+	}
+}
+
+func TestIssueOneLine_Styled(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+	issue := makeTestIssue(12, "Ship it", model.StatusDone, model.PriorityLow, model.IssueKindChore, nil)
+
+	got := IssueOneLine(issue)
+	for _, want := range []string{"DKT-12", "✔ done", "Ship it"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("styled IssueOneLine missing %q: %q", want, got)
+		}
+	}
+	if strings.Contains(got, "\n") {
+		t.Errorf("IssueOneLine spans lines: %q", got)
+	}
+}
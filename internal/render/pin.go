@@ -0,0 +1,16 @@
+package render
+
+import "github.com/ALT-F4-LLC/docket/internal/model"
+
+// pinnedMarker returns "📌 " for a pinned issue when colors are enabled,
+// "[pinned] " when they are not, or "" for an unpinned issue. It prefixes the
+// title so pinned issues stand out at the top of a listing.
+func pinnedMarker(issue *model.Issue) string {
+	if !issue.Pinned {
+		return ""
+	}
+	if ColorsEnabled() {
+		return "📌 "
+	}
+	return "[pinned] "
+}
@@ -0,0 +1,33 @@
+package render
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// SnoozeTime formats the end of a snooze in local time, as a date alone when
+// it falls on midnight, e.g. "2026-02-03" or "2026-02-03 14:30".
+func SnoozeTime(t time.Time) string {
+	t = t.Local()
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return t.Format(time.DateOnly)
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+// snoozedMarker returns " (snoozed until …)" for an issue that is snoozed
+// now, dimmed when colors are enabled, or "" otherwise. Listings only show
+// snoozed issues when asked to, so the marker tells them apart.
+func snoozedMarker(issue *model.Issue) string {
+	if !issue.IsSnoozed(time.Now()) {
+		return ""
+	}
+	marker := "(snoozed until " + SnoozeTime(issue.SnoozedUntil) + ")"
+	if ColorsEnabled() {
+		marker = lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render(marker)
+	}
+	return " " + marker
+}
@@ -0,0 +1,44 @@
+package render
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// StatusColors overrides the color names statuses are drawn in, e.g. to
+// swap red and green for colorblind users. Statuses it leaves out keep
+// status.Color().
+type StatusColors map[model.Status]string
+
+// Color returns the color name for status: its override, if any, or
+// status.Color().
+func (c StatusColors) Color(status model.Status) string {
+	if name, ok := c[status]; ok {
+		return name
+	}
+	return status.Color()
+}
+
+// ParseStatusColors validates a status-to-color mapping, such as the
+// status_colors table of a project config file. Keys must be statuses and
+// values must be in ColorNames.
+func ParseStatusColors(raw map[string]string) (StatusColors, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	colors := make(StatusColors, len(raw))
+	for status, name := range raw {
+		if err := model.ValidateStatus(model.Status(status)); err != nil {
+			return nil, fmt.Errorf("status_colors: %w", err)
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(ColorNames, name) {
+			return nil, fmt.Errorf("status_colors: invalid color %q for %s: must be one of %s", name, status, strings.Join(ColorNames, ", "))
+		}
+		colors[model.Status(status)] = name
+	}
+	return colors, nil
+}
@@ -1,13 +1,13 @@
 package render
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"unicode/utf8"
 
-	humanize "github.com/dustin/go-humanize"
-
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
 	"github.com/charmbracelet/lipgloss/tree"
@@ -15,7 +15,89 @@ import (
 	"github.com/ALT-F4-LLC/docket/internal/model"
 )
 
-const maxTitleWidth = 40
+// DefaultTitleWidth is the length, in runes, that issue titles are truncated
+// to unless LayoutOptions says otherwise.
+const DefaultTitleWidth = 40
+
+// LayoutOptions controls how wide rendered tables, trees, boards and detail
+// views may be and how issue titles are fitted into them. The zero value
+// probes the terminal for boards, leaves tables unconstrained and truncates
+// titles to DefaultTitleWidth.
+type LayoutOptions struct {
+	// Width overrides the detected terminal width. Tables are wrapped to fit
+	// it; 0 keeps the default behavior.
+	Width int
+	// TitleWidth is the maximum title length in runes; 0 means
+	// DefaultTitleWidth.
+	TitleWidth int
+	// NoTruncate shows titles in full, letting tables and cards wrap them.
+	NoTruncate bool
+	// Columns chooses and orders the columns of issue tables; nil keeps the
+	// default layout.
+	Columns TableColumns
+	// KeepOrder keeps issues in the order given rather than re-sorting
+	// grouped tables by status and priority. Set it when the caller sorted
+	// the issues on purpose.
+	KeepOrder bool
+	// MinGroupSize is the fewest children a parent needs for a grouped table
+	// to give it its own section; smaller groups are folded into the
+	// standalone section. 0 or 1 groups every parent.
+	MinGroupSize int
+	// StatusColors overrides the colors of statuses; nil keeps the defaults.
+	StatusColors StatusColors
+	// MaxLabels is how many labels table rows and board cards show before
+	// "+k"; 0 means DefaultMaxLabels and a negative value shows them all.
+	MaxLabels int
+	// ImportantLabels are shown ahead of other labels when not all fit.
+	ImportantLabels []string
+	// DiffHunks is how many hunks of a description edit activity views
+	// show; 0 means DefaultDiffHunks and a negative value shows them all.
+	DiffHunks int
+}
+
+// titleWidth returns the title truncation length in runes.
+func (o LayoutOptions) titleWidth() int {
+	if o.TitleWidth > 0 {
+		return o.TitleWidth
+	}
+	return DefaultTitleWidth
+}
+
+// fitTitle truncates a title to maxLen runes unless truncation is disabled.
+func (o LayoutOptions) fitTitle(title string, maxLen int) string {
+	if o.NoTruncate {
+		return title
+	}
+	return truncate(title, maxLen)
+}
+
+// title truncates an issue title to the configured title width.
+func (o LayoutOptions) title(title string) string {
+	return o.fitTitle(title, o.titleWidth())
+}
+
+// issueTitle truncates an issue's title to the configured title width less
+// the width of its pinned and snoozed markers, so that the title and the
+// markers around it together stay within the title column.
+func (o LayoutOptions) issueTitle(issue *model.Issue) string {
+	markers := lipgloss.Width(pinnedMarker(issue)) + lipgloss.Width(snoozedMarker(issue))
+	return o.fitTitle(issue.Title, max(o.titleWidth()-markers, 0))
+}
+
+// markedTitle returns an issue's fitted title between its pinned and snoozed
+// markers.
+func (o LayoutOptions) markedTitle(issue *model.Issue) string {
+	return pinnedMarker(issue) + o.issueTitle(issue) + snoozedMarker(issue)
+}
+
+// filePath fits a file path into the terminal width less indent columns,
+// eliding its middle, unless truncation is disabled.
+func (o LayoutOptions) filePath(path string, indent int) string {
+	if o.NoTruncate {
+		return path
+	}
+	return TruncateMiddle(path, terminalWidth(o.Width)-indent)
+}
 
 // StyledText applies a lipgloss style to text when colors are enabled.
 // When colors are disabled, it returns the plain text unchanged.
@@ -26,6 +108,9 @@ func StyledText(text string, style lipgloss.Style) string {
 	return text
 }
 
+// ColorNames lists the color names ColorFromName maps to a distinct color.
+var ColorNames = []string{"red", "yellow", "blue", "green", "magenta", "gray", "white"}
+
 // ColorFromName maps model color name strings to lipgloss colors.
 func ColorFromName(name string) lipgloss.Color {
 	switch name {
@@ -60,6 +145,44 @@ func truncate(s string, maxLen int) string {
 	return string(runes[:maxLen-3]) + "..."
 }
 
+// TruncateMiddle shortens s to maxLen runes by replacing its middle with
+// "...", keeping both ends readable. Slash-separated paths are cut at
+// separators where possible, keeping the leading directory and as much of the
+// tail as fits, e.g. "internal/.../files.go"; other strings, and paths whose
+// first and last segments alone are too long, keep runes from both ends.
+func TruncateMiddle(s string, maxLen int) string {
+	if utf8.RuneCountInString(s) <= maxLen {
+		return s
+	}
+	runes := []rune(s)
+	if maxLen <= 3 {
+		return string(runes[:max(maxLen, 0)])
+	}
+
+	if parts := strings.Split(s, "/"); len(parts) > 2 {
+		fits := func(head, tail int) (string, bool) {
+			out := strings.Join(parts[:head], "/") + "/.../" + strings.Join(parts[len(parts)-tail:], "/")
+			return out, utf8.RuneCountInString(out) <= maxLen
+		}
+		if best, ok := fits(1, 1); ok {
+			for head, tail := 1, 1; head+tail < len(parts)-1; {
+				if out, ok := fits(head, tail+1); ok {
+					best, tail = out, tail+1
+				} else if out, ok := fits(head+1, tail); ok {
+					best, head = out, head+1
+				} else {
+					break
+				}
+			}
+			return best
+		}
+	}
+
+	keep := maxLen - 3
+	head := keep / 2
+	return string(runes[:head]) + "..." + string(runes[len(runes)-(keep-head):])
+}
+
 // statusLabel returns a status string with icon, e.g. "✔ done".
 func statusLabel(s model.Status) string {
 	return s.Icon() + " " + string(s)
@@ -88,106 +211,82 @@ func EmptyState(message, hint string, quiet bool) string {
 
 // RenderTable renders a list of issues as a formatted table.
 // If treeMode is true, issues are rendered as an indented hierarchy instead.
-func RenderTable(issues []*model.Issue, treeMode bool) string {
+func RenderTable(issues []*model.Issue, treeMode bool, opts LayoutOptions) string {
 	if len(issues) == 0 {
 		return EmptyState("No issues found.", "Create one with: docket issue create", false)
 	}
 
 	if treeMode {
-		return RenderTreeList(issues)
-	}
-
-	if !ColorsEnabled() {
-		return renderPlainTable(issues)
+		return RenderTreeList(issues, opts)
 	}
 
-	headers := []string{"ID", "Status", "Priority", "Type", "Title", "Assignee", "Updated"}
-
-	rows := make([][]string, 0, len(issues))
-	for _, issue := range issues {
-		rows = append(rows, issueToRow(issue))
-	}
+	columns := opts.issueColumns(issues)
 
-	// Build color lookup for styling
-	type rowColors struct {
-		statusColor   string
-		priorityColor string
-		kindColor     string
-	}
-	colorMap := make([]rowColors, len(issues))
-	for i, issue := range issues {
-		colorMap[i] = rowColors{
-			statusColor:   issue.Status.Color(),
-			priorityColor: issue.Priority.Color(),
-			kindColor:     issue.Kind.Color(),
-		}
+	if !ColorsEnabled() {
+		return renderPlainTable(issues, columns, opts)
 	}
 
 	t := table.New().
 		Border(lipgloss.NormalBorder()).
 		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("8"))).
-		Headers(headers...).
-		Rows(rows...).
-		StyleFunc(func(row, col int) lipgloss.Style {
-			s := lipgloss.NewStyle().PaddingLeft(1).PaddingRight(1)
-
-			if row == table.HeaderRow {
-				return s.Bold(true).Foreground(lipgloss.Color("15"))
-			}
+		Headers(columnHeaders(columns)...).
+		Rows(issueRows(issues, columns, opts)...).
+		StyleFunc(issueCellStyle(issues, columns, opts))
+	if opts.Width > 0 {
+		t = t.Width(opts.Width)
+	}
 
-			if row < 0 || row >= len(colorMap) {
-				return s
-			}
+	return t.Render()
+}
 
-			rc := colorMap[row]
-			switch col {
-			case 0: // ID
-				return s.Foreground(lipgloss.Color("15"))
-			case 1: // Status
-				return s.Foreground(ColorFromName(rc.statusColor))
-			case 2: // Priority
-				return s.Foreground(ColorFromName(rc.priorityColor))
-			case 3: // Type
-				return s.Foreground(ColorFromName(rc.kindColor))
-			case 4: // Title
-				return s.Bold(true)
-			default:
-				return s
-			}
-		})
+// anyComments reports whether any issue has at least one comment, which is
+// when tables grow a comment-count column.
+func anyComments(issues []*model.Issue) bool {
+	for _, issue := range issues {
+		if issue.CommentCount > 0 {
+			return true
+		}
+	}
+	return false
+}
 
-	return t.Render()
+// commentCell formats the comment-count column, e.g. "💬 3", leaving it blank
+// for issues without comments.
+func commentCell(issue *model.Issue) string {
+	if issue.CommentCount == 0 {
+		return ""
+	}
+	return fmt.Sprintf("💬 %d", issue.CommentCount)
 }
 
-func issueToRow(issue *model.Issue) []string {
-	return []string{
-		model.FormatID(issue.ID),
-		statusLabel(issue.Status),
-		fmt.Sprintf("%s %s", issue.Priority.Icon(), string(issue.Priority)),
-		fmt.Sprintf("%s %s", issue.Kind.Icon(), string(issue.Kind)),
-		truncate(issue.Title, maxTitleWidth),
-		issue.Assignee,
-		humanize.Time(issue.UpdatedAt),
+// degreeCell renders the relation degrees of issue as "↑2 ↓1": blocked by
+// two issues and blocking one. Zero counts are left out, and the cell is
+// empty for issues with neither.
+func degreeCell(issue *model.Issue) string {
+	var parts []string
+	if issue.BlockedByCount > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d", issue.BlockedByCount))
+	}
+	if issue.BlocksCount > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d", issue.BlocksCount))
 	}
+	return strings.Join(parts, " ")
 }
 
-func renderPlainTable(issues []*model.Issue) string {
+func renderPlainTable(issues []*model.Issue, columns []issueColumn, opts LayoutOptions) string {
 	var b strings.Builder
 
-	fmt.Fprintf(&b, "%-10s %-14s %-18s %-10s %-40s %-15s %s\n",
-		"ID", "Status", "Priority", "Type", "Title", "Assignee", "Updated")
-	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", 120))
+	for i, c := range columns {
+		writePlainCell(&b, c.header, c.headerWidth, i == len(columns)-1)
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", plainTableWidth(columns, false)))
 
 	for _, issue := range issues {
-		fmt.Fprintf(&b, "%-10s %-16s %-18s %-12s %-40s %-15s %s\n",
-			model.FormatID(issue.ID),
-			statusLabel(issue.Status),
-			fmt.Sprintf("%s %s", issue.Priority.Icon(), string(issue.Priority)),
-			fmt.Sprintf("%s %s", issue.Kind.Icon(), string(issue.Kind)),
-			truncate(issue.Title, maxTitleWidth),
-			issue.Assignee,
-			humanize.Time(issue.UpdatedAt),
-		)
+		for i, c := range columns {
+			writePlainCell(&b, c.cell(issue, opts), c.cellWidth, i == len(columns)-1)
+		}
+		b.WriteString("\n")
 	}
 
 	return b.String()
@@ -195,13 +294,13 @@ func renderPlainTable(issues []*model.Issue) string {
 
 // RenderTreeList renders issues as an indented hierarchy using tree lines.
 // Root issues (no parent) are top-level nodes; sub-issues are children.
-func RenderTreeList(issues []*model.Issue) string {
+func RenderTreeList(issues []*model.Issue, opts LayoutOptions) string {
 	if len(issues) == 0 {
 		return EmptyState("No issues found.", "Create one with: docket issue create", false)
 	}
 
 	if !ColorsEnabled() {
-		return renderPlainTree(issues)
+		return renderPlainTree(issues, opts)
 	}
 
 	// Group children by parent.
@@ -220,53 +319,54 @@ func RenderTreeList(issues []*model.Issue) string {
 	if len(roots) == 0 {
 		roots = issues
 	}
+	orderSiblings(children)
 
 	t := tree.New().Root("Issues")
 
 	for _, root := range roots {
-		node := tree.Root(formatTreeNode(root))
-		addTreeChildren(node, root.ID, children)
+		node := tree.Root(formatTreeNode(root, opts))
+		addTreeChildren(node, root.ID, children, opts)
 		t.Child(node)
 	}
 
 	return t.String()
 }
 
-func formatTreeNode(issue *model.Issue) string {
+func formatTreeNode(issue *model.Issue, opts LayoutOptions) string {
 	if !ColorsEnabled() {
 		return fmt.Sprintf("%s %s %s %s %s",
 			model.FormatID(issue.ID),
 			statusLabel(issue.Status),
 			issue.Priority.Icon(),
 			fmt.Sprintf("%s %s", issue.Kind.Icon(), string(issue.Kind)),
-			truncate(issue.Title, maxTitleWidth),
+			opts.markedTitle(issue),
 		)
 	}
 
 	idStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
-	statusStyle := lipgloss.NewStyle().Foreground(ColorFromName(issue.Status.Color()))
+	statusStyle := lipgloss.NewStyle().Foreground(ColorFromName(opts.StatusColors.Color(issue.Status)))
 	priorityStyle := lipgloss.NewStyle().Foreground(ColorFromName(issue.Priority.Color()))
 	kindStyle := lipgloss.NewStyle().Foreground(ColorFromName(issue.Kind.Color()))
 	titleStyle := lipgloss.NewStyle().Bold(true)
 
 	return fmt.Sprintf("%s %s %s %s %s",
-		idStyle.Render(model.FormatID(issue.ID)),
+		idStyle.Render(LinkedID(issue.ID)),
 		statusStyle.Render(statusLabel(issue.Status)),
 		priorityStyle.Render(issue.Priority.Icon()),
 		kindStyle.Render(fmt.Sprintf("%s %s", issue.Kind.Icon(), string(issue.Kind))),
-		titleStyle.Render(truncate(issue.Title, maxTitleWidth)),
+		pinnedMarker(issue)+titleStyle.Render(opts.issueTitle(issue))+snoozedMarker(issue),
 	)
 }
 
-func addTreeChildren(node *tree.Tree, parentID int, children map[int][]*model.Issue) {
+func addTreeChildren(node *tree.Tree, parentID int, children map[int][]*model.Issue, opts LayoutOptions) {
 	for _, child := range children[parentID] {
-		childNode := tree.Root(formatTreeNode(child))
-		addTreeChildren(childNode, child.ID, children)
+		childNode := tree.Root(formatTreeNode(child, opts))
+		addTreeChildren(childNode, child.ID, children, opts)
 		node.Child(childNode)
 	}
 }
 
-func renderPlainTree(issues []*model.Issue) string {
+func renderPlainTree(issues []*model.Issue, opts LayoutOptions) string {
 	// Index issues by ID and group children by parent.
 	children := make(map[int][]*model.Issue)
 	var roots []*model.Issue
@@ -282,15 +382,16 @@ func renderPlainTree(issues []*model.Issue) string {
 	if len(roots) == 0 {
 		roots = issues
 	}
+	orderSiblings(children)
 
 	var b strings.Builder
 	for _, root := range roots {
-		renderPlainTreeNode(&b, root, children, 0)
+		renderPlainTreeNode(&b, root, children, 0, opts)
 	}
 	return b.String()
 }
 
-func renderPlainTreeNode(b *strings.Builder, issue *model.Issue, children map[int][]*model.Issue, depth int) {
+func renderPlainTreeNode(b *strings.Builder, issue *model.Issue, children map[int][]*model.Issue, depth int, opts LayoutOptions) {
 	indent := strings.Repeat("  ", depth)
 	fmt.Fprintf(b, "%s%s %s %s %s %s\n",
 		indent,
@@ -298,10 +399,32 @@ func renderPlainTreeNode(b *strings.Builder, issue *model.Issue, children map[in
 		statusLabel(issue.Status),
 		issue.Priority.Icon(),
 		fmt.Sprintf("%s %s", issue.Kind.Icon(), string(issue.Kind)),
-		truncate(issue.Title, maxTitleWidth),
+		opts.markedTitle(issue),
 	)
 	for _, child := range children[issue.ID] {
-		renderPlainTreeNode(b, child, children, depth+1)
+		renderPlainTreeNode(b, child, children, depth+1, opts)
+	}
+}
+
+// orderSiblings puts each group of children that has a manual order (see
+// db.SetChildOrder) in that order: ranked issues first, then the rest by
+// creation time. Groups without one keep the listing's order.
+func orderSiblings(children map[int][]*model.Issue) {
+	for _, group := range children {
+		if !slices.ContainsFunc(group, func(i *model.Issue) bool { return i.SortOrder != nil }) {
+			continue
+		}
+		slices.SortStableFunc(group, func(a, b *model.Issue) int {
+			switch {
+			case a.SortOrder != nil && b.SortOrder != nil:
+				return cmp.Compare(*a.SortOrder, *b.SortOrder)
+			case a.SortOrder != nil:
+				return -1
+			case b.SortOrder != nil:
+				return 1
+			}
+			return a.CreatedAt.Compare(b.CreatedAt)
+		})
 	}
 }
 
@@ -341,9 +464,13 @@ func priorityRank(p model.Priority) int {
 	}
 }
 
-// sortIssuesByRank sorts issues by status rank, then priority rank, then created_at DESC.
+// sortIssuesByRank sorts pinned issues first, then by status rank, then
+// priority rank, then created_at DESC.
 func sortIssuesByRank(issues []*model.Issue) {
 	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Pinned != issues[j].Pinned {
+			return issues[i].Pinned
+		}
 		si, sj := statusRank(issues[i].Status), statusRank(issues[j].Status)
 		if si != sj {
 			return si < sj
@@ -366,12 +493,14 @@ type parentGroup struct {
 // Parent issues are displayed as section headers with progress indicators,
 // and their children are rendered as indented table rows beneath them.
 // Standalone issues (no parent and no children) appear in a separate section at the bottom.
+// Groups with fewer than opts.MinGroupSize children are folded into the standalone section.
 //
 // Parameters:
 //   - issues: the filtered result set from the query.
 //   - parentMap: parent issues fetched separately that are NOT in the filtered set.
 //   - progress: sub-issue progress data keyed by parent issue ID.
-func RenderGroupedTable(issues []*model.Issue, parentMap map[int]*model.Issue, progress map[int]SubIssueProgress) string {
+//   - opts: width and title truncation settings.
+func RenderGroupedTable(issues []*model.Issue, parentMap map[int]*model.Issue, progress map[int]SubIssueProgress, opts LayoutOptions) string {
 	if len(issues) == 0 {
 		return EmptyState("No issues found.", "Create one with: docket issue create", false)
 	}
@@ -436,6 +565,16 @@ func RenderGroupedTable(issues []*model.Issue, parentMap map[int]*model.Issue, p
 			continue
 		}
 
+		if len(children) < opts.MinGroupSize {
+			// Too small for its own section -- fold the group, and its parent
+			// if it would otherwise only appear as the header, into standalone.
+			if issueSet[parentID] && parent.ParentID == nil {
+				standalone = append(standalone, parent)
+			}
+			standalone = append(standalone, children...)
+			continue
+		}
+
 		groups = append(groups, parentGroup{
 			parent:   parent,
 			children: children,
@@ -454,40 +593,28 @@ func RenderGroupedTable(issues []*model.Issue, parentMap map[int]*model.Issue, p
 
 	// If there are no groups at all, render as a flat table.
 	if len(groups) == 0 {
-		return RenderTable(issues, false)
+		return RenderTable(issues, false, opts)
 	}
 
-	// Sort parent groups by status rank, priority rank, created_at ASC.
-	sort.SliceStable(groups, func(i, j int) bool {
-		si, sj := statusRank(groups[i].parent.Status), statusRank(groups[j].parent.Status)
-		if si != sj {
-			return si < sj
-		}
-		pi, pj := priorityRank(groups[i].parent.Priority), priorityRank(groups[j].parent.Priority)
-		if pi != pj {
-			return pi < pj
-		}
-		return groups[i].parent.CreatedAt.Before(groups[j].parent.CreatedAt)
-	})
-
-	// Sort children within each group.
-	for i := range groups {
-		sortIssuesByRank(groups[i].children)
+	if opts.KeepOrder {
+		keepInputOrder(issues, groups, standalone)
+	} else {
+		sortGroupsByRank(groups, standalone)
 	}
 
-	// Sort standalone issues.
-	sortIssuesByRank(standalone)
+	columns := opts.issueColumns(issues)
 
 	if !ColorsEnabled() {
-		return renderGroupedPlainTable(groups, standalone, progress)
+		return renderGroupedPlainTable(groups, standalone, progress, columns, opts)
 	}
 
-	return renderGroupedColorTable(groups, standalone, progress)
+	return renderGroupedColorTable(groups, standalone, progress, columns, opts)
 }
 
 // buildParentTitle builds the styled title string for a parent group header,
-// truncating the issue title so the total visual width does not exceed maxWidth.
-func buildParentTitle(g parentGroup, progress map[int]SubIssueProgress, maxWidth int) string {
+// truncating the issue title so the total visual width does not exceed maxWidth
+// unless opts disables truncation.
+func buildParentTitle(g parentGroup, progress map[int]SubIssueProgress, maxWidth int, opts LayoutOptions) string {
 	headerBoldStyle := lipgloss.NewStyle().Bold(true)
 	idStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
 	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
@@ -496,7 +623,7 @@ func buildParentTitle(g parentGroup, progress map[int]SubIssueProgress, maxWidth
 		Foreground(ColorFromName(g.parent.Kind.Color())).
 		Bold(true)
 	statusStyle := lipgloss.NewStyle().
-		Foreground(ColorFromName(g.parent.Status.Color())).
+		Foreground(ColorFromName(opts.StatusColors.Color(g.parent.Status))).
 		Bold(true)
 	priorityStyle := lipgloss.NewStyle().
 		Foreground(ColorFromName(g.parent.Priority.Color())).
@@ -504,7 +631,7 @@ func buildParentTitle(g parentGroup, progress map[int]SubIssueProgress, maxWidth
 
 	// Build fixed-width parts.
 	kindPart := kindStyle.Render(g.parent.Kind.Icon())
-	idPart := idStyle.Render(model.FormatID(g.parent.ID))
+	idPart := idStyle.Render(LinkedID(g.parent.ID))
 	statusPart := statusStyle.Render(fmt.Sprintf("%s %s", g.parent.Status.Icon(), string(g.parent.Status)))
 	priorityPart := priorityStyle.Render(fmt.Sprintf("%s %s", g.parent.Priority.Icon(), string(g.parent.Priority)))
 
@@ -528,7 +655,7 @@ func buildParentTitle(g parentGroup, progress map[int]SubIssueProgress, maxWidth
 		availableForTitle = 10
 	}
 
-	truncatedTitle := truncate(g.parent.Title, availableForTitle)
+	truncatedTitle := opts.fitTitle(g.parent.Title, availableForTitle)
 	titlePart := headerBoldStyle.Render(truncatedTitle)
 
 	return fmt.Sprintf("%s %s  %s  %s  %s%s",
@@ -554,23 +681,73 @@ func buildTitleBox(title string, innerWidth int, borderStyle lipgloss.Style) str
 	return topLine + "\n" + titleLine
 }
 
+// sortGroupsByRank sorts parent groups with a pinned parent first, then by
+// status rank, priority rank and created_at ascending, and the issues within each group and the standalone
+// issues by rank.
+func sortGroupsByRank(groups []parentGroup, standalone []*model.Issue) {
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].parent.Pinned != groups[j].parent.Pinned {
+			return groups[i].parent.Pinned
+		}
+		si, sj := statusRank(groups[i].parent.Status), statusRank(groups[j].parent.Status)
+		if si != sj {
+			return si < sj
+		}
+		pi, pj := priorityRank(groups[i].parent.Priority), priorityRank(groups[j].parent.Priority)
+		if pi != pj {
+			return pi < pj
+		}
+		return groups[i].parent.CreatedAt.Before(groups[j].parent.CreatedAt)
+	})
+
+	// Sort children within each group.
+	for i := range groups {
+		sortIssuesByRank(groups[i].children)
+	}
+
+	// Sort standalone issues.
+	sortIssuesByRank(standalone)
+}
+
+// keepInputOrder orders parent groups by the earliest position of the parent
+// or any of its children in issues, and standalone issues by their position,
+// so a grouped table follows the caller's sort.
+func keepInputOrder(issues []*model.Issue, groups []parentGroup, standalone []*model.Issue) {
+	pos := make(map[int]int, len(issues))
+	for i, issue := range issues {
+		pos[issue.ID] = i
+	}
+	first := func(g parentGroup) int {
+		p, ok := pos[g.parent.ID]
+		if !ok {
+			p = len(issues)
+		}
+		for _, c := range g.children {
+			p = min(p, pos[c.ID])
+		}
+		return p
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return first(groups[i]) < first(groups[j]) })
+	sort.SliceStable(standalone, func(i, j int) bool { return pos[standalone[i].ID] < pos[standalone[j].ID] })
+}
+
 // renderGroupedColorTable renders grouped issues with lipgloss styling.
-func renderGroupedColorTable(groups []parentGroup, standalone []*model.Issue, progress map[int]SubIssueProgress) string {
+func renderGroupedColorTable(groups []parentGroup, standalone []*model.Issue, progress map[int]SubIssueProgress, columns []issueColumn, opts LayoutOptions) string {
 	var sections []string
 
 	borderStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
 
 	for _, g := range groups {
-		childTable := renderColorChildTable(g.children, true)
+		childTable := renderColorChildTable(g.children, true, columns, opts)
 		innerWidth := colorTableInnerWidth(childTable)
-		title := buildParentTitle(g, progress, innerWidth-4)
+		title := buildParentTitle(g, progress, innerWidth-4, opts)
 		titleBox := buildTitleBox(title, innerWidth, borderStyle)
 		sections = append(sections, titleBox+"\n"+childTable)
 	}
 
 	if len(standalone) > 0 {
 		sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15"))
-		childTable := renderColorChildTable(standalone, true)
+		childTable := renderColorChildTable(standalone, true, columns, opts)
 		innerWidth := colorTableInnerWidth(childTable)
 		standaloneTitle := sectionStyle.Render("Standalone Issues")
 		titleBox := buildTitleBox(standaloneTitle, innerWidth, borderStyle)
@@ -591,28 +768,7 @@ func colorTableInnerWidth(rendered string) int {
 
 // renderColorChildTable renders a set of issues as a lipgloss-styled table.
 // If withConnector is true, the top border uses ├/┤ to connect with a title box above.
-func renderColorChildTable(issues []*model.Issue, withConnector bool) string {
-	headers := []string{"ID", "Status", "Priority", "Type", "Title", "Assignee", "Updated"}
-
-	rows := make([][]string, 0, len(issues))
-	for _, issue := range issues {
-		rows = append(rows, issueToRow(issue))
-	}
-
-	type rowColors struct {
-		statusColor   string
-		priorityColor string
-		kindColor     string
-	}
-	colorMap := make([]rowColors, len(issues))
-	for i, issue := range issues {
-		colorMap[i] = rowColors{
-			statusColor:   issue.Status.Color(),
-			priorityColor: issue.Priority.Color(),
-			kindColor:     issue.Kind.Color(),
-		}
-	}
-
+func renderColorChildTable(issues []*model.Issue, withConnector bool, columns []issueColumn, opts LayoutOptions) string {
 	border := lipgloss.NormalBorder()
 	if withConnector {
 		border.TopLeft = "├"
@@ -624,52 +780,28 @@ func renderColorChildTable(issues []*model.Issue, withConnector bool) string {
 	t := table.New().
 		Border(border).
 		BorderStyle(borderStyle).
-		Headers(headers...).
-		Rows(rows...).
-		StyleFunc(func(row, col int) lipgloss.Style {
-			s := lipgloss.NewStyle().PaddingLeft(1).PaddingRight(1)
-
-			if row == table.HeaderRow {
-				return s.Bold(true).Foreground(lipgloss.Color("15"))
-			}
-
-			if row < 0 || row >= len(colorMap) {
-				return s
-			}
-
-			rc := colorMap[row]
-			switch col {
-			case 0: // ID
-				return s.Foreground(lipgloss.Color("15"))
-			case 1: // Status
-				return s.Foreground(ColorFromName(rc.statusColor))
-			case 2: // Priority
-				return s.Foreground(ColorFromName(rc.priorityColor))
-			case 3: // Type
-				return s.Foreground(ColorFromName(rc.kindColor))
-			case 4: // Title
-				return s.Bold(true)
-			default:
-				return s
-			}
-		})
+		Headers(columnHeaders(columns)...).
+		Rows(issueRows(issues, columns, opts)...).
+		StyleFunc(issueCellStyle(issues, columns, opts))
+	if opts.Width > 0 {
+		t = t.Width(opts.Width)
+	}
 
 	return t.Render()
 }
 
-// plainTableWidth is the content width for plain-text grouped tables.
-const plainTableWidth = 120
-
 // renderGroupedPlainTable renders grouped issues as plain text without color.
-func renderGroupedPlainTable(groups []parentGroup, standalone []*model.Issue, progress map[int]SubIssueProgress) string {
+func renderGroupedPlainTable(groups []parentGroup, standalone []*model.Issue, progress map[int]SubIssueProgress, columns []issueColumn, opts LayoutOptions) string {
 	var b strings.Builder
+	// The title box spans the columns and the space either side of them.
+	boxWidth := plainTableWidth(columns, true) + 2
 
 	for i, g := range groups {
 		if i > 0 {
 			b.WriteString("\n")
 		}
 
-		// Build title string, truncating the issue title to fit within plainTableWidth.
+		// Build title string, truncating the issue title to fit within the box.
 		prog := ""
 		if progress != nil {
 			if p, ok := progress[g.parent.ID]; ok && p.Total > 0 {
@@ -686,11 +818,11 @@ func renderGroupedPlainTable(groups []parentGroup, standalone []*model.Issue, pr
 			g.parent.Priority.Icon(), string(g.parent.Priority),
 			prog,
 		)
-		availableForTitle := plainTableWidth - len([]rune(fixedParts))
+		availableForTitle := boxWidth - len([]rune(fixedParts))
 		if availableForTitle < 10 {
 			availableForTitle = 10
 		}
-		truncatedTitle := truncate(g.parent.Title, availableForTitle)
+		truncatedTitle := opts.fitTitle(g.parent.Title, availableForTitle)
 
 		title := fmt.Sprintf("%s %s  %s  %s %s  %s %s%s",
 			g.parent.Kind.Icon(),
@@ -701,7 +833,7 @@ func renderGroupedPlainTable(groups []parentGroup, standalone []*model.Issue, pr
 			prog,
 		)
 
-		renderPlainSection(&b, title, g.children)
+		renderPlainSection(&b, title, g.children, columns, opts)
 	}
 
 	// Standalone issues.
@@ -709,22 +841,46 @@ func renderGroupedPlainTable(groups []parentGroup, standalone []*model.Issue, pr
 		if len(groups) > 0 {
 			b.WriteString("\n")
 		}
-		renderPlainSection(&b, "Standalone Issues", standalone)
+		renderPlainSection(&b, "Standalone Issues", standalone, columns, opts)
 	}
 
 	return b.String()
 }
 
 // renderPlainSection renders a plain-text section with a centered title box
-// connected to the data rows below.
-func renderPlainSection(b *strings.Builder, title string, issues []*model.Issue) {
-	w := plainTableWidth
+// connected to the data rows below. The box spans the columns, widening for
+// any row that outgrows them, such as an untruncated title.
+func renderPlainSection(b *strings.Builder, title string, issues []*model.Issue, columns []issueColumn, opts LayoutOptions) {
+	line := func(cell func(c issueColumn) (string, int)) string {
+		var lb strings.Builder
+		for i, c := range columns {
+			text, width := cell(c)
+			writePlainCell(&lb, text, width, i == len(columns)-1)
+		}
+		return lb.String()
+	}
+
+	header := line(func(c issueColumn) (string, int) { return c.header, c.sectionHeaderWidth })
+	cellOpts := opts
+	cellOpts.TitleWidth = opts.titleWidth() - 1
+	rows := make([]string, len(issues))
+	for i, issue := range issues {
+		rows[i] = line(func(c issueColumn) (string, int) { return c.cell(issue, cellOpts), c.sectionCellWidth })
+	}
+
+	inner := plainTableWidth(columns, true)
+	for _, l := range append([]string{header}, rows...) {
+		inner = max(inner, lipgloss.Width(l))
+	}
+	w := inner + 2
+	pad := func(l string) string {
+		return "│ " + l + strings.Repeat(" ", max(inner-lipgloss.Width(l), 0)) + " │\n"
+	}
 
 	// Title box: top border, centered title, connector.
 	fmt.Fprintf(b, "┌%s┐\n", strings.Repeat("─", w))
 
-	titleRunes := []rune(title)
-	titleLen := len(titleRunes)
+	titleLen := lipgloss.Width(title)
 	padding := w - titleLen
 	if padding < 0 {
 		padding = 0
@@ -736,20 +892,10 @@ func renderPlainSection(b *strings.Builder, title string, issues []*model.Issue)
 	fmt.Fprintf(b, "├%s┤\n", strings.Repeat("─", w))
 
 	// Column header and data rows.
-	fmt.Fprintf(b, "│ %-9s %-15s %-17s %-11s %-39s %-14s %s │\n",
-		"ID", "Status", "Priority", "Type", "Title", "Assignee", "Updated")
+	b.WriteString(pad(header))
 	fmt.Fprintf(b, "├%s┤\n", strings.Repeat("─", w))
-
-	for _, issue := range issues {
-		fmt.Fprintf(b, "│ %-9s %-17s %-17s %-13s %-39s %-14s %s │\n",
-			model.FormatID(issue.ID),
-			statusLabel(issue.Status),
-			fmt.Sprintf("%s %s", issue.Priority.Icon(), string(issue.Priority)),
-			fmt.Sprintf("%s %s", issue.Kind.Icon(), string(issue.Kind)),
-			truncate(issue.Title, maxTitleWidth-1),
-			issue.Assignee,
-			humanize.Time(issue.UpdatedAt),
-		)
+	for _, row := range rows {
+		b.WriteString(pad(row))
 	}
 
 	// Bottom border.
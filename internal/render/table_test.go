@@ -1,10 +1,13 @@
 package render
 
 import (
+	"os"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
+
 	"github.com/ALT-F4-LLC/docket/internal/model"
 )
 
@@ -33,7 +36,7 @@ func TestRenderGroupedTable_AllStandalone(t *testing.T) {
 		makeTestIssue(3, "Task C", model.StatusTodo, model.PriorityLow, model.IssueKindBug, nil),
 	}
 
-	got := RenderGroupedTable(issues, nil, nil)
+	got := RenderGroupedTable(issues, nil, nil, LayoutOptions{})
 
 	// When there are no parent-child relationships and no groups, RenderGroupedTable
 	// falls back to RenderTable (flat table). Verify all issue IDs appear.
@@ -66,7 +69,7 @@ func TestRenderGroupedTable_SingleParentWithChildren(t *testing.T) {
 		1: {Done: 1, Total: 3},
 	}
 
-	got := RenderGroupedTable(issues, nil, progress)
+	got := RenderGroupedTable(issues, nil, progress, LayoutOptions{})
 
 	// Parent should appear as section header.
 	if !strings.Contains(got, "DKT-1") {
@@ -125,7 +128,7 @@ func TestRenderGroupedTable_MultipleGroups(t *testing.T) {
 		4: {Done: 0, Total: 1},
 	}
 
-	got := RenderGroupedTable(issues, nil, progress)
+	got := RenderGroupedTable(issues, nil, progress, LayoutOptions{})
 
 	// Both parent groups should appear.
 	if !strings.Contains(got, "DKT-1") {
@@ -156,6 +159,74 @@ func TestRenderGroupedTable_MultipleGroups(t *testing.T) {
 	}
 }
 
+func TestRenderGroupedTable_KeepOrder(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	// Sorted by the caller with the low-priority issues first.
+	issues := []*model.Issue{
+		makeTestIssue(3, "Child B1", model.StatusTodo, model.PriorityLow, model.IssueKindTask, intPtr(2)),
+		makeTestIssue(5, "Loose low", model.StatusTodo, model.PriorityLow, model.IssueKindTask, nil),
+		makeTestIssue(2, "Epic B", model.StatusTodo, model.PriorityMedium, model.IssueKindEpic, nil),
+		makeTestIssue(4, "Child A1", model.StatusInProgress, model.PriorityCritical, model.IssueKindTask, intPtr(1)),
+		makeTestIssue(6, "Loose high", model.StatusInProgress, model.PriorityHigh, model.IssueKindTask, nil),
+	}
+	parentMap := map[int]*model.Issue{
+		1: makeTestIssue(1, "Epic A", model.StatusInProgress, model.PriorityCritical, model.IssueKindEpic, nil),
+	}
+
+	assertOrder := func(t *testing.T, got string, titles ...string) {
+		t.Helper()
+		last := -1
+		for _, title := range titles {
+			idx := strings.Index(got, title)
+			if idx <= last {
+				t.Fatalf("want %q in order, got:\n%s", titles, got)
+			}
+			last = idx
+		}
+	}
+
+	assertOrder(t, RenderGroupedTable(issues, parentMap, nil, LayoutOptions{KeepOrder: true}),
+		"Epic B", "Epic A", "Loose low", "Loose high")
+	assertOrder(t, RenderGroupedTable(issues, parentMap, nil, LayoutOptions{}),
+		"Epic A", "Epic B", "Loose high", "Loose low")
+}
+
+func TestRenderGroupedTable_MinGroupSize(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	issues := []*model.Issue{
+		makeTestIssue(1, "Epic A", model.StatusInProgress, model.PriorityHigh, model.IssueKindEpic, nil),
+		makeTestIssue(2, "Lone child", model.StatusTodo, model.PriorityHigh, model.IssueKindTask, intPtr(1)),
+		makeTestIssue(3, "Epic B", model.StatusTodo, model.PriorityMedium, model.IssueKindEpic, nil),
+		makeTestIssue(4, "Child B1", model.StatusTodo, model.PriorityHigh, model.IssueKindTask, intPtr(3)),
+		makeTestIssue(5, "Child B2", model.StatusTodo, model.PriorityLow, model.IssueKindTask, intPtr(3)),
+	}
+
+	got := RenderGroupedTable(issues, nil, nil, LayoutOptions{MinGroupSize: 2})
+
+	standaloneAt := strings.Index(got, "Standalone Issues")
+	if standaloneAt < 0 {
+		t.Fatalf("expected a standalone section, got:\n%s", got)
+	}
+	for _, title := range []string{"Epic A", "Lone child"} {
+		if idx := strings.Index(got, title); idx < standaloneAt {
+			t.Errorf("expected %q in the standalone section, got:\n%s", title, got)
+		}
+	}
+	for _, title := range []string{"Epic B", "Child B1", "Child B2"} {
+		if idx := strings.Index(got, title); idx < 0 || idx > standaloneAt {
+			t.Errorf("expected %q in the Epic B group, got:\n%s", title, got)
+		}
+	}
+
+	// The default groups every parent, however small.
+	got = RenderGroupedTable(issues, nil, nil, LayoutOptions{})
+	if idx := strings.Index(got, "Lone child"); idx < 0 || strings.Contains(got, "Standalone Issues") {
+		t.Errorf("expected the lone child grouped under Epic A by default, got:\n%s", got)
+	}
+}
+
 func TestRenderGroupedTable_FilteredParentInMap(t *testing.T) {
 	t.Setenv("NO_COLOR", "1")
 
@@ -175,7 +246,7 @@ func TestRenderGroupedTable_FilteredParentInMap(t *testing.T) {
 		1: {Done: 0, Total: 2},
 	}
 
-	got := RenderGroupedTable(issues, parentMap, progress)
+	got := RenderGroupedTable(issues, parentMap, progress, LayoutOptions{})
 
 	// The parent header should appear even though it's not in the issues slice.
 	if !strings.Contains(got, "DKT-1") {
@@ -202,12 +273,12 @@ func TestRenderGroupedTable_FilteredParentInMap(t *testing.T) {
 func TestRenderGroupedTable_EmptyList(t *testing.T) {
 	t.Setenv("NO_COLOR", "1")
 
-	got := RenderGroupedTable(nil, nil, nil)
+	got := RenderGroupedTable(nil, nil, nil, LayoutOptions{})
 	if !strings.Contains(got, "No issues found.") {
 		t.Errorf("expected empty state message, got:\n%s", got)
 	}
 
-	got = RenderGroupedTable([]*model.Issue{}, nil, nil)
+	got = RenderGroupedTable([]*model.Issue{}, nil, nil, LayoutOptions{})
 	if !strings.Contains(got, "No issues found.") {
 		t.Errorf("expected empty state message for empty slice, got:\n%s", got)
 	}
@@ -224,7 +295,7 @@ func TestRenderGroupedTable_ParentNoChildrenInResult(t *testing.T) {
 
 	issues := []*model.Issue{parent, standalone}
 
-	got := RenderGroupedTable(issues, nil, nil)
+	got := RenderGroupedTable(issues, nil, nil, LayoutOptions{})
 
 	// Since there are no parent-child groups, it should fall back to flat table.
 	if !strings.Contains(got, "DKT-1") {
@@ -254,7 +325,7 @@ func TestRenderGroupedTable_ProgressDisplay(t *testing.T) {
 		1: {Done: 2, Total: 3},
 	}
 
-	got := RenderGroupedTable(issues, nil, progress)
+	got := RenderGroupedTable(issues, nil, progress, LayoutOptions{})
 
 	// Verify progress indicator format.
 	if !strings.Contains(got, "(2/3 done)") {
@@ -271,7 +342,7 @@ func TestRenderGroupedTable_ProgressNotShownWhenNil(t *testing.T) {
 	issues := []*model.Issue{parent, child}
 
 	// nil progress map.
-	got := RenderGroupedTable(issues, nil, nil)
+	got := RenderGroupedTable(issues, nil, nil, LayoutOptions{})
 
 	// Should still render the group, just without progress.
 	if !strings.Contains(got, "DKT-1") {
@@ -292,7 +363,7 @@ func TestRenderGroupedTable_ProgressNotShownWhenEmpty(t *testing.T) {
 	issues := []*model.Issue{parent, child}
 
 	// Empty progress map (no entry for this parent).
-	got := RenderGroupedTable(issues, nil, map[int]SubIssueProgress{})
+	got := RenderGroupedTable(issues, nil, map[int]SubIssueProgress{}, LayoutOptions{})
 
 	// Should still render the group, just without progress.
 	if !strings.Contains(got, "DKT-1") {
@@ -319,13 +390,13 @@ func TestRenderGroupedTable_ColorPathExecutes(t *testing.T) {
 		{parent: parent, children: []*model.Issue{child}},
 	}
 
-	got := renderGroupedColorTable(groups, standalone, progress)
+	got := renderGroupedColorTable(groups, standalone, progress, LayoutOptions{}.issueColumns(issues), LayoutOptions{})
 	if got == "" {
 		t.Error("expected non-empty output from renderGroupedColorTable")
 	}
 
 	// Also test renderColorChildTable directly.
-	childTable := renderColorChildTable(issues, false)
+	childTable := renderColorChildTable(issues, false, LayoutOptions{}.issueColumns(issues), LayoutOptions{})
 	if childTable == "" {
 		t.Error("expected non-empty output from renderColorChildTable")
 	}
@@ -346,7 +417,7 @@ func TestRenderGroupedTable_ChildOrderingWithinGroup(t *testing.T) {
 
 	issues := []*model.Issue{parent, child1, child2, child3}
 
-	got := RenderGroupedTable(issues, nil, nil)
+	got := RenderGroupedTable(issues, nil, nil, LayoutOptions{})
 
 	// Verify child ordering: in-progress before todo, high before low.
 	idxIP := strings.Index(got, "Child InProgress High")
@@ -377,7 +448,7 @@ func TestRenderGroupedTable_PlainHeaderFormat(t *testing.T) {
 		1: {Done: 1, Total: 2},
 	}
 
-	got := RenderGroupedTable(issues, nil, progress)
+	got := RenderGroupedTable(issues, nil, progress, LayoutOptions{})
 
 	// Plain text header should use a bordered title box.
 	if !strings.Contains(got, "┌") || !strings.Contains(got, "┐") {
@@ -416,7 +487,7 @@ func TestRenderGroupedTable_MixedParentNotInIssuesOrMap(t *testing.T) {
 
 	issues := []*model.Issue{child1, child2, standalone}
 
-	got := RenderGroupedTable(issues, nil, nil)
+	got := RenderGroupedTable(issues, nil, nil, LayoutOptions{})
 
 	// Since the parent (99) is not available, children fall to standalone.
 	// With no valid groups, it should fall back to a flat table.
@@ -430,3 +501,338 @@ func TestRenderGroupedTable_MixedParentNotInIssuesOrMap(t *testing.T) {
 		t.Errorf("expected DKT-4 in output, got:\n%s", got)
 	}
 }
+
+func TestRenderTable_CommentColumnOnlyWhenCommented(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	quiet := makeTestIssue(1, "Quiet", model.StatusTodo, model.PriorityLow, model.IssueKindTask, nil)
+	busy := makeTestIssue(2, "Busy", model.StatusTodo, model.PriorityLow, model.IssueKindTask, nil)
+
+	got := RenderTable([]*model.Issue{quiet, busy}, false, LayoutOptions{})
+	if strings.Contains(got, "Comments") || strings.Contains(got, "💬") {
+		t.Errorf("expected no comment column without comments, got:\n%s", got)
+	}
+
+	busy.CommentCount = 3
+	got = RenderTable([]*model.Issue{quiet, busy}, false, LayoutOptions{})
+	if !strings.Contains(got, "Comments") {
+		t.Errorf("expected Comments header, got:\n%s", got)
+	}
+	if !strings.Contains(got, "💬 3") {
+		t.Errorf("expected '💬 3' cell, got:\n%s", got)
+	}
+	if strings.Contains(got, "💬 0") {
+		t.Errorf("expected zero-comment issue cell to be blank, got:\n%s", got)
+	}
+}
+
+func TestRenderTable_NoTruncateShowsFullTitle(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	longTitle := "Refactor the storage layer so that every query goes through one retrying helper"
+	parent := makeTestIssue(1, longTitle, model.StatusInProgress, model.PriorityHigh, model.IssueKindEpic, nil)
+	child := makeTestIssue(2, longTitle+" (child)", model.StatusTodo, model.PriorityHigh, model.IssueKindTask, intPtr(1))
+	issues := []*model.Issue{parent, child}
+
+	renders := map[string]func(LayoutOptions) string{
+		"flat":    func(o LayoutOptions) string { return RenderTable(issues, false, o) },
+		"tree":    func(o LayoutOptions) string { return RenderTable(issues, true, o) },
+		"grouped": func(o LayoutOptions) string { return RenderGroupedTable(issues, nil, nil, o) },
+	}
+	for name, render := range renders {
+		t.Run(name, func(t *testing.T) {
+			if got := render(LayoutOptions{}); strings.Contains(got, longTitle+" (child)") {
+				t.Errorf("expected child title to be truncated by default, got:\n%s", got)
+			}
+			got := render(LayoutOptions{NoTruncate: true})
+			if !strings.Contains(got, longTitle+" (child)") {
+				t.Errorf("expected full title with NoTruncate, got:\n%s", got)
+			}
+		})
+	}
+}
+
+func TestRenderTable_TitleWidth(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	issues := []*model.Issue{
+		makeTestIssue(1, "A fairly long issue title", model.StatusTodo, model.PriorityHigh, model.IssueKindTask, nil),
+	}
+
+	got := RenderTable(issues, false, LayoutOptions{TitleWidth: 10})
+	if !strings.Contains(got, "A fairl...") {
+		t.Errorf("expected title truncated to 10 runes, got:\n%s", got)
+	}
+}
+
+func TestRenderColorChildTable_WidthWrapsTitles(t *testing.T) {
+	longTitle := "Refactor the storage layer so that every query goes through one retrying helper"
+	issues := []*model.Issue{
+		makeTestIssue(1, longTitle, model.StatusTodo, model.PriorityHigh, model.IssueKindTask, nil),
+	}
+
+	unbounded := renderColorChildTable(issues, false, LayoutOptions{}.issueColumns(issues), LayoutOptions{NoTruncate: true})
+	if w := colorTableInnerWidth(unbounded) + 2; w <= 100 {
+		t.Fatalf("expected unbounded table wider than 100 columns, got %d", w)
+	}
+
+	got := renderColorChildTable(issues, false, LayoutOptions{}.issueColumns(issues), LayoutOptions{Width: 100, NoTruncate: true})
+	for _, line := range strings.Split(got, "\n") {
+		if w := lipgloss.Width(line); w > 100 {
+			t.Errorf("line is %d columns wide, want <= 100: %q", w, line)
+		}
+	}
+	for _, word := range strings.Fields(longTitle) {
+		if !strings.Contains(got, word) {
+			t.Errorf("expected wrapped title to keep %q, got:\n%s", word, got)
+		}
+	}
+	if strings.Contains(got, "...") {
+		t.Errorf("expected no ellipsis with NoTruncate, got:\n%s", got)
+	}
+}
+
+func TestRenderTable_ColumnsSelectAndOrderHeaders(t *testing.T) {
+	issue := makeTestIssue(1, "Columns", model.StatusTodo, model.PriorityHigh, model.IssueKindTask, nil)
+	issue.Labels = []string{"backend", "urgent"}
+	opts := LayoutOptions{Columns: TableColumns{"title", "id", "labels"}}
+
+	// The color pass runs first because NO_COLOR stays set once the plain
+	// pass sets it.
+	for _, colors := range []bool{true, false} {
+		if !colors {
+			t.Setenv("NO_COLOR", "1")
+		}
+		got := RenderTable([]*model.Issue{issue}, false, opts)
+
+		header := strings.Split(got, "\n")[0]
+		if colors {
+			header = strings.Split(got, "\n")[1]
+		}
+		fields := strings.FieldsFunc(header, func(r rune) bool { return r == ' ' || r == '│' })
+		if want := []string{"Title", "ID", "Labels"}; strings.Join(fields, ",") != strings.Join(want, ",") {
+			t.Errorf("colors=%v: headers = %v, want %v\n%s", colors, fields, want, got)
+		}
+		for _, hidden := range []string{"Status", "Priority", "Updated", "Assignee"} {
+			if strings.Contains(got, hidden) {
+				t.Errorf("colors=%v: unexpected %q column in:\n%s", colors, hidden, got)
+			}
+		}
+		if !strings.Contains(got, "backend, urgent") {
+			t.Errorf("colors=%v: expected joined labels, got:\n%s", colors, got)
+		}
+	}
+}
+
+func TestRenderGroupedTable_ColumnsApplyToSections(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	parent := makeTestIssue(1, "Parent", model.StatusInProgress, model.PriorityHigh, model.IssueKindEpic, nil)
+	child := makeTestIssue(2, "Child", model.StatusTodo, model.PriorityLow, model.IssueKindTask, intPtr(1))
+	child.Labels = []string{"docs"}
+
+	got := RenderGroupedTable([]*model.Issue{parent, child}, nil, nil, LayoutOptions{Columns: TableColumns{"id", "labels"}})
+	if !strings.Contains(got, "Labels") || !strings.Contains(got, "docs") {
+		t.Errorf("expected labels column in grouped section, got:\n%s", got)
+	}
+	if strings.Contains(got, "Updated") {
+		t.Errorf("expected Updated column to be hidden, got:\n%s", got)
+	}
+}
+
+func TestRenderGroupedTable_PlainBoxFitsColumns(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	parent := makeTestIssue(1, "Parent", model.StatusInProgress, model.PriorityHigh, model.IssueKindEpic, nil)
+	child := makeTestIssue(2, "Child", model.StatusTodo, model.PriorityLow, model.IssueKindTask, intPtr(1))
+	child.CommentCount = 2
+	child.Labels = []string{"docs", "backend"}
+	child.BlocksCount = 1
+	lone := makeTestIssue(3, "Lone", model.StatusBacklog, model.PriorityNone, model.IssueKindBug, nil)
+	issues := []*model.Issue{parent, child, lone}
+
+	for _, tc := range []struct {
+		name string
+		opts LayoutOptions
+	}{
+		{"default with comments", LayoutOptions{}},
+		{"wide columns", LayoutOptions{Columns: TableColumns{"id", "status", "priority", "type", "title", "assignee", "comments", "deps", "labels", "updated"}}},
+		{"narrow columns", LayoutOptions{Columns: TableColumns{"id", "title"}}},
+		{"untruncated title", LayoutOptions{NoTruncate: true, Columns: TableColumns{"id", "title"}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.opts.NoTruncate {
+				child.Title = strings.Repeat("long ", 30)
+				t.Cleanup(func() { child.Title = "Child" })
+			}
+			got := RenderGroupedTable(issues, nil, nil, tc.opts)
+			for _, section := range strings.Split(strings.TrimRight(got, "\n"), "\n\n") {
+				lines := strings.Split(section, "\n")
+				want := lipgloss.Width(lines[0])
+				for _, line := range lines[1:] {
+					if w := lipgloss.Width(line); w != want {
+						t.Errorf("line is %d columns wide, box is %d:\n%s", w, want, section)
+						break
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParseTableColumns(t *testing.T) {
+	got, err := ParseTableColumns(" ID, title ,labels")
+	if err != nil {
+		t.Fatalf("ParseTableColumns: %v", err)
+	}
+	if strings.Join(got, ",") != "id,title,labels" {
+		t.Errorf("columns = %v", got)
+	}
+
+	for _, bad := range []string{"id,nope", "id,title,id", " , "} {
+		if _, err := ParseTableColumns(bad); err == nil {
+			t.Errorf("ParseTableColumns(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestDegreeCell(t *testing.T) {
+	tests := []struct {
+		blockedBy, blocks int
+		want              string
+	}{
+		{0, 0, ""},
+		{2, 1, "↑2 ↓1"},
+		{3, 0, "↑3"},
+		{0, 4, "↓4"},
+	}
+	for _, tt := range tests {
+		issue := &model.Issue{BlockedByCount: tt.blockedBy, BlocksCount: tt.blocks}
+		if got := degreeCell(issue); got != tt.want {
+			t.Errorf("degreeCell(%d, %d) = %q, want %q", tt.blockedBy, tt.blocks, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateMiddle(t *testing.T) {
+	tests := []struct {
+		in     string
+		maxLen int
+		want   string
+	}{
+		{"internal/db/files.go", 40, "internal/db/files.go"},
+		{"internal/render/detail/sections/files.go", 21, "internal/.../files.go"},
+		{"internal/render/detail/sections/files.go", 30, "internal/.../sections/files.go"},
+		{"internal/render/detail/sections/files.go", 37, "internal/.../detail/sections/files.go"},
+		{"a/b", 2, "a/"},
+		{"abcdefghijklmnopqrstuvwxyz", 10, "abc...wxyz"},
+		{"internal/a_very_long_filename_indeed.go", 20, "internal...indeed.go"},
+	}
+	for _, tt := range tests {
+		got := TruncateMiddle(tt.in, tt.maxLen)
+		if got != tt.want {
+			t.Errorf("TruncateMiddle(%q, %d) = %q, want %q", tt.in, tt.maxLen, got, tt.want)
+		}
+		if n := len([]rune(got)); n > tt.maxLen {
+			t.Errorf("TruncateMiddle(%q, %d) is %d runes long", tt.in, tt.maxLen, n)
+		}
+	}
+}
+
+func TestIssueCellStyle_StatusColors(t *testing.T) {
+	issues := []*model.Issue{
+		makeIssue(1, "Shipped", model.StatusDone, model.PriorityLow),
+		makeIssue(2, "Planned", model.StatusTodo, model.PriorityLow),
+	}
+	columns := LayoutOptions{Columns: TableColumns{"id", "status"}}.issueColumns(issues)
+	style := issueCellStyle(issues, columns, LayoutOptions{StatusColors: StatusColors{model.StatusDone: "blue"}})
+
+	if got, want := style(0, 1).GetForeground(), ColorFromName("blue"); got != want {
+		t.Errorf("done status cell color = %v, want override %v", got, want)
+	}
+	if got, want := style(1, 1).GetForeground(), ColorFromName(model.StatusTodo.Color()); got != want {
+		t.Errorf("todo status cell color = %v, want default %v", got, want)
+	}
+}
+
+func TestPinnedMarker(t *testing.T) {
+	pinned := makeTestIssue(1, "Pinned", model.StatusTodo, model.PriorityLow, model.IssueKindTask, nil)
+	pinned.Pinned = true
+	plain := makeTestIssue(2, "Plain", model.StatusTodo, model.PriorityLow, model.IssueKindTask, nil)
+	issues := []*model.Issue{pinned, plain}
+
+	t.Run("plain", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		for name, out := range map[string]string{
+			"table": RenderTable(issues, false, LayoutOptions{}),
+			"tree":  RenderTreeList(issues, LayoutOptions{}),
+			"board": RenderBoard(issues, BoardOptions{}),
+		} {
+			if strings.Count(out, "[pinned]") != 1 {
+				t.Errorf("%s: expected one [pinned] marker, got:\n%s", name, out)
+			}
+		}
+	})
+
+	t.Run("color", func(t *testing.T) {
+		t.Setenv("TERM", "xterm-256color")
+		t.Setenv("NO_COLOR", "") // restored after the test
+		os.Unsetenv("NO_COLOR")
+		for name, out := range map[string]string{
+			"table": RenderTable(issues, false, LayoutOptions{}),
+			"tree":  RenderTreeList(issues, LayoutOptions{}),
+			"board": RenderBoard(issues, BoardOptions{}),
+		} {
+			if strings.Count(out, "📌") != 1 {
+				t.Errorf("%s: expected one 📌 marker, got:\n%s", name, out)
+			}
+		}
+	})
+}
+
+func TestRenderTable_SnoozedMarkerKeepsColumnsAligned(t *testing.T) {
+	issue := makeTestIssue(1, strings.Repeat("long ", 12), model.StatusTodo, model.PriorityLow, model.IssueKindTask, nil)
+	issue.SnoozedUntil = time.Now().Add(48 * time.Hour)
+	assertMarkedTitleAligned(t, issue, "(snoozed until")
+}
+
+func TestRenderTable_PinnedMarkerKeepsColumnsAligned(t *testing.T) {
+	issue := makeTestIssue(1, strings.Repeat("long ", 12), model.StatusTodo, model.PriorityLow, model.IssueKindTask, nil)
+	issue.Pinned = true
+	assertMarkedTitleAligned(t, issue, "[pinned]")
+}
+
+// assertMarkedTitleAligned checks that the plain flat and grouped tables
+// show marker beside the long title of issue and still start the assignee
+// cell under its header.
+func assertMarkedTitleAligned(t *testing.T, issue *model.Issue, marker string) {
+	t.Helper()
+	t.Setenv("NO_COLOR", "1")
+
+	issue.Assignee = "amy"
+	issues := []*model.Issue{issue}
+	opts := LayoutOptions{Columns: TableColumns{"id", "title", "assignee"}}
+
+	got := RenderTable(issues, false, opts)
+	if !strings.Contains(got, marker) {
+		t.Fatalf("expected a %s marker, got:\n%s", marker, got)
+	}
+	lines := strings.Split(got, "\n")
+	if want, at := strings.Index(lines[0], "Assignee"), strings.Index(lines[2], "amy"); at != want {
+		t.Errorf("assignee cell starts at offset %d, header at %d:\n%s", at, want, got)
+	}
+
+	got = RenderGroupedTable(issues, nil, nil, opts)
+	header, row := -1, -1
+	for _, line := range strings.Split(got, "\n") {
+		if i := strings.Index(line, "Assignee"); i >= 0 {
+			header = i
+		}
+		if i := strings.Index(line, "amy"); i >= 0 {
+			row = i
+		}
+	}
+	if header < 0 || row != header {
+		t.Errorf("assignee cell starts at offset %d, header at %d:\n%s", row, header, got)
+	}
+}
@@ -0,0 +1,52 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// RenderTaskList renders the descendants of rootID as a GitHub-flavored
+// Markdown task list, e.g. "- [x] DKT-7 Fix parser", with nested issues
+// indented under their parents. Done issues are checked. With verbose, each
+// item ends with its status and assignee in parentheses. Issues are listed in
+// the order given; those not descended from rootID are ignored. The result
+// has no trailing newline and is empty when there are no descendants.
+func RenderTaskList(rootID int, issues []*model.Issue, verbose bool) string {
+	children := make(map[int][]*model.Issue)
+	for _, issue := range issues {
+		if issue.ParentID != nil {
+			children[*issue.ParentID] = append(children[*issue.ParentID], issue)
+		}
+	}
+
+	var lines []string
+	var walk func(parentID, depth int)
+	walk = func(parentID, depth int) {
+		for _, issue := range children[parentID] {
+			lines = append(lines, strings.Repeat("  ", depth)+taskListItem(issue, verbose))
+			walk(issue.ID, depth+1)
+		}
+	}
+	walk(rootID, 0)
+
+	return strings.Join(lines, "\n")
+}
+
+// taskListItem formats a single task list line without indentation.
+func taskListItem(issue *model.Issue, verbose bool) string {
+	box := "[ ]"
+	if issue.Status == model.StatusDone {
+		box = "[x]"
+	}
+	item := fmt.Sprintf("- %s %s %s", box, model.FormatID(issue.ID), EscapeMarkdown(issue.Title))
+	if verbose {
+		details := string(issue.Status)
+		if issue.Assignee != "" {
+			details += ", " + EscapeMarkdown(issue.Assignee)
+		}
+		item += " (" + details + ")"
+	}
+	return item
+}
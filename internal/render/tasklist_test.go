@@ -0,0 +1,61 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+func TestRenderTaskListNesting(t *testing.T) {
+	issues := []*model.Issue{
+		makeTestIssue(2, "Parser", model.StatusInProgress, model.PriorityHigh, model.IssueKindFeature, intPtr(1)),
+		makeTestIssue(3, "Lexer", model.StatusDone, model.PriorityLow, model.IssueKindTask, intPtr(2)),
+		makeTestIssue(4, "Docs", model.StatusTodo, model.PriorityLow, model.IssueKindTask, intPtr(1)),
+		makeTestIssue(5, "Tokens", model.StatusBacklog, model.PriorityLow, model.IssueKindTask, intPtr(3)),
+		makeTestIssue(9, "Elsewhere", model.StatusTodo, model.PriorityLow, model.IssueKindTask, intPtr(8)),
+	}
+
+	want := "- [ ] DKT-2 Parser\n" +
+		"  - [x] DKT-3 Lexer\n" +
+		"    - [ ] DKT-5 Tokens\n" +
+		"- [ ] DKT-4 Docs"
+	if got := RenderTaskList(1, issues, false); got != want {
+		t.Errorf("RenderTaskList =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := RenderTaskList(1, nil, false); got != "" {
+		t.Errorf("RenderTaskList with no issues = %q, want empty", got)
+	}
+}
+
+func TestRenderTaskListCheckboxPerStatus(t *testing.T) {
+	tests := []struct {
+		status model.Status
+		want   string
+	}{
+		{model.StatusBacklog, "- [ ] DKT-2 Task"},
+		{model.StatusTodo, "- [ ] DKT-2 Task"},
+		{model.StatusInProgress, "- [ ] DKT-2 Task"},
+		{model.StatusReview, "- [ ] DKT-2 Task"},
+		{model.StatusDone, "- [x] DKT-2 Task"},
+	}
+	for _, tt := range tests {
+		issues := []*model.Issue{makeTestIssue(2, "Task", tt.status, model.PriorityLow, model.IssueKindTask, intPtr(1))}
+		if got := RenderTaskList(1, issues, false); got != tt.want {
+			t.Errorf("status %s: got %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestRenderTaskListEscapesAndVerbose(t *testing.T) {
+	issue := makeTestIssue(2, "Handle [x] in `titles` *now*", model.StatusReview, model.PriorityLow, model.IssueKindTask, intPtr(1))
+	issue.Assignee = "alice"
+	unassigned := makeTestIssue(3, "Plain", model.StatusTodo, model.PriorityLow, model.IssueKindTask, intPtr(1))
+
+	got := RenderTaskList(1, []*model.Issue{issue, unassigned}, true)
+	want := "- [ ] DKT-2 Handle \\[x\\] in \\`titles\\` \\*now\\* (review, alice)\n" +
+		"- [ ] DKT-3 Plain (todo)"
+	if got != want {
+		t.Errorf("RenderTaskList =\n%s\nwant\n%s", got, want)
+	}
+}
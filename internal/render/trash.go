@@ -0,0 +1,83 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// RenderTrashList renders the issues in the trash with when they were
+// deleted. Titles are fitted according to opts.
+func RenderTrashList(trashed []model.TrashedIssue, opts LayoutOptions) string {
+	if len(trashed) == 0 {
+		return EmptyState("Trash is empty.", "", false)
+	}
+
+	if !ColorsEnabled() {
+		return renderPlainTrashList(trashed, opts)
+	}
+
+	headers := []string{"ID", "Title", "Status", "Deleted"}
+
+	rows := make([][]string, 0, len(trashed))
+	for _, t := range trashed {
+		rows = append(rows, []string{
+			model.FormatID(t.ID),
+			opts.title(t.Title),
+			statusLabel(t.Status),
+			humanize.Time(t.DeletedAt),
+		})
+	}
+
+	tbl := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("8"))).
+		Headers(headers...).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			s := lipgloss.NewStyle().PaddingLeft(1).PaddingRight(1)
+
+			if row == table.HeaderRow {
+				return s.Bold(true).Foreground(lipgloss.Color("15"))
+			}
+
+			switch col {
+			case 0:
+				return s.Bold(true).Foreground(lipgloss.Color("15"))
+			case 1:
+				return s
+			default:
+				return s.Foreground(lipgloss.Color("8"))
+			}
+		})
+	if opts.Width > 0 {
+		tbl = tbl.Width(opts.Width)
+	}
+
+	return tbl.Render()
+}
+
+func renderPlainTrashList(trashed []model.TrashedIssue, opts LayoutOptions) string {
+	var b strings.Builder
+
+	titleWidth := opts.titleWidth()
+	fmt.Fprintf(&b, "%-8s %-*s %-12s %s\n", "ID", titleWidth, "Title", "Status", "Deleted")
+	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", 8+titleWidth+12+16))
+
+	for _, t := range trashed {
+		fmt.Fprintf(&b, "%-8s %-*s %-12s %s\n",
+			model.FormatID(t.ID),
+			titleWidth, opts.title(t.Title),
+			string(t.Status),
+			humanize.Time(t.DeletedAt),
+		)
+	}
+
+	return b.String()
+}
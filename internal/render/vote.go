@@ -128,7 +128,7 @@ func RenderProposalTable(rows []ProposalRow) string {
 func proposalToRow(r ProposalRow) []string {
 	return []string{
 		model.FormatProposalID(r.Proposal.ID),
-		truncate(r.Proposal.Description, maxTitleWidth),
+		truncate(r.Proposal.Description, DefaultTitleWidth),
 		string(r.Proposal.Status),
 		fmt.Sprintf("%d/%d", r.VoteCast, r.Proposal.RequiredVoters),
 		string(r.Proposal.Criticality),
@@ -145,7 +145,7 @@ func renderPlainProposalTable(rows []ProposalRow) string {
 	for _, r := range rows {
 		fmt.Fprintf(&b, "%-10s %-42s %-12s %-10s %s\n",
 			model.FormatProposalID(r.Proposal.ID),
-			truncate(r.Proposal.Description, maxTitleWidth),
+			truncate(r.Proposal.Description, DefaultTitleWidth),
 			string(r.Proposal.Status),
 			fmt.Sprintf("%d/%d", r.VoteCast, r.Proposal.RequiredVoters),
 			string(r.Proposal.Criticality),
@@ -0,0 +1,171 @@
+package standup
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+// SystemActor groups activity recorded without a changed_by value.
+const SystemActor = "system"
+
+// Action is the kind of work an Item summarizes.
+type Action string
+
+const (
+	ActionCreated   Action = "created"
+	ActionMoved     Action = "moved"
+	ActionCommented Action = "commented"
+	ActionEdited    Action = "edited"
+)
+
+// Item is one line of a standup report: a run of consecutive activity by
+// one actor on one issue, collapsed into a single action.
+type Item struct {
+	IssueID    int
+	IssueTitle string
+	Action     Action
+	// Status is the final status of a run of moves.
+	Status model.Status
+	// Fields lists the fields changed by a run of edits, in first-changed
+	// order without duplicates.
+	Fields []string
+	// Count is the number of activity entries collapsed into the item.
+	Count int
+	// LastAt is when the most recent collapsed entry was recorded.
+	LastAt time.Time
+}
+
+// Summary describes the item in a short phrase such as "moved DKT-7 to
+// review" or "commented on DKT-3 (x2)".
+func (it Item) Summary() string {
+	id := model.FormatID(it.IssueID)
+	switch it.Action {
+	case ActionCreated:
+		return "created " + id
+	case ActionMoved:
+		return fmt.Sprintf("moved %s to %s", id, it.Status)
+	case ActionCommented:
+		if it.Count > 1 {
+			return fmt.Sprintf("commented on %s (x%d)", id, it.Count)
+		}
+		return "commented on " + id
+	default:
+		return fmt.Sprintf("edited %s on %s", strings.Join(it.Fields, ", "), id)
+	}
+}
+
+// ActorSummary is the condensed activity of one actor.
+type ActorSummary struct {
+	Actor string
+	Items []Item
+	// Entries is the number of activity entries behind Items.
+	Entries int
+}
+
+// Summarize groups activity by actor and collapses consecutive entries on the
+// same issue: repeated moves keep the final status, repeated comments are
+// counted, and field edits are merged, including those that directly follow
+// the issue's creation. Actors are sorted by name with SystemActor last;
+// each actor's items are in chronological order.
+func Summarize(entries []model.IssueActivity) []ActorSummary {
+	sorted := slices.Clone(entries)
+	slices.SortStableFunc(sorted, func(a, b model.IssueActivity) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+
+	byActor := make(map[string]*ActorSummary)
+	for _, e := range sorted {
+		actor := e.ChangedBy
+		if actor == "" {
+			actor = SystemActor
+		}
+		s, ok := byActor[actor]
+		if !ok {
+			s = &ActorSummary{Actor: actor}
+			byActor[actor] = s
+		}
+		s.Entries++
+		s.Items = appendEntry(s.Items, e)
+	}
+
+	summaries := make([]ActorSummary, 0, len(byActor))
+	for _, s := range byActor {
+		summaries = append(summaries, *s)
+	}
+	slices.SortFunc(summaries, func(a, b ActorSummary) int {
+		if (a.Actor == SystemActor) != (b.Actor == SystemActor) {
+			if a.Actor == SystemActor {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(a.Actor, b.Actor)
+	})
+	return summaries
+}
+
+// appendEntry folds e into the last item when it continues the same action on
+// the same issue, and appends a new item otherwise.
+func appendEntry(items []Item, e model.IssueActivity) []Item {
+	action, field := classify(e)
+
+	if n := len(items); n > 0 {
+		last := &items[n-1]
+		if last.IssueID == e.IssueID && (last.Action == action || (last.Action == ActionCreated && action == ActionEdited)) {
+			last.Count++
+			last.LastAt = e.CreatedAt
+			switch action {
+			case ActionMoved:
+				last.Status = model.Status(e.NewValue)
+			case ActionEdited:
+				if last.Action == ActionEdited && !slices.Contains(last.Fields, field) {
+					last.Fields = append(last.Fields, field)
+				}
+			}
+			return items
+		}
+	}
+
+	item := Item{
+		IssueID:    e.IssueID,
+		IssueTitle: e.IssueTitle,
+		Action:     action,
+		Count:      1,
+		LastAt:     e.CreatedAt,
+	}
+	switch action {
+	case ActionMoved:
+		item.Status = model.Status(e.NewValue)
+	case ActionEdited:
+		item.Fields = []string{field}
+	}
+	return append(items, item)
+}
+
+// classify maps an activity entry to its action and, for edits, the
+// user-facing name of the changed field.
+func classify(e model.IssueActivity) (Action, string) {
+	switch e.FieldChanged {
+	case "created":
+		return ActionCreated, ""
+	case "status":
+		return ActionMoved, ""
+	case "comment_added":
+		return ActionCommented, ""
+	case "label_added", "label_removed":
+		return ActionEdited, "labels"
+	case "relation_added", "relation_removed", "relation_changed":
+		return ActionEdited, "relations"
+	case "parent_id":
+		return ActionEdited, "parent"
+	case "milestone_id":
+		return ActionEdited, "milestone"
+	default:
+		return ActionEdited, e.FieldChanged
+	}
+}
@@ -0,0 +1,132 @@
+package standup
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ALT-F4-LLC/docket/internal/model"
+)
+
+var base = time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+
+func entry(id, issueID int, field, oldVal, newVal, actor string) model.IssueActivity {
+	return model.IssueActivity{
+		Activity: model.Activity{
+			ID:           id,
+			IssueID:      issueID,
+			FieldChanged: field,
+			OldValue:     oldVal,
+			NewValue:     newVal,
+			ChangedBy:    actor,
+			CreatedAt:    base.Add(time.Duration(id) * time.Minute),
+		},
+		IssueTitle: "issue",
+	}
+}
+
+func summaries(items []Item) []string {
+	out := make([]string, len(items))
+	for i, it := range items {
+		out[i] = it.Summary()
+	}
+	return out
+}
+
+func TestSummarizeGroupsByActorWithSystemLast(t *testing.T) {
+	got := Summarize([]model.IssueActivity{
+		entry(1, 22, "created", "", "", ""),
+		entry(2, 7, "status", "in-progress", "review", "zoe"),
+		entry(3, 3, "comment_added", "", "hi", "amy"),
+	})
+
+	var actors []string
+	for _, s := range got {
+		actors = append(actors, s.Actor)
+	}
+	if want := []string{"amy", "zoe", SystemActor}; !slices.Equal(actors, want) {
+		t.Fatalf("actors = %v, want %v", actors, want)
+	}
+	if s := summaries(got[1].Items); !slices.Equal(s, []string{"moved DKT-7 to review"}) {
+		t.Errorf("zoe = %v", s)
+	}
+	if s := summaries(got[2].Items); !slices.Equal(s, []string{"created DKT-22"}) {
+		t.Errorf("system = %v", s)
+	}
+}
+
+func TestSummarizeCollapsesConsecutiveEntries(t *testing.T) {
+	got := Summarize([]model.IssueActivity{
+		entry(1, 3, "comment_added", "", "a", "amy"),
+		entry(2, 3, "comment_added", "", "b", "amy"),
+		entry(3, 7, "status", "todo", "in-progress", "amy"),
+		entry(4, 7, "status", "in-progress", "review", "amy"),
+		entry(5, 4, "title", "old", "new", "amy"),
+		entry(6, 4, "priority", "low", "high", "amy"),
+		entry(7, 4, "title", "new", "newer", "amy"),
+		entry(8, 4, "label_added", "", "bug", "amy"),
+		entry(9, 3, "comment_added", "", "c", "amy"),
+	})
+	if len(got) != 1 {
+		t.Fatalf("got %d actors, want 1", len(got))
+	}
+	want := []string{
+		"commented on DKT-3 (x2)",
+		"moved DKT-7 to review",
+		"edited title, priority, labels on DKT-4",
+		"commented on DKT-3",
+	}
+	if s := summaries(got[0].Items); !slices.Equal(s, want) {
+		t.Errorf("items = %q, want %q", s, want)
+	}
+	if got[0].Entries != 9 {
+		t.Errorf("Entries = %d, want 9", got[0].Entries)
+	}
+	if got[0].Items[2].Count != 4 || !got[0].Items[2].LastAt.Equal(base.Add(8*time.Minute)) {
+		t.Errorf("edit item = %+v", got[0].Items[2])
+	}
+}
+
+func TestSummarizeFoldsEditsIntoCreation(t *testing.T) {
+	got := Summarize([]model.IssueActivity{
+		entry(1, 5, "created", "", "", "bob"),
+		entry(2, 5, "files", "", "main.go", "bob"),
+		entry(3, 5, "status", "backlog", "todo", "bob"),
+		entry(4, 6, "title", "a", "b", "bob"),
+	})
+	want := []string{"created DKT-5", "moved DKT-5 to todo", "edited title on DKT-6"}
+	if s := summaries(got[0].Items); !slices.Equal(s, want) {
+		t.Errorf("items = %q, want %q", s, want)
+	}
+}
+
+func TestSummarizeDoesNotCollapseAcrossActorsOrIssues(t *testing.T) {
+	got := Summarize([]model.IssueActivity{
+		entry(1, 1, "comment_added", "", "a", "amy"),
+		entry(2, 1, "comment_added", "", "b", "bob"),
+		entry(3, 2, "comment_added", "", "c", "amy"),
+		entry(4, 1, "comment_added", "", "d", "amy"),
+	})
+	if s := summaries(got[0].Items); !slices.Equal(s, []string{"commented on DKT-1", "commented on DKT-2", "commented on DKT-1"}) {
+		t.Errorf("amy = %q", s)
+	}
+	if s := summaries(got[1].Items); !slices.Equal(s, []string{"commented on DKT-1"}) {
+		t.Errorf("bob = %q", s)
+	}
+}
+
+func TestSummarizeSortsUnorderedInput(t *testing.T) {
+	got := Summarize([]model.IssueActivity{
+		entry(2, 9, "status", "todo", "done", "amy"),
+		entry(1, 9, "status", "backlog", "todo", "amy"),
+	})
+	if s := summaries(got[0].Items); !slices.Equal(s, []string{"moved DKT-9 to done"}) {
+		t.Errorf("items = %q", s)
+	}
+}
+
+func TestSummarizeEmpty(t *testing.T) {
+	if got := Summarize(nil); len(got) != 0 {
+		t.Errorf("Summarize(nil) = %v, want empty", got)
+	}
+}
@@ -0,0 +1,222 @@
+// Package textdiff produces compact line-based unified diffs of short texts
+// such as issue descriptions.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxLCSCells bounds the size of the table used to align the changed middle
+// of two texts. Larger changes are reported as a block replacement.
+const maxLCSCells = 1 << 22
+
+// op is one line of a diff: kind is ' ' for context, '-' for a removed line
+// and '+' for an added line.
+type op struct {
+	kind byte
+	text string
+}
+
+// Unified returns a unified diff of oldText and newText labelled name, with
+// up to context unchanged lines around each change. It returns "" when the
+// texts are equal.
+func Unified(name, oldText, newText string, context int) string {
+	if oldText == newText {
+		return ""
+	}
+	ops := diffLines(splitLines(oldText), splitLines(newText))
+
+	// oldNo[i] and newNo[i] are the 1-based line numbers at which ops[i]
+	// starts in each text.
+	oldNo := make([]int, len(ops)+1)
+	newNo := make([]int, len(ops)+1)
+	oldNo[0], newNo[0] = 1, 1
+	for i, o := range ops {
+		oldNo[i+1], newNo[i+1] = oldNo[i], newNo[i]
+		if o.kind != '+' {
+			oldNo[i+1]++
+		}
+		if o.kind != '-' {
+			newNo[i+1]++
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", name, name)
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+		start := max(0, i-context)
+		// Extend the hunk over later changes separated by at most 2*context
+		// unchanged lines.
+		last := i
+		for j := i + 1; j < len(ops) && j <= last+2*context+1; j++ {
+			if ops[j].kind != ' ' {
+				last = j
+			}
+		}
+		end := min(len(ops), last+context+1)
+
+		oldCount, newCount := oldNo[end]-oldNo[start], newNo[end]-newNo[start]
+		oldStart, newStart := oldNo[start], newNo[start]
+		if oldCount == 0 {
+			oldStart--
+		}
+		if newCount == 0 {
+			newStart--
+		}
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+		for _, o := range ops[start:end] {
+			b.WriteByte(o.kind)
+			b.WriteString(o.text)
+			b.WriteByte('\n')
+		}
+		i = end
+	}
+	return b.String()
+}
+
+// Stats counts the added and removed lines in a diff returned by Unified.
+// ok is false when diff is not in that format.
+func Stats(diff string) (added, removed int, ok bool) {
+	lines := strings.Split(diff, "\n")
+	if len(lines) < 2 || !strings.HasPrefix(lines[0], "--- ") || !strings.HasPrefix(lines[1], "+++ ") {
+		return 0, 0, false
+	}
+	for _, line := range lines[2:] {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			added++
+		case strings.HasPrefix(line, "-"):
+			removed++
+		}
+	}
+	return added, removed, true
+}
+
+// Line is one line of a hunk: Kind is ' ' for context, '-' for a removed
+// line and '+' for an added line.
+type Line struct {
+	Kind byte
+	Text string
+}
+
+// Hunk is one "@@" section of a diff returned by Unified.
+type Hunk struct {
+	Header string // the "@@ -1,3 +1,4 @@" line
+	Lines  []Line
+}
+
+// Hunks splits a diff returned by Unified into its hunks. ok is false when
+// diff is not in that format.
+func Hunks(diff string) (hunks []Hunk, ok bool) {
+	lines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+	if len(lines) < 2 || !strings.HasPrefix(lines[0], "--- ") || !strings.HasPrefix(lines[1], "+++ ") {
+		return nil, false
+	}
+	for _, line := range lines[2:] {
+		if strings.HasPrefix(line, "@@ ") {
+			hunks = append(hunks, Hunk{Header: line})
+			continue
+		}
+		if len(hunks) == 0 || line == "" {
+			return nil, false
+		}
+		switch line[0] {
+		case ' ', '-', '+':
+		default:
+			return nil, false
+		}
+		h := &hunks[len(hunks)-1]
+		h.Lines = append(h.Lines, Line{Kind: line[0], Text: line[1:]})
+	}
+	return hunks, true
+}
+
+// splitLines splits s into lines, ignoring a single trailing newline.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines aligns a and b on a longest common subsequence of lines after
+// trimming their common prefix and suffix.
+func diffLines(a, b []string) []op {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]op, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, op{' ', line})
+	}
+	ops = append(ops, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, op{' ', line})
+	}
+	return ops
+}
+
+// diffMiddle diffs two texts that share no common prefix or suffix.
+func diffMiddle(a, b []string) []op {
+	var ops []op
+	if len(a)*len(b) > maxLCSCells {
+		for _, line := range a {
+			ops = append(ops, op{'-', line})
+		}
+		for _, line := range b {
+			ops = append(ops, op{'+', line})
+		}
+		return ops
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	n, m := len(a), len(b)
+	lcs := make([][]int32, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, op{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, op{'-', a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, op{'+', b[j]})
+	}
+	return ops
+}
@@ -0,0 +1,130 @@
+package textdiff
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		context  int
+		want     string
+	}{
+		{"equal", "a\nb", "a\nb", 1, ""},
+		{
+			"changed line", "a\nb\nc\nd", "a\nB\nc\nd", 1,
+			"--- f\n+++ f\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			"append to empty", "", "a\nb", 1,
+			"--- f\n+++ f\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			"separate hunks", "1\n2\n3\n4\n5\n6\n7", "x\n2\n3\n4\n5\n6\ny", 1,
+			"--- f\n+++ f\n@@ -1,2 +1,2 @@\n-1\n+x\n 2\n@@ -6,2 +6,2 @@\n 6\n-7\n+y\n",
+		},
+		{
+			"merged hunks", "1\n2\n3\n4", "x\n2\n3\ny", 1,
+			"--- f\n+++ f\n@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n-4\n+y\n",
+		},
+		{"trailing newline only", "a\n", "a", 1, "--- f\n+++ f\n"},
+		{
+			"clear to empty", "a\nb", "", 1,
+			"--- f\n+++ f\n@@ -1,2 +0,0 @@\n-a\n-b\n",
+		},
+		{
+			"whitespace only", "a\nb \nc", "a\nb\n\tc", 0,
+			"--- f\n+++ f\n@@ -2,2 +2,2 @@\n-b \n-c\n+b\n+\tc\n",
+		},
+		{
+			"blank line added", "a\nb", "a\n\nb", 0,
+			"--- f\n+++ f\n@@ -1,0 +2,1 @@\n+\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unified("f", tt.old, tt.new, tt.context); got != tt.want {
+				t.Errorf("Unified() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStats(t *testing.T) {
+	diff := Unified("description", "a\nb\nc", "a\nc\nd\ne", 0)
+	added, removed, ok := Stats(diff)
+	if !ok || added != 2 || removed != 1 {
+		t.Errorf("Stats = +%d/-%d (ok=%v), want +2/-1", added, removed, ok)
+	}
+
+	if _, _, ok := Stats("plain old description"); ok {
+		t.Error("Stats accepted text that is not a diff")
+	}
+}
+
+func TestUnifiedLongInputs(t *testing.T) {
+	// Too many changed lines to align line by line are reported as a block
+	// replacement, which still counts every line.
+	var oldLines, newLines []string
+	for i := range 3000 {
+		oldLines = append(oldLines, fmt.Sprintf("old %d", i))
+		newLines = append(newLines, fmt.Sprintf("new %d", i))
+	}
+	head, tail := "same head", "same tail"
+	oldText := head + "\n" + strings.Join(oldLines, "\n") + "\n" + tail
+	newText := head + "\n" + strings.Join(newLines, "\n") + "\n" + tail
+
+	diff := Unified("description", oldText, newText, 1)
+	added, removed, ok := Stats(diff)
+	if !ok || added != 3000 || removed != 3000 {
+		t.Fatalf("Stats = +%d/-%d (ok=%v), want +3000/-3000", added, removed, ok)
+	}
+	hunks, ok := Hunks(diff)
+	if !ok || len(hunks) != 1 {
+		t.Fatalf("Hunks returned %d hunks (ok=%v), want 1", len(hunks), ok)
+	}
+	if first := hunks[0].Lines[0]; first != (Line{' ', head}) {
+		t.Errorf("first line = %+v, want context %q", first, head)
+	}
+
+	// A long text with a single edit keeps the diff small.
+	edited := slices.Clone(oldLines)
+	edited[1500] = "changed"
+	diff = Unified("description", strings.Join(oldLines, "\n"), strings.Join(edited, "\n"), 1)
+	if want := "--- description\n+++ description\n@@ -1500,3 +1500,3 @@\n old 1499\n-old 1500\n+changed\n old 1501\n"; diff != want {
+		t.Errorf("Unified() = %q, want %q", diff, want)
+	}
+}
+
+func TestHunks(t *testing.T) {
+	diff := Unified("f", "1\n2\n3\n4\n5\n6\n7", "x\n2\n3\n4\n5\n6\ny", 1)
+	hunks, ok := Hunks(diff)
+	if !ok {
+		t.Fatalf("Hunks rejected %q", diff)
+	}
+	want := []Hunk{
+		{Header: "@@ -1,2 +1,2 @@", Lines: []Line{{'-', "1"}, {'+', "x"}, {' ', "2"}}},
+		{Header: "@@ -6,2 +6,2 @@", Lines: []Line{{' ', "6"}, {'-', "7"}, {'+', "y"}}},
+	}
+	if len(hunks) != len(want) {
+		t.Fatalf("Hunks = %+v, want %+v", hunks, want)
+	}
+	for i := range want {
+		if hunks[i].Header != want[i].Header || !slices.Equal(hunks[i].Lines, want[i].Lines) {
+			t.Errorf("hunk %d = %+v, want %+v", i, hunks[i], want[i])
+		}
+	}
+
+	if hunks, ok := Hunks(Unified("f", "a\n", "a", 1)); !ok || len(hunks) != 0 {
+		t.Errorf("diff without hunks: Hunks = %+v (ok=%v), want none", hunks, ok)
+	}
+	for _, notDiff := range []string{"", "plain old description", "--- f\n+++ f\nstray line"} {
+		if _, ok := Hunks(notDiff); ok {
+			t.Errorf("Hunks accepted %q", notDiff)
+		}
+	}
+}